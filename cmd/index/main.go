@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"time"
 
+	"gin-sample/internal/authz"
 	"gin-sample/internal/config"
 	"gin-sample/internal/database"
+	"gin-sample/internal/models"
+	"gin-sample/internal/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,11 +18,18 @@ import (
 )
 
 func main() {
+	reconcile := flag.Bool("reconcile-relationships", false, "backfill and repair authorization relationships from team members")
+	dryRun := flag.Bool("dry-run", false, "with -reconcile-relationships, only report the changes")
+	flag.Parse()
+
 	log.Println("Starting migration...")
 
-	cfg := config.Load()
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	mongoDB := database.NewMongoDB(cfg.MongoURI, cfg.MongoDatabase)
+	mongoDB := database.NewMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
 	defer mongoDB.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -26,14 +37,51 @@ func main() {
 
 	createIndexes(ctx, mongoDB.Database)
 
+	if *reconcile {
+		if err := reconcileRelationships(context.Background(), mongoDB.Database, *dryRun); err != nil {
+			log.Fatalf("Failed to reconcile relationships: %v", err)
+		}
+	}
+
 	log.Println("Migration completed successfully!")
 }
 
+// reconcileRelationships makes the authorization relationships match the team members.
+// Run it before switching AUTHZ_MODE to dual or relationship, and to repair drift.
+func reconcileRelationships(ctx context.Context, db *mongo.Database, dryRun bool) error {
+	cursor, err := db.Collection("team_members").Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	var members []models.TeamMember
+	if err := cursor.All(ctx, &members); err != nil {
+		return err
+	}
+
+	store := repository.NewRelationshipRepository(db)
+	result, err := authz.ReconcileTeamMembers(ctx, store, members, dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Reconciled"
+	if dryRun {
+		verb = "Dry run: would reconcile"
+	}
+	log.Printf("%s relationships for %d members: %d written, %d deleted", verb, len(members), result.Written, result.Deleted)
+	return nil
+}
+
 func createIndexes(ctx context.Context, db *mongo.Database) {
 	// Users indexes
 	createIndex(ctx, db, "users", bson.D{{Key: "email", Value: 1}}, &options.IndexOptions{
 		Unique: ptrBool(true),
 	})
+	// An external identity (provider|subject key) can be linked to only one user
+	createIndex(ctx, db, "users", bson.D{{Key: "identities.key", Value: 1}}, &options.IndexOptions{
+		Unique:                  ptrBool(true),
+		PartialFilterExpression: bson.M{"identities.key": bson.M{"$exists": true}},
+	})
 
 	// Teams indexes
 	createIndex(ctx, db, "teams", bson.D{{Key: "slug", Value: 1}}, &options.IndexOptions{
@@ -57,7 +105,17 @@ func createIndexes(ctx context.Context, db *mongo.Database) {
 		{Key: "email", Value: 1},
 	}, nil)
 	createIndex(ctx, db, "team_invitations", bson.D{{Key: "email", Value: 1}}, nil)
-	createIndex(ctx, db, "team_invitations", bson.D{{Key: "expiresAt", Value: 1}}, nil)
+	createIndex(ctx, db, "team_invitations", bson.D{
+		{Key: "teamId", Value: 1},
+		{Key: "createdAt", Value: -1},
+	}, nil)
+	// TTL index purges invitations once they are past the history retention window.
+	// Replaces the plain expiresAt index, which MongoDB will not convert in place.
+	dropIndex(ctx, db, "team_invitations", "expiresAt_1")
+	createIndex(ctx, db, "team_invitations", bson.D{{Key: "expiresAt", Value: 1}}, &options.IndexOptions{
+		Name:               ptrString("expiresAt_ttl"),
+		ExpireAfterSeconds: ptrInt32(repository.InvitationHistoryRetentionDays * 24 * 60 * 60),
+	})
 
 	// Voice memos indexes
 	createIndex(ctx, db, "voice_memos", bson.D{{Key: "userId", Value: 1}}, nil)
@@ -87,6 +145,23 @@ func createIndex(ctx context.Context, db *mongo.Database, collection string, key
 	log.Printf("Created index %s on %s", name, collection)
 }
 
+func dropIndex(ctx context.Context, db *mongo.Database, collection, name string) {
+	if _, err := db.Collection(collection).Indexes().DropOne(ctx, name); err != nil {
+		// Index not existing is the normal case after the first run
+		return
+	}
+
+	log.Printf("Dropped index %s on %s", name, collection)
+}
+
 func ptrBool(b bool) *bool {
 	return &b
 }
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func ptrInt32(i int32) *int32 {
+	return &i
+}
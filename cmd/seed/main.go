@@ -45,19 +45,22 @@ func main() {
 	log.Println("Starting seed...")
 
 	// Load config
-	cfg := config.Load()
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Connect to MongoDB
-	mongoDB := database.NewMongoDB(cfg.MongoURI, cfg.MongoDatabase)
+	mongoDB := database.NewMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
 	defer mongoDB.Close()
 
 	// Connect to S3/MinIO
 	s3Client := storage.NewS3Client(
-		cfg.S3Endpoint,
-		cfg.S3AccessKey,
-		cfg.S3SecretKey,
-		cfg.S3Bucket,
-		cfg.S3UseSSL,
+		cfg.Storage.Endpoint,
+		cfg.Storage.AccessKey,
+		cfg.Storage.SecretKey,
+		cfg.Storage.Bucket,
+		cfg.Storage.UseSSL,
 	)
 
 	ctx := context.Background()
@@ -4,11 +4,14 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -17,11 +20,19 @@ import (
 	"gin-sample/internal/config"
 	"gin-sample/internal/database"
 	"gin-sample/internal/handler"
+	"gin-sample/internal/health"
+	"gin-sample/internal/httpserver"
+	"gin-sample/internal/jobs"
+	"gin-sample/internal/logger"
+	"gin-sample/internal/metrics"
+	"gin-sample/internal/middleware"
 	"gin-sample/internal/queue"
+	"gin-sample/internal/ratelimit"
 	"gin-sample/internal/repository"
 	"gin-sample/internal/router"
 	"gin-sample/internal/service"
 	"gin-sample/internal/storage"
+	"gin-sample/internal/tracing"
 	"gin-sample/internal/transcription"
 	"gin-sample/internal/validator"
 	"gin-sample/pkg/auth"
@@ -45,139 +56,552 @@ import (
 // @description Enter your bearer token in the format: Bearer {token}
 
 func main() {
-	// Load configuration
-	cfg := config.Load()
-	log.Println("Configuration loaded")
+	// "config print" and "config validate" inspect the configuration without starting the server
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(os.Args[2:]))
+	}
+
+	configPath := flag.String("config", "", "path to a YAML or TOML config file (default $"+config.FileEnv+")")
+	flag.Parse()
+
+	// Load configuration; every invalid setting is reported before anything starts
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Structured logging; records logged while serving a request carry its request ID
+	if _, err := logger.Setup(cfg.Log.Format, cfg.Log.Level); err != nil {
+		logger.Fatal("invalid logging configuration", "error", err)
+	}
+	slog.Info("configuration loaded")
+	cfgHolder := config.NewHolder(cfg, *configPath)
 
 	// Register custom validators
 	validator.RegisterCustomValidators()
 
 	// Set Gin mode
-	gin.SetMode(cfg.GinMode)
+	gin.SetMode(cfg.Server.GinMode)
+
+	// OpenTelemetry tracing (spans are discarded when disabled)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		ServiceName: cfg.Tracing.ServiceName,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("failed to configure tracing", "error", err)
+	}
+	var tracingServiceName string
+	if cfg.Tracing.Enabled {
+		tracingServiceName = cfg.Tracing.ServiceName
+		slog.Info("tracing enabled", "endpoint", cfg.Tracing.OTLPEndpoint, "sample_ratio", cfg.Tracing.SampleRatio)
+	}
+
+	// Prometheus metrics (nil when disabled, so instrumentation records nothing)
+	var appMetrics *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		appMetrics = metrics.New()
+	}
 
 	// Database
-	mongoDB := database.NewMongoDB(cfg.MongoURI, cfg.MongoDatabase)
+	mongoDB := database.NewMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
 	defer mongoDB.Close()
 
 	// Redis Cache
-	redisCache := cache.NewRedis(cfg.RedisURI)
+	redisCache := cache.NewRedis(cfg.Redis.URI)
 	defer redisCache.Close()
+	appCache := cache.NewInstrumentedCache(redisCache, appMetrics)
 
 	// S3 Storage
-	s3Client := storage.NewS3Client(cfg.S3Endpoint, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Bucket, cfg.S3UseSSL)
+	s3Storage := storage.NewS3Client(cfg.Storage.Endpoint, cfg.Storage.AccessKey, cfg.Storage.SecretKey, cfg.Storage.Bucket, cfg.Storage.UseSSL)
+	s3Client := storage.NewInstrumentedStorage(s3Storage, appMetrics)
 
 	// JWT Manager
-	jwtManager := auth.NewJWTManager(cfg.AccessTokenSecret, cfg.AccessTokenExpiry)
+	jwtManager, err := newJWTManager(cfg)
+	if err != nil {
+		logger.Fatal("failed to configure access token signing", "error", err)
+	}
+
+	// Access token revocation (logout, password change, account deletion)
+	tokenRevocation := cache.NewTokenRevocationStore(redisCache.Client())
+
+	// OpenID Connect login providers
+	oidcProviders := newOIDCProviders(cfg)
+
+	// TOTP provider (for MFA)
+	totpProvider := auth.NewTOTPProvider(cfg.Auth.MFAIssuer)
 
 	// Refresh token generator and store (for rotation)
 	var tokenGenerator auth.RefreshTokenGenerator
 	var tokenStore cache.RefreshTokenStore
-	if cfg.RefreshTokenRotation {
+	if cfg.Auth.RefreshTokenRotation {
 		tokenGenerator = auth.NewRefreshTokenGenerator()
 		tokenStore = cache.NewRefreshTokenStore(redisCache)
-		log.Println("Refresh token rotation enabled")
+		slog.Info("refresh token rotation enabled")
+	}
+
+	// Rate limiting and login lockout
+	var rateLimiter ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		rateLimiter = ratelimit.NewRedisLimiter(redisCache.Client())
+	}
+	var loginLockout ratelimit.Lockout
+	if cfg.Auth.Lockout.Threshold > 0 {
+		loginLockout = ratelimit.NewRedisLockout(redisCache.Client(), ratelimit.LockoutPolicy{
+			Threshold:     cfg.Auth.Lockout.Threshold,
+			BaseDuration:  cfg.Auth.Lockout.Duration,
+			MaxDuration:   cfg.Auth.Lockout.MaxDuration,
+			FailureWindow: cfg.Auth.Lockout.FailureWindow,
+		})
 	}
 
-	// Repository layer
-	userRepo := repository.NewUserRepository(mongoDB.Database)
-	refreshTokenRepo := repository.NewRefreshTokenRepository(mongoDB.Database)
-	voiceMemoRepo := repository.NewVoiceMemoRepository(mongoDB.Database)
-	teamRepo := repository.NewTeamRepository(mongoDB.Database)
-	teamMemberRepo := repository.NewTeamMemberRepository(mongoDB.Database)
-	teamInvitationRepo := repository.NewTeamInvitationRepository(mongoDB.Database)
+	// Repository layer (instrumented to record MongoDB call latencies)
+	userRepo := repository.NewInstrumentedUserRepository(repository.NewUserRepository(mongoDB.Database), appMetrics)
+	refreshTokenRepo := repository.NewInstrumentedRefreshTokenRepository(repository.NewRefreshTokenRepository(mongoDB.Database), appMetrics)
+	voiceMemoRepo := repository.NewInstrumentedVoiceMemoRepository(repository.NewVoiceMemoRepository(mongoDB.Database), appMetrics)
+	teamRepo := repository.NewInstrumentedTeamRepository(repository.NewTeamRepository(mongoDB.Database), appMetrics)
+	teamMemberRepo := repository.NewInstrumentedTeamMemberRepository(repository.NewTeamMemberRepository(mongoDB.Database), appMetrics)
+	teamInvitationRepo := repository.NewInstrumentedTeamInvitationRepository(repository.NewTeamInvitationRepository(mongoDB.Database), appMetrics)
+	apiKeyRepo := repository.NewInstrumentedAPIKeyRepository(repository.NewAPIKeyRepository(mongoDB.Database), appMetrics)
+	teamRoleRepo := repository.NewInstrumentedTeamRoleRepository(repository.NewTeamRoleRepository(mongoDB.Database), appMetrics)
+	relationshipRepo := repository.NewInstrumentedRelationshipRepository(repository.NewRelationshipRepository(mongoDB.Database), appMetrics)
+	auditLogRepo := repository.NewInstrumentedAuditLogRepository(repository.NewAuditLogRepository(mongoDB.Database), appMetrics)
 
 	// Authorization
-	authorizer := authz.NewLocalAuthorizer(teamMemberRepo)
+	memberFinder := authz.NewCachedMemberFinder(teamMemberRepo, appCache, cfg.Cache.TeamMemberTTL)
+	relationshipAuthorizer := authz.NewRelationshipAuthorizer(relationshipRepo, teamRoleRepo)
+	authorizer, err := newAuthorizer(cfg.Authz.Mode, authz.NewLocalAuthorizer(memberFinder, teamRoleRepo), relationshipAuthorizer)
+	if err != nil {
+		logger.Fatal("failed to configure authorization", "error", err)
+	}
+	slog.Info("authorization configured", "mode", cfg.Authz.Mode)
 
 	// Transcription queue and processor
-	transcriptionQueue := queue.NewMemoryQueue(cfg.TranscriptionQueueSize)
+	transcriptionQueue := queue.NewMemoryQueue(cfg.Queue.Size)
+	appMetrics.RegisterQueue(transcriptionQueue)
 	transcriptionService := transcription.NewMockService()
 
 	// Service layer
 	authService := service.NewAuthService(service.AuthServiceConfig{
 		UserRepo:         userRepo,
 		RefreshTokenRepo: refreshTokenRepo,
-		Cache:            redisCache,
+		APIKeyRepo:       apiKeyRepo,
+		Cache:            appCache,
 		TokenStore:       tokenStore,
 		JWTManager:       jwtManager,
 		TokenGenerator:   tokenGenerator,
-		AccessTokenTTL:   cfg.AccessTokenExpiry,
-		RefreshTokenTTL:  cfg.RefreshTokenExpiry,
-		RotationEnabled:  cfg.RefreshTokenRotation,
+		AccessTokenTTL:   cfg.Auth.AccessTokenExpiry,
+		RefreshTokenTTL:  cfg.Auth.RefreshTokenExpiry,
+		RotationEnabled:  cfg.Auth.RefreshTokenRotation,
+		TOTPProvider:     totpProvider,
+		MFAChallengeTTL:  cfg.Auth.MFAChallengeExpiry,
+		Lockout:          loginLockout,
+		TokenRevocation:  tokenRevocation,
+		OIDCProviders:    oidcProviders,
+		OIDCStateTTL:     cfg.Auth.OIDCStateExpiry,
 	})
-	userService := service.NewUserService(userRepo, redisCache, cfg.UserCacheTTL)
-	voiceMemoService := service.NewVoiceMemoService(voiceMemoRepo, s3Client, transcriptionQueue, cfg.PresignedURLExpiry, cfg.PresignedUploadExpiry)
-	teamService := service.NewTeamService(teamRepo, teamMemberRepo, teamInvitationRepo, voiceMemoRepo)
-	teamMemberService := service.NewTeamMemberService(teamMemberRepo, userRepo, teamRepo)
-	teamInvitationService := service.NewTeamInvitationService(teamInvitationRepo, teamMemberRepo, teamRepo, userRepo)
+	mfaService := service.NewMFAService(userRepo, appCache, totpProvider)
+	userService := service.NewUserService(userRepo, appCache, cfg.Cache.UserTTL, authService)
+	auditLogService := service.NewAuditLogService(auditLogRepo)
+	memoSettings := voiceMemoSettings(cfg)
+	voiceMemoService := service.NewVoiceMemoService(voiceMemoRepo, s3Client, queue.NewInstrumentedQueue(transcriptionQueue, appMetrics), memoSettings.PresignedURLExpiry, memoSettings.PresignedUploadExpiry, rateLimiter, memoSettings.Limits, auditLogService)
+	teamService := service.NewTeamService(teamRepo, teamMemberRepo, teamInvitationRepo, teamRoleRepo, voiceMemoRepo, memberFinder, authorizer, relationshipAuthorizer, auditLogService)
+	teamMemberService := service.NewTeamMemberService(teamMemberRepo, userRepo, teamRepo, teamRoleRepo, memberFinder, relationshipAuthorizer, auditLogService)
+	teamInvitationService := service.NewTeamInvitationService(teamInvitationRepo, teamMemberRepo, teamRepo, userRepo, teamRoleRepo, relationshipAuthorizer, auditLogService)
+	teamRoleService := service.NewTeamRoleService(teamRoleRepo, teamMemberRepo, teamInvitationRepo, authorizer, auditLogService)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo, teamMemberRepo, auth.NewAPIKeyGenerator())
 
 	// Transcription processor (uses voiceMemoRepo for updates)
-	transcriptionProcessor := queue.NewProcessor(transcriptionQueue, transcriptionService, voiceMemoRepo, cfg.TranscriptionWorkerCount)
+	transcriptionProcessor := queue.NewProcessor(transcriptionQueue, transcriptionService, voiceMemoRepo, cfg.Queue.WorkerCount, appMetrics)
+
+	// Scheduled invitation cleanup (marks expired, purges past retention)
+	invitationRetention := time.Duration(repository.InvitationHistoryRetentionDays) * 24 * time.Hour
+	invitationCleanup := jobs.NewInvitationCleanup(teamInvitationRepo, cfg.Jobs.InvitationCleanupInterval, invitationRetention)
+
+	// Health checks: liveness covers the transcription workers, readiness also the dependencies
+	healthChecker := health.NewChecker(cfg.Health.CheckTimeout)
+	healthChecker.AddLivenessCheck("transcription_workers", transcriptionProcessor.CheckWorkers)
+	healthChecker.AddReadinessCheck("mongodb", mongoDB.Ping)
+	healthChecker.AddReadinessCheck("redis", redisCache.Ping)
+	healthChecker.AddReadinessCheck("s3", s3Storage.Ping)
+	healthChecker.AddReadinessCheck("transcription_queue", health.QueueSaturation(transcriptionQueue, cfg.Health.QueueSaturation))
 
 	// Handler layer
 	authHandler := handler.NewAuthHandler(authService)
+	mfaHandler := handler.NewMFAHandler(mfaService)
 	userHandler := handler.NewUserHandler(userService)
-	voiceMemoHandler := handler.NewVoiceMemoHandler(voiceMemoService)
+	voiceMemoHandler := handler.NewVoiceMemoHandler(voiceMemoService, authorizer)
 	teamHandler := handler.NewTeamHandler(teamService)
-	teamMemberHandler := handler.NewTeamMemberHandler(teamMemberService)
+	teamMemberHandler := handler.NewTeamMemberHandler(teamMemberService, authorizer)
+	teamRoleHandler := handler.NewTeamRoleHandler(teamRoleService)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogService)
 	invitationHandler := handler.NewTeamInvitationHandler(teamInvitationService, userService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	healthHandler := handler.NewHealthHandler(healthChecker)
+
+	// CORS policy, swapped on reload
+	var corsPolicy atomic.Pointer[middleware.CORSConfig]
+	corsPolicy.Store(corsConfig(cfg))
 
 	// Router
 	r := router.Setup(&router.Config{
-		AuthHandler:       authHandler,
-		UserHandler:       userHandler,
-		VoiceMemoHandler:  voiceMemoHandler,
-		TeamHandler:       teamHandler,
-		TeamMemberHandler: teamMemberHandler,
-		InvitationHandler: invitationHandler,
-		JWTManager:        jwtManager,
-		Authorizer:        authorizer,
+		AuthHandler:             authHandler,
+		MFAHandler:              mfaHandler,
+		UserHandler:             userHandler,
+		VoiceMemoHandler:        voiceMemoHandler,
+		TeamHandler:             teamHandler,
+		TeamMemberHandler:       teamMemberHandler,
+		TeamRoleHandler:         teamRoleHandler,
+		AuditLogHandler:         auditLogHandler,
+		InvitationHandler:       invitationHandler,
+		APIKeyHandler:           apiKeyHandler,
+		HealthHandler:           healthHandler,
+		JWTManager:              jwtManager,
+		Authorizer:              authorizer,
+		TokenRevocation:         tokenRevocation,
+		TokenRevocationFailOpen: cfg.Auth.RevocationFailOpen,
+		APIKeys:                 apiKeyService,
+		RateLimiter:             rateLimiter,
+		AuthRateLimits: func() router.AuthRateLimits {
+			return authRateLimits(cfgHolder.Get())
+		},
+		Hardening: middleware.HardeningConfig{
+			MaxBodyBytes:          cfg.Server.MaxBodyBytes,
+			HSTSMaxAge:            cfg.Server.HSTSMaxAge,
+			HSTSIncludeSubdomains: cfg.Server.HSTSIncludeSubdomains,
+		},
+		Swagger:            cfg.Server.Swagger,
+		CORS:               corsPolicy.Load,
+		TrustedProxies:     cfg.Server.TrustedProxies,
+		ProblemJSONErrors:  cfg.Server.ProblemJSONErrors,
+		Metrics:            appMetrics,
+		TracingServiceName: tracingServiceName,
 	})
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Reload on SIGHUP or when the config file changes; only settings tagged reload apply
+	cfgHolder.OnReload(func(c *config.Config) {
+		if err := logger.SetLevel(c.Log.Level); err != nil {
+			slog.Error("failed to change log level", "error", err)
+		}
+		transcriptionProcessor.SetWorkerCount(c.Queue.WorkerCount)
+		voiceMemoService.UpdateSettings(voiceMemoSettings(c))
+		corsPolicy.Store(corsConfig(c))
+	})
+	reloadConfig := func(trigger string) {
+		result, err := cfgHolder.Reload()
+		if err != nil {
+			slog.Error("configuration reload rejected", "trigger", trigger, "error", err)
+			return
+		}
+		if len(result.RequiresRestart) > 0 {
+			slog.Warn("changed settings require a restart", "settings", result.RequiresRestart)
+		}
+		slog.Info("configuration reloaded", "trigger", trigger, "applied", result.Applied)
+	}
+	if path := config.FilePath(*configPath); path != "" && cfg.Reload.WatchInterval > 0 {
+		go config.WatchFile(ctx, path, cfg.Reload.WatchInterval, func() { reloadConfig("file") })
+	}
+
 	// Start transcription processor
 	transcriptionProcessor.Start(ctx)
 
-	// Create HTTP server for graceful shutdown support
-	addr := fmt.Sprintf(":%s", cfg.ServerPort)
-	srv := &http.Server{
-		Addr:    addr,
-		Handler: r,
+	// Start scheduled jobs
+	invitationCleanup.Start(ctx)
+
+	// TLS certificate, reloaded on SIGHUP or when its files change
+	var certificates *httpserver.Certificates
+	reloadCertificates := func(trigger string) {
+		if certificates == nil {
+			return
+		}
+		if err := certificates.Reload(); err != nil {
+			slog.Error("TLS certificate reload failed", "trigger", trigger, "error", err)
+			return
+		}
+		slog.Info("TLS certificate reloaded", "trigger", trigger)
+	}
+	if cfg.Server.TLS.CertFile != "" {
+		certificates, err = httpserver.NewCertificates(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		if err != nil {
+			logger.Fatal("failed to load TLS certificate", "error", err)
+		}
+		if cfg.Reload.WatchInterval > 0 {
+			for _, path := range certificates.Files() {
+				go config.WatchFile(ctx, path, cfg.Reload.WatchInterval, func() { reloadCertificates("file") })
+			}
+		}
+	}
+	minTLSVersion, err := httpserver.ParseTLSVersion(cfg.Server.TLS.MinVersion)
+	if err != nil {
+		logger.Fatal("invalid TLS version", "error", err)
 	}
 
+	// Create HTTP server for graceful shutdown support
+	addr := fmt.Sprintf(":%s", cfg.Server.Port)
+	srv := httpserver.New(httpserver.Config{
+		Addr:              addr,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		HTTP2:             cfg.Server.HTTP2,
+		H2C:               cfg.Server.H2C,
+		Certificates:      certificates,
+		MinTLSVersion:     minTLSVersion,
+	}, r)
+
 	// Start server in goroutine
 	go func() {
-		log.Printf("Server starting on %s", addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+		slog.Info("server starting", "addr", addr, "tls", certificates != nil)
+		if err := httpserver.ListenAndServe(srv); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("failed to start server", "error", err)
 		}
 	}()
 
 	// Wait for shutdown signal
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	<-sigCh
-	log.Println("Shutdown signal received")
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reloadConfig("sighup")
+		reloadCertificates("sighup")
+	}
+	slog.Info("shutdown signal received")
+
+	// Fail readiness first so load balancers stop routing new requests here
+	healthChecker.Shutdown()
+	slog.Info("draining before shutdown", "delay", cfg.Server.ShutdownDrainDelay)
+	time.Sleep(cfg.Server.ShutdownDrainDelay)
 
 	// Graceful shutdown with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
 	// Shutdown HTTP server first (drain connections)
-	log.Println("Shutting down HTTP server...")
+	slog.Info("shutting down HTTP server")
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Printf("HTTP server shutdown error: %v", err)
+		slog.Error("HTTP server shutdown failed", "error", err)
 	}
 
 	// Cancel context to signal processor shutdown
 	cancel()
 
 	// Stop transcription processor (waits for workers)
-	log.Println("Stopping transcription processor...")
+	slog.Info("stopping transcription processor")
 	transcriptionProcessor.Stop()
 
-	log.Println("Server shutdown complete")
+	// Stop scheduled jobs
+	slog.Info("stopping scheduled jobs")
+	invitationCleanup.Stop()
+
+	// Flush spans still buffered for export
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("tracing shutdown failed", "error", err)
+	}
+
+	slog.Info("server shutdown complete")
+}
+
+// voiceMemoSettings returns the voice memo expiries, rate limits and quotas of cfg.
+func voiceMemoSettings(cfg *config.Config) service.VoiceMemoSettings {
+	return service.VoiceMemoSettings{
+		PresignedURLExpiry:    cfg.Storage.PresignedURLExpiry,
+		PresignedUploadExpiry: cfg.Storage.PresignedUploadExpiry,
+		Limits: service.VoiceMemoLimits{
+			CreatePerUser: ratelimit.Limit{Requests: cfg.RateLimit.MemoCreatePerUser, Window: cfg.RateLimit.Window},
+			CreatePerTeam: ratelimit.Limit{Requests: cfg.RateLimit.MemoCreatePerTeam, Window: cfg.RateLimit.Window},
+			User: service.UsageQuota{
+				MaxMemos:                cfg.Quota.User.MaxMemos,
+				MaxAudioBytes:           cfg.Quota.User.MaxAudioBytes,
+				MaxTranscriptionMinutes: cfg.Quota.User.TranscriptionMinutes,
+			},
+			Team: service.UsageQuota{
+				MaxMemos:                cfg.Quota.Team.MaxMemos,
+				MaxAudioBytes:           cfg.Quota.Team.MaxAudioBytes,
+				MaxTranscriptionMinutes: cfg.Quota.Team.TranscriptionMinutes,
+			},
+		},
+	}
+}
+
+// authRateLimits returns the auth endpoint rate limits of cfg.
+func authRateLimits(cfg *config.Config) router.AuthRateLimits {
+	return router.AuthRateLimits{
+		LoginPerIP:      ratelimit.Limit{Requests: cfg.RateLimit.LoginPerIP, Window: cfg.RateLimit.Window},
+		LoginPerAccount: ratelimit.Limit{Requests: cfg.RateLimit.LoginPerAccount, Window: cfg.RateLimit.Window},
+		RegisterPerIP:   ratelimit.Limit{Requests: cfg.RateLimit.RegisterPerIP, Window: cfg.RateLimit.Window},
+		RefreshPerIP:    ratelimit.Limit{Requests: cfg.RateLimit.RefreshPerIP, Window: cfg.RateLimit.Window},
+	}
+}
+
+// corsConfig returns the CORS policy of cfg. Route overrides inherit the methods and headers
+// they do not set.
+func corsConfig(cfg *config.Config) *middleware.CORSConfig {
+	policy := middleware.CORSPolicy{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		MaxAge:           cfg.CORS.MaxAge,
+	}
+	routes := make(map[string]middleware.CORSPolicy, len(cfg.CORS.Routes))
+	for _, route := range cfg.CORS.Routes {
+		override := policy
+		override.AllowedOrigins = route.AllowedOrigins
+		override.AllowCredentials = route.AllowCredentials
+		if route.AllowedMethods != nil {
+			override.AllowedMethods = route.AllowedMethods
+		}
+		if route.AllowedHeaders != nil {
+			override.AllowedHeaders = route.AllowedHeaders
+		}
+		if route.ExposedHeaders != nil {
+			override.ExposedHeaders = route.ExposedHeaders
+		}
+		routes[route.PathPrefix] = override
+	}
+	return &middleware.CORSConfig{Policy: policy, Routes: routes}
+}
+
+// roleCachingAuthorizer is an authorizer that caches custom role definitions.
+type roleCachingAuthorizer interface {
+	authz.Authorizer
+	service.RoleCacheInvalidator
+}
+
+// newAuthorizer selects the authorizer for AUTHZ_MODE. Relationships are written on every
+// membership change regardless of mode; dual mode keeps deciding from team membership records
+// and logs every decision the relationship authorizer would make differently.
+func newAuthorizer(mode string, local *authz.LocalAuthorizer, relationship *authz.RelationshipAuthorizer) (roleCachingAuthorizer, error) {
+	switch mode {
+	case config.AuthzModeLocal:
+		return local, nil
+	case config.AuthzModeDual:
+		return authz.NewDualAuthorizer(local, relationship), nil
+	case config.AuthzModeRelationship:
+		return relationship, nil
+	default:
+		return nil, fmt.Errorf("unknown AUTHZ_MODE %q", mode)
+	}
+}
+
+// newJWTManager creates the access token manager. Tokens are signed with the asymmetric key
+// from JWT_SIGNING_KEY_FILE when set, otherwise with the HS256 ACCESS_TOKEN_SECRET.
+func newJWTManager(cfg *config.Config) (*auth.JWTManager, error) {
+	if cfg.Auth.SigningKeyFile == "" {
+		return auth.NewJWTManager(cfg.Auth.AccessTokenSecret, cfg.Auth.AccessTokenExpiry), nil
+	}
+
+	signingKey, err := auth.LoadSigningKeyFile(cfg.Auth.SigningKeyFile, cfg.Auth.SigningKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeys := make([]*auth.VerificationKey, 0, len(cfg.Auth.VerificationKeyFiles))
+	for _, entry := range cfg.Auth.VerificationKeyFiles {
+		// Entries are "path" or "kid=path"
+		kid, path, found := strings.Cut(entry, "=")
+		if !found {
+			kid, path = "", entry
+		}
+		key, err := auth.LoadVerificationKeyFile(path, kid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	slog.Info("signing access tokens with asymmetric key", "alg", signingKey.Method.Alg(), "kid", signingKey.ID)
+	return auth.NewAsymmetricJWTManager(signingKey, verificationKeys, cfg.Auth.AccessTokenSecret, cfg.Auth.AccessTokenExpiry)
+}
+
+// newOIDCProviders discovers the configured OpenID Connect providers and sets up GitHub ones.
+// A provider that cannot be reached is skipped so the rest of the API still starts.
+func newOIDCProviders(cfg *config.Config) []auth.OIDCProvider {
+	providers := make([]auth.OIDCProvider, 0, len(cfg.Auth.OIDCProviders))
+	for _, providerCfg := range cfg.Auth.OIDCProviders {
+		if providerCfg.Type == config.OIDCProviderTypeGitHub {
+			providers = append(providers, auth.NewGitHubProvider(auth.GitHubConfig{
+				Name:         providerCfg.Name,
+				DisplayName:  providerCfg.DisplayName,
+				BaseURL:      providerCfg.IssuerURL,
+				ClientID:     providerCfg.ClientID,
+				ClientSecret: providerCfg.ClientSecret,
+				RedirectURL:  providerCfg.RedirectURL,
+				Scopes:       providerCfg.Scopes,
+				TrustEmail:   providerCfg.TrustEmail,
+			}))
+			slog.Info("GitHub login enabled", "provider", providerCfg.Name)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		provider, err := auth.NewOIDCProvider(ctx, auth.OIDCConfig{
+			Name:         providerCfg.Name,
+			DisplayName:  providerCfg.DisplayName,
+			IssuerURL:    providerCfg.IssuerURL,
+			ClientID:     providerCfg.ClientID,
+			ClientSecret: providerCfg.ClientSecret,
+			RedirectURL:  providerCfg.RedirectURL,
+			Scopes:       providerCfg.Scopes,
+			TrustEmail:   providerCfg.TrustEmail,
+		})
+		cancel()
+		if err != nil {
+			slog.Warn("OIDC login disabled", "provider", providerCfg.Name, "error", err)
+			continue
+		}
+		providers = append(providers, provider)
+		slog.Info("OIDC login enabled", "provider", providerCfg.Name)
+	}
+	return providers
+}
+
+// runConfigCommand implements "config print" (the effective configuration as YAML, secrets
+// redacted) and "config validate", returning the exit code.
+func runConfigCommand(args []string) int {
+	usage := "usage: server config print|validate [-config file]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	action := args[0]
+
+	flags := flag.NewFlagSet("config "+action, flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to a YAML or TOML config file (default $"+config.FileEnv+")")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch action {
+	case "print":
+		if err := cfg.Redacted().WriteYAML(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	case "validate":
+		fmt.Println("configuration is valid")
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	return 0
 }
@@ -1,5 +1,6 @@
 // Package authz provides authorization interfaces and implementations.
-// This module is designed for future migration to SpiceDB or API Gateway.
+// LocalAuthorizer decides from team membership records; RelationshipAuthorizer decides from
+// SpiceDB/Zanzibar-style relationships (see Schema), and DualAuthorizer runs both to verify a migration.
 package authz
 
 import (
@@ -20,7 +21,11 @@ const (
 	ActionMemoView         = "memo:view"
 	ActionMemoCreate       = "memo:create"
 	ActionMemoUpdate       = "memo:update"
+	ActionMemoUpdateOwn    = "memo:update_own"
 	ActionMemoDelete       = "memo:delete"
+	ActionMemoDeleteOwn    = "memo:delete_own"
+	ActionRoleManage       = "role:manage"
+	ActionAuditView        = "audit:view"
 )
 
 // Authorizer defines the interface for authorization checks.
@@ -29,6 +34,11 @@ type Authorizer interface {
 	// CanPerform checks if a user can perform an action on a team.
 	CanPerform(ctx context.Context, userID, teamID primitive.ObjectID, action string) (bool, error)
 
+	// CanPerformOnResource checks if a user can perform an action on a team resource
+	// created by resourceOwnerID. Roles granted only the own-resources variant of the
+	// action (e.g. memo:delete_own) are allowed when the user created the resource.
+	CanPerformOnResource(ctx context.Context, userID, teamID, resourceOwnerID primitive.ObjectID, action string) (bool, error)
+
 	// GetUserRole returns the user's role in a team, or empty string if not a member.
 	GetUserRole(ctx context.Context, userID, teamID primitive.ObjectID) (string, error)
 
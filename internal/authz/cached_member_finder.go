@@ -0,0 +1,55 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	"gin-sample/internal/cache"
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CachedMemberFinder wraps a TeamMemberFinder with a shared cache of team memberships.
+// Only existing memberships are cached, so a user who joins a team is visible immediately.
+// Callers must invalidate an entry whenever the membership changes or is removed.
+type CachedMemberFinder struct {
+	next  TeamMemberFinder
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedMemberFinder creates a new CachedMemberFinder.
+func NewCachedMemberFinder(next TeamMemberFinder, cache cache.Cache, ttl time.Duration) *CachedMemberFinder {
+	return &CachedMemberFinder{
+		next:  next,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+// FindByTeamAndUser returns the user's membership in a team, checking the cache first.
+func (f *CachedMemberFinder) FindByTeamAndUser(ctx context.Context, teamID, userID primitive.ObjectID) (*models.TeamMember, error) {
+	key := cache.TeamMemberCacheKey(teamID.Hex(), userID.Hex())
+
+	var member models.TeamMember
+	found, err := f.cache.Get(ctx, key, &member)
+	if err == nil && found {
+		return &member, nil // Cache hit
+	}
+
+	dbMember, err := f.next.FindByTeamAndUser(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in cache (ignore errors - cache is best effort)
+	_ = f.cache.Set(ctx, key, dbMember, f.ttl)
+
+	return dbMember, nil
+}
+
+// InvalidateMember drops a user's cached membership in a team.
+func (f *CachedMemberFinder) InvalidateMember(ctx context.Context, teamID, userID primitive.ObjectID) error {
+	return f.cache.Delete(ctx, cache.TeamMemberCacheKey(teamID.Hex(), userID.Hex()))
+}
@@ -0,0 +1,138 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gin-sample/internal/cache"
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// countingMemberFinder is a TeamMemberFinder that counts lookups.
+type countingMemberFinder struct {
+	member *models.TeamMember
+	err    error
+	calls  int
+}
+
+func (f *countingMemberFinder) FindByTeamAndUser(_ context.Context, _, _ primitive.ObjectID) (*models.TeamMember, error) {
+	f.calls++
+	return f.member, f.err
+}
+
+func setupCachedMemberFinder(t *testing.T, next TeamMemberFinder) (*CachedMemberFinder, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	redisCache := cache.NewRedis(mr.Addr())
+	t.Cleanup(redisCache.Close)
+	return NewCachedMemberFinder(next, redisCache, time.Minute), mr
+}
+
+func TestCachedMemberFinder_FindByTeamAndUser(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	t.Run("caches existing membership", func(t *testing.T) {
+		next := &countingMemberFinder{
+			member: &models.TeamMember{TeamID: teamID, UserID: userID, Role: models.RoleAdmin},
+		}
+		finder, _ := setupCachedMemberFinder(t, next)
+
+		first, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+		require.NoError(t, err)
+		second, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.RoleAdmin, first.Role)
+		assert.Equal(t, models.RoleAdmin, second.Role)
+		assert.Equal(t, userID, second.UserID)
+		assert.Equal(t, 1, next.calls)
+	})
+
+	t.Run("entry expires after ttl", func(t *testing.T) {
+		next := &countingMemberFinder{
+			member: &models.TeamMember{TeamID: teamID, UserID: userID, Role: models.RoleMember},
+		}
+		finder, mr := setupCachedMemberFinder(t, next)
+
+		_, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+		require.NoError(t, err)
+		mr.FastForward(2 * time.Minute)
+		_, err = finder.FindByTeamAndUser(ctx, teamID, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("does not cache non-members", func(t *testing.T) {
+		next := &countingMemberFinder{err: apperrors.ErrNotTeamMember}
+		finder, _ := setupCachedMemberFinder(t, next)
+
+		_, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+		assert.Equal(t, apperrors.ErrNotTeamMember, err)
+		_, err = finder.FindByTeamAndUser(ctx, teamID, userID)
+		assert.Equal(t, apperrors.ErrNotTeamMember, err)
+
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("propagates database error", func(t *testing.T) {
+		dbError := errors.New("database connection failed")
+		finder, _ := setupCachedMemberFinder(t, &countingMemberFinder{err: dbError})
+
+		member, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+
+		assert.Equal(t, dbError, err)
+		assert.Nil(t, member)
+	})
+
+	t.Run("falls back to finder when cache is unavailable", func(t *testing.T) {
+		next := &countingMemberFinder{
+			member: &models.TeamMember{TeamID: teamID, UserID: userID, Role: models.RoleMember},
+		}
+		finder, mr := setupCachedMemberFinder(t, next)
+		mr.Close()
+
+		// Bound the time spent retrying the unreachable cache
+		shortCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		member, err := finder.FindByTeamAndUser(shortCtx, teamID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleMember, member.Role)
+	})
+}
+
+func TestCachedMemberFinder_InvalidateMember(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	next := &countingMemberFinder{
+		member: &models.TeamMember{TeamID: teamID, UserID: userID, Role: models.RoleMember},
+	}
+	finder, _ := setupCachedMemberFinder(t, next)
+
+	_, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+	require.NoError(t, err)
+
+	// Role changes in the database, then the entry is invalidated
+	next.member = &models.TeamMember{TeamID: teamID, UserID: userID, Role: models.RoleAdmin}
+	require.NoError(t, finder.InvalidateMember(ctx, teamID, userID))
+
+	member, err := finder.FindByTeamAndUser(ctx, teamID, userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RoleAdmin, member.Role)
+	assert.Equal(t, 2, next.calls)
+}
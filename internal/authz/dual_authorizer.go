@@ -0,0 +1,105 @@
+package authz
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// roleInvalidator is implemented by authorizers that cache custom role definitions.
+type roleInvalidator interface {
+	InvalidateRole(teamID primitive.ObjectID, name string)
+}
+
+// DualAuthorizer runs two authorizers side by side during a migration.
+// Decisions come from the primary; the shadow is evaluated on every call and any
+// disagreement or shadow error is logged, so the shadow can be verified before it becomes primary.
+type DualAuthorizer struct {
+	primary Authorizer
+	shadow  Authorizer
+	logger  *slog.Logger
+}
+
+// NewDualAuthorizer creates a new DualAuthorizer.
+func NewDualAuthorizer(primary, shadow Authorizer) *DualAuthorizer {
+	return &DualAuthorizer{
+		primary: primary,
+		shadow:  shadow,
+		logger:  slog.Default(),
+	}
+}
+
+// CanPerform checks if a user can perform an action on a team.
+func (a *DualAuthorizer) CanPerform(ctx context.Context, userID, teamID primitive.ObjectID, action string) (bool, error) {
+	allowed, err := a.primary.CanPerform(ctx, userID, teamID, action)
+	if err != nil {
+		return false, err
+	}
+
+	shadowAllowed, shadowErr := a.shadow.CanPerform(ctx, userID, teamID, action)
+	a.compare(ctx, "CanPerform", userID, teamID, action, allowed, shadowAllowed, shadowErr)
+
+	return allowed, nil
+}
+
+// CanPerformOnResource checks if a user can perform an action on a team resource created by resourceOwnerID.
+func (a *DualAuthorizer) CanPerformOnResource(ctx context.Context, userID, teamID, resourceOwnerID primitive.ObjectID, action string) (bool, error) {
+	allowed, err := a.primary.CanPerformOnResource(ctx, userID, teamID, resourceOwnerID, action)
+	if err != nil {
+		return false, err
+	}
+
+	shadowAllowed, shadowErr := a.shadow.CanPerformOnResource(ctx, userID, teamID, resourceOwnerID, action)
+	a.compare(ctx, "CanPerformOnResource", userID, teamID, action, allowed, shadowAllowed, shadowErr)
+
+	return allowed, nil
+}
+
+// GetUserRole returns the user's role in a team, or empty string if not a member.
+func (a *DualAuthorizer) GetUserRole(ctx context.Context, userID, teamID primitive.ObjectID) (string, error) {
+	role, err := a.primary.GetUserRole(ctx, userID, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	shadowRole, shadowErr := a.shadow.GetUserRole(ctx, userID, teamID)
+	a.compare(ctx, "GetUserRole", userID, teamID, "", role, shadowRole, shadowErr)
+
+	return role, nil
+}
+
+// IsMember checks if a user is a member of a team.
+func (a *DualAuthorizer) IsMember(ctx context.Context, userID, teamID primitive.ObjectID) (bool, error) {
+	member, err := a.primary.IsMember(ctx, userID, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	shadowMember, shadowErr := a.shadow.IsMember(ctx, userID, teamID)
+	a.compare(ctx, "IsMember", userID, teamID, "", member, shadowMember, shadowErr)
+
+	return member, nil
+}
+
+// InvalidateRole drops a custom role from both authorizers' caches.
+func (a *DualAuthorizer) InvalidateRole(teamID primitive.ObjectID, name string) {
+	for _, authorizer := range []Authorizer{a.primary, a.shadow} {
+		if cache, ok := authorizer.(roleInvalidator); ok {
+			cache.InvalidateRole(teamID, name)
+		}
+	}
+}
+
+// compare logs a shadow error or a decision that differs from the primary's.
+func (a *DualAuthorizer) compare(ctx context.Context, check string, userID, teamID primitive.ObjectID, action string, primary, shadow any, shadowErr error) {
+	if shadowErr != nil {
+		a.logger.WarnContext(ctx, "authz shadow check failed", "check", check,
+			"user_id", userID.Hex(), "team_id", teamID.Hex(), "action", action, "error", shadowErr)
+		return
+	}
+	if primary != shadow {
+		a.logger.WarnContext(ctx, "authz mismatch", "check", check,
+			"user_id", userID.Hex(), "team_id", teamID.Hex(), "action", action, "primary", primary, "shadow", shadow)
+	}
+}
@@ -0,0 +1,170 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// logLines collects each record written by a slog text handler as one line.
+type logLines []string
+
+func (l *logLines) Write(p []byte) (int, error) {
+	*l = append(*l, string(p))
+	return len(p), nil
+}
+
+// newTestLogger returns a logger that appends every record to logs.
+func newTestLogger(logs *[]string) *slog.Logger {
+	return slog.New(slog.NewTextHandler((*logLines)(logs), nil))
+}
+
+// newTestDualAuthorizer creates a DualAuthorizer whose primary sees the user as primaryRole
+// and whose shadow reads relationships from store. Logged lines are appended to logs.
+func newTestDualAuthorizer(primaryRole string, store RelationshipStore, logs *[]string) *DualAuthorizer {
+	primary := NewLocalAuthorizer(&mockMemberFinder{member: &models.TeamMember{Role: primaryRole}}, &mockRoleFinder{})
+	auth := NewDualAuthorizer(primary, NewRelationshipAuthorizer(store, &mockRoleFinder{}))
+	auth.logger = newTestLogger(logs)
+	return auth
+}
+
+func TestDualAuthorizer_CanPerform(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	t.Run("matching decisions are not logged", func(t *testing.T) {
+		var logs []string
+		store := NewMemoryRelationshipStore()
+		auth := newTestDualAuthorizer(models.RoleAdmin, store, &logs)
+		require.NoError(t, NewRelationshipAuthorizer(store, &mockRoleFinder{}).WriteTeamMember(ctx, teamID, userID, models.RoleAdmin))
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionMemberInvite)
+
+		require.NoError(t, err)
+		assert.True(t, can)
+		assert.Empty(t, logs)
+	})
+
+	t.Run("mismatch is logged and the primary decision is returned", func(t *testing.T) {
+		var logs []string
+		auth := newTestDualAuthorizer(models.RoleAdmin, NewMemoryRelationshipStore(), &logs)
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionMemberInvite)
+
+		require.NoError(t, err)
+		assert.True(t, can)
+		require.Len(t, logs, 1)
+		assert.Contains(t, logs[0], `msg="authz mismatch" check=CanPerform`)
+		assert.Contains(t, logs[0], "primary=true shadow=false")
+	})
+
+	t.Run("shadow error is logged and the primary decision is returned", func(t *testing.T) {
+		var logs []string
+		auth := newTestDualAuthorizer(models.RoleAdmin, &failingRelationshipStore{err: errors.New("connection refused")}, &logs)
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionMemberInvite)
+
+		require.NoError(t, err)
+		assert.True(t, can)
+		require.Len(t, logs, 1)
+		assert.Contains(t, logs[0], `msg="authz shadow check failed" check=CanPerform`)
+		assert.Contains(t, logs[0], "connection refused")
+	})
+
+	t.Run("primary error is returned without consulting the shadow", func(t *testing.T) {
+		var logs []string
+		dbError := errors.New("database error")
+		primary := NewLocalAuthorizer(&mockMemberFinder{err: dbError}, &mockRoleFinder{})
+		auth := NewDualAuthorizer(primary, NewRelationshipAuthorizer(&failingRelationshipStore{err: errors.New("unused")}, &mockRoleFinder{}))
+		auth.logger = newTestLogger(&logs)
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionMemberInvite)
+
+		assert.Equal(t, dbError, err)
+		assert.False(t, can)
+		assert.Empty(t, logs)
+	})
+}
+
+func TestDualAuthorizer_CanPerformOnResource(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	var logs []string
+	store := NewMemoryRelationshipStore()
+	auth := newTestDualAuthorizer(models.RoleMember, store, &logs)
+	require.NoError(t, NewRelationshipAuthorizer(store, &mockRoleFinder{}).WriteTeamMember(ctx, teamID, userID, models.RoleViewer))
+
+	can, err := auth.CanPerformOnResource(ctx, userID, teamID, userID, ActionMemoDelete)
+
+	require.NoError(t, err)
+	assert.True(t, can)
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `msg="authz mismatch" check=CanPerformOnResource`)
+}
+
+func TestDualAuthorizer_GetUserRole(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	var logs []string
+	store := NewMemoryRelationshipStore()
+	auth := newTestDualAuthorizer(models.RoleOwner, store, &logs)
+	require.NoError(t, NewRelationshipAuthorizer(store, &mockRoleFinder{}).WriteTeamMember(ctx, teamID, userID, models.RoleAdmin))
+
+	role, err := auth.GetUserRole(ctx, userID, teamID)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleOwner, role)
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], "primary=owner shadow=admin")
+}
+
+func TestDualAuthorizer_IsMember(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	var logs []string
+	auth := newTestDualAuthorizer(models.RoleMember, NewMemoryRelationshipStore(), &logs)
+
+	isMember, err := auth.IsMember(ctx, userID, teamID)
+
+	require.NoError(t, err)
+	assert.True(t, isMember)
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `msg="authz mismatch" check=IsMember`)
+}
+
+func TestDualAuthorizer_InvalidateRole(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	primaryRoles := &mockRoleFinder{role: &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView}}}
+	shadowRoles := &mockRoleFinder{role: &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView}}}
+	store := NewMemoryRelationshipStore()
+	shadow := NewRelationshipAuthorizer(store, shadowRoles)
+	require.NoError(t, shadow.WriteTeamMember(ctx, teamID, userID, "reviewer"))
+	auth := NewDualAuthorizer(NewLocalAuthorizer(&mockMemberFinder{member: &models.TeamMember{Role: "reviewer"}}, primaryRoles), shadow)
+
+	_, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+	require.NoError(t, err)
+
+	auth.InvalidateRole(teamID, "reviewer")
+
+	_, err = auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+	require.NoError(t, err)
+	assert.Equal(t, 2, primaryRoles.calls)
+	assert.Equal(t, 2, shadowRoles.calls)
+}
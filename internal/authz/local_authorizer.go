@@ -16,56 +16,62 @@ type TeamMemberFinder interface {
 	FindByTeamAndUser(ctx context.Context, teamID, userID primitive.ObjectID) (*models.TeamMember, error)
 }
 
+// TeamRoleFinder is the interface required by LocalAuthorizer to look up custom team roles.
+type TeamRoleFinder interface {
+	FindByTeamAndName(ctx context.Context, teamID primitive.ObjectID, name string) (*models.TeamRole, error)
+}
+
 // LocalAuthorizer implements Authorizer using database lookups.
 // This is the initial implementation that can be replaced with SpiceDBAuthorizer later.
 type LocalAuthorizer struct {
 	memberFinder TeamMemberFinder
+	roles        *roleResolver
 }
 
 // NewLocalAuthorizer creates a new LocalAuthorizer.
-func NewLocalAuthorizer(memberFinder TeamMemberFinder) *LocalAuthorizer {
+func NewLocalAuthorizer(memberFinder TeamMemberFinder, roleFinder TeamRoleFinder) *LocalAuthorizer {
 	return &LocalAuthorizer{
 		memberFinder: memberFinder,
+		roles:        newRoleResolver(roleFinder),
 	}
 }
 
-// rolePermissions maps actions to the roles that can perform them.
-var rolePermissions = map[string][]string{
-	ActionTeamView:         {models.RoleOwner, models.RoleAdmin, models.RoleMember},
-	ActionTeamUpdate:       {models.RoleOwner, models.RoleAdmin},
-	ActionTeamDelete:       {models.RoleOwner},
-	ActionTeamTransfer:     {models.RoleOwner},
-	ActionMemberInvite:     {models.RoleOwner, models.RoleAdmin},
-	ActionMemberRemove:     {models.RoleOwner, models.RoleAdmin},
-	ActionMemberUpdateRole: {models.RoleOwner, models.RoleAdmin},
-	ActionMemoView:         {models.RoleOwner, models.RoleAdmin, models.RoleMember},
-	ActionMemoCreate:       {models.RoleOwner, models.RoleAdmin, models.RoleMember},
-	ActionMemoUpdate:       {models.RoleOwner, models.RoleAdmin, models.RoleMember},
-	ActionMemoDelete:       {models.RoleOwner, models.RoleAdmin, models.RoleMember},
-}
-
 // CanPerform checks if a user can perform an action on a team.
 func (a *LocalAuthorizer) CanPerform(ctx context.Context, userID, teamID primitive.ObjectID, action string) (bool, error) {
-	member, err := a.memberFinder.FindByTeamAndUser(ctx, teamID, userID)
+	actions, err := a.memberActions(ctx, userID, teamID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrNotTeamMember) {
-			return false, nil // Expected: not a member
-		}
-		return false, err // Unexpected: propagate error
+		return false, err
 	}
 
-	allowedRoles, exists := rolePermissions[action]
-	if !exists {
-		return false, nil // Unknown action
+	return actionsAllow(actions, action), nil
+}
+
+// CanPerformOnResource checks if a user can perform an action on a team resource created by resourceOwnerID.
+func (a *LocalAuthorizer) CanPerformOnResource(ctx context.Context, userID, teamID, resourceOwnerID primitive.ObjectID, action string) (bool, error) {
+	actions, err := a.memberActions(ctx, userID, teamID)
+	if err != nil {
+		return false, err
 	}
 
-	for _, role := range allowedRoles {
-		if member.Role == role {
-			return true, nil
+	return actionsAllowOnResource(actions, action, resourceOwnerID == userID), nil
+}
+
+// memberActions returns the actions granted by the user's role in a team, or nil if not a member.
+func (a *LocalAuthorizer) memberActions(ctx context.Context, userID, teamID primitive.ObjectID) ([]string, error) {
+	member, err := a.memberFinder.FindByTeamAndUser(ctx, teamID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotTeamMember) {
+			return nil, nil // Expected: not a member
 		}
+		return nil, err // Unexpected: propagate error
 	}
 
-	return false, nil
+	return a.roles.actions(ctx, teamID, member.Role)
+}
+
+// InvalidateRole drops a custom role from the cache after it is changed or deleted.
+func (a *LocalAuthorizer) InvalidateRole(teamID primitive.ObjectID, name string) {
+	a.roles.invalidate(teamID, name)
 }
 
 // GetUserRole returns the user's role in a team, or empty string if not a member.
@@ -23,13 +23,30 @@ func (m *mockMemberFinder) FindByTeamAndUser(_ context.Context, _, _ primitive.O
 	return m.member, m.err
 }
 
+// mockRoleFinder is a test double for TeamRoleFinder that counts lookups.
+type mockRoleFinder struct {
+	role  *models.TeamRole
+	err   error
+	calls int
+}
+
+func (m *mockRoleFinder) FindByTeamAndName(_ context.Context, _ primitive.ObjectID, _ string) (*models.TeamRole, error) {
+	m.calls++
+	if m.role == nil && m.err == nil {
+		return nil, apperrors.ErrTeamRoleNotFound
+	}
+	return m.role, m.err
+}
+
 func TestNewLocalAuthorizer(t *testing.T) {
 	finder := &mockMemberFinder{}
+	roleFinder := &mockRoleFinder{}
 
-	auth := NewLocalAuthorizer(finder)
+	auth := NewLocalAuthorizer(finder, roleFinder)
 
 	require.NotNil(t, auth)
 	assert.Equal(t, finder, auth.memberFinder)
+	assert.Equal(t, roleFinder, auth.roles.finder)
 }
 
 func TestLocalAuthorizer_CanPerform(t *testing.T) {
@@ -80,8 +97,27 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 		{"member cannot update roles", models.RoleMember, ActionMemberUpdateRole, false},
 		{"member can view memos", models.RoleMember, ActionMemoView, true},
 		{"member can create memos", models.RoleMember, ActionMemoCreate, true},
-		{"member can update memos", models.RoleMember, ActionMemoUpdate, true},
-		{"member can delete memos", models.RoleMember, ActionMemoDelete, true},
+		{"member cannot update any memo", models.RoleMember, ActionMemoUpdate, false},
+		{"member cannot delete any memo", models.RoleMember, ActionMemoDelete, false},
+		{"member can update own memos", models.RoleMember, ActionMemoUpdateOwn, true},
+		{"member can delete own memos", models.RoleMember, ActionMemoDeleteOwn, true},
+		{"admin can update own memos", models.RoleAdmin, ActionMemoUpdateOwn, true},
+		{"member cannot manage roles", models.RoleMember, ActionRoleManage, false},
+		{"owner can manage roles", models.RoleOwner, ActionRoleManage, true},
+		{"admin cannot manage roles", models.RoleAdmin, ActionRoleManage, false},
+
+		// Viewer preset - read only
+		{"viewer can view team", models.RoleViewer, ActionTeamView, true},
+		{"viewer can view memos", models.RoleViewer, ActionMemoView, true},
+		{"viewer cannot create memos", models.RoleViewer, ActionMemoCreate, false},
+		{"viewer cannot delete own memos", models.RoleViewer, ActionMemoDeleteOwn, false},
+
+		// Contributor preset - creates memos and deletes only their own
+		{"contributor can view memos", models.RoleContributor, ActionMemoView, true},
+		{"contributor can create memos", models.RoleContributor, ActionMemoCreate, true},
+		{"contributor cannot delete any memo", models.RoleContributor, ActionMemoDelete, false},
+		{"contributor can delete own memos", models.RoleContributor, ActionMemoDeleteOwn, true},
+		{"contributor cannot invite members", models.RoleContributor, ActionMemberInvite, false},
 	}
 
 	for _, tt := range roleActionTests {
@@ -89,7 +125,7 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 			finder := &mockMemberFinder{
 				member: &models.TeamMember{Role: tt.role},
 			}
-			auth := NewLocalAuthorizer(finder)
+			auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 			can, err := auth.CanPerform(ctx, userID, teamID, tt.action)
 
@@ -102,7 +138,7 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 		finder := &mockMemberFinder{
 			err: apperrors.ErrNotTeamMember,
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		can, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
 
@@ -114,7 +150,7 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 		finder := &mockMemberFinder{
 			member: &models.TeamMember{Role: models.RoleOwner},
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		can, err := auth.CanPerform(ctx, userID, teamID, "unknown:action")
 
@@ -126,7 +162,7 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 		finder := &mockMemberFinder{
 			member: &models.TeamMember{Role: "unknown_role"},
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		can, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
 
@@ -134,12 +170,79 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 		assert.False(t, can)
 	})
 
+	t.Run("custom role grants its stored actions", func(t *testing.T) {
+		finder := &mockMemberFinder{
+			member: &models.TeamMember{Role: "reviewer"},
+		}
+		roleFinder := &mockRoleFinder{
+			role: &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView, ActionMemoView}},
+		}
+		auth := NewLocalAuthorizer(finder, roleFinder)
+
+		canView, err := auth.CanPerform(ctx, userID, teamID, ActionMemoView)
+		require.NoError(t, err)
+		canCreate, err := auth.CanPerform(ctx, userID, teamID, ActionMemoCreate)
+		require.NoError(t, err)
+
+		assert.True(t, canView)
+		assert.False(t, canCreate)
+		assert.Equal(t, 1, roleFinder.calls, "second check should hit the cache")
+	})
+
+	t.Run("invalidated custom role is reloaded", func(t *testing.T) {
+		finder := &mockMemberFinder{
+			member: &models.TeamMember{Role: "reviewer"},
+		}
+		roleFinder := &mockRoleFinder{
+			role: &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView}},
+		}
+		auth := NewLocalAuthorizer(finder, roleFinder)
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionMemoView)
+		require.NoError(t, err)
+		assert.False(t, can)
+
+		roleFinder.role = &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView, ActionMemoView}}
+		auth.InvalidateRole(teamID, "reviewer")
+
+		can, err = auth.CanPerform(ctx, userID, teamID, ActionMemoView)
+		require.NoError(t, err)
+		assert.True(t, can)
+		assert.Equal(t, 2, roleFinder.calls)
+	})
+
+	t.Run("built-in roles are not looked up", func(t *testing.T) {
+		finder := &mockMemberFinder{
+			member: &models.TeamMember{Role: models.RoleMember},
+		}
+		roleFinder := &mockRoleFinder{}
+		auth := NewLocalAuthorizer(finder, roleFinder)
+
+		_, err := auth.CanPerform(ctx, userID, teamID, ActionMemoView)
+
+		require.NoError(t, err)
+		assert.Zero(t, roleFinder.calls)
+	})
+
+	t.Run("role lookup error is propagated", func(t *testing.T) {
+		finder := &mockMemberFinder{
+			member: &models.TeamMember{Role: "reviewer"},
+		}
+		dbError := errors.New("database connection failed")
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{err: dbError})
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionMemoView)
+
+		assert.Equal(t, dbError, err)
+		assert.False(t, can)
+	})
+
 	t.Run("database error is propagated", func(t *testing.T) {
 		dbError := errors.New("database connection failed")
 		finder := &mockMemberFinder{
 			err: dbError,
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		can, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
 
@@ -149,6 +252,71 @@ func TestLocalAuthorizer_CanPerform(t *testing.T) {
 	})
 }
 
+func TestLocalAuthorizer_CanPerformOnResource(t *testing.T) {
+	userID := primitive.NewObjectID()
+	otherUserID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		role     string
+		ownerID  primitive.ObjectID
+		action   string
+		expected bool
+	}{
+		{"member can delete own memo", models.RoleMember, userID, ActionMemoDelete, true},
+		{"member cannot delete another member's memo", models.RoleMember, otherUserID, ActionMemoDelete, false},
+		{"member can update own memo", models.RoleMember, userID, ActionMemoUpdate, true},
+		{"member cannot update another member's memo", models.RoleMember, otherUserID, ActionMemoUpdate, false},
+		{"admin can delete any memo", models.RoleAdmin, otherUserID, ActionMemoDelete, true},
+		{"owner can update any memo", models.RoleOwner, otherUserID, ActionMemoUpdate, true},
+		{"viewer cannot delete own memo", models.RoleViewer, userID, ActionMemoDelete, false},
+		{"contributor can delete own memo", models.RoleContributor, userID, ActionMemoDelete, true},
+		{"member can view another member's memo", models.RoleMember, otherUserID, ActionMemoView, true},
+		{"ownership does not grant actions without an own variant", models.RoleViewer, userID, ActionMemoCreate, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finder := &mockMemberFinder{
+				member: &models.TeamMember{Role: tt.role},
+			}
+			auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
+
+			can, err := auth.CanPerformOnResource(ctx, userID, teamID, tt.ownerID, tt.action)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, can)
+		})
+	}
+
+	t.Run("non-member cannot act on own resource", func(t *testing.T) {
+		finder := &mockMemberFinder{
+			err: apperrors.ErrNotTeamMember,
+		}
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
+
+		can, err := auth.CanPerformOnResource(ctx, userID, teamID, userID, ActionMemoDelete)
+
+		require.NoError(t, err)
+		assert.False(t, can)
+	})
+
+	t.Run("database error is propagated", func(t *testing.T) {
+		dbError := errors.New("database connection failed")
+		finder := &mockMemberFinder{
+			err: dbError,
+		}
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
+
+		can, err := auth.CanPerformOnResource(ctx, userID, teamID, userID, ActionMemoDelete)
+
+		assert.Equal(t, dbError, err)
+		assert.False(t, can)
+	})
+}
+
 func TestLocalAuthorizer_GetUserRole(t *testing.T) {
 	userID := primitive.NewObjectID()
 	teamID := primitive.NewObjectID()
@@ -158,7 +326,7 @@ func TestLocalAuthorizer_GetUserRole(t *testing.T) {
 		finder := &mockMemberFinder{
 			member: &models.TeamMember{Role: models.RoleAdmin},
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		role, err := auth.GetUserRole(ctx, userID, teamID)
 
@@ -170,7 +338,7 @@ func TestLocalAuthorizer_GetUserRole(t *testing.T) {
 		finder := &mockMemberFinder{
 			err: apperrors.ErrNotTeamMember,
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		role, err := auth.GetUserRole(ctx, userID, teamID)
 
@@ -181,7 +349,7 @@ func TestLocalAuthorizer_GetUserRole(t *testing.T) {
 	t.Run("propagates database error", func(t *testing.T) {
 		dbError := errors.New("database error")
 		finder := &mockMemberFinder{err: dbError}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		role, err := auth.GetUserRole(ctx, userID, teamID)
 
@@ -200,7 +368,7 @@ func TestLocalAuthorizer_IsMember(t *testing.T) {
 		finder := &mockMemberFinder{
 			member: &models.TeamMember{Role: models.RoleMember},
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		isMember, err := auth.IsMember(ctx, userID, teamID)
 
@@ -212,7 +380,7 @@ func TestLocalAuthorizer_IsMember(t *testing.T) {
 		finder := &mockMemberFinder{
 			err: apperrors.ErrNotTeamMember,
 		}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		isMember, err := auth.IsMember(ctx, userID, teamID)
 
@@ -223,7 +391,7 @@ func TestLocalAuthorizer_IsMember(t *testing.T) {
 	t.Run("propagates database error", func(t *testing.T) {
 		dbError := errors.New("database error")
 		finder := &mockMemberFinder{err: dbError}
-		auth := NewLocalAuthorizer(finder)
+		auth := NewLocalAuthorizer(finder, &mockRoleFinder{})
 
 		isMember, err := auth.IsMember(ctx, userID, teamID)
 
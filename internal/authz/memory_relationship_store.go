@@ -0,0 +1,78 @@
+package authz
+
+import (
+	"context"
+	"sync"
+
+	"gin-sample/internal/models"
+)
+
+// MemoryRelationshipStore is an in-memory RelationshipStore.
+// It is intended for tests and single-instance development setups.
+type MemoryRelationshipStore struct {
+	mu            sync.RWMutex
+	relationships []models.Relationship
+}
+
+// NewMemoryRelationshipStore creates a new MemoryRelationshipStore.
+func NewMemoryRelationshipStore() *MemoryRelationshipStore {
+	return &MemoryRelationshipStore{}
+}
+
+// WriteRelationships deletes the relationships matching each filter, then adds the touched
+// relationships if they do not already exist.
+func (s *MemoryRelationshipStore) WriteRelationships(_ context.Context, deletes []models.RelationshipFilter, touches []models.Relationship) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, filter := range deletes {
+		kept := s.relationships[:0]
+		for _, rel := range s.relationships {
+			if !relationshipMatches(rel, filter) {
+				kept = append(kept, rel)
+			}
+		}
+		s.relationships = kept
+	}
+
+	for _, rel := range touches {
+		if !s.contains(rel) {
+			s.relationships = append(s.relationships, rel)
+		}
+	}
+
+	return nil
+}
+
+// ReadRelationships returns the relationships matching filter.
+func (s *MemoryRelationshipStore) ReadRelationships(_ context.Context, filter models.RelationshipFilter) ([]models.Relationship, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := []models.Relationship{}
+	for _, rel := range s.relationships {
+		if relationshipMatches(rel, filter) {
+			matched = append(matched, rel)
+		}
+	}
+	return matched, nil
+}
+
+// contains reports whether rel is stored. Callers must hold the lock.
+func (s *MemoryRelationshipStore) contains(rel models.Relationship) bool {
+	for _, existing := range s.relationships {
+		if existing == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// relationshipMatches reports whether rel matches filter. Empty filter fields match any value.
+func relationshipMatches(rel models.Relationship, filter models.RelationshipFilter) bool {
+	return rel.ResourceType == filter.ResourceType &&
+		(filter.ResourceID == "" || rel.ResourceID == filter.ResourceID) &&
+		(filter.Relation == "" || rel.Relation == filter.Relation) &&
+		(filter.SubjectType == "" || rel.SubjectType == filter.SubjectType) &&
+		(filter.SubjectID == "" || rel.SubjectID == filter.SubjectID)
+}
@@ -0,0 +1,65 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRelationshipStore(t *testing.T) {
+	ctx := context.Background()
+	admin := models.Relationship{ResourceType: ObjectTypeTeam, ResourceID: "t1", Relation: "admin", SubjectType: ObjectTypeUser, SubjectID: "u1"}
+	member := models.Relationship{ResourceType: ObjectTypeTeam, ResourceID: "t1", Relation: "member", SubjectType: ObjectTypeUser, SubjectID: "u2"}
+	otherTeam := models.Relationship{ResourceType: ObjectTypeTeam, ResourceID: "t2", Relation: "owner", SubjectType: ObjectTypeUser, SubjectID: "u1"}
+
+	t.Run("reads relationships matching the filter", func(t *testing.T) {
+		store := NewMemoryRelationshipStore()
+		require.NoError(t, store.WriteRelationships(ctx, nil, []models.Relationship{admin, member, otherTeam}))
+
+		rels, err := store.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: ObjectTypeTeam, ResourceID: "t1"})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{admin, member}, rels)
+
+		rels, err = store.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: ObjectTypeTeam, SubjectID: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{admin, otherTeam}, rels)
+	})
+
+	t.Run("writing the same relationship twice is idempotent", func(t *testing.T) {
+		store := NewMemoryRelationshipStore()
+		require.NoError(t, store.WriteRelationships(ctx, nil, []models.Relationship{admin}))
+		require.NoError(t, store.WriteRelationships(ctx, nil, []models.Relationship{admin}))
+
+		rels, err := store.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: ObjectTypeTeam})
+		require.NoError(t, err)
+		assert.Len(t, rels, 1)
+	})
+
+	t.Run("deletes run before touches", func(t *testing.T) {
+		store := NewMemoryRelationshipStore()
+		require.NoError(t, store.WriteRelationships(ctx, nil, []models.Relationship{admin, member}))
+
+		promoted := admin
+		promoted.Relation = "owner"
+		deletes := []models.RelationshipFilter{{ResourceType: ObjectTypeTeam, ResourceID: "t1", SubjectID: "u1"}}
+		require.NoError(t, store.WriteRelationships(ctx, deletes, []models.Relationship{promoted}))
+
+		rels, err := store.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: ObjectTypeTeam, ResourceID: "t1"})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{member, promoted}, rels)
+	})
+
+	t.Run("returns empty slice when nothing matches", func(t *testing.T) {
+		store := NewMemoryRelationshipStore()
+
+		rels, err := store.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: ObjectTypeMemo})
+
+		require.NoError(t, err)
+		assert.NotNil(t, rels)
+		assert.Empty(t, rels)
+	})
+}
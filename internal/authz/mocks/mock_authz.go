@@ -56,6 +56,21 @@ func (mr *MockAuthorizerMockRecorder) CanPerform(ctx, userID, teamID, action any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanPerform", reflect.TypeOf((*MockAuthorizer)(nil).CanPerform), ctx, userID, teamID, action)
 }
 
+// CanPerformOnResource mocks base method.
+func (m *MockAuthorizer) CanPerformOnResource(ctx context.Context, userID, teamID, resourceOwnerID primitive.ObjectID, action string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanPerformOnResource", ctx, userID, teamID, resourceOwnerID, action)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanPerformOnResource indicates an expected call of CanPerformOnResource.
+func (mr *MockAuthorizerMockRecorder) CanPerformOnResource(ctx, userID, teamID, resourceOwnerID, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanPerformOnResource", reflect.TypeOf((*MockAuthorizer)(nil).CanPerformOnResource), ctx, userID, teamID, resourceOwnerID, action)
+}
+
 // GetUserRole mocks base method.
 func (m *MockAuthorizer) GetUserRole(ctx context.Context, userID, teamID primitive.ObjectID) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,86 @@
+package authz
+
+import (
+	"context"
+
+	"gin-sample/internal/models"
+)
+
+// ReconcileResult counts the relationships changed by ReconcileTeamMembers.
+type ReconcileResult struct {
+	// Written is the number of members whose role relationship was missing or outdated.
+	Written int
+	// Deleted is the number of users whose relationships on a team had no membership.
+	Deleted int
+}
+
+// ReconcileTeamMembers makes the team relationships in store match members, the team
+// membership records: missing or outdated roles are written and the relations of users who
+// are not members are deleted. It backfills memberships that predate relationships and
+// repairs drift between the two. With dryRun set the changes are only counted.
+func ReconcileTeamMembers(ctx context.Context, store RelationshipStore, members []models.TeamMember, dryRun bool) (ReconcileResult, error) {
+	existing, err := store.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: ObjectTypeTeam})
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+
+	type memberKey struct{ teamID, userID string }
+	relations := make(map[memberKey][]string)
+	for _, rel := range existing {
+		if rel.SubjectType != ObjectTypeUser {
+			continue
+		}
+		key := memberKey{rel.ResourceID, rel.SubjectID}
+		relations[key] = append(relations[key], rel.Relation)
+	}
+
+	var result ReconcileResult
+	for _, member := range members {
+		key := memberKey{member.TeamID.Hex(), member.UserID.Hex()}
+		current := relations[key]
+		delete(relations, key)
+		if len(current) == 1 && current[0] == member.Role {
+			continue
+		}
+
+		result.Written++
+		if dryRun {
+			continue
+		}
+		if err := store.WriteRelationships(ctx,
+			[]models.RelationshipFilter{memberRelationFilter(key.teamID, key.userID)},
+			[]models.Relationship{{
+				ResourceType: ObjectTypeTeam,
+				ResourceID:   key.teamID,
+				Relation:     member.Role,
+				SubjectType:  ObjectTypeUser,
+				SubjectID:    key.userID,
+			}},
+		); err != nil {
+			return result, err
+		}
+	}
+
+	// Whatever is left has no membership
+	for key := range relations {
+		result.Deleted++
+		if dryRun {
+			continue
+		}
+		if err := store.WriteRelationships(ctx, []models.RelationshipFilter{memberRelationFilter(key.teamID, key.userID)}, nil); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// memberRelationFilter selects a user's relations on a team by their hex IDs.
+func memberRelationFilter(teamID, userID string) models.RelationshipFilter {
+	return models.RelationshipFilter{
+		ResourceType: ObjectTypeTeam,
+		ResourceID:   teamID,
+		SubjectType:  ObjectTypeUser,
+		SubjectID:    userID,
+	}
+}
@@ -0,0 +1,85 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReconcileTeamMembers(t *testing.T) {
+	ctx := context.Background()
+	teamID := primitive.NewObjectID()
+	deletedTeamID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+	adminID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	formerID := primitive.NewObjectID()
+
+	members := []models.TeamMember{
+		{TeamID: teamID, UserID: ownerID, Role: models.RoleOwner},
+		{TeamID: teamID, UserID: adminID, Role: models.RoleAdmin},
+		{TeamID: teamID, UserID: memberID, Role: models.RoleMember},
+	}
+
+	// setup returns a store where the owner is in sync, the admin has an outdated role, the
+	// member predates relationships, and a former member and a deleted team are left over
+	setup := func(t *testing.T) (*MemoryRelationshipStore, *RelationshipAuthorizer) {
+		store := NewMemoryRelationshipStore()
+		auth := NewRelationshipAuthorizer(store, &mockRoleFinder{})
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, ownerID, models.RoleOwner))
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, adminID, models.RoleMember))
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, formerID, models.RoleMember))
+		require.NoError(t, auth.WriteTeamMember(ctx, deletedTeamID, ownerID, models.RoleOwner))
+		return store, auth
+	}
+
+	t.Run("writes missing and outdated roles and deletes stale relations", func(t *testing.T) {
+		store, auth := setup(t)
+
+		result, err := ReconcileTeamMembers(ctx, store, members, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, ReconcileResult{Written: 2, Deleted: 2}, result)
+		for _, member := range members {
+			role, err := auth.GetUserRole(ctx, member.UserID, member.TeamID)
+			require.NoError(t, err)
+			assert.Equal(t, member.Role, role)
+		}
+		isMember, err := auth.IsMember(ctx, formerID, teamID)
+		require.NoError(t, err)
+		assert.False(t, isMember)
+		isMember, err = auth.IsMember(ctx, ownerID, deletedTeamID)
+		require.NoError(t, err)
+		assert.False(t, isMember)
+
+		again, err := ReconcileTeamMembers(ctx, store, members, false)
+		require.NoError(t, err)
+		assert.Equal(t, ReconcileResult{}, again, "a reconciled store has nothing to change")
+	})
+
+	t.Run("dry run only counts changes", func(t *testing.T) {
+		store, auth := setup(t)
+
+		result, err := ReconcileTeamMembers(ctx, store, members, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, ReconcileResult{Written: 2, Deleted: 2}, result)
+		isMember, err := auth.IsMember(ctx, memberID, teamID)
+		require.NoError(t, err)
+		assert.False(t, isMember)
+	})
+
+	t.Run("returns store errors", func(t *testing.T) {
+		storeErr := errors.New("store unavailable")
+
+		_, err := ReconcileTeamMembers(ctx, &failingRelationshipStore{err: storeErr}, members, false)
+
+		assert.ErrorIs(t, err, storeErr)
+	})
+}
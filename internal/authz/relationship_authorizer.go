@@ -0,0 +1,138 @@
+package authz
+
+import (
+	"context"
+
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Object types used in relationships.
+const (
+	ObjectTypeUser = "user"
+	ObjectTypeTeam = "team"
+	ObjectTypeMemo = "memo"
+)
+
+// RelationshipStore is the interface required by RelationshipAuthorizer to read and write relationships.
+// It is satisfied by the MongoDB relationship repository and by MemoryRelationshipStore.
+type RelationshipStore interface {
+	WriteRelationships(ctx context.Context, deletes []models.RelationshipFilter, touches []models.Relationship) error
+	ReadRelationships(ctx context.Context, filter models.RelationshipFilter) ([]models.Relationship, error)
+}
+
+// RelationshipAuthorizer implements Authorizer with SpiceDB/Zanzibar-style relationships.
+// A user's role in a team is stored as the relationship team:<teamId>#<role>@user:<userId>
+// and permissions are computed as described by Schema. Custom roles are stored the same way
+// and their actions are resolved from the team's role definitions.
+type RelationshipAuthorizer struct {
+	store RelationshipStore
+	roles *roleResolver
+}
+
+// NewRelationshipAuthorizer creates a new RelationshipAuthorizer.
+func NewRelationshipAuthorizer(store RelationshipStore, roleFinder TeamRoleFinder) *RelationshipAuthorizer {
+	return &RelationshipAuthorizer{
+		store: store,
+		roles: newRoleResolver(roleFinder),
+	}
+}
+
+// CanPerform checks if a user can perform an action on a team.
+func (a *RelationshipAuthorizer) CanPerform(ctx context.Context, userID, teamID primitive.ObjectID, action string) (bool, error) {
+	actions, err := a.memberActions(ctx, userID, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	return actionsAllow(actions, action), nil
+}
+
+// CanPerformOnResource checks if a user can perform an action on a team resource created by resourceOwnerID.
+// resourceOwnerID plays the part of the memo's creator relation in Schema.
+func (a *RelationshipAuthorizer) CanPerformOnResource(ctx context.Context, userID, teamID, resourceOwnerID primitive.ObjectID, action string) (bool, error) {
+	actions, err := a.memberActions(ctx, userID, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	return actionsAllowOnResource(actions, action, resourceOwnerID == userID), nil
+}
+
+// GetUserRole returns the user's role in a team, or empty string if not a member.
+func (a *RelationshipAuthorizer) GetUserRole(ctx context.Context, userID, teamID primitive.ObjectID) (string, error) {
+	rels, err := a.store.ReadRelationships(ctx, teamMemberFilter(teamID, userID))
+	if err != nil {
+		return "", err
+	}
+	if len(rels) == 0 {
+		return "", nil
+	}
+	return rels[0].Relation, nil
+}
+
+// IsMember checks if a user is a member of a team.
+func (a *RelationshipAuthorizer) IsMember(ctx context.Context, userID, teamID primitive.ObjectID) (bool, error) {
+	rels, err := a.store.ReadRelationships(ctx, teamMemberFilter(teamID, userID))
+	if err != nil {
+		return false, err
+	}
+	return len(rels) > 0, nil
+}
+
+// InvalidateRole drops a custom role from the cache after it is changed or deleted.
+func (a *RelationshipAuthorizer) InvalidateRole(teamID primitive.ObjectID, name string) {
+	a.roles.invalidate(teamID, name)
+}
+
+// WriteTeamMember records the user's role in a team, replacing any previous role.
+func (a *RelationshipAuthorizer) WriteTeamMember(ctx context.Context, teamID, userID primitive.ObjectID, role string) error {
+	return a.store.WriteRelationships(ctx,
+		[]models.RelationshipFilter{teamMemberFilter(teamID, userID)},
+		[]models.Relationship{{
+			ResourceType: ObjectTypeTeam,
+			ResourceID:   teamID.Hex(),
+			Relation:     role,
+			SubjectType:  ObjectTypeUser,
+			SubjectID:    userID.Hex(),
+		}},
+	)
+}
+
+// DeleteTeamMember removes the user's role in a team.
+func (a *RelationshipAuthorizer) DeleteTeamMember(ctx context.Context, teamID, userID primitive.ObjectID) error {
+	return a.store.WriteRelationships(ctx, []models.RelationshipFilter{teamMemberFilter(teamID, userID)}, nil)
+}
+
+// DeleteTeam removes all relationships on a team.
+func (a *RelationshipAuthorizer) DeleteTeam(ctx context.Context, teamID primitive.ObjectID) error {
+	return a.store.WriteRelationships(ctx, []models.RelationshipFilter{{
+		ResourceType: ObjectTypeTeam,
+		ResourceID:   teamID.Hex(),
+	}}, nil)
+}
+
+// memberActions returns the actions granted by the user's relations on a team, or nil if there are none.
+func (a *RelationshipAuthorizer) memberActions(ctx context.Context, userID, teamID primitive.ObjectID) ([]string, error) {
+	rels, err := a.store.ReadRelationships(ctx, teamMemberFilter(teamID, userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []string
+	for _, rel := range rels {
+		roleActions, err := a.roles.actions(ctx, teamID, rel.Relation)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, roleActions...)
+	}
+
+	return actions, nil
+}
+
+// teamMemberFilter selects a user's relations on a team.
+func teamMemberFilter(teamID, userID primitive.ObjectID) models.RelationshipFilter {
+	return memberRelationFilter(teamID.Hex(), userID.Hex())
+}
@@ -0,0 +1,307 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// failingRelationshipStore is a RelationshipStore whose operations always fail.
+type failingRelationshipStore struct {
+	err error
+}
+
+func (s *failingRelationshipStore) WriteRelationships(_ context.Context, _ []models.RelationshipFilter, _ []models.Relationship) error {
+	return s.err
+}
+
+func (s *failingRelationshipStore) ReadRelationships(_ context.Context, _ models.RelationshipFilter) ([]models.Relationship, error) {
+	return nil, s.err
+}
+
+func TestNewRelationshipAuthorizer(t *testing.T) {
+	store := NewMemoryRelationshipStore()
+	roleFinder := &mockRoleFinder{}
+
+	auth := NewRelationshipAuthorizer(store, roleFinder)
+
+	require.NotNil(t, auth)
+	assert.Equal(t, store, auth.store)
+	assert.Equal(t, roleFinder, auth.roles.finder)
+}
+
+func TestRelationshipAuthorizer_CanPerform(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	// Every built-in role and action must decide the same way as LocalAuthorizer
+	for _, role := range builtinRoleOrder {
+		for _, action := range schemaActions {
+			t.Run(role+" "+action, func(t *testing.T) {
+				auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+				require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, role))
+				local := NewLocalAuthorizer(&mockMemberFinder{member: &models.TeamMember{Role: role}}, &mockRoleFinder{})
+
+				can, err := auth.CanPerform(ctx, userID, teamID, action)
+				require.NoError(t, err)
+				expected, err := local.CanPerform(ctx, userID, teamID, action)
+				require.NoError(t, err)
+
+				assert.Equal(t, expected, can)
+			})
+		}
+	}
+
+	t.Run("non-member cannot perform any action", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+
+		require.NoError(t, err)
+		assert.False(t, can)
+	})
+
+	t.Run("membership in another team grants nothing", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+		require.NoError(t, auth.WriteTeamMember(ctx, primitive.NewObjectID(), userID, models.RoleOwner))
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+
+		require.NoError(t, err)
+		assert.False(t, can)
+	})
+
+	t.Run("custom role grants its defined actions", func(t *testing.T) {
+		roleFinder := &mockRoleFinder{
+			role: &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView, ActionMemoView}},
+		}
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), roleFinder)
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, "reviewer"))
+
+		canView, err := auth.CanPerform(ctx, userID, teamID, ActionMemoView)
+		require.NoError(t, err)
+		canCreate, err := auth.CanPerform(ctx, userID, teamID, ActionMemoCreate)
+		require.NoError(t, err)
+
+		assert.True(t, canView)
+		assert.False(t, canCreate)
+	})
+
+	t.Run("store error is propagated", func(t *testing.T) {
+		storeErr := errors.New("database connection failed")
+		auth := NewRelationshipAuthorizer(&failingRelationshipStore{err: storeErr}, &mockRoleFinder{})
+
+		can, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+
+		assert.Equal(t, storeErr, err)
+		assert.False(t, can)
+	})
+}
+
+func TestRelationshipAuthorizer_CanPerformOnResource(t *testing.T) {
+	userID := primitive.NewObjectID()
+	otherUserID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		role     string
+		ownerID  primitive.ObjectID
+		action   string
+		expected bool
+	}{
+		{"member can delete own memo", models.RoleMember, userID, ActionMemoDelete, true},
+		{"member cannot delete another member's memo", models.RoleMember, otherUserID, ActionMemoDelete, false},
+		{"member can update own memo", models.RoleMember, userID, ActionMemoUpdate, true},
+		{"admin can delete any memo", models.RoleAdmin, otherUserID, ActionMemoDelete, true},
+		{"viewer cannot delete own memo", models.RoleViewer, userID, ActionMemoDelete, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+			require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, tt.role))
+
+			can, err := auth.CanPerformOnResource(ctx, userID, teamID, tt.ownerID, tt.action)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, can)
+		})
+	}
+
+	t.Run("non-member cannot act on own resource", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+
+		can, err := auth.CanPerformOnResource(ctx, userID, teamID, userID, ActionMemoDelete)
+
+		require.NoError(t, err)
+		assert.False(t, can)
+	})
+}
+
+func TestRelationshipAuthorizer_GetUserRole(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	t.Run("returns role for team member", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, models.RoleAdmin))
+
+		role, err := auth.GetUserRole(ctx, userID, teamID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleAdmin, role)
+	})
+
+	t.Run("returns empty string for non-member", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+
+		role, err := auth.GetUserRole(ctx, userID, teamID)
+
+		require.NoError(t, err)
+		assert.Empty(t, role)
+	})
+
+	t.Run("propagates store error", func(t *testing.T) {
+		storeErr := errors.New("database error")
+		auth := NewRelationshipAuthorizer(&failingRelationshipStore{err: storeErr}, &mockRoleFinder{})
+
+		role, err := auth.GetUserRole(ctx, userID, teamID)
+
+		assert.Equal(t, storeErr, err)
+		assert.Empty(t, role)
+	})
+}
+
+func TestRelationshipAuthorizer_IsMember(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	t.Run("returns true for team member", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, models.RoleMember))
+
+		isMember, err := auth.IsMember(ctx, userID, teamID)
+
+		require.NoError(t, err)
+		assert.True(t, isMember)
+	})
+
+	t.Run("returns false for non-member", func(t *testing.T) {
+		auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+
+		isMember, err := auth.IsMember(ctx, userID, teamID)
+
+		require.NoError(t, err)
+		assert.False(t, isMember)
+	})
+}
+
+func TestRelationshipAuthorizer_WriteTeamMember(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	t.Run("replaces the previous role", func(t *testing.T) {
+		store := NewMemoryRelationshipStore()
+		auth := NewRelationshipAuthorizer(store, &mockRoleFinder{})
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, models.RoleMember))
+
+		require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, models.RoleAdmin))
+
+		rels, err := store.ReadRelationships(ctx, teamMemberFilter(teamID, userID))
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{{
+			ResourceType: ObjectTypeTeam,
+			ResourceID:   teamID.Hex(),
+			Relation:     models.RoleAdmin,
+			SubjectType:  ObjectTypeUser,
+			SubjectID:    userID.Hex(),
+		}}, rels)
+	})
+
+	t.Run("propagates store error", func(t *testing.T) {
+		storeErr := errors.New("database error")
+		auth := NewRelationshipAuthorizer(&failingRelationshipStore{err: storeErr}, &mockRoleFinder{})
+
+		err := auth.WriteTeamMember(ctx, teamID, userID, models.RoleMember)
+
+		assert.Equal(t, storeErr, err)
+	})
+}
+
+func TestRelationshipAuthorizer_DeleteTeamMember(t *testing.T) {
+	userID := primitive.NewObjectID()
+	otherUserID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+	require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, models.RoleMember))
+	require.NoError(t, auth.WriteTeamMember(ctx, teamID, otherUserID, models.RoleMember))
+
+	require.NoError(t, auth.DeleteTeamMember(ctx, teamID, userID))
+
+	isMember, err := auth.IsMember(ctx, userID, teamID)
+	require.NoError(t, err)
+	assert.False(t, isMember)
+
+	otherIsMember, err := auth.IsMember(ctx, otherUserID, teamID)
+	require.NoError(t, err)
+	assert.True(t, otherIsMember)
+}
+
+func TestRelationshipAuthorizer_DeleteTeam(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	otherTeamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), &mockRoleFinder{})
+	require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, models.RoleOwner))
+	require.NoError(t, auth.WriteTeamMember(ctx, otherTeamID, userID, models.RoleMember))
+
+	require.NoError(t, auth.DeleteTeam(ctx, teamID))
+
+	isMember, err := auth.IsMember(ctx, userID, teamID)
+	require.NoError(t, err)
+	assert.False(t, isMember)
+
+	otherIsMember, err := auth.IsMember(ctx, userID, otherTeamID)
+	require.NoError(t, err)
+	assert.True(t, otherIsMember)
+}
+
+func TestRelationshipAuthorizer_InvalidateRole(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	roleFinder := &mockRoleFinder{
+		role: &models.TeamRole{Name: "reviewer", Actions: []string{ActionTeamView}},
+	}
+	auth := NewRelationshipAuthorizer(NewMemoryRelationshipStore(), roleFinder)
+	require.NoError(t, auth.WriteTeamMember(ctx, teamID, userID, "reviewer"))
+
+	_, err := auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+	require.NoError(t, err)
+	_, err = auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+	require.NoError(t, err)
+	assert.Equal(t, 1, roleFinder.calls)
+
+	auth.InvalidateRole(teamID, "reviewer")
+
+	_, err = auth.CanPerform(ctx, userID, teamID, ActionTeamView)
+	require.NoError(t, err)
+	assert.Equal(t, 2, roleFinder.calls)
+}
@@ -0,0 +1,87 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// customRoleCacheTTL bounds how long a custom role's actions are cached.
+// Changes made through this instance invalidate the cache immediately; other instances
+// pick them up once the entry expires.
+const customRoleCacheTTL = 30 * time.Second
+
+// cachedRole is a custom role's actions cached by roleResolver.
+// A nil actions slice caches a role that no longer exists.
+type cachedRole struct {
+	actions   []string
+	expiresAt time.Time
+}
+
+// roleResolver resolves role names to the actions they grant, caching custom roles.
+type roleResolver struct {
+	finder TeamRoleFinder
+
+	mu    sync.Mutex
+	roles map[string]cachedRole
+}
+
+// newRoleResolver creates a new roleResolver.
+func newRoleResolver(finder TeamRoleFinder) *roleResolver {
+	return &roleResolver{
+		finder: finder,
+		roles:  make(map[string]cachedRole),
+	}
+}
+
+// actions resolves the actions granted by a role, checking built-in roles first
+// and then the team's custom roles.
+func (r *roleResolver) actions(ctx context.Context, teamID primitive.ObjectID, role string) ([]string, error) {
+	if actions, ok := builtinRoles[role]; ok {
+		return actions, nil
+	}
+
+	key := roleCacheKey(teamID, role)
+	now := time.Now()
+
+	r.mu.Lock()
+	cached, ok := r.roles[key]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.actions, nil
+	}
+
+	var actions []string
+	customRole, err := r.finder.FindByTeamAndName(ctx, teamID, role)
+	if err != nil {
+		if !errors.Is(err, apperrors.ErrTeamRoleNotFound) {
+			return nil, err
+		}
+		// Expected: role was deleted, member gets no permissions
+	} else {
+		actions = customRole.Actions
+	}
+
+	r.mu.Lock()
+	r.roles[key] = cachedRole{actions: actions, expiresAt: now.Add(customRoleCacheTTL)}
+	r.mu.Unlock()
+
+	return actions, nil
+}
+
+// invalidate drops a custom role from the cache after it is changed or deleted.
+func (r *roleResolver) invalidate(teamID primitive.ObjectID, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.roles, roleCacheKey(teamID, name))
+}
+
+// roleCacheKey returns the cache key for a team's custom role.
+func roleCacheKey(teamID primitive.ObjectID, name string) string {
+	return teamID.Hex() + ":" + name
+}
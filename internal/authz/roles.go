@@ -0,0 +1,115 @@
+package authz
+
+import "gin-sample/internal/models"
+
+// builtinRoles maps each built-in role to the actions it grants.
+// Built-in roles exist in every team; custom roles are stored per team.
+var builtinRoles = map[string][]string{
+	models.RoleOwner: {
+		ActionTeamView, ActionTeamUpdate, ActionTeamDelete, ActionTeamTransfer,
+		ActionMemberInvite, ActionMemberRemove, ActionMemberUpdateRole, ActionRoleManage,
+		ActionMemoView, ActionMemoCreate, ActionMemoUpdate, ActionMemoDelete,
+		ActionAuditView,
+	},
+	models.RoleAdmin: {
+		ActionTeamView, ActionTeamUpdate,
+		ActionMemberInvite, ActionMemberRemove, ActionMemberUpdateRole,
+		ActionMemoView, ActionMemoCreate, ActionMemoUpdate, ActionMemoDelete,
+		ActionAuditView,
+	},
+	models.RoleMember: {
+		ActionTeamView,
+		ActionMemoView, ActionMemoCreate, ActionMemoUpdateOwn, ActionMemoDeleteOwn,
+	},
+	// Presets
+	models.RoleViewer: {
+		ActionTeamView,
+		ActionMemoView,
+	},
+	models.RoleContributor: {
+		ActionTeamView,
+		ActionMemoView, ActionMemoCreate, ActionMemoUpdateOwn, ActionMemoDeleteOwn,
+	},
+}
+
+// builtinRoleOrder lists the built-in roles from most to least privileged.
+var builtinRoleOrder = []string{
+	models.RoleOwner,
+	models.RoleAdmin,
+	models.RoleMember,
+	models.RoleContributor,
+	models.RoleViewer,
+}
+
+// ownActions maps an action to its variant limited to resources the user created.
+var ownActions = map[string]string{
+	ActionMemoUpdate: ActionMemoUpdateOwn,
+	ActionMemoDelete: ActionMemoDeleteOwn,
+}
+
+// IsBuiltinRole reports whether name is a built-in role.
+func IsBuiltinRole(name string) bool {
+	_, ok := builtinRoles[name]
+	return ok
+}
+
+// BuiltinRoleActions returns the actions a built-in role grants.
+func BuiltinRoleActions(name string) ([]string, bool) {
+	actions, ok := builtinRoles[name]
+	return actions, ok
+}
+
+// ActionsCover reports whether a role granting actions may perform every action in required,
+// that is whether it grants at least what a role granting required does.
+func ActionsCover(actions, required []string) bool {
+	for _, action := range required {
+		if !actionsAllow(actions, action) {
+			return false
+		}
+	}
+	return true
+}
+
+// BuiltinRoles returns the definitions of all built-in roles.
+func BuiltinRoles() []models.RoleDefinition {
+	defs := make([]models.RoleDefinition, 0, len(builtinRoleOrder))
+	for _, name := range builtinRoleOrder {
+		defs = append(defs, models.RoleDefinition{
+			Name:    name,
+			Actions: append([]string(nil), builtinRoles[name]...),
+		})
+	}
+	return defs
+}
+
+// OwnAction returns the variant of action limited to the user's own resources, if one exists.
+func OwnAction(action string) (string, bool) {
+	own, ok := ownActions[action]
+	return own, ok
+}
+
+// actionsAllow reports whether a role granting actions may perform action.
+// Granting an action also grants its own-resources variant.
+func actionsAllow(actions []string, action string) bool {
+	for _, granted := range actions {
+		if granted == action {
+			return true
+		}
+		if own, ok := ownActions[granted]; ok && own == action {
+			return true
+		}
+	}
+	return false
+}
+
+// actionsAllowOnResource reports whether a role granting actions may perform action on a
+// resource. The own-resources variant of the action applies only when isCreator is true.
+func actionsAllowOnResource(actions []string, action string, isCreator bool) bool {
+	if actionsAllow(actions, action) {
+		return true
+	}
+	if own, ok := ownActions[action]; ok && isCreator {
+		return actionsAllow(actions, own)
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package authz
+
+import (
+	"testing"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBuiltinRole(t *testing.T) {
+	assert.True(t, IsBuiltinRole(models.RoleOwner))
+	assert.True(t, IsBuiltinRole(models.RoleViewer))
+	assert.True(t, IsBuiltinRole(models.RoleContributor))
+	assert.False(t, IsBuiltinRole("reviewer"))
+}
+
+func TestBuiltinRoles(t *testing.T) {
+	defs := BuiltinRoles()
+
+	require.Len(t, defs, 5)
+	assert.Equal(t, models.RoleOwner, defs[0].Name)
+
+	// Returned slices are copies
+	defs[0].Actions[0] = "changed"
+	assert.Equal(t, ActionTeamView, BuiltinRoles()[0].Actions[0])
+}
+
+func TestOwnAction(t *testing.T) {
+	own, ok := OwnAction(ActionMemoDelete)
+	assert.True(t, ok)
+	assert.Equal(t, ActionMemoDeleteOwn, own)
+
+	own, ok = OwnAction(ActionMemoUpdate)
+	assert.True(t, ok)
+	assert.Equal(t, ActionMemoUpdateOwn, own)
+
+	_, ok = OwnAction(ActionMemoView)
+	assert.False(t, ok)
+}
@@ -0,0 +1,58 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+)
+
+// schemaActions lists the team actions in the order they appear in Schema.
+var schemaActions = []string{
+	ActionTeamView, ActionTeamUpdate, ActionTeamDelete, ActionTeamTransfer,
+	ActionMemberInvite, ActionMemberRemove, ActionMemberUpdateRole, ActionRoleManage,
+	ActionMemoView, ActionMemoCreate, ActionMemoUpdate, ActionMemoUpdateOwn, ActionMemoDelete, ActionMemoDeleteOwn,
+	ActionAuditView,
+}
+
+// Schema returns the relationship schema in SpiceDB schema language.
+// It is generated from the built-in roles so it always matches LocalAuthorizer.
+// Custom roles are not part of the schema; their members are stored as relations named
+// after the role and resolved from the team's role definitions.
+func Schema() string {
+	var b strings.Builder
+
+	b.WriteString("definition user {}\n\n")
+
+	b.WriteString("definition team {\n")
+	for _, role := range builtinRoleOrder {
+		fmt.Fprintf(&b, "\trelation %s: %s\n", role, ObjectTypeUser)
+	}
+	b.WriteString("\n")
+	for _, action := range schemaActions {
+		var granted []string
+		for _, role := range builtinRoleOrder {
+			if actionsAllow(builtinRoles[role], action) {
+				granted = append(granted, role)
+			}
+		}
+		fmt.Fprintf(&b, "\tpermission %s = %s\n", schemaPermission(action), strings.Join(granted, " + "))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("definition memo {\n")
+	fmt.Fprintf(&b, "\trelation team: %s\n", ObjectTypeTeam)
+	fmt.Fprintf(&b, "\trelation creator: %s\n", ObjectTypeUser)
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "\tpermission view = team->%s\n", schemaPermission(ActionMemoView))
+	for _, action := range []string{ActionMemoUpdate, ActionMemoDelete} {
+		fmt.Fprintf(&b, "\tpermission %s = team->%s + (creator & team->%s)\n",
+			strings.TrimPrefix(action, "memo:"), schemaPermission(action), schemaPermission(ownActions[action]))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// schemaPermission converts an action such as memo:delete_own to a schema permission name.
+func schemaPermission(action string) string {
+	return strings.ReplaceAll(action, ":", "_")
+}
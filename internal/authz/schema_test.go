@@ -0,0 +1,30 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	schema := Schema()
+
+	expectedLines := []string{
+		"definition user {}",
+		"definition team {",
+		"\trelation owner: user",
+		"\trelation viewer: user",
+		"\tpermission team_delete = owner",
+		"\tpermission team_view = owner + admin + member + contributor + viewer",
+		"\tpermission memo_delete_own = owner + admin + member + contributor",
+		"definition memo {",
+		"\trelation team: team",
+		"\trelation creator: user",
+		"\tpermission view = team->memo_view",
+		"\tpermission delete = team->memo_delete + (creator & team->memo_delete_own)",
+		"\tpermission update = team->memo_update + (creator & team->memo_update_own)",
+	}
+	for _, line := range expectedLines {
+		assert.Contains(t, schema, line+"\n")
+	}
+}
@@ -0,0 +1,32 @@
+package authz
+
+import "gin-sample/pkg/auth"
+
+// apiKeyScopeActions maps API key scopes to the actions they grant.
+var apiKeyScopeActions = map[string][]string{
+	auth.ScopeMemosRead:  {ActionMemoView},
+	auth.ScopeMemosWrite: {ActionMemoCreate, ActionMemoUpdate, ActionMemoDelete},
+}
+
+// APIKeyActions returns the actions granted by a set of API key scopes.
+// The result is never nil, so a key whose scopes grant nothing stays restricted.
+func APIKeyActions(scopes []string) []string {
+	actions := []string{}
+	for _, scope := range scopes {
+		actions = append(actions, apiKeyScopeActions[scope]...)
+	}
+	return actions
+}
+
+// ScopeAllows reports whether scopes grant action. A nil scopes list is unrestricted.
+func ScopeAllows(scopes []string, action string) bool {
+	if scopes == nil {
+		return true
+	}
+	for _, scope := range scopes {
+		if scope == action {
+			return true
+		}
+	}
+	return false
+}
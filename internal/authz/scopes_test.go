@@ -0,0 +1,44 @@
+package authz
+
+import (
+	"testing"
+
+	"gin-sample/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyActions(t *testing.T) {
+	t.Run("maps scopes to actions", func(t *testing.T) {
+		actions := APIKeyActions([]string{auth.ScopeMemosRead, auth.ScopeMemosWrite})
+
+		assert.ElementsMatch(t, []string{ActionMemoView, ActionMemoCreate, ActionMemoUpdate, ActionMemoDelete}, actions)
+	})
+
+	t.Run("returns empty non-nil list for unknown scopes", func(t *testing.T) {
+		actions := APIKeyActions([]string{"unknown"})
+
+		assert.NotNil(t, actions)
+		assert.Empty(t, actions)
+	})
+}
+
+func TestScopeAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		action string
+		want   bool
+	}{
+		{"unrestricted", nil, ActionTeamDelete, true},
+		{"granted", []string{ActionMemoView}, ActionMemoView, true},
+		{"not granted", []string{ActionMemoView}, ActionMemoDelete, false},
+		{"empty grants nothing", []string{}, ActionMemoView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ScopeAllows(tt.scopes, tt.action))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"gin-sample/internal/metrics"
+)
+
+// instrumentedCache records the latency of every Cache call as a Redis dependency call.
+type instrumentedCache struct {
+	next    Cache
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedCache wraps next so every call is recorded in m.
+func NewInstrumentedCache(next Cache, m *metrics.Metrics) Cache {
+	return &instrumentedCache{next: next, metrics: m}
+}
+
+// observe records a call that started at start and returned *err.
+func (c *instrumentedCache) observe(operation string, start time.Time, err *error) {
+	c.metrics.ObserveDependencyCall(metrics.DependencyRedis, operation, time.Since(start), *err)
+}
+
+func (c *instrumentedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) (err error) {
+	defer c.observe("Set", time.Now(), &err)
+	return c.next.Set(ctx, key, value, ttl)
+}
+
+func (c *instrumentedCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (_ bool, err error) {
+	defer c.observe("SetNX", time.Now(), &err)
+	return c.next.SetNX(ctx, key, value, ttl)
+}
+
+func (c *instrumentedCache) Get(ctx context.Context, key string, dest interface{}) (_ bool, err error) {
+	defer c.observe("Get", time.Now(), &err)
+	return c.next.Get(ctx, key, dest)
+}
+
+func (c *instrumentedCache) GetDel(ctx context.Context, key string, dest interface{}) (_ bool, err error) {
+	defer c.observe("GetDel", time.Now(), &err)
+	return c.next.GetDel(ctx, key, dest)
+}
+
+func (c *instrumentedCache) Delete(ctx context.Context, key string) (err error) {
+	defer c.observe("Delete", time.Now(), &err)
+	return c.next.Delete(ctx, key)
+}
+
+func (c *instrumentedCache) Incr(ctx context.Context, key string, ttl time.Duration) (_ int64, err error) {
+	defer c.observe("Incr", time.Now(), &err)
+	return c.next.Incr(ctx, key, ttl)
+}
+
+func (c *instrumentedCache) SetRefreshToken(ctx context.Context, token string, userID string, ttl time.Duration) (err error) {
+	defer c.observe("SetRefreshToken", time.Now(), &err)
+	return c.next.SetRefreshToken(ctx, token, userID, ttl)
+}
+
+func (c *instrumentedCache) GetRefreshToken(ctx context.Context, token string) (_ string, err error) {
+	defer c.observe("GetRefreshToken", time.Now(), &err)
+	return c.next.GetRefreshToken(ctx, token)
+}
+
+func (c *instrumentedCache) DeleteRefreshToken(ctx context.Context, token string) (err error) {
+	defer c.observe("DeleteRefreshToken", time.Now(), &err)
+	return c.next.DeleteRefreshToken(ctx, token)
+}
+
+func (c *instrumentedCache) DeleteRefreshTokens(ctx context.Context, tokens []string) (err error) {
+	defer c.observe("DeleteRefreshTokens", time.Now(), &err)
+	return c.next.DeleteRefreshTokens(ctx, tokens)
+}
@@ -0,0 +1,38 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gin-sample/internal/cache"
+	"gin-sample/internal/cache/mocks"
+	"gin-sample/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInstrumentedCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := metrics.New()
+	mockCache := mocks.NewMockCache(ctrl)
+	c := cache.NewInstrumentedCache(mockCache, m)
+
+	mockCache.EXPECT().Get(gomock.Any(), "user:1", gomock.Any()).Return(true, nil)
+	mockCache.EXPECT().Delete(gomock.Any(), "user:1").Return(errors.New("connection refused"))
+
+	found, err := c.Get(context.Background(), "user:1", &struct{}{})
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Error(t, c.Delete(context.Background(), "user:1"))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `gin_sample_dependency_call_duration_seconds_count{dependency="redis",operation="Get",result="ok"} 1`)
+	assert.Contains(t, w.Body.String(), `gin_sample_dependency_call_duration_seconds_count{dependency="redis",operation="Delete",result="error"} 1`)
+}
@@ -11,10 +11,18 @@ import (
 type Cache interface {
 	// Set stores a value in cache with TTL.
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	// SetNX stores a value in cache with TTL only if the key does not exist.
+	// Returns false if the key already exists.
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
 	// Get retrieves a value from cache. Returns false if key doesn't exist.
 	Get(ctx context.Context, key string, dest interface{}) (bool, error)
+	// GetDel atomically retrieves and removes a value from cache. Returns false if key doesn't exist.
+	GetDel(ctx context.Context, key string, dest interface{}) (bool, error)
 	// Delete removes a key from cache.
 	Delete(ctx context.Context, key string) error
+	// Incr atomically increments a counter and returns its new value.
+	// A new counter expires after ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
 	// SetRefreshToken stores a refresh token in cache.
 	SetRefreshToken(ctx context.Context, token string, userID string, ttl time.Duration) error
 	// GetRefreshToken retrieves a user ID from a refresh token.
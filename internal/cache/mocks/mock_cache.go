@@ -98,6 +98,21 @@ func (mr *MockCacheMockRecorder) Get(ctx, key, dest any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), ctx, key, dest)
 }
 
+// GetDel mocks base method.
+func (m *MockCache) GetDel(ctx context.Context, key string, dest any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDel", ctx, key, dest)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDel indicates an expected call of GetDel.
+func (mr *MockCacheMockRecorder) GetDel(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDel", reflect.TypeOf((*MockCache)(nil).GetDel), ctx, key, dest)
+}
+
 // GetRefreshToken mocks base method.
 func (m *MockCache) GetRefreshToken(ctx context.Context, token string) (string, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +128,21 @@ func (mr *MockCacheMockRecorder) GetRefreshToken(ctx, token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockCache)(nil).GetRefreshToken), ctx, token)
 }
 
+// Incr mocks base method.
+func (m *MockCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, key, ttl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockCacheMockRecorder) Incr(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockCache)(nil).Incr), ctx, key, ttl)
+}
+
 // Set mocks base method.
 func (m *MockCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
 	m.ctrl.T.Helper()
@@ -127,6 +157,21 @@ func (mr *MockCacheMockRecorder) Set(ctx, key, value, ttl any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, key, value, ttl)
 }
 
+// SetNX mocks base method.
+func (m *MockCache) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockCacheMockRecorder) SetNX(ctx, key, value, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockCache)(nil).SetNX), ctx, key, value, ttl)
+}
+
 // SetRefreshToken mocks base method.
 func (m *MockCache) SetRefreshToken(ctx context.Context, token, userID string, ttl time.Duration) error {
 	m.ctrl.T.Helper()
@@ -99,16 +99,31 @@ func (mr *MockRefreshTokenStoreMockRecorder) Get(ctx, familyID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRefreshTokenStore)(nil).Get), ctx, familyID)
 }
 
+// ListByUserID mocks base method.
+func (m *MockRefreshTokenStore) ListByUserID(ctx context.Context, userID string) (map[string]*cache.RefreshTokenData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret0, _ := ret[0].(map[string]*cache.RefreshTokenData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockRefreshTokenStoreMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockRefreshTokenStore)(nil).ListByUserID), ctx, userID)
+}
+
 // Rotate mocks base method.
-func (m *MockRefreshTokenStore) Rotate(ctx context.Context, familyID, newTokenHash string, ttl time.Duration) error {
+func (m *MockRefreshTokenStore) Rotate(ctx context.Context, familyID, newTokenHash, ipAddress string, ttl time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Rotate", ctx, familyID, newTokenHash, ttl)
+	ret := m.ctrl.Call(m, "Rotate", ctx, familyID, newTokenHash, ipAddress, ttl)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Rotate indicates an expected call of Rotate.
-func (mr *MockRefreshTokenStoreMockRecorder) Rotate(ctx, familyID, newTokenHash, ttl any) *gomock.Call {
+func (mr *MockRefreshTokenStoreMockRecorder) Rotate(ctx, familyID, newTokenHash, ipAddress, ttl any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRefreshTokenStore)(nil).Rotate), ctx, familyID, newTokenHash, ttl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRefreshTokenStore)(nil).Rotate), ctx, familyID, newTokenHash, ipAddress, ttl)
 }
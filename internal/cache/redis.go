@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"gin-sample/internal/logger"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -21,20 +24,25 @@ type Redis struct {
 func NewRedis(uri string) *Redis {
 	opt, err := redis.ParseURL("redis://" + uri)
 	if err != nil {
-		log.Fatalf("Failed to parse Redis URI: %v", err)
+		logger.Fatal("failed to parse Redis URI", "error", err)
 	}
 
 	client := redis.NewClient(opt)
 
+	// Record a span for every command. Statements are left out since they contain cached values.
+	if err := redisotel.InstrumentTracing(client, redisotel.WithDBStatement(false)); err != nil {
+		logger.Fatal("failed to instrument Redis client", "error", err)
+	}
+
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+		logger.Fatal("failed to connect to Redis", "error", err)
 	}
 
-	log.Println("Connected to Redis")
+	slog.Info("connected to Redis")
 
 	return &Redis{client: client}
 }
@@ -44,12 +52,17 @@ func (r *Redis) Client() *redis.Client {
 	return r.client
 }
 
+// Ping checks that Redis is reachable.
+func (r *Redis) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 // Close closes the Redis connection.
 func (r *Redis) Close() {
 	if err := r.client.Close(); err != nil {
-		log.Printf("Error closing Redis connection: %v", err)
+		slog.Error("failed to close Redis connection", "error", err)
 	}
-	log.Println("Disconnected from Redis")
+	slog.Info("disconnected from Redis")
 }
 
 // Set stores a value in cache with TTL.
@@ -62,6 +75,17 @@ func (r *Redis) Set(ctx context.Context, key string, value interface{}, ttl time
 	return r.client.Set(ctx, key, data, ttl).Err()
 }
 
+// SetNX stores a value in cache with TTL only if the key does not exist.
+// Returns false if the key already exists.
+func (r *Redis) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	return r.client.SetNX(ctx, key, data, ttl).Result()
+}
+
 // Get retrieves a value from cache.
 // Returns false if key doesn't exist.
 func (r *Redis) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
@@ -80,21 +104,89 @@ func (r *Redis) Get(ctx context.Context, key string, dest interface{}) (bool, er
 	return true, nil
 }
 
+// GetDel atomically retrieves and removes a value from cache, so only one caller
+// receives it. Returns false if key doesn't exist.
+func (r *Redis) GetDel(ctx context.Context, key string, dest interface{}) (bool, error) {
+	data, err := r.client.GetDel(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil // Key doesn't exist
+		}
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	return true, nil
+}
+
 // Delete removes a key from cache.
 func (r *Redis) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// Incr atomically increments a counter and returns its new value.
+// A new counter expires after ttl.
+func (r *Redis) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	n, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 1 {
+		if err := r.client.PExpire(ctx, key, ttl).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
 // UserCacheKey generates a cache key for a user.
 func UserCacheKey(userID string) string {
 	return fmt.Sprintf("user:%s", userID)
 }
 
+// TeamMemberCacheKey generates a cache key for a user's membership in a team.
+func TeamMemberCacheKey(teamID, userID string) string {
+	return fmt.Sprintf("team_member:%s:%s", teamID, userID)
+}
+
 // RefreshTokenCacheKey generates a cache key for a refresh token.
 func RefreshTokenCacheKey(token string) string {
 	return fmt.Sprintf("refresh:%s", token)
 }
 
+// MFAChallengeCacheKey generates a cache key for a pending MFA login challenge.
+func MFAChallengeCacheKey(token string) string {
+	return fmt.Sprintf("mfa_challenge:%s", token)
+}
+
+// MFAChallengeAttemptsCacheKey generates a cache key counting codes tried for an MFA login challenge.
+func MFAChallengeAttemptsCacheKey(token string) string {
+	return fmt.Sprintf("mfa_challenge_attempts:%s", token)
+}
+
+// MFAAttemptsCacheKey generates a cache key counting codes a user tried to manage MFA settings.
+func MFAAttemptsCacheKey(userID string) string {
+	return fmt.Sprintf("mfa_attempts:%s", userID)
+}
+
+// MFAActivationAttemptsCacheKey generates a cache key counting codes a user tried to activate MFA.
+func MFAActivationAttemptsCacheKey(userID string) string {
+	return fmt.Sprintf("mfa_activation_attempts:%s", userID)
+}
+
+// MFAUsedCodeCacheKey generates a cache key marking a TOTP code as used by a user.
+func MFAUsedCodeCacheKey(userID, code string) string {
+	return fmt.Sprintf("mfa_used:%s:%s", userID, code)
+}
+
+// OIDCStateCacheKey generates a cache key for a pending OIDC login.
+func OIDCStateCacheKey(state string) string {
+	return fmt.Sprintf("oidc_state:%s", state)
+}
+
 // SetRefreshToken stores a refresh token in cache.
 func (r *Redis) SetRefreshToken(ctx context.Context, token string, userID string, ttl time.Duration) error {
 	return r.client.Set(ctx, RefreshTokenCacheKey(token), userID, ttl).Err()
@@ -1,9 +1,14 @@
 package cache
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUserCacheKey(t *testing.T) {
@@ -45,3 +50,86 @@ func TestRefreshTokenCacheKey(t *testing.T) {
 		})
 	}
 }
+
+func TestMFAChallengeCacheKey(t *testing.T) {
+	assert.Equal(t, "mfa_challenge:mfa_abc123", MFAChallengeCacheKey("mfa_abc123"))
+}
+
+func TestMFAChallengeAttemptsCacheKey(t *testing.T) {
+	assert.Equal(t, "mfa_challenge_attempts:mfa_abc123", MFAChallengeAttemptsCacheKey("mfa_abc123"))
+}
+
+func TestMFAAttemptsCacheKey(t *testing.T) {
+	assert.Equal(t, "mfa_attempts:507f1f77bcf86cd799439011", MFAAttemptsCacheKey("507f1f77bcf86cd799439011"))
+}
+
+func TestMFAUsedCodeCacheKey(t *testing.T) {
+	assert.Equal(t, "mfa_used:507f1f77bcf86cd799439011:123456", MFAUsedCodeCacheKey("507f1f77bcf86cd799439011", "123456"))
+}
+
+func TestTeamMemberCacheKey(t *testing.T) {
+	assert.Equal(t, "team_member:team1:user1", TeamMemberCacheKey("team1", "user1"))
+}
+
+func TestRedis_Incr(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	r := &Redis{client: client}
+
+	n, err := r.Incr(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	mr.FastForward(30 * time.Second)
+	n, err = r.Incr(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// The expiry is set when the counter is created and not extended
+	mr.FastForward(31 * time.Second)
+	assert.False(t, mr.Exists("counter"))
+}
+
+func TestRedis_SetNX(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	r := &Redis{client: client}
+
+	stored, err := r.SetNX(ctx, "claim", true, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	stored, err = r.SetNX(ctx, "claim", true, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, stored, "an existing key is not overwritten")
+
+	mr.FastForward(time.Minute)
+	stored, err = r.SetNX(ctx, "claim", true, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored, "the key can be claimed again once it expires")
+}
+
+func TestRedis_GetDel(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	r := &Redis{client: client}
+
+	require.NoError(t, r.Set(ctx, "state", "value", time.Minute))
+
+	var got string
+	found, err := r.GetDel(ctx, "state", &got)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "value", got)
+
+	found, err = r.GetDel(ctx, "state", &got)
+	require.NoError(t, err)
+	assert.False(t, found, "the value is only returned once")
+	assert.False(t, mr.Exists("state"))
+}
@@ -14,12 +14,16 @@ import (
 var ErrRefreshTokenFamilyNotFound = errors.New("refresh token family not found")
 
 // RefreshTokenData represents the data stored in Redis for a refresh token family.
+// Each family is one login session on one device.
 type RefreshTokenData struct {
 	UserID            string    `json:"user_id"`
 	CurrentTokenHash  string    `json:"current_token_hash"`
 	PreviousTokenHash string    `json:"previous_token_hash,omitempty"`
 	ExpiresAt         time.Time `json:"expires_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	LastUsedAt        time.Time `json:"last_used_at"`
+	UserAgent         string    `json:"user_agent,omitempty"`
+	IPAddress         string    `json:"ip_address,omitempty"`
 }
 
 // RefreshTokenStore manages refresh token storage in Redis.
@@ -28,8 +32,10 @@ type RefreshTokenStore interface {
 	Create(ctx context.Context, familyID string, data *RefreshTokenData, ttl time.Duration) error
 	// Get retrieves refresh token data by family ID.
 	Get(ctx context.Context, familyID string) (*RefreshTokenData, error)
-	// Rotate updates the token hashes for rotation.
-	Rotate(ctx context.Context, familyID string, newTokenHash string, ttl time.Duration) error
+	// Rotate updates the token hashes for rotation and records the session as used from ipAddress.
+	Rotate(ctx context.Context, familyID string, newTokenHash string, ipAddress string, ttl time.Duration) error
+	// ListByUserID returns all active refresh token families for a user, keyed by family ID.
+	ListByUserID(ctx context.Context, userID string) (map[string]*RefreshTokenData, error)
 	// Delete removes a refresh token family.
 	Delete(ctx context.Context, familyID string) error
 	// DeleteAllByUserID removes all refresh token families for a user.
@@ -105,6 +111,8 @@ var rotateScript = redis.NewScript(`
 local key = KEYS[1]
 local newTokenHash = ARGV[1]
 local ttlSeconds = tonumber(ARGV[2])
+local lastUsedAt = ARGV[3]
+local ipAddress = ARGV[4]
 
 -- Get existing data
 local data = redis.call('GET', key)
@@ -119,6 +127,12 @@ local decoded = cjson.decode(data)
 decoded.previous_token_hash = decoded.current_token_hash
 decoded.current_token_hash = newTokenHash
 
+-- Record session activity
+decoded.last_used_at = lastUsedAt
+if ipAddress ~= "" then
+    decoded.ip_address = ipAddress
+end
+
 -- Encode and store with TTL
 local encoded = cjson.encode(decoded)
 redis.call('SET', key, encoded, 'EX', ttlSeconds)
@@ -126,14 +140,18 @@ redis.call('SET', key, encoded, 'EX', ttlSeconds)
 return "OK"
 `)
 
-// Rotate updates the token hashes for rotation (current becomes previous, new becomes current).
+// Rotate updates the token hashes for rotation (current becomes previous, new becomes current)
+// and updates the session's last used time and IP address.
 // This operation is atomic to prevent race conditions.
-func (s *refreshTokenStore) Rotate(ctx context.Context, familyID string, newTokenHash string, ttl time.Duration) error {
+func (s *refreshTokenStore) Rotate(ctx context.Context, familyID string, newTokenHash string, ipAddress string, ttl time.Duration) error {
+	now := time.Now().UTC()
+
 	if s.client != nil {
 		// Use atomic Lua script
 		key := refreshTokenFamilyKey(familyID)
 		ttlSeconds := int(ttl.Seconds())
-		_, err := rotateScript.Run(ctx, s.client, []string{key}, newTokenHash, ttlSeconds).Result()
+		lastUsedAt := now.Format(time.RFC3339Nano)
+		_, err := rotateScript.Run(ctx, s.client, []string{key}, newTokenHash, ttlSeconds, lastUsedAt, ipAddress).Result()
 		if err != nil {
 			if err.Error() == "refresh token family not found" {
 				return ErrRefreshTokenFamilyNotFound
@@ -144,11 +162,11 @@ func (s *refreshTokenStore) Rotate(ctx context.Context, familyID string, newToke
 	}
 
 	// Fallback for non-Redis clients (e.g., mocks in tests)
-	return s.rotateFallback(ctx, familyID, newTokenHash, ttl)
+	return s.rotateFallback(ctx, familyID, newTokenHash, ipAddress, now, ttl)
 }
 
 // rotateFallback provides non-atomic rotation for testing/mocking scenarios.
-func (s *refreshTokenStore) rotateFallback(ctx context.Context, familyID string, newTokenHash string, ttl time.Duration) error {
+func (s *refreshTokenStore) rotateFallback(ctx context.Context, familyID string, newTokenHash string, ipAddress string, now time.Time, ttl time.Duration) error {
 	data, err := s.Get(ctx, familyID)
 	if err != nil {
 		return err
@@ -159,6 +177,10 @@ func (s *refreshTokenStore) rotateFallback(ctx context.Context, familyID string,
 
 	data.PreviousTokenHash = data.CurrentTokenHash
 	data.CurrentTokenHash = newTokenHash
+	data.LastUsedAt = now
+	if ipAddress != "" {
+		data.IPAddress = ipAddress
+	}
 
 	return s.cache.Set(ctx, refreshTokenFamilyKey(familyID), data, ttl)
 }
@@ -178,6 +200,38 @@ func (s *refreshTokenStore) Delete(ctx context.Context, familyID string) error {
 	return s.cache.Delete(ctx, refreshTokenFamilyKey(familyID))
 }
 
+// ListByUserID returns all active refresh token families for a user.
+// Families that have expired are pruned from the user's index.
+func (s *refreshTokenStore) ListByUserID(ctx context.Context, userID string) (map[string]*RefreshTokenData, error) {
+	sessions := make(map[string]*RefreshTokenData)
+	if s.client == nil {
+		// Fallback: no user index for non-Redis clients (mocks)
+		return sessions, nil
+	}
+
+	userKey := userRefreshTokensKey(userID)
+
+	familyIDs, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token families: %w", err)
+	}
+
+	for _, familyID := range familyIDs {
+		data, err := s.Get(ctx, familyID)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil || data.UserID != userID {
+			// Family expired or was deleted without updating the index (best-effort cleanup)
+			_ = s.client.SRem(ctx, userKey, familyID)
+			continue
+		}
+		sessions[familyID] = data
+	}
+
+	return sessions, nil
+}
+
 // DeleteAllByUserID removes all refresh token families for a user.
 func (s *refreshTokenStore) DeleteAllByUserID(ctx context.Context, userID string) error {
 	if s.client == nil {
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRedisStore(t *testing.T) (*miniredis.Miniredis, RefreshTokenStore) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, NewRefreshTokenStore(&Redis{client: client})
+}
+
+func TestRefreshTokenStore_Rotate_Redis(t *testing.T) {
+	ctx := context.Background()
+	_, store := setupRedisStore(t)
+
+	createdAt := time.Now().Add(-time.Hour).UTC()
+	require.NoError(t, store.Create(ctx, "family123", &RefreshTokenData{
+		UserID:           "user123",
+		CurrentTokenHash: "hash1",
+		ExpiresAt:        time.Now().Add(time.Hour),
+		CreatedAt:        createdAt,
+		LastUsedAt:       createdAt,
+		UserAgent:        "test-agent/1.0",
+		IPAddress:        "198.51.100.1",
+	}, time.Hour))
+
+	t.Run("rotates hashes and records activity", func(t *testing.T) {
+		require.NoError(t, store.Rotate(ctx, "family123", "hash2", "203.0.113.7", time.Hour))
+
+		data, err := store.Get(ctx, "family123")
+		require.NoError(t, err)
+		require.NotNil(t, data)
+		assert.Equal(t, "hash2", data.CurrentTokenHash)
+		assert.Equal(t, "hash1", data.PreviousTokenHash)
+		assert.Equal(t, "203.0.113.7", data.IPAddress)
+		assert.Equal(t, "test-agent/1.0", data.UserAgent)
+		assert.True(t, data.LastUsedAt.After(createdAt))
+	})
+
+	t.Run("keeps previous ip when none is given", func(t *testing.T) {
+		require.NoError(t, store.Rotate(ctx, "family123", "hash3", "", time.Hour))
+
+		data, err := store.Get(ctx, "family123")
+		require.NoError(t, err)
+		assert.Equal(t, "203.0.113.7", data.IPAddress)
+	})
+
+	t.Run("returns error when family not found", func(t *testing.T) {
+		err := store.Rotate(ctx, "unknown", "hash", "", time.Hour)
+
+		assert.ErrorIs(t, err, ErrRefreshTokenFamilyNotFound)
+	})
+}
+
+func TestRefreshTokenStore_ListByUserID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lists active families of a user", func(t *testing.T) {
+		_, store := setupRedisStore(t)
+
+		for _, familyID := range []string{"family1", "family2"} {
+			require.NoError(t, store.Create(ctx, familyID, &RefreshTokenData{UserID: "user123"}, time.Hour))
+		}
+		require.NoError(t, store.Create(ctx, "family3", &RefreshTokenData{UserID: "other"}, time.Hour))
+
+		sessions, err := store.ListByUserID(ctx, "user123")
+
+		require.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		assert.Contains(t, sessions, "family1")
+		assert.Contains(t, sessions, "family2")
+	})
+
+	t.Run("prunes expired families from the index", func(t *testing.T) {
+		mr, store := setupRedisStore(t)
+
+		require.NoError(t, store.Create(ctx, "short", &RefreshTokenData{UserID: "user123"}, time.Minute))
+		require.NoError(t, store.Create(ctx, "long", &RefreshTokenData{UserID: "user123"}, time.Hour))
+		mr.FastForward(2 * time.Minute)
+
+		sessions, err := store.ListByUserID(ctx, "user123")
+
+		require.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.Contains(t, sessions, "long")
+
+		members, err := mr.SMembers(userRefreshTokensKey("user123"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"long"}, members)
+	})
+
+	t.Run("returns empty map for unknown user", func(t *testing.T) {
+		_, store := setupRedisStore(t)
+
+		sessions, err := store.ListByUserID(ctx, "nobody")
+
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
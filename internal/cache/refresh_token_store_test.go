@@ -176,7 +176,7 @@ func TestRefreshTokenStore_Rotate_Fallback(t *testing.T) {
 			})
 
 		store := cache.NewRefreshTokenStore(mockCache)
-		err := store.Rotate(ctx, familyID, newTokenHash, ttl)
+		err := store.Rotate(ctx, familyID, newTokenHash, "203.0.113.7", ttl)
 
 		require.NoError(t, err)
 	})
@@ -191,7 +191,7 @@ func TestRefreshTokenStore_Rotate_Fallback(t *testing.T) {
 			Return(false, nil)
 
 		store := cache.NewRefreshTokenStore(mockCache)
-		err := store.Rotate(ctx, familyID, newTokenHash, ttl)
+		err := store.Rotate(ctx, familyID, newTokenHash, "203.0.113.7", ttl)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, cache.ErrRefreshTokenFamilyNotFound)
@@ -208,7 +208,7 @@ func TestRefreshTokenStore_Rotate_Fallback(t *testing.T) {
 			Return(false, expectedErr)
 
 		store := cache.NewRefreshTokenStore(mockCache)
-		err := store.Rotate(ctx, familyID, newTokenHash, ttl)
+		err := store.Rotate(ctx, familyID, newTokenHash, "203.0.113.7", ttl)
 
 		assert.Error(t, err)
 	})
@@ -238,7 +238,7 @@ func TestRefreshTokenStore_Rotate_Fallback(t *testing.T) {
 			Return(expectedErr)
 
 		store := cache.NewRefreshTokenStore(mockCache)
-		err := store.Rotate(ctx, familyID, newTokenHash, ttl)
+		err := store.Rotate(ctx, familyID, newTokenHash, "203.0.113.7", ttl)
 
 		assert.Error(t, err)
 	})
@@ -0,0 +1,129 @@
+package cache
+
+//go:generate mockgen -destination=mocks/mock_token_revocation_store.go -package=mocks gin-sample/internal/cache TokenRevocationStore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenRevocationStore tracks revoked access tokens so they stop working before they expire.
+// Tokens can be revoked individually (by jti), per session (by refresh token family ID),
+// or for a user as a whole by bumping the user's token version.
+type TokenRevocationStore interface {
+	// TokenVersion returns the user's current access token version (0 if never revoked).
+	TokenVersion(ctx context.Context, userID string) (int64, error)
+	// RevokeUserTokens invalidates all access tokens issued to a user so far.
+	RevokeUserTokens(ctx context.Context, userID string) error
+	// RevokeSessionTokens invalidates all access tokens issued for a session.
+	// ttl should cover the lifetime of the longest-lived access token.
+	RevokeSessionTokens(ctx context.Context, sessionID string, ttl time.Duration) error
+	// RevokeToken adds a single access token ID to the denylist for ttl.
+	RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error
+	// IsRevoked reports whether an access token has been revoked.
+	// sessionID and tokenID may be empty.
+	IsRevoked(ctx context.Context, userID, sessionID, tokenID string, version int64) (bool, error)
+}
+
+type tokenRevocationStore struct {
+	client *redis.Client
+}
+
+// NewTokenRevocationStore creates a new TokenRevocationStore backed by Redis.
+func NewTokenRevocationStore(client *redis.Client) TokenRevocationStore {
+	return &tokenRevocationStore{client: client}
+}
+
+// tokenVersionKey generates a cache key for a user's access token version.
+func tokenVersionKey(userID string) string {
+	return fmt.Sprintf("token_version:%s", userID)
+}
+
+// revokedSessionKey generates a cache key for a revoked session.
+func revokedSessionKey(sessionID string) string {
+	return fmt.Sprintf("revoked_session:%s", sessionID)
+}
+
+// revokedTokenKey generates a cache key for a revoked access token.
+func revokedTokenKey(tokenID string) string {
+	return fmt.Sprintf("revoked_token:%s", tokenID)
+}
+
+// TokenVersion returns the user's current access token version.
+func (s *tokenRevocationStore) TokenVersion(ctx context.Context, userID string) (int64, error) {
+	version, err := s.client.Get(ctx, tokenVersionKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token version: %w", err)
+	}
+	return version, nil
+}
+
+// RevokeUserTokens increments the user's token version. Tokens carrying an older version are rejected.
+func (s *tokenRevocationStore) RevokeUserTokens(ctx context.Context, userID string) error {
+	if err := s.client.Incr(ctx, tokenVersionKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to increment token version: %w", err)
+	}
+	return nil
+}
+
+// RevokeSessionTokens marks a session as revoked for ttl.
+func (s *tokenRevocationStore) RevokeSessionTokens(ctx context.Context, sessionID string, ttl time.Duration) error {
+	if err := s.client.Set(ctx, revokedSessionKey(sessionID), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke session tokens: %w", err)
+	}
+	return nil
+}
+
+// RevokeToken marks a single access token as revoked for ttl.
+func (s *tokenRevocationStore) RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		// Token has already expired
+		return nil
+	}
+	if err := s.client.Set(ctx, revokedTokenKey(tokenID), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked checks the user's token version and the session and token denylists in one round trip.
+func (s *tokenRevocationStore) IsRevoked(ctx context.Context, userID, sessionID, tokenID string, version int64) (bool, error) {
+	keys := []string{tokenVersionKey(userID)}
+	if sessionID != "" {
+		keys = append(keys, revokedSessionKey(sessionID))
+	}
+	if tokenID != "" {
+		keys = append(keys, revokedTokenKey(tokenID))
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	if values[0] != nil {
+		current, err := strconv.ParseInt(values[0].(string), 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid token version: %w", err)
+		}
+		if version < current {
+			return true, nil
+		}
+	}
+
+	for _, value := range values[1:] {
+		if value != nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
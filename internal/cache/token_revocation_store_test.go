@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRevocationStore(t *testing.T) (*miniredis.Miniredis, TokenRevocationStore) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, NewTokenRevocationStore(client)
+}
+
+func TestTokenRevocationStore_UserTokens(t *testing.T) {
+	ctx := context.Background()
+	_, store := setupRevocationStore(t)
+
+	version, err := store.TokenVersion(ctx, "user123")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	revoked, err := store.IsRevoked(ctx, "user123", "", "", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked, "tokens are valid before any revocation")
+
+	require.NoError(t, store.RevokeUserTokens(ctx, "user123"))
+
+	revoked, err = store.IsRevoked(ctx, "user123", "", "", 0)
+	require.NoError(t, err)
+	assert.True(t, revoked, "tokens with an older version are revoked")
+
+	version, err = store.TokenVersion(ctx, "user123")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	revoked, err = store.IsRevoked(ctx, "user123", "", "", version)
+	require.NoError(t, err)
+	assert.False(t, revoked, "tokens issued after revocation are valid")
+
+	revoked, err = store.IsRevoked(ctx, "other", "", "", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked, "other users are not affected")
+}
+
+func TestTokenRevocationStore_SessionTokens(t *testing.T) {
+	ctx := context.Background()
+	mr, store := setupRevocationStore(t)
+
+	require.NoError(t, store.RevokeSessionTokens(ctx, "family123", 15*time.Minute))
+
+	revoked, err := store.IsRevoked(ctx, "user123", "family123", "", 0)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = store.IsRevoked(ctx, "user123", "family456", "", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	mr.FastForward(16 * time.Minute)
+
+	revoked, err = store.IsRevoked(ctx, "user123", "family123", "", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked, "denylist entries expire with the access tokens")
+}
+
+func TestTokenRevocationStore_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("revokes a single token", func(t *testing.T) {
+		_, store := setupRevocationStore(t)
+
+		require.NoError(t, store.RevokeToken(ctx, "jti-1", time.Minute))
+
+		revoked, err := store.IsRevoked(ctx, "user123", "family123", "jti-1", 0)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = store.IsRevoked(ctx, "user123", "family123", "jti-2", 0)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("ignores expired tokens", func(t *testing.T) {
+		mr, store := setupRevocationStore(t)
+
+		require.NoError(t, store.RevokeToken(ctx, "jti-1", 0))
+
+		assert.False(t, mr.Exists(revokedTokenKey("jti-1")))
+	})
+
+	t.Run("returns error when redis is unavailable", func(t *testing.T) {
+		mr, store := setupRevocationStore(t)
+		mr.Close()
+
+		_, err := store.IsRevoked(ctx, "user123", "", "jti-1", 0)
+
+		assert.Error(t, err)
+	})
+}
@@ -1,104 +1,382 @@
-// Package config handles application configuration from environment variables.
+// Package config loads application configuration from an optional YAML or TOML file,
+// overridden by environment variables, and validates it upfront.
 package config
 
-import (
-	"fmt"
-	"log"
-	"os"
-	"time"
+import "time"
 
-	"github.com/joho/godotenv"
-)
-
-// Config holds all configuration for the application
+// Config holds all configuration for the application, grouped by subsystem.
+//
+// Each setting has a file key (the yaml tags joined with dots, e.g. "storage.bucket") and
+// usually an environment variable (the env tag, e.g. S3_BUCKET; the env tag of a nested
+// struct prefixes those of its settings). Settings tagged secret are redacted when the
+// configuration is printed; settings tagged reload can change while the server runs (see Holder).
 type Config struct {
-	ServerPort         string
-	GinMode            string
-	MongoURI           string
-	MongoDatabase      string
-	RedisURI           string
-	AccessTokenSecret  string
-	AccessTokenExpiry  time.Duration
-	RefreshTokenExpiry time.Duration
-	S3Endpoint         string
-	S3AccessKey        string
-	S3SecretKey        string
-	S3Bucket           string
-	S3UseSSL           bool
-	// Service configuration
-	PresignedURLExpiry       time.Duration
-	PresignedUploadExpiry    time.Duration
-	UserCacheTTL             time.Duration
-	TranscriptionQueueSize   int
-	TranscriptionWorkerCount int
-	// Refresh token rotation
-	RefreshTokenRotation bool
-}
-
-// Load reads configuration from .env file and environment variables
-func Load() *Config {
-	// Load .env file (ignore error if file doesn't exist - env vars may be set directly)
-	_ = godotenv.Load()
-
-	cfg := &Config{
-		ServerPort:         getEnv("SERVER_PORT", "8080"),
-		GinMode:            getEnv("GIN_MODE", "debug"),
-		MongoURI:           getEnvRequired("MONGO_URI"),
-		MongoDatabase:      getEnvRequired("MONGO_DATABASE"),
-		RedisURI:           getEnv("REDIS_URI", "localhost:6379"),
-		AccessTokenSecret:  getEnvRequired("ACCESS_TOKEN_SECRET"),
-		AccessTokenExpiry:  parseDuration(getEnv("ACCESS_TOKEN_EXPIRY", "15m")),
-		RefreshTokenExpiry: parseDuration(getEnv("REFRESH_TOKEN_EXPIRY", "168h")),
-		S3Endpoint:         getEnv("S3_ENDPOINT", "localhost:9000"),
-		S3AccessKey:        getEnv("S3_ACCESS_KEY", "minioadmin"),
-		S3SecretKey:        getEnv("S3_SECRET_KEY", "minioadmin"),
-		S3Bucket:           getEnv("S3_BUCKET", "voice-memos"),
-		S3UseSSL:           getEnv("S3_USE_SSL", "false") == "true",
-		// Service configuration with sensible defaults
-		PresignedURLExpiry:       parseDuration(getEnv("PRESIGNED_URL_EXPIRY", "1h")),
-		PresignedUploadExpiry:    parseDuration(getEnv("PRESIGNED_UPLOAD_EXPIRY", "15m")),
-		UserCacheTTL:             parseDuration(getEnv("USER_CACHE_TTL", "15m")),
-		TranscriptionQueueSize:   parseInt(getEnv("TRANSCRIPTION_QUEUE_SIZE", "100")),
-		TranscriptionWorkerCount: parseInt(getEnv("TRANSCRIPTION_WORKER_COUNT", "2")),
-		// Refresh token rotation
-		RefreshTokenRotation: getEnv("REFRESH_TOKEN_ROTATION", "false") == "true",
-	}
+	Server    ServerConfig    `yaml:"server"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Mongo     MongoConfig     `yaml:"mongo"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Authz     AuthzConfig     `yaml:"authz"`
+	Storage   StorageConfig   `yaml:"storage"`
+	Cache     CacheConfig     `yaml:"cache"`
+	Queue     QueueConfig     `yaml:"queue"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Quota     QuotaConfig     `yaml:"quota"`
+	Jobs      JobsConfig      `yaml:"jobs"`
+	Log       LogConfig       `yaml:"log"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Health    HealthConfig    `yaml:"health"`
+	Reload    ReloadConfig    `yaml:"reload"`
+}
 
-	return cfg
+// ServerConfig holds the HTTP server settings.
+type ServerConfig struct {
+	Port    string `yaml:"port" env:"SERVER_PORT"`
+	GinMode string `yaml:"gin_mode" env:"GIN_MODE"`
+	// TrustedProxies are the proxies allowed to set the client IP via X-Forwarded-For.
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+	// ProblemJSONErrors sends all error responses as RFC 7807 application/problem+json.
+	// Otherwise only clients that accept application/problem+json receive them.
+	ProblemJSONErrors bool `yaml:"problem_json_errors" env:"PROBLEM_JSON_ERRORS"`
+	// ShutdownDrainDelay is how long readiness fails before the server stops accepting
+	// connections, giving load balancers time to stop routing to it.
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	// ReadHeaderTimeout bounds reading the request headers, ReadTimeout the whole request and
+	// WriteTimeout writing the response; IdleTimeout closes idle keep-alive connections.
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"`
+	// MaxBodyBytes bounds request bodies; larger requests get 413. Audio is uploaded to
+	// storage directly, so API bodies stay small.
+	MaxBodyBytes int64 `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES"`
+	// HSTSMaxAge is sent in Strict-Transport-Security; 0 disables the header.
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age" env:"HSTS_MAX_AGE"`
+	HSTSIncludeSubdomains bool          `yaml:"hsts_include_subdomains" env:"HSTS_INCLUDE_SUBDOMAINS"`
+	// HTTP2 serves HTTP/2 over TLS; H2C serves HTTP/2 without TLS, for proxies that speak it.
+	HTTP2 bool `yaml:"http2" env:"SERVER_HTTP2"`
+	H2C   bool `yaml:"h2c" env:"SERVER_H2C"`
+	// Swagger serves the API docs at /docs. Disable it in production.
+	Swagger bool      `yaml:"swagger" env:"SWAGGER_ENABLED"`
+	TLS     TLSConfig `yaml:"tls" env:"TLS_"`
 }
 
-// getEnv reads an environment variable with a fallback default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+// TLSConfig holds the settings for serving HTTPS directly. TLS is enabled when a certificate
+// is set; the certificate and key are reloaded when their files change or on SIGHUP.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file" env:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"KEY_FILE"`
+	// MinVersion is the lowest TLS version accepted: 1.2 or 1.3.
+	MinVersion string `yaml:"min_version" env:"MIN_VERSION"`
 }
 
-// getEnvRequired reads an environment variable and panics if not set
-func getEnvRequired(key string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		log.Fatalf("Required environment variable %s is not set", key)
-	}
-	return value
+// CORSConfig holds the Cross-Origin Resource Sharing policy.
+type CORSConfig struct {
+	// AllowedOrigins are exact origins ("https://app.example.com"), subdomain wildcards
+	// ("https://*.example.com") or "*" for any origin. Empty disallows cross-origin requests.
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" reload:"true"`
+	// AllowCredentials lets browsers send cookies and read responses to credentialed
+	// requests. It requires explicit origins rather than "*".
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" reload:"true"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS" reload:"true"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" reload:"true"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS" reload:"true"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" reload:"true"`
+	// Routes override the policy for paths starting with a prefix; the longest prefix wins.
+	// They can only be set in the config file.
+	Routes []CORSRouteConfig `yaml:"routes" reload:"true"`
 }
 
-// parseDuration parses a duration string, panics on error
-func parseDuration(s string) time.Duration {
-	d, err := time.ParseDuration(s)
-	if err != nil {
-		log.Fatalf("Invalid duration format: %s", s)
-	}
-	return d
+// CORSRouteConfig overrides the CORS policy for one path prefix. Origins and credentials
+// replace the global ones; methods and headers are inherited unless set.
+type CORSRouteConfig struct {
+	PathPrefix       string   `yaml:"path_prefix"`
+	AllowedOrigins   []string `yaml:"allowed_origins"`
+	AllowCredentials bool     `yaml:"allow_credentials"`
+	AllowedMethods   []string `yaml:"allowed_methods"`
+	AllowedHeaders   []string `yaml:"allowed_headers"`
+	ExposedHeaders   []string `yaml:"exposed_headers"`
+}
+
+// MongoConfig holds the MongoDB connection settings.
+type MongoConfig struct {
+	URI      string `yaml:"uri" env:"MONGO_URI" secret:"true"`
+	Database string `yaml:"database" env:"MONGO_DATABASE"`
+}
+
+// RedisConfig holds the Redis connection settings.
+type RedisConfig struct {
+	URI string `yaml:"uri" env:"REDIS_URI" secret:"true"`
+}
+
+// AuthConfig holds the authentication settings.
+type AuthConfig struct {
+	// AccessTokenSecret signs HS256 access tokens. When SigningKeyFile is set it is optional
+	// and only used to accept HS256 tokens issued before the switch.
+	AccessTokenSecret    string        `yaml:"access_token_secret" env:"ACCESS_TOKEN_SECRET" secret:"true"`
+	AccessTokenExpiry    time.Duration `yaml:"access_token_expiry" env:"ACCESS_TOKEN_EXPIRY"`
+	RefreshTokenExpiry   time.Duration `yaml:"refresh_token_expiry" env:"REFRESH_TOKEN_EXPIRY"`
+	RefreshTokenRotation bool          `yaml:"refresh_token_rotation" env:"REFRESH_TOKEN_ROTATION"`
+	// RevocationFailOpen accepts access tokens when the revocation store cannot be reached,
+	// instead of rejecting them. Revoked tokens then stay usable until they expire.
+	RevocationFailOpen bool `yaml:"revocation_fail_open" env:"TOKEN_REVOCATION_FAIL_OPEN"`
+	// Asymmetric access token signing (RS256/ES256/EdDSA)
+	SigningKeyFile string `yaml:"signing_key_file" env:"JWT_SIGNING_KEY_FILE"`
+	SigningKeyID   string `yaml:"signing_key_id" env:"JWT_SIGNING_KEY_ID"`
+	// VerificationKeyFiles are additional public keys accepted during rotation, as "path" or "kid=path"
+	VerificationKeyFiles []string `yaml:"verification_key_files" env:"JWT_VERIFICATION_KEY_FILES"`
+	// Multi-factor authentication
+	MFAIssuer          string        `yaml:"mfa_issuer" env:"MFA_ISSUER"`
+	MFAChallengeExpiry time.Duration `yaml:"mfa_challenge_expiry" env:"MFA_CHALLENGE_EXPIRY"`
+	// OpenID Connect login providers. OIDC_PROVIDERS lists the provider names, each configured
+	// from OIDC_<NAME>_* variables; it replaces the providers listed in the file.
+	OIDCProviders   []OIDCProviderConfig `yaml:"oidc_providers"`
+	OIDCStateExpiry time.Duration        `yaml:"oidc_state_expiry" env:"OIDC_STATE_EXPIRY"`
+	// Lockout is the progressive login lockout
+	Lockout LockoutConfig `yaml:"lockout" env:"LOGIN_LOCKOUT_"`
+}
+
+// OIDCProviderConfig holds the settings for one login provider.
+// Environment variables are prefixed with OIDC_<NAME>_, with the name upper-cased and
+// dashes replaced by underscores.
+type OIDCProviderConfig struct {
+	Name string `yaml:"name"`
+	// Type is "oidc" (the default when empty) or "github" (see OIDCProviderType constants)
+	Type        string `yaml:"type" env:"TYPE"`
+	DisplayName string `yaml:"display_name" env:"DISPLAY_NAME"`
+	// IssuerURL is the OpenID Connect issuer. For github providers it is optional and
+	// sets the GitHub Enterprise Server URL.
+	IssuerURL    string   `yaml:"issuer" env:"ISSUER"`
+	ClientID     string   `yaml:"client_id" env:"CLIENT_ID"`
+	ClientSecret string   `yaml:"client_secret" env:"CLIENT_SECRET" secret:"true"`
+	RedirectURL  string   `yaml:"redirect_url" env:"REDIRECT_URL"`
+	Scopes       []string `yaml:"scopes" env:"SCOPES"`
+	// TrustEmail links logins to existing accounts with the same verified email. Off by default:
+	// any provider can claim any email, so enable it only for providers that own their users'
+	// email domains.
+	TrustEmail bool `yaml:"trust_email" env:"TRUST_EMAIL"`
+}
+
+// LockoutConfig holds the progressive login lockout settings. A zero threshold disables it.
+type LockoutConfig struct {
+	Threshold     int           `yaml:"threshold" env:"THRESHOLD"`
+	Duration      time.Duration `yaml:"duration" env:"DURATION"`
+	MaxDuration   time.Duration `yaml:"max_duration" env:"MAX_DURATION"`
+	FailureWindow time.Duration `yaml:"failure_window" env:"FAILURE_WINDOW"`
+}
+
+// AuthzConfig holds the authorization settings.
+type AuthzConfig struct {
+	// Mode is the authorization backend: "local", "dual" or "relationship" (see AuthzMode constants)
+	Mode string `yaml:"mode" env:"AUTHZ_MODE"`
+}
+
+// StorageConfig holds the S3-compatible object storage settings.
+type StorageConfig struct {
+	Endpoint              string        `yaml:"endpoint" env:"S3_ENDPOINT"`
+	AccessKey             string        `yaml:"access_key" env:"S3_ACCESS_KEY" secret:"true"`
+	SecretKey             string        `yaml:"secret_key" env:"S3_SECRET_KEY" secret:"true"`
+	Bucket                string        `yaml:"bucket" env:"S3_BUCKET"`
+	UseSSL                bool          `yaml:"use_ssl" env:"S3_USE_SSL"`
+	PresignedURLExpiry    time.Duration `yaml:"presigned_url_expiry" env:"PRESIGNED_URL_EXPIRY" reload:"true"`
+	PresignedUploadExpiry time.Duration `yaml:"presigned_upload_expiry" env:"PRESIGNED_UPLOAD_EXPIRY" reload:"true"`
 }
 
-// parseInt parses an integer string, panics on error
-func parseInt(s string) int {
-	var i int
-	_, err := fmt.Sscanf(s, "%d", &i)
-	if err != nil {
-		log.Fatalf("Invalid integer format: %s", s)
+// CacheConfig holds the cache TTLs.
+type CacheConfig struct {
+	UserTTL       time.Duration `yaml:"user_ttl" env:"USER_CACHE_TTL"`
+	TeamMemberTTL time.Duration `yaml:"team_member_ttl" env:"TEAM_MEMBER_CACHE_TTL"`
+}
+
+// QueueConfig holds the transcription queue settings.
+type QueueConfig struct {
+	Size        int `yaml:"size" env:"TRANSCRIPTION_QUEUE_SIZE"`
+	WorkerCount int `yaml:"worker_count" env:"TRANSCRIPTION_WORKER_COUNT" reload:"true"`
+}
+
+// RateLimitConfig holds the request limits, in requests per Window. A zero limit is not enforced.
+type RateLimitConfig struct {
+	Enabled         bool          `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	Window          time.Duration `yaml:"window" env:"RATE_LIMIT_WINDOW" reload:"true"`
+	LoginPerIP      int           `yaml:"login_per_ip" env:"RATE_LIMIT_LOGIN_PER_IP" reload:"true"`
+	LoginPerAccount int           `yaml:"login_per_account" env:"RATE_LIMIT_LOGIN_PER_ACCOUNT" reload:"true"`
+	RegisterPerIP   int           `yaml:"register_per_ip" env:"RATE_LIMIT_REGISTER_PER_IP" reload:"true"`
+	RefreshPerIP    int           `yaml:"refresh_per_ip" env:"RATE_LIMIT_REFRESH_PER_IP" reload:"true"`
+	// Voice memo creation
+	MemoCreatePerUser int `yaml:"memo_create_per_user" env:"RATE_LIMIT_MEMO_CREATE_PER_USER" reload:"true"`
+	MemoCreatePerTeam int `yaml:"memo_create_per_team" env:"RATE_LIMIT_MEMO_CREATE_PER_TEAM" reload:"true"`
+}
+
+// QuotaConfig holds the voice memo quotas of users and teams.
+type QuotaConfig struct {
+	User UsageQuotaConfig `yaml:"user" env:"QUOTA_USER_"`
+	Team UsageQuotaConfig `yaml:"team" env:"QUOTA_TEAM_"`
+}
+
+// UsageQuotaConfig holds the limits of one owner (0 = unlimited). Transcription minutes
+// are per calendar month.
+type UsageQuotaConfig struct {
+	MaxMemos             int64 `yaml:"max_memos" env:"MAX_MEMOS" reload:"true"`
+	MaxAudioBytes        int64 `yaml:"max_audio_bytes" env:"MAX_AUDIO_BYTES" reload:"true"`
+	TranscriptionMinutes int64 `yaml:"transcription_minutes" env:"TRANSCRIPTION_MINUTES" reload:"true"`
+}
+
+// JobsConfig holds the scheduled job settings.
+type JobsConfig struct {
+	InvitationCleanupInterval time.Duration `yaml:"invitation_cleanup_interval" env:"INVITATION_CLEANUP_INTERVAL"`
+}
+
+// LogConfig holds the logging settings.
+type LogConfig struct {
+	// Format is "json" or "text"
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+	// Level is "debug", "info", "warn" or "error"
+	Level string `yaml:"level" env:"LOG_LEVEL" reload:"true"`
+}
+
+// MetricsConfig holds the Prometheus metrics settings.
+type MetricsConfig struct {
+	// Enabled serves metrics at /metrics and instruments requests, the transcription queue
+	// and calls to MongoDB, Redis and S3
+	Enabled bool `yaml:"enabled" env:"METRICS_ENABLED"`
+}
+
+// TracingConfig holds the OpenTelemetry tracing settings. Spans are exported over OTLP/HTTP.
+type TracingConfig struct {
+	Enabled      bool   `yaml:"enabled" env:"TRACING_ENABLED"`
+	ServiceName  string `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool   `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+	// SampleRatio is the fraction of new traces recorded; requests carrying a caller's
+	// trace follow its decision
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// HealthConfig holds the health check settings.
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+	// QueueSaturation (0-1] is how full the transcription queue may get before readiness fails
+	QueueSaturation float64 `yaml:"queue_saturation" env:"HEALTH_QUEUE_SATURATION"`
+}
+
+// ReloadConfig holds the settings for reloading the configuration while the server runs.
+type ReloadConfig struct {
+	// WatchInterval is how often the config file is checked for changes; 0 disables
+	// watching, leaving SIGHUP to trigger reloads
+	WatchInterval time.Duration `yaml:"watch_interval" env:"CONFIG_WATCH_INTERVAL"`
+}
+
+// Authorization modes.
+const (
+	// AuthzModeLocal authorizes from team membership records.
+	AuthzModeLocal = "local"
+	// AuthzModeDual authorizes from team membership records and logs where relationships disagree.
+	AuthzModeDual = "dual"
+	// AuthzModeRelationship authorizes from relationships only.
+	AuthzModeRelationship = "relationship"
+)
+
+// Login provider types.
+const (
+	// OIDCProviderTypeOIDC is an OpenID Connect provider that supports discovery.
+	OIDCProviderTypeOIDC = "oidc"
+	// OIDCProviderTypeGitHub is GitHub (or GitHub Enterprise Server), which only supports OAuth2.
+	OIDCProviderTypeGitHub = "github"
+)
+
+// Default returns the configuration used for settings that are neither in the file nor
+// in the environment.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:               "8080",
+			GinMode:            "debug",
+			ShutdownDrainDelay: 5 * time.Second,
+			ReadHeaderTimeout:  10 * time.Second,
+			ReadTimeout:        30 * time.Second,
+			WriteTimeout:       60 * time.Second,
+			IdleTimeout:        120 * time.Second,
+			MaxHeaderBytes:     1 << 20,
+			MaxBodyBytes:       1 << 20,
+			HSTSMaxAge:         365 * 24 * time.Hour,
+			HTTP2:              true,
+			Swagger:            true,
+			TLS: TLSConfig{
+				MinVersion: "1.2",
+			},
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "If-Match", "If-None-Match", "X-Request-ID", "traceparent", "tracestate"},
+			ExposedHeaders: []string{"ETag", "Location", "Retry-After", "X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining"},
+			MaxAge:         24 * time.Hour,
+		},
+		Redis: RedisConfig{URI: "localhost:6379"},
+		Auth: AuthConfig{
+			AccessTokenExpiry:  15 * time.Minute,
+			RefreshTokenExpiry: 168 * time.Hour,
+			MFAIssuer:          "gin-sample",
+			MFAChallengeExpiry: 5 * time.Minute,
+			OIDCStateExpiry:    10 * time.Minute,
+			Lockout: LockoutConfig{
+				Threshold:     5,
+				Duration:      time.Minute,
+				MaxDuration:   time.Hour,
+				FailureWindow: 15 * time.Minute,
+			},
+		},
+		Authz: AuthzConfig{Mode: AuthzModeLocal},
+		Storage: StorageConfig{
+			Endpoint:              "localhost:9000",
+			AccessKey:             "minioadmin",
+			SecretKey:             "minioadmin",
+			Bucket:                "voice-memos",
+			PresignedURLExpiry:    time.Hour,
+			PresignedUploadExpiry: 15 * time.Minute,
+		},
+		Cache: CacheConfig{
+			UserTTL:       15 * time.Minute,
+			TeamMemberTTL: time.Minute,
+		},
+		Queue: QueueConfig{
+			Size:        100,
+			WorkerCount: 2,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           true,
+			Window:            time.Minute,
+			LoginPerIP:        20,
+			LoginPerAccount:   10,
+			RegisterPerIP:     5,
+			RefreshPerIP:      30,
+			MemoCreatePerUser: 30,
+			MemoCreatePerTeam: 100,
+		},
+		Quota: QuotaConfig{
+			User: UsageQuotaConfig{MaxMemos: 1000, MaxAudioBytes: 1 << 30, TranscriptionMinutes: 300},
+			Team: UsageQuotaConfig{MaxMemos: 10000, MaxAudioBytes: 10 << 30, TranscriptionMinutes: 3000},
+		},
+		Jobs: JobsConfig{InvitationCleanupInterval: time.Hour},
+		Log: LogConfig{
+			Format: "json",
+			Level:  "info",
+		},
+		Metrics: MetricsConfig{Enabled: true},
+		Tracing: TracingConfig{
+			ServiceName:  "gin-sample",
+			OTLPEndpoint: "localhost:4318",
+			SampleRatio:  1,
+		},
+		Health: HealthConfig{
+			CheckTimeout:    2 * time.Second,
+			QueueSaturation: 0.9,
+		},
+		Reload: ReloadConfig{
+			WatchInterval: 10 * time.Second,
+		},
 	}
-	return i
 }
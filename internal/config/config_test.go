@@ -1,6 +1,10 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -8,76 +12,37 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestGetEnv(t *testing.T) {
-	t.Run("returns environment variable value when set", func(t *testing.T) {
-		t.Setenv("TEST_CONFIG_VAR", "custom_value")
-
-		result := getEnv("TEST_CONFIG_VAR", "default_value")
-
-		assert.Equal(t, "custom_value", result)
-	})
-
-	t.Run("returns default value when env var not set", func(t *testing.T) {
-		result := getEnv("NONEXISTENT_CONFIG_VAR_12345", "default_value")
-
-		assert.Equal(t, "default_value", result)
-	})
-
-	t.Run("returns default value when env var is empty string", func(t *testing.T) {
-		t.Setenv("EMPTY_CONFIG_VAR", "")
-
-		result := getEnv("EMPTY_CONFIG_VAR", "default_value")
-
-		assert.Equal(t, "default_value", result)
-	})
+// setRequiredEnv sets the settings that have no default.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+	t.Setenv("MONGO_DATABASE", "testdb")
+	t.Setenv("ACCESS_TOKEN_SECRET", "test-secret-key")
 }
 
-func TestParseDuration(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected time.Duration
-	}{
-		{"minutes", "15m", 15 * time.Minute},
-		{"hours", "168h", 168 * time.Hour},
-		{"seconds", "30s", 30 * time.Second},
-		{"combined", "1h30m", 90 * time.Minute},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseDuration(tt.input)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
+// writeFile writes a config file into a temporary directory and returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
 }
 
-func TestParseInt(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected int
-	}{
-		{"positive integer", "100", 100},
-		{"zero", "0", 0},
-		{"negative integer", "-5", -5},
-		{"large number", "1000000", 1000000},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseInt(tt.input)
-			assert.Equal(t, tt.expected, result)
-		})
+// problems returns the messages of the individual errors in a load error.
+func problems(t *testing.T, err error) []string {
+	t.Helper()
+	var errs Errors
+	require.True(t, errors.As(err, &errs), "expected config.Errors, got %v", err)
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Error())
 	}
+	return messages
 }
 
 func TestLoad(t *testing.T) {
 	t.Run("loads config with all required env vars", func(t *testing.T) {
-		// Set required env vars
-		t.Setenv("MONGO_URI", "mongodb://localhost:27017")
-		t.Setenv("MONGO_DATABASE", "testdb")
-		t.Setenv("ACCESS_TOKEN_SECRET", "test-secret-key")
+		setRequiredEnv(t)
 
 		// Set optional env vars to test custom values
 		t.Setenv("SERVER_PORT", "3000")
@@ -90,60 +55,446 @@ func TestLoad(t *testing.T) {
 		t.Setenv("S3_SECRET_KEY", "mysecretkey")
 		t.Setenv("S3_BUCKET", "my-bucket")
 		t.Setenv("S3_USE_SSL", "true")
+		t.Setenv("QUOTA_TEAM_MAX_MEMOS", "42")
+		t.Setenv("LOGIN_LOCKOUT_THRESHOLD", "3")
 
-		cfg := Load()
-
-		require.NotNil(t, cfg)
+		cfg, err := Load("")
+		require.NoError(t, err)
 
 		// Required fields
-		assert.Equal(t, "mongodb://localhost:27017", cfg.MongoURI)
-		assert.Equal(t, "testdb", cfg.MongoDatabase)
-		assert.Equal(t, "test-secret-key", cfg.AccessTokenSecret)
+		assert.Equal(t, "mongodb://localhost:27017", cfg.Mongo.URI)
+		assert.Equal(t, "testdb", cfg.Mongo.Database)
+		assert.Equal(t, "test-secret-key", cfg.Auth.AccessTokenSecret)
 
 		// Optional fields with custom values
-		assert.Equal(t, "3000", cfg.ServerPort)
-		assert.Equal(t, "release", cfg.GinMode)
-		assert.Equal(t, "redis.example.com:6379", cfg.RedisURI)
-		assert.Equal(t, 30*time.Minute, cfg.AccessTokenExpiry)
-		assert.Equal(t, 720*time.Hour, cfg.RefreshTokenExpiry)
-		assert.Equal(t, "s3.example.com:9000", cfg.S3Endpoint)
-		assert.Equal(t, "myaccesskey", cfg.S3AccessKey)
-		assert.Equal(t, "mysecretkey", cfg.S3SecretKey)
-		assert.Equal(t, "my-bucket", cfg.S3Bucket)
-		assert.True(t, cfg.S3UseSSL)
+		assert.Equal(t, "3000", cfg.Server.Port)
+		assert.Equal(t, "release", cfg.Server.GinMode)
+		assert.Equal(t, "redis.example.com:6379", cfg.Redis.URI)
+		assert.Equal(t, 30*time.Minute, cfg.Auth.AccessTokenExpiry)
+		assert.Equal(t, 720*time.Hour, cfg.Auth.RefreshTokenExpiry)
+		assert.Equal(t, "s3.example.com:9000", cfg.Storage.Endpoint)
+		assert.Equal(t, "myaccesskey", cfg.Storage.AccessKey)
+		assert.Equal(t, "mysecretkey", cfg.Storage.SecretKey)
+		assert.Equal(t, "my-bucket", cfg.Storage.Bucket)
+		assert.True(t, cfg.Storage.UseSSL)
+		assert.Equal(t, int64(42), cfg.Quota.Team.MaxMemos)
+		assert.Equal(t, int64(1000), cfg.Quota.User.MaxMemos)
+		assert.Equal(t, 3, cfg.Auth.Lockout.Threshold)
 	})
 
 	t.Run("uses default values for optional env vars", func(t *testing.T) {
-		// Only set required env vars
-		t.Setenv("MONGO_URI", "mongodb://localhost:27017")
-		t.Setenv("MONGO_DATABASE", "testdb")
-		t.Setenv("ACCESS_TOKEN_SECRET", "test-secret-key")
-
-		cfg := Load()
+		setRequiredEnv(t)
 
-		require.NotNil(t, cfg)
+		cfg, err := Load("")
+		require.NoError(t, err)
 
 		// Check default values
-		assert.Equal(t, "8080", cfg.ServerPort)
-		assert.Equal(t, "debug", cfg.GinMode)
-		assert.Equal(t, "localhost:6379", cfg.RedisURI)
-		assert.Equal(t, 15*time.Minute, cfg.AccessTokenExpiry)
-		assert.Equal(t, 168*time.Hour, cfg.RefreshTokenExpiry)
-		assert.Equal(t, "localhost:9000", cfg.S3Endpoint)
-		assert.Equal(t, "minioadmin", cfg.S3AccessKey)
-		assert.Equal(t, "minioadmin", cfg.S3SecretKey)
-		assert.Equal(t, "voice-memos", cfg.S3Bucket)
-		assert.False(t, cfg.S3UseSSL)
-	})
-
-	t.Run("S3UseSSL is false for non-true values", func(t *testing.T) {
-		t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+		assert.Equal(t, "8080", cfg.Server.Port)
+		assert.Equal(t, "debug", cfg.Server.GinMode)
+		assert.Equal(t, "localhost:6379", cfg.Redis.URI)
+		assert.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenExpiry)
+		assert.Equal(t, 168*time.Hour, cfg.Auth.RefreshTokenExpiry)
+		assert.Equal(t, "localhost:9000", cfg.Storage.Endpoint)
+		assert.Equal(t, "minioadmin", cfg.Storage.AccessKey)
+		assert.Equal(t, "minioadmin", cfg.Storage.SecretKey)
+		assert.Equal(t, "voice-memos", cfg.Storage.Bucket)
+		assert.False(t, cfg.Storage.UseSSL)
+		assert.Equal(t, time.Hour, cfg.Jobs.InvitationCleanupInterval)
+		assert.Equal(t, "gin-sample", cfg.Auth.MFAIssuer)
+		assert.Equal(t, 5*time.Minute, cfg.Auth.MFAChallengeExpiry)
+		assert.True(t, cfg.RateLimit.Enabled)
+		assert.Equal(t, time.Minute, cfg.RateLimit.Window)
+		assert.Equal(t, 20, cfg.RateLimit.LoginPerIP)
+		assert.Equal(t, 10, cfg.RateLimit.LoginPerAccount)
+		assert.Equal(t, 5, cfg.RateLimit.RegisterPerIP)
+		assert.Equal(t, 30, cfg.RateLimit.RefreshPerIP)
+		assert.Nil(t, cfg.Server.TrustedProxies)
+		assert.Equal(t, 30, cfg.RateLimit.MemoCreatePerUser)
+		assert.Equal(t, 100, cfg.RateLimit.MemoCreatePerTeam)
+		assert.Equal(t, int64(1000), cfg.Quota.User.MaxMemos)
+		assert.Equal(t, int64(1<<30), cfg.Quota.User.MaxAudioBytes)
+		assert.Equal(t, int64(300), cfg.Quota.User.TranscriptionMinutes)
+		assert.Equal(t, int64(10000), cfg.Quota.Team.MaxMemos)
+		assert.Equal(t, int64(10<<30), cfg.Quota.Team.MaxAudioBytes)
+		assert.Equal(t, int64(3000), cfg.Quota.Team.TranscriptionMinutes)
+		assert.Equal(t, 5, cfg.Auth.Lockout.Threshold)
+		assert.Equal(t, time.Minute, cfg.Auth.Lockout.Duration)
+		assert.Equal(t, time.Hour, cfg.Auth.Lockout.MaxDuration)
+		assert.Equal(t, 15*time.Minute, cfg.Auth.Lockout.FailureWindow)
+		assert.Equal(t, AuthzModeLocal, cfg.Authz.Mode)
+		assert.False(t, cfg.Tracing.Enabled)
+		assert.Equal(t, "gin-sample", cfg.Tracing.ServiceName)
+		assert.Equal(t, "localhost:4318", cfg.Tracing.OTLPEndpoint)
+		assert.False(t, cfg.Tracing.OTLPInsecure)
+		assert.Equal(t, 1.0, cfg.Tracing.SampleRatio)
+		assert.Equal(t, 2*time.Second, cfg.Health.CheckTimeout)
+		assert.Equal(t, 0.9, cfg.Health.QueueSaturation)
+		assert.Equal(t, 5*time.Second, cfg.Server.ShutdownDrainDelay)
+		assert.Equal(t, 10*time.Second, cfg.Reload.WatchInterval)
+	})
+
+	t.Run("S3UseSSL is false for false values", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("S3_USE_SSL", "false")
+
+		cfg, err := Load("")
+		require.NoError(t, err)
+
+		assert.False(t, cfg.Storage.UseSSL)
+	})
+
+	t.Run("parses trusted proxies list", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16,")
+
+		cfg, err := Load("")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"10.0.0.1", "192.168.0.0/16"}, cfg.Server.TrustedProxies)
+	})
+
+	t.Run("loads asymmetric signing config without secret", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("ACCESS_TOKEN_SECRET", "")
+		t.Setenv("JWT_SIGNING_KEY_FILE", "/etc/keys/current.pem")
+		t.Setenv("JWT_SIGNING_KEY_ID", "2026-10")
+		t.Setenv("JWT_VERIFICATION_KEY_FILES", "2026-04=/etc/keys/previous.pem, /etc/keys/next.pem")
+
+		cfg, err := Load("")
+		require.NoError(t, err)
+
+		assert.Empty(t, cfg.Auth.AccessTokenSecret)
+		assert.Equal(t, "/etc/keys/current.pem", cfg.Auth.SigningKeyFile)
+		assert.Equal(t, "2026-10", cfg.Auth.SigningKeyID)
+		assert.Equal(t, []string{"2026-04=/etc/keys/previous.pem", "/etc/keys/next.pem"}, cfg.Auth.VerificationKeyFiles)
+	})
+
+	t.Run("loads oidc providers", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("OIDC_PROVIDERS", "google, acme-sso, github")
+		t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+		t.Setenv("OIDC_GOOGLE_CLIENT_ID", "google-client")
+		t.Setenv("OIDC_GOOGLE_CLIENT_SECRET", "google-secret")
+		t.Setenv("OIDC_GOOGLE_REDIRECT_URL", "https://app.example.com/auth/callback/google")
+		t.Setenv("OIDC_GOOGLE_DISPLAY_NAME", "Google")
+		t.Setenv("OIDC_ACME_SSO_ISSUER", "https://sso.acme.com")
+		t.Setenv("OIDC_ACME_SSO_CLIENT_ID", "acme-client")
+		t.Setenv("OIDC_ACME_SSO_REDIRECT_URL", "https://app.example.com/auth/callback/acme-sso")
+		t.Setenv("OIDC_ACME_SSO_SCOPES", "email,profile,groups")
+		t.Setenv("OIDC_ACME_SSO_TRUST_EMAIL", "true")
+		t.Setenv("OIDC_GITHUB_TYPE", "github")
+		t.Setenv("OIDC_GITHUB_CLIENT_ID", "github-client")
+		t.Setenv("OIDC_GITHUB_REDIRECT_URL", "https://app.example.com/auth/callback/github")
+
+		cfg, err := Load("")
+		require.NoError(t, err)
+
+		require.Len(t, cfg.Auth.OIDCProviders, 3)
+		assert.Equal(t, OIDCProviderConfig{
+			Name:         "google",
+			DisplayName:  "Google",
+			IssuerURL:    "https://accounts.google.com",
+			ClientID:     "google-client",
+			ClientSecret: "google-secret",
+			RedirectURL:  "https://app.example.com/auth/callback/google",
+		}, cfg.Auth.OIDCProviders[0])
+		assert.Equal(t, "acme-sso", cfg.Auth.OIDCProviders[1].Name)
+		assert.Equal(t, "acme-sso", cfg.Auth.OIDCProviders[1].DisplayName)
+		assert.Equal(t, []string{"email", "profile", "groups"}, cfg.Auth.OIDCProviders[1].Scopes)
+		assert.True(t, cfg.Auth.OIDCProviders[1].TrustEmail)
+		assert.Equal(t, OIDCProviderTypeGitHub, cfg.Auth.OIDCProviders[2].Type)
+		assert.Empty(t, cfg.Auth.OIDCProviders[2].IssuerURL)
+		assert.Equal(t, 10*time.Minute, cfg.Auth.OIDCStateExpiry)
+	})
+
+	t.Run("reports every invalid setting at once", func(t *testing.T) {
+		t.Setenv("MONGO_URI", "")
 		t.Setenv("MONGO_DATABASE", "testdb")
 		t.Setenv("ACCESS_TOKEN_SECRET", "test-secret-key")
-		t.Setenv("S3_USE_SSL", "false")
+		t.Setenv("ACCESS_TOKEN_EXPIRY", "15")
+		t.Setenv("TRANSCRIPTION_WORKER_COUNT", "two")
+		t.Setenv("S3_USE_SSL", "yes please")
+		t.Setenv("AUTHZ_MODE", "remote")
+
+		_, err := Load("")
+
+		require.Error(t, err)
+		assert.ElementsMatch(t, []string{
+			`auth.access_token_expiry (ACCESS_TOKEN_EXPIRY): invalid duration "15" (use e.g. 30s, 15m, 1h)`,
+			`queue.worker_count (TRANSCRIPTION_WORKER_COUNT): invalid integer "two"`,
+			`storage.use_ssl (S3_USE_SSL): invalid boolean "yes please" (use true or false)`,
+			`mongo.uri (MONGO_URI): is required`,
+			`authz.mode (AUTHZ_MODE): must be one of [local dual relationship], got "remote"`,
+		}, problems(t, err))
+		assert.Contains(t, err.Error(), "invalid configuration:\n  - ")
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	const yamlConfig = `
+server:
+  port: 9090
+  trusted_proxies: [10.0.0.1, 10.0.0.2]
+mongo:
+  uri: mongodb://db:27017
+  database: app
+auth:
+  access_token_secret: file-secret
+  lockout:
+    threshold: 0
+  oidc_providers:
+    - name: google
+      issuer: https://accounts.google.com
+      client_id: file-client
+      redirect_url: https://app.example.com/callback
+      scopes: [email]
+storage:
+  presigned_url_expiry: 30m
+queue:
+  worker_count: 4
+tracing:
+  sample_ratio: 0.25
+`
+
+	t.Run("loads a YAML file", func(t *testing.T) {
+		cfg, err := Load(writeFile(t, "config.yaml", yamlConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "9090", cfg.Server.Port)
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, cfg.Server.TrustedProxies)
+		assert.Equal(t, "mongodb://db:27017", cfg.Mongo.URI)
+		assert.Equal(t, "file-secret", cfg.Auth.AccessTokenSecret)
+		assert.Equal(t, 0, cfg.Auth.Lockout.Threshold)
+		assert.Equal(t, 30*time.Minute, cfg.Storage.PresignedURLExpiry)
+		assert.Equal(t, 4, cfg.Queue.WorkerCount)
+		assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+		assert.Equal(t, 15*time.Minute, cfg.Storage.PresignedUploadExpiry, "unset settings keep their default")
+		require.Len(t, cfg.Auth.OIDCProviders, 1)
+		assert.Equal(t, "google", cfg.Auth.OIDCProviders[0].DisplayName)
+		assert.Equal(t, []string{"email"}, cfg.Auth.OIDCProviders[0].Scopes)
+	})
+
+	t.Run("environment variables override the file", func(t *testing.T) {
+		t.Setenv("SERVER_PORT", "7070")
+		t.Setenv("QUOTA_USER_MAX_MEMOS", "5")
+		t.Setenv("OIDC_GOOGLE_CLIENT_ID", "env-client")
+
+		cfg, err := Load(writeFile(t, "config.yaml", yamlConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "7070", cfg.Server.Port)
+		assert.Equal(t, int64(5), cfg.Quota.User.MaxMemos)
+		assert.Equal(t, "env-client", cfg.Auth.OIDCProviders[0].ClientID)
+		assert.Equal(t, "https://accounts.google.com", cfg.Auth.OIDCProviders[0].IssuerURL)
+	})
+
+	t.Run("uses CONFIG_FILE when no path is given", func(t *testing.T) {
+		t.Setenv(FileEnv, writeFile(t, "config.yaml", yamlConfig))
+
+		cfg, err := Load("")
+		require.NoError(t, err)
+
+		assert.Equal(t, "app", cfg.Mongo.Database)
+	})
+
+	t.Run("loads a TOML file", func(t *testing.T) {
+		cfg, err := Load(writeFile(t, "config.toml", `
+[mongo]
+uri = "mongodb://db:27017"
+database = "app"
+
+[auth]
+access_token_secret = "file-secret"
+
+[cache]
+user_ttl = "5m"
+
+[rate_limit]
+enabled = false
+login_per_ip = 50
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "app", cfg.Mongo.Database)
+		assert.Equal(t, 5*time.Minute, cfg.Cache.UserTTL)
+		assert.False(t, cfg.RateLimit.Enabled)
+		assert.Equal(t, 50, cfg.RateLimit.LoginPerIP)
+	})
+
+	t.Run("reports unknown and invalid settings", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+mongo:
+  uri: mongodb://db:27017
+  database: app
+  databse: typo
+auth:
+  access_token_secret: file-secret
+cache:
+  user_ttl: 5
+server:
+  trusted_proxies: 10.0.0.1
+  port: [1, 2]
+`)
+
+		_, err := Load(path)
+
+		require.Error(t, err)
+		assert.ElementsMatch(t, []string{
+			`cache.user_ttl: invalid duration "5" (use e.g. 30s, 15m, 1h)`,
+			`server.port: expected a single value, got a list`,
+			"mongo.databse: unknown setting in " + path,
+		}, problems(t, err))
+	})
+
+	t.Run("loads cors routes", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com, https://*.example.com")
+		t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+		cfg, err := Load(writeFile(t, "config.yaml", `
+cors:
+  routes:
+    - path_prefix: /.well-known/
+      allowed_origins: ["*"]
+      allowed_methods: [GET]
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"https://app.example.com", "https://*.example.com"}, cfg.CORS.AllowedOrigins)
+		assert.True(t, cfg.CORS.AllowCredentials)
+		assert.Equal(t, []CORSRouteConfig{{
+			PathPrefix:     "/.well-known/",
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET"},
+		}}, cfg.CORS.Routes)
+	})
 
-		cfg := Load()
+	t.Run("reports unknown cors route settings", func(t *testing.T) {
+		setRequiredEnv(t)
 
-		assert.False(t, cfg.S3UseSSL)
+		_, err := Load(writeFile(t, "config.yaml", `
+cors:
+  routes:
+    - path_prefix: /public/
+      origins: ["*"]
+`))
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"cors.routes[0].origins: unknown setting"}, problems(t, err))
+	})
+
+	t.Run("rejects unsupported formats", func(t *testing.T) {
+		_, err := Load(writeFile(t, "config.json", `{}`))
+		assert.ErrorContains(t, err, "unsupported format")
 	})
+
+	t.Run("reports a missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.ErrorContains(t, err, "read config file")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := Default()
+		cfg.Mongo.URI = "mongodb://localhost:27017"
+		cfg.Mongo.Database = "app"
+		cfg.Auth.AccessTokenSecret = "secret"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		problem string
+	}{
+		{"valid", func(*Config) {}, ""},
+		{"port out of range", func(c *Config) { c.Server.Port = "70000" }, `server.port (SERVER_PORT): must be a port number, got "70000"`},
+		{"non-positive expiry", func(c *Config) { c.Storage.PresignedURLExpiry = 0 }, "storage.presigned_url_expiry (PRESIGNED_URL_EXPIRY): must be positive, got 0s"},
+		{"negative quota", func(c *Config) { c.Quota.Team.MaxMemos = -1 }, "quota.team.max_memos (QUOTA_TEAM_MAX_MEMOS): must not be negative, got -1"},
+		{"no workers", func(c *Config) { c.Queue.WorkerCount = 0 }, "queue.worker_count (TRANSCRIPTION_WORKER_COUNT): must be at least 1, got 0"},
+		{"lockout max below base", func(c *Config) { c.Auth.Lockout.MaxDuration = time.Second }, "auth.lockout.max_duration (LOGIN_LOCKOUT_MAX_DURATION): must be at least auth.lockout.duration (1m0s), got 1s"},
+		{"sample ratio", func(c *Config) { c.Tracing.SampleRatio = 2 }, "tracing.sample_ratio (TRACING_SAMPLE_RATIO): must be between 0 and 1, got 2"},
+		{"log level", func(c *Config) { c.Log.Level = "verbose" }, `log.level (LOG_LEVEL): must be one of [debug info warn error], got "verbose"`},
+		{"no body limit", func(c *Config) { c.Server.MaxBodyBytes = 0 }, "server.max_body_bytes (SERVER_MAX_BODY_BYTES): must be positive, got 0"},
+		{"tls key without certificate", func(c *Config) { c.Server.TLS.KeyFile = "/etc/tls/tls.key" }, "server.tls: cert_file and key_file must be set together"},
+		{"tls version", func(c *Config) { c.Server.TLS.MinVersion = "1.0" }, `server.tls.min_version (TLS_MIN_VERSION): must be one of [1.2 1.3], got "1.0"`},
+		{"incomplete oidc provider", func(c *Config) {
+			c.Auth.OIDCProviders = []OIDCProviderConfig{{Name: "google", IssuerURL: "https://accounts.google.com", ClientID: "id"}}
+		}, "auth.oidc_providers[google].redirect_url: is required"},
+		{"oidc provider without issuer", func(c *Config) {
+			c.Auth.OIDCProviders = []OIDCProviderConfig{{Name: "acme", ClientID: "id", RedirectURL: "https://app.example.com/callback"}}
+		}, "auth.oidc_providers[acme].issuer: is required"},
+		{"unknown oidc provider type", func(c *Config) {
+			c.Auth.OIDCProviders = []OIDCProviderConfig{{Name: "gitlab", Type: "gitlab", ClientID: "id", RedirectURL: "https://app.example.com/callback", IssuerURL: "https://gitlab.com"}}
+		}, `auth.oidc_providers[gitlab].type: must be one of [oidc github], got "gitlab"`},
+		{"cors credentials with any origin", func(c *Config) { c.CORS.AllowCredentials = true },
+			`cors.allowed_origins (CORS_ALLOWED_ORIGINS): cannot contain "*" when credentials are allowed; list the origins`},
+		{"cors invalid origin", func(c *Config) { c.CORS.AllowedOrigins = []string{"app.example.com"} },
+			`cors.allowed_origins (CORS_ALLOWED_ORIGINS): invalid origin "app.example.com" (use e.g. https://app.example.com or https://*.example.com)`},
+		{"cors origin with path", func(c *Config) { c.CORS.AllowedOrigins = []string{"https://app.example.com/"} },
+			`cors.allowed_origins (CORS_ALLOWED_ORIGINS): invalid origin "https://app.example.com/" (use e.g. https://app.example.com or https://*.example.com)`},
+		{"cors wildcard subdomain", func(c *Config) {
+			c.CORS.AllowedOrigins = []string{"https://*.example.com", "http://localhost:3000"}
+			c.CORS.AllowCredentials = true
+		}, ""},
+		{"cors route without path prefix", func(c *Config) {
+			c.CORS.Routes = []CORSRouteConfig{{PathPrefix: "public", AllowedOrigins: []string{"*"}}}
+		}, `cors.routes[0].path_prefix: must start with /, got "public"`},
+		{"secret optional with signing key", func(c *Config) {
+			c.Auth.AccessTokenSecret = ""
+			c.Auth.SigningKeyFile = "/etc/keys/current.pem"
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+
+			if tt.problem == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, []string{tt.problem}, problems(t, err))
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Mongo.URI = "mongodb://user:pass@db:27017"
+	cfg.Redis.URI = "redis://:pass@cache:6379/0"
+	cfg.Auth.AccessTokenSecret = "secret"
+	cfg.Auth.OIDCProviders = []OIDCProviderConfig{{Name: "google", ClientSecret: "client-secret"}}
+
+	redactedCfg := cfg.Redacted()
+
+	assert.Equal(t, "[REDACTED]", redactedCfg.Mongo.URI)
+	assert.Equal(t, "[REDACTED]", redactedCfg.Redis.URI)
+	assert.Equal(t, "[REDACTED]", redactedCfg.Auth.AccessTokenSecret)
+	assert.Equal(t, "[REDACTED]", redactedCfg.Storage.SecretKey)
+	assert.Equal(t, "[REDACTED]", redactedCfg.Auth.OIDCProviders[0].ClientSecret)
+	assert.Equal(t, "voice-memos", redactedCfg.Storage.Bucket)
+	assert.Equal(t, "secret", cfg.Auth.AccessTokenSecret, "the original is unchanged")
+	assert.Equal(t, "client-secret", cfg.Auth.OIDCProviders[0].ClientSecret, "the original is unchanged")
+}
+
+func TestWriteYAML(t *testing.T) {
+	cfg := Default()
+	cfg.Mongo.URI = "mongodb://db:27017"
+	cfg.Mongo.Database = "app"
+	cfg.Auth.AccessTokenSecret = "secret"
+	cfg.Server.TrustedProxies = []string{"10.0.0.1"}
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.WriteYAML(&buf))
+	assert.Contains(t, buf.String(), "presigned_url_expiry: 1h0m0s")
+
+	// The output is a valid config file
+	loaded, err := Load(writeFile(t, "config.yaml", buf.String()))
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
 }
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Holder holds the running configuration and applies reloads. Code that should pick up
+// reloaded settings reads them through Get on every use, or registers with OnReload,
+// instead of keeping the values. Only settings tagged reload change; a reload reports the
+// other changed settings as requiring a restart and keeps their running values.
+type Holder struct {
+	path    string
+	current atomic.Pointer[Config]
+
+	mu        sync.Mutex // serializes reloads and guards listeners
+	listeners []func(*Config)
+}
+
+// ReloadResult lists, by file key, the settings a reload found changed.
+type ReloadResult struct {
+	// Applied settings took effect.
+	Applied []string
+	// RequiresRestart settings keep their running value until the server restarts.
+	RequiresRestart []string
+}
+
+// NewHolder creates a holder for cfg, reloading from the config file at path (or
+// $CONFIG_FILE when path is empty).
+func NewHolder(cfg *Config, path string) *Holder {
+	h := &Holder{path: path}
+	h.current.Store(cfg)
+	return h
+}
+
+// Get returns the running configuration. It must not be modified.
+func (h *Holder) Get() *Config {
+	return h.current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after each reload that
+// applied a setting. Listeners are called in order, one reload at a time.
+func (h *Holder) OnReload(fn func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners = append(h.listeners, fn)
+}
+
+// Reload loads the configuration again and applies its reloadable settings. An invalid
+// configuration is rejected as a whole, leaving the running one unchanged.
+func (h *Holder) Reload() (ReloadResult, error) {
+	next, err := Load(h.path)
+	if err != nil {
+		return ReloadResult{}, err
+	}
+	return h.Update(next), nil
+}
+
+// Update applies the reloadable settings of next.
+func (h *Holder) Update(next *Config) ReloadResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	updated := *h.Get()
+	var result ReloadResult
+	merge(reflect.ValueOf(&updated).Elem(), reflect.ValueOf(next).Elem(), "", &result)
+	if len(result.Applied) == 0 {
+		return result
+	}
+
+	h.current.Store(&updated)
+	for _, fn := range h.listeners {
+		fn(&updated)
+	}
+	return result
+}
+
+// merge copies the changed reloadable settings of next into dst, recording every changed
+// setting in result. keyPrefix is prepended to the field's yaml tag.
+func merge(dst, next reflect.Value, keyPrefix string, result *ReloadResult) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		key := keyPrefix + field.Tag.Get("yaml")
+
+		if field.Type.Kind() == reflect.Struct {
+			merge(dst.Field(i), next.Field(i), key+".", result)
+			continue
+		}
+		if reflect.DeepEqual(dst.Field(i).Interface(), next.Field(i).Interface()) {
+			continue
+		}
+		if field.Tag.Get("reload") != "true" {
+			result.RequiresRestart = append(result.RequiresRestart, key)
+			continue
+		}
+		dst.Field(i).Set(next.Field(i))
+		result.Applied = append(result.Applied, key)
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolder_Update(t *testing.T) {
+	t.Run("applies reloadable settings and reports the others", func(t *testing.T) {
+		holder := NewHolder(Default(), "")
+		var notified []*Config
+		holder.OnReload(func(cfg *Config) { notified = append(notified, cfg) })
+
+		next := Default()
+		next.Queue.WorkerCount = 8
+		next.Quota.Team.MaxMemos = 50
+		next.Server.Port = "9090"
+		next.Auth.OIDCProviders = []OIDCProviderConfig{{Name: "google"}}
+
+		result := holder.Update(next)
+
+		assert.Equal(t, []string{"queue.worker_count", "quota.team.max_memos"}, result.Applied)
+		assert.Equal(t, []string{"server.port", "auth.oidc_providers"}, result.RequiresRestart)
+
+		current := holder.Get()
+		assert.Equal(t, 8, current.Queue.WorkerCount)
+		assert.Equal(t, int64(50), current.Quota.Team.MaxMemos)
+		assert.Equal(t, "8080", current.Server.Port, "settings requiring a restart keep their running value")
+		assert.Empty(t, current.Auth.OIDCProviders)
+		require.Len(t, notified, 1)
+		assert.Same(t, current, notified[0])
+	})
+
+	t.Run("keeps the configuration when nothing reloadable changed", func(t *testing.T) {
+		initial := Default()
+		holder := NewHolder(initial, "")
+		holder.OnReload(func(*Config) { t.Error("listener called without applied settings") })
+
+		next := Default()
+		next.Log.Format = "text"
+
+		result := holder.Update(next)
+
+		assert.Empty(t, result.Applied)
+		assert.Equal(t, []string{"log.format"}, result.RequiresRestart)
+		assert.Same(t, initial, holder.Get())
+	})
+
+	t.Run("does not modify the previous configuration", func(t *testing.T) {
+		initial := Default()
+		holder := NewHolder(initial, "")
+
+		next := Default()
+		next.Storage.PresignedURLExpiry = time.Minute
+		holder.Update(next)
+
+		assert.Equal(t, time.Hour, initial.Storage.PresignedURLExpiry)
+		assert.Equal(t, time.Minute, holder.Get().Storage.PresignedURLExpiry)
+	})
+}
+
+func TestHolder_Reload(t *testing.T) {
+	setRequiredEnv(t)
+	path := writeFile(t, "config.yaml", "queue:\n  worker_count: 2\n")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	holder := NewHolder(cfg, path)
+
+	t.Run("applies changes from the file", func(t *testing.T) {
+		require.NoError(t, writeConfig(path, "queue:\n  worker_count: 6\nrate_limit:\n  login_per_ip: 3\n"))
+
+		result, err := holder.Reload()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"queue.worker_count", "rate_limit.login_per_ip"}, result.Applied)
+		assert.Equal(t, 6, holder.Get().Queue.WorkerCount)
+		assert.Equal(t, 3, holder.Get().RateLimit.LoginPerIP)
+	})
+
+	t.Run("rejects an invalid file and keeps the running configuration", func(t *testing.T) {
+		require.NoError(t, writeConfig(path, "queue:\n  worker_count: 0\n"))
+
+		_, err := holder.Reload()
+
+		assert.ErrorContains(t, err, "queue.worker_count")
+		assert.Equal(t, 6, holder.Get().Queue.WorkerCount)
+	})
+}
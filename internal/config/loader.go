@@ -0,0 +1,323 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv is the environment variable naming the config file when none is given explicitly.
+const FileEnv = "CONFIG_FILE"
+
+// oidcProvidersKey is the file key of the OIDC provider list, which is loaded separately.
+const oidcProvidersKey = "auth.oidc_providers"
+
+// Errors lists every problem found while loading the configuration.
+type Errors []error
+
+func (e Errors) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid configuration:")
+	for _, err := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the individual problems.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// Load reads the configuration. Settings are taken from environment variables (including a
+// .env file), then from the YAML or TOML file at path (or $CONFIG_FILE when path is empty),
+// then from Default. Every invalid, unknown or missing setting is reported in one Errors.
+func Load(path string) (*Config, error) {
+	// Load .env file (ignore error if file doesn't exist - env vars may be set directly)
+	_ = godotenv.Load()
+
+	path = FilePath(path)
+	values := map[string]any{}
+	if path != "" {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		flatten(file, "", values)
+	}
+
+	cfg := Default()
+	var errs Errors
+	apply(reflect.ValueOf(cfg).Elem(), "", "", values, &errs)
+	cfg.Auth.OIDCProviders = loadOIDCProviders(values[oidcProvidersKey], &errs)
+	delete(values, oidcProvidersKey)
+
+	reportUnknown(values, " in "+path, &errs)
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err.(Errors)...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return cfg, nil
+}
+
+// reportUnknown reports the file values no setting used, in key order.
+func reportUnknown(values map[string]any, suffix string, errs *Errors) {
+	unknown := make([]string, 0, len(values))
+	for key := range values {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		*errs = append(*errs, fmt.Errorf("%s: unknown setting%s", key, suffix))
+	}
+}
+
+// FilePath returns path, or $CONFIG_FILE when path is empty.
+func FilePath(path string) string {
+	if path == "" {
+		return os.Getenv(FileEnv)
+	}
+	return path
+}
+
+// readFile decodes a YAML (.yaml, .yml) or TOML (.toml) file into nested maps.
+func readFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	file := map[string]any{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".toml":
+		err = toml.Unmarshal(data, &file)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format %q (use .yaml, .yml or .toml)", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// flatten stores the leaves of nested maps in values under dotted keys, e.g. "storage.bucket".
+// Lists are leaves.
+func flatten(m map[string]any, prefix string, values map[string]any) {
+	for key, value := range m {
+		if nested, ok := value.(map[string]any); ok {
+			flatten(nested, prefix+key+".", values)
+			continue
+		}
+		values[prefix+key] = value
+	}
+}
+
+// apply sets every setting of the struct v from its environment variable, falling back to
+// the file values and leaving the default otherwise. keyPrefix and envPrefix are prepended
+// to the field's yaml and env tags. Used file values are removed from values.
+func apply(v reflect.Value, keyPrefix, envPrefix string, values map[string]any, errs *Errors) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := keyPrefix + field.Tag.Get("yaml")
+		env := field.Tag.Get("env")
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			apply(v.Field(i), key+".", envPrefix+env, values, errs)
+			continue
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			// OIDC providers are loaded separately, since they are also set from the environment
+			if key != oidcProvidersKey {
+				loadList(v.Field(i), key, values, errs)
+			}
+			continue
+		}
+
+		fileValue, inFile := values[key]
+		delete(values, key)
+
+		if env != "" {
+			if envValue := os.Getenv(envPrefix + env); envValue != "" {
+				if err := setValue(v.Field(i), envValue); err != nil {
+					*errs = append(*errs, fmt.Errorf("%s (%s): %w", key, envPrefix+env, err))
+				}
+				continue
+			}
+		}
+		if inFile {
+			if err := setValue(v.Field(i), fileValue); err != nil {
+				*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+			}
+		}
+	}
+}
+
+// loadList sets a list of settings groups, such as "cors.routes", from the file.
+func loadList(field reflect.Value, key string, values map[string]any, errs *Errors) {
+	fileValue, inFile := values[key]
+	if !inFile {
+		return
+	}
+	delete(values, key)
+
+	list, ok := fileValue.([]any)
+	if !ok {
+		*errs = append(*errs, fmt.Errorf("%s: expected a list", key))
+		return
+	}
+	if len(list) == 0 {
+		field.Set(reflect.Zero(field.Type()))
+		return
+	}
+	items := reflect.MakeSlice(field.Type(), len(list), len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s[%d]: expected a group of settings", key, i))
+			continue
+		}
+		keyPrefix := fmt.Sprintf("%s[%d].", key, i)
+		itemValues := map[string]any{}
+		flatten(m, keyPrefix, itemValues)
+		apply(items.Index(i), keyPrefix, "", itemValues, errs)
+		reportUnknown(itemValues, "", errs)
+	}
+	field.Set(items)
+}
+
+// loadOIDCProviders loads the provider list from the file, replaced by the names in
+// OIDC_PROVIDERS when set, and applies each provider's OIDC_<NAME>_* variables.
+func loadOIDCProviders(fileValue any, errs *Errors) []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	fileProviders := map[string]map[string]any{}
+	if fileValue != nil {
+		list, ok := fileValue.([]any)
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s: expected a list of providers", oidcProvidersKey))
+			return nil
+		}
+		for i, item := range list {
+			m, ok := item.(map[string]any)
+			if !ok {
+				*errs = append(*errs, fmt.Errorf("%s[%d]: expected a provider", oidcProvidersKey, i))
+				continue
+			}
+			name, _ := m["name"].(string)
+			if name == "" {
+				*errs = append(*errs, fmt.Errorf("%s[%d].name: is required", oidcProvidersKey, i))
+				continue
+			}
+			fileProviders[name] = m
+			providers = append(providers, OIDCProviderConfig{Name: name})
+		}
+	}
+
+	if names := os.Getenv("OIDC_PROVIDERS"); names != "" {
+		providers = providers[:0]
+		for _, name := range parseList(names) {
+			providers = append(providers, OIDCProviderConfig{Name: name})
+		}
+	}
+
+	for i := range providers {
+		keyPrefix := fmt.Sprintf("%s[%s].", oidcProvidersKey, providers[i].Name)
+		values := map[string]any{}
+		flatten(fileProviders[providers[i].Name], keyPrefix, values)
+		delete(values, keyPrefix+"name")
+		envPrefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(providers[i].Name, "-", "_")) + "_"
+		apply(reflect.ValueOf(&providers[i]).Elem(), keyPrefix, envPrefix, values, errs)
+		if providers[i].DisplayName == "" {
+			providers[i].DisplayName = providers[i].Name
+		}
+		reportUnknown(values, "", errs)
+	}
+	return providers
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses raw, a string from the environment or a decoded file value, into field.
+func setValue(field reflect.Value, raw any) error {
+	if field.Kind() == reflect.Slice {
+		field.Set(reflect.ValueOf(toList(raw)))
+		return nil
+	}
+	if _, ok := raw.([]any); ok {
+		return errors.New("expected a single value, got a list")
+	}
+
+	s := strings.TrimSpace(fmt.Sprint(raw))
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use e.g. 30s, 15m, 1h)", s)
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(s)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q (use true or false)", s)
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Int, field.Kind() == reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", s)
+		}
+		field.SetInt(i)
+	case field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", s)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+	return nil
+}
+
+// toList converts a comma-separated string or a file list into a list of strings.
+func toList(raw any) []string {
+	list, ok := raw.([]any)
+	if !ok {
+		return parseList(fmt.Sprint(raw))
+	}
+	var items []string
+	for _, item := range list {
+		if s := strings.TrimSpace(fmt.Sprint(item)); s != "" {
+			items = append(items, s)
+		}
+	}
+	return items
+}
+
+// parseList splits a comma-separated string, dropping empty entries
+func parseList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
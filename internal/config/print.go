@@ -0,0 +1,47 @@
+package config
+
+import (
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the value of secret settings when printing.
+const redacted = "[REDACTED]"
+
+// Redacted returns a copy of the configuration with every secret that is set replaced by
+// "[REDACTED]", so it can be printed or logged.
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Auth.OIDCProviders = append([]OIDCProviderConfig(nil), c.Auth.OIDCProviders...)
+	redact(reflect.ValueOf(&out).Elem())
+	return &out
+}
+
+func redact(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			redact(value)
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			for j := 0; j < value.Len(); j++ {
+				redact(value.Index(j))
+			}
+		case field.Tag.Get("secret") == "true" && value.String() != "":
+			value.SetString(redacted)
+		}
+	}
+}
+
+// WriteYAML writes the configuration in the config file format. Write a Redacted copy
+// unless the output is meant to contain secrets.
+func (c *Config) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
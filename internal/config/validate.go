@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envNames maps the file key of each setting to its environment variable.
+var envNames = collectEnvNames(reflect.TypeOf(Config{}), "", "", map[string]string{})
+
+func collectEnvNames(t reflect.Type, keyPrefix, envPrefix string, names map[string]string) map[string]string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, env := keyPrefix+field.Tag.Get("yaml"), field.Tag.Get("env")
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			collectEnvNames(field.Type, key+".", envPrefix+env, names)
+		case env != "":
+			names[key] = envPrefix + env
+		}
+	}
+	return names
+}
+
+// validator collects every problem with a configuration.
+type validator struct {
+	errs Errors
+}
+
+// addf records a problem with the setting at key, naming its environment variable too.
+func (v *validator) addf(key, format string, args ...any) {
+	if env := envNames[key]; env != "" {
+		key += " (" + env + ")"
+	}
+	v.errs = append(v.errs, fmt.Errorf("%s: "+format, append([]any{key}, args...)...))
+}
+
+func (v *validator) required(key, value string) {
+	if value == "" {
+		v.addf(key, "is required")
+	}
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	if d <= 0 {
+		v.addf(key, "must be positive, got %s", d)
+	}
+}
+
+func (v *validator) nonNegative(key string, n int64) {
+	if n < 0 {
+		v.addf(key, "must not be negative, got %d", n)
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.addf(key, "must be one of %v, got %q", allowed, value)
+	}
+}
+
+func (v *validator) between(key string, f, min, max float64) {
+	if f < min || f > max {
+		v.addf(key, "must be between %v and %v, got %v", min, max, f)
+	}
+}
+
+func (v *validator) quota(key string, q UsageQuotaConfig) {
+	v.nonNegative(key+".max_memos", q.MaxMemos)
+	v.nonNegative(key+".max_audio_bytes", q.MaxAudioBytes)
+	v.nonNegative(key+".transcription_minutes", q.TranscriptionMinutes)
+}
+
+// corsOrigins checks that each origin is "*", or a scheme and host with an optional port,
+// where the host may start with "*." to match subdomains.
+func (v *validator) corsOrigins(key string, origins []string, allowCredentials bool) {
+	for _, origin := range origins {
+		if origin == "*" {
+			if allowCredentials {
+				v.addf(key, `cannot contain "*" when credentials are allowed; list the origins`)
+			}
+			continue
+		}
+		u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.User != nil || strings.Contains(u.Host, "*") {
+			v.addf(key, "invalid origin %q (use e.g. https://app.example.com or https://*.example.com)", origin)
+		}
+	}
+}
+
+// Validate checks every setting and returns an Errors listing all problems, or nil.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		v.addf("server.port", "must be a port number, got %q", c.Server.Port)
+	}
+	v.oneOf("server.gin_mode", c.Server.GinMode, "debug", "release", "test")
+	v.nonNegative("server.shutdown_drain_delay", int64(c.Server.ShutdownDrainDelay))
+	v.positive("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	v.nonNegative("server.read_timeout", int64(c.Server.ReadTimeout))
+	v.nonNegative("server.write_timeout", int64(c.Server.WriteTimeout))
+	v.nonNegative("server.idle_timeout", int64(c.Server.IdleTimeout))
+	if c.Server.MaxHeaderBytes <= 0 {
+		v.addf("server.max_header_bytes", "must be positive, got %d", c.Server.MaxHeaderBytes)
+	}
+	if c.Server.MaxBodyBytes <= 0 {
+		v.addf("server.max_body_bytes", "must be positive, got %d", c.Server.MaxBodyBytes)
+	}
+	v.nonNegative("server.hsts_max_age", int64(c.Server.HSTSMaxAge))
+	if (c.Server.TLS.CertFile == "") != (c.Server.TLS.KeyFile == "") {
+		v.addf("server.tls", "cert_file and key_file must be set together")
+	}
+	v.oneOf("server.tls.min_version", c.Server.TLS.MinVersion, "1.2", "1.3")
+
+	v.corsOrigins("cors.allowed_origins", c.CORS.AllowedOrigins, c.CORS.AllowCredentials)
+	v.nonNegative("cors.max_age", int64(c.CORS.MaxAge))
+	for i, route := range c.CORS.Routes {
+		key := fmt.Sprintf("cors.routes[%d]", i)
+		if !strings.HasPrefix(route.PathPrefix, "/") {
+			v.addf(key+".path_prefix", "must start with /, got %q", route.PathPrefix)
+		}
+		v.corsOrigins(key+".allowed_origins", route.AllowedOrigins, route.AllowCredentials)
+	}
+
+	v.required("mongo.uri", c.Mongo.URI)
+	v.required("mongo.database", c.Mongo.Database)
+	v.required("redis.uri", c.Redis.URI)
+
+	// The HS256 secret is only required when no asymmetric signing key is configured
+	if c.Auth.SigningKeyFile == "" {
+		v.required("auth.access_token_secret", c.Auth.AccessTokenSecret)
+	}
+	v.positive("auth.access_token_expiry", c.Auth.AccessTokenExpiry)
+	v.positive("auth.refresh_token_expiry", c.Auth.RefreshTokenExpiry)
+	v.required("auth.mfa_issuer", c.Auth.MFAIssuer)
+	v.positive("auth.mfa_challenge_expiry", c.Auth.MFAChallengeExpiry)
+	v.positive("auth.oidc_state_expiry", c.Auth.OIDCStateExpiry)
+	names := map[string]bool{}
+	for _, p := range c.Auth.OIDCProviders {
+		key := fmt.Sprintf("auth.oidc_providers[%s]", p.Name)
+		if names[p.Name] {
+			v.addf(key, "is listed more than once")
+		}
+		names[p.Name] = true
+		if p.Type != "" {
+			v.oneOf(key+".type", p.Type, OIDCProviderTypeOIDC, OIDCProviderTypeGitHub)
+		}
+		// GitHub has no issuer; its endpoints default to github.com
+		if p.Type != OIDCProviderTypeGitHub {
+			v.required(key+".issuer", p.IssuerURL)
+		}
+		v.required(key+".client_id", p.ClientID)
+		v.required(key+".redirect_url", p.RedirectURL)
+	}
+	v.nonNegative("auth.lockout.threshold", int64(c.Auth.Lockout.Threshold))
+	if c.Auth.Lockout.Threshold > 0 {
+		v.positive("auth.lockout.duration", c.Auth.Lockout.Duration)
+		v.positive("auth.lockout.failure_window", c.Auth.Lockout.FailureWindow)
+		if c.Auth.Lockout.MaxDuration < c.Auth.Lockout.Duration {
+			v.addf("auth.lockout.max_duration", "must be at least auth.lockout.duration (%s), got %s", c.Auth.Lockout.Duration, c.Auth.Lockout.MaxDuration)
+		}
+	}
+
+	v.oneOf("authz.mode", c.Authz.Mode, AuthzModeLocal, AuthzModeDual, AuthzModeRelationship)
+
+	v.required("storage.endpoint", c.Storage.Endpoint)
+	v.required("storage.bucket", c.Storage.Bucket)
+	v.positive("storage.presigned_url_expiry", c.Storage.PresignedURLExpiry)
+	v.positive("storage.presigned_upload_expiry", c.Storage.PresignedUploadExpiry)
+
+	v.positive("cache.user_ttl", c.Cache.UserTTL)
+	v.positive("cache.team_member_ttl", c.Cache.TeamMemberTTL)
+
+	if c.Queue.Size < 1 {
+		v.addf("queue.size", "must be at least 1, got %d", c.Queue.Size)
+	}
+	if c.Queue.WorkerCount < 1 {
+		v.addf("queue.worker_count", "must be at least 1, got %d", c.Queue.WorkerCount)
+	}
+
+	if c.RateLimit.Enabled {
+		v.positive("rate_limit.window", c.RateLimit.Window)
+	}
+	v.nonNegative("rate_limit.login_per_ip", int64(c.RateLimit.LoginPerIP))
+	v.nonNegative("rate_limit.login_per_account", int64(c.RateLimit.LoginPerAccount))
+	v.nonNegative("rate_limit.register_per_ip", int64(c.RateLimit.RegisterPerIP))
+	v.nonNegative("rate_limit.refresh_per_ip", int64(c.RateLimit.RefreshPerIP))
+	v.nonNegative("rate_limit.memo_create_per_user", int64(c.RateLimit.MemoCreatePerUser))
+	v.nonNegative("rate_limit.memo_create_per_team", int64(c.RateLimit.MemoCreatePerTeam))
+
+	v.quota("quota.user", c.Quota.User)
+	v.quota("quota.team", c.Quota.Team)
+
+	v.positive("jobs.invitation_cleanup_interval", c.Jobs.InvitationCleanupInterval)
+
+	v.oneOf("log.format", c.Log.Format, "json", "text")
+	v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+
+	if c.Tracing.Enabled {
+		v.required("tracing.service_name", c.Tracing.ServiceName)
+		v.required("tracing.otlp_endpoint", c.Tracing.OTLPEndpoint)
+	}
+	v.between("tracing.sample_ratio", c.Tracing.SampleRatio, 0, 1)
+
+	v.positive("health.check_timeout", c.Health.CheckTimeout)
+	if c.Health.QueueSaturation <= 0 || c.Health.QueueSaturation > 1 {
+		v.addf("health.queue_saturation", "must be greater than 0 and at most 1, got %v", c.Health.QueueSaturation)
+	}
+
+	v.nonNegative("reload.watch_interval", int64(c.Reload.WatchInterval))
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// WatchFile calls onChange each time the file at path is modified, checking every interval
+// until ctx is done. It compares the modification time and size rather than relying on
+// filesystem notifications, so replaced files and mounted volumes are picked up too.
+func WatchFile(ctx context.Context, path string, interval time.Duration, onChange func()) {
+	last, _ := os.Stat(path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			// Editors may briefly remove the file while saving; wait for it to come back
+			continue
+		}
+		if last == nil || !info.ModTime().Equal(last.ModTime()) || info.Size() != last.Size() {
+			last = info
+			onChange()
+		}
+	}
+}
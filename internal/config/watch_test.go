@@ -0,0 +1,55 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig rewrites a config file, moving its modification time forward so the change
+// is seen even on filesystems with coarse timestamps.
+func writeConfig(path, content string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return err
+	}
+	modTime := info.ModTime().Add(time.Second)
+	return os.Chtimes(path, modTime, modTime)
+}
+
+func TestWatchFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", "queue:\n  worker_count: 2\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var changes atomic.Int32
+	go func() {
+		WatchFile(ctx, path, 5*time.Millisecond, func() { changes.Add(1) })
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), changes.Load(), "an unchanged file is not reported")
+
+	require.NoError(t, writeConfig(path, "queue:\n  worker_count: 4\n"))
+	assert.Eventually(t, func() bool { return changes.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, os.Remove(path))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), changes.Load(), "a missing file is not reported")
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("WatchFile did not return after cancel")
+	}
+}
@@ -3,11 +3,15 @@ package database
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"time"
 
+	"gin-sample/internal/logger"
+	"gin-sample/internal/tracing"
+
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // MongoDB holds the database connection
@@ -23,21 +27,21 @@ func NewMongoDB(uri, dbName string) *MongoDB {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel() // Always call cancel to release resources
 
-	// Configure client options
-	clientOptions := options.Client().ApplyURI(uri)
+	// Configure client options; the monitor records a span for every command
+	clientOptions := options.Client().ApplyURI(uri).SetMonitor(tracing.NewMongoMonitor())
 
 	// Connect to MongoDB
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatalf("Failed to connect to MongoDB: %v", err)
+		logger.Fatal("failed to connect to MongoDB", "error", err)
 	}
 
 	// Ping to verify connection
 	if err := client.Ping(ctx, nil); err != nil {
-		log.Fatalf("Failed to ping MongoDB: %v", err)
+		logger.Fatal("failed to ping MongoDB", "error", err)
 	}
 
-	log.Printf("Connected to MongoDB: %s", dbName)
+	slog.Info("connected to MongoDB", "database", dbName)
 
 	return &MongoDB{
 		Client:   client,
@@ -51,9 +55,14 @@ func (m *MongoDB) Close() {
 	defer cancel()
 
 	if err := m.Client.Disconnect(ctx); err != nil {
-		log.Printf("Error disconnecting from MongoDB: %v", err)
+		slog.Error("failed to disconnect from MongoDB", "error", err)
 	}
-	log.Println("Disconnected from MongoDB")
+	slog.Info("disconnected from MongoDB")
+}
+
+// Ping checks that the primary is reachable.
+func (m *MongoDB) Ping(ctx context.Context) error {
+	return m.Client.Ping(ctx, readpref.Primary())
 }
 
 // Collection returns a collection from the database
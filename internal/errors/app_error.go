@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Stable error codes for errors that are not tied to a sentinel.
+const (
+	CodeBadRequest       = "bad_request"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeInternal         = "internal_error"
+)
+
+// AppError is an error with a stable machine-readable code and the HTTP status it maps to.
+// The sentinel errors in this package are AppErrors, so they can be compared with errors.Is
+// and rendered by the error middleware without per-handler mapping.
+type AppError struct {
+	// Status is the HTTP status code the error maps to.
+	Status int
+	// Code is a stable identifier clients can match on, e.g. "team_not_found".
+	Code string
+	// Message is a human-readable description, safe to show to clients.
+	Message string
+	// Details holds additional machine-readable information about the error.
+	Details map[string]any
+	// Fields lists the invalid request fields of a validation error.
+	Fields []FieldError
+	// Err is the underlying cause, if any. It is never shown to clients.
+	Err error
+}
+
+// FieldError describes why a single request field is invalid.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "name" or "members[0].email".
+	Field string `json:"field"`
+	// Rule is the validation rule that failed, e.g. "required" or "slug".
+	Rule string `json:"rule"`
+	// Param is the rule's parameter, e.g. "100" for "max=100".
+	Param string `json:"param,omitempty"`
+	// Message is a human-readable, localized description.
+	Message string `json:"message"`
+}
+
+// New creates an AppError with the given status, code and message.
+func New(status int, code, message string) *AppError {
+	return &AppError{Status: status, Code: code, Message: message}
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// WithMessage returns a copy of e with a different message. The copy wraps e,
+// so it still matches e with errors.Is.
+func (e *AppError) WithMessage(message string) *AppError {
+	wrapped := *e
+	wrapped.Message = message
+	wrapped.Err = e
+	return &wrapped
+}
+
+// WithStatus returns a copy of e with a different HTTP status, for errors whose status
+// depends on context. The copy wraps e, so it still matches e with errors.Is.
+func (e *AppError) WithStatus(status int) *AppError {
+	wrapped := *e
+	wrapped.Status = status
+	wrapped.Err = e
+	return &wrapped
+}
+
+// WithDetails returns a copy of e with the given details. The copy wraps e,
+// so it still matches e with errors.Is.
+func (e *AppError) WithDetails(details map[string]any) *AppError {
+	wrapped := *e
+	wrapped.Details = details
+	wrapped.Err = e
+	return &wrapped
+}
+
+// BadRequest creates a 400 error for a malformed request.
+func BadRequest(message string) *AppError {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// Validation creates a 400 error listing the invalid request fields.
+func Validation(fields ...FieldError) *AppError {
+	err := New(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+	err.Fields = fields
+	return err
+}
+
+// Unauthorized creates a 401 error.
+func Unauthorized(message string) *AppError {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+// Forbidden creates a 403 error.
+func Forbidden(message string) *AppError {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+// NotFound creates a 404 error.
+func NotFound(message string) *AppError {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Internal wraps an unexpected error as a 500 error. The cause is kept for logging
+// but never shown to clients.
+func Internal(err error) *AppError {
+	return &AppError{Status: http.StatusInternalServerError, Code: CodeInternal, Message: "internal server error", Err: err}
+}
+
+// From returns the AppError for err. Errors that are not AppErrors are treated as internal errors.
+func From(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal(err)
+}
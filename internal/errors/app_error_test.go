@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppError_WithStatus(t *testing.T) {
+	err := ErrNotTeamMember.WithStatus(http.StatusNotFound)
+
+	assert.Equal(t, http.StatusNotFound, err.Status)
+	assert.Equal(t, ErrNotTeamMember.Code, err.Code)
+	assert.Equal(t, ErrNotTeamMember.Message, err.Message)
+	assert.True(t, errors.Is(err, ErrNotTeamMember))
+	assert.Equal(t, http.StatusForbidden, ErrNotTeamMember.Status, "sentinel must not be modified")
+}
+
+func TestAppError_WithMessage(t *testing.T) {
+	err := ErrInvalidToken.WithMessage("token has been revoked")
+
+	assert.Equal(t, "token has been revoked", err.Error())
+	assert.Equal(t, ErrInvalidToken.Status, err.Status)
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestAppError_WithDetails(t *testing.T) {
+	fields := Validation(FieldError{Field: "name", Rule: "required", Message: "name is required"})
+	err := fields.WithDetails(map[string]any{"hint": "see docs"})
+
+	assert.Equal(t, "see docs", err.Details["hint"])
+	assert.Equal(t, fields.Fields, err.Fields)
+	assert.True(t, errors.Is(err, fields))
+}
+
+func TestValidation(t *testing.T) {
+	err := Validation(FieldError{Field: "email", Rule: "email", Message: "email must be a valid email address"})
+
+	assert.Equal(t, http.StatusBadRequest, err.Status)
+	assert.Equal(t, CodeValidationFailed, err.Code)
+	assert.Len(t, err.Fields, 1)
+}
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"sentinel", ErrTeamNotFound, http.StatusNotFound, "team_not_found"},
+		{"wrapped sentinel", fmt.Errorf("load team: %w", ErrTeamNotFound), http.StatusNotFound, "team_not_found"},
+		{"bad request", BadRequest("invalid id"), http.StatusBadRequest, CodeBadRequest},
+		{"rate limit", &RateLimitError{}, http.StatusTooManyRequests, ErrRateLimitExceeded.Code},
+		{"unexpected error", errors.New("database error"), http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := From(tt.err)
+
+			assert.Equal(t, tt.expectedStatus, appErr.Status)
+			assert.Equal(t, tt.expectedCode, appErr.Code)
+		})
+	}
+}
+
+func TestInternal_HidesCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := Internal(cause)
+
+	assert.Equal(t, "internal server error", err.Error())
+	assert.True(t, errors.Is(err, cause))
+}
@@ -1,53 +1,159 @@
 // Package errors provides custom error types for the application.
+// Each sentinel error carries a stable code and HTTP status, see AppError.
 package errors
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Request errors
+var (
+	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "request_too_large", "request body too large")
+)
 
 // User errors
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserAlreadyExists  = errors.New("user with this email already exists")
-	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrUserNotFound       = New(http.StatusNotFound, "user_not_found", "user not found")
+	ErrUserAlreadyExists  = New(http.StatusConflict, "user_already_exists", "user with this email already exists")
+	ErrInvalidCredentials = New(http.StatusUnauthorized, "invalid_credentials", "invalid email or password")
 )
 
 // Auth errors
 var (
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrInvalidToken        = errors.New("invalid token")
-	ErrTokenExpired        = errors.New("token expired")
-	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
-	ErrRefreshTokenExpired = errors.New("refresh token expired")
-	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrUnauthorized        = New(http.StatusUnauthorized, "unauthorized", "unauthorized")
+	ErrInvalidToken        = New(http.StatusUnauthorized, "invalid_token", "invalid token")
+	ErrTokenExpired        = New(http.StatusUnauthorized, "token_expired", "token expired")
+	ErrInvalidRefreshToken = New(http.StatusUnauthorized, "invalid_refresh_token", "invalid or expired refresh token")
+	ErrRefreshTokenExpired = New(http.StatusUnauthorized, "refresh_token_expired", "refresh token expired")
+	ErrRefreshTokenReused  = New(http.StatusUnauthorized, "refresh_token_reused", "refresh token reuse detected")
+	ErrAccountLocked       = New(http.StatusTooManyRequests, "account_locked", "too many failed login attempts, try again later")
+	ErrSessionNotFound     = New(http.StatusNotFound, "session_not_found", "session not found")
+	ErrSessionsUnsupported = New(http.StatusNotImplemented, "sessions_unsupported", "session management requires refresh token rotation")
+	ErrAuthUnavailable     = New(http.StatusServiceUnavailable, "auth_unavailable", "authentication is temporarily unavailable, please try again later")
+)
+
+// AccountLockedError is returned when login is blocked after repeated failures.
+// It wraps ErrAccountLocked.
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+// Unwrap returns ErrAccountLocked.
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// OIDC login errors
+var (
+	ErrOIDCProviderNotFound  = New(http.StatusNotFound, "oidc_provider_not_found", "login provider not found")
+	ErrInvalidOIDCState      = New(http.StatusBadRequest, "invalid_oidc_state", "invalid or expired login state")
+	ErrOIDCLoginFailed       = New(http.StatusUnauthorized, "oidc_login_failed", "login with provider failed")
+	ErrOIDCEmailNotVerified  = New(http.StatusForbidden, "oidc_email_not_verified", "provider account has no verified email")
+	ErrOIDCAccountExists     = New(http.StatusConflict, "oidc_account_exists", "an account with this email already exists, log in to it instead")
+	ErrIdentityAlreadyLinked = New(http.StatusConflict, "identity_already_linked", "provider account is already linked to another user")
+)
+
+// API key errors
+var (
+	ErrAPIKeyNotFound     = New(http.StatusNotFound, "api_key_not_found", "api key not found")
+	ErrInvalidAPIKey      = New(http.StatusUnauthorized, "invalid_api_key", "invalid or expired api key")
+	ErrAPIKeyLimitReached = New(http.StatusConflict, "api_key_limit_reached", "api key limit reached, revoke an unused key first")
+)
+
+// MFA errors
+var (
+	ErrMFAAlreadyEnabled  = New(http.StatusConflict, "mfa_already_enabled", "mfa is already enabled")
+	ErrMFANotEnabled      = New(http.StatusBadRequest, "mfa_not_enabled", "mfa is not enabled")
+	ErrMFANotEnrolled     = New(http.StatusBadRequest, "mfa_not_enrolled", "mfa enrollment has not been started")
+	ErrInvalidMFACode     = New(http.StatusUnauthorized, "invalid_mfa_code", "invalid mfa code")
+	ErrInvalidMFAToken    = New(http.StatusUnauthorized, "invalid_mfa_token", "invalid or expired mfa token")
+	ErrTooManyMFAAttempts = New(http.StatusTooManyRequests, "too_many_mfa_attempts", "too many invalid mfa codes, try again later")
 )
 
 // Voice memo errors
 var (
-	ErrVoiceMemoNotFound      = errors.New("voice memo not found")
-	ErrVoiceMemoUnauthorized  = errors.New("you can only delete your own voice memos")
-	ErrVoiceMemoInvalidStatus = errors.New("invalid voice memo status transition")
-	ErrTranscriptionQueueFull = errors.New("transcription queue is full, please try again later")
+	ErrVoiceMemoNotFound      = New(http.StatusNotFound, "voice_memo_not_found", "voice memo not found")
+	ErrVoiceMemoUnauthorized  = New(http.StatusForbidden, "voice_memo_forbidden", "you can only delete your own voice memos")
+	ErrVoiceMemoInvalidStatus = New(http.StatusConflict, "voice_memo_invalid_status", "invalid voice memo status transition")
+	ErrTranscriptionQueueFull = New(http.StatusServiceUnavailable, "transcription_queue_full", "transcription queue is full, please try again later")
+	ErrAudioNotUploaded       = New(http.StatusConflict, "audio_not_uploaded", "audio file has not been uploaded")
+	ErrAudioSizeMismatch      = New(http.StatusBadRequest, "audio_size_mismatch", "uploaded audio size does not match the declared file size")
 )
 
+// Rate limit and quota errors
+var (
+	ErrRateLimitExceeded          = New(http.StatusTooManyRequests, "rate_limit_exceeded", "rate limit exceeded, please try again later")
+	ErrMemoQuotaExceeded          = New(http.StatusForbidden, "memo_quota_exceeded", "voice memo quota exceeded")
+	ErrStorageQuotaExceeded       = New(http.StatusForbidden, "storage_quota_exceeded", "audio storage quota exceeded")
+	ErrTranscriptionQuotaExceeded = New(http.StatusForbidden, "transcription_quota_exceeded", "monthly transcription quota exceeded")
+)
+
+// RateLimitError is returned when a request is rejected by a rate limit.
+// It wraps ErrRateLimitExceeded.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return ErrRateLimitExceeded.Error()
+}
+
+// Unwrap returns ErrRateLimitExceeded.
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimitExceeded
+}
+
+// RetryAfter returns how long the client should wait before retrying a request
+// rejected with err, if err is a rate limit or account lockout error.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return rateErr.RetryAfter, true
+	}
+	var lockedErr *AccountLockedError
+	if errors.As(err, &lockedErr) {
+		return lockedErr.RetryAfter, true
+	}
+	return 0, false
+}
+
 // Team errors
 var (
-	ErrTeamNotFound            = errors.New("team not found")
-	ErrTeamSlugTaken           = errors.New("team slug is already taken")
-	ErrTeamLimitReached        = errors.New("free users can only create 1 team")
-	ErrNotTeamMember           = errors.New("you are not a member of this team")
-	ErrInsufficientPermissions = errors.New("insufficient permissions")
-	ErrOwnerCannotLeave        = errors.New("owner must transfer ownership before leaving")
-	ErrCannotRemoveOwner       = errors.New("cannot remove team owner")
-	ErrCannotRemoveSelf        = errors.New("cannot remove yourself, use leave endpoint")
-	ErrCannotChangeOwnerRole   = errors.New("cannot change owner role, use transfer")
-	ErrSeatsExceeded           = errors.New("team seats limit exceeded")
-	ErrInvalidRole             = errors.New("invalid role, must be admin or member")
+	ErrTeamNotFound            = New(http.StatusNotFound, "team_not_found", "team not found")
+	ErrTeamSlugTaken           = New(http.StatusConflict, "team_slug_taken", "team slug is already taken")
+	ErrTeamLimitReached        = New(http.StatusForbidden, "team_limit_reached", "free users can only create 1 team")
+	ErrNotTeamMember           = New(http.StatusForbidden, "not_team_member", "you are not a member of this team")
+	ErrInsufficientPermissions = New(http.StatusForbidden, "insufficient_permissions", "insufficient permissions")
+	ErrOwnerCannotLeave        = New(http.StatusBadRequest, "owner_cannot_leave", "owner must transfer ownership before leaving")
+	ErrCannotRemoveOwner       = New(http.StatusBadRequest, "cannot_remove_owner", "cannot remove team owner")
+	ErrCannotRemoveSelf        = New(http.StatusBadRequest, "cannot_remove_self", "cannot remove yourself, use leave endpoint")
+	ErrCannotChangeOwnerRole   = New(http.StatusBadRequest, "cannot_change_owner_role", "cannot change owner role, use transfer")
+	ErrCannotChangeOwnRole     = New(http.StatusForbidden, "cannot_change_own_role", "cannot change your own role")
+	ErrRoleExceedsPermissions  = New(http.StatusForbidden, "role_exceeds_permissions", "cannot assign a role with permissions you do not have")
+	ErrSeatsExceeded           = New(http.StatusForbidden, "seats_exceeded", "team seats limit exceeded")
+	ErrInvalidRole             = New(http.StatusBadRequest, "invalid_role", "invalid role for this team")
+)
+
+// Team role errors
+var (
+	ErrTeamRoleNotFound     = New(http.StatusNotFound, "team_role_not_found", "team role not found")
+	ErrTeamRoleExists       = New(http.StatusConflict, "team_role_exists", "a role with this name already exists")
+	ErrTeamRoleInUse        = New(http.StatusConflict, "team_role_in_use", "role is assigned to members or pending invitations")
+	ErrTeamRoleLimitReached = New(http.StatusConflict, "team_role_limit_reached", "team custom role limit reached")
 )
 
 // Invitation errors
 var (
-	ErrInvitationNotFound      = errors.New("invitation not found")
-	ErrInvitationExpired       = errors.New("invitation has expired")
-	ErrInvitationEmailMismatch = errors.New("invitation email does not match your account")
-	ErrAlreadyMember           = errors.New("user is already a team member")
-	ErrPendingInvitation       = errors.New("invitation already pending for this email")
+	ErrInvitationNotFound      = New(http.StatusNotFound, "invitation_not_found", "invitation not found")
+	ErrInvitationExpired       = New(http.StatusBadRequest, "invitation_expired", "invitation has expired")
+	ErrInvitationNotPending    = New(http.StatusConflict, "invitation_not_pending", "invitation is no longer pending")
+	ErrInvitationEmailMismatch = New(http.StatusForbidden, "invitation_email_mismatch", "invitation email does not match your account")
+	ErrAlreadyMember           = New(http.StatusConflict, "already_member", "user is already a team member")
+	ErrPendingInvitation       = New(http.StatusConflict, "invitation_pending", "invitation already pending for this email")
 )
@@ -2,7 +2,9 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +38,70 @@ func TestAuthErrors(t *testing.T) {
 		{"ErrInvalidToken", ErrInvalidToken, "invalid token"},
 		{"ErrTokenExpired", ErrTokenExpired, "token expired"},
 		{"ErrInvalidRefreshToken", ErrInvalidRefreshToken, "invalid or expired refresh token"},
+		{"ErrAccountLocked", ErrAccountLocked, "too many failed login attempts, try again later"},
+		{"ErrSessionNotFound", ErrSessionNotFound, "session not found"},
+		{"ErrSessionsUnsupported", ErrSessionsUnsupported, "session management requires refresh token rotation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, tt.err)
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestOIDCErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ErrOIDCProviderNotFound", ErrOIDCProviderNotFound, "login provider not found"},
+		{"ErrInvalidOIDCState", ErrInvalidOIDCState, "invalid or expired login state"},
+		{"ErrOIDCLoginFailed", ErrOIDCLoginFailed, "login with provider failed"},
+		{"ErrOIDCEmailNotVerified", ErrOIDCEmailNotVerified, "provider account has no verified email"},
+		{"ErrIdentityAlreadyLinked", ErrIdentityAlreadyLinked, "provider account is already linked to another user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, tt.err)
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestAPIKeyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ErrAPIKeyNotFound", ErrAPIKeyNotFound, "api key not found"},
+		{"ErrInvalidAPIKey", ErrInvalidAPIKey, "invalid or expired api key"},
+		{"ErrAPIKeyLimitReached", ErrAPIKeyLimitReached, "api key limit reached, revoke an unused key first"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, tt.err)
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestMFAErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ErrMFAAlreadyEnabled", ErrMFAAlreadyEnabled, "mfa is already enabled"},
+		{"ErrMFANotEnabled", ErrMFANotEnabled, "mfa is not enabled"},
+		{"ErrMFANotEnrolled", ErrMFANotEnrolled, "mfa enrollment has not been started"},
+		{"ErrInvalidMFACode", ErrInvalidMFACode, "invalid mfa code"},
+		{"ErrInvalidMFAToken", ErrInvalidMFAToken, "invalid or expired mfa token"},
 	}
 
 	for _, tt := range tests {
@@ -66,6 +132,34 @@ func TestVoiceMemoErrors(t *testing.T) {
 	}
 }
 
+func TestQuotaErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ErrRateLimitExceeded", ErrRateLimitExceeded, "rate limit exceeded, please try again later"},
+		{"ErrMemoQuotaExceeded", ErrMemoQuotaExceeded, "voice memo quota exceeded"},
+		{"ErrStorageQuotaExceeded", ErrStorageQuotaExceeded, "audio storage quota exceeded"},
+		{"ErrTranscriptionQuotaExceeded", ErrTranscriptionQuotaExceeded, "monthly transcription quota exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, tt.err)
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	err := &RateLimitError{RetryAfter: time.Second}
+
+	assert.True(t, errors.Is(err, ErrRateLimitExceeded))
+	assert.False(t, errors.Is(err, ErrAccountLocked))
+	assert.Equal(t, ErrRateLimitExceeded.Error(), err.Error())
+}
+
 func TestTeamErrors(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -82,7 +176,27 @@ func TestTeamErrors(t *testing.T) {
 		{"ErrCannotRemoveSelf", ErrCannotRemoveSelf, "cannot remove yourself, use leave endpoint"},
 		{"ErrCannotChangeOwnerRole", ErrCannotChangeOwnerRole, "cannot change owner role, use transfer"},
 		{"ErrSeatsExceeded", ErrSeatsExceeded, "team seats limit exceeded"},
-		{"ErrInvalidRole", ErrInvalidRole, "invalid role, must be admin or member"},
+		{"ErrInvalidRole", ErrInvalidRole, "invalid role for this team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, tt.err)
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestTeamRoleErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ErrTeamRoleNotFound", ErrTeamRoleNotFound, "team role not found"},
+		{"ErrTeamRoleExists", ErrTeamRoleExists, "a role with this name already exists"},
+		{"ErrTeamRoleInUse", ErrTeamRoleInUse, "role is assigned to members or pending invitations"},
+		{"ErrTeamRoleLimitReached", ErrTeamRoleLimitReached, "team custom role limit reached"},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +249,17 @@ func TestErrorsIsComparison(t *testing.T) {
 	}
 }
 
+func TestAccountLockedError(t *testing.T) {
+	err := fmt.Errorf("login: %w", &AccountLockedError{RetryAfter: time.Minute})
+
+	assert.True(t, errors.Is(err, ErrAccountLocked))
+	assert.Equal(t, "login: "+ErrAccountLocked.Error(), err.Error())
+
+	var lockedErr *AccountLockedError
+	assert.True(t, errors.As(err, &lockedErr))
+	assert.Equal(t, time.Minute, lockedErr.RetryAfter)
+}
+
 func TestAllErrorsAreUnique(t *testing.T) {
 	allErrors := []error{
 		// User errors
@@ -146,11 +271,35 @@ func TestAllErrorsAreUnique(t *testing.T) {
 		ErrInvalidToken,
 		ErrTokenExpired,
 		ErrInvalidRefreshToken,
+		ErrAccountLocked,
+		ErrSessionNotFound,
+		ErrSessionsUnsupported,
+		// OIDC login errors
+		ErrOIDCProviderNotFound,
+		ErrInvalidOIDCState,
+		ErrOIDCLoginFailed,
+		ErrOIDCEmailNotVerified,
+		ErrIdentityAlreadyLinked,
+		// API key errors
+		ErrAPIKeyNotFound,
+		ErrInvalidAPIKey,
+		ErrAPIKeyLimitReached,
+		// MFA errors
+		ErrMFAAlreadyEnabled,
+		ErrMFANotEnabled,
+		ErrMFANotEnrolled,
+		ErrInvalidMFACode,
+		ErrInvalidMFAToken,
 		// Voice memo errors
 		ErrVoiceMemoNotFound,
 		ErrVoiceMemoUnauthorized,
 		ErrVoiceMemoInvalidStatus,
 		ErrTranscriptionQueueFull,
+		// Rate limit and quota errors
+		ErrRateLimitExceeded,
+		ErrMemoQuotaExceeded,
+		ErrStorageQuotaExceeded,
+		ErrTranscriptionQuotaExceeded,
 		// Team errors
 		ErrTeamNotFound,
 		ErrTeamSlugTaken,
@@ -163,6 +312,11 @@ func TestAllErrorsAreUnique(t *testing.T) {
 		ErrCannotChangeOwnerRole,
 		ErrSeatsExceeded,
 		ErrInvalidRole,
+		// Team role errors
+		ErrTeamRoleNotFound,
+		ErrTeamRoleExists,
+		ErrTeamRoleInUse,
+		ErrTeamRoleLimitReached,
 		// Invitation errors
 		ErrInvitationNotFound,
 		ErrInvitationExpired,
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/middleware"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
+	"gin-sample/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKeyHandler handles HTTP requests for personal API keys.
+type APIKeyHandler struct {
+	service service.APIKeyServicer
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler.
+func NewAPIKeyHandler(service service.APIKeyServicer) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// CreateAPIKey godoc
+// @Summary      Create API key
+// @Description  Create a personal API key for scripts and integrations. The key is returned only once.
+// @Description  Scopes: memos:read, memos:write. Set teamId to restrict the key to one team's memos.
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateAPIKeyRequest  true  "Key name, scopes, team and expiry"
+// @Success      201      {object}  response.Response{data=models.CreateAPIKeyResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response  "Not a member of the team"
+// @Failure      409      {object}  response.Response  "API key limit reached"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, ok := getAPIKeyUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	result, err := h.service.CreateAPIKey(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// ListAPIKeys godoc
+// @Summary      List API keys
+// @Description  List the authenticated user's API keys. Keys themselves are never returned.
+// @Tags         api-keys
+// @Produce      json
+// @Success      200  {object}  response.Response{data=models.APIKeyListResponse}
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, ok := getAPIKeyUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListAPIKeys(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke API key
+// @Description  Delete one of the authenticated user's API keys. It stops working immediately.
+// @Tags         api-keys
+// @Param        id   path      string  true  "API key ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, ok := getAPIKeyUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest("invalid api key id"))
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(c.Request.Context(), userID, keyID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getAPIKeyUserID returns the authenticated user ID, adding an error to the context if it is invalid.
+func getAPIKeyUserID(c *gin.Context) (primitive.ObjectID, bool) {
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
+		return primitive.NilObjectID, false
+	}
+	return userID, true
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewAPIKeyHandler(t *testing.T) {
+	mockService := &mocks.MockAPIKeyService{}
+	handler := NewAPIKeyHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.service)
+}
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockAPIKeyService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successful create",
+			body: models.CreateAPIKeyRequest{Name: "Backup script", Scopes: []string{"memos:read"}},
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.CreateAPIKeyFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+					assert.Equal(t, userID, uID)
+					return &models.CreateAPIKeyResponse{
+						Key:    "gmk_1a2b3c4d_secret",
+						APIKey: models.APIKey{Name: req.Name, Prefix: "gmk_1a2b3c4d", Scopes: req.Scopes, KeyHash: "hash"},
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Equal(t, "gmk_1a2b3c4d_secret", data["key"])
+				apiKey := data["apiKey"].(map[string]interface{})
+				assert.Equal(t, "gmk_1a2b3c4d", apiKey["prefix"])
+				assert.NotContains(t, apiKey, "keyHash")
+			},
+		},
+		{
+			name:           "unknown scope",
+			body:           models.CreateAPIKeyRequest{Name: "Backup script", Scopes: []string{"users:delete"}},
+			mockSetup:      func(m *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing scopes",
+			body:           map[string]string{"name": "Backup script"},
+			mockSetup:      func(m *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid team id",
+			body:           models.CreateAPIKeyRequest{Name: "Team sync", Scopes: []string{"memos:read"}, TeamID: "invalid"},
+			mockSetup:      func(m *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not a team member",
+			body: models.CreateAPIKeyRequest{Name: "Team sync", Scopes: []string{"memos:read"}, TeamID: primitive.NewObjectID().Hex()},
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.CreateAPIKeyFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+					return nil, apperrors.ErrNotTeamMember
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "key limit reached",
+			body: models.CreateAPIKeyRequest{Name: "Backup script", Scopes: []string{"memos:read"}},
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.CreateAPIKeyFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+					return nil, apperrors.ErrAPIKeyLimitReached
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "service error",
+			body: models.CreateAPIKeyRequest{Name: "Backup script", Scopes: []string{"memos:read"}},
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.CreateAPIKeyFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAPIKeyService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAPIKeyHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/api-keys", setUserID(userID.Hex()), handler.CreateAPIKey)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/api-keys", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestAPIKeyHandler_ListAPIKeys(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		userID         string
+		mockSetup      func(*mocks.MockAPIKeyService)
+		expectedStatus int
+	}{
+		{
+			name:   "successful list",
+			userID: userID.Hex(),
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.ListAPIKeysFunc = func(ctx context.Context, uID primitive.ObjectID) (*models.APIKeyListResponse, error) {
+					return &models.APIKeyListResponse{Items: []models.APIKey{{Name: "Backup script"}}}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid user ID",
+			userID:         "invalid-id",
+			mockSetup:      func(m *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAPIKeyService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAPIKeyHandler(mockService)
+
+			router := newTestRouter()
+			router.GET("/auth/api-keys", setUserID(tt.userID), handler.ListAPIKeys)
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/api-keys", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	userID := primitive.NewObjectID()
+	keyID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		keyID          string
+		mockSetup      func(*mocks.MockAPIKeyService)
+		expectedStatus int
+	}{
+		{
+			name:  "successful revoke",
+			keyID: keyID.Hex(),
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.RevokeAPIKeyFunc = func(ctx context.Context, uID, kID primitive.ObjectID) error {
+					assert.Equal(t, keyID, kID)
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "invalid key ID",
+			keyID:          "invalid-id",
+			mockSetup:      func(m *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "key not found",
+			keyID: keyID.Hex(),
+			mockSetup: func(m *mocks.MockAPIKeyService) {
+				m.RevokeAPIKeyFunc = func(ctx context.Context, uID, kID primitive.ObjectID) error {
+					return apperrors.ErrAPIKeyNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAPIKeyService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAPIKeyHandler(mockService)
+
+			router := newTestRouter()
+			router.DELETE("/auth/api-keys/:id", setUserID(userID.Hex()), handler.RevokeAPIKey)
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/api-keys/"+tt.keyID, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/middleware"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service"
+	"gin-sample/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditLogHandler handles HTTP requests for team audit logs.
+type AuditLogHandler struct {
+	service service.AuditLogServicer
+}
+
+// NewAuditLogHandler creates a new AuditLogHandler.
+func NewAuditLogHandler(service service.AuditLogServicer) *AuditLogHandler {
+	return &AuditLogHandler{service: service}
+}
+
+// ListTeamAuditLog godoc
+// @Summary      List team audit log
+// @Description  List administrative actions taken in a team, newest first. Each entry records the actor, the action taken (e.g. member:remove or invitation:cancel), the target, its before/after state and the request ID. Requires owner or admin role.
+// @Tags         teams
+// @Produce      json
+// @Param        teamId      path      string  true   "Team ID"
+// @Param        actorId     query     string  false  "Only entries by this user"
+// @Param        action      query     string  false  "Only entries with this action (e.g. member:remove)"
+// @Param        targetType  query     string  false  "Only entries on this target type"  Enums(team, member, invitation, memo, role)
+// @Param        targetId    query     string  false  "Only entries on this target"
+// @Param        from        query     string  false  "Only entries at or after this time (RFC 3339)"
+// @Param        to          query     string  false  "Only entries before this time (RFC 3339)"
+// @Param        page        query     int     false  "Page number (default: 1)"
+// @Param        limit       query     int     false  "Items per page (default: 20, max: 100)"
+// @Success      200         {object}  response.Response{data=models.AuditLogListResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      401         {object}  response.Response
+// @Failure      403         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/audit-log [get]
+func (h *AuditLogHandler) ListTeamAuditLog(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	result, err := h.service.ListTeamAuditLog(c.Request.Context(), teamID, filter, page, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// parseAuditLogFilter reads the audit log filter from the query string.
+func parseAuditLogFilter(c *gin.Context) (models.AuditLogFilter, error) {
+	filter := models.AuditLogFilter{
+		Action:     c.DefaultQuery("action", ""),
+		TargetType: c.DefaultQuery("targetType", ""),
+	}
+
+	switch filter.TargetType {
+	case "", models.AuditTargetTeam, models.AuditTargetMember, models.AuditTargetInvitation, models.AuditTargetMemo, models.AuditTargetRole:
+	default:
+		return filter, errors.New("invalid targetType")
+	}
+
+	var err error
+	if filter.ActorID, err = optionalObjectIDQuery(c, "actorId"); err != nil {
+		return filter, err
+	}
+	if filter.TargetID, err = optionalObjectIDQuery(c, "targetId"); err != nil {
+		return filter, err
+	}
+	if filter.From, err = optionalTimeQuery(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = optionalTimeQuery(c, "to"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// optionalObjectIDQuery parses an optional ObjectID query parameter.
+func optionalObjectIDQuery(c *gin.Context, param string) (*primitive.ObjectID, error) {
+	value := c.DefaultQuery(param, "")
+	if value == "" {
+		return nil, nil
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s format", param)
+	}
+	return &id, nil
+}
+
+// optionalTimeQuery parses an optional RFC 3339 time query parameter.
+func optionalTimeQuery(c *gin.Context, param string) (*time.Time, error) {
+	value := c.DefaultQuery(param, "")
+	if value == "" {
+		return nil, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s time, expected RFC 3339", param)
+	}
+	return &at, nil
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gin-sample/internal/models"
+	"gin-sample/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewAuditLogHandler(t *testing.T) {
+	mockService := &mocks.MockAuditLogService{}
+	handler := NewAuditLogHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.service)
+}
+
+func TestAuditLogHandler_ListTeamAuditLog(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	actorID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*mocks.MockAuditLogService)
+		expectedStatus int
+	}{
+		{
+			name:  "successful list with filters",
+			query: "?actorId=" + actorID.Hex() + "&action=member:remove&targetType=member&from=2026-01-01T00:00:00Z&page=2&limit=5",
+			mockSetup: func(m *mocks.MockAuditLogService) {
+				m.ListTeamAuditLogFunc = func(ctx context.Context, tID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (*models.AuditLogListResponse, error) {
+					assert.Equal(t, teamID, tID)
+					require.NotNil(t, filter.ActorID)
+					assert.Equal(t, actorID, *filter.ActorID)
+					assert.Equal(t, "member:remove", filter.Action)
+					assert.Equal(t, models.AuditTargetMember, filter.TargetType)
+					require.NotNil(t, filter.From)
+					assert.True(t, filter.From.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+					assert.Nil(t, filter.To)
+					assert.Equal(t, 2, page)
+					assert.Equal(t, 5, limit)
+					return &models.AuditLogListResponse{Items: []models.AuditLogEntry{}}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid actor id",
+			query:          "?actorId=invalid",
+			mockSetup:      func(m *mocks.MockAuditLogService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid target type",
+			query:          "?targetType=user",
+			mockSetup:      func(m *mocks.MockAuditLogService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid time",
+			query:          "?from=yesterday",
+			mockSetup:      func(m *mocks.MockAuditLogService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "service error",
+			query: "",
+			mockSetup: func(m *mocks.MockAuditLogService) {
+				m.ListTeamAuditLogFunc = func(ctx context.Context, tID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (*models.AuditLogListResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuditLogService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuditLogHandler(mockService)
+
+			router := newTestRouter()
+			router.GET("/teams/:teamId/audit-log", setTeamID(teamID), handler.ListTeamAuditLog)
+
+			req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/audit-log"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	t.Run("missing team id", func(t *testing.T) {
+		handler := NewAuditLogHandler(&mocks.MockAuditLogService{})
+
+		router := newTestRouter()
+		router.GET("/teams/:teamId/audit-log", handler.ListTeamAuditLog)
+
+		req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/audit-log", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
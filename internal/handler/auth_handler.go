@@ -9,6 +9,7 @@ import (
 	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -35,23 +36,20 @@ func NewAuthHandler(service service.AuthServicer) *AuthHandler {
 // @Success      201      {object}  response.Response{data=models.AuthResponse}
 // @Failure      400      {object}  response.Response
 // @Failure      409      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Too many requests; see Retry-After"
 // @Failure      500      {object}  response.Response
 // @Router       /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.CreateUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
-	result, err := h.service.Register(c.Request.Context(), &req)
+	result, err := h.service.Register(c.Request.Context(), &req, clientInfo(c))
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserAlreadyExists) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -60,7 +58,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary      User login
-// @Description  Authenticate user and return access token and refresh token
+// @Description  Authenticate user and return access token and refresh token.
+// @Description  If the user has MFA enabled, the response is an MFA challenge (models.MFAChallengeResponse)
+// @Description  with mfaRequired=true and an mfaToken to exchange at /auth/mfa/verify.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -68,23 +68,129 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success      200      {object}  response.Response{data=models.AuthResponse}
 // @Failure      400      {object}  response.Response
 // @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Too many requests or account locked; see Retry-After"
 // @Failure      500      {object}  response.Response
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
-	result, err := h.service.Login(c.Request.Context(), &req)
+	result, challenge, err := h.service.Login(c.Request.Context(), &req, clientInfo(c))
 	if err != nil {
-		if errors.Is(err, apperrors.ErrInvalidCredentials) {
-			response.Unauthorized(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
+		return
+	}
+
+	if challenge != nil {
+		response.Success(c, challenge)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// VerifyMFA godoc
+// @Summary      Complete MFA login
+// @Description  Exchange the MFA token from login and a TOTP or recovery code for access and refresh tokens
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFAVerifyRequest  true  "MFA token and code"
+// @Success      200      {object}  response.Response{data=models.AuthResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Too many requests; see Retry-After"
+// @Failure      500      {object}  response.Response
+// @Router       /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req models.MFAVerifyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	result, err := h.service.VerifyMFA(c.Request.Context(), &req, clientInfo(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListOIDCProviders godoc
+// @Summary      List login providers
+// @Description  List the OpenID Connect identity providers available for login
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  response.Response{data=models.OIDCProviderListResponse}
+// @Router       /auth/oidc [get]
+func (h *AuthHandler) ListOIDCProviders(c *gin.Context) {
+	response.Success(c, h.service.ListOIDCProviders())
+}
+
+// StartOIDCLogin godoc
+// @Summary      Start provider login
+// @Description  Start an authorization code login with PKCE. Send the user to authorizationUrl;
+// @Description  the provider redirects back with a code and state to post to /auth/oidc/{provider}/callback.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200       {object}  response.Response{data=models.OIDCAuthorizeResponse}
+// @Failure      404       {object}  response.Response
+// @Failure      500       {object}  response.Response
+// @Router       /auth/oidc/{provider}/authorize [get]
+func (h *AuthHandler) StartOIDCLogin(c *gin.Context) {
+	result, err := h.service.StartOIDCLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CompleteOIDCLogin godoc
+// @Summary      Complete provider login
+// @Description  Exchange the authorization code and state from the provider redirect for access and refresh tokens.
+// @Description  The provider account is linked to the user with the same verified email if the provider is configured
+// @Description  with trust_email, or a new user is created.
+// @Description  If the user has MFA enabled, the response is an MFA challenge (models.MFAChallengeResponse).
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider  path      string                      true  "Provider name"
+// @Param        request   body      models.OIDCCallbackRequest  true  "Authorization code and state"
+// @Success      200       {object}  response.Response{data=models.AuthResponse}
+// @Failure      400       {object}  response.Response
+// @Failure      401       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Failure      404       {object}  response.Response
+// @Failure      409       {object}  response.Response  "An account with this email exists and the provider is not trusted to link it (code: oidc_account_exists), or the provider account is linked to another user (code: identity_already_linked)"
+// @Failure      429       {object}  response.Response  "Too many requests; see Retry-After"
+// @Failure      500       {object}  response.Response
+// @Router       /auth/oidc/{provider}/callback [post]
+func (h *AuthHandler) CompleteOIDCLogin(c *gin.Context) {
+	var req models.OIDCCallbackRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	result, challenge, err := h.service.CompleteOIDCLogin(c.Request.Context(), c.Param("provider"), &req, clientInfo(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if challenge != nil {
+		response.Success(c, challenge)
 		return
 	}
 
@@ -101,28 +207,20 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Success      200      {object}  response.Response{data=models.RefreshResponse}
 // @Failure      400      {object}  response.Response
 // @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Too many requests; see Retry-After"
 // @Failure      500      {object}  response.Response
 // @Router       /auth/refresh [post]
 func (h *AuthHandler) Refresh(c *gin.Context) {
 	var req models.RefreshRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
-	result, err := h.service.Refresh(c.Request.Context(), &req)
+	result, err := h.service.Refresh(c.Request.Context(), &req, clientInfo(c))
 	if err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrInvalidRefreshToken):
-			response.Unauthorized(c, err.Error())
-		case errors.Is(err, apperrors.ErrRefreshTokenExpired):
-			response.Unauthorized(c, err.Error())
-		case errors.Is(err, apperrors.ErrRefreshTokenReused):
-			response.Unauthorized(c, err.Error())
-		default:
-			response.InternalError(c)
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -131,7 +229,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 
 // Logout godoc
 // @Summary      User logout
-// @Description  Invalidate the refresh token
+// @Description  Invalidate the refresh token and the access token used for this request
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -146,12 +244,12 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	var req models.LogoutRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
-	if err := h.service.Logout(c.Request.Context(), &req); err != nil {
-		response.InternalError(c)
+	if err := h.service.Logout(c.Request.Context(), &req, middleware.GetClaims(c)); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -160,7 +258,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 // LogoutAll godoc
 // @Summary      Logout from all devices
-// @Description  Invalidate all refresh tokens for the authenticated user
+// @Description  Invalidate all refresh tokens, access tokens and API keys for the authenticated user
 // @Tags         auth
 // @Produce      json
 // @Success      204      "No Content"
@@ -172,14 +270,156 @@ func (h *AuthHandler) LogoutAll(c *gin.Context) {
 	userIDStr := middleware.GetUserID(c)
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.Unauthorized(c, "invalid session")
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
 		return
 	}
 
 	if err := h.service.LogoutAll(c.Request.Context(), userID); err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Change the authenticated user's password. All sessions are signed out, including the current one, and all API keys are revoked.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ChangePasswordRequest  true  "Current and new password"
+// @Success      204      "No Content"
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response  "Invalid session or wrong current password"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userIDStr := middleware.GetUserID(c)
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	if err := h.service.ChangePassword(c.Request.Context(), userID, &req); err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			err = apperrors.ErrUserNotFound.WithStatus(http.StatusUnauthorized).WithMessage("invalid session")
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateScopedToken godoc
+// @Summary      Create restricted access token
+// @Description  Create an access token that can only perform the given actions (e.g. memo:view), for third-party integrations.
+// @Description  It expires like a normal access token, is revoked when the current session signs out, and cannot be refreshed.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ScopedTokenRequest  true  "Actions the token may perform"
+// @Success      201      {object}  response.Response{data=models.ScopedTokenResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response  "Restricted tokens cannot create tokens"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/tokens [post]
+func (h *AuthHandler) CreateScopedToken(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
+		return
+	}
+
+	var req models.ScopedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	result, err := h.service.CreateScopedToken(c.Request.Context(), userID, middleware.GetSessionID(c), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// ListSessions godoc
+// @Summary      List active sessions
+// @Description  List the authenticated user's active login sessions (devices), most recently used first.
+// @Description  The session of the current access token is marked with current=true.
+// @Description  Requires refresh token rotation to be enabled.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  response.Response{data=models.SessionListResponse}
+// @Failure      401  {object}  response.Response
+// @Failure      501  {object}  response.Response  "Refresh token rotation is disabled"
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userIDStr := middleware.GetUserID(c)
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
+		return
+	}
+
+	result, err := h.service.ListSessions(c.Request.Context(), userID, middleware.GetSessionID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Sign out one device by revoking its session. Its refresh and access tokens stop working immediately.
+// @Tags         auth
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"
+// @Success      204  "No Content"
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      501  {object}  response.Response  "Refresh token rotation is disabled"
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userIDStr := middleware.GetUserID(c)
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
+		return
+	}
+
+	if err := h.service.RevokeSession(c.Request.Context(), userID, c.Param("id")); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// clientInfo returns the device metadata of the request for session tracking.
+func clientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
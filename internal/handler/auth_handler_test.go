@@ -14,6 +14,7 @@ import (
 	"gin-sample/internal/models"
 	"gin-sample/internal/service/mocks"
 	"gin-sample/internal/validator"
+	"gin-sample/pkg/auth"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -52,7 +53,7 @@ func TestAuthHandler_Register(t *testing.T) {
 				Name:     "Test User",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.RegisterFunc = func(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error) {
+				m.RegisterFunc = func(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 					return &models.AuthResponse{
 						AccessToken:  "access-token",
 						RefreshToken: "refresh-token",
@@ -100,7 +101,7 @@ func TestAuthHandler_Register(t *testing.T) {
 				Name:     "Test User",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.RegisterFunc = func(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error) {
+				m.RegisterFunc = func(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 					return nil, apperrors.ErrUserAlreadyExists
 				}
 			},
@@ -114,7 +115,7 @@ func TestAuthHandler_Register(t *testing.T) {
 				Name:     "Test User",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.RegisterFunc = func(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error) {
+				m.RegisterFunc = func(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 					return nil, errors.New("database error")
 				}
 			},
@@ -129,7 +130,7 @@ func TestAuthHandler_Register(t *testing.T) {
 
 			handler := NewAuthHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.POST("/auth/register", handler.Register)
 
 			var body []byte
@@ -172,7 +173,7 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "password123",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
+				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
 					return &models.AuthResponse{
 						AccessToken:  "access-token",
 						RefreshToken: "refresh-token",
@@ -184,7 +185,7 @@ func TestAuthHandler_Login(t *testing.T) {
 							CreatedAt: now,
 							UpdatedAt: now,
 						},
-					}, nil
+					}, nil, nil
 				}
 			},
 			expectedStatus: http.StatusOK,
@@ -197,6 +198,32 @@ func TestAuthHandler_Login(t *testing.T) {
 				assert.Equal(t, "access-token", data["accessToken"])
 			},
 		},
+		{
+			name: "mfa required",
+			body: models.LoginRequest{
+				Email:    "test@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, &models.MFAChallengeResponse{
+						MFARequired: true,
+						MFAToken:    "mfa_token",
+						ExpiresIn:   300,
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Equal(t, true, data["mfaRequired"])
+				assert.Equal(t, "mfa_token", data["mfaToken"])
+				assert.Nil(t, data["accessToken"])
+			},
+		},
 		{
 			name:           "invalid JSON body",
 			body:           "invalid json",
@@ -218,12 +245,28 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "wrongpassword",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
-					return nil, apperrors.ErrInvalidCredentials
+				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, apperrors.ErrInvalidCredentials
 				}
 			},
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "account locked",
+			body: models.LoginRequest{
+				Email:    "test@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, &apperrors.AccountLockedError{RetryAfter: 90 * time.Second}
+				}
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "90", w.Header().Get("Retry-After"))
+			},
+		},
 		{
 			name: "internal server error",
 			body: models.LoginRequest{
@@ -231,8 +274,8 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "password123",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
-					return nil, errors.New("database error")
+				m.LoginFunc = func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, errors.New("database error")
 				}
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -246,7 +289,7 @@ func TestAuthHandler_Login(t *testing.T) {
 
 			handler := NewAuthHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.POST("/auth/login", handler.Login)
 
 			var body []byte
@@ -271,6 +314,112 @@ func TestAuthHandler_Login(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_VerifyMFA(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successful verification",
+			body: models.MFAVerifyRequest{
+				MFAToken: "mfa_token",
+				Code:     "123456",
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.VerifyMFAFunc = func(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+					return &models.AuthResponse{
+						AccessToken:  "access-token",
+						RefreshToken: "refresh-token",
+						ExpiresIn:    900,
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Equal(t, "access-token", data["accessToken"])
+			},
+		},
+		{
+			name: "missing code",
+			body: map[string]string{
+				"mfaToken": "mfa_token",
+			},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid mfa token",
+			body: models.MFAVerifyRequest{
+				MFAToken: "mfa_token",
+				Code:     "123456",
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.VerifyMFAFunc = func(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+					return nil, apperrors.ErrInvalidMFAToken
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid code",
+			body: models.MFAVerifyRequest{
+				MFAToken: "mfa_token",
+				Code:     "000000",
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.VerifyMFAFunc = func(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+					return nil, apperrors.ErrInvalidMFACode
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "internal server error",
+			body: models.MFAVerifyRequest{
+				MFAToken: "mfa_token",
+				Code:     "123456",
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.VerifyMFAFunc = func(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+					return nil, errors.New("redis error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/mfa/verify", handler.VerifyMFA)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
 func TestAuthHandler_Refresh(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -285,7 +434,7 @@ func TestAuthHandler_Refresh(t *testing.T) {
 				RefreshToken: "valid-refresh-token",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.RefreshFunc = func(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error) {
+				m.RefreshFunc = func(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error) {
 					return &models.RefreshResponse{
 						AccessToken: "new-access-token",
 						ExpiresIn:   900,
@@ -320,7 +469,7 @@ func TestAuthHandler_Refresh(t *testing.T) {
 				RefreshToken: "invalid-token",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.RefreshFunc = func(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error) {
+				m.RefreshFunc = func(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error) {
 					return nil, apperrors.ErrInvalidRefreshToken
 				}
 			},
@@ -332,7 +481,7 @@ func TestAuthHandler_Refresh(t *testing.T) {
 				RefreshToken: "valid-token",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.RefreshFunc = func(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error) {
+				m.RefreshFunc = func(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error) {
 					return nil, errors.New("database error")
 				}
 			},
@@ -347,7 +496,7 @@ func TestAuthHandler_Refresh(t *testing.T) {
 
 			handler := NewAuthHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.POST("/auth/refresh", handler.Refresh)
 
 			var body []byte
@@ -385,7 +534,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 				RefreshToken: "valid-refresh-token",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.LogoutFunc = func(ctx context.Context, req *models.LogoutRequest) error {
+				m.LogoutFunc = func(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error {
 					return nil
 				}
 			},
@@ -409,7 +558,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 				RefreshToken: "valid-token",
 			},
 			mockSetup: func(m *mocks.MockAuthService) {
-				m.LogoutFunc = func(ctx context.Context, req *models.LogoutRequest) error {
+				m.LogoutFunc = func(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error {
 					return errors.New("database error")
 				}
 			},
@@ -424,7 +573,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 
 			handler := NewAuthHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.POST("/auth/logout", handler.Logout)
 
 			var body []byte
@@ -504,7 +653,7 @@ func TestAuthHandler_LogoutAll(t *testing.T) {
 
 			handler := NewAuthHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.POST("/auth/logout-all", func(c *gin.Context) {
 				tt.setupContext(c)
 				handler.LogoutAll(c)
@@ -519,3 +668,596 @@ func TestAuthHandler_LogoutAll(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_Login_ClientInfo(t *testing.T) {
+	var got models.ClientInfo
+	mockService := &mocks.MockAuthService{
+		LoginFunc: func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+			got = client
+			return &models.AuthResponse{}, nil, nil
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	router := newTestRouter()
+	router.POST("/auth/login", handler.Login)
+
+	body, _ := json.Marshal(models.LoginRequest{Email: "test@example.com", Password: "password123"})
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "test-agent/1.0")
+	req.RemoteAddr = "203.0.113.7:54321"
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "test-agent/1.0", got.UserAgent)
+	assert.Equal(t, "203.0.113.7", got.IPAddress)
+}
+
+func TestAuthHandler_ListSessions(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		setupContext   func(*gin.Context)
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successful list with current session",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+				c.Set("sessionID", "family123")
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.ListSessionsFunc = func(ctx context.Context, id primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error) {
+					assert.Equal(t, userID, id)
+					return &models.SessionListResponse{Items: []models.Session{
+						{ID: currentSessionID, UserAgent: "phone", Current: true},
+						{ID: "family456", UserAgent: "laptop"},
+					}}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				items := data["items"].([]interface{})
+				assert.Len(t, items, 2)
+				first := items[0].(map[string]interface{})
+				assert.Equal(t, "family123", first["id"])
+				assert.Equal(t, true, first["current"])
+			},
+		},
+		{
+			name: "invalid user ID in context",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", "invalid-object-id")
+			},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "rotation disabled",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.ListSessionsFunc = func(ctx context.Context, id primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error) {
+					return nil, apperrors.ErrSessionsUnsupported
+				}
+			},
+			expectedStatus: http.StatusNotImplemented,
+		},
+		{
+			name: "internal server error",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.ListSessionsFunc = func(ctx context.Context, id primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error) {
+					return nil, errors.New("redis error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.GET("/auth/sessions", func(c *gin.Context) {
+				tt.setupContext(c)
+				handler.ListSessions(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/sessions", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		setupContext   func(*gin.Context)
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name: "successful revoke",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.RevokeSessionFunc = func(ctx context.Context, id primitive.ObjectID, sessionID string) error {
+					assert.Equal(t, "family123", sessionID)
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "invalid user ID in context",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", "invalid-object-id")
+			},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "session not found",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.RevokeSessionFunc = func(ctx context.Context, id primitive.ObjectID, sessionID string) error {
+					return apperrors.ErrSessionNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal server error",
+			setupContext: func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+			},
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.RevokeSessionFunc = func(ctx context.Context, id primitive.ObjectID, sessionID string) error {
+					return errors.New("redis error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.DELETE("/auth/sessions/:id", func(c *gin.Context) {
+				tt.setupContext(c)
+				handler.RevokeSession(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/sessions/family123", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_Logout_PassesAccessClaims(t *testing.T) {
+	claims := &auth.Claims{UserID: primitive.NewObjectID().Hex()}
+	claims.ID = "jti-123"
+
+	var got *auth.Claims
+	mockService := &mocks.MockAuthService{
+		LogoutFunc: func(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error {
+			got = accessClaims
+			return nil
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	router := newTestRouter()
+	router.POST("/auth/logout", func(c *gin.Context) {
+		c.Set("tokenClaims", claims)
+		handler.Logout(c)
+	})
+
+	body, _ := json.Marshal(models.LogoutRequest{RefreshToken: "valid-refresh-token"})
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, claims, got)
+}
+
+func TestAuthHandler_ChangePassword(t *testing.T) {
+	userID := primitive.NewObjectID()
+	validBody := models.ChangePasswordRequest{CurrentPassword: "oldpassword", NewPassword: "newpassword"}
+
+	tests := []struct {
+		name           string
+		userID         string
+		body           interface{}
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name:   "successful password change",
+			userID: userID.Hex(),
+			body:   validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.ChangePasswordFunc = func(ctx context.Context, id primitive.ObjectID, req *models.ChangePasswordRequest) error {
+					assert.Equal(t, userID, id)
+					assert.Equal(t, "newpassword", req.NewPassword)
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "invalid user ID in context",
+			userID:         "invalid-object-id",
+			body:           validBody,
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "new password too short",
+			userID:         userID.Hex(),
+			body:           models.ChangePasswordRequest{CurrentPassword: "oldpassword", NewPassword: "short"},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "wrong current password",
+			userID: userID.Hex(),
+			body:   validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.ChangePasswordFunc = func(ctx context.Context, id primitive.ObjectID, req *models.ChangePasswordRequest) error {
+					return apperrors.ErrInvalidCredentials
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "internal server error",
+			userID: userID.Hex(),
+			body:   validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.ChangePasswordFunc = func(ctx context.Context, id primitive.ObjectID, req *models.ChangePasswordRequest) error {
+					return errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.PUT("/auth/password", func(c *gin.Context) {
+				c.Set("userID", tt.userID)
+				handler.ChangePassword(c)
+			})
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPut, "/auth/password", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_CreateScopedToken(t *testing.T) {
+	userID := primitive.NewObjectID()
+	validBody := models.ScopedTokenRequest{Scopes: []string{"memo:view"}}
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name: "successful create",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CreateScopedTokenFunc = func(ctx context.Context, id primitive.ObjectID, sessionID string, req *models.ScopedTokenRequest) (*models.ScopedTokenResponse, error) {
+					assert.Equal(t, userID, id)
+					assert.Equal(t, "a1b2c3d4e5f67890", sessionID)
+					return &models.ScopedTokenResponse{AccessToken: "scoped-token", ExpiresIn: 900, Scopes: req.Scopes}, nil
+				}
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unknown scope",
+			body:           models.ScopedTokenRequest{Scopes: []string{"user:delete"}},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing scopes",
+			body:           map[string]interface{}{},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal server error",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CreateScopedTokenFunc = func(ctx context.Context, id primitive.ObjectID, sessionID string, req *models.ScopedTokenRequest) (*models.ScopedTokenResponse, error) {
+					return nil, errors.New("signing error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/tokens", func(c *gin.Context) {
+				c.Set("userID", userID.Hex())
+				c.Set("sessionID", "a1b2c3d4e5f67890")
+				handler.CreateScopedToken(c)
+			})
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/tokens", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_ListOIDCProviders(t *testing.T) {
+	mockService := &mocks.MockAuthService{
+		ListOIDCProvidersFunc: func() *models.OIDCProviderListResponse {
+			return &models.OIDCProviderListResponse{Items: []models.OIDCProviderInfo{{Name: "google", DisplayName: "Google"}}}
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	router := newTestRouter()
+	router.GET("/auth/oidc", handler.ListOIDCProviders)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"google"`)
+}
+
+func TestAuthHandler_StartOIDCLogin(t *testing.T) {
+	tests := []struct {
+		name           string
+		provider       string
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name:     "returns authorization URL",
+			provider: "google",
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.StartOIDCLoginFunc = func(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error) {
+					assert.Equal(t, "google", providerName)
+					return &models.OIDCAuthorizeResponse{AuthorizationURL: "https://idp.example.com/authorize", State: "oidc_state"}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "unknown provider",
+			provider: "unknown",
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.StartOIDCLoginFunc = func(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error) {
+					return nil, apperrors.ErrOIDCProviderNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:     "internal server error",
+			provider: "google",
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.StartOIDCLoginFunc = func(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error) {
+					return nil, errors.New("redis error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.GET("/auth/oidc/:provider/authorize", handler.StartOIDCLogin)
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/oidc/"+tt.provider+"/authorize", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_CompleteOIDCLogin(t *testing.T) {
+	validBody := models.OIDCCallbackRequest{Code: "code123", State: "oidc_state123"}
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockAuthService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "returns auth tokens",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					assert.Equal(t, "google", providerName)
+					assert.Equal(t, "code123", req.Code)
+					return &models.AuthResponse{AccessToken: "access-token"}, nil, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"accessToken":"access-token"`,
+		},
+		{
+			name: "returns mfa challenge",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, &models.MFAChallengeResponse{MFARequired: true, MFAToken: "mfa_token"}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"mfaRequired":true`,
+		},
+		{
+			name:           "missing state",
+			body:           map[string]string{"code": "code123"},
+			mockSetup:      func(m *mocks.MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid state",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, apperrors.ErrInvalidOIDCState
+				}
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "provider login failed",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, apperrors.ErrOIDCLoginFailed
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "email not verified",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, apperrors.ErrOIDCEmailNotVerified
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "unknown provider",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, apperrors.ErrOIDCProviderNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal server error",
+			body: validBody,
+			mockSetup: func(m *mocks.MockAuthService) {
+				m.CompleteOIDCLoginFunc = func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+					return nil, nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/oidc/:provider/callback", handler.CompleteOIDCLogin)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/oidc/google/callback", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"gin-sample/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler serves the liveness and readiness probes.
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+// NewHealthHandler creates a new HealthHandler.
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Live reports whether the server's own workers are running. A failure means the process
+// should be restarted. Responds 200 when every check passes, otherwise 503, with the
+// status of each component.
+func (h *HealthHandler) Live(c *gin.Context) {
+	respondHealth(c, h.checker.Live(c.Request.Context()))
+}
+
+// Ready reports whether the server can serve requests: its workers are running, MongoDB,
+// Redis and S3 are reachable, the transcription queue has room and shutdown has not begun.
+// Responds 200 when every check passes, otherwise 503, with the status of each component.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	respondHealth(c, h.checker.Ready(c.Request.Context()))
+}
+
+func respondHealth(c *gin.Context, report health.Report) {
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gin-sample/internal/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	var redisErr error
+	checker := health.NewChecker(time.Second)
+	checker.AddLivenessCheck("transcription_workers", func(context.Context) error { return nil })
+	checker.AddReadinessCheck("redis", func(context.Context) error { return redisErr })
+
+	router := newTestRouter()
+	h := NewHealthHandler(checker)
+	router.GET("/livez", h.Live)
+	router.GET("/readyz", h.Ready)
+
+	get := func(path string) (int, health.Report) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var report health.Report
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		return w.Code, report
+	}
+
+	t.Run("ready when every component is ok", func(t *testing.T) {
+		code, report := get("/readyz")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, health.StatusOK, report.Status)
+		assert.Equal(t, health.StatusOK, report.Components["redis"].Status)
+		assert.Contains(t, report.Components, "transcription_workers")
+	})
+
+	t.Run("not ready when a dependency fails", func(t *testing.T) {
+		redisErr = errors.New("connection refused")
+		defer func() { redisErr = nil }()
+
+		code, report := get("/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.StatusFail, report.Status)
+		assert.Equal(t, "connection refused", report.Components["redis"].Error)
+		assert.Equal(t, health.StatusOK, report.Components["transcription_workers"].Status)
+	})
+
+	t.Run("live ignores dependencies", func(t *testing.T) {
+		redisErr = errors.New("connection refused")
+		defer func() { redisErr = nil }()
+
+		code, report := get("/livez")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, report.Components, "redis")
+	})
+
+	t.Run("not ready during shutdown", func(t *testing.T) {
+		checker.Shutdown()
+
+		code, report := get("/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.ErrShuttingDown.Error(), report.Components["shutdown"].Error)
+	})
+}
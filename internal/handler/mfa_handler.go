@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"net/http"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/middleware"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
+	"gin-sample/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MFAHandler handles HTTP requests for MFA enrollment operations.
+type MFAHandler struct {
+	service service.MFAServicer
+}
+
+// NewMFAHandler creates a new MFAHandler.
+func NewMFAHandler(service service.MFAServicer) *MFAHandler {
+	return &MFAHandler{service: service}
+}
+
+// GetStatus godoc
+// @Summary      Get MFA status
+// @Description  Get the MFA enrollment status of the authenticated user
+// @Tags         mfa
+// @Produce      json
+// @Success      200  {object}  response.Response{data=models.MFAStatusResponse}
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/mfa [get]
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	userID, ok := getMFAUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Enroll godoc
+// @Summary      Start MFA enrollment
+// @Description  Generate a TOTP secret and otpauth URI for an authenticator app. MFA is enabled after activation.
+// @Tags         mfa
+// @Produce      json
+// @Success      200  {object}  response.Response{data=models.MFAEnrollResponse}
+// @Failure      401  {object}  response.Response
+// @Failure      409  {object}  response.Response  "MFA is already enabled"
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/mfa/enroll [post]
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	userID, ok := getMFAUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Enroll(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Activate godoc
+// @Summary      Activate MFA
+// @Description  Confirm enrollment with a TOTP code and enable MFA. Returns recovery codes, which are shown only once.
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFACodeRequest  true  "TOTP code"
+// @Success      200      {object}  response.Response{data=models.MFARecoveryCodesResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      409      {object}  response.Response  "MFA is already enabled"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/mfa/activate [post]
+func (h *MFAHandler) Activate(c *gin.Context) {
+	userID, ok := getMFAUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	result, err := h.service.Activate(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Disable godoc
+// @Summary      Disable MFA
+// @Description  Disable MFA. Requires the account password and a current TOTP or recovery code.
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFADisableRequest  true  "Password and code"
+// @Success      204      "No Content"
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Too many invalid MFA codes"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	userID, ok := getMFAUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	if err := h.service.Disable(c.Request.Context(), userID, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate recovery codes
+// @Description  Replace all recovery codes after verifying a current TOTP or recovery code
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFACodeRequest  true  "TOTP or recovery code"
+// @Success      200      {object}  response.Response{data=models.MFARecoveryCodesResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Too many invalid MFA codes"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /auth/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := getMFAUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	result, err := h.service.RegenerateRecoveryCodes(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// getMFAUserID returns the authenticated user ID, adding an error to the context if it is invalid.
+func getMFAUserID(c *gin.Context) (primitive.ObjectID, bool) {
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid session"))
+		return primitive.NilObjectID, false
+	}
+	return userID, true
+}
@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewMFAHandler(t *testing.T) {
+	mockService := &mocks.MockMFAService{}
+	handler := NewMFAHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.service)
+}
+
+func TestMFAHandler_GetStatus(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		userID         string
+		mockSetup      func(*mocks.MockMFAService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "successful get status",
+			userID: userID.Hex(),
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.GetStatusFunc = func(ctx context.Context, uID primitive.ObjectID) (*models.MFAStatusResponse, error) {
+					return &models.MFAStatusResponse{Enabled: true, RecoveryCodesRemaining: 8}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Equal(t, true, data["enabled"])
+				assert.Equal(t, float64(8), data["recoveryCodesRemaining"])
+			},
+		},
+		{
+			name:           "invalid user ID",
+			userID:         "invalid-id",
+			mockSetup:      func(m *mocks.MockMFAService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "user not found",
+			userID: userID.Hex(),
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.GetStatusFunc = func(ctx context.Context, uID primitive.ObjectID) (*models.MFAStatusResponse, error) {
+					return nil, apperrors.ErrUserNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockMFAService{}
+			tt.mockSetup(mockService)
+
+			handler := NewMFAHandler(mockService)
+
+			router := newTestRouter()
+			router.GET("/auth/mfa", setUserID(tt.userID), handler.GetStatus)
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/mfa", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestMFAHandler_Enroll(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*mocks.MockMFAService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successful enroll",
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.EnrollFunc = func(ctx context.Context, uID primitive.ObjectID) (*models.MFAEnrollResponse, error) {
+					return &models.MFAEnrollResponse{
+						Secret:     "JBSWY3DPEHPK3PXP",
+						OTPAuthURI: "otpauth://totp/gin-sample:user@example.com?secret=JBSWY3DPEHPK3PXP",
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Equal(t, "JBSWY3DPEHPK3PXP", data["secret"])
+				assert.NotEmpty(t, data["otpauthUri"])
+			},
+		},
+		{
+			name: "mfa already enabled",
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.EnrollFunc = func(ctx context.Context, uID primitive.ObjectID) (*models.MFAEnrollResponse, error) {
+					return nil, apperrors.ErrMFAAlreadyEnabled
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.EnrollFunc = func(ctx context.Context, uID primitive.ObjectID) (*models.MFAEnrollResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockMFAService{}
+			tt.mockSetup(mockService)
+
+			handler := NewMFAHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/mfa/enroll", setUserID(userID.Hex()), handler.Enroll)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/enroll", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestMFAHandler_Activate(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockMFAService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successful activate",
+			body: models.MFACodeRequest{Code: "123456"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.ActivateFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+					return &models.MFARecoveryCodesResponse{RecoveryCodes: []string{"abcde-12345", "fghij-67890"}}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Len(t, data["recoveryCodes"], 2)
+			},
+		},
+		{
+			name:           "missing code",
+			body:           map[string]string{},
+			mockSetup:      func(m *mocks.MockMFAService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "enrollment not started",
+			body: models.MFACodeRequest{Code: "123456"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.ActivateFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+					return nil, apperrors.ErrMFANotEnrolled
+				}
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid code",
+			body: models.MFACodeRequest{Code: "000000"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.ActivateFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+					return nil, apperrors.ErrInvalidMFACode
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockMFAService{}
+			tt.mockSetup(mockService)
+
+			handler := NewMFAHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/mfa/activate", setUserID(userID.Hex()), handler.Activate)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/activate", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestMFAHandler_Disable(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockMFAService)
+		expectedStatus int
+	}{
+		{
+			name: "successful disable",
+			body: models.MFADisableRequest{Password: "password123", Code: "123456"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.DisableFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFADisableRequest) error {
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing password",
+			body:           map[string]string{"code": "123456"},
+			mockSetup:      func(m *mocks.MockMFAService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "wrong password",
+			body: models.MFADisableRequest{Password: "wrong", Code: "123456"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.DisableFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFADisableRequest) error {
+					return apperrors.ErrInvalidCredentials
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "mfa not enabled",
+			body: models.MFADisableRequest{Password: "password123", Code: "123456"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.DisableFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFADisableRequest) error {
+					return apperrors.ErrMFANotEnabled
+				}
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockMFAService{}
+			tt.mockSetup(mockService)
+
+			handler := NewMFAHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/mfa/disable", setUserID(userID.Hex()), handler.Disable)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/disable", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestMFAHandler_RegenerateRecoveryCodes(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockMFAService)
+		expectedStatus int
+	}{
+		{
+			name: "successful regenerate",
+			body: models.MFACodeRequest{Code: "123456"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.RegenerateRecoveryCodesFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+					return &models.MFARecoveryCodesResponse{RecoveryCodes: []string{"abcde-12345"}}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid code",
+			body: models.MFACodeRequest{Code: "000000"},
+			mockSetup: func(m *mocks.MockMFAService) {
+				m.RegenerateRecoveryCodesFunc = func(ctx context.Context, uID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+					return nil, apperrors.ErrInvalidMFACode
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockMFAService{}
+			tt.mockSetup(mockService)
+
+			handler := NewMFAHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/auth/mfa/recovery-codes", setUserID(userID.Hex()), handler.RegenerateRecoveryCodes)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/recovery-codes", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 	"strconv"
 
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -42,33 +44,25 @@ func NewTeamHandler(service service.TeamServicer) *TeamHandler {
 func (h *TeamHandler) CreateTeam(c *gin.Context) {
 	userIDStr := middleware.GetUserID(c)
 	if userIDStr == "" {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	var req models.CreateTeamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	team, err := h.service.CreateTeam(c.Request.Context(), userID, &req)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrTeamLimitReached) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrTeamSlugTaken) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -91,13 +85,13 @@ func (h *TeamHandler) CreateTeam(c *gin.Context) {
 func (h *TeamHandler) ListTeams(c *gin.Context) {
 	userIDStr := middleware.GetUserID(c)
 	if userIDStr == "" {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
@@ -106,7 +100,7 @@ func (h *TeamHandler) ListTeams(c *gin.Context) {
 
 	result, err := h.service.ListTeams(c.Request.Context(), userID, page, limit)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -131,17 +125,13 @@ func (h *TeamHandler) ListTeams(c *gin.Context) {
 func (h *TeamHandler) GetTeam(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	team, err := h.service.GetTeam(c.Request.Context(), teamID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrTeamNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -168,27 +158,25 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 func (h *TeamHandler) UpdateTeam(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	var req models.UpdateTeamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
-	team, err := h.service.UpdateTeam(c.Request.Context(), teamID, &req)
+	team, err := h.service.UpdateTeam(c.Request.Context(), teamID, userID, &req)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrTeamNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrTeamSlugTaken) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -213,16 +201,18 @@ func (h *TeamHandler) UpdateTeam(c *gin.Context) {
 func (h *TeamHandler) DeleteTeam(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
-	if err := h.service.DeleteTeam(c.Request.Context(), teamID); err != nil {
-		if errors.Is(err, apperrors.ErrTeamNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
+		return
+	}
+
+	if err := h.service.DeleteTeam(c.Request.Context(), teamID, userID); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -248,39 +238,34 @@ func (h *TeamHandler) DeleteTeam(c *gin.Context) {
 func (h *TeamHandler) TransferOwnership(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	userIDStr := middleware.GetUserID(c)
 	currentOwnerID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	var req models.TransferOwnershipRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	newOwnerID, err := primitive.ObjectIDFromHex(req.NewOwnerID)
 	if err != nil {
-		response.BadRequest(c, "invalid new owner id format")
+		_ = c.Error(apperrors.BadRequest("invalid new owner id format"))
 		return
 	}
 
 	if err := h.service.TransferOwnership(c.Request.Context(), teamID, currentOwnerID, newOwnerID); err != nil {
 		if errors.Is(err, apperrors.ErrNotTeamMember) {
-			response.NotFound(c, "new owner must be a team member")
-			return
-		}
-		if errors.Is(err, apperrors.ErrTeamNotFound) {
-			response.NotFound(c, err.Error())
-			return
+			err = apperrors.ErrNotTeamMember.WithStatus(http.StatusNotFound).WithMessage("new owner must be a team member")
 		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
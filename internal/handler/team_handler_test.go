@@ -28,6 +28,13 @@ func TestNewTeamHandler(t *testing.T) {
 	assert.Equal(t, mockService, handler.service)
 }
 
+// newTestRouter returns a router that renders handler errors like the application router.
+func newTestRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(false))
+	return router
+}
+
 // setUserID is a helper middleware to set user ID in context
 func setUserID(userID string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -110,6 +117,24 @@ func TestTeamHandler_CreateTeam(t *testing.T) {
 			mockSetup:      func(m *mocks.MockTeamService) {},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "field validation errors",
+			userID:         userID.Hex(),
+			body:           models.CreateTeamRequest{Name: "T", Slug: "Not A Slug"},
+			mockSetup:      func(m *mocks.MockTeamService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{
+					"success": false,
+					"error": "request validation failed",
+					"code": "validation_failed",
+					"fields": [
+						{"field": "name", "rule": "min", "param": "2", "message": "name must be at least 2 characters in length"},
+						{"field": "slug", "rule": "slug", "message": "slug must be a valid slug (lowercase letters, numbers and single hyphens)"}
+					]
+				}`, w.Body.String())
+			},
+		},
 		{
 			name:   "team limit reached",
 			userID: userID.Hex(),
@@ -161,7 +186,7 @@ func TestTeamHandler_CreateTeam(t *testing.T) {
 
 			handler := NewTeamHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.POST("/teams", setUserID(tt.userID), handler.CreateTeam)
 			} else {
@@ -273,7 +298,7 @@ func TestTeamHandler_ListTeams(t *testing.T) {
 
 			handler := NewTeamHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.GET("/teams", setUserID(tt.userID), handler.ListTeams)
 			} else {
@@ -364,7 +389,7 @@ func TestTeamHandler_GetTeam(t *testing.T) {
 
 			handler := NewTeamHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
 				router.GET("/teams/:teamId", setTeamID(*tt.teamID), handler.GetTeam)
 			} else {
@@ -407,7 +432,8 @@ func TestTeamHandler_UpdateTeam(t *testing.T) {
 				Slug: &newSlug,
 			},
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.UpdateTeamFunc = func(ctx context.Context, tID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+				m.UpdateTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+					assert.Equal(t, userID, aID)
 					return &models.Team{
 						ID:        teamID,
 						Name:      *req.Name,
@@ -446,7 +472,7 @@ func TestTeamHandler_UpdateTeam(t *testing.T) {
 			teamID: &teamID,
 			body:   models.UpdateTeamRequest{Name: &newName},
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.UpdateTeamFunc = func(ctx context.Context, tID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+				m.UpdateTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
 					return nil, apperrors.ErrTeamNotFound
 				}
 			},
@@ -457,7 +483,7 @@ func TestTeamHandler_UpdateTeam(t *testing.T) {
 			teamID: &teamID,
 			body:   models.UpdateTeamRequest{Slug: &newSlug},
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.UpdateTeamFunc = func(ctx context.Context, tID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+				m.UpdateTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
 					return nil, apperrors.ErrTeamSlugTaken
 				}
 			},
@@ -468,7 +494,7 @@ func TestTeamHandler_UpdateTeam(t *testing.T) {
 			teamID: &teamID,
 			body:   models.UpdateTeamRequest{Name: &newName},
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.UpdateTeamFunc = func(ctx context.Context, tID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+				m.UpdateTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
 					return nil, errors.New("database error")
 				}
 			},
@@ -483,11 +509,11 @@ func TestTeamHandler_UpdateTeam(t *testing.T) {
 
 			handler := NewTeamHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
-				router.PUT("/teams/:teamId", setTeamID(*tt.teamID), handler.UpdateTeam)
+				router.PUT("/teams/:teamId", setTeamID(*tt.teamID), setUserID(userID.Hex()), handler.UpdateTeam)
 			} else {
-				router.PUT("/teams/:teamId", handler.UpdateTeam)
+				router.PUT("/teams/:teamId", setUserID(userID.Hex()), handler.UpdateTeam)
 			}
 
 			var body []byte
@@ -514,6 +540,7 @@ func TestTeamHandler_UpdateTeam(t *testing.T) {
 
 func TestTeamHandler_DeleteTeam(t *testing.T) {
 	teamID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
 
 	tests := []struct {
 		name           string
@@ -526,7 +553,9 @@ func TestTeamHandler_DeleteTeam(t *testing.T) {
 			name:   "successful delete team",
 			teamID: &teamID,
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.DeleteTeamFunc = func(ctx context.Context, tID primitive.ObjectID) error {
+				m.DeleteTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID) error {
+					assert.Equal(t, teamID, tID)
+					assert.Equal(t, userID, aID)
 					return nil
 				}
 			},
@@ -549,7 +578,7 @@ func TestTeamHandler_DeleteTeam(t *testing.T) {
 			name:   "team not found",
 			teamID: &teamID,
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.DeleteTeamFunc = func(ctx context.Context, tID primitive.ObjectID) error {
+				m.DeleteTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID) error {
 					return apperrors.ErrTeamNotFound
 				}
 			},
@@ -559,7 +588,7 @@ func TestTeamHandler_DeleteTeam(t *testing.T) {
 			name:   "internal server error",
 			teamID: &teamID,
 			mockSetup: func(m *mocks.MockTeamService) {
-				m.DeleteTeamFunc = func(ctx context.Context, tID primitive.ObjectID) error {
+				m.DeleteTeamFunc = func(ctx context.Context, tID, aID primitive.ObjectID) error {
 					return errors.New("database error")
 				}
 			},
@@ -574,11 +603,11 @@ func TestTeamHandler_DeleteTeam(t *testing.T) {
 
 			handler := NewTeamHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
-				router.DELETE("/teams/:teamId", setTeamID(*tt.teamID), handler.DeleteTeam)
+				router.DELETE("/teams/:teamId", setTeamID(*tt.teamID), setUserID(userID.Hex()), handler.DeleteTeam)
 			} else {
-				router.DELETE("/teams/:teamId", handler.DeleteTeam)
+				router.DELETE("/teams/:teamId", setUserID(userID.Hex()), handler.DeleteTeam)
 			}
 
 			req := httptest.NewRequest(http.MethodDelete, "/teams/"+teamID.Hex(), nil)
@@ -714,7 +743,7 @@ func TestTeamHandler_TransferOwnership(t *testing.T) {
 
 			handler := NewTeamHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			handlers := []gin.HandlerFunc{}
 			if tt.teamID != nil {
 				handlers = append(handlers, setTeamID(*tt.teamID))
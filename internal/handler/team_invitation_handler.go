@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"errors"
+	"strconv"
 
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -46,7 +47,7 @@ func NewTeamInvitationHandler(invitationService service.TeamInvitationServicer,
 func (h *TeamInvitationHandler) CreateInvitation(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
@@ -55,25 +56,13 @@ func (h *TeamInvitationHandler) CreateInvitation(c *gin.Context) {
 
 	var req models.CreateInvitationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	invitation, err := h.invitationService.CreateInvitation(c.Request.Context(), teamID, inviterID, &req)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrAlreadyMember) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrPendingInvitation) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrSeatsExceeded) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -97,13 +86,13 @@ func (h *TeamInvitationHandler) CreateInvitation(c *gin.Context) {
 func (h *TeamInvitationHandler) ListTeamInvitations(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	result, err := h.invitationService.ListTeamInvitations(c.Request.Context(), teamID)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -123,34 +112,160 @@ func (h *TeamInvitationHandler) ListTeamInvitations(c *gin.Context) {
 // @Failure      401     {object}  response.Response
 // @Failure      403     {object}  response.Response
 // @Failure      404     {object}  response.Response
+// @Failure      409     {object}  response.Response  "Invitation is no longer pending"
 // @Failure      500     {object}  response.Response
 // @Security     BearerAuth
 // @Router       /teams/{teamId}/invitations/{id} [delete]
 func (h *TeamInvitationHandler) CancelInvitation(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
+	userIDStr := middleware.GetUserID(c)
+	userID, _ := primitive.ObjectIDFromHex(userIDStr)
+
 	invitationID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid invitation id format")
+		_ = c.Error(apperrors.BadRequest("invalid invitation id format"))
 		return
 	}
 
-	if err := h.invitationService.CancelInvitation(c.Request.Context(), invitationID, teamID); err != nil {
-		if errors.Is(err, apperrors.ErrInvitationNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+	if err := h.invitationService.CancelInvitation(c.Request.Context(), invitationID, teamID, userID); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	response.Success(c, gin.H{"message": "invitation cancelled"})
 }
 
+// ListInvitationHistory godoc
+// @Summary      List team invitation history
+// @Description  List all invitations for a team in any status, newest first. Requires owner or admin role.
+// @Tags         team-invitations
+// @Accept       json
+// @Produce      json
+// @Param        teamId  path      string  true   "Team ID"
+// @Param        page    query     int     false  "Page number (default: 1)"
+// @Param        limit   query     int     false  "Items per page (default: 20, max: 50)"
+// @Success      200     {object}  response.Response{data=models.InvitationHistoryResponse}
+// @Failure      400     {object}  response.Response
+// @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      500     {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/invitations/history [get]
+func (h *TeamInvitationHandler) ListInvitationHistory(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	result, err := h.invitationService.ListInvitationHistory(c.Request.Context(), teamID, page, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ResendInvitation godoc
+// @Summary      Resend team invitation
+// @Description  Resend a pending or expired invitation and reset its expiry. Requires owner or admin role.
+// @Tags         team-invitations
+// @Accept       json
+// @Produce      json
+// @Param        teamId  path      string  true  "Team ID"
+// @Param        id      path      string  true  "Invitation ID"
+// @Success      200     {object}  response.Response{data=models.TeamInvitation}
+// @Failure      400     {object}  response.Response
+// @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Failure      409     {object}  response.Response  "Invitation is no longer pending, or the email has another pending invitation"
+// @Failure      500     {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/invitations/{id}/resend [post]
+func (h *TeamInvitationHandler) ResendInvitation(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	userIDStr := middleware.GetUserID(c)
+	userID, _ := primitive.ObjectIDFromHex(userIDStr)
+
+	invitationID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest("invalid invitation id format"))
+		return
+	}
+
+	invitation, err := h.invitationService.ResendInvitation(c.Request.Context(), invitationID, teamID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, invitation)
+}
+
+// ExtendInvitation godoc
+// @Summary      Extend team invitation
+// @Description  Push back the expiry of a pending or expired invitation. Requires owner or admin role.
+// @Tags         team-invitations
+// @Accept       json
+// @Produce      json
+// @Param        teamId  path      string                          true  "Team ID"
+// @Param        id      path      string                          true  "Invitation ID"
+// @Param        body    body      models.ExtendInvitationRequest  true  "Extension details"
+// @Success      200     {object}  response.Response{data=models.TeamInvitation}
+// @Failure      400     {object}  response.Response
+// @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Failure      409     {object}  response.Response  "Invitation is no longer pending, or the email has another pending invitation"
+// @Failure      500     {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/invitations/{id}/extend [post]
+func (h *TeamInvitationHandler) ExtendInvitation(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	userIDStr := middleware.GetUserID(c)
+	userID, _ := primitive.ObjectIDFromHex(userIDStr)
+
+	invitationID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest("invalid invitation id format"))
+		return
+	}
+
+	var req models.ExtendInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	invitation, err := h.invitationService.ExtendInvitation(c.Request.Context(), invitationID, teamID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, invitation)
+}
+
 // ListMyInvitations godoc
 // @Summary      List my invitations
 // @Description  List all pending invitations for the authenticated user
@@ -165,26 +280,26 @@ func (h *TeamInvitationHandler) CancelInvitation(c *gin.Context) {
 func (h *TeamInvitationHandler) ListMyInvitations(c *gin.Context) {
 	userIDStr := middleware.GetUserID(c)
 	if userIDStr == "" {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	// Get user to get their email
 	user, err := h.userService.GetUser(c.Request.Context(), userID)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	result, err := h.invitationService.ListMyInvitations(c.Request.Context(), user.Email)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -203,54 +318,39 @@ func (h *TeamInvitationHandler) ListMyInvitations(c *gin.Context) {
 // @Failure      401 {object}  response.Response
 // @Failure      403 {object}  response.Response
 // @Failure      404 {object}  response.Response
+// @Failure      409 {object}  response.Response  "Invitation is no longer pending"
 // @Failure      500 {object}  response.Response
 // @Security     BearerAuth
 // @Router       /invitations/{id}/accept [post]
 func (h *TeamInvitationHandler) AcceptInvitation(c *gin.Context) {
 	userIDStr := middleware.GetUserID(c)
 	if userIDStr == "" {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	invitationID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid invitation id format")
+		_ = c.Error(apperrors.BadRequest("invalid invitation id format"))
 		return
 	}
 
 	// Get user to get their email
 	user, err := h.userService.GetUser(c.Request.Context(), userID)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	result, err := h.invitationService.AcceptInvitation(c.Request.Context(), invitationID, userID, user.Email)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrInvitationNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrInvitationEmailMismatch) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrInvitationExpired) {
-			response.BadRequest(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrSeatsExceeded) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -269,45 +369,38 @@ func (h *TeamInvitationHandler) AcceptInvitation(c *gin.Context) {
 // @Failure      401 {object}  response.Response
 // @Failure      403 {object}  response.Response
 // @Failure      404 {object}  response.Response
+// @Failure      409 {object}  response.Response  "Invitation is no longer pending"
 // @Failure      500 {object}  response.Response
 // @Security     BearerAuth
 // @Router       /invitations/{id}/decline [post]
 func (h *TeamInvitationHandler) DeclineInvitation(c *gin.Context) {
 	userIDStr := middleware.GetUserID(c)
 	if userIDStr == "" {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	invitationID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid invitation id format")
+		_ = c.Error(apperrors.BadRequest("invalid invitation id format"))
 		return
 	}
 
 	// Get user to get their email
 	user, err := h.userService.GetUser(c.Request.Context(), userID)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
-	if err := h.invitationService.DeclineInvitation(c.Request.Context(), invitationID, user.Email); err != nil {
-		if errors.Is(err, apperrors.ErrInvitationNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrInvitationEmailMismatch) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+	if err := h.invitationService.DeclineInvitation(c.Request.Context(), invitationID, userID, user.Email); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -161,7 +161,7 @@ func TestTeamInvitationHandler_CreateInvitation(t *testing.T) {
 
 			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
 
-			router := gin.New()
+			router := newTestRouter()
 			handlers := []gin.HandlerFunc{}
 			if tt.teamID != nil {
 				handlers = append(handlers, setTeamID(*tt.teamID))
@@ -263,7 +263,7 @@ func TestTeamInvitationHandler_ListTeamInvitations(t *testing.T) {
 
 			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
 				router.GET("/teams/:teamId/invitations", setTeamID(*tt.teamID), handler.ListTeamInvitations)
 			} else {
@@ -300,7 +300,7 @@ func TestTeamInvitationHandler_CancelInvitation(t *testing.T) {
 			teamID:       &teamID,
 			invitationID: invitationID.Hex(),
 			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
-				m.CancelInvitationFunc = func(ctx context.Context, iID, tID primitive.ObjectID) error {
+				m.CancelInvitationFunc = func(ctx context.Context, iID, tID, uID primitive.ObjectID) error {
 					return nil
 				}
 			},
@@ -332,7 +332,7 @@ func TestTeamInvitationHandler_CancelInvitation(t *testing.T) {
 			teamID:       &teamID,
 			invitationID: invitationID.Hex(),
 			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
-				m.CancelInvitationFunc = func(ctx context.Context, iID, tID primitive.ObjectID) error {
+				m.CancelInvitationFunc = func(ctx context.Context, iID, tID, uID primitive.ObjectID) error {
 					return apperrors.ErrInvitationNotFound
 				}
 			},
@@ -343,7 +343,7 @@ func TestTeamInvitationHandler_CancelInvitation(t *testing.T) {
 			teamID:       &teamID,
 			invitationID: invitationID.Hex(),
 			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
-				m.CancelInvitationFunc = func(ctx context.Context, iID, tID primitive.ObjectID) error {
+				m.CancelInvitationFunc = func(ctx context.Context, iID, tID, uID primitive.ObjectID) error {
 					return errors.New("database error")
 				}
 			},
@@ -359,7 +359,7 @@ func TestTeamInvitationHandler_CancelInvitation(t *testing.T) {
 
 			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
 				router.DELETE("/teams/:teamId/invitations/:id", setTeamID(*tt.teamID), handler.CancelInvitation)
 			} else {
@@ -379,6 +379,298 @@ func TestTeamInvitationHandler_CancelInvitation(t *testing.T) {
 	}
 }
 
+func TestTeamInvitationHandler_ListInvitationHistory(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		teamID         *primitive.ObjectID
+		query          string
+		mockSetup      func(*mocks.MockTeamInvitationService, *mocks.MockUserService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "successful list invitation history",
+			teamID: &teamID,
+			query:  "?page=2&limit=5",
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ListInvitationHistoryFunc = func(ctx context.Context, tID primitive.ObjectID, page, limit int) (*models.InvitationHistoryResponse, error) {
+					assert.Equal(t, 2, page)
+					assert.Equal(t, 5, limit)
+					return &models.InvitationHistoryResponse{
+						Items: []models.TeamInvitation{
+							{ID: primitive.NewObjectID(), TeamID: tID, Status: models.InvitationStatusDeclined},
+						},
+						Pagination: models.Pagination{Page: page, Limit: limit, TotalItems: 6, TotalPages: 2},
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				items := data["items"].([]interface{})
+				assert.Len(t, items, 1)
+				assert.Equal(t, "declined", items[0].(map[string]interface{})["status"])
+			},
+		},
+		{
+			name:           "missing team ID in context",
+			teamID:         nil,
+			mockSetup:      func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "internal server error",
+			teamID: &teamID,
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ListInvitationHistoryFunc = func(ctx context.Context, tID primitive.ObjectID, page, limit int) (*models.InvitationHistoryResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationService := &mocks.MockTeamInvitationService{}
+			mockUserService := &mocks.MockUserService{}
+			tt.mockSetup(mockInvitationService, mockUserService)
+
+			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
+
+			router := newTestRouter()
+			if tt.teamID != nil {
+				router.GET("/teams/:teamId/invitations/history", setTeamID(*tt.teamID), handler.ListInvitationHistory)
+			} else {
+				router.GET("/teams/:teamId/invitations/history", handler.ListInvitationHistory)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/invitations/history"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestTeamInvitationHandler_ResendInvitation(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	invitationID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		teamID         *primitive.ObjectID
+		invitationID   string
+		mockSetup      func(*mocks.MockTeamInvitationService, *mocks.MockUserService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "successful resend invitation",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ResendInvitationFunc = func(ctx context.Context, iID, tID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+					return &models.TeamInvitation{ID: iID, TeamID: tID, ResendCount: 1}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				assert.Equal(t, float64(1), data["resendCount"])
+			},
+		},
+		{
+			name:           "missing team ID in context",
+			teamID:         nil,
+			invitationID:   invitationID.Hex(),
+			mockSetup:      func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid invitation ID format",
+			teamID:         &teamID,
+			invitationID:   "invalid-id",
+			mockSetup:      func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invitation not found",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ResendInvitationFunc = func(ctx context.Context, iID, tID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+					return nil, apperrors.ErrInvitationNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "invitation no longer pending",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ResendInvitationFunc = func(ctx context.Context, iID, tID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+					return nil, apperrors.ErrInvitationNotPending
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:         "seats exceeded",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ResendInvitationFunc = func(ctx context.Context, iID, tID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+					return nil, apperrors.ErrSeatsExceeded
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:         "internal server error",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ResendInvitationFunc = func(ctx context.Context, iID, tID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationService := &mocks.MockTeamInvitationService{}
+			mockUserService := &mocks.MockUserService{}
+			tt.mockSetup(mockInvitationService, mockUserService)
+
+			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
+
+			router := newTestRouter()
+			if tt.teamID != nil {
+				router.POST("/teams/:teamId/invitations/:id/resend", setTeamID(*tt.teamID), handler.ResendInvitation)
+			} else {
+				router.POST("/teams/:teamId/invitations/:id/resend", handler.ResendInvitation)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/teams/"+teamID.Hex()+"/invitations/"+tt.invitationID+"/resend", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestTeamInvitationHandler_ExtendInvitation(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	invitationID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		teamID         *primitive.ObjectID
+		invitationID   string
+		body           interface{}
+		mockSetup      func(*mocks.MockTeamInvitationService, *mocks.MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:         "successful extend invitation",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			body:         models.ExtendInvitationRequest{Days: 3},
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ExtendInvitationFunc = func(ctx context.Context, iID, tID, extendedBy primitive.ObjectID, req *models.ExtendInvitationRequest) (*models.TeamInvitation, error) {
+					assert.Equal(t, 3, req.Days)
+					return &models.TeamInvitation{ID: iID, TeamID: tID, ExpiresAt: time.Now().AddDate(0, 0, req.Days)}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing team ID in context",
+			teamID:         nil,
+			invitationID:   invitationID.Hex(),
+			body:           models.ExtendInvitationRequest{Days: 3},
+			mockSetup:      func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid invitation ID format",
+			teamID:         &teamID,
+			invitationID:   "invalid-id",
+			body:           models.ExtendInvitationRequest{Days: 3},
+			mockSetup:      func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "days out of range",
+			teamID:         &teamID,
+			invitationID:   invitationID.Hex(),
+			body:           models.ExtendInvitationRequest{Days: 31},
+			mockSetup:      func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invitation no longer pending",
+			teamID:       &teamID,
+			invitationID: invitationID.Hex(),
+			body:         models.ExtendInvitationRequest{Days: 3},
+			mockSetup: func(m *mocks.MockTeamInvitationService, u *mocks.MockUserService) {
+				m.ExtendInvitationFunc = func(ctx context.Context, iID, tID, extendedBy primitive.ObjectID, req *models.ExtendInvitationRequest) (*models.TeamInvitation, error) {
+					return nil, apperrors.ErrInvitationNotPending
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationService := &mocks.MockTeamInvitationService{}
+			mockUserService := &mocks.MockUserService{}
+			tt.mockSetup(mockInvitationService, mockUserService)
+
+			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
+
+			router := newTestRouter()
+			if tt.teamID != nil {
+				router.POST("/teams/:teamId/invitations/:id/extend", setTeamID(*tt.teamID), handler.ExtendInvitation)
+			} else {
+				router.POST("/teams/:teamId/invitations/:id/extend", handler.ExtendInvitation)
+			}
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/teams/"+teamID.Hex()+"/invitations/"+tt.invitationID+"/extend", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestTeamInvitationHandler_ListMyInvitations(t *testing.T) {
 	userID := primitive.NewObjectID()
 	invitationID := primitive.NewObjectID()
@@ -472,7 +764,7 @@ func TestTeamInvitationHandler_ListMyInvitations(t *testing.T) {
 
 			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.GET("/invitations", setUserID(tt.userID), handler.ListMyInvitations)
 			} else {
@@ -641,7 +933,7 @@ func TestTeamInvitationHandler_AcceptInvitation(t *testing.T) {
 
 			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.POST("/invitations/:id/accept", setUserID(tt.userID), handler.AcceptInvitation)
 			} else {
@@ -681,7 +973,7 @@ func TestTeamInvitationHandler_DeclineInvitation(t *testing.T) {
 				u.GetUserFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
 					return &models.User{ID: userID, Email: "user@example.com", Name: "Test User"}, nil
 				}
-				m.DeclineInvitationFunc = func(ctx context.Context, iID primitive.ObjectID, email string) error {
+				m.DeclineInvitationFunc = func(ctx context.Context, iID, uID primitive.ObjectID, email string) error {
 					return nil
 				}
 			},
@@ -734,7 +1026,7 @@ func TestTeamInvitationHandler_DeclineInvitation(t *testing.T) {
 				u.GetUserFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
 					return &models.User{ID: userID, Email: "user@example.com"}, nil
 				}
-				m.DeclineInvitationFunc = func(ctx context.Context, iID primitive.ObjectID, email string) error {
+				m.DeclineInvitationFunc = func(ctx context.Context, iID, uID primitive.ObjectID, email string) error {
 					return apperrors.ErrInvitationNotFound
 				}
 			},
@@ -748,7 +1040,7 @@ func TestTeamInvitationHandler_DeclineInvitation(t *testing.T) {
 				u.GetUserFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
 					return &models.User{ID: userID, Email: "wrong@example.com"}, nil
 				}
-				m.DeclineInvitationFunc = func(ctx context.Context, iID primitive.ObjectID, email string) error {
+				m.DeclineInvitationFunc = func(ctx context.Context, iID, uID primitive.ObjectID, email string) error {
 					return apperrors.ErrInvitationEmailMismatch
 				}
 			},
@@ -762,7 +1054,7 @@ func TestTeamInvitationHandler_DeclineInvitation(t *testing.T) {
 				u.GetUserFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
 					return &models.User{ID: userID, Email: "user@example.com"}, nil
 				}
-				m.DeclineInvitationFunc = func(ctx context.Context, iID primitive.ObjectID, email string) error {
+				m.DeclineInvitationFunc = func(ctx context.Context, iID, uID primitive.ObjectID, email string) error {
 					return errors.New("database error")
 				}
 			},
@@ -778,7 +1070,7 @@ func TestTeamInvitationHandler_DeclineInvitation(t *testing.T) {
 
 			handler := NewTeamInvitationHandler(mockInvitationService, mockUserService)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.POST("/invitations/:id/decline", setUserID(tt.userID), handler.DeclineInvitation)
 			} else {
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -15,17 +18,19 @@ import (
 
 // TeamMemberHandler handles HTTP requests for team member operations.
 type TeamMemberHandler struct {
-	service service.TeamMemberServicer
+	service    service.TeamMemberServicer
+	authorizer authz.Authorizer
 }
 
 // NewTeamMemberHandler creates a new TeamMemberHandler.
-func NewTeamMemberHandler(service service.TeamMemberServicer) *TeamMemberHandler {
-	return &TeamMemberHandler{service: service}
+// The authorizer decides which members see each member's MFA status.
+func NewTeamMemberHandler(service service.TeamMemberServicer, authorizer authz.Authorizer) *TeamMemberHandler {
+	return &TeamMemberHandler{service: service, authorizer: authorizer}
 }
 
 // ListMembers godoc
 // @Summary      List team members
-// @Description  Retrieve all members of a team with their details
+// @Description  Retrieve all members of a team with their details. Members who can change member roles also see each member's MFA status.
 // @Tags         team-members
 // @Accept       json
 // @Produce      json
@@ -40,13 +45,26 @@ func NewTeamMemberHandler(service service.TeamMemberServicer) *TeamMemberHandler
 func (h *TeamMemberHandler) ListMembers(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
-	result, err := h.service.ListMembers(c.Request.Context(), teamID)
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
+		return
+	}
+
+	// MFA status is shown to members who manage other members' roles
+	includeMFAStatus, err := h.authorizer.CanPerform(c.Request.Context(), userID, teamID, authz.ActionMemberUpdateRole)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	result, err := h.service.ListMembers(c.Request.Context(), teamID, includeMFAStatus)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -72,13 +90,13 @@ func (h *TeamMemberHandler) ListMembers(c *gin.Context) {
 func (h *TeamMemberHandler) RemoveMember(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	targetUserID, err := primitive.ObjectIDFromHex(c.Param("userId"))
 	if err != nil {
-		response.BadRequest(c, "invalid user id format")
+		_ = c.Error(apperrors.BadRequest("invalid user id format"))
 		return
 	}
 
@@ -86,23 +104,7 @@ func (h *TeamMemberHandler) RemoveMember(c *gin.Context) {
 	requestingUserID, _ := primitive.ObjectIDFromHex(requestingUserIDStr)
 
 	if err := h.service.RemoveMember(c.Request.Context(), teamID, targetUserID, requestingUserID); err != nil {
-		if errors.Is(err, apperrors.ErrNotTeamMember) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrCannotRemoveOwner) {
-			response.BadRequest(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrCannotRemoveSelf) {
-			response.BadRequest(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrInsufficientPermissions) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(memberNotFound(err))
 		return
 	}
 
@@ -129,13 +131,13 @@ func (h *TeamMemberHandler) RemoveMember(c *gin.Context) {
 func (h *TeamMemberHandler) UpdateRole(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	targetUserID, err := primitive.ObjectIDFromHex(c.Param("userId"))
 	if err != nil {
-		response.BadRequest(c, "invalid user id format")
+		_ = c.Error(apperrors.BadRequest("invalid user id format"))
 		return
 	}
 
@@ -144,28 +146,12 @@ func (h *TeamMemberHandler) UpdateRole(c *gin.Context) {
 
 	var req models.UpdateRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	if err := h.service.UpdateRole(c.Request.Context(), teamID, targetUserID, requestingUserID, req.Role); err != nil {
-		if errors.Is(err, apperrors.ErrNotTeamMember) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrCannotChangeOwnerRole) {
-			response.BadRequest(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrInvalidRole) {
-			response.BadRequest(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrInsufficientPermissions) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(memberNotFound(err))
 		return
 	}
 
@@ -190,7 +176,7 @@ func (h *TeamMemberHandler) UpdateRole(c *gin.Context) {
 func (h *TeamMemberHandler) LeaveTeam(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
@@ -198,17 +184,18 @@ func (h *TeamMemberHandler) LeaveTeam(c *gin.Context) {
 	userID, _ := primitive.ObjectIDFromHex(userIDStr)
 
 	if err := h.service.LeaveTeam(c.Request.Context(), teamID, userID); err != nil {
-		if errors.Is(err, apperrors.ErrNotTeamMember) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrOwnerCannotLeave) {
-			response.BadRequest(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(memberNotFound(err))
 		return
 	}
 
 	response.Success(c, gin.H{"message": "left team successfully"})
 }
+
+// memberNotFound reports ErrNotTeamMember as 404 Not Found, since here it means
+// the membership being acted on does not exist.
+func memberNotFound(err error) error {
+	if errors.Is(err, apperrors.ErrNotTeamMember) {
+		return apperrors.ErrNotTeamMember.WithStatus(http.StatusNotFound)
+	}
+	return err
+}
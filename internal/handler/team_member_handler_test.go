@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"gin-sample/internal/authz"
+	authzmocks "gin-sample/internal/authz/mocks"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
@@ -18,14 +20,28 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
 )
 
+// newMemberAuthorizer returns an authorizer mock that answers every action check with allowed.
+func newMemberAuthorizer(t *testing.T, allowed bool) *authzmocks.MockAuthorizer {
+	ctrl := gomock.NewController(t)
+	mockAuthz := authzmocks.NewMockAuthorizer(ctrl)
+	mockAuthz.EXPECT().
+		CanPerform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(allowed, nil).
+		AnyTimes()
+	return mockAuthz
+}
+
 func TestNewTeamMemberHandler(t *testing.T) {
 	mockService := &mocks.MockTeamMemberService{}
-	handler := NewTeamMemberHandler(mockService)
+	mockAuthz := newMemberAuthorizer(t, false)
+	handler := NewTeamMemberHandler(mockService, mockAuthz)
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockService, handler.service)
+	assert.Equal(t, mockAuthz, handler.authorizer)
 }
 
 func TestTeamMemberHandler_ListMembers(t *testing.T) {
@@ -44,7 +60,7 @@ func TestTeamMemberHandler_ListMembers(t *testing.T) {
 			name:   "successful list members",
 			teamID: &teamID,
 			mockSetup: func(m *mocks.MockTeamMemberService) {
-				m.ListMembersFunc = func(ctx context.Context, tID primitive.ObjectID) (*models.TeamMemberListResponse, error) {
+				m.ListMembersFunc = func(ctx context.Context, tID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error) {
 					return &models.TeamMemberListResponse{
 						Items: []models.TeamMemberWithUser{
 							{
@@ -79,7 +95,7 @@ func TestTeamMemberHandler_ListMembers(t *testing.T) {
 			name:   "internal server error",
 			teamID: &teamID,
 			mockSetup: func(m *mocks.MockTeamMemberService) {
-				m.ListMembersFunc = func(ctx context.Context, tID primitive.ObjectID) (*models.TeamMemberListResponse, error) {
+				m.ListMembersFunc = func(ctx context.Context, tID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error) {
 					return nil, errors.New("database error")
 				}
 			},
@@ -92,13 +108,13 @@ func TestTeamMemberHandler_ListMembers(t *testing.T) {
 			mockService := &mocks.MockTeamMemberService{}
 			tt.mockSetup(mockService)
 
-			handler := NewTeamMemberHandler(mockService)
+			handler := NewTeamMemberHandler(mockService, newMemberAuthorizer(t, false))
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
-				router.GET("/teams/:teamId/members", setTeamID(*tt.teamID), handler.ListMembers)
+				router.GET("/teams/:teamId/members", setUserID(userID.Hex()), setTeamID(*tt.teamID), handler.ListMembers)
 			} else {
-				router.GET("/teams/:teamId/members", handler.ListMembers)
+				router.GET("/teams/:teamId/members", setUserID(userID.Hex()), handler.ListMembers)
 			}
 
 			req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/members", nil)
@@ -114,6 +130,71 @@ func TestTeamMemberHandler_ListMembers(t *testing.T) {
 	}
 }
 
+func TestTeamMemberHandler_ListMembers_MFAVisibility(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		authzSetup     func(*authzmocks.MockAuthorizer)
+		expectedStatus int
+		expectedMFA    bool
+	}{
+		{
+			name: "can update member roles",
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().CanPerform(gomock.Any(), userID, teamID, authz.ActionMemberUpdateRole).Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMFA:    true,
+		},
+		{
+			name: "cannot update member roles",
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().CanPerform(gomock.Any(), userID, teamID, authz.ActionMemberUpdateRole).Return(false, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMFA:    false,
+		},
+		{
+			name: "authorizer error",
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().CanPerform(gomock.Any(), userID, teamID, authz.ActionMemberUpdateRole).Return(false, errors.New("authz error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMFA bool
+			mockService := &mocks.MockTeamMemberService{
+				ListMembersFunc: func(ctx context.Context, tID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error) {
+					gotMFA = includeMFAStatus
+					return &models.TeamMemberListResponse{Items: []models.TeamMemberWithUser{}}, nil
+				},
+			}
+
+			ctrl := gomock.NewController(t)
+			mockAuthz := authzmocks.NewMockAuthorizer(ctrl)
+			tt.authzSetup(mockAuthz)
+
+			handler := NewTeamMemberHandler(mockService, mockAuthz)
+
+			router := newTestRouter()
+			router.GET("/teams/:teamId/members", setUserID(userID.Hex()), setTeamID(teamID), handler.ListMembers)
+
+			req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/members", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedMFA, gotMFA)
+		})
+	}
+}
+
 func TestTeamMemberHandler_RemoveMember(t *testing.T) {
 	teamID := primitive.NewObjectID()
 	requestingUserID := primitive.NewObjectID()
@@ -230,9 +311,9 @@ func TestTeamMemberHandler_RemoveMember(t *testing.T) {
 			mockService := &mocks.MockTeamMemberService{}
 			tt.mockSetup(mockService)
 
-			handler := NewTeamMemberHandler(mockService)
+			handler := NewTeamMemberHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			handlers := []gin.HandlerFunc{}
 			if tt.teamID != nil {
 				handlers = append(handlers, setTeamID(*tt.teamID))
@@ -390,9 +471,9 @@ func TestTeamMemberHandler_UpdateRole(t *testing.T) {
 			mockService := &mocks.MockTeamMemberService{}
 			tt.mockSetup(mockService)
 
-			handler := NewTeamMemberHandler(mockService)
+			handler := NewTeamMemberHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			handlers := []gin.HandlerFunc{}
 			if tt.teamID != nil {
 				handlers = append(handlers, setTeamID(*tt.teamID))
@@ -502,9 +583,9 @@ func TestTeamMemberHandler_LeaveTeam(t *testing.T) {
 			mockService := &mocks.MockTeamMemberService{}
 			tt.mockSetup(mockService)
 
-			handler := NewTeamMemberHandler(mockService)
+			handler := NewTeamMemberHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			handlers := []gin.HandlerFunc{}
 			if tt.teamID != nil {
 				handlers = append(handlers, setTeamID(*tt.teamID))
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/middleware"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
+	"gin-sample/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TeamRoleHandler handles HTTP requests for custom team roles.
+type TeamRoleHandler struct {
+	service service.TeamRoleServicer
+}
+
+// NewTeamRoleHandler creates a new TeamRoleHandler.
+func NewTeamRoleHandler(service service.TeamRoleServicer) *TeamRoleHandler {
+	return &TeamRoleHandler{service: service}
+}
+
+// ListRoles godoc
+// @Summary      List team roles
+// @Description  List the built-in roles (owner, admin, member, contributor, viewer) and the team's custom roles with their actions.
+// @Tags         team-roles
+// @Produce      json
+// @Param        teamId  path      string  true  "Team ID"
+// @Success      200     {object}  response.Response{data=models.TeamRoleListResponse}
+// @Failure      400     {object}  response.Response
+// @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      500     {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/roles [get]
+func (h *TeamRoleHandler) ListRoles(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	result, err := h.service.ListRoles(c.Request.Context(), teamID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CreateRole godoc
+// @Summary      Create custom team role
+// @Description  Define a custom role with a chosen set of actions. Requires owner role.
+// @Description  Owner-only actions (team:delete, team:transfer, role:manage) cannot be granted.
+// @Description  Roles that create memos also need memo:update_own to confirm uploads and retry transcriptions of their memos.
+// @Tags         team-roles
+// @Accept       json
+// @Produce      json
+// @Param        teamId   path      string                        true  "Team ID"
+// @Param        request  body      models.CreateTeamRoleRequest  true  "Role name and actions"
+// @Success      201      {object}  response.Response{data=models.TeamRole}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      409      {object}  response.Response  "Role name taken or role limit reached"
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/roles [post]
+func (h *TeamRoleHandler) CreateRole(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	userIDStr := middleware.GetUserID(c)
+	userID, _ := primitive.ObjectIDFromHex(userIDStr)
+
+	var req models.CreateTeamRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	role, err := h.service.CreateRole(c.Request.Context(), teamID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Created(c, role)
+}
+
+// UpdateRole godoc
+// @Summary      Update custom team role
+// @Description  Replace the actions of a custom role. Members with the role are affected immediately. Requires owner role.
+// @Tags         team-roles
+// @Accept       json
+// @Produce      json
+// @Param        teamId   path      string                        true  "Team ID"
+// @Param        name     path      string                        true  "Role name"
+// @Param        request  body      models.UpdateTeamRoleRequest  true  "New actions"
+// @Success      200      {object}  response.Response{data=models.TeamRole}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/roles/{name} [put]
+func (h *TeamRoleHandler) UpdateRole(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	userIDStr := middleware.GetUserID(c)
+	userID, _ := primitive.ObjectIDFromHex(userIDStr)
+
+	var req models.UpdateTeamRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
+		return
+	}
+
+	role, err := h.service.UpdateRole(c.Request.Context(), teamID, userID, c.Param("name"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, role)
+}
+
+// DeleteRole godoc
+// @Summary      Delete custom team role
+// @Description  Delete a custom role. Fails if the role is assigned to a member or a pending invitation. Requires owner role.
+// @Tags         team-roles
+// @Param        teamId  path      string  true  "Team ID"
+// @Param        name    path      string  true  "Role name"
+// @Success      204     "No Content"
+// @Failure      400     {object}  response.Response
+// @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Failure      409     {object}  response.Response  "Role is in use"
+// @Failure      500     {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/roles/{name} [delete]
+func (h *TeamRoleHandler) DeleteRole(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	userIDStr := middleware.GetUserID(c)
+	userID, _ := primitive.ObjectIDFromHex(userIDStr)
+
+	if err := h.service.DeleteRole(c.Request.Context(), teamID, userID, c.Param("name")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	"gin-sample/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewTeamRoleHandler(t *testing.T) {
+	mockService := &mocks.MockTeamRoleService{}
+	handler := NewTeamRoleHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.service)
+}
+
+func TestTeamRoleHandler_ListRoles(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*mocks.MockTeamRoleService)
+		expectedStatus int
+	}{
+		{
+			name: "successful list",
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.ListRolesFunc = func(ctx context.Context, tID primitive.ObjectID) (*models.TeamRoleListResponse, error) {
+					assert.Equal(t, teamID, tID)
+					return &models.TeamRoleListResponse{Custom: []models.TeamRole{}}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "service error",
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.ListRolesFunc = func(ctx context.Context, tID primitive.ObjectID) (*models.TeamRoleListResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockTeamRoleService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTeamRoleHandler(mockService)
+
+			router := newTestRouter()
+			router.GET("/teams/:teamId/roles", setTeamID(teamID), handler.ListRoles)
+
+			req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/roles", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTeamRoleHandler_CreateRole(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockTeamRoleService)
+		expectedStatus int
+	}{
+		{
+			name: "successful create",
+			body: models.CreateTeamRoleRequest{Name: "reviewer", Actions: []string{"team:view", "memo:view"}},
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.CreateRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, req *models.CreateTeamRoleRequest) (*models.TeamRole, error) {
+					return &models.TeamRole{TeamID: tID, Name: req.Name, Actions: req.Actions}, nil
+				}
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "owner-only action cannot be granted",
+			body:           models.CreateTeamRoleRequest{Name: "reviewer", Actions: []string{"team:delete"}},
+			mockSetup:      func(m *mocks.MockTeamRoleService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid role name",
+			body:           models.CreateTeamRoleRequest{Name: "Bad Name", Actions: []string{"team:view"}},
+			mockSetup:      func(m *mocks.MockTeamRoleService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "role name taken",
+			body: models.CreateTeamRoleRequest{Name: "reviewer", Actions: []string{"team:view"}},
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.CreateRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, req *models.CreateTeamRoleRequest) (*models.TeamRole, error) {
+					return nil, apperrors.ErrTeamRoleExists
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockTeamRoleService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTeamRoleHandler(mockService)
+
+			router := newTestRouter()
+			router.POST("/teams/:teamId/roles", setTeamID(teamID), handler.CreateRole)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/teams/"+teamID.Hex()+"/roles", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTeamRoleHandler_UpdateRole(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockSetup      func(*mocks.MockTeamRoleService)
+		expectedStatus int
+	}{
+		{
+			name: "successful update",
+			body: models.UpdateTeamRoleRequest{Actions: []string{"team:view"}},
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.UpdateRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, name string, req *models.UpdateTeamRoleRequest) (*models.TeamRole, error) {
+					assert.Equal(t, "reviewer", name)
+					return &models.TeamRole{Name: name, Actions: req.Actions}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty actions",
+			body:           models.UpdateTeamRoleRequest{Actions: []string{}},
+			mockSetup:      func(m *mocks.MockTeamRoleService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "role not found",
+			body: models.UpdateTeamRoleRequest{Actions: []string{"team:view"}},
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.UpdateRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, name string, req *models.UpdateTeamRoleRequest) (*models.TeamRole, error) {
+					return nil, apperrors.ErrTeamRoleNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockTeamRoleService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTeamRoleHandler(mockService)
+
+			router := newTestRouter()
+			router.PUT("/teams/:teamId/roles/:name", setTeamID(teamID), handler.UpdateRole)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPut, "/teams/"+teamID.Hex()+"/roles/reviewer", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTeamRoleHandler_DeleteRole(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*mocks.MockTeamRoleService)
+		expectedStatus int
+	}{
+		{
+			name: "successful delete",
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.DeleteRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, name string) error {
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "role in use",
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.DeleteRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, name string) error {
+					return apperrors.ErrTeamRoleInUse
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "role not found",
+			mockSetup: func(m *mocks.MockTeamRoleService) {
+				m.DeleteRoleFunc = func(ctx context.Context, tID, requester primitive.ObjectID, name string) error {
+					return apperrors.ErrTeamRoleNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockTeamRoleService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTeamRoleHandler(mockService)
+
+			router := newTestRouter()
+			router.DELETE("/teams/:teamId/roles/:name", setTeamID(teamID), handler.DeleteRole)
+
+			req := httptest.NewRequest(http.MethodDelete, "/teams/"+teamID.Hex()+"/roles/reviewer", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -2,11 +2,10 @@
 package handler
 
 import (
-	"errors"
-
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -40,17 +39,13 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := primitive.ObjectIDFromHex(idStr)
 	if err != nil {
-		response.BadRequest(c, "invalid user ID format")
+		_ = c.Error(apperrors.BadRequest("invalid user ID format"))
 		return
 	}
 
 	user, err := h.service.GetUser(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -70,7 +65,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
 	users, err := h.service.GetAllUsers(c.Request.Context())
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -96,27 +91,19 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := primitive.ObjectIDFromHex(idStr)
 	if err != nil {
-		response.BadRequest(c, "invalid user ID format")
+		_ = c.Error(apperrors.BadRequest("invalid user ID format"))
 		return
 	}
 
 	var req models.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	user, err := h.service.UpdateUser(c.Request.Context(), id, &req)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrUserAlreadyExists) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -140,17 +127,13 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := primitive.ObjectIDFromHex(idStr)
 	if err != nil {
-		response.BadRequest(c, "invalid user ID format")
+		_ = c.Error(apperrors.BadRequest("invalid user ID format"))
 		return
 	}
 
 	err = h.service.DeleteUser(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -14,7 +14,6 @@ import (
 	"gin-sample/internal/models"
 	"gin-sample/internal/service/mocks"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -104,7 +103,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 
 			handler := NewUserHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.GET("/users/:id", handler.GetUser)
 
 			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.userID, nil)
@@ -185,7 +184,7 @@ func TestUserHandler_GetAllUsers(t *testing.T) {
 
 			handler := NewUserHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.GET("/users", handler.GetAllUsers)
 
 			req := httptest.NewRequest(http.MethodGet, "/users", nil)
@@ -314,7 +313,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 			handler := NewUserHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.PUT("/users/:id", handler.UpdateUser)
 
 			var body []byte
@@ -409,7 +408,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 
 			handler := NewUserHandler(mockService)
 
-			router := gin.New()
+			router := newTestRouter()
 			router.DELETE("/users/:id", handler.DeleteUser)
 
 			req := httptest.NewRequest(http.MethodDelete, "/users/"+tt.userID, nil)
@@ -1,13 +1,14 @@
 package handler
 
 import (
-	"errors"
 	"strconv"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service"
+	"gin-sample/internal/validator"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -16,12 +17,14 @@ import (
 
 // VoiceMemoHandler handles HTTP requests for voice memo operations.
 type VoiceMemoHandler struct {
-	service service.VoiceMemoServicer
+	service    service.VoiceMemoServicer
+	authorizer authz.Authorizer
 }
 
 // NewVoiceMemoHandler creates a new VoiceMemoHandler.
-func NewVoiceMemoHandler(service service.VoiceMemoServicer) *VoiceMemoHandler {
-	return &VoiceMemoHandler{service: service}
+// The authorizer checks per-memo permissions on team memos, e.g. members acting only on memos they created.
+func NewVoiceMemoHandler(service service.VoiceMemoServicer, authorizer authz.Authorizer) *VoiceMemoHandler {
+	return &VoiceMemoHandler{service: service, authorizer: authorizer}
 }
 
 // ListVoiceMemos godoc
@@ -41,7 +44,7 @@ func (h *VoiceMemoHandler) ListVoiceMemos(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
@@ -52,7 +55,7 @@ func (h *VoiceMemoHandler) ListVoiceMemos(c *gin.Context) {
 	// Get memos from service
 	result, err := h.service.ListByUserID(c.Request.Context(), userID.(string), page, limit)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -76,36 +79,28 @@ func (h *VoiceMemoHandler) DeleteVoiceMemo(c *gin.Context) {
 	// Validate and parse memo ID from path
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	// Validate and parse user ID
 	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	// Call service to delete (atomic operation with ownership check)
 	err = h.service.DeleteVoiceMemo(c.Request.Context(), memoID, userID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoUnauthorized) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -131,7 +126,7 @@ func (h *VoiceMemoHandler) DeleteVoiceMemo(c *gin.Context) {
 func (h *VoiceMemoHandler) ListTeamVoiceMemos(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
@@ -140,7 +135,7 @@ func (h *VoiceMemoHandler) ListTeamVoiceMemos(c *gin.Context) {
 
 	result, err := h.service.ListByTeamID(c.Request.Context(), teamID.Hex(), page, limit)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -166,29 +161,25 @@ func (h *VoiceMemoHandler) ListTeamVoiceMemos(c *gin.Context) {
 func (h *VoiceMemoHandler) GetTeamVoiceMemo(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
 		return
 	}
 
 	memo, err := h.service.GetVoiceMemo(c.Request.Context(), memoID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	// Verify memo belongs to this team
 	if memo.TeamID == nil || *memo.TeamID != teamID {
-		response.NotFound(c, "voice memo not found")
+		_ = c.Error(apperrors.NotFound("voice memo not found"))
 		return
 	}
 
@@ -198,6 +189,7 @@ func (h *VoiceMemoHandler) GetTeamVoiceMemo(c *gin.Context) {
 // DeleteTeamVoiceMemo godoc
 // @Summary      Delete team voice memo
 // @Description  Soft delete a voice memo from a team. Idempotent - returns 204 even if already deleted.
+// @Description  Members and contributors can delete only memos they created; admins and owners can delete any team memo.
 // @Tags         team-voice-memos
 // @Param        teamId path      string  true  "Team ID"
 // @Param        id     path      string  true  "Voice Memo ID"
@@ -212,23 +204,25 @@ func (h *VoiceMemoHandler) GetTeamVoiceMemo(c *gin.Context) {
 func (h *VoiceMemoHandler) DeleteTeamVoiceMemo(c *gin.Context) {
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
+		return
+	}
+
+	userID, ok := h.authorizeTeamMemo(c, memoID, teamID, authz.ActionMemoDelete)
+	if !ok {
 		return
 	}
 
 	// Call service to delete (atomic operation with team check)
-	if err := h.service.DeleteTeamVoiceMemo(c.Request.Context(), memoID, teamID); err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		response.InternalError(c)
+	err = h.service.DeleteTeamVoiceMemo(c.Request.Context(), memoID, teamID, userID)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -245,6 +239,8 @@ func (h *VoiceMemoHandler) DeleteTeamVoiceMemo(c *gin.Context) {
 // @Success      201     {object}  response.Response{data=models.CreateVoiceMemoResponse}
 // @Failure      400     {object}  response.Response
 // @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response  "Memo or storage quota exceeded (code: memo_quota_exceeded, storage_quota_exceeded)"
+// @Failure      429     {object}  response.Response  "Rate limit exceeded; see Retry-After"
 // @Failure      500     {object}  response.Response
 // @Security     BearerAuth
 // @Router       /voice-memos [post]
@@ -252,27 +248,27 @@ func (h *VoiceMemoHandler) CreateVoiceMemo(c *gin.Context) {
 	// Get user ID from context
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	// Bind and validate request
 	var req models.CreateVoiceMemoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	// Create memo via service
 	result, err := h.service.CreateVoiceMemo(c.Request.Context(), userID, &req)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -291,6 +287,8 @@ func (h *VoiceMemoHandler) CreateVoiceMemo(c *gin.Context) {
 // @Failure      400     {object}  response.Response
 // @Failure      401     {object}  response.Response
 // @Failure      403     {object}  response.Response
+// @Failure      403     {object}  response.Response  "Memo or storage quota exceeded (code: memo_quota_exceeded, storage_quota_exceeded)"
+// @Failure      429     {object}  response.Response  "Rate limit exceeded; see Retry-After"
 // @Failure      500     {object}  response.Response
 // @Security     BearerAuth
 // @Router       /teams/{teamId}/voice-memos [post]
@@ -298,34 +296,34 @@ func (h *VoiceMemoHandler) CreateTeamVoiceMemo(c *gin.Context) {
 	// Get team ID from middleware context
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	// Get user ID from context
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	// Bind and validate request
 	var req models.CreateVoiceMemoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		_ = c.Error(validator.BindError(err, c.GetHeader("Accept-Language")))
 		return
 	}
 
 	// Create memo via service
 	result, err := h.service.CreateTeamVoiceMemo(c.Request.Context(), userID, teamID, &req)
 	if err != nil {
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -339,11 +337,11 @@ func (h *VoiceMemoHandler) CreateTeamVoiceMemo(c *gin.Context) {
 // @Produce      json
 // @Param        id   path      string  true  "Voice Memo ID"
 // @Success      200  {object}  response.Response
-// @Failure      400  {object}  response.Response
+// @Failure      400  {object}  response.Response  "Invalid request, or uploaded audio size differs from the declared size (code: audio_size_mismatch)"
 // @Failure      401  {object}  response.Response
-// @Failure      403  {object}  response.Response
+// @Failure      403  {object}  response.Response  "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)"
 // @Failure      404  {object}  response.Response
-// @Failure      409  {object}  response.Response  "Invalid status transition"
+// @Failure      409  {object}  response.Response  "Invalid status transition, or audio not uploaded (code: audio_not_uploaded)"
 // @Failure      503  {object}  response.Response  "Transcription queue full"
 // @Failure      500  {object}  response.Response
 // @Security     BearerAuth
@@ -352,43 +350,27 @@ func (h *VoiceMemoHandler) ConfirmUpload(c *gin.Context) {
 	// Parse memo ID
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
 		return
 	}
 
 	// Get user ID from context
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	// Confirm upload via service
 	err = h.service.ConfirmUpload(c.Request.Context(), memoID, userID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoUnauthorized) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoInvalidStatus) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrTranscriptionQueueFull) {
-			response.Error(c, 503, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -397,17 +379,18 @@ func (h *VoiceMemoHandler) ConfirmUpload(c *gin.Context) {
 
 // ConfirmTeamUpload godoc
 // @Summary      Confirm team audio upload
-// @Description  Confirm that audio has been uploaded to S3 and trigger transcription for a team memo
+// @Description  Confirm that audio has been uploaded to S3 and trigger transcription for a team memo.
+// @Description  Members and contributors can confirm only memos they created.
 // @Tags         team-voice-memos
 // @Produce      json
 // @Param        teamId path      string  true  "Team ID"
 // @Param        id     path      string  true  "Voice Memo ID"
 // @Success      200    {object}  response.Response
-// @Failure      400    {object}  response.Response
+// @Failure      400    {object}  response.Response  "Invalid request, or uploaded audio size differs from the declared size (code: audio_size_mismatch)"
 // @Failure      401    {object}  response.Response
-// @Failure      403    {object}  response.Response
+// @Failure      403    {object}  response.Response  "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)"
 // @Failure      404    {object}  response.Response
-// @Failure      409    {object}  response.Response  "Invalid status transition"
+// @Failure      409    {object}  response.Response  "Invalid status transition, or audio not uploaded (code: audio_not_uploaded)"
 // @Failure      503    {object}  response.Response  "Transcription queue full"
 // @Failure      500    {object}  response.Response
 // @Security     BearerAuth
@@ -416,33 +399,25 @@ func (h *VoiceMemoHandler) ConfirmTeamUpload(c *gin.Context) {
 	// Get team ID from middleware context
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	// Parse memo ID
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
+		return
+	}
+
+	if _, ok := h.authorizeTeamMemo(c, memoID, teamID, authz.ActionMemoUpdate); !ok {
 		return
 	}
 
 	// Confirm upload via service
 	err = h.service.ConfirmTeamUpload(c.Request.Context(), memoID, teamID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoInvalidStatus) {
-			response.Conflict(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrTranscriptionQueueFull) {
-			response.Error(c, 503, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -458,7 +433,7 @@ func (h *VoiceMemoHandler) ConfirmTeamUpload(c *gin.Context) {
 // @Success      200  {object}  response.Response
 // @Failure      400  {object}  response.Response
 // @Failure      401  {object}  response.Response
-// @Failure      403  {object}  response.Response
+// @Failure      403  {object}  response.Response  "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)"
 // @Failure      404  {object}  response.Response
 // @Failure      409  {object}  response.Response  "Invalid status - memo is not in failed state"
 // @Failure      503  {object}  response.Response  "Transcription queue full"
@@ -469,43 +444,27 @@ func (h *VoiceMemoHandler) RetryTranscription(c *gin.Context) {
 	// Parse memo ID
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
 		return
 	}
 
 	// Get user ID from context
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "user not authenticated")
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
 	if err != nil {
-		response.Unauthorized(c, "invalid user id format")
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
 		return
 	}
 
 	// Retry transcription via service
 	err = h.service.RetryTranscription(c.Request.Context(), memoID, userID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoUnauthorized) {
-			response.Forbidden(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoInvalidStatus) {
-			response.Conflict(c, "memo is not in failed state")
-			return
-		}
-		if errors.Is(err, apperrors.ErrTranscriptionQueueFull) {
-			response.Error(c, 503, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
@@ -514,7 +473,8 @@ func (h *VoiceMemoHandler) RetryTranscription(c *gin.Context) {
 
 // RetryTeamTranscription godoc
 // @Summary      Retry team transcription
-// @Description  Retry transcription for a failed team voice memo
+// @Description  Retry transcription for a failed team voice memo.
+// @Description  Members and contributors can retry only memos they created.
 // @Tags         team-voice-memos
 // @Produce      json
 // @Param        teamId path      string  true  "Team ID"
@@ -522,7 +482,7 @@ func (h *VoiceMemoHandler) RetryTranscription(c *gin.Context) {
 // @Success      200    {object}  response.Response
 // @Failure      400    {object}  response.Response
 // @Failure      401    {object}  response.Response
-// @Failure      403    {object}  response.Response
+// @Failure      403    {object}  response.Response  "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)"
 // @Failure      404    {object}  response.Response
 // @Failure      409    {object}  response.Response  "Invalid status - memo is not in failed state"
 // @Failure      503    {object}  response.Response  "Transcription queue full"
@@ -533,35 +493,117 @@ func (h *VoiceMemoHandler) RetryTeamTranscription(c *gin.Context) {
 	// Get team ID from middleware context
 	teamID, exists := middleware.GetTeamID(c)
 	if !exists {
-		response.BadRequest(c, "team id not found in context")
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
 		return
 	}
 
 	// Parse memo ID
 	memoID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "invalid voice memo id format")
+		_ = c.Error(apperrors.BadRequest("invalid voice memo id format"))
+		return
+	}
+
+	if _, ok := h.authorizeTeamMemo(c, memoID, teamID, authz.ActionMemoUpdate); !ok {
 		return
 	}
 
 	// Retry transcription via service
 	err = h.service.RetryTeamTranscription(c.Request.Context(), memoID, teamID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrVoiceMemoNotFound) {
-			response.NotFound(c, err.Error())
-			return
-		}
-		if errors.Is(err, apperrors.ErrVoiceMemoInvalidStatus) {
-			response.Conflict(c, "memo is not in failed state")
-			return
-		}
-		if errors.Is(err, apperrors.ErrTranscriptionQueueFull) {
-			response.Error(c, 503, err.Error())
-			return
-		}
-		response.InternalError(c)
+		_ = c.Error(err)
 		return
 	}
 
 	response.Success(c, gin.H{"message": "transcription retry started"})
 }
+
+// authorizeTeamMemo checks that the current user may perform action on a team memo,
+// taking into account who created it, and that the token's scopes grant action.
+// Returns the current user's ID, or adds the error to the context and returns false
+// if not allowed.
+func (h *VoiceMemoHandler) authorizeTeamMemo(c *gin.Context, memoID, teamID primitive.ObjectID, action string) (primitive.ObjectID, bool) {
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("invalid user id format"))
+		return primitive.NilObjectID, false
+	}
+
+	if err := middleware.CheckScope(c, action); err != nil {
+		_ = c.Error(err)
+		return primitive.NilObjectID, false
+	}
+
+	ownerID, err := h.service.GetTeamVoiceMemoOwner(c.Request.Context(), memoID, teamID)
+	if err != nil {
+		_ = c.Error(err)
+		return primitive.NilObjectID, false
+	}
+
+	allowed, err := h.authorizer.CanPerformOnResource(c.Request.Context(), userID, teamID, ownerID, action)
+	if err != nil {
+		_ = c.Error(err)
+		return primitive.NilObjectID, false
+	}
+	if !allowed {
+		_ = c.Error(apperrors.ErrInsufficientPermissions)
+		return primitive.NilObjectID, false
+	}
+
+	return userID, true
+}
+
+// GetUsage godoc
+// @Summary      Get my usage
+// @Description  Get usage and quota limits for the authenticated user's private voice memos. A limit of 0 means unlimited.
+// @Tags         usage
+// @Produce      json
+// @Success      200  {object}  response.Response{data=models.UsageResponse}
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Security     BearerAuth
+// @Router       /usage [get]
+func (h *VoiceMemoHandler) GetUsage(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(middleware.GetUserID(c))
+	if err != nil {
+		_ = c.Error(apperrors.Unauthorized("user not authenticated"))
+		return
+	}
+
+	result, err := h.service.GetUserUsage(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetTeamUsage godoc
+// @Summary      Get team usage
+// @Description  Get usage and quota limits for a team's voice memos. A limit of 0 means unlimited.
+// @Tags         usage
+// @Produce      json
+// @Param        teamId  path      string  true  "Team ID"
+// @Success      200     {object}  response.Response{data=models.UsageResponse}
+// @Failure      400     {object}  response.Response
+// @Failure      401     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      500     {object}  response.Response
+// @Security     BearerAuth
+// @Router       /teams/{teamId}/usage [get]
+func (h *VoiceMemoHandler) GetTeamUsage(c *gin.Context) {
+	teamID, exists := middleware.GetTeamID(c)
+	if !exists {
+		_ = c.Error(apperrors.BadRequest("team id not found in context"))
+		return
+	}
+
+	result, err := h.service.GetTeamUsage(c.Request.Context(), teamID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -7,24 +7,44 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"gin-sample/internal/authz"
+	authzmocks "gin-sample/internal/authz/mocks"
 	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/middleware"
 	"gin-sample/internal/models"
 	"gin-sample/internal/service/mocks"
+	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
 )
 
+// newMemoAuthorizer returns an authorizer mock that answers every resource check with allowed.
+func newMemoAuthorizer(t *testing.T, allowed bool) *authzmocks.MockAuthorizer {
+	ctrl := gomock.NewController(t)
+	mockAuthz := authzmocks.NewMockAuthorizer(ctrl)
+	mockAuthz.EXPECT().
+		CanPerformOnResource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(allowed, nil).
+		AnyTimes()
+	return mockAuthz
+}
+
 func TestNewVoiceMemoHandler(t *testing.T) {
 	mockService := &mocks.MockVoiceMemoService{}
-	handler := NewVoiceMemoHandler(mockService)
+	mockAuthz := newMemoAuthorizer(t, true)
+	handler := NewVoiceMemoHandler(mockService, mockAuthz)
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockService, handler.service)
+	assert.Equal(t, mockAuthz, handler.authorizer)
 }
 
 func TestVoiceMemoHandler_ListVoiceMemos(t *testing.T) {
@@ -102,9 +122,9 @@ func TestVoiceMemoHandler_ListVoiceMemos(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.GET("/voice-memos", setUserID(tt.userID), handler.ListVoiceMemos)
 			} else {
@@ -213,6 +233,45 @@ func TestVoiceMemoHandler_CreateVoiceMemo(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:   "rate limit exceeded",
+			userID: userID.Hex(),
+			body: models.CreateVoiceMemoRequest{
+				Title:       "Test",
+				FileSize:    1000,
+				AudioFormat: "mp3",
+			},
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.CreateVoiceMemoFunc = func(ctx context.Context, uid primitive.ObjectID, req *models.CreateVoiceMemoRequest) (*models.CreateVoiceMemoResponse, error) {
+					return nil, &apperrors.RateLimitError{RetryAfter: 10 * time.Second}
+				}
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "10", w.Header().Get("Retry-After"))
+			},
+		},
+		{
+			name:   "storage quota exceeded",
+			userID: userID.Hex(),
+			body: models.CreateVoiceMemoRequest{
+				Title:       "Test",
+				FileSize:    1000,
+				AudioFormat: "mp3",
+			},
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.CreateVoiceMemoFunc = func(ctx context.Context, uid primitive.ObjectID, req *models.CreateVoiceMemoRequest) (*models.CreateVoiceMemoResponse, error) {
+					return nil, apperrors.ErrStorageQuotaExceeded
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, "storage_quota_exceeded", resp["code"])
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -220,9 +279,9 @@ func TestVoiceMemoHandler_CreateVoiceMemo(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.POST("/voice-memos", setUserID(tt.userID), handler.CreateVoiceMemo)
 			} else {
@@ -249,6 +308,34 @@ func TestVoiceMemoHandler_CreateVoiceMemo(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("localized field validation errors", func(t *testing.T) {
+		handler := NewVoiceMemoHandler(&mocks.MockVoiceMemoService{}, nil)
+
+		router := newTestRouter()
+		router.POST("/voice-memos", setUserID(userID.Hex()), handler.CreateVoiceMemo)
+
+		body, _ := json.Marshal(models.CreateVoiceMemoRequest{FileSize: 1000, AudioFormat: "ogg", Tags: []string{strings.Repeat("a", 51)}})
+		req := httptest.NewRequest(http.MethodPost, "/voice-memos", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "es-MX,es;q=0.9,en;q=0.8")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp response.Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "validation_failed", resp.Code)
+		require.Len(t, resp.Fields, 3)
+		assert.Equal(t, response.FieldError{Field: "title", Rule: "required", Message: "title es un campo requerido"}, resp.Fields[0])
+		assert.Equal(t, "audioFormat", resp.Fields[1].Field)
+		assert.Equal(t, "oneof", resp.Fields[1].Rule)
+		assert.Equal(t, "mp3 wav m4a webm aac", resp.Fields[1].Param)
+		assert.Equal(t, "tags[0]", resp.Fields[2].Field)
+		assert.Equal(t, "max", resp.Fields[2].Rule)
+	})
 }
 
 func TestVoiceMemoHandler_DeleteVoiceMemo(t *testing.T) {
@@ -334,9 +421,9 @@ func TestVoiceMemoHandler_DeleteVoiceMemo(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.DELETE("/voice-memos/:id", setUserID(tt.userID), handler.DeleteVoiceMemo)
 			} else {
@@ -466,9 +553,9 @@ func TestVoiceMemoHandler_ConfirmUpload(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.POST("/voice-memos/:id/confirm-upload", setUserID(tt.userID), handler.ConfirmUpload)
 			} else {
@@ -601,9 +688,9 @@ func TestVoiceMemoHandler_RetryTranscription(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.userID != "" {
 				router.POST("/voice-memos/:id/retry-transcription", setUserID(tt.userID), handler.RetryTranscription)
 			} else {
@@ -700,9 +787,9 @@ func TestVoiceMemoHandler_ListTeamVoiceMemos(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
 				router.GET("/teams/:teamId/voice-memos", setTeamID(*tt.teamID), handler.ListTeamVoiceMemos)
 			} else {
@@ -845,9 +932,9 @@ func TestVoiceMemoHandler_GetTeamVoiceMemo(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			if tt.teamID != nil {
 				router.GET("/teams/:teamId/voice-memos/:id", setTeamID(*tt.teamID), handler.GetTeamVoiceMemo)
 			} else {
@@ -976,9 +1063,9 @@ func TestVoiceMemoHandler_CreateTeamVoiceMemo(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, nil)
 
-			router := gin.New()
+			router := newTestRouter()
 			handlers := []gin.HandlerFunc{}
 			if tt.teamID != nil {
 				handlers = append(handlers, setTeamID(*tt.teamID))
@@ -1014,12 +1101,15 @@ func TestVoiceMemoHandler_CreateTeamVoiceMemo(t *testing.T) {
 func TestVoiceMemoHandler_DeleteTeamVoiceMemo(t *testing.T) {
 	teamID := primitive.NewObjectID()
 	memoID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	otherUserID := primitive.NewObjectID()
 
 	tests := []struct {
 		name           string
 		teamID         *primitive.ObjectID
 		memoID         string
 		mockSetup      func(*mocks.MockVoiceMemoService)
+		authzSetup     func(*authzmocks.MockAuthorizer)
 		expectedStatus int
 	}{
 		{
@@ -1027,10 +1117,19 @@ func TestVoiceMemoHandler_DeleteTeamVoiceMemo(t *testing.T) {
 			teamID: &teamID,
 			memoID: memoID.Hex(),
 			mockSetup: func(m *mocks.MockVoiceMemoService) {
-				m.DeleteTeamVoiceMemoFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+				m.GetTeamVoiceMemoOwnerFunc = func(ctx context.Context, mid, tid primitive.ObjectID) (primitive.ObjectID, error) {
+					return otherUserID, nil
+				}
+				m.DeleteTeamVoiceMemoFunc = func(ctx context.Context, mid, tid, aid primitive.ObjectID) error {
+					assert.Equal(t, userID, aid)
 					return nil
 				}
 			},
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().
+					CanPerformOnResource(gomock.Any(), userID, teamID, otherUserID, "memo:delete").
+					Return(true, nil)
+			},
 			expectedStatus: http.StatusNoContent,
 		},
 		{
@@ -1038,6 +1137,7 @@ func TestVoiceMemoHandler_DeleteTeamVoiceMemo(t *testing.T) {
 			teamID:         nil,
 			memoID:         memoID.Hex(),
 			mockSetup:      func(m *mocks.MockVoiceMemoService) {},
+			authzSetup:     func(m *authzmocks.MockAuthorizer) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -1045,6 +1145,7 @@ func TestVoiceMemoHandler_DeleteTeamVoiceMemo(t *testing.T) {
 			teamID:         &teamID,
 			memoID:         "invalid-id",
 			mockSetup:      func(m *mocks.MockVoiceMemoService) {},
+			authzSetup:     func(m *authzmocks.MockAuthorizer) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -1052,33 +1153,87 @@ func TestVoiceMemoHandler_DeleteTeamVoiceMemo(t *testing.T) {
 			teamID: &teamID,
 			memoID: memoID.Hex(),
 			mockSetup: func(m *mocks.MockVoiceMemoService) {
-				m.DeleteTeamVoiceMemoFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
-					return apperrors.ErrVoiceMemoNotFound
+				m.GetTeamVoiceMemoOwnerFunc = func(ctx context.Context, mid, tid primitive.ObjectID) (primitive.ObjectID, error) {
+					return primitive.NilObjectID, apperrors.ErrVoiceMemoNotFound
 				}
 			},
+			authzSetup:     func(m *authzmocks.MockAuthorizer) {},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:   "not allowed to delete another member's memo",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.GetTeamVoiceMemoOwnerFunc = func(ctx context.Context, mid, tid primitive.ObjectID) (primitive.ObjectID, error) {
+					return otherUserID, nil
+				}
+				m.DeleteTeamVoiceMemoFunc = func(ctx context.Context, mid, tid, aid primitive.ObjectID) error {
+					t.Error("DeleteTeamVoiceMemo should not be called")
+					return nil
+				}
+			},
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().
+					CanPerformOnResource(gomock.Any(), userID, teamID, otherUserID, "memo:delete").
+					Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "authorizer error",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.GetTeamVoiceMemoOwnerFunc = func(ctx context.Context, mid, tid primitive.ObjectID) (primitive.ObjectID, error) {
+					return userID, nil
+				}
+			},
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().
+					CanPerformOnResource(gomock.Any(), userID, teamID, userID, "memo:delete").
+					Return(false, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
 		{
 			name:   "internal server error",
 			teamID: &teamID,
 			memoID: memoID.Hex(),
 			mockSetup: func(m *mocks.MockVoiceMemoService) {
-				m.DeleteTeamVoiceMemoFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+				m.GetTeamVoiceMemoOwnerFunc = func(ctx context.Context, mid, tid primitive.ObjectID) (primitive.ObjectID, error) {
+					return userID, nil
+				}
+				m.DeleteTeamVoiceMemoFunc = func(ctx context.Context, mid, tid, aid primitive.ObjectID) error {
 					return errors.New("database error")
 				}
 			},
+			authzSetup: func(m *authzmocks.MockAuthorizer) {
+				m.EXPECT().
+					CanPerformOnResource(gomock.Any(), userID, teamID, userID, "memo:delete").
+					Return(true, nil)
+			},
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
+			mockAuthz := authzmocks.NewMockAuthorizer(ctrl)
+			tt.authzSetup(mockAuthz)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, mockAuthz)
 
-			router := gin.New()
+			router := newTestRouter()
+			router.Use(func(c *gin.Context) {
+				c.Set(middleware.UserIDKey, userID.Hex())
+				c.Next()
+			})
 			if tt.teamID != nil {
 				router.DELETE("/teams/:teamId/voice-memos/:id", setTeamID(*tt.teamID), handler.DeleteTeamVoiceMemo)
 			} else {
@@ -1103,6 +1258,8 @@ func TestVoiceMemoHandler_ConfirmTeamUpload(t *testing.T) {
 		name           string
 		teamID         *primitive.ObjectID
 		memoID         string
+		denied         bool
+		scopes         []string
 		mockSetup      func(*mocks.MockVoiceMemoService)
 		expectedStatus int
 		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
@@ -1172,6 +1329,23 @@ func TestVoiceMemoHandler_ConfirmTeamUpload(t *testing.T) {
 			},
 			expectedStatus: http.StatusServiceUnavailable,
 		},
+		{
+			name:   "transcription quota exceeded",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.ConfirmTeamUploadFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+					return apperrors.ErrTranscriptionQuotaExceeded
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, "transcription_quota_exceeded", resp["code"])
+			},
+		},
 		{
 			name:   "internal server error",
 			teamID: &teamID,
@@ -1183,6 +1357,32 @@ func TestVoiceMemoHandler_ConfirmTeamUpload(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:   "not allowed to update another member's memo",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			denied: true,
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.ConfirmTeamUploadFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+					t.Error("ConfirmTeamUpload should not be called")
+					return nil
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "token without memo:update scope",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			scopes: []string{authz.ActionMemoView, authz.ActionMemoCreate},
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.ConfirmTeamUploadFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+					t.Error("ConfirmTeamUpload should not be called")
+					return nil
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1190,9 +1390,16 @@ func TestVoiceMemoHandler_ConfirmTeamUpload(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, newMemoAuthorizer(t, !tt.denied))
 
-			router := gin.New()
+			router := newTestRouter()
+			router.Use(func(c *gin.Context) {
+				c.Set(middleware.UserIDKey, primitive.NewObjectID().Hex())
+				if tt.scopes != nil {
+					c.Set(middleware.ScopesKey, tt.scopes)
+				}
+				c.Next()
+			})
 			if tt.teamID != nil {
 				router.POST("/teams/:teamId/voice-memos/:id/confirm-upload", setTeamID(*tt.teamID), handler.ConfirmTeamUpload)
 			} else {
@@ -1220,6 +1427,8 @@ func TestVoiceMemoHandler_RetryTeamTranscription(t *testing.T) {
 		name           string
 		teamID         *primitive.ObjectID
 		memoID         string
+		denied         bool
+		scopes         []string
 		mockSetup      func(*mocks.MockVoiceMemoService)
 		expectedStatus int
 		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
@@ -1300,6 +1509,32 @@ func TestVoiceMemoHandler_RetryTeamTranscription(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:   "not allowed to update another member's memo",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			denied: true,
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.RetryTeamTranscriptionFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+					t.Error("RetryTeamTranscription should not be called")
+					return nil
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "token without memo:update scope",
+			teamID: &teamID,
+			memoID: memoID.Hex(),
+			scopes: []string{authz.ActionMemoView, authz.ActionMemoCreate},
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.RetryTeamTranscriptionFunc = func(ctx context.Context, mid, tid primitive.ObjectID) error {
+					t.Error("RetryTeamTranscription should not be called")
+					return nil
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1307,9 +1542,16 @@ func TestVoiceMemoHandler_RetryTeamTranscription(t *testing.T) {
 			mockService := &mocks.MockVoiceMemoService{}
 			tt.mockSetup(mockService)
 
-			handler := NewVoiceMemoHandler(mockService)
+			handler := NewVoiceMemoHandler(mockService, newMemoAuthorizer(t, !tt.denied))
 
-			router := gin.New()
+			router := newTestRouter()
+			router.Use(func(c *gin.Context) {
+				c.Set(middleware.UserIDKey, primitive.NewObjectID().Hex())
+				if tt.scopes != nil {
+					c.Set(middleware.ScopesKey, tt.scopes)
+				}
+				c.Next()
+			})
 			if tt.teamID != nil {
 				router.POST("/teams/:teamId/voice-memos/:id/retry-transcription", setTeamID(*tt.teamID), handler.RetryTeamTranscription)
 			} else {
@@ -1328,3 +1570,141 @@ func TestVoiceMemoHandler_RetryTeamTranscription(t *testing.T) {
 		})
 	}
 }
+
+func TestVoiceMemoHandler_GetUsage(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		userID         string
+		mockSetup      func(*mocks.MockVoiceMemoService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "successful get usage",
+			userID: userID.Hex(),
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.GetUserUsageFunc = func(ctx context.Context, uid primitive.ObjectID) (*models.UsageResponse, error) {
+					return &models.UsageResponse{
+						Memos: models.UsageMetric{Used: 3, Limit: 100},
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				data := resp["data"].(map[string]interface{})
+				memos := data["memos"].(map[string]interface{})
+				assert.Equal(t, float64(3), memos["used"])
+				assert.Equal(t, float64(100), memos["limit"])
+			},
+		},
+		{
+			name:           "missing user ID",
+			userID:         "",
+			mockSetup:      func(m *mocks.MockVoiceMemoService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "internal server error",
+			userID: userID.Hex(),
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.GetUserUsageFunc = func(ctx context.Context, uid primitive.ObjectID) (*models.UsageResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockVoiceMemoService{}
+			tt.mockSetup(mockService)
+
+			handler := NewVoiceMemoHandler(mockService, nil)
+
+			router := newTestRouter()
+			if tt.userID != "" {
+				router.GET("/usage", setUserID(tt.userID), handler.GetUsage)
+			} else {
+				router.GET("/usage", handler.GetUsage)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/usage", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestVoiceMemoHandler_GetTeamUsage(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		teamID         *primitive.ObjectID
+		mockSetup      func(*mocks.MockVoiceMemoService)
+		expectedStatus int
+	}{
+		{
+			name:   "successful get team usage",
+			teamID: &teamID,
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.GetTeamUsageFunc = func(ctx context.Context, tid primitive.ObjectID) (*models.UsageResponse, error) {
+					assert.Equal(t, teamID, tid)
+					return &models.UsageResponse{}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing team ID in context",
+			teamID:         nil,
+			mockSetup:      func(m *mocks.MockVoiceMemoService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "internal server error",
+			teamID: &teamID,
+			mockSetup: func(m *mocks.MockVoiceMemoService) {
+				m.GetTeamUsageFunc = func(ctx context.Context, tid primitive.ObjectID) (*models.UsageResponse, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mocks.MockVoiceMemoService{}
+			tt.mockSetup(mockService)
+
+			handler := NewVoiceMemoHandler(mockService, nil)
+
+			router := newTestRouter()
+			if tt.teamID != nil {
+				router.GET("/teams/:teamId/usage", setTeamID(*tt.teamID), handler.GetTeamUsage)
+			} else {
+				router.GET("/teams/:teamId/usage", handler.GetTeamUsage)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/teams/"+teamID.Hex()+"/usage", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Package health reports whether the server is alive and ready to serve traffic.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statuses of a report and its components.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrShuttingDown is reported by readiness once graceful shutdown has begun.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Check reports whether a component is healthy. A check still running when its timeout
+// expires is reported as failed.
+type Check func(ctx context.Context) error
+
+// Report is the result of running a set of checks.
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+// ComponentStatus is the result of one check.
+type ComponentStatus struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// OK reports whether every component passed.
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs liveness and readiness checks. Liveness covers the server's own workers and
+// should only fail when restarting the process would help; readiness also covers the
+// dependencies needed to serve requests and fails once shutdown begins, so load balancers
+// stop routing new requests while in-flight ones drain.
+type Checker struct {
+	timeout      time.Duration
+	liveness     []namedCheck
+	readiness    []namedCheck
+	shuttingDown atomic.Bool
+}
+
+// NewChecker creates a checker that gives each check up to timeout to complete.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// AddLivenessCheck adds a check to liveness. Liveness checks are also part of readiness.
+func (c *Checker) AddLivenessCheck(name string, check Check) {
+	c.liveness = append(c.liveness, namedCheck{name, check})
+}
+
+// AddReadinessCheck adds a check to readiness only.
+func (c *Checker) AddReadinessCheck(name string, check Check) {
+	c.readiness = append(c.readiness, namedCheck{name, check})
+}
+
+// Shutdown marks the server as shutting down, failing readiness from now on.
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Live runs the liveness checks.
+func (c *Checker) Live(ctx context.Context) Report {
+	return c.run(ctx, c.liveness)
+}
+
+// Ready runs the liveness and readiness checks, and fails during shutdown.
+func (c *Checker) Ready(ctx context.Context) Report {
+	checks := make([]namedCheck, 0, len(c.liveness)+len(c.readiness)+1)
+	checks = append(checks, namedCheck{"shutdown", c.checkShutdown})
+	checks = append(checks, c.liveness...)
+	checks = append(checks, c.readiness...)
+	return c.run(ctx, checks)
+}
+
+func (c *Checker) checkShutdown(context.Context) error {
+	if c.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+// QueueSaturation returns a check that fails when a queue is at least threshold full
+// (0-1), since new jobs are about to be rejected.
+func QueueSaturation(q Queue, threshold float64) Check {
+	return func(context.Context) error {
+		length, capacity := q.Len(), q.Capacity()
+		if capacity > 0 && float64(length) >= threshold*float64(capacity) {
+			return fmt.Errorf("queue saturated: %d of %d jobs", length, capacity)
+		}
+		return nil
+	}
+}
+
+// Queue is a queue whose saturation is checked.
+type Queue interface {
+	Len() int
+	Capacity() int
+}
+
+// run runs checks concurrently, each with its own timeout.
+func (c *Checker) run(ctx context.Context, checks []namedCheck) Report {
+	report := Report{Status: StatusOK, Components: make(map[string]ComponentStatus, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := c.runCheck(ctx, nc.check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[nc.name] = status
+			if status.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) runCheck(ctx context.Context, check Check) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	status := ComponentStatus{Status: StatusOK, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		status.Status = StatusFail
+		status.Error = err.Error()
+	}
+	return status
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQueue reports a fixed length and capacity.
+type fakeQueue struct {
+	length, capacity int
+}
+
+func (q fakeQueue) Len() int      { return q.length }
+func (q fakeQueue) Capacity() int { return q.capacity }
+
+func TestChecker(t *testing.T) {
+	t.Run("ok when every check passes", func(t *testing.T) {
+		checker := NewChecker(time.Second)
+		checker.AddLivenessCheck("workers", func(context.Context) error { return nil })
+		checker.AddReadinessCheck("mongodb", func(context.Context) error { return nil })
+
+		live := checker.Live(context.Background())
+		ready := checker.Ready(context.Background())
+
+		assert.True(t, live.OK())
+		assert.Len(t, live.Components, 1)
+		assert.True(t, ready.OK())
+		assert.Len(t, ready.Components, 3, "readiness includes liveness and shutdown")
+	})
+
+	t.Run("fails when any check fails", func(t *testing.T) {
+		checker := NewChecker(time.Second)
+		checker.AddReadinessCheck("mongodb", func(context.Context) error { return nil })
+		checker.AddReadinessCheck("redis", func(context.Context) error { return errors.New("connection refused") })
+
+		report := checker.Ready(context.Background())
+
+		assert.False(t, report.OK())
+		assert.Equal(t, StatusOK, report.Components["mongodb"].Status)
+		assert.Equal(t, StatusFail, report.Components["redis"].Status)
+		assert.Equal(t, "connection refused", report.Components["redis"].Error)
+	})
+
+	t.Run("times out slow checks", func(t *testing.T) {
+		checker := NewChecker(20 * time.Millisecond)
+		release := make(chan struct{})
+		defer close(release)
+		checker.AddReadinessCheck("s3", func(context.Context) error {
+			<-release // ignores its context
+			return nil
+		})
+
+		start := time.Now()
+		report := checker.Ready(context.Background())
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, StatusFail, report.Components["s3"].Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Components["s3"].Error)
+	})
+
+	t.Run("readiness fails after shutdown, liveness does not", func(t *testing.T) {
+		checker := NewChecker(time.Second)
+		checker.Shutdown()
+
+		assert.False(t, checker.Ready(context.Background()).OK())
+		assert.True(t, checker.Live(context.Background()).OK())
+	})
+}
+
+func TestQueueSaturation(t *testing.T) {
+	tests := []struct {
+		name    string
+		queue   fakeQueue
+		wantErr bool
+	}{
+		{"empty", fakeQueue{0, 100}, false},
+		{"below threshold", fakeQueue{89, 100}, false},
+		{"at threshold", fakeQueue{90, 100}, true},
+		{"full", fakeQueue{100, 100}, true},
+		{"unbuffered", fakeQueue{0, 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := QueueSaturation(tt.queue, 0.9)(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+)
+
+// Certificates holds a TLS certificate loaded from a certificate and a key file. Reload
+// replaces it without a restart, so renewed certificates are served to new connections.
+type Certificates struct {
+	certFile string
+	keyFile  string
+	current  atomic.Pointer[tls.Certificate]
+}
+
+// NewCertificates loads the certificate in certFile with the private key in keyFile.
+func NewCertificates(certFile, keyFile string) (*Certificates, error) {
+	c := &Certificates{certFile: certFile, keyFile: keyFile}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Files returns the certificate and key file paths.
+func (c *Certificates) Files() []string {
+	return []string{c.certFile, c.keyFile}
+}
+
+// Reload loads the certificate files again. If they cannot be loaded, for example because
+// only one of them has been replaced so far, the current certificate is kept.
+func (c *Certificates) Reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS certificate: %w", err)
+	}
+	c.current.Store(&cert)
+	return nil
+}
+
+// GetCertificate returns the current certificate, for tls.Config.
+func (c *Certificates) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.current.Load(), nil
+}
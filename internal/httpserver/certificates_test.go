@@ -0,0 +1,103 @@
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate for commonName and its key to dir,
+// returning the file paths.
+func writeCertificate(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+// commonName returns the subject common name of cert.
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return parsed.Subject.CommonName
+}
+
+func TestCertificates(t *testing.T) {
+	t.Run("loads the certificate", func(t *testing.T) {
+		certFile, keyFile := writeCertificate(t, t.TempDir(), "old.example.com")
+
+		certs, err := NewCertificates(certFile, keyFile)
+		require.NoError(t, err)
+
+		cert, err := certs.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "old.example.com", commonName(t, cert))
+		assert.Equal(t, []string{certFile, keyFile}, certs.Files())
+	})
+
+	t.Run("fails for missing files", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := NewCertificates(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+		assert.Error(t, err)
+	})
+
+	t.Run("reload replaces the certificate", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "old.example.com")
+		certs, err := NewCertificates(certFile, keyFile)
+		require.NoError(t, err)
+
+		writeCertificate(t, dir, "new.example.com")
+		require.NoError(t, certs.Reload())
+
+		cert, err := certs.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "new.example.com", commonName(t, cert))
+	})
+
+	t.Run("failed reload keeps the current certificate", func(t *testing.T) {
+		certFile, keyFile := writeCertificate(t, t.TempDir(), "old.example.com")
+		certs, err := NewCertificates(certFile, keyFile)
+		require.NoError(t, err)
+
+		// A new key without its certificate does not match
+		_, otherKey := writeCertificate(t, t.TempDir(), "new.example.com")
+		keyPEM, err := os.ReadFile(otherKey)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+
+		assert.Error(t, certs.Reload())
+		cert, err := certs.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "old.example.com", commonName(t, cert))
+	})
+}
@@ -0,0 +1,74 @@
+// Package httpserver builds the HTTP server with bounded timeouts and header sizes, and
+// optional TLS, HTTP/2 and h2c.
+package httpserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Config holds the HTTP server settings.
+type Config struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// HTTP2 serves HTTP/2 over TLS.
+	HTTP2 bool
+	// H2C serves HTTP/2 without TLS (prior knowledge), for proxies that speak it.
+	H2C bool
+	// Certificates serves HTTPS with the certificate it holds. Nil serves plain HTTP.
+	Certificates *Certificates
+	// MinTLSVersion is the lowest TLS version accepted, see ParseTLSVersion.
+	MinTLSVersion uint16
+}
+
+// New creates the server for handler.
+func New(cfg Config, handler http.Handler) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(cfg.HTTP2)
+	protocols.SetUnencryptedHTTP2(cfg.H2C)
+
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		Protocols:         protocols,
+	}
+	if cfg.Certificates != nil {
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     cfg.MinTLSVersion,
+			GetCertificate: cfg.Certificates.GetCertificate,
+		}
+	}
+	return srv
+}
+
+// ListenAndServe serves HTTPS if srv was created with certificates, otherwise plain HTTP.
+func ListenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
+
+// ParseTLSVersion parses a TLS version of the form "1.2" or "1.3".
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", version)
+	}
+}
@@ -0,0 +1,124 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve starts srv on a random local port and returns its address.
+func serve(t *testing.T, srv *http.Server) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		if srv.TLSConfig != nil {
+			_ = srv.ServeTLS(ln, "", "")
+			return
+		}
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() { _ = srv.Close() })
+	return ln.Addr().String()
+}
+
+// protoHandler responds with the protocol the request was made with.
+var protoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(r.Proto))
+})
+
+func TestNew(t *testing.T) {
+	srv := New(Config{
+		Addr:              ":8080",
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      20 * time.Second,
+		IdleTimeout:       30 * time.Second,
+		MaxHeaderBytes:    4096,
+	}, protoHandler)
+
+	assert.Equal(t, ":8080", srv.Addr)
+	assert.Equal(t, 5*time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 10*time.Second, srv.ReadTimeout)
+	assert.Equal(t, 20*time.Second, srv.WriteTimeout)
+	assert.Equal(t, 30*time.Second, srv.IdleTimeout)
+	assert.Equal(t, 4096, srv.MaxHeaderBytes)
+	assert.Nil(t, srv.TLSConfig)
+}
+
+func TestNew_TLS(t *testing.T) {
+	certFile, keyFile := writeCertificate(t, t.TempDir(), "localhost")
+	certs, err := NewCertificates(certFile, keyFile)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		http2         bool
+		expectedProto string
+	}{
+		{"negotiates HTTP/2", true, "HTTP/2.0"},
+		{"serves HTTP/1.1 when HTTP/2 is disabled", false, "HTTP/1.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := New(Config{HTTP2: tt.http2, Certificates: certs, MinTLSVersion: tls.VersionTLS12}, protoHandler)
+			addr := serve(t, srv)
+
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+				ForceAttemptHTTP2: true,
+			}}
+			resp, err := client.Get("https://" + addr)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedProto, resp.Proto)
+			assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+		})
+	}
+
+	t.Run("rejects clients below the minimum version", func(t *testing.T) {
+		srv := New(Config{Certificates: certs, MinTLSVersion: tls.VersionTLS13}, protoHandler)
+		addr := serve(t, srv)
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true, MaxVersion: tls.VersionTLS12},
+		}}
+		_, err := client.Get("https://" + addr)
+		assert.Error(t, err)
+	})
+}
+
+func TestNew_H2C(t *testing.T) {
+	srv := New(Config{H2C: true}, protoHandler)
+	addr := serve(t, srv)
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	resp, err := client.Get("http://" + addr)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "HTTP/2.0", resp.Proto)
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	version, err := ParseTLSVersion("1.2")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), version)
+
+	version, err = ParseTLSVersion("1.3")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), version)
+
+	_, err = ParseTLSVersion("1.1")
+	assert.Error(t, err)
+}
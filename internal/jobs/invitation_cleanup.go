@@ -0,0 +1,95 @@
+// Package jobs provides scheduled background jobs.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// CleanupTimeout is the timeout for a single invitation cleanup run.
+const CleanupTimeout = 30 * time.Second
+
+// InvitationCleaner defines the invitation operations needed by the cleanup job.
+type InvitationCleaner interface {
+	MarkExpired(ctx context.Context) (int, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
+
+// InvitationCleanup periodically marks pending invitations past their expiry as expired
+// and removes invitations that expired longer ago than the retention period.
+type InvitationCleanup struct {
+	cleaner   InvitationCleaner
+	interval  time.Duration
+	retention time.Duration
+	wg        sync.WaitGroup
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+}
+
+// NewInvitationCleanup creates a new invitation cleanup job.
+func NewInvitationCleanup(cleaner InvitationCleaner, interval, retention time.Duration) *InvitationCleanup {
+	return &InvitationCleanup{
+		cleaner:   cleaner,
+		interval:  interval,
+		retention: retention,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start runs the cleanup immediately and then on every interval until stopped.
+func (j *InvitationCleanup) Start(ctx context.Context) {
+	j.wg.Add(1)
+	go j.loop(ctx)
+	slog.Info("invitation cleanup job started", "interval", j.interval)
+}
+
+// Stop stops the job and waits for an in-flight run to finish.
+func (j *InvitationCleanup) Stop() {
+	j.stopOnce.Do(func() {
+		close(j.stopCh)
+	})
+	j.wg.Wait()
+	slog.Info("invitation cleanup job stopped")
+}
+
+func (j *InvitationCleanup) loop(ctx context.Context) {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.stopCh:
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run performs a single cleanup pass.
+func (j *InvitationCleanup) run(ctx context.Context) {
+	runCtx, cancel := context.WithTimeout(ctx, CleanupTimeout)
+	defer cancel()
+
+	expired, err := j.cleaner.MarkExpired(runCtx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to mark expired invitations", "error", err)
+	} else if expired > 0 {
+		slog.InfoContext(ctx, "marked invitations as expired", "count", expired)
+	}
+
+	deleted, err := j.cleaner.DeleteExpired(runCtx, time.Now().Add(-j.retention))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete old invitations", "error", err)
+	} else if deleted > 0 {
+		slog.InfoContext(ctx, "deleted invitations past retention", "count", deleted)
+	}
+}
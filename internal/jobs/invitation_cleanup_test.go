@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCleaner implements InvitationCleaner for testing.
+type mockCleaner struct {
+	mu          sync.Mutex
+	markCalls   int
+	deleteCalls int
+	lastBefore  time.Time
+	markErr     error
+}
+
+func (m *mockCleaner) MarkExpired(_ context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.markCalls++
+	return 1, m.markErr
+}
+
+func (m *mockCleaner) DeleteExpired(_ context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleteCalls++
+	m.lastBefore = before
+	return 0, nil
+}
+
+func (m *mockCleaner) calls() (int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.markCalls, m.deleteCalls
+}
+
+func TestNewInvitationCleanup(t *testing.T) {
+	cleaner := &mockCleaner{}
+
+	job := NewInvitationCleanup(cleaner, time.Hour, 24*time.Hour)
+
+	require.NotNil(t, job)
+	assert.Equal(t, time.Hour, job.interval)
+	assert.Equal(t, 24*time.Hour, job.retention)
+}
+
+func TestInvitationCleanup_Run(t *testing.T) {
+	t.Run("marks expired and deletes past retention", func(t *testing.T) {
+		cleaner := &mockCleaner{}
+		job := NewInvitationCleanup(cleaner, time.Hour, 90*24*time.Hour)
+
+		job.run(context.Background())
+
+		marks, deletes := cleaner.calls()
+		assert.Equal(t, 1, marks)
+		assert.Equal(t, 1, deletes)
+		assert.WithinDuration(t, time.Now().Add(-90*24*time.Hour), cleaner.lastBefore, time.Minute)
+	})
+
+	t.Run("still deletes when marking fails", func(t *testing.T) {
+		cleaner := &mockCleaner{markErr: errors.New("database error")}
+		job := NewInvitationCleanup(cleaner, time.Hour, time.Hour)
+
+		job.run(context.Background())
+
+		_, deletes := cleaner.calls()
+		assert.Equal(t, 1, deletes)
+	})
+}
+
+func TestInvitationCleanup_StartStop(t *testing.T) {
+	t.Run("runs immediately and on every tick", func(t *testing.T) {
+		cleaner := &mockCleaner{}
+		job := NewInvitationCleanup(cleaner, 20*time.Millisecond, time.Hour)
+
+		job.Start(context.Background())
+
+		assert.Eventually(t, func() bool {
+			marks, _ := cleaner.calls()
+			return marks >= 3
+		}, time.Second, 10*time.Millisecond)
+
+		job.Stop()
+	})
+
+	t.Run("stop is idempotent", func(t *testing.T) {
+		job := NewInvitationCleanup(&mockCleaner{}, time.Hour, time.Hour)
+		job.Start(context.Background())
+
+		job.Stop()
+		job.Stop()
+	})
+
+	t.Run("exits when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		job := NewInvitationCleanup(&mockCleaner{}, time.Hour, time.Hour)
+		job.Start(ctx)
+
+		cancel()
+
+		done := make(chan struct{})
+		go func() {
+			job.wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("job did not exit after context cancellation")
+		}
+	})
+}
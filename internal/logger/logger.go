@@ -0,0 +1,113 @@
+// Package logger configures structured logging with log/slog.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gin-sample/internal/requestid"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Output formats.
+const (
+	// FormatJSON writes one JSON object per record.
+	FormatJSON = "json"
+	// FormatText writes key=value pairs, for local development.
+	FormatText = "text"
+)
+
+// New creates a logger writing to w in the given format ("json" or "text") at the given
+// level ("debug", "info", "warn" or "error"). Records logged with a context carry the
+// context's request ID and trace ID, so services only need to pass their context along.
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return newLogger(w, format, lvl)
+}
+
+func newLogger(w io.Writer, format string, level slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+
+	return slog.New(contextHandler{handler}), nil
+}
+
+// defaultLevel is the level of the logger created by Setup, changed by SetLevel.
+var defaultLevel slog.LevelVar
+
+// Setup creates a logger writing to stderr and makes it the default, so both slog's
+// top-level functions and the standard log package write through it.
+func Setup(format, level string) (*slog.Logger, error) {
+	if err := SetLevel(level); err != nil {
+		return nil, err
+	}
+	logger, err := newLogger(os.Stderr, format, &defaultLevel)
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+// SetLevel changes the level of the logger created by Setup while the server runs.
+func SetLevel(level string) error {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	defaultLevel.Set(lvl)
+	return nil
+}
+
+func parseLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", level)
+	}
+	return lvl, nil
+}
+
+// Fatal logs msg at error level with the default logger and exits.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// contextHandler adds the request ID and the trace and span IDs carried by the record's context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		record.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"gin-sample/internal/requestid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("json records carry the request ID", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, FormatJSON, "info")
+		require.NoError(t, err)
+
+		ctx := requestid.NewContext(context.Background(), "req-123")
+		logger.With("component", "test").ErrorContext(ctx, "update failed", "memo_id", "abc", "error", errors.New("boom"))
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "update failed", record["msg"])
+		assert.Equal(t, "req-123", record["request_id"])
+		assert.Equal(t, "test", record["component"])
+		assert.Equal(t, "abc", record["memo_id"])
+		assert.Equal(t, "boom", record["error"])
+	})
+
+	t.Run("records carry the trace and span IDs", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, FormatJSON, "info")
+		require.NoError(t, err)
+
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01, 0x02},
+			SpanID:  trace.SpanID{0x03},
+		})
+		logger.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "traced")
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, sc.TraceID().String(), record["trace_id"])
+		assert.Equal(t, sc.SpanID().String(), record["span_id"])
+	})
+
+	t.Run("records without a request ID omit it", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, FormatText, "info")
+		require.NoError(t, err)
+
+		logger.InfoContext(context.Background(), "started")
+
+		assert.Contains(t, buf.String(), "msg=started")
+		assert.NotContains(t, buf.String(), "request_id")
+		assert.NotContains(t, buf.String(), "trace_id")
+	})
+
+	t.Run("filters below the level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, FormatJSON, "warn")
+		require.NoError(t, err)
+
+		logger.Info("ignored")
+		logger.Warn("kept")
+
+		assert.NotContains(t, buf.String(), "ignored")
+		assert.Contains(t, buf.String(), "kept")
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, FormatJSON, "verbose")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, "xml", "info")
+		assert.Error(t, err)
+	})
+}
+
+func TestSetLevel(t *testing.T) {
+	t.Cleanup(func() { defaultLevel.Set(slog.LevelInfo) })
+
+	require.NoError(t, SetLevel("debug"))
+	assert.Equal(t, slog.LevelDebug, defaultLevel.Level())
+
+	assert.Error(t, SetLevel("verbose"))
+	assert.Equal(t, slog.LevelDebug, defaultLevel.Level(), "an invalid level is ignored")
+}
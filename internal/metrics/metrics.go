@@ -0,0 +1,230 @@
+// Package metrics exposes Prometheus metrics for HTTP requests, the transcription queue
+// and calls to external dependencies.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gin_sample"
+
+// Dependencies whose calls are timed.
+const (
+	DependencyMongoDB = "mongodb"
+	DependencyRedis   = "redis"
+	DependencyS3      = "s3"
+)
+
+// Transcription job outcomes.
+const (
+	// OutcomeSuccess is a transcription attempt that stored its result.
+	OutcomeSuccess = "success"
+	// OutcomeError is a transcription attempt that failed and may be retried.
+	OutcomeError = "error"
+)
+
+// Reasons a transcription job is marked as failed.
+const (
+	FailureMaxRetries    = "max_retries"
+	FailureShutdown      = "shutdown"
+	FailureRequeueFailed = "requeue_failed"
+)
+
+// Call results recorded for dependency calls. Application errors such as "not found"
+// are expected results and count as ok; anything else is an error.
+const (
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+// unmatchedRoute labels requests that matched no route, so unknown paths don't create new series.
+const unmatchedRoute = "unmatched"
+
+// otherMethod labels requests with a non-standard method, so clients can't create new series
+// by sending arbitrary methods.
+const otherMethod = "OTHER"
+
+// standardMethods are the HTTP methods recorded under their own name.
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// Metrics holds the application's Prometheus collectors.
+// A nil *Metrics is valid and records nothing, so instrumentation can be disabled by passing nil.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequestDuration *prometheus.HistogramVec
+	dependencyDuration  *prometheus.HistogramVec
+	queueRejections     prometheus.Counter
+	jobDuration         *prometheus.HistogramVec
+	jobRetries          prometheus.Counter
+	jobFailures         *prometheus.CounterVec
+}
+
+// New creates the collectors and registers them, along with Go runtime and process metrics,
+// on a new registry.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dependencyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "dependency_call_duration_seconds",
+			Help:      "Latency of calls to MongoDB, Redis and S3 by operation and result.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"dependency", "operation", "result"}),
+		queueRejections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transcription_queue_rejections_total",
+			Help:      "Transcription jobs rejected because the queue was full.",
+		}),
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "transcription_job_duration_seconds",
+			Help:      "Duration of transcription attempts by outcome.",
+			Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 120, 300},
+		}, []string{"outcome"}),
+		jobRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transcription_job_retries_total",
+			Help:      "Transcription jobs scheduled for an automatic retry.",
+		}),
+		jobFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transcription_job_failures_total",
+			Help:      "Transcription jobs marked as failed, by reason.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequestDuration,
+		m.dependencyDuration,
+		m.queueRejections,
+		m.jobDuration,
+		m.jobRetries,
+		m.jobFailures,
+	)
+	return m
+}
+
+// Handler returns the HTTP handler that serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Registry returns the registry the metrics are registered on.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// ObserveHTTPRequest records the latency of an HTTP request. route is the route template,
+// e.g. "/api/v1/voice-memos/:id", or empty if no route matched. Non-standard methods are
+// recorded as "OTHER".
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	if !standardMethods[method] {
+		method = otherMethod
+	}
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// ObserveDependencyCall records the latency and result of a call to an external dependency.
+func (m *Metrics) ObserveDependencyCall(dependency, operation string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.dependencyDuration.WithLabelValues(dependency, operation, callResult(err)).Observe(duration.Seconds())
+}
+
+// RegisterQueue exports the depth and capacity of the transcription queue.
+func (m *Metrics) RegisterQueue(queue interface {
+	Len() int
+	Capacity() int
+}) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "transcription_queue_depth",
+			Help:      "Transcription jobs waiting in the queue.",
+		}, func() float64 { return float64(queue.Len()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "transcription_queue_capacity",
+			Help:      "Maximum number of transcription jobs the queue holds.",
+		}, func() float64 { return float64(queue.Capacity()) }),
+	)
+}
+
+// QueueRejected records a transcription job rejected because the queue was full.
+func (m *Metrics) QueueRejected() {
+	if m == nil {
+		return
+	}
+	m.queueRejections.Inc()
+}
+
+// JobProcessed records the duration and outcome (OutcomeSuccess or OutcomeError) of a transcription attempt.
+func (m *Metrics) JobProcessed(outcome string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.jobDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// JobRetried records a transcription job scheduled for an automatic retry.
+func (m *Metrics) JobRetried() {
+	if m == nil {
+		return
+	}
+	m.jobRetries.Inc()
+}
+
+// JobFailed records a transcription job marked as failed for the given reason.
+func (m *Metrics) JobFailed(reason string) {
+	if m == nil {
+		return
+	}
+	m.jobFailures.WithLabelValues(reason).Inc()
+}
+
+// callResult classifies a dependency call's error.
+func callResult(err error) string {
+	var appErr *apperrors.AppError
+	if err == nil || errors.As(err, &appErr) {
+		return resultOK
+	}
+	return resultError
+}
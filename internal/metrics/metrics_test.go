@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQueue reports a fixed depth and capacity.
+type fakeQueue struct {
+	length, capacity int
+}
+
+func (q fakeQueue) Len() int      { return q.length }
+func (q fakeQueue) Capacity() int { return q.capacity }
+
+func TestNilMetricsRecordNothing(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveHTTPRequest(http.MethodGet, "/health", http.StatusOK, time.Millisecond)
+		m.ObserveDependencyCall(DependencyMongoDB, "users.FindByID", time.Millisecond, nil)
+		m.RegisterQueue(fakeQueue{})
+		m.QueueRejected()
+		m.JobProcessed(OutcomeSuccess, time.Second)
+		m.JobRetried()
+		m.JobFailed(FailureMaxRetries)
+	})
+}
+
+func TestObserveDependencyCall(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"success", nil, resultOK},
+		{"application error is an expected result", apperrors.ErrUserNotFound, resultOK},
+		{"wrapped application error", fmt.Errorf("find: %w", apperrors.ErrUserNotFound), resultOK},
+		{"unexpected error", errors.New("connection refused"), resultError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New()
+
+			m.ObserveDependencyCall(DependencyMongoDB, "users.FindByID", time.Millisecond, tt.err)
+
+			assert.Equal(t, 1, testutil.CollectAndCount(m.dependencyDuration))
+			assert.True(t, m.dependencyDuration.DeleteLabelValues(DependencyMongoDB, "users.FindByID", tt.expected))
+		})
+	}
+}
+
+func TestTranscriptionMetrics(t *testing.T) {
+	m := New()
+	m.RegisterQueue(fakeQueue{length: 3, capacity: 100})
+
+	m.QueueRejected()
+	m.JobProcessed(OutcomeError, time.Second)
+	m.JobRetried()
+	m.JobFailed(FailureMaxRetries)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.queueRejections))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.jobRetries))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.jobFailures.WithLabelValues(FailureMaxRetries)))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "gin_sample_transcription_queue_depth 3")
+	assert.Contains(t, w.Body.String(), "gin_sample_transcription_queue_capacity 100")
+	assert.Contains(t, w.Body.String(), `gin_sample_transcription_job_duration_seconds_count{outcome="error"} 1`)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+}
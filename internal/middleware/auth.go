@@ -2,48 +2,100 @@
 package middleware
 
 import (
+	"context"
+	"log/slog"
 	"strings"
 
+	"gin-sample/internal/authz"
+	"gin-sample/internal/cache"
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
 	"gin-sample/pkg/auth"
-	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Context keys for storing user data
 const (
-	UserIDKey = "userID"
+	UserIDKey    = "userID"
+	SessionIDKey = "sessionID"
+	ClaimsKey    = "tokenClaims"
+	APIKeyKey    = "apiKey"
+	ScopesKey    = "scopes"
 )
 
+// APIKeyAuthenticator resolves personal API keys sent as bearer tokens.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
+
 // Auth returns a middleware that validates JWT tokens.
-func Auth(jwtManager *auth.JWTManager) gin.HandlerFunc {
+// If revocations is not nil, revoked tokens are rejected. If the store is unavailable, tokens
+// are rejected with ErrAuthUnavailable, or accepted if revocationFailOpen is set.
+// If apiKeys is not nil, personal API keys are also accepted.
+// API keys and access tokens with scope claims are restricted to the actions in their scopes;
+// routes check them with RequireScope or TeamAuthz, or reject them with RequireFullAccess.
+func Auth(jwtManager *auth.JWTManager, revocations cache.TokenRevocationStore, revocationFailOpen bool, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			response.Unauthorized(c, "missing authorization header")
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized("missing authorization header"))
 			return
 		}
 
 		// Check Bearer prefix
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Unauthorized(c, "invalid authorization header format")
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized("invalid authorization header format"))
+			return
+		}
+
+		// Personal API keys are looked up by hash instead of being parsed as JWTs
+		if apiKeys != nil && auth.IsAPIKey(parts[1]) {
+			apiKey, err := apiKeys.AuthenticateAPIKey(c.Request.Context(), parts[1])
+			if err != nil {
+				abortWithError(c, err)
+				return
+			}
+
+			c.Set(UserIDKey, apiKey.UserID.Hex())
+			c.Set(APIKeyKey, apiKey)
+			c.Set(ScopesKey, authz.APIKeyActions(apiKey.Scopes))
+			c.Next()
 			return
 		}
 
 		// Validate token
 		claims, err := jwtManager.ValidateToken(parts[1])
 		if err != nil {
-			response.Unauthorized(c, "invalid or expired token")
-			c.Abort()
+			abortWithError(c, apperrors.ErrInvalidToken.WithMessage("invalid or expired token"))
 			return
 		}
 
+		// Reject tokens revoked by logout, password change or account deletion
+		if revocations != nil {
+			revoked, err := revocations.IsRevoked(c.Request.Context(), claims.UserID, claims.SessionID, claims.ID, claims.TokenVersion)
+			if err != nil {
+				if !revocationFailOpen {
+					slog.ErrorContext(c.Request.Context(), "failed to check token revocation", "error", err)
+					abortWithError(c, apperrors.ErrAuthUnavailable)
+					return
+				}
+				slog.WarnContext(c.Request.Context(), "failed to check token revocation, accepting token", "error", err)
+			} else if revoked {
+				abortWithError(c, apperrors.ErrInvalidToken.WithMessage("token has been revoked"))
+				return
+			}
+		}
+
 		// Store user ID in context for handlers to use
 		c.Set(UserIDKey, claims.UserID)
+		c.Set(SessionIDKey, claims.SessionID)
+		c.Set(ClaimsKey, claims)
+		if len(claims.Scopes) > 0 {
+			c.Set(ScopesKey, claims.Scopes)
+		}
 
 		// Continue to next handler
 		c.Next()
@@ -59,3 +111,43 @@ func GetUserID(c *gin.Context) string {
 	}
 	return userID.(string)
 }
+
+// GetSessionID retrieves the login session ID from the context.
+// Returns empty string if the token is not bound to a session.
+func GetSessionID(c *gin.Context) string {
+	sessionID, exists := c.Get(SessionIDKey)
+	if !exists {
+		return ""
+	}
+	return sessionID.(string)
+}
+
+// GetClaims retrieves the validated access token claims from the context.
+// Returns nil if not found.
+func GetClaims(c *gin.Context) *auth.Claims {
+	claims, exists := c.Get(ClaimsKey)
+	if !exists {
+		return nil
+	}
+	return claims.(*auth.Claims)
+}
+
+// GetAPIKey retrieves the API key the request was authenticated with.
+// Returns nil if the request used an access token.
+func GetAPIKey(c *gin.Context) *models.APIKey {
+	apiKey, exists := c.Get(APIKeyKey)
+	if !exists {
+		return nil
+	}
+	return apiKey.(*models.APIKey)
+}
+
+// GetScopes retrieves the actions the request is restricted to.
+// Returns nil if the request is not restricted.
+func GetScopes(c *gin.Context) []string {
+	scopes, exists := c.Get(ScopesKey)
+	if !exists {
+		return nil
+	}
+	return scopes.([]string)
+}
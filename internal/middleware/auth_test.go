@@ -1,17 +1,25 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"gin-sample/internal/authz"
+	cachemocks "gin-sample/internal/cache/mocks"
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
 	"gin-sample/pkg/auth"
 	"gin-sample/pkg/response"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
 )
 
 func init() {
@@ -20,7 +28,7 @@ func init() {
 
 func TestAuth(t *testing.T) {
 	jwtManager := auth.NewJWTManager("testsecret", 15*time.Minute)
-	authMiddleware := Auth(jwtManager)
+	authMiddleware := Auth(jwtManager, nil, false, nil)
 
 	t.Run("allows request with valid token", func(t *testing.T) {
 		userID := "507f1f77bcf86cd799439011"
@@ -37,7 +45,7 @@ func TestAuth(t *testing.T) {
 			c.Status(http.StatusOK)
 		}
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 		if !c.IsAborted() {
 			handler(c)
 		}
@@ -51,7 +59,7 @@ func TestAuth(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -65,7 +73,7 @@ func TestAuth(t *testing.T) {
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Request.Header.Set("Authorization", token) // Missing "Bearer " prefix
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -79,7 +87,7 @@ func TestAuth(t *testing.T) {
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Request.Header.Set("Authorization", "Basic "+token)
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -91,7 +99,7 @@ func TestAuth(t *testing.T) {
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Request.Header.Set("Authorization", "Bearer invalid.token.here")
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -102,14 +110,14 @@ func TestAuth(t *testing.T) {
 		token, _ := shortManager.GenerateToken("user123")
 		time.Sleep(10 * time.Millisecond)
 
-		shortAuthMiddleware := Auth(shortManager)
+		shortAuthMiddleware := Auth(shortManager, nil, false, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Request.Header.Set("Authorization", "Bearer "+token)
 
-		shortAuthMiddleware(c)
+		runMiddleware(c, shortAuthMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -124,7 +132,7 @@ func TestAuth(t *testing.T) {
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Request.Header.Set("Authorization", "Bearer "+token)
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -136,7 +144,7 @@ func TestAuth(t *testing.T) {
 		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Request.Header.Set("Authorization", "Bearer ")
 
-		authMiddleware(c)
+		runMiddleware(c, authMiddleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -165,11 +173,243 @@ func TestGetUserID(t *testing.T) {
 	})
 }
 
+func TestAuth_Revocation(t *testing.T) {
+	jwtManager := auth.NewJWTManager("testsecret", 15*time.Minute)
+	userID := "507f1f77bcf86cd799439011"
+	token, _ := jwtManager.GenerateSessionToken(userID, "family123", 2)
+	claims, _ := jwtManager.ValidateToken(token)
+
+	newRouter := func(revocations *cachemocks.MockTokenRevocationStore, failOpen bool) *gin.Engine {
+		router := newTestRouter()
+		router.Use(ErrorHandler(false), Auth(jwtManager, revocations, failOpen, nil))
+		router.GET("/protected", func(c *gin.Context) {
+			assert.Equal(t, claims.ID, GetClaims(c).ID)
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	tests := []struct {
+		name           string
+		revoked        bool
+		err            error
+		failOpen       bool
+		expectedStatus int
+	}{
+		{"allows token that is not revoked", false, nil, false, http.StatusOK},
+		{"rejects revoked token", true, nil, false, http.StatusUnauthorized},
+		{"rejects token when revocation store fails", false, errors.New("redis down"), false, http.StatusServiceUnavailable},
+		{"allows token when revocation store fails and failing open", false, errors.New("redis down"), true, http.StatusOK},
+		{"rejects revoked token when failing open", true, nil, true, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			revocations := cachemocks.NewMockTokenRevocationStore(ctrl)
+			revocations.EXPECT().
+				IsRevoked(gomock.Any(), userID, "family123", claims.ID, int64(2)).
+				Return(tt.revoked, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			newRouter(revocations, tt.failOpen).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestGetClaims(t *testing.T) {
+	t.Run("returns nil when not set", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		assert.Nil(t, GetClaims(c))
+	})
+}
+
+// apiKeyAuthenticatorFunc adapts a function to APIKeyAuthenticator.
+type apiKeyAuthenticatorFunc func(ctx context.Context, key string) (*models.APIKey, error)
+
+func (f apiKeyAuthenticatorFunc) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	return f(ctx, key)
+}
+
+func TestAuth_APIKey(t *testing.T) {
+	jwtManager := auth.NewJWTManager("testsecret", 15*time.Minute)
+	userID := primitive.NewObjectID()
+	validKey := "gmk_1a2b3c4d_secret"
+
+	apiKeys := apiKeyAuthenticatorFunc(func(ctx context.Context, key string) (*models.APIKey, error) {
+		switch key {
+		case validKey:
+			return &models.APIKey{UserID: userID, Scopes: []string{auth.ScopeMemosRead}}, nil
+		case "gmk_broken_store":
+			return nil, errors.New("database unavailable")
+		default:
+			return nil, apperrors.ErrInvalidAPIKey
+		}
+	})
+
+	newRouter := func(apiKeys APIKeyAuthenticator) *gin.Engine {
+		router := newTestRouter()
+		router.Use(Auth(jwtManager, nil, false, apiKeys))
+		router.GET("/protected", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	tests := []struct {
+		name           string
+		apiKeys        APIKeyAuthenticator
+		token          string
+		expectedStatus int
+	}{
+		{"accepts valid api key", apiKeys, validKey, http.StatusOK},
+		{"rejects unknown api key", apiKeys, "gmk_1a2b3c4d_wrong", http.StatusUnauthorized},
+		{"returns error when lookup fails", apiKeys, "gmk_broken_store", http.StatusInternalServerError},
+		{"rejects api key when api keys are disabled", nil, validKey, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newRouter(tt.apiKeys)
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	t.Run("sets user ID and api key in context", func(t *testing.T) {
+		var capturedUserID string
+		var capturedKey *models.APIKey
+		var capturedScopes []string
+		router := newTestRouter()
+		router.Use(Auth(jwtManager, nil, false, apiKeys))
+		router.GET("/protected", func(c *gin.Context) {
+			capturedUserID = GetUserID(c)
+			capturedKey = GetAPIKey(c)
+			capturedScopes = GetScopes(c)
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+validKey)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, userID.Hex(), capturedUserID)
+		require.NotNil(t, capturedKey)
+		assert.Equal(t, []string{auth.ScopeMemosRead}, capturedKey.Scopes)
+		assert.Equal(t, []string{authz.ActionMemoView}, capturedScopes, "api key scopes are mapped to actions")
+	})
+
+	t.Run("still accepts access tokens", func(t *testing.T) {
+		token, _ := jwtManager.GenerateToken(userID.Hex())
+		var capturedKey *models.APIKey
+		router := newTestRouter()
+		router.Use(Auth(jwtManager, nil, false, apiKeys))
+		router.GET("/protected", func(c *gin.Context) {
+			capturedKey = GetAPIKey(c)
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, capturedKey)
+	})
+}
+
+func TestAuth_ScopedToken(t *testing.T) {
+	jwtManager := auth.NewJWTManager("testsecret", 15*time.Minute)
+
+	tests := []struct {
+		name           string
+		scopes         []string
+		expectedScopes []string
+	}{
+		{"scoped token is restricted", []string{authz.ActionMemoView}, []string{authz.ActionMemoView}},
+		{"token without scopes is unrestricted", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwtManager.GenerateScopedToken("507f1f77bcf86cd799439011", "", 0, tt.scopes)
+			require.NoError(t, err)
+
+			var capturedScopes []string
+			router := newTestRouter()
+			router.Use(Auth(jwtManager, nil, false, nil))
+			router.GET("/protected", func(c *gin.Context) {
+				capturedScopes = GetScopes(c)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedScopes, capturedScopes)
+		})
+	}
+}
+
+func TestGetSessionID(t *testing.T) {
+	t.Run("returns session ID from token", func(t *testing.T) {
+		jwtManager := auth.NewJWTManager("testsecret", 15*time.Minute)
+		token, _ := jwtManager.GenerateSessionToken("507f1f77bcf86cd799439011", "a1b2c3d4e5f67890", 0)
+
+		var sessionID string
+		router := newTestRouter()
+		router.Use(Auth(jwtManager, nil, false, nil))
+		router.GET("/protected", func(c *gin.Context) {
+			sessionID = GetSessionID(c)
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "a1b2c3d4e5f67890", sessionID)
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		assert.Empty(t, GetSessionID(c))
+	})
+}
+
 func TestAuthMiddleware_Integration(t *testing.T) {
 	jwtManager := auth.NewJWTManager("testsecret", 15*time.Minute)
 
-	router := gin.New()
-	router.Use(Auth(jwtManager))
+	router := newTestRouter()
+	router.Use(Auth(jwtManager, nil, false, nil))
 	router.GET("/protected", func(c *gin.Context) {
 		userID := GetUserID(c)
 		response.Success(c, gin.H{"userId": userID})
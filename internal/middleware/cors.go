@@ -1,23 +1,153 @@
 package middleware
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
-// CORS returns a middleware that handles Cross-Origin Resource Sharing.
-func CORS() gin.HandlerFunc {
+// CORSPolicy is a Cross-Origin Resource Sharing policy.
+type CORSPolicy struct {
+	// AllowedOrigins are exact origins ("https://app.example.com"), subdomain wildcards
+	// ("https://*.example.com") or "*" for any origin.
+	AllowedOrigins   []string
+	AllowCredentials bool
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	// ExposedHeaders are the response headers scripts may read.
+	ExposedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// CORSConfig is the CORS policy of all routes, with overrides for path prefixes.
+type CORSConfig struct {
+	Policy CORSPolicy
+	// Routes maps path prefixes to their policy; the longest matching prefix wins.
+	Routes map[string]CORSPolicy
+}
+
+// policyFor returns the policy applying to path.
+func (c *CORSConfig) policyFor(path string) *CORSPolicy {
+	policy, longest := &c.Policy, -1
+	for prefix := range c.Routes {
+		if len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			p := c.Routes[prefix]
+			policy, longest = &p, len(prefix)
+		}
+	}
+	return policy
+}
+
+// allowsAnyOrigin reports whether the policy allows every origin.
+func (p *CORSPolicy) allowsAnyOrigin() bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsOrigin reports whether origin matches one of the allowed origins.
+func (p *CORSPolicy) allowsOrigin(origin string) bool {
+	for _, allowed := range p.AllowedOrigins {
+		if matchOrigin(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchOrigin reports whether origin matches pattern: "*", an exact origin, or an origin
+// whose host starts with "*." to match any subdomain (but not the domain itself).
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" || strings.EqualFold(pattern, origin) {
+		return true
+	}
+	scheme, domain, ok := strings.Cut(pattern, "://*.")
+	if !ok {
+		return false
+	}
+	prefix, suffix := scheme+"://", "."+domain
+	if len(origin) <= len(prefix)+len(suffix) ||
+		!strings.EqualFold(origin[:len(prefix)], prefix) ||
+		!strings.EqualFold(origin[len(origin)-len(suffix):], suffix) {
+		return false
+	}
+	subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+	return !strings.ContainsAny(subdomain, "/:@")
+}
+
+// CORS returns a middleware that handles Cross-Origin Resource Sharing. The configuration
+// is read on every request so it can change while the server runs; nil sends no CORS
+// headers.
+//
+// Allowed origins are echoed back, or "*" when any origin is allowed without credentials.
+// Responses that depend on the origin carry Vary: Origin so shared caches keep them apart.
+// Preflight requests are answered with 204, or 403 when the origin is not allowed, and do
+// not reach the route.
+func CORS(current func() *CORSConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
-		c.Header("Access-Control-Max-Age", "86400") // 24 hours
-
-		// Handle preflight requests
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		cfg := current()
+		if cfg == nil {
+			c.Next()
+			return
+		}
+		policy := cfg.policyFor(c.Request.URL.Path)
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		header := c.Writer.Header()
+		wildcard := policy.allowsAnyOrigin() && !policy.AllowCredentials
+		if !wildcard {
+			header.Add("Vary", "Origin")
+		}
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			c.Next()
 			return
 		}
+		if !policy.allowsOrigin(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if wildcard {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if policy.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
 
+		if preflight {
+			header.Set("Access-Control-Allow-Methods", strings.Join(policy.AllowedMethods, ", "))
+			if len(policy.AllowedHeaders) > 0 {
+				header.Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+			}
+			if policy.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if len(policy.ExposedHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(policy.ExposedHeaders, ", "))
+		}
 		c.Next()
 	}
 }
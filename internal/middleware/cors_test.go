@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -14,91 +15,193 @@ func init() {
 }
 
 func TestCORS(t *testing.T) {
+	allowlist := &CORSConfig{
+		Policy: CORSPolicy{
+			AllowedOrigins:   []string{"https://app.example.com", "https://*.example.org"},
+			AllowCredentials: true,
+			AllowedMethods:   []string{"GET", "POST", "PATCH"},
+			AllowedHeaders:   []string{"Authorization", "Content-Type", "If-Match", "X-Request-ID"},
+			ExposedHeaders:   []string{"ETag", "X-Request-ID"},
+			MaxAge:           10 * time.Minute,
+		},
+		Routes: map[string]CORSPolicy{
+			"/.well-known/": {AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}},
+		},
+	}
+	anyOrigin := &CORSConfig{
+		Policy: CORSPolicy{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET", "POST"}},
+	}
+
 	tests := []struct {
 		name           string
+		config         *CORSConfig
 		method         string
+		path           string
+		headers        map[string]string
 		expectedStatus int
-		checkHeaders   func(*testing.T, http.Header)
+		expected       map[string]string
+		expectedVary   []string
 	}{
 		{
-			name:           "GET request passes through with CORS headers",
+			name:           "allowed origin is echoed with credentials and exposed headers",
+			config:         allowlist,
 			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://app.example.com"},
 			expectedStatus: http.StatusOK,
-			checkHeaders: func(t *testing.T, h http.Header) {
-				assert.Equal(t, "*", h.Get("Access-Control-Allow-Origin"))
-				assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", h.Get("Access-Control-Allow-Methods"))
-				assert.Equal(t, "Origin, Content-Type, Authorization", h.Get("Access-Control-Allow-Headers"))
-				assert.Equal(t, "86400", h.Get("Access-Control-Max-Age"))
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "ETag, X-Request-ID",
+				"Access-Control-Allow-Methods":     "",
 			},
+			expectedVary: []string{"Origin"},
 		},
 		{
-			name:           "POST request passes through with CORS headers",
+			name:           "subdomain matches wildcard origin",
+			config:         allowlist,
 			method:         http.MethodPost,
+			headers:        map[string]string{"Origin": "https://eu.app.example.org"},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": "https://eu.app.example.org"},
+			expectedVary:   []string{"Origin"},
+		},
+		{
+			name:           "wildcard does not match the bare domain",
+			config:         allowlist,
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://example.org"},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": ""},
+			expectedVary:   []string{"Origin"},
+		},
+		{
+			name:           "wildcard does not match a lookalike domain",
+			config:         allowlist,
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://evilexample.org"},
 			expectedStatus: http.StatusOK,
-			checkHeaders: func(t *testing.T, h http.Header) {
-				assert.Equal(t, "*", h.Get("Access-Control-Allow-Origin"))
-				assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", h.Get("Access-Control-Allow-Methods"))
+			expected:       map[string]string{"Access-Control-Allow-Origin": ""},
+			expectedVary:   []string{"Origin"},
+		},
+		{
+			name:           "disallowed origin gets no CORS headers but reaches the handler",
+			config:         allowlist,
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://evil.example.com"},
+			expectedStatus: http.StatusOK,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "",
+				"Access-Control-Allow-Credentials": "",
 			},
+			expectedVary: []string{"Origin"},
 		},
 		{
-			name:           "PUT request passes through with CORS headers",
-			method:         http.MethodPut,
+			name:           "same-origin request still varies on origin",
+			config:         allowlist,
+			method:         http.MethodGet,
 			expectedStatus: http.StatusOK,
-			checkHeaders: func(t *testing.T, h http.Header) {
-				assert.Equal(t, "*", h.Get("Access-Control-Allow-Origin"))
+			expected:       map[string]string{"Access-Control-Allow-Origin": ""},
+			expectedVary:   []string{"Origin"},
+		},
+		{
+			name:   "preflight from allowed origin returns 204",
+			config: allowlist,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "PATCH",
+				"Access-Control-Request-Headers": "if-match",
+			},
+			expectedStatus: http.StatusNoContent,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, POST, PATCH",
+				"Access-Control-Allow-Headers":     "Authorization, Content-Type, If-Match, X-Request-ID",
+				"Access-Control-Max-Age":           "600",
+				"Access-Control-Expose-Headers":    "",
+			},
+			expectedVary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name:   "preflight from disallowed origin returns 403",
+			config: allowlist,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.com",
+				"Access-Control-Request-Method": "POST",
 			},
+			expectedStatus: http.StatusForbidden,
+			expected:       map[string]string{"Access-Control-Allow-Origin": ""},
+			expectedVary:   []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
 		},
 		{
-			name:           "DELETE request passes through with CORS headers",
-			method:         http.MethodDelete,
+			name:           "route override allows any origin without credentials",
+			config:         allowlist,
+			method:         http.MethodGet,
+			path:           "/.well-known/jwks.json",
+			headers:        map[string]string{"Origin": "https://other.example.net"},
 			expectedStatus: http.StatusOK,
-			checkHeaders: func(t *testing.T, h http.Header) {
-				assert.Equal(t, "*", h.Get("Access-Control-Allow-Origin"))
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
 			},
 		},
 		{
-			name:           "OPTIONS preflight request returns 204",
+			name:           "any origin without credentials uses * and does not vary",
+			config:         anyOrigin,
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://app.example.com"},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": "*"},
+		},
+		{
+			name:           "OPTIONS without request method is not a preflight",
+			config:         anyOrigin,
 			method:         http.MethodOptions,
-			expectedStatus: http.StatusNoContent,
-			checkHeaders: func(t *testing.T, h http.Header) {
-				assert.Equal(t, "*", h.Get("Access-Control-Allow-Origin"))
-				assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", h.Get("Access-Control-Allow-Methods"))
-				assert.Equal(t, "Origin, Content-Type, Authorization", h.Get("Access-Control-Allow-Headers"))
-				assert.Equal(t, "86400", h.Get("Access-Control-Max-Age"))
+			headers:        map[string]string{"Origin": "https://app.example.com"},
+			expectedStatus: http.StatusOK,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "",
 			},
 		},
+		{
+			name:           "nil config sends no CORS headers",
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://app.example.com"},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": ""},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			router := gin.New()
-			router.Use(CORS())
-			router.GET("/test", func(c *gin.Context) {
-				c.Status(http.StatusOK)
-			})
-			router.POST("/test", func(c *gin.Context) {
-				c.Status(http.StatusOK)
-			})
-			router.PUT("/test", func(c *gin.Context) {
-				c.Status(http.StatusOK)
-			})
-			router.DELETE("/test", func(c *gin.Context) {
-				c.Status(http.StatusOK)
-			})
-			router.OPTIONS("/test", func(c *gin.Context) {
-				// This won't be reached due to CORS middleware aborting
-				c.Status(http.StatusOK)
-			})
-
-			req := httptest.NewRequest(tt.method, "/test", nil)
+			router.Use(CORS(func() *CORSConfig { return tt.config }))
+			handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+			router.GET("/test", handler)
+			router.POST("/test", handler)
+			router.OPTIONS("/test", handler)
+			router.GET("/.well-known/jwks.json", handler)
+
+			path := tt.path
+			if path == "" {
+				path = "/test"
+			}
+			req := httptest.NewRequest(tt.method, path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
-			if tt.checkHeaders != nil {
-				tt.checkHeaders(t, w.Header())
+			for name, value := range tt.expected {
+				assert.Equal(t, value, w.Header().Get(name), name)
 			}
+			assert.Equal(t, tt.expectedVary, w.Header().Values("Vary"))
 		})
 	}
 }
@@ -107,13 +210,17 @@ func TestCORS_PreflightDoesNotReachHandler(t *testing.T) {
 	handlerCalled := false
 
 	router := gin.New()
-	router.Use(CORS())
+	router.Use(CORS(func() *CORSConfig {
+		return &CORSConfig{Policy: CORSPolicy{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}}}
+	}))
 	router.OPTIONS("/test", func(c *gin.Context) {
 		handlerCalled = true
 		c.Status(http.StatusOK)
 	})
 
 	req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -121,3 +228,21 @@ func TestCORS_PreflightDoesNotReachHandler(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, w.Code)
 	assert.False(t, handlerCalled, "handler should not be called for preflight requests")
 }
+
+func TestCORS_PreflightForRouteWithoutOptionsHandler(t *testing.T) {
+	router := gin.New()
+	router.Use(CORS(func() *CORSConfig {
+		return &CORSConfig{Policy: CORSPolicy{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"DELETE"}}}
+	}))
+	router.DELETE("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "DELETE")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "DELETE", w.Header().Get("Access-Control-Allow-Methods"))
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler returns a middleware that renders the last error added with c.Error,
+// unless a response has already been written. Errors are mapped to their status and code
+// with apperrors.From; unexpected errors are logged and reported as internal errors.
+// Errors are sent in the standard response format, or as RFC 7807 problems if problemJSON
+// is set or the client accepts application/problem+json.
+func ErrorHandler(problemJSON bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperrors.From(err)
+		if appErr.Status >= http.StatusInternalServerError {
+			slog.ErrorContext(c.Request.Context(), "request failed",
+				"method", c.Request.Method, "path", c.Request.URL.Path, "error", err)
+		}
+
+		if retryAfter, ok := apperrors.RetryAfter(err); ok {
+			response.SetRetryAfter(c, retryAfter)
+		}
+
+		fields := make([]response.FieldError, 0, len(appErr.Fields))
+		for _, field := range appErr.Fields {
+			fields = append(fields, response.FieldError(field))
+		}
+		if len(fields) == 0 {
+			fields = nil
+		}
+
+		if problemJSON || acceptsProblemJSON(c) {
+			response.ProblemDetails(c, response.Problem{
+				Status:  appErr.Status,
+				Detail:  appErr.Message,
+				Code:    appErr.Code,
+				Details: appErr.Details,
+				Errors:  fields,
+			})
+			return
+		}
+		response.ErrorWithDetails(c, appErr.Status, appErr.Code, appErr.Message, appErr.Details, fields)
+	}
+}
+
+// abortWithError adds err to the context for ErrorHandler and stops the handler chain.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// acceptsProblemJSON reports whether the client asked for application/problem+json responses.
+func acceptsProblemJSON(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), response.ProblemContentType)
+}
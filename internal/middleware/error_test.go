@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRouter returns a router that renders errors like the application router.
+func newTestRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(ErrorHandler(false))
+	return router
+}
+
+// runMiddleware runs a middleware on a test context and renders any error it adds,
+// as ErrorHandler does in the application router.
+func runMiddleware(c *gin.Context, middleware gin.HandlerFunc) {
+	middleware(c)
+	ErrorHandler(false)(c)
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedError  string
+	}{
+		{"sentinel error", apperrors.ErrTeamNotFound, http.StatusNotFound, "team_not_found", "team not found"},
+		{"sentinel with message", apperrors.ErrNotTeamMember.WithMessage("new owner must be a team member"), http.StatusForbidden, "not_team_member", "new owner must be a team member"},
+		{"bad request", apperrors.BadRequest("invalid team id format"), http.StatusBadRequest, apperrors.CodeBadRequest, "invalid team id format"},
+		{"unexpected error is hidden", errors.New("connection refused"), http.StatusInternalServerError, apperrors.CodeInternal, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/test", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.False(t, resp.Success)
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.expectedError, resp.Error)
+		})
+	}
+
+	t.Run("renders field errors and details", func(t *testing.T) {
+		router := newTestRouter()
+		router.GET("/test", func(c *gin.Context) {
+			err := apperrors.Validation(apperrors.FieldError{Field: "email", Rule: "email", Message: "email must be a valid email address"})
+			_ = c.Error(err.WithDetails(map[string]any{"limit": 3}))
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"success": false,
+			"error": "request validation failed",
+			"code": "validation_failed",
+			"details": {"limit": 3},
+			"fields": [{"field": "email", "rule": "email", "message": "email must be a valid email address"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("sets Retry-After for rate limit errors", func(t *testing.T) {
+		router := newTestRouter()
+		router.GET("/test", func(c *gin.Context) {
+			_ = c.Error(&apperrors.RateLimitError{RetryAfter: 30 * time.Second})
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("does not overwrite a written response", func(t *testing.T) {
+		router := newTestRouter()
+		router.GET("/test", func(c *gin.Context) {
+			_ = c.Error(errors.New("logged elsewhere"))
+			c.JSON(http.StatusAccepted, gin.H{"ok": true})
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.JSONEq(t, `{"ok": true}`, w.Body.String())
+	})
+}
+
+func TestErrorHandler_ProblemJSON(t *testing.T) {
+	newRouter := func(problemJSON bool) *gin.Engine {
+		router := gin.New()
+		router.Use(ErrorHandler(problemJSON))
+		router.GET("/teams/:teamId", func(c *gin.Context) {
+			_ = c.Error(apperrors.ErrTeamNotFound)
+		})
+		return router
+	}
+
+	tests := []struct {
+		name        string
+		problemJSON bool
+		accept      string
+		expectJSON  bool
+	}{
+		{"client accepts problem+json", false, "application/problem+json", false},
+		{"enabled for all responses", true, "", false},
+		{"standard response by default", false, "application/json", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teams/123", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			newRouter(tt.problemJSON).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			if tt.expectJSON {
+				assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+				return
+			}
+
+			assert.Equal(t, response.ProblemContentType, w.Header().Get("Content-Type"))
+			assert.JSONEq(t, `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"detail": "team not found",
+				"instance": "/teams/123",
+				"code": "team_not_found"
+			}`, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gin-sample/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardening(t *testing.T) {
+	cfg := HardeningConfig{
+		MaxBodyBytes:          16,
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		DocsPath:              "/docs/",
+	}
+
+	newRouter := func(cfg HardeningConfig) *gin.Engine {
+		router := gin.New()
+		router.Use(ErrorHandler(false), Hardening(cfg))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.GET("/docs/*any", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.POST("/test", func(c *gin.Context) {
+			var body map[string]any
+			if err := c.ShouldBindJSON(&body); err != nil {
+				_ = c.Error(validator.BindError(err, ""))
+				return
+			}
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	t.Run("sets security headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(cfg).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+		assert.Equal(t, apiContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+		assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("allows the docs UI under the docs path", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(cfg).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/index.html", nil))
+
+		assert.Equal(t, docsContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("omits HSTS when max age is zero", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(HardeningConfig{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("accepts a body within the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(cfg).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"a":1}`)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects a declared body over the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(cfg).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"longer than sixteen bytes"}`)))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "request_too_large")
+	})
+
+	t.Run("rejects a streamed body over the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", io.NopCloser(strings.NewReader(`{"name":"longer than sixteen bytes"}`)))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		newRouter(cfg).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
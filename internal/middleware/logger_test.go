@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gin-sample/internal/logger"
+	"gin-sample/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		expectedLevel string
+	}{
+		{"success at info", http.StatusOK, "INFO"},
+		{"client error at warn", http.StatusNotFound, "WARN"},
+		{"server error at error", http.StatusInternalServerError, "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log, err := logger.New(&buf, logger.FormatJSON, "info")
+			require.NoError(t, err)
+
+			router := gin.New()
+			router.Use(RequestID(), Logger(log))
+			router.GET("/memos/:id", func(c *gin.Context) {
+				c.Status(tt.status)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/memos/123", nil)
+			req.Header.Set(requestid.Header, "req-42")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			assert.Equal(t, tt.expectedLevel, record["level"])
+			assert.Equal(t, "request", record["msg"])
+			assert.Equal(t, "GET", record["method"])
+			assert.Equal(t, "/memos/123", record["path"])
+			assert.Equal(t, "/memos/:id", record["route"])
+			assert.Equal(t, float64(tt.status), record["status"])
+			assert.Equal(t, "req-42", record["request_id"])
+		})
+	}
+}
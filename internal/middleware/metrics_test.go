@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gin-sample/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+
+	router := gin.New()
+	router.Use(Metrics(m))
+	router.GET("/memos/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/memos/1", "/memos/2", "/unknown"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	count := testutil.CollectAndCount(m.Registry(), "gin_sample_http_request_duration_seconds")
+	assert.Equal(t, 2, count, "requests are grouped by route template")
+
+	expected := `gin_sample_http_request_duration_seconds_count{method="GET",route="/memos/:id",status="200"} 2`
+	body := scrape(t, m)
+	assert.Contains(t, body, expected)
+	assert.Contains(t, body, `route="unmatched",status="404"`)
+}
+
+func TestMetrics_BoundsLabels(t *testing.T) {
+	m := metrics.New()
+
+	router := gin.New()
+	router.Use(Metrics(m))
+	router.GET("/memos/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, method := range []string{"PROPFIND", "X-RANDOM-1", "X-RANDOM-2"} {
+		req := httptest.NewRequest(method, "/memos/1", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	for _, path := range []string{"/a", "/b/c", "/d?e=f"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `gin_sample_http_request_duration_seconds_count{method="OTHER",route="unmatched",status="404"} 3`)
+	assert.Contains(t, body, `gin_sample_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 3`)
+	assert.NotContains(t, body, "PROPFIND")
+	assert.NotContains(t, body, "X-RANDOM")
+}
+
+// scrape returns the metrics exposition served by m's handler.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	return w.Body.String()
+}
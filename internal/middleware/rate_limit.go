@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxRateLimitBodySize bounds how much of the request body is read to find an account key.
+const maxRateLimitBodySize = 64 << 10
+
+// RateLimitKeyFunc extracts the value a request is limited by.
+// An empty value skips rate limiting for the request.
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// ByClientIP limits requests per client IP.
+func ByClientIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// ByJSONField limits requests per value of a top-level string field in the JSON body,
+// such as the account email. The body is restored for the handler.
+func ByJSONField(field string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRateLimitBodySize))
+		if err != nil {
+			return ""
+		}
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		value, _ := fields[field].(string)
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
+
+// RateLimit returns a middleware that limits requests per key over a sliding window.
+// The name scopes the limit so different routes and keys are counted separately.
+// The limit is read on every request so it can change while the server runs; a limit with
+// zero requests is not enforced. Requests over the limit get 429 with a Retry-After header.
+// If the limiter is unavailable the request is allowed.
+func RateLimit(limiter ratelimit.Limiter, name string, currentLimit func() ratelimit.Limit, keyFunc RateLimitKeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := currentLimit()
+		if limit.Requests <= 0 {
+			c.Next()
+			return
+		}
+
+		key := keyFunc(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		result, err := limiter.Allow(c.Request.Context(), name+":"+key, limit)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limit check failed", "limit", name, "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+
+		if !result.Allowed {
+			abortWithError(c, &apperrors.RateLimitError{RetryAfter: result.RetryAfter})
+			return
+		}
+
+		c.Next()
+	}
+}
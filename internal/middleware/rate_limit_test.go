@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gin-sample/internal/ratelimit"
+	"gin-sample/internal/ratelimit/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimit(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 5, Window: time.Minute}
+
+	tests := []struct {
+		name           string
+		body           string
+		keyFunc        RateLimitKeyFunc
+		unlimited      bool
+		mockSetup      func(*mocks.MockLimiter)
+		expectedStatus int
+		expectedRetry  string
+	}{
+		{
+			name:    "allows request within limit",
+			keyFunc: ByClientIP,
+			mockSetup: func(m *mocks.MockLimiter) {
+				m.EXPECT().Allow(gomock.Any(), "login:ip:192.0.2.1", limit).
+					Return(&ratelimit.Result{Allowed: true, Remaining: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "rejects request over limit",
+			keyFunc: ByClientIP,
+			mockSetup: func(m *mocks.MockLimiter) {
+				m.EXPECT().Allow(gomock.Any(), "login:ip:192.0.2.1", limit).
+					Return(&ratelimit.Result{Allowed: false, RetryAfter: 42 * time.Second}, nil)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedRetry:  "42",
+		},
+		{
+			name:    "allows request when limiter fails",
+			keyFunc: ByClientIP,
+			mockSetup: func(m *mocks.MockLimiter) {
+				m.EXPECT().Allow(gomock.Any(), gomock.Any(), limit).
+					Return(nil, errors.New("redis down"))
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "limits by normalized json field",
+			body:    `{"email":" User@Example.com ","password":"secret"}`,
+			keyFunc: ByJSONField("email"),
+			mockSetup: func(m *mocks.MockLimiter) {
+				m.EXPECT().Allow(gomock.Any(), "login:ip:user@example.com", limit).
+					Return(&ratelimit.Result{Allowed: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "skips when json field is missing",
+			body:           `{"password":"secret"}`,
+			keyFunc:        ByJSONField("email"),
+			mockSetup:      func(m *mocks.MockLimiter) {},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "skips when limit is not set",
+			keyFunc:        ByClientIP,
+			unlimited:      true,
+			mockSetup:      func(m *mocks.MockLimiter) {},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLimiter := mocks.NewMockLimiter(ctrl)
+			tt.mockSetup(mockLimiter)
+
+			currentLimit := func() ratelimit.Limit {
+				if tt.unlimited {
+					return ratelimit.Limit{}
+				}
+				return limit
+			}
+
+			var handlerBody string
+			router := newTestRouter()
+			router.POST("/login", RateLimit(mockLimiter, "login:ip", currentLimit, tt.keyFunc), func(c *gin.Context) {
+				data, _ := io.ReadAll(c.Request.Body)
+				handlerBody = string(data)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(tt.body))
+			req.RemoteAddr = "192.0.2.1:1234"
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedRetry, w.Header().Get("Retry-After"))
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.body, handlerBody, "body must be readable by the handler")
+			}
+		})
+	}
+}
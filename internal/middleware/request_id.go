@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"gin-sample/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestID returns a middleware that assigns every request an ID.
+// A valid X-Request-ID header from the client is kept, otherwise a new ID is generated.
+// The ID is echoed in the response header and carried in the request context.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gin-sample/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		expectKept bool
+	}{
+		{"generates an ID when the header is missing", "", false},
+		{"keeps a valid client ID", "client-req-42", true},
+		{"replaces an ID with whitespace", "bad id", false},
+		{"replaces an overlong ID", strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contextID string
+			router := gin.New()
+			router.Use(RequestID())
+			router.GET("/test", func(c *gin.Context) {
+				contextID = requestid.FromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(requestid.Header, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			responseID := w.Header().Get(requestid.Header)
+			assert.NotEmpty(t, responseID)
+			assert.Equal(t, responseID, contextID)
+			if tt.expectKept {
+				assert.Equal(t, tt.header, responseID)
+			} else {
+				assert.NotEqual(t, tt.header, responseID)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"gin-sample/internal/authz"
+	apperrors "gin-sample/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope returns a middleware that limits restricted requests (API keys and access
+// tokens with scope claims) to those granted the action. A team-scoped API key is also
+// limited to routes for its team (identified by the teamId path parameter).
+// Unrestricted requests are not affected.
+func RequireScope(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := CheckScope(c, action); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireFullAccess returns a middleware that rejects restricted requests.
+// Use it on routes that do not map to an authorization action, such as account management.
+func RequireFullAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if GetScopes(c) != nil {
+			abortWithError(c, apperrors.Forbidden("restricted token cannot access this endpoint"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// CheckScope returns an error explaining why a request may not perform action, or nil if it may.
+// Handlers use it when the action depends on the resource, so it cannot be fixed on the route.
+func CheckScope(c *gin.Context, action string) error {
+	if !authz.ScopeAllows(GetScopes(c), action) {
+		return apperrors.Forbidden("token is missing scope " + action)
+	}
+
+	if apiKey := GetAPIKey(c); apiKey != nil && apiKey.TeamID != nil && c.Param("teamId") != apiKey.TeamID.Hex() {
+		return apperrors.Forbidden("api key is restricted to another team")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gin-sample/internal/authz"
+	"gin-sample/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// setScopes returns a handler that restricts the request like Auth does for scoped credentials.
+func setScopes(scopes []string, apiKey *models.APIKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scopes != nil {
+			c.Set(ScopesKey, scopes)
+		}
+		if apiKey != nil {
+			c.Set(APIKeyKey, apiKey)
+		}
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	otherTeamID := primitive.NewObjectID()
+	teamKey := &models.APIKey{TeamID: &teamID}
+
+	tests := []struct {
+		name           string
+		scopes         []string
+		apiKey         *models.APIKey
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "allows unrestricted token",
+			path:           "/voice-memos",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "allows token with scope",
+			scopes:         []string{authz.ActionMemoView},
+			path:           "/voice-memos",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects token without scope",
+			scopes:         []string{authz.ActionMemoCreate},
+			path:           "/voice-memos",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "allows team-scoped api key on its team",
+			scopes:         []string{authz.ActionMemoView},
+			apiKey:         teamKey,
+			path:           "/teams/" + teamID.Hex() + "/voice-memos",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects team-scoped api key on another team",
+			scopes:         []string{authz.ActionMemoView},
+			apiKey:         teamKey,
+			path:           "/teams/" + otherTeamID.Hex() + "/voice-memos",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "rejects team-scoped api key on personal routes",
+			scopes:         []string{authz.ActionMemoView},
+			apiKey:         teamKey,
+			path:           "/voice-memos",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+			router := newTestRouter()
+			router.Use(setScopes(tt.scopes, tt.apiKey))
+			router.GET("/voice-memos", RequireScope(authz.ActionMemoView), ok)
+			router.GET("/teams/:teamId/voice-memos", RequireScope(authz.ActionMemoView), ok)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestRequireFullAccess(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopes         []string
+		expectedStatus int
+	}{
+		{"allows unrestricted token", nil, http.StatusOK},
+		{"rejects scoped token", []string{authz.ActionMemoView}, http.StatusForbidden},
+		{"rejects token restricted to nothing", []string{}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/auth/password", setScopes(tt.scopes, nil), RequireFullAccess(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/password", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
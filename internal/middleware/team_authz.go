@@ -2,7 +2,7 @@ package middleware
 
 import (
 	"gin-sample/internal/authz"
-	"gin-sample/pkg/response"
+	apperrors "gin-sample/internal/errors"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,50 +15,63 @@ const (
 )
 
 // TeamAuthz returns a middleware that checks team authorization.
-// It validates that the user is a member of the team and has permission for the action.
+// It validates that the token's scopes include the action, and that the user is a member
+// of the team whose role has permission for it. If the role only has the own-resources
+// variant of the action (e.g. memo:delete_own), the request is allowed through and the
+// handler must check the resource with Authorizer.CanPerformOnResource.
 func TeamAuthz(authorizer authz.Authorizer, action string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from context (set by Auth middleware)
 		userIDStr := GetUserID(c)
 		if userIDStr == "" {
-			response.Unauthorized(c, "user not authenticated")
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized("user not authenticated"))
 			return
 		}
 
 		userID, err := primitive.ObjectIDFromHex(userIDStr)
 		if err != nil {
-			response.Unauthorized(c, "invalid user id format")
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized("invalid user id format"))
 			return
 		}
 
 		// Get team ID from path parameter
 		teamIDStr := c.Param("teamId")
 		if teamIDStr == "" {
-			response.BadRequest(c, "team id is required")
-			c.Abort()
+			abortWithError(c, apperrors.BadRequest("team id is required"))
 			return
 		}
 
 		teamID, err := primitive.ObjectIDFromHex(teamIDStr)
 		if err != nil {
-			response.BadRequest(c, "invalid team id format")
-			c.Abort()
+			abortWithError(c, apperrors.BadRequest("invalid team id format"))
+			return
+		}
+
+		// Check the token's scopes before the user's role
+		if err := CheckScope(c, action); err != nil {
+			abortWithError(c, err)
 			return
 		}
 
 		// Check authorization
 		allowed, err := authorizer.CanPerform(c.Request.Context(), userID, teamID, action)
 		if err != nil {
-			response.InternalError(c)
-			c.Abort()
+			abortWithError(c, err)
 			return
 		}
 
 		if !allowed {
-			response.Forbidden(c, "insufficient permissions")
-			c.Abort()
+			if ownAction, ok := authz.OwnAction(action); ok {
+				allowed, err = authorizer.CanPerform(c.Request.Context(), userID, teamID, ownAction)
+				if err != nil {
+					abortWithError(c, err)
+					return
+				}
+			}
+		}
+
+		if !allowed {
+			abortWithError(c, apperrors.ErrInsufficientPermissions)
 			return
 		}
 
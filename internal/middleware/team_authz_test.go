@@ -47,7 +47,7 @@ func TestTeamAuthz(t *testing.T) {
 			c.Status(http.StatusOK)
 		}
 
-		middleware(c)
+		runMiddleware(c, middleware)
 		if !c.IsAborted() {
 			handler(c)
 		}
@@ -79,12 +79,114 @@ func TestTeamAuthz(t *testing.T) {
 		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
 		c.Set(UserIDKey, validUserID.Hex())
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.Equal(t, http.StatusForbidden, w.Code)
 		assert.True(t, c.IsAborted())
 	})
 
+	t.Run("allows role with only the own-resources variant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuthz := mocks.NewMockAuthorizer(ctrl)
+		mockAuthz.EXPECT().
+			CanPerform(gomock.Any(), validUserID, validTeamID, authz.ActionMemoDelete).
+			Return(false, nil)
+		mockAuthz.EXPECT().
+			CanPerform(gomock.Any(), validUserID, validTeamID, authz.ActionMemoDeleteOwn).
+			Return(true, nil)
+		mockAuthz.EXPECT().
+			GetUserRole(gomock.Any(), validUserID, validTeamID).
+			Return("contributor", nil)
+
+		middleware := TeamAuthz(mockAuthz, authz.ActionMemoDelete)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/teams/"+validTeamID.Hex()+"/voice-memos/1", nil)
+		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
+		c.Set(UserIDKey, validUserID.Hex())
+
+		runMiddleware(c, middleware)
+
+		assert.False(t, c.IsAborted())
+	})
+
+	t.Run("rejects when neither action nor own variant is allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuthz := mocks.NewMockAuthorizer(ctrl)
+		mockAuthz.EXPECT().
+			CanPerform(gomock.Any(), validUserID, validTeamID, authz.ActionMemoDelete).
+			Return(false, nil)
+		mockAuthz.EXPECT().
+			CanPerform(gomock.Any(), validUserID, validTeamID, authz.ActionMemoDeleteOwn).
+			Return(false, nil)
+
+		middleware := TeamAuthz(mockAuthz, authz.ActionMemoDelete)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/teams/"+validTeamID.Hex()+"/voice-memos/1", nil)
+		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
+		c.Set(UserIDKey, validUserID.Hex())
+
+		runMiddleware(c, middleware)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("rejects token without scope for the action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// Authorizer is not consulted when the token's scopes do not allow the action
+		mockAuthz := mocks.NewMockAuthorizer(ctrl)
+
+		middleware := TeamAuthz(mockAuthz, authz.ActionMemoDelete)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/teams/"+validTeamID.Hex()+"/voice-memos/1", nil)
+		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
+		c.Set(UserIDKey, validUserID.Hex())
+		c.Set(ScopesKey, []string{authz.ActionMemoView})
+
+		runMiddleware(c, middleware)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("allows token with scope when role permits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuthz := mocks.NewMockAuthorizer(ctrl)
+		mockAuthz.EXPECT().
+			CanPerform(gomock.Any(), validUserID, validTeamID, authz.ActionMemoView).
+			Return(true, nil)
+		mockAuthz.EXPECT().
+			GetUserRole(gomock.Any(), validUserID, validTeamID).
+			Return("member", nil)
+
+		middleware := TeamAuthz(mockAuthz, authz.ActionMemoView)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/teams/"+validTeamID.Hex()+"/voice-memos", nil)
+		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
+		c.Set(UserIDKey, validUserID.Hex())
+		c.Set(ScopesKey, []string{authz.ActionMemoView})
+
+		runMiddleware(c, middleware)
+
+		assert.False(t, c.IsAborted())
+	})
+
 	t.Run("rejects request when user not authenticated", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -98,7 +200,7 @@ func TestTeamAuthz(t *testing.T) {
 		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
 		// UserID not set
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -117,7 +219,7 @@ func TestTeamAuthz(t *testing.T) {
 		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
 		c.Set(UserIDKey, "invalid-user-id")
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.True(t, c.IsAborted())
@@ -136,7 +238,7 @@ func TestTeamAuthz(t *testing.T) {
 		// No teamId param
 		c.Set(UserIDKey, validUserID.Hex())
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.True(t, c.IsAborted())
@@ -155,7 +257,7 @@ func TestTeamAuthz(t *testing.T) {
 		c.Params = gin.Params{{Key: "teamId", Value: "invalid-team-id"}}
 		c.Set(UserIDKey, validUserID.Hex())
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.True(t, c.IsAborted())
@@ -178,7 +280,7 @@ func TestTeamAuthz(t *testing.T) {
 		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
 		c.Set(UserIDKey, validUserID.Hex())
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		assert.True(t, c.IsAborted())
@@ -212,7 +314,7 @@ func TestTeamMember(t *testing.T) {
 		c.Params = gin.Params{{Key: "teamId", Value: validTeamID.Hex()}}
 		c.Set(UserIDKey, validUserID.Hex())
 
-		middleware(c)
+		runMiddleware(c, middleware)
 
 		assert.False(t, c.IsAborted())
 	})
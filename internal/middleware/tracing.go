@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// untracedPaths are polled by infrastructure and would only add noise to traces.
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+}
+
+// Tracing returns a middleware that starts a server span for every request, named after
+// the route template. A trace propagated by the caller in the traceparent header is
+// continued; otherwise a new trace is started. Metrics and health probes are not traced.
+// serviceName is the name of the server.
+func Tracing(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithGinFilter(func(c *gin.Context) bool {
+		return !untracedPaths[c.Request.URL.Path]
+	}))
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gin-sample/internal/tracing/tracingtest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	setup := func() *gin.Engine {
+		router := gin.New()
+		router.Use(Tracing("gin-sample"))
+		router.GET("/memos/:id", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		router.GET("/health", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	t.Run("names spans after the route", func(t *testing.T) {
+		recorder := tracingtest.Record(t)
+
+		setup().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/memos/42", nil))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GET /memos/:id", spans[0].Name())
+		assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	})
+
+	t.Run("continues the caller's trace", func(t *testing.T) {
+		recorder := tracingtest.Record(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/memos/42", nil)
+		req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		setup().ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "b7ad6b7169203331", spans[0].Parent().SpanID().String())
+	})
+
+	t.Run("skips health checks", func(t *testing.T) {
+		recorder := tracingtest.Record(t)
+
+		setup().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		assert.Empty(t, recorder.Ended())
+	})
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKey is a personal access token a user creates for scripts and integrations.
+// Only a SHA-256 hash of the key is stored; the key itself is shown once, when it is created.
+type APIKey struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	UserID     primitive.ObjectID  `json:"-" bson:"userId"`
+	Name       string              `json:"name" bson:"name" example:"Backup script"`
+	Prefix     string              `json:"prefix" bson:"prefix" example:"gmk_1a2b3c4d"`
+	KeyHash    string              `json:"-" bson:"keyHash"`
+	Scopes     []string            `json:"scopes" bson:"scopes" example:"memos:read"`
+	TeamID     *primitive.ObjectID `json:"teamId,omitempty" bson:"teamId,omitempty" example:"507f1f77bcf86cd799439012"` // Restricts the key to one team's memos
+	ExpiresAt  *time.Time          `json:"expiresAt,omitempty" bson:"expiresAt,omitempty" example:"2024-04-15T09:30:00Z"`
+	LastUsedAt *time.Time          `json:"lastUsedAt,omitempty" bson:"lastUsedAt,omitempty" example:"2024-01-20T14:00:00Z"`
+	CreatedAt  time.Time           `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
+}
+
+// HasScope reports whether the key was granted scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether the key has an expiry that has passed.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// CreateAPIKeyRequest is the payload for creating an API key.
+// Keys without ExpiresInDays never expire.
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,min=1,max=100" example:"Backup script"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=memos:read memos:write" example:"memos:read"`
+	TeamID        string   `json:"teamId,omitempty" binding:"omitempty,mongodb" example:"507f1f77bcf86cd799439012"`
+	ExpiresInDays int      `json:"expiresInDays,omitempty" binding:"omitempty,min=1,max=365" example:"90"`
+}
+
+// CreateAPIKeyResponse is the response after creating an API key.
+// Key is only returned here and cannot be retrieved later.
+type CreateAPIKeyResponse struct {
+	Key    string `json:"key" example:"gmk_1a2b3c4d_9f8e7d6c..."`
+	APIKey APIKey `json:"apiKey"`
+}
+
+// APIKeyListResponse is the response for listing a user's API keys.
+type APIKeyListResponse struct {
+	Items []APIKey `json:"items"`
+}
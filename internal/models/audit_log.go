@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Audit log target types.
+const (
+	AuditTargetTeam       = "team"
+	AuditTargetMember     = "member"
+	AuditTargetInvitation = "invitation"
+	AuditTargetMemo       = "memo"
+	AuditTargetRole       = "role"
+)
+
+// Audit log actions for changes that share an authorization action with other changes.
+const (
+	AuditActionInvitationCancel = "invitation:cancel"
+	AuditActionInvitationResend = "invitation:resend"
+	AuditActionInvitationExtend = "invitation:extend"
+	AuditActionRoleCreate       = "role:create"
+	AuditActionRoleUpdate       = "role:update"
+	AuditActionRoleDelete       = "role:delete"
+)
+
+// AuditLogEntry records an administrative action taken in a team. Entries are append-only.
+// Action is the authorization action that permitted the change (e.g. member:remove), or an
+// AuditAction constant where that action permits several kinds of change;
+// Before and After hold the changed fields of the target.
+type AuditLogEntry struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	TeamID     primitive.ObjectID `json:"teamId" bson:"teamId" example:"507f1f77bcf86cd799439012"`
+	ActorID    primitive.ObjectID `json:"actorId" bson:"actorId" example:"507f1f77bcf86cd799439013"`
+	Action     string             `json:"action" bson:"action" example:"member:update_role"`
+	TargetType string             `json:"targetType" bson:"targetType" example:"member"`
+	TargetID   primitive.ObjectID `json:"targetId" bson:"targetId" example:"507f1f77bcf86cd799439014"`
+	Before     map[string]any     `json:"before,omitempty" bson:"before,omitempty"`
+	After      map[string]any     `json:"after,omitempty" bson:"after,omitempty"`
+	RequestID  string             `json:"requestId,omitempty" bson:"requestId,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
+}
+
+// AuditLogFilter narrows a team's audit log. Nil and empty fields match any entry.
+type AuditLogFilter struct {
+	ActorID    *primitive.ObjectID
+	Action     string
+	TargetType string
+	TargetID   *primitive.ObjectID
+	// From and To bound CreatedAt, inclusive and exclusive respectively.
+	From *time.Time
+	To   *time.Time
+}
+
+// AuditLogListResponse is the response for listing a team's audit log.
+type AuditLogListResponse struct {
+	Items      []AuditLogEntry `json:"items"`
+	Pagination Pagination      `json:"pagination"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// MFAChallengeResponse is returned by the password step of login when the user has MFA enabled.
+// The MFA token is exchanged for an AuthResponse at /auth/mfa/verify.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired" example:"true"`
+	MFAToken    string `json:"mfaToken" example:"mfa_3f2a9c..."`
+	ExpiresIn   int    `json:"expiresIn" example:"300"`
+}
+
+// MFAVerifyRequest is the payload for the second step of login.
+// Code is either a 6-digit TOTP code or a recovery code.
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfaToken" binding:"required" example:"mfa_3f2a9c..."`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// MFAEnrollResponse is returned when starting TOTP enrollment.
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OTPAuthURI string `json:"otpauthUri" example:"otpauth://totp/gin-sample:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=gin-sample"`
+}
+
+// MFACodeRequest is the payload for confirming enrollment or regenerating recovery codes.
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// MFADisableRequest is the payload for disabling MFA. Requires the password and a current code.
+type MFADisableRequest struct {
+	Password string `json:"password" binding:"required" example:"secret123"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// MFARecoveryCodesResponse contains newly issued recovery codes. They are shown only once.
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" example:"a1b2c-3d4e5,f6a7b-8c9d0"`
+}
+
+// MFAStatusResponse describes a user's MFA enrollment.
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled" example:"true"`
+	EnabledAt              *time.Time `json:"enabledAt,omitempty" example:"2024-01-15T09:30:00Z"`
+	RecoveryCodesRemaining int        `json:"recoveryCodesRemaining" example:"8"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ExternalIdentity links a user to an account at an OpenID Connect provider.
+type ExternalIdentity struct {
+	Provider string `json:"provider" bson:"provider" example:"google"`
+	Subject  string `json:"-" bson:"subject"`
+	// Key is IdentityKey(Provider, Subject), set by the repository. It has a unique index:
+	// a compound index on provider and subject cannot enforce uniqueness of pairs inside an array.
+	Key      string    `json:"-" bson:"key"`
+	Email    string    `json:"email" bson:"email" example:"user@example.com"`
+	LinkedAt time.Time `json:"linkedAt" bson:"linkedAt" example:"2024-01-15T09:30:00Z"`
+}
+
+// IdentityKey returns the key identifying the account subject at provider.
+func IdentityKey(provider, subject string) string {
+	return provider + "|" + subject
+}
+
+// OIDCProviderInfo describes a configured login provider.
+type OIDCProviderInfo struct {
+	Name        string `json:"name" example:"google"`
+	DisplayName string `json:"displayName" example:"Google"`
+}
+
+// OIDCProviderListResponse lists the configured login providers.
+type OIDCProviderListResponse struct {
+	Items []OIDCProviderInfo `json:"items"`
+}
+
+// OIDCAuthorizeResponse starts a provider login. The client sends the user to AuthorizationURL;
+// the provider redirects back with a code and the state, which are posted to the callback endpoint.
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorizationUrl" example:"https://accounts.google.com/o/oauth2/v2/auth?client_id=..."`
+	State            string `json:"state" example:"oidc_7c1e4b..."`
+	ExpiresIn        int    `json:"expiresIn" example:"600"`
+}
+
+// OIDCCallbackRequest is the payload for completing a provider login.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required" example:"4/0AX4XfWh..."`
+	State string `json:"state" binding:"required" example:"oidc_7c1e4b..."`
+}
@@ -41,3 +41,37 @@ type RefreshResponse struct {
 	RefreshToken string `json:"refreshToken,omitempty" example:"rt_a1b2c3d4e5f67890_..."`
 	ExpiresIn    int    `json:"expiresIn" example:"900"`
 }
+
+// ScopedTokenRequest is the payload for creating an access token restricted to some actions.
+type ScopedTokenRequest struct {
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=team:view team:update team:delete team:transfer member:invite member:remove member:update_role memo:view memo:create memo:update memo:delete" example:"memo:view"`
+}
+
+// ScopedTokenResponse is the response after creating a restricted access token.
+type ScopedTokenResponse struct {
+	AccessToken string   `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIs..."`
+	ExpiresIn   int      `json:"expiresIn" example:"900"`
+	Scopes      []string `json:"scopes" example:"memo:view"`
+}
+
+// ClientInfo describes the device a request came from. It is recorded on login sessions.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Session is an active login session (refresh token family) on one device.
+type Session struct {
+	ID         string    `json:"id" example:"a1b2c3d4e5f67890"`
+	UserAgent  string    `json:"userAgent,omitempty" example:"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"`
+	IPAddress  string    `json:"ipAddress,omitempty" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current" example:"true"`
+}
+
+// SessionListResponse is the response for listing a user's active sessions.
+type SessionListResponse struct {
+	Items []Session `json:"items"`
+}
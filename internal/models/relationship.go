@@ -0,0 +1,21 @@
+package models
+
+// Relationship is an authorization tuple of the form resource#relation@subject,
+// e.g. team:507f...#admin@user:507f... grants the user the admin relation on the team.
+type Relationship struct {
+	ResourceType string `json:"resourceType" bson:"resourceType" example:"team"`
+	ResourceID   string `json:"resourceId" bson:"resourceId" example:"507f1f77bcf86cd799439012"`
+	Relation     string `json:"relation" bson:"relation" example:"admin"`
+	SubjectType  string `json:"subjectType" bson:"subjectType" example:"user"`
+	SubjectID    string `json:"subjectId" bson:"subjectId" example:"507f1f77bcf86cd799439013"`
+}
+
+// RelationshipFilter selects relationships. ResourceType is required;
+// other empty fields match any value.
+type RelationshipFilter struct {
+	ResourceType string
+	ResourceID   string
+	Relation     string
+	SubjectType  string
+	SubjectID    string
+}
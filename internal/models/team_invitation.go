@@ -6,15 +6,31 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Invitation status constants.
+const (
+	InvitationStatusPending   = "pending"
+	InvitationStatusAccepted  = "accepted"
+	InvitationStatusDeclined  = "declined"
+	InvitationStatusCancelled = "cancelled"
+	InvitationStatusExpired   = "expired"
+)
+
 // TeamInvitation represents an invitation to join a team.
+// Invitations are kept after they are accepted, declined, cancelled or expired
+// so the team can see its invitation history.
 type TeamInvitation struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
-	TeamID    primitive.ObjectID `json:"teamId" bson:"teamId" example:"507f1f77bcf86cd799439012"`
-	Email     string             `json:"email" bson:"email" example:"newuser@example.com"`
-	InvitedBy primitive.ObjectID `json:"invitedBy" bson:"invitedBy" example:"507f1f77bcf86cd799439013"`
-	Role      string             `json:"role" bson:"role" example:"member"`
-	ExpiresAt time.Time          `json:"expiresAt" bson:"expiresAt" example:"2024-01-22T09:30:00Z"`
-	CreatedAt time.Time          `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	TeamID      primitive.ObjectID  `json:"teamId" bson:"teamId" example:"507f1f77bcf86cd799439012"`
+	Email       string              `json:"email" bson:"email" example:"newuser@example.com"`
+	InvitedBy   primitive.ObjectID  `json:"invitedBy" bson:"invitedBy" example:"507f1f77bcf86cd799439013"`
+	Role        string              `json:"role" bson:"role" example:"member"`
+	Status      string              `json:"status" bson:"status" example:"pending"` // Documents created before status tracking have no status and are pending
+	ResendCount int                 `json:"resendCount" bson:"resendCount" example:"0"`
+	LastSentAt  time.Time           `json:"lastSentAt" bson:"lastSentAt" example:"2024-01-15T09:30:00Z"`
+	ResolvedBy  *primitive.ObjectID `json:"resolvedBy,omitempty" bson:"resolvedBy,omitempty" example:"507f1f77bcf86cd799439014"` // User who accepted, declined or cancelled
+	ResolvedAt  *time.Time          `json:"resolvedAt,omitempty" bson:"resolvedAt,omitempty" example:"2024-01-16T09:30:00Z"`
+	ExpiresAt   time.Time           `json:"expiresAt" bson:"expiresAt" example:"2024-01-22T09:30:00Z"`
+	CreatedAt   time.Time           `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
 }
 
 // TeamInvitationWithDetails is an invitation with expanded team and inviter info.
@@ -35,9 +51,15 @@ type TeamSummary struct {
 }
 
 // CreateInvitationRequest is the payload for creating an invitation.
+// Role may be a built-in role other than owner, or the name of one of the team's custom roles.
 type CreateInvitationRequest struct {
 	Email string `json:"email" binding:"required,email" example:"newuser@example.com"`
-	Role  string `json:"role" binding:"required,oneof=admin member" example:"member"`
+	Role  string `json:"role" binding:"required,max=32" example:"member"`
+}
+
+// ExtendInvitationRequest is the payload for extending an invitation's expiry.
+type ExtendInvitationRequest struct {
+	Days int `json:"days" binding:"required,min=1,max=30" example:"7"`
 }
 
 // InvitationListResponse is the response for listing invitations.
@@ -45,6 +67,12 @@ type InvitationListResponse struct {
 	Items []TeamInvitation `json:"items"`
 }
 
+// InvitationHistoryResponse is the response for listing a team's invitation history.
+type InvitationHistoryResponse struct {
+	Items      []TeamInvitation `json:"items"`
+	Pagination Pagination       `json:"pagination"`
+}
+
 // MyInvitationListResponse is the response for listing user's pending invitations.
 type MyInvitationListResponse struct {
 	Items []TeamInvitationWithDetails `json:"items"`
@@ -8,9 +8,11 @@ import (
 
 // Team role constants.
 const (
-	RoleOwner  = "owner"
-	RoleAdmin  = "admin"
-	RoleMember = "member"
+	RoleOwner       = "owner"
+	RoleAdmin       = "admin"
+	RoleMember      = "member"
+	RoleViewer      = "viewer"
+	RoleContributor = "contributor"
 )
 
 // TeamMember represents a user's membership in a team.
@@ -33,15 +35,18 @@ type TeamMemberWithUser struct {
 }
 
 // UserSummary is a minimal user representation for embedding.
+// MFAEnabled is only set where team owners and admins review members.
 type UserSummary struct {
-	ID    primitive.ObjectID `json:"id" example:"507f1f77bcf86cd799439013"`
-	Email string             `json:"email" example:"user@example.com"`
-	Name  string             `json:"name" example:"John Doe"`
+	ID         primitive.ObjectID `json:"id" example:"507f1f77bcf86cd799439013"`
+	Email      string             `json:"email" example:"user@example.com"`
+	Name       string             `json:"name" example:"John Doe"`
+	MFAEnabled *bool              `json:"mfaEnabled,omitempty" example:"true"`
 }
 
 // UpdateRoleRequest is the payload for updating a member's role.
+// Role may be a built-in role other than owner, or the name of one of the team's custom roles.
 type UpdateRoleRequest struct {
-	Role string `json:"role" binding:"required,oneof=admin member" example:"admin"`
+	Role string `json:"role" binding:"required,max=32" example:"admin"`
 }
 
 // TeamMemberListResponse is the response for listing team members.
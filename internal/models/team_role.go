@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TeamRole is a custom role defined by a team owner.
+// Members assigned the role can perform exactly the listed actions.
+type TeamRole struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	TeamID    primitive.ObjectID `json:"teamId" bson:"teamId" example:"507f1f77bcf86cd799439012"`
+	Name      string             `json:"name" bson:"name" example:"reviewer"`
+	Actions   []string           `json:"actions" bson:"actions" example:"team:view,memo:view"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt" example:"2024-01-15T09:30:00Z"`
+}
+
+// RoleDefinition describes a built-in role and the actions it grants.
+type RoleDefinition struct {
+	Name    string   `json:"name" example:"contributor"`
+	Actions []string `json:"actions" example:"team:view,memo:view,memo:create,memo:update_own,memo:delete_own"`
+}
+
+// CreateTeamRoleRequest is the payload for creating a custom team role.
+// Owner-only actions (team:delete, team:transfer, role:manage) cannot be granted.
+type CreateTeamRoleRequest struct {
+	Name    string   `json:"name" binding:"required,min=2,max=32,slug" example:"reviewer"`
+	Actions []string `json:"actions" binding:"required,min=1,dive,oneof=team:view team:update member:invite member:remove member:update_role memo:view memo:create memo:update memo:update_own memo:delete memo:delete_own audit:view" example:"team:view,memo:view"`
+}
+
+// UpdateTeamRoleRequest is the payload for replacing a custom role's actions.
+type UpdateTeamRoleRequest struct {
+	Actions []string `json:"actions" binding:"required,min=1,dive,oneof=team:view team:update member:invite member:remove member:update_role memo:view memo:create memo:update memo:update_own memo:delete memo:delete_own audit:view" example:"team:view,memo:view,memo:create"`
+}
+
+// TeamRoleListResponse is the response for listing the roles available in a team.
+type TeamRoleListResponse struct {
+	BuiltIn []RoleDefinition `json:"builtIn"`
+	Custom  []TeamRole       `json:"custom"`
+}
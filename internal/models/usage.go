@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// VoiceMemoUsage is the aggregated voice memo usage of a user or team.
+type VoiceMemoUsage struct {
+	MemoCount            int64 `bson:"memoCount"`
+	AudioBytes           int64 `bson:"audioBytes"`
+	TranscriptionSeconds int64 `bson:"transcriptionSeconds"`
+}
+
+// UsageMetric is the current usage of one quota. A limit of 0 means unlimited.
+type UsageMetric struct {
+	Used  int64 `json:"used" example:"42"`
+	Limit int64 `json:"limit" example:"1000"`
+}
+
+// UsageResponse is the response for the usage endpoints.
+// Memos and audio bytes count stored memos; transcription minutes count memos
+// submitted for transcription in the current calendar month (UTC).
+type UsageResponse struct {
+	Memos                UsageMetric `json:"memos"`
+	AudioBytes           UsageMetric `json:"audioBytes"`
+	TranscriptionMinutes UsageMetric `json:"transcriptionMinutes"`
+	PeriodStart          time.Time   `json:"periodStart" example:"2024-01-01T00:00:00Z"`
+	PeriodEnd            time.Time   `json:"periodEnd" example:"2024-02-01T00:00:00Z"`
+}
@@ -15,6 +15,14 @@ type User struct {
 	Name      string             `json:"name" bson:"name" example:"John Doe"`
 	CreatedAt time.Time          `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
 	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt" example:"2024-01-15T09:30:00Z"`
+	// MFA settings. Secrets and recovery code hashes are never included in JSON responses.
+	MFAEnabled       bool       `json:"mfaEnabled" bson:"mfaEnabled" example:"false"`
+	MFAEnabledAt     *time.Time `json:"mfaEnabledAt,omitempty" bson:"mfaEnabledAt,omitempty" example:"2024-01-15T09:30:00Z"`
+	MFASecret        string     `json:"-" bson:"mfaSecret,omitempty"`
+	MFAPendingSecret string     `json:"-" bson:"mfaPendingSecret,omitempty"`
+	MFARecoveryCodes []string   `json:"-" bson:"mfaRecoveryCodes,omitempty"`
+	// Identities are the linked OpenID Connect provider accounts.
+	Identities []ExternalIdentity `json:"identities,omitempty" bson:"identities,omitempty"`
 }
 
 // CreateUserRequest is the payload for creating a user.
@@ -30,6 +38,12 @@ type UpdateUserRequest struct {
 	Name  *string `json:"name" binding:"omitempty,min=2" example:"Jane Doe"`
 }
 
+// ChangePasswordRequest is the payload for changing the authenticated user's password.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required" example:"secret123"`
+	NewPassword     string `json:"newPassword" binding:"required,min=6" example:"newsecret456"`
+}
+
 // LoginRequest is the payload for user login.
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email" example:"user@example.com"`
@@ -39,6 +39,9 @@ type VoiceMemo struct {
 	CreatedAt     time.Time           `json:"createdAt" bson:"createdAt" example:"2024-01-15T09:30:00Z"`
 	UpdatedAt     time.Time           `json:"updatedAt" bson:"updatedAt" example:"2024-01-15T10:00:00Z"`
 	DeletedAt     *time.Time          `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	// TranscriptionStartedAt is when the memo was last submitted for transcription.
+	// Transcription quotas bill the memo's duration to the period containing it.
+	TranscriptionStartedAt *time.Time `json:"-" bson:"transcriptionStartedAt,omitempty"`
 }
 
 // VoiceMemoListResponse is the response for listing voice memos.
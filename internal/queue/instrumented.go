@@ -0,0 +1,27 @@
+package queue
+
+import (
+	"errors"
+
+	"gin-sample/internal/metrics"
+)
+
+// instrumentedQueue counts jobs rejected because the queue is full.
+type instrumentedQueue struct {
+	Queue
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedQueue wraps next so rejected jobs are recorded in m.
+func NewInstrumentedQueue(next Queue, m *metrics.Metrics) Queue {
+	return &instrumentedQueue{Queue: next, metrics: m}
+}
+
+// Enqueue adds a job to the queue, recording a rejection if the queue is full.
+func (q *instrumentedQueue) Enqueue(job TranscriptionJob) error {
+	err := q.Queue.Enqueue(job)
+	if errors.Is(err, ErrQueueFull) {
+		q.metrics.QueueRejected()
+	}
+	return err
+}
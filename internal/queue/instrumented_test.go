@@ -0,0 +1,25 @@
+package queue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gin-sample/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestInstrumentedQueue_Enqueue(t *testing.T) {
+	m := metrics.New()
+	q := NewInstrumentedQueue(NewMemoryQueue(1), m)
+
+	assert.NoError(t, q.Enqueue(TranscriptionJob{MemoID: primitive.NewObjectID()}))
+	assert.ErrorIs(t, q.Enqueue(TranscriptionJob{MemoID: primitive.NewObjectID()}), ErrQueueFull)
+	assert.Equal(t, 1, q.Len())
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), "gin_sample_transcription_queue_rejections_total 1")
+}
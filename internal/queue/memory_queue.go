@@ -13,6 +13,11 @@ type TranscriptionJob struct {
 	MemoID       primitive.ObjectID
 	AudioFileKey string
 	RetryCount   int
+	// RequestID is the ID of the request that enqueued the job, so worker logs link back to it.
+	RequestID string
+	// TraceContext carries the enqueuing request's trace (see tracing.Inject), so the
+	// transcription span links back to it.
+	TraceContext map[string]string
 }
 
 // MemoryQueue is an in-memory job queue for transcription jobs.
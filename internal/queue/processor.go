@@ -3,14 +3,21 @@ package queue
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"gin-sample/internal/metrics"
 	"gin-sample/internal/models"
+	"gin-sample/internal/requestid"
+	"gin-sample/internal/tracing"
 	"gin-sample/internal/transcription"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -22,6 +29,10 @@ const (
 	StatusUpdateTimeout = 5 * time.Second
 	// TranscriptionTimeout is the timeout for transcription operations.
 	TranscriptionTimeout = 5 * time.Minute
+	// StuckJobThreshold is how long a worker may spend on one job before it is considered stuck.
+	// Jobs are bounded by the transcription and update timeouts, so this only trips when a
+	// worker ignores them.
+	StuckJobThreshold = TranscriptionTimeout + StatusUpdateTimeout + time.Minute
 )
 
 // TranscriptionUpdater defines the interface for updating transcription results.
@@ -32,64 +43,181 @@ type TranscriptionUpdater interface {
 
 // Processor processes transcription jobs from the queue.
 type Processor struct {
-	queue        *MemoryQueue
-	transcriber  transcription.Service
-	updater      TranscriptionUpdater
-	workerCount  int
+	queue       *MemoryQueue
+	transcriber transcription.Service
+	updater     TranscriptionUpdater
+	metrics     *metrics.Metrics
+	running     atomic.Int32
+
+	mu          sync.Mutex      // guards the fields below and closing shutdownCh
+	workerCount int             // target number of workers
+	workers     []*workerState  // current workers, by ID
+	ctx         context.Context // passed to Start, nil until started
+
 	wg           sync.WaitGroup
 	shutdownOnce sync.Once
 	shutdownCh   chan struct{}
 }
 
+// workerState tracks one worker.
+type workerState struct {
+	retire    context.CancelFunc // stops the worker once its current job is done
+	busySince atomic.Int64       // unix nanoseconds the current job started, 0 when idle
+}
+
 // NewProcessor creates a new transcription job processor.
-func NewProcessor(queue *MemoryQueue, transcriber transcription.Service, updater TranscriptionUpdater, workerCount int) *Processor {
+// If m is not nil, job durations, retries and failures are recorded in it.
+func NewProcessor(queue *MemoryQueue, transcriber transcription.Service, updater TranscriptionUpdater, workerCount int, m *metrics.Metrics) *Processor {
 	return &Processor{
 		queue:       queue,
 		transcriber: transcriber,
 		updater:     updater,
 		workerCount: workerCount,
+		metrics:     m,
 		shutdownCh:  make(chan struct{}),
 	}
 }
 
 // Start begins processing jobs with the configured number of workers.
 func (p *Processor) Start(ctx context.Context) {
-	for i := 0; i < p.workerCount; i++ {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ctx = ctx
+	p.scale()
+	slog.Info("transcription processor started", "workers", p.workerCount)
+}
+
+// SetWorkerCount changes the number of workers. Once started, workers are added right away;
+// removed workers finish their current job before they stop.
+func (p *Processor) SetWorkerCount(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n == p.workerCount {
+		return
+	}
+	previous := p.workerCount
+	p.workerCount = n
+	if p.ctx == nil || p.stopped() {
+		return
+	}
+	p.scale()
+	slog.Info("transcription workers scaled", "from", previous, "to", n)
+}
+
+// WorkerCount returns the configured number of workers.
+func (p *Processor) WorkerCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.workerCount
+}
+
+// scale starts or retires workers until there are workerCount. Must be called with mu held.
+func (p *Processor) scale() {
+	for len(p.workers) < p.workerCount {
+		id := len(p.workers)
+		// Cancelling dequeueCtx stops the worker waiting for jobs without cancelling a job in progress
+		dequeueCtx, retire := context.WithCancel(p.ctx)
+		w := &workerState{retire: retire}
+		p.workers = append(p.workers, w)
 		p.wg.Add(1)
-		go p.worker(ctx, i)
+		p.running.Add(1)
+		go p.worker(p.ctx, dequeueCtx, id, w)
+	}
+	for len(p.workers) > p.workerCount {
+		last := len(p.workers) - 1
+		p.workers[last].retire()
+		p.workers = p.workers[:last]
+	}
+}
+
+// stopped reports whether Stop has been called. Must be called with mu held.
+func (p *Processor) stopped() bool {
+	select {
+	case <-p.shutdownCh:
+		return true
+	default:
+		return false
 	}
-	log.Printf("Transcription processor started with %d workers", p.workerCount)
 }
 
 // Stop gracefully stops the processor, waiting for workers to finish.
 func (p *Processor) Stop() {
 	p.shutdownOnce.Do(func() {
+		// Closed under mu so SetWorkerCount starts no workers once Stop is waiting
+		p.mu.Lock()
 		close(p.shutdownCh)
+		p.mu.Unlock()
 		p.queue.Close()
 	})
 	p.wg.Wait()
-	log.Println("Transcription processor stopped")
+	slog.Info("transcription processor stopped")
+}
+
+// CheckWorkers reports an error unless every worker is running and none is stuck on a job.
+func (p *Processor) CheckWorkers(context.Context) error {
+	p.mu.Lock()
+	workerCount, workers := p.workerCount, p.workers
+	p.mu.Unlock()
+
+	if running := int(p.running.Load()); running < workerCount {
+		return fmt.Errorf("%d of %d transcription workers running", running, workerCount)
+	}
+	for id, w := range workers {
+		if since := w.busySince.Load(); since != 0 {
+			if busy := time.Since(time.Unix(0, since)); busy > StuckJobThreshold {
+				return fmt.Errorf("transcription worker %d stuck on a job for %s", id, busy.Round(time.Second))
+			}
+		}
+	}
+	return nil
 }
 
-func (p *Processor) worker(ctx context.Context, id int) {
+// worker processes jobs with ctx until the queue closes or dequeueCtx is cancelled.
+func (p *Processor) worker(ctx, dequeueCtx context.Context, id int, w *workerState) {
 	defer p.wg.Done()
-	log.Printf("Worker %d started", id)
+	defer p.running.Add(-1)
+	slog.Debug("transcription worker started", "worker", id)
 
 	for {
-		job, err := p.queue.Dequeue(ctx)
+		// Dequeue may still return a job once cancelled, so a retired worker checks first
+		if dequeueCtx.Err() != nil {
+			slog.Debug("transcription worker shutting down", "worker", id)
+			return
+		}
+		job, err := p.queue.Dequeue(dequeueCtx)
 		if err != nil {
 			if errors.Is(err, ErrQueueClosed) || errors.Is(err, context.Canceled) {
-				log.Printf("Worker %d shutting down", id)
+				slog.Debug("transcription worker shutting down", "worker", id)
 				return
 			}
 			continue
 		}
+		w.busySince.Store(time.Now().UnixNano())
 		p.processJob(ctx, job)
+		w.busySince.Store(0)
 	}
 }
 
 func (p *Processor) processJob(ctx context.Context, job TranscriptionJob) {
-	log.Printf("Processing transcription job for memo %s (attempt %d)", job.MemoID.Hex(), job.RetryCount+1)
+	// Carry the originating request ID so logs from this job link back to the request
+	if job.RequestID != "" {
+		ctx = requestid.NewContext(ctx, job.RequestID)
+	}
+	// Each attempt is its own trace, linked to the request that enqueued the job
+	ctx, span := tracing.Tracer().Start(ctx, "transcription.process",
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(trace.LinkFromContext(tracing.Extract(ctx, job.TraceContext))),
+		trace.WithAttributes(
+			attribute.String("memo.id", job.MemoID.Hex()),
+			attribute.Int("transcription.attempt", job.RetryCount+1),
+		),
+	)
+	defer span.End()
+
+	logger := slog.With("memo_id", job.MemoID.Hex(), "attempt", job.RetryCount+1)
+	logger.InfoContext(ctx, "processing transcription job")
+	start := time.Now()
 
 	// Perform transcription with timeout
 	transcribeCtx, transcribeCancel := context.WithTimeout(ctx, TranscriptionTimeout)
@@ -97,7 +225,9 @@ func (p *Processor) processJob(ctx context.Context, job TranscriptionJob) {
 
 	text, err := p.transcriber.Transcribe(transcribeCtx, job.AudioFileKey)
 	if err != nil {
-		log.Printf("Transcription failed for memo %s: %v", job.MemoID.Hex(), err)
+		logger.WarnContext(ctx, "transcription failed", "error", err)
+		tracing.RecordError(span, err)
+		p.metrics.JobProcessed(metrics.OutcomeError, time.Since(start))
 		p.handleFailure(ctx, job)
 		return
 	}
@@ -108,29 +238,35 @@ func (p *Processor) processJob(ctx context.Context, job TranscriptionJob) {
 
 	err = p.updater.UpdateTranscriptionAndStatus(updateCtx, job.MemoID, text, models.StatusReady)
 	if err != nil {
-		log.Printf("Failed to update memo %s with transcription: %v", job.MemoID.Hex(), err)
+		logger.ErrorContext(ctx, "failed to update memo with transcription", "error", err)
+		tracing.RecordError(span, err)
+		p.metrics.JobProcessed(metrics.OutcomeError, time.Since(start))
 		p.handleFailure(ctx, job)
 		return
 	}
 
-	log.Printf("Transcription completed for memo %s", job.MemoID.Hex())
+	p.metrics.JobProcessed(metrics.OutcomeSuccess, time.Since(start))
+	logger.InfoContext(ctx, "transcription completed")
 }
 
 func (p *Processor) handleFailure(ctx context.Context, job TranscriptionJob) {
 	job.RetryCount++
+	logger := slog.With("memo_id", job.MemoID.Hex())
 
 	if job.RetryCount >= MaxRetries {
 		// Max retries reached, mark as failed
-		log.Printf("Max retries reached for memo %s, marking as failed", job.MemoID.Hex())
+		logger.ErrorContext(ctx, "max transcription retries reached, marking memo as failed", "retries", job.RetryCount)
+		p.metrics.JobFailed(metrics.FailureMaxRetries)
 		if err := p.updater.UpdateStatus(ctx, job.MemoID, models.StatusFailed); err != nil {
-			log.Printf("Failed to update status to failed for memo %s: %v", job.MemoID.Hex(), err)
+			logger.ErrorContext(ctx, "failed to update memo status to failed", "error", err)
 		}
 		return
 	}
 
 	// Calculate exponential backoff delay
 	delay := RetryDelay * time.Duration(1<<uint(job.RetryCount-1))
-	log.Printf("Retrying memo %s in %v (attempt %d/%d)", job.MemoID.Hex(), delay, job.RetryCount+1, MaxRetries)
+	logger.InfoContext(ctx, "retrying transcription", "delay", delay, "attempt", job.RetryCount+1, "max_retries", MaxRetries)
+	p.metrics.JobRetried()
 
 	// Schedule retry with delay. Uses shutdownCh instead of ctx to allow
 	// in-flight retries to complete during graceful shutdown.
@@ -138,21 +274,23 @@ func (p *Processor) handleFailure(ctx context.Context, job TranscriptionJob) {
 		select {
 		case <-p.shutdownCh:
 			// Shutdown initiated - mark as failed since we can't retry
-			log.Printf("Shutdown during retry delay for memo %s, marking as failed", job.MemoID.Hex())
-			updateCtx, cancel := context.WithTimeout(context.Background(), StatusUpdateTimeout)
+			logger.WarnContext(ctx, "shutdown during transcription retry delay, marking memo as failed")
+			p.metrics.JobFailed(metrics.FailureShutdown)
+			updateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), StatusUpdateTimeout)
 			defer cancel()
 			if updateErr := p.updater.UpdateStatus(updateCtx, job.MemoID, models.StatusFailed); updateErr != nil {
-				log.Printf("Failed to update status to failed: %v", updateErr)
+				logger.ErrorContext(ctx, "failed to update memo status to failed", "error", updateErr)
 			}
 			return
 		case <-time.After(delay):
 			if err := p.queue.Enqueue(job); err != nil {
-				log.Printf("Failed to re-enqueue job for memo %s: %v", job.MemoID.Hex(), err)
+				logger.ErrorContext(ctx, "failed to re-enqueue transcription job", "error", err)
+				p.metrics.JobFailed(metrics.FailureRequeueFailed)
 				// Mark as failed if we can't re-enqueue
-				updateCtx, cancel := context.WithTimeout(context.Background(), StatusUpdateTimeout)
+				updateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), StatusUpdateTimeout)
 				defer cancel()
 				if updateErr := p.updater.UpdateStatus(updateCtx, job.MemoID, models.StatusFailed); updateErr != nil {
-					log.Printf("Failed to update status to failed: %v", updateErr)
+					logger.ErrorContext(ctx, "failed to update memo status to failed", "error", updateErr)
 				}
 			}
 		}
@@ -2,16 +2,24 @@ package queue
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
 
+	"gin-sample/internal/metrics"
 	"gin-sample/internal/models"
+	"gin-sample/internal/requestid"
+	"gin-sample/internal/tracing"
+	"gin-sample/internal/tracing/tracingtest"
 	transcriptionmocks "gin-sample/internal/transcription/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
 
@@ -89,7 +97,7 @@ func TestNewProcessor(t *testing.T) {
 	mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 	mockUpdater := NewMockUpdater()
 
-	processor := NewProcessor(queue, mockTranscriber, mockUpdater, 2)
+	processor := NewProcessor(queue, mockTranscriber, mockUpdater, 2, nil)
 
 	assert.NotNil(t, processor)
 	assert.Equal(t, queue, processor.queue)
@@ -98,6 +106,24 @@ func TestNewProcessor(t *testing.T) {
 	assert.Equal(t, 2, processor.workerCount)
 }
 
+func TestProcessor_CheckWorkers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processor := NewProcessor(NewMemoryQueue(10), transcriptionmocks.NewMockService(ctrl), NewMockUpdater(), 2, nil)
+	assert.Error(t, processor.CheckWorkers(context.Background()), "workers not started")
+
+	processor.Start(context.Background())
+	assert.NoError(t, processor.CheckWorkers(context.Background()))
+
+	processor.workers[1].busySince.Store(time.Now().Add(-StuckJobThreshold - time.Second).UnixNano())
+	assert.ErrorContains(t, processor.CheckWorkers(context.Background()), "worker 1 stuck")
+	processor.workers[1].busySince.Store(0)
+
+	processor.Stop()
+	assert.Error(t, processor.CheckWorkers(context.Background()), "workers stopped")
+}
+
 func TestProcessor_StartStop(t *testing.T) {
 	t.Run("starts and stops cleanly", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -106,7 +132,7 @@ func TestProcessor_StartStop(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 3)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 3, nil)
 
 		ctx := context.Background()
 		processor.Start(ctx)
@@ -136,7 +162,7 @@ func TestProcessor_StartStop(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
 
 		ctx := context.Background()
 		processor.Start(ctx)
@@ -156,7 +182,7 @@ func TestProcessor_ProcessJob(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
 
 		memoID := primitive.NewObjectID()
 		job := TranscriptionJob{
@@ -192,6 +218,94 @@ func TestProcessor_ProcessJob(t *testing.T) {
 		assert.Equal(t, models.StatusReady, status)
 	})
 
+	t.Run("carries the request ID of the job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queue := NewMemoryQueue(10)
+		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
+		mockUpdater := NewMockUpdater()
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
+
+		var gotRequestID string
+		mockTranscriber.EXPECT().
+			Transcribe(gomock.Any(), "test/audio.mp3").
+			DoAndReturn(func(ctx context.Context, key string) (string, error) {
+				gotRequestID = requestid.FromContext(ctx)
+				return "text", nil
+			})
+
+		processor.processJob(context.Background(), TranscriptionJob{
+			MemoID:       primitive.NewObjectID(),
+			AudioFileKey: "test/audio.mp3",
+			RequestID:    "req-123",
+		})
+
+		assert.Equal(t, "req-123", gotRequestID)
+	})
+
+	t.Run("links the job span to the enqueuing request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		recorder := tracingtest.Record(t)
+
+		queue := NewMemoryQueue(10)
+		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
+		processor := NewProcessor(queue, mockTranscriber, NewMockUpdater(), 1, nil)
+
+		var transcribeSpan trace.SpanContext
+		mockTranscriber.EXPECT().
+			Transcribe(gomock.Any(), "test/audio.mp3").
+			DoAndReturn(func(ctx context.Context, key string) (string, error) {
+				transcribeSpan = trace.SpanContextFromContext(ctx)
+				return "text", nil
+			})
+
+		requestCtx, requestSpan := tracing.Tracer().Start(context.Background(), "POST /api/v1/voice-memos/:id/confirm-upload")
+		requestSpan.End()
+
+		processor.processJob(context.Background(), TranscriptionJob{
+			MemoID:       primitive.NewObjectID(),
+			AudioFileKey: "test/audio.mp3",
+			TraceContext: tracing.Inject(requestCtx),
+		})
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		jobSpan := spans[1]
+		assert.Equal(t, "transcription.process", jobSpan.Name())
+		assert.False(t, jobSpan.Parent().IsValid(), "each attempt starts its own trace")
+		require.Len(t, jobSpan.Links(), 1)
+		assert.Equal(t, requestSpan.SpanContext().TraceID(), jobSpan.Links()[0].SpanContext.TraceID())
+		assert.Equal(t, requestSpan.SpanContext().SpanID(), jobSpan.Links()[0].SpanContext.SpanID())
+		assert.Equal(t, jobSpan.SpanContext().SpanID(), transcribeSpan.SpanID(), "transcription runs inside the job span")
+	})
+
+	t.Run("records job duration and retries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := metrics.New()
+		queue := NewMemoryQueue(10)
+		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
+		processor := NewProcessor(queue, mockTranscriber, NewMockUpdater(), 1, m)
+		defer processor.Stop()
+
+		mockTranscriber.EXPECT().
+			Transcribe(gomock.Any(), "test/audio.mp3").
+			Return("", errors.New("transcription service unavailable"))
+
+		processor.processJob(context.Background(), TranscriptionJob{
+			MemoID:       primitive.NewObjectID(),
+			AudioFileKey: "test/audio.mp3",
+		})
+
+		w := httptest.NewRecorder()
+		m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Contains(t, w.Body.String(), `gin_sample_transcription_job_duration_seconds_count{outcome="error"} 1`)
+		assert.Contains(t, w.Body.String(), "gin_sample_transcription_job_retries_total 1")
+	})
+
 	t.Run("handles transcription failure with retry", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -199,7 +313,7 @@ func TestProcessor_ProcessJob(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
 
 		memoID := primitive.NewObjectID()
 		job := TranscriptionJob{
@@ -234,7 +348,7 @@ func TestProcessor_ProcessJob(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
 
 		memoID := primitive.NewObjectID()
 		job := TranscriptionJob{
@@ -273,7 +387,7 @@ func TestProcessor_HandleFailure(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
 
 		// handleFailure is called internally during processJob
 		// We test it indirectly through processJob test cases
@@ -311,7 +425,7 @@ func TestProcessor_WorkerShutdown(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 3)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 3, nil)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		processor.Start(ctx)
@@ -338,6 +452,93 @@ func TestProcessor_WorkerShutdown(t *testing.T) {
 	})
 }
 
+func TestProcessor_SetWorkerCount(t *testing.T) {
+	t.Run("scales running workers up and down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queue := NewMemoryQueue(10)
+		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
+		mockUpdater := NewMockUpdater()
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		processor.Start(ctx)
+		defer processor.Stop()
+
+		processor.SetWorkerCount(3)
+		assert.Equal(t, 3, processor.WorkerCount())
+		assert.Equal(t, int32(3), processor.running.Load())
+		assert.NoError(t, processor.CheckWorkers(ctx))
+
+		processor.SetWorkerCount(1)
+		assert.Eventually(t, func() bool { return processor.running.Load() == 1 }, time.Second, 10*time.Millisecond)
+		assert.NoError(t, processor.CheckWorkers(ctx))
+
+		// The remaining worker still processes jobs
+		memoID := primitive.NewObjectID()
+		mockTranscriber.EXPECT().Transcribe(gomock.Any(), "test/audio.mp3").Return("transcription", nil)
+		require.NoError(t, queue.Enqueue(TranscriptionJob{MemoID: memoID, AudioFileKey: "test/audio.mp3"}))
+		assert.Eventually(t, func() bool {
+			_, ok := mockUpdater.GetTranscription(memoID)
+			return ok
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("retired worker finishes its current job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queue := NewMemoryQueue(10)
+		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
+		mockUpdater := NewMockUpdater()
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		mockTranscriber.EXPECT().
+			Transcribe(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string) (string, error) {
+				close(started)
+				<-release
+				return "transcription", ctx.Err()
+			})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		processor.Start(ctx)
+
+		memoID := primitive.NewObjectID()
+		require.NoError(t, queue.Enqueue(TranscriptionJob{MemoID: memoID, AudioFileKey: "test/audio.mp3"}))
+		<-started
+
+		processor.SetWorkerCount(0)
+		close(release)
+
+		assert.Eventually(t, func() bool { return processor.running.Load() == 0 }, time.Second, 10*time.Millisecond)
+		text, ok := mockUpdater.GetTranscription(memoID)
+		assert.True(t, ok)
+		assert.Equal(t, "transcription", text)
+		processor.Stop()
+	})
+
+	t.Run("applies count set before start and ignores changes after stop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := NewProcessor(NewMemoryQueue(10), transcriptionmocks.NewMockService(ctrl), NewMockUpdater(), 1, nil)
+		processor.SetWorkerCount(2)
+		assert.Equal(t, int32(0), processor.running.Load())
+
+		processor.Start(context.Background())
+		assert.Equal(t, int32(2), processor.running.Load())
+
+		processor.Stop()
+		processor.SetWorkerCount(4)
+		assert.Equal(t, int32(0), processor.running.Load())
+	})
+}
+
 func TestProcessor_Concurrent(t *testing.T) {
 	t.Run("processes multiple jobs concurrently", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -346,7 +547,7 @@ func TestProcessor_Concurrent(t *testing.T) {
 		queue := NewMemoryQueue(100)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 5)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 5, nil)
 
 		jobCount := 10
 		memoIDs := make([]primitive.ObjectID, jobCount)
@@ -391,7 +592,7 @@ func TestProcessor_UpdateFailure(t *testing.T) {
 		queue := NewMemoryQueue(10)
 		mockTranscriber := transcriptionmocks.NewMockService(ctrl)
 		mockUpdater := NewMockUpdater()
-		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1)
+		processor := NewProcessor(queue, mockTranscriber, mockUpdater, 1, nil)
 
 		memoID := primitive.NewObjectID()
 		job := TranscriptionJob{
@@ -0,0 +1,44 @@
+// Package ratelimit provides Redis-backed request rate limiting and login lockout.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination=mocks/mock_ratelimit.go -package=mocks gin-sample/internal/ratelimit Limiter,Lockout
+
+// Limit is the number of requests allowed per window.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Result is the outcome of a rate limit check.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Limiter enforces request limits over a sliding window.
+type Limiter interface {
+	// Allow records a request for key and reports whether it is within the limit.
+	Allow(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// Lockout tracks failed login attempts and temporarily locks accounts.
+type Lockout interface {
+	// LockedFor returns how long the account remains locked, or zero if it is not locked.
+	LockedFor(ctx context.Context, account string) (time.Duration, error)
+	// RecordFailure records a failed attempt and returns the lock duration if it triggered a lockout.
+	RecordFailure(ctx context.Context, account string) (time.Duration, error)
+	// Reset clears failed attempts and any lock for the account.
+	Reset(ctx context.Context, account string) error
+}
+
+// Ensure Redis implementations satisfy the interfaces
+var (
+	_ Limiter = (*RedisLimiter)(nil)
+	_ Lockout = (*RedisLockout)(nil)
+)
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript atomically trims expired entries from a sorted set, counts the
+// remaining requests and records the new one if it is within the limit.
+// Returns {allowed, remaining, retry_after_ms}.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local member = ARGV[4]
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+
+if count < limit then
+    redis.call('ZADD', key, now, member)
+    redis.call('PEXPIRE', key, window)
+    return {1, limit - count - 1, 0}
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local retryAfter = window
+if oldest[2] then
+    retryAfter = tonumber(oldest[2]) + window - now
+end
+return {0, 0, retryAfter}
+`)
+
+// RedisLimiter is a sliding window log rate limiter backed by Redis sorted sets.
+type RedisLimiter struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// NewRedisLimiter creates a new RedisLimiter.
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client, now: time.Now}
+}
+
+// rateLimitKey generates a cache key for a rate limit window.
+func rateLimitKey(key string) string {
+	return fmt.Sprintf("rate_limit:%s", key)
+}
+
+// Allow records a request for key and reports whether it is within the limit.
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	member, err := requestID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now().UnixMilli()
+	values, err := slidingWindowScript.Run(ctx, l.client, []string{rateLimitKey(key)},
+		now, limit.Window.Milliseconds(), limit.Requests, member).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	return &Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+// requestID generates a unique sorted set member so concurrent requests are counted separately.
+func requestID() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate request id: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
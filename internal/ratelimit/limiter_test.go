@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRedis starts an in-memory Redis server for tests.
+func setupRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Requests: 3, Window: time.Minute}
+
+	t.Run("allows requests within the limit", func(t *testing.T) {
+		_, client := setupRedis(t)
+		limiter := NewRedisLimiter(client)
+
+		for i := 2; i >= 0; i-- {
+			result, err := limiter.Allow(ctx, "login:ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, i, result.Remaining)
+		}
+	})
+
+	t.Run("rejects requests over the limit with retry after", func(t *testing.T) {
+		_, client := setupRedis(t)
+		limiter := NewRedisLimiter(client)
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			_, err := limiter.Allow(ctx, "key", limit)
+			require.NoError(t, err)
+		}
+
+		now = now.Add(20 * time.Second)
+		result, err := limiter.Allow(ctx, "key", limit)
+
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, 40*time.Second, result.RetryAfter)
+	})
+
+	t.Run("allows requests again after the window slides", func(t *testing.T) {
+		_, client := setupRedis(t)
+		limiter := NewRedisLimiter(client)
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			_, err := limiter.Allow(ctx, "key", limit)
+			require.NoError(t, err)
+		}
+
+		now = now.Add(time.Minute + time.Millisecond)
+		result, err := limiter.Allow(ctx, "key", limit)
+
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("tracks keys independently", func(t *testing.T) {
+		_, client := setupRedis(t)
+		limiter := NewRedisLimiter(client)
+
+		for i := 0; i < 3; i++ {
+			_, err := limiter.Allow(ctx, "a", limit)
+			require.NoError(t, err)
+		}
+
+		result, err := limiter.Allow(ctx, "b", limit)
+
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("returns error when redis is unavailable", func(t *testing.T) {
+		mr, client := setupRedis(t)
+		limiter := NewRedisLimiter(client)
+		mr.Close()
+
+		result, err := limiter.Allow(ctx, "key", limit)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
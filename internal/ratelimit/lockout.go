@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LockoutPolicy configures progressive account lockout.
+// After every Threshold consecutive failures the account is locked. The first lock lasts
+// BaseDuration and each further lock doubles, up to MaxDuration. Failures are forgotten
+// after FailureWindow without a new failure.
+type LockoutPolicy struct {
+	Threshold     int
+	BaseDuration  time.Duration
+	MaxDuration   time.Duration
+	FailureWindow time.Duration
+}
+
+// lockDuration returns the lock duration after the given number of failures, or zero.
+func (p LockoutPolicy) lockDuration(failures int64) time.Duration {
+	if p.Threshold <= 0 || failures < int64(p.Threshold) || failures%int64(p.Threshold) != 0 {
+		return 0
+	}
+
+	d := p.BaseDuration
+	for i := int64(1); i < failures/int64(p.Threshold); i++ {
+		d *= 2
+		if d >= p.MaxDuration {
+			return p.MaxDuration
+		}
+	}
+	return min(d, p.MaxDuration)
+}
+
+// RedisLockout tracks login failures in Redis.
+type RedisLockout struct {
+	client *redis.Client
+	policy LockoutPolicy
+}
+
+// NewRedisLockout creates a new RedisLockout.
+func NewRedisLockout(client *redis.Client, policy LockoutPolicy) *RedisLockout {
+	return &RedisLockout{client: client, policy: policy}
+}
+
+// loginFailuresKey generates a cache key for an account's failure counter.
+func loginFailuresKey(account string) string {
+	return fmt.Sprintf("login_failures:%s", normalizeAccount(account))
+}
+
+// loginLockKey generates a cache key for an account lock.
+func loginLockKey(account string) string {
+	return fmt.Sprintf("login_lock:%s", normalizeAccount(account))
+}
+
+// normalizeAccount makes account keys case-insensitive (emails).
+func normalizeAccount(account string) string {
+	return strings.ToLower(strings.TrimSpace(account))
+}
+
+// LockedFor returns how long the account remains locked, or zero if it is not locked.
+func (l *RedisLockout) LockedFor(ctx context.Context, account string) (time.Duration, error) {
+	ttl, err := l.client.PTTL(ctx, loginLockKey(account)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check lockout: %w", err)
+	}
+	// Negative values mean the key does not exist or has no expiry
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// RecordFailure records a failed attempt and returns the lock duration if it triggered a lockout.
+func (l *RedisLockout) RecordFailure(ctx context.Context, account string) (time.Duration, error) {
+	key := loginFailuresKey(account)
+
+	failures, err := l.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	lock := l.policy.lockDuration(failures)
+
+	// Keep the counter for the lock plus the failure window so lockouts can escalate
+	if err := l.client.Expire(ctx, key, lock+l.policy.FailureWindow).Err(); err != nil {
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	if lock > 0 {
+		if err := l.client.Set(ctx, loginLockKey(account), failures, lock).Err(); err != nil {
+			return 0, fmt.Errorf("failed to lock account: %w", err)
+		}
+	}
+
+	return lock, nil
+}
+
+// Reset clears failed attempts and any lock for the account.
+func (l *RedisLockout) Reset(ctx context.Context, account string) error {
+	err := l.client.Del(ctx, loginFailuresKey(account), loginLockKey(account)).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to reset lockout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockoutPolicy_lockDuration(t *testing.T) {
+	policy := LockoutPolicy{Threshold: 3, BaseDuration: time.Minute, MaxDuration: 5 * time.Minute}
+
+	tests := []struct {
+		failures int64
+		expected time.Duration
+	}{
+		{1, 0},
+		{2, 0},
+		{3, time.Minute},
+		{4, 0},
+		{6, 2 * time.Minute},
+		{9, 4 * time.Minute},
+		{12, 5 * time.Minute},
+		{30, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, policy.lockDuration(tt.failures), "failures=%d", tt.failures)
+	}
+
+	t.Run("disabled when threshold is zero", func(t *testing.T) {
+		assert.Zero(t, LockoutPolicy{}.lockDuration(10))
+	})
+}
+
+func TestRedisLockout(t *testing.T) {
+	ctx := context.Background()
+	policy := LockoutPolicy{
+		Threshold:     3,
+		BaseDuration:  time.Minute,
+		MaxDuration:   time.Hour,
+		FailureWindow: 15 * time.Minute,
+	}
+
+	t.Run("locks account after threshold failures", func(t *testing.T) {
+		_, client := setupRedis(t)
+		lockout := NewRedisLockout(client, policy)
+
+		for i := 0; i < 2; i++ {
+			lock, err := lockout.RecordFailure(ctx, "user@example.com")
+			require.NoError(t, err)
+			assert.Zero(t, lock)
+		}
+
+		lock, err := lockout.RecordFailure(ctx, "user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, lock)
+
+		lockedFor, err := lockout.LockedFor(ctx, "USER@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, lockedFor)
+	})
+
+	t.Run("lock expires", func(t *testing.T) {
+		mr, client := setupRedis(t)
+		lockout := NewRedisLockout(client, policy)
+
+		for i := 0; i < 3; i++ {
+			_, err := lockout.RecordFailure(ctx, "user@example.com")
+			require.NoError(t, err)
+		}
+
+		mr.FastForward(time.Minute + time.Second)
+
+		lockedFor, err := lockout.LockedFor(ctx, "user@example.com")
+		require.NoError(t, err)
+		assert.Zero(t, lockedFor)
+	})
+
+	t.Run("escalates on repeated lockouts", func(t *testing.T) {
+		mr, client := setupRedis(t)
+		lockout := NewRedisLockout(client, policy)
+
+		for i := 0; i < 3; i++ {
+			_, err := lockout.RecordFailure(ctx, "user@example.com")
+			require.NoError(t, err)
+		}
+		mr.FastForward(time.Minute + time.Second)
+
+		var lock time.Duration
+		for i := 0; i < 3; i++ {
+			var err error
+			lock, err = lockout.RecordFailure(ctx, "user@example.com")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2*time.Minute, lock)
+	})
+
+	t.Run("forgets failures after the window", func(t *testing.T) {
+		mr, client := setupRedis(t)
+		lockout := NewRedisLockout(client, policy)
+
+		for i := 0; i < 2; i++ {
+			_, err := lockout.RecordFailure(ctx, "user@example.com")
+			require.NoError(t, err)
+		}
+		mr.FastForward(16 * time.Minute)
+
+		lock, err := lockout.RecordFailure(ctx, "user@example.com")
+		require.NoError(t, err)
+		assert.Zero(t, lock)
+	})
+
+	t.Run("reset clears failures and lock", func(t *testing.T) {
+		_, client := setupRedis(t)
+		lockout := NewRedisLockout(client, policy)
+
+		for i := 0; i < 3; i++ {
+			_, err := lockout.RecordFailure(ctx, "user@example.com")
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, lockout.Reset(ctx, "user@example.com"))
+
+		lockedFor, err := lockout.LockedFor(ctx, "user@example.com")
+		require.NoError(t, err)
+		assert.Zero(t, lockedFor)
+
+		lock, err := lockout.RecordFailure(ctx, "user@example.com")
+		require.NoError(t, err)
+		assert.Zero(t, lock)
+	})
+}
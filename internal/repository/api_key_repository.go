@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// APIKeyRepository defines the interface for API key data operations.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.APIKey, error)
+	CountByUserID(ctx context.Context, userID primitive.ObjectID) (int, error)
+	UpdateLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error
+	Delete(ctx context.Context, id, userID primitive.ObjectID) error
+	DeleteByUserID(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// apiKeyRepository implements APIKeyRepository using MongoDB.
+type apiKeyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository.
+func NewAPIKeyRepository(db *mongo.Database) APIKeyRepository {
+	collection := db.Collection("api_keys")
+
+	// Create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "keyHash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "userId", Value: 1}},
+		},
+		{
+			// Removes expired keys; keys without expiresAt are kept
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &apiKeyRepository{
+		collection: collection,
+	}
+}
+
+// Create inserts a new API key into the database.
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	key.ID = primitive.NewObjectID()
+	key.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, key)
+	return err
+}
+
+// FindByHash finds an API key by the hash of the key.
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+
+	err := r.collection.FindOne(ctx, bson.M{"keyHash": keyHash}).Decode(&key)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// FindByUserID returns all API keys of a user, newest first.
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []models.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+
+	return keys, nil
+}
+
+// CountByUserID returns the number of API keys a user has.
+func (r *apiKeyRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (int, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// UpdateLastUsed records when an API key was last used.
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"lastUsedAt": lastUsedAt}},
+	)
+	return err
+}
+
+// Delete removes an API key owned by the given user.
+func (r *apiKeyRepository) Delete(ctx context.Context, id, userID primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "userId": userID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return apperrors.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes all API keys of a user.
+func (r *apiKeyRepository) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"userId": userID})
+	return err
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewAPIKeyRepository(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAPIKeyRepository(tdb.Database)
+
+	assert.NotNil(t, repo)
+}
+
+func TestAPIKeyRepository_CreateAndFindByHash(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAPIKeyRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("finds created key by hash", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+
+		key := &models.APIKey{
+			UserID:  primitive.NewObjectID(),
+			Name:    "Backup script",
+			Prefix:  "gmk_1a2b3c4d",
+			KeyHash: "hash-1",
+			Scopes:  []string{"memos:read"},
+		}
+		require.NoError(t, repo.Create(ctx, key))
+		assert.False(t, key.ID.IsZero())
+		assert.NotZero(t, key.CreatedAt)
+
+		found, err := repo.FindByHash(ctx, "hash-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, found.ID)
+		assert.Equal(t, key.UserID, found.UserID)
+		assert.Equal(t, []string{"memos:read"}, found.Scopes)
+	})
+
+	t.Run("returns error for unknown hash", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+
+		_, err := repo.FindByHash(ctx, "unknown")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIKey)
+	})
+}
+
+func TestAPIKeyRepository_FindByUserID(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAPIKeyRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("returns only the user's keys", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+
+		userID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.APIKey{UserID: userID, KeyHash: "hash-1"}))
+		require.NoError(t, repo.Create(ctx, &models.APIKey{UserID: userID, KeyHash: "hash-2"}))
+		require.NoError(t, repo.Create(ctx, &models.APIKey{UserID: primitive.NewObjectID(), KeyHash: "hash-3"}))
+
+		keys, err := repo.FindByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+
+		count, err := repo.CountByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("returns empty slice when user has no keys", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+
+		keys, err := repo.FindByUserID(ctx, primitive.NewObjectID())
+
+		require.NoError(t, err)
+		assert.NotNil(t, keys)
+		assert.Empty(t, keys)
+	})
+}
+
+func TestAPIKeyRepository_UpdateLastUsed(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAPIKeyRepository(tdb.Database)
+	ctx := context.Background()
+
+	tdb.ClearCollection(t, "api_keys")
+	key := &models.APIKey{UserID: primitive.NewObjectID(), KeyHash: "hash-1"}
+	require.NoError(t, repo.Create(ctx, key))
+
+	lastUsed := time.Now().Truncate(time.Millisecond)
+	require.NoError(t, repo.UpdateLastUsed(ctx, key.ID, lastUsed))
+
+	found, err := repo.FindByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, found.LastUsedAt)
+	assert.True(t, lastUsed.Equal(*found.LastUsedAt))
+}
+
+func TestAPIKeyRepository_Delete(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAPIKeyRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("deletes the user's key", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+		key := &models.APIKey{UserID: primitive.NewObjectID(), KeyHash: "hash-1"}
+		require.NoError(t, repo.Create(ctx, key))
+
+		err := repo.Delete(ctx, key.ID, key.UserID)
+
+		require.NoError(t, err)
+		_, err = repo.FindByHash(ctx, "hash-1")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIKey)
+	})
+
+	t.Run("returns not found for another user's key", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+		key := &models.APIKey{UserID: primitive.NewObjectID(), KeyHash: "hash-1"}
+		require.NoError(t, repo.Create(ctx, key))
+
+		err := repo.Delete(ctx, key.ID, primitive.NewObjectID())
+
+		assert.ErrorIs(t, err, apperrors.ErrAPIKeyNotFound)
+	})
+}
+
+func TestAPIKeyRepository_DeleteByUserID(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAPIKeyRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("deletes all keys of the user", func(t *testing.T) {
+		tdb.ClearCollection(t, "api_keys")
+		userID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.APIKey{UserID: userID, KeyHash: "hash-1"}))
+		require.NoError(t, repo.Create(ctx, &models.APIKey{UserID: userID, KeyHash: "hash-2"}))
+		other := &models.APIKey{UserID: primitive.NewObjectID(), KeyHash: "hash-3"}
+		require.NoError(t, repo.Create(ctx, other))
+
+		err := repo.DeleteByUserID(ctx, userID)
+
+		require.NoError(t, err)
+		keys, err := repo.FindByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+		_, err = repo.FindByHash(ctx, "hash-3")
+		assert.NoError(t, err)
+	})
+}
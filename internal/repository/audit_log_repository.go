@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditLogRepository defines the interface for audit log data operations.
+// The audit log is append-only, so entries cannot be updated or deleted.
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *models.AuditLogEntry) error
+	FindByTeamID(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) ([]models.AuditLogEntry, int, error)
+}
+
+// auditLogRepository implements AuditLogRepository using MongoDB.
+type auditLogRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditLogRepository creates a new AuditLogRepository.
+func NewAuditLogRepository(db *mongo.Database) AuditLogRepository {
+	collection := db.Collection("audit_logs")
+
+	// Create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "teamId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "teamId", Value: 1}, {Key: "actorId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &auditLogRepository{
+		collection: collection,
+	}
+}
+
+// Create appends an entry to the audit log.
+func (r *auditLogRepository) Create(ctx context.Context, entry *models.AuditLogEntry) error {
+	entry.ID = primitive.NewObjectID()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// FindByTeamID returns paginated audit log entries for a team matching filter, newest first.
+func (r *auditLogRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) ([]models.AuditLogEntry, int, error) {
+	query := auditLogFilterQuery(teamID, filter)
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * limit)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.AuditLogEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+
+	if entries == nil {
+		entries = []models.AuditLogEntry{}
+	}
+
+	return entries, int(total), nil
+}
+
+// auditLogFilterQuery converts a filter to a MongoDB query on a team's entries.
+func auditLogFilterQuery(teamID primitive.ObjectID, filter models.AuditLogFilter) bson.M {
+	query := bson.M{"teamId": teamID}
+	if filter.ActorID != nil {
+		query["actorId"] = *filter.ActorID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.TargetType != "" {
+		query["targetType"] = filter.TargetType
+	}
+	if filter.TargetID != nil {
+		query["targetId"] = *filter.TargetID
+	}
+	if filter.From != nil || filter.To != nil {
+		createdAt := bson.M{}
+		if filter.From != nil {
+			createdAt["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			createdAt["$lt"] = *filter.To
+		}
+		query["createdAt"] = createdAt
+	}
+	return query
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewAuditLogRepository(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAuditLogRepository(tdb.Database)
+
+	assert.NotNil(t, repo)
+}
+
+func TestAuditLogRepository_Create(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAuditLogRepository(tdb.Database)
+	ctx := context.Background()
+
+	entry := &models.AuditLogEntry{
+		TeamID:     primitive.NewObjectID(),
+		ActorID:    primitive.NewObjectID(),
+		Action:     "member:update_role",
+		TargetType: models.AuditTargetMember,
+		TargetID:   primitive.NewObjectID(),
+		Before:     map[string]any{"role": "member"},
+		After:      map[string]any{"role": "admin"},
+		RequestID:  "req-1",
+	}
+
+	err := repo.Create(ctx, entry)
+
+	require.NoError(t, err)
+	assert.False(t, entry.ID.IsZero())
+	assert.False(t, entry.CreatedAt.IsZero())
+
+	entries, total, err := repo.FindByTeamID(ctx, entry.TeamID, models.AuditLogFilter{}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, entry.ActorID, entries[0].ActorID)
+	assert.Equal(t, "member", entries[0].Before["role"])
+	assert.Equal(t, "admin", entries[0].After["role"])
+	assert.Equal(t, "req-1", entries[0].RequestID)
+}
+
+func TestAuditLogRepository_FindByTeamID(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewAuditLogRepository(tdb.Database)
+	ctx := context.Background()
+
+	teamID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+	adminID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	base := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+
+	seed := func(t *testing.T) {
+		tdb.ClearCollection(t, "audit_logs")
+		entries := []*models.AuditLogEntry{
+			{TeamID: teamID, ActorID: ownerID, Action: "member:invite", TargetType: models.AuditTargetInvitation, TargetID: primitive.NewObjectID(), CreatedAt: base},
+			{TeamID: teamID, ActorID: adminID, Action: "member:update_role", TargetType: models.AuditTargetMember, TargetID: memberID, CreatedAt: base.Add(time.Minute)},
+			{TeamID: teamID, ActorID: ownerID, Action: "member:remove", TargetType: models.AuditTargetMember, TargetID: memberID, CreatedAt: base.Add(2 * time.Minute)},
+			{TeamID: primitive.NewObjectID(), ActorID: ownerID, Action: "member:remove", TargetType: models.AuditTargetMember, TargetID: memberID, CreatedAt: base},
+		}
+		for _, entry := range entries {
+			require.NoError(t, repo.Create(ctx, entry))
+		}
+	}
+
+	t.Run("returns team entries newest first", func(t *testing.T) {
+		seed(t)
+
+		entries, total, err := repo.FindByTeamID(ctx, teamID, models.AuditLogFilter{}, 1, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "member:remove", entries[0].Action)
+		assert.Equal(t, "member:invite", entries[2].Action)
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		seed(t)
+
+		entries, total, err := repo.FindByTeamID(ctx, teamID, models.AuditLogFilter{}, 2, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "member:invite", entries[0].Action)
+	})
+
+	t.Run("filters by actor, action, target and time", func(t *testing.T) {
+		seed(t)
+		from := base.Add(time.Minute)
+		to := base.Add(2 * time.Minute)
+
+		tests := []struct {
+			name     string
+			filter   models.AuditLogFilter
+			expected int
+		}{
+			{"actor", models.AuditLogFilter{ActorID: &ownerID}, 2},
+			{"action", models.AuditLogFilter{Action: "member:update_role"}, 1},
+			{"target type", models.AuditLogFilter{TargetType: models.AuditTargetMember}, 2},
+			{"target id", models.AuditLogFilter{TargetID: &memberID}, 2},
+			{"time range", models.AuditLogFilter{From: &from, To: &to}, 1},
+			{"combined", models.AuditLogFilter{ActorID: &ownerID, TargetID: &memberID}, 1},
+		}
+		for _, tt := range tests {
+			entries, total, err := repo.FindByTeamID(ctx, teamID, tt.filter, 1, 10)
+			require.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, total, tt.name)
+			assert.Len(t, entries, tt.expected, tt.name)
+		}
+	})
+
+	t.Run("returns empty slice for team without entries", func(t *testing.T) {
+		seed(t)
+
+		entries, total, err := repo.FindByTeamID(ctx, primitive.NewObjectID(), models.AuditLogFilter{}, 1, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.NotNil(t, entries)
+		assert.Empty(t, entries)
+	})
+}
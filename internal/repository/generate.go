@@ -1,3 +1,3 @@
 package repository
 
-//go:generate mockgen -destination=mocks/mock_repositories.go -package=mocks gin-sample/internal/repository UserRepository,RefreshTokenRepository,TeamRepository,TeamMemberRepository,TeamInvitationRepository,VoiceMemoRepository
+//go:generate mockgen -destination=mocks/mock_repositories.go -package=mocks gin-sample/internal/repository UserRepository,RefreshTokenRepository,TeamRepository,TeamMemberRepository,TeamInvitationRepository,VoiceMemoRepository,APIKeyRepository,TeamRoleRepository,AuditLogRepository
@@ -0,0 +1,539 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gin-sample/internal/metrics"
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// instrumented records the latency of each call to a repository as a MongoDB dependency call,
+// labeled with the collection and method, e.g. "users.FindByID".
+type instrumented struct {
+	metrics    *metrics.Metrics
+	collection string
+}
+
+// observe records a call that started at start and returned *err. It is deferred with the
+// method's named error result, so the error is read after the call returns.
+func (i instrumented) observe(method string, start time.Time, err *error) {
+	i.metrics.ObserveDependencyCall(metrics.DependencyMongoDB, i.collection+"."+method, time.Since(start), *err)
+}
+
+// instrumentedAPIKeyRepository records the latency of every APIKeyRepository call.
+type instrumentedAPIKeyRepository struct {
+	instrumented
+	next APIKeyRepository
+}
+
+// NewInstrumentedAPIKeyRepository wraps next so every call is recorded in m.
+func NewInstrumentedAPIKeyRepository(next APIKeyRepository, m *metrics.Metrics) APIKeyRepository {
+	return &instrumentedAPIKeyRepository{instrumented: instrumented{metrics: m, collection: "api_keys"}, next: next}
+}
+
+func (r *instrumentedAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, key)
+}
+
+func (r *instrumentedAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (_ *models.APIKey, err error) {
+	defer r.observe("FindByHash", time.Now(), &err)
+	return r.next.FindByHash(ctx, keyHash)
+}
+
+func (r *instrumentedAPIKeyRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) (_ []models.APIKey, err error) {
+	defer r.observe("FindByUserID", time.Now(), &err)
+	return r.next.FindByUserID(ctx, userID)
+}
+
+func (r *instrumentedAPIKeyRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (_ int, err error) {
+	defer r.observe("CountByUserID", time.Now(), &err)
+	return r.next.CountByUserID(ctx, userID)
+}
+
+func (r *instrumentedAPIKeyRepository) UpdateLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) (err error) {
+	defer r.observe("UpdateLastUsed", time.Now(), &err)
+	return r.next.UpdateLastUsed(ctx, id, lastUsedAt)
+}
+
+func (r *instrumentedAPIKeyRepository) Delete(ctx context.Context, id, userID primitive.ObjectID) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id, userID)
+}
+
+func (r *instrumentedAPIKeyRepository) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) (err error) {
+	defer r.observe("DeleteByUserID", time.Now(), &err)
+	return r.next.DeleteByUserID(ctx, userID)
+}
+
+// instrumentedAuditLogRepository records the latency of every AuditLogRepository call.
+type instrumentedAuditLogRepository struct {
+	instrumented
+	next AuditLogRepository
+}
+
+// NewInstrumentedAuditLogRepository wraps next so every call is recorded in m.
+func NewInstrumentedAuditLogRepository(next AuditLogRepository, m *metrics.Metrics) AuditLogRepository {
+	return &instrumentedAuditLogRepository{instrumented: instrumented{metrics: m, collection: "audit_logs"}, next: next}
+}
+
+func (r *instrumentedAuditLogRepository) Create(ctx context.Context, entry *models.AuditLogEntry) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, entry)
+}
+
+func (r *instrumentedAuditLogRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (_ []models.AuditLogEntry, _ int, err error) {
+	defer r.observe("FindByTeamID", time.Now(), &err)
+	return r.next.FindByTeamID(ctx, teamID, filter, page, limit)
+}
+
+// instrumentedRefreshTokenRepository records the latency of every RefreshTokenRepository call.
+type instrumentedRefreshTokenRepository struct {
+	instrumented
+	next RefreshTokenRepository
+}
+
+// NewInstrumentedRefreshTokenRepository wraps next so every call is recorded in m.
+func NewInstrumentedRefreshTokenRepository(next RefreshTokenRepository, m *metrics.Metrics) RefreshTokenRepository {
+	return &instrumentedRefreshTokenRepository{instrumented: instrumented{metrics: m, collection: "refresh_tokens"}, next: next}
+}
+
+func (r *instrumentedRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, token)
+}
+
+func (r *instrumentedRefreshTokenRepository) FindByToken(ctx context.Context, token string) (_ *models.RefreshToken, err error) {
+	defer r.observe("FindByToken", time.Now(), &err)
+	return r.next.FindByToken(ctx, token)
+}
+
+func (r *instrumentedRefreshTokenRepository) FindAllByUserID(ctx context.Context, userID primitive.ObjectID) (_ []models.RefreshToken, err error) {
+	defer r.observe("FindAllByUserID", time.Now(), &err)
+	return r.next.FindAllByUserID(ctx, userID)
+}
+
+func (r *instrumentedRefreshTokenRepository) DeleteByToken(ctx context.Context, token string) (err error) {
+	defer r.observe("DeleteByToken", time.Now(), &err)
+	return r.next.DeleteByToken(ctx, token)
+}
+
+func (r *instrumentedRefreshTokenRepository) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) (err error) {
+	defer r.observe("DeleteByUserID", time.Now(), &err)
+	return r.next.DeleteByUserID(ctx, userID)
+}
+
+// instrumentedRelationshipRepository records the latency of every RelationshipRepository call.
+type instrumentedRelationshipRepository struct {
+	instrumented
+	next RelationshipRepository
+}
+
+// NewInstrumentedRelationshipRepository wraps next so every call is recorded in m.
+func NewInstrumentedRelationshipRepository(next RelationshipRepository, m *metrics.Metrics) RelationshipRepository {
+	return &instrumentedRelationshipRepository{instrumented: instrumented{metrics: m, collection: "relationships"}, next: next}
+}
+
+func (r *instrumentedRelationshipRepository) WriteRelationships(ctx context.Context, deletes []models.RelationshipFilter, touches []models.Relationship) (err error) {
+	defer r.observe("WriteRelationships", time.Now(), &err)
+	return r.next.WriteRelationships(ctx, deletes, touches)
+}
+
+func (r *instrumentedRelationshipRepository) ReadRelationships(ctx context.Context, filter models.RelationshipFilter) (_ []models.Relationship, err error) {
+	defer r.observe("ReadRelationships", time.Now(), &err)
+	return r.next.ReadRelationships(ctx, filter)
+}
+
+// instrumentedTeamInvitationRepository records the latency of every TeamInvitationRepository call.
+type instrumentedTeamInvitationRepository struct {
+	instrumented
+	next TeamInvitationRepository
+}
+
+// NewInstrumentedTeamInvitationRepository wraps next so every call is recorded in m.
+func NewInstrumentedTeamInvitationRepository(next TeamInvitationRepository, m *metrics.Metrics) TeamInvitationRepository {
+	return &instrumentedTeamInvitationRepository{instrumented: instrumented{metrics: m, collection: "team_invitations"}, next: next}
+}
+
+func (r *instrumentedTeamInvitationRepository) Create(ctx context.Context, invitation *models.TeamInvitation) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, invitation)
+}
+
+func (r *instrumentedTeamInvitationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (_ *models.TeamInvitation, err error) {
+	defer r.observe("FindByID", time.Now(), &err)
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *instrumentedTeamInvitationRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID) (_ []models.TeamInvitation, err error) {
+	defer r.observe("FindByTeamID", time.Now(), &err)
+	return r.next.FindByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamInvitationRepository) FindByEmail(ctx context.Context, email string) (_ []models.TeamInvitation, err error) {
+	defer r.observe("FindByEmail", time.Now(), &err)
+	return r.next.FindByEmail(ctx, email)
+}
+
+func (r *instrumentedTeamInvitationRepository) FindByTeamAndEmail(ctx context.Context, teamID primitive.ObjectID, email string) (_ *models.TeamInvitation, err error) {
+	defer r.observe("FindByTeamAndEmail", time.Now(), &err)
+	return r.next.FindByTeamAndEmail(ctx, teamID, email)
+}
+
+func (r *instrumentedTeamInvitationRepository) FindHistoryByTeamID(ctx context.Context, teamID primitive.ObjectID, page, limit int) (_ []models.TeamInvitation, _ int, err error) {
+	defer r.observe("FindHistoryByTeamID", time.Now(), &err)
+	return r.next.FindHistoryByTeamID(ctx, teamID, page, limit)
+}
+
+func (r *instrumentedTeamInvitationRepository) CountPendingByTeamID(ctx context.Context, teamID primitive.ObjectID) (_ int, err error) {
+	defer r.observe("CountPendingByTeamID", time.Now(), &err)
+	return r.next.CountPendingByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamInvitationRepository) Resolve(ctx context.Context, id primitive.ObjectID, status string, resolvedBy primitive.ObjectID) (err error) {
+	defer r.observe("Resolve", time.Now(), &err)
+	return r.next.Resolve(ctx, id, status, resolvedBy)
+}
+
+func (r *instrumentedTeamInvitationRepository) Renew(ctx context.Context, id primitive.ObjectID, expiresAt time.Time, resent bool) (_ *models.TeamInvitation, err error) {
+	defer r.observe("Renew", time.Now(), &err)
+	return r.next.Renew(ctx, id, expiresAt, resent)
+}
+
+func (r *instrumentedTeamInvitationRepository) MarkExpired(ctx context.Context) (_ int, err error) {
+	defer r.observe("MarkExpired", time.Now(), &err)
+	return r.next.MarkExpired(ctx)
+}
+
+func (r *instrumentedTeamInvitationRepository) Delete(ctx context.Context, id primitive.ObjectID) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedTeamInvitationRepository) DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) (err error) {
+	defer r.observe("DeleteAllByTeamID", time.Now(), &err)
+	return r.next.DeleteAllByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamInvitationRepository) DeleteExpired(ctx context.Context, before time.Time) (_ int, err error) {
+	defer r.observe("DeleteExpired", time.Now(), &err)
+	return r.next.DeleteExpired(ctx, before)
+}
+
+// instrumentedTeamMemberRepository records the latency of every TeamMemberRepository call.
+type instrumentedTeamMemberRepository struct {
+	instrumented
+	next TeamMemberRepository
+}
+
+// NewInstrumentedTeamMemberRepository wraps next so every call is recorded in m.
+func NewInstrumentedTeamMemberRepository(next TeamMemberRepository, m *metrics.Metrics) TeamMemberRepository {
+	return &instrumentedTeamMemberRepository{instrumented: instrumented{metrics: m, collection: "team_members"}, next: next}
+}
+
+func (r *instrumentedTeamMemberRepository) Create(ctx context.Context, member *models.TeamMember) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, member)
+}
+
+func (r *instrumentedTeamMemberRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID) (_ []models.TeamMember, err error) {
+	defer r.observe("FindByTeamID", time.Now(), &err)
+	return r.next.FindByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamMemberRepository) FindByTeamAndUser(ctx context.Context, teamID, userID primitive.ObjectID) (_ *models.TeamMember, err error) {
+	defer r.observe("FindByTeamAndUser", time.Now(), &err)
+	return r.next.FindByTeamAndUser(ctx, teamID, userID)
+}
+
+func (r *instrumentedTeamMemberRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) (_ []models.TeamMember, err error) {
+	defer r.observe("FindByUserID", time.Now(), &err)
+	return r.next.FindByUserID(ctx, userID)
+}
+
+func (r *instrumentedTeamMemberRepository) CountByTeamID(ctx context.Context, teamID primitive.ObjectID) (_ int, err error) {
+	defer r.observe("CountByTeamID", time.Now(), &err)
+	return r.next.CountByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamMemberRepository) UpdateRole(ctx context.Context, teamID, userID primitive.ObjectID, role string) (err error) {
+	defer r.observe("UpdateRole", time.Now(), &err)
+	return r.next.UpdateRole(ctx, teamID, userID, role)
+}
+
+func (r *instrumentedTeamMemberRepository) Delete(ctx context.Context, teamID, userID primitive.ObjectID) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, teamID, userID)
+}
+
+func (r *instrumentedTeamMemberRepository) DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) (err error) {
+	defer r.observe("DeleteAllByTeamID", time.Now(), &err)
+	return r.next.DeleteAllByTeamID(ctx, teamID)
+}
+
+// instrumentedTeamRepository records the latency of every TeamRepository call.
+type instrumentedTeamRepository struct {
+	instrumented
+	next TeamRepository
+}
+
+// NewInstrumentedTeamRepository wraps next so every call is recorded in m.
+func NewInstrumentedTeamRepository(next TeamRepository, m *metrics.Metrics) TeamRepository {
+	return &instrumentedTeamRepository{instrumented: instrumented{metrics: m, collection: "teams"}, next: next}
+}
+
+func (r *instrumentedTeamRepository) Create(ctx context.Context, team *models.Team) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, team)
+}
+
+func (r *instrumentedTeamRepository) FindByID(ctx context.Context, id primitive.ObjectID) (_ *models.Team, err error) {
+	defer r.observe("FindByID", time.Now(), &err)
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *instrumentedTeamRepository) FindBySlug(ctx context.Context, slug string) (_ *models.Team, err error) {
+	defer r.observe("FindBySlug", time.Now(), &err)
+	return r.next.FindBySlug(ctx, slug)
+}
+
+func (r *instrumentedTeamRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, page, limit int) (_ []models.Team, _ int, err error) {
+	defer r.observe("FindByUserID", time.Now(), &err)
+	return r.next.FindByUserID(ctx, userID, page, limit)
+}
+
+func (r *instrumentedTeamRepository) CountByOwnerID(ctx context.Context, ownerID primitive.ObjectID) (_ int, err error) {
+	defer r.observe("CountByOwnerID", time.Now(), &err)
+	return r.next.CountByOwnerID(ctx, ownerID)
+}
+
+func (r *instrumentedTeamRepository) Update(ctx context.Context, team *models.Team) (err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.next.Update(ctx, team)
+}
+
+func (r *instrumentedTeamRepository) SoftDelete(ctx context.Context, id primitive.ObjectID) (err error) {
+	defer r.observe("SoftDelete", time.Now(), &err)
+	return r.next.SoftDelete(ctx, id)
+}
+
+// instrumentedTeamRoleRepository records the latency of every TeamRoleRepository call.
+type instrumentedTeamRoleRepository struct {
+	instrumented
+	next TeamRoleRepository
+}
+
+// NewInstrumentedTeamRoleRepository wraps next so every call is recorded in m.
+func NewInstrumentedTeamRoleRepository(next TeamRoleRepository, m *metrics.Metrics) TeamRoleRepository {
+	return &instrumentedTeamRoleRepository{instrumented: instrumented{metrics: m, collection: "team_roles"}, next: next}
+}
+
+func (r *instrumentedTeamRoleRepository) Create(ctx context.Context, role *models.TeamRole) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, role)
+}
+
+func (r *instrumentedTeamRoleRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID) (_ []models.TeamRole, err error) {
+	defer r.observe("FindByTeamID", time.Now(), &err)
+	return r.next.FindByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamRoleRepository) FindByTeamAndName(ctx context.Context, teamID primitive.ObjectID, name string) (_ *models.TeamRole, err error) {
+	defer r.observe("FindByTeamAndName", time.Now(), &err)
+	return r.next.FindByTeamAndName(ctx, teamID, name)
+}
+
+func (r *instrumentedTeamRoleRepository) CountByTeamID(ctx context.Context, teamID primitive.ObjectID) (_ int, err error) {
+	defer r.observe("CountByTeamID", time.Now(), &err)
+	return r.next.CountByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedTeamRoleRepository) UpdateActions(ctx context.Context, teamID primitive.ObjectID, name string, actions []string) (_ *models.TeamRole, err error) {
+	defer r.observe("UpdateActions", time.Now(), &err)
+	return r.next.UpdateActions(ctx, teamID, name, actions)
+}
+
+func (r *instrumentedTeamRoleRepository) Delete(ctx context.Context, teamID primitive.ObjectID, name string) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, teamID, name)
+}
+
+func (r *instrumentedTeamRoleRepository) DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) (err error) {
+	defer r.observe("DeleteAllByTeamID", time.Now(), &err)
+	return r.next.DeleteAllByTeamID(ctx, teamID)
+}
+
+// instrumentedUserRepository records the latency of every UserRepository call.
+type instrumentedUserRepository struct {
+	instrumented
+	next UserRepository
+}
+
+// NewInstrumentedUserRepository wraps next so every call is recorded in m.
+func NewInstrumentedUserRepository(next UserRepository, m *metrics.Metrics) UserRepository {
+	return &instrumentedUserRepository{instrumented: instrumented{metrics: m, collection: "users"}, next: next}
+}
+
+func (r *instrumentedUserRepository) Create(ctx context.Context, user *models.User) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, user)
+}
+
+func (r *instrumentedUserRepository) FindByID(ctx context.Context, id primitive.ObjectID) (_ *models.User, err error) {
+	defer r.observe("FindByID", time.Now(), &err)
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *instrumentedUserRepository) FindByEmail(ctx context.Context, email string) (_ *models.User, err error) {
+	defer r.observe("FindByEmail", time.Now(), &err)
+	return r.next.FindByEmail(ctx, email)
+}
+
+func (r *instrumentedUserRepository) FindByIdentity(ctx context.Context, provider, subject string) (_ *models.User, err error) {
+	defer r.observe("FindByIdentity", time.Now(), &err)
+	return r.next.FindByIdentity(ctx, provider, subject)
+}
+
+func (r *instrumentedUserRepository) FindAll(ctx context.Context) (_ []models.User, err error) {
+	defer r.observe("FindAll", time.Now(), &err)
+	return r.next.FindAll(ctx)
+}
+
+func (r *instrumentedUserRepository) Update(ctx context.Context, id primitive.ObjectID, update *models.UpdateUserRequest) (_ *models.User, err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.next.Update(ctx, id, update)
+}
+
+func (r *instrumentedUserRepository) Delete(ctx context.Context, id primitive.ObjectID) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedUserRepository) UpdatePassword(ctx context.Context, id primitive.ObjectID, hashedPassword string) (err error) {
+	defer r.observe("UpdatePassword", time.Now(), &err)
+	return r.next.UpdatePassword(ctx, id, hashedPassword)
+}
+
+func (r *instrumentedUserRepository) SetMFAPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) (err error) {
+	defer r.observe("SetMFAPendingSecret", time.Now(), &err)
+	return r.next.SetMFAPendingSecret(ctx, id, secret)
+}
+
+func (r *instrumentedUserRepository) EnableMFA(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodeHashes []string) (err error) {
+	defer r.observe("EnableMFA", time.Now(), &err)
+	return r.next.EnableMFA(ctx, id, secret, recoveryCodeHashes)
+}
+
+func (r *instrumentedUserRepository) DisableMFA(ctx context.Context, id primitive.ObjectID) (err error) {
+	defer r.observe("DisableMFA", time.Now(), &err)
+	return r.next.DisableMFA(ctx, id)
+}
+
+func (r *instrumentedUserRepository) SetRecoveryCodes(ctx context.Context, id primitive.ObjectID, recoveryCodeHashes []string) (err error) {
+	defer r.observe("SetRecoveryCodes", time.Now(), &err)
+	return r.next.SetRecoveryCodes(ctx, id, recoveryCodeHashes)
+}
+
+func (r *instrumentedUserRepository) ConsumeRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (_ bool, err error) {
+	defer r.observe("ConsumeRecoveryCode", time.Now(), &err)
+	return r.next.ConsumeRecoveryCode(ctx, id, codeHash)
+}
+
+func (r *instrumentedUserRepository) AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.ExternalIdentity) (err error) {
+	defer r.observe("AddIdentity", time.Now(), &err)
+	return r.next.AddIdentity(ctx, id, identity)
+}
+
+// instrumentedVoiceMemoRepository records the latency of every VoiceMemoRepository call.
+type instrumentedVoiceMemoRepository struct {
+	instrumented
+	next VoiceMemoRepository
+}
+
+// NewInstrumentedVoiceMemoRepository wraps next so every call is recorded in m.
+func NewInstrumentedVoiceMemoRepository(next VoiceMemoRepository, m *metrics.Metrics) VoiceMemoRepository {
+	return &instrumentedVoiceMemoRepository{instrumented: instrumented{metrics: m, collection: "voice_memos"}, next: next}
+}
+
+func (r *instrumentedVoiceMemoRepository) Create(ctx context.Context, memo *models.VoiceMemo) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, memo)
+}
+
+func (r *instrumentedVoiceMemoRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, page, limit int) (_ []models.VoiceMemo, _ int, err error) {
+	defer r.observe("FindByUserID", time.Now(), &err)
+	return r.next.FindByUserID(ctx, userID, page, limit)
+}
+
+func (r *instrumentedVoiceMemoRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID, page, limit int) (_ []models.VoiceMemo, _ int, err error) {
+	defer r.observe("FindByTeamID", time.Now(), &err)
+	return r.next.FindByTeamID(ctx, teamID, page, limit)
+}
+
+func (r *instrumentedVoiceMemoRepository) FindByID(ctx context.Context, id primitive.ObjectID) (_ *models.VoiceMemo, err error) {
+	defer r.observe("FindByID", time.Now(), &err)
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *instrumentedVoiceMemoRepository) FindByIDIncludingDeleted(ctx context.Context, id primitive.ObjectID) (_ *models.VoiceMemo, err error) {
+	defer r.observe("FindByIDIncludingDeleted", time.Now(), &err)
+	return r.next.FindByIDIncludingDeleted(ctx, id)
+}
+
+func (r *instrumentedVoiceMemoRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status models.VoiceMemoStatus) (err error) {
+	defer r.observe("UpdateStatus", time.Now(), &err)
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+func (r *instrumentedVoiceMemoRepository) UpdateStatusConditional(ctx context.Context, id primitive.ObjectID, fromStatus, toStatus models.VoiceMemoStatus) (err error) {
+	defer r.observe("UpdateStatusConditional", time.Now(), &err)
+	return r.next.UpdateStatusConditional(ctx, id, fromStatus, toStatus)
+}
+
+func (r *instrumentedVoiceMemoRepository) UpdateStatusWithOwnership(ctx context.Context, id, userID primitive.ObjectID, fromStatus, toStatus models.VoiceMemoStatus) (_ *models.VoiceMemo, err error) {
+	defer r.observe("UpdateStatusWithOwnership", time.Now(), &err)
+	return r.next.UpdateStatusWithOwnership(ctx, id, userID, fromStatus, toStatus)
+}
+
+func (r *instrumentedVoiceMemoRepository) UpdateStatusWithTeam(ctx context.Context, id, teamID primitive.ObjectID, fromStatus, toStatus models.VoiceMemoStatus) (_ *models.VoiceMemo, err error) {
+	defer r.observe("UpdateStatusWithTeam", time.Now(), &err)
+	return r.next.UpdateStatusWithTeam(ctx, id, teamID, fromStatus, toStatus)
+}
+
+func (r *instrumentedVoiceMemoRepository) UpdateTranscriptionAndStatus(ctx context.Context, id primitive.ObjectID, transcription string, status models.VoiceMemoStatus) (err error) {
+	defer r.observe("UpdateTranscriptionAndStatus", time.Now(), &err)
+	return r.next.UpdateTranscriptionAndStatus(ctx, id, transcription, status)
+}
+
+func (r *instrumentedVoiceMemoRepository) SoftDeleteByID(ctx context.Context, id primitive.ObjectID) (err error) {
+	defer r.observe("SoftDeleteByID", time.Now(), &err)
+	return r.next.SoftDeleteByID(ctx, id)
+}
+
+func (r *instrumentedVoiceMemoRepository) SoftDeleteWithOwnership(ctx context.Context, id, userID primitive.ObjectID) (err error) {
+	defer r.observe("SoftDeleteWithOwnership", time.Now(), &err)
+	return r.next.SoftDeleteWithOwnership(ctx, id, userID)
+}
+
+func (r *instrumentedVoiceMemoRepository) SoftDeleteWithTeam(ctx context.Context, id, teamID primitive.ObjectID) (err error) {
+	defer r.observe("SoftDeleteWithTeam", time.Now(), &err)
+	return r.next.SoftDeleteWithTeam(ctx, id, teamID)
+}
+
+func (r *instrumentedVoiceMemoRepository) SoftDeleteByTeamID(ctx context.Context, teamID primitive.ObjectID) (err error) {
+	defer r.observe("SoftDeleteByTeamID", time.Now(), &err)
+	return r.next.SoftDeleteByTeamID(ctx, teamID)
+}
+
+func (r *instrumentedVoiceMemoRepository) GetUserUsage(ctx context.Context, userID primitive.ObjectID, periodStart time.Time) (_ *models.VoiceMemoUsage, err error) {
+	defer r.observe("GetUserUsage", time.Now(), &err)
+	return r.next.GetUserUsage(ctx, userID, periodStart)
+}
+
+func (r *instrumentedVoiceMemoRepository) GetTeamUsage(ctx context.Context, teamID primitive.ObjectID, periodStart time.Time) (_ *models.VoiceMemoUsage, err error) {
+	defer r.observe("GetTeamUsage", time.Now(), &err)
+	return r.next.GetTeamUsage(ctx, teamID, periodStart)
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/metrics"
+	"gin-sample/internal/models"
+	"gin-sample/internal/repository"
+	"gin-sample/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInstrumentedUserRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := metrics.New()
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	repo := repository.NewInstrumentedUserRepository(mockRepo, m)
+
+	user := &models.User{ID: primitive.NewObjectID()}
+	dbErr := errors.New("connection refused")
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	mockRepo.EXPECT().FindByEmail(gomock.Any(), "missing@example.com").Return(nil, apperrors.ErrUserNotFound)
+	mockRepo.EXPECT().Delete(gomock.Any(), user.ID).Return(dbErr)
+
+	found, err := repo.FindByID(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, user, found)
+
+	_, err = repo.FindByEmail(context.Background(), "missing@example.com")
+	assert.Equal(t, apperrors.ErrUserNotFound, err)
+
+	err = repo.Delete(context.Background(), user.ID)
+	assert.Equal(t, dbErr, err)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, `gin_sample_dependency_call_duration_seconds_count{dependency="mongodb",operation="users.FindByID",result="ok"} 1`)
+	assert.Contains(t, body, `gin_sample_dependency_call_duration_seconds_count{dependency="mongodb",operation="users.FindByEmail",result="ok"} 1`)
+	assert.Contains(t, body, `gin_sample_dependency_call_duration_seconds_count{dependency="mongodb",operation="users.Delete",result="error"} 1`)
+}
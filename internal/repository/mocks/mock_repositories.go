@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: gin-sample/internal/repository (interfaces: UserRepository,RefreshTokenRepository,TeamRepository,TeamMemberRepository,TeamInvitationRepository,VoiceMemoRepository)
+// Source: gin-sample/internal/repository (interfaces: UserRepository,RefreshTokenRepository,TeamRepository,TeamMemberRepository,TeamInvitationRepository,VoiceMemoRepository,APIKeyRepository,TeamRoleRepository,AuditLogRepository)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mock_repositories.go -package=mocks gin-sample/internal/repository UserRepository,RefreshTokenRepository,TeamRepository,TeamMemberRepository,TeamInvitationRepository,VoiceMemoRepository
+//	mockgen -destination=mocks/mock_repositories.go -package=mocks gin-sample/internal/repository UserRepository,RefreshTokenRepository,TeamRepository,TeamMemberRepository,TeamInvitationRepository,VoiceMemoRepository,APIKeyRepository,TeamRoleRepository,AuditLogRepository
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	models "gin-sample/internal/models"
 	reflect "reflect"
+	time "time"
 
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 	gomock "go.uber.org/mock/gomock"
@@ -42,6 +43,35 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddIdentity mocks base method.
+func (m *MockUserRepository) AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIdentity", ctx, id, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddIdentity indicates an expected call of AddIdentity.
+func (mr *MockUserRepositoryMockRecorder) AddIdentity(ctx, id, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentity", reflect.TypeOf((*MockUserRepository)(nil).AddIdentity), ctx, id, identity)
+}
+
+// ConsumeRecoveryCode mocks base method.
+func (m *MockUserRepository) ConsumeRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRecoveryCode", ctx, id, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeRecoveryCode indicates an expected call of ConsumeRecoveryCode.
+func (mr *MockUserRepositoryMockRecorder) ConsumeRecoveryCode(ctx, id, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRecoveryCode", reflect.TypeOf((*MockUserRepository)(nil).ConsumeRecoveryCode), ctx, id, codeHash)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
@@ -70,6 +100,34 @@ func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
 }
 
+// DisableMFA mocks base method.
+func (m *MockUserRepository) DisableMFA(ctx context.Context, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockUserRepositoryMockRecorder) DisableMFA(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockUserRepository)(nil).DisableMFA), ctx, id)
+}
+
+// EnableMFA mocks base method.
+func (m *MockUserRepository) EnableMFA(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", ctx, id, secret, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockUserRepositoryMockRecorder) EnableMFA(ctx, id, secret, recoveryCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockUserRepository)(nil).EnableMFA), ctx, id, secret, recoveryCodeHashes)
+}
+
 // FindAll mocks base method.
 func (m *MockUserRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +173,49 @@ func (mr *MockUserRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), ctx, id)
 }
 
+// FindByIdentity mocks base method.
+func (m *MockUserRepository) FindByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdentity indicates an expected call of FindByIdentity.
+func (mr *MockUserRepositoryMockRecorder) FindByIdentity(ctx, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdentity", reflect.TypeOf((*MockUserRepository)(nil).FindByIdentity), ctx, provider, subject)
+}
+
+// SetMFAPendingSecret mocks base method.
+func (m *MockUserRepository) SetMFAPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMFAPendingSecret", ctx, id, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMFAPendingSecret indicates an expected call of SetMFAPendingSecret.
+func (mr *MockUserRepositoryMockRecorder) SetMFAPendingSecret(ctx, id, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMFAPendingSecret", reflect.TypeOf((*MockUserRepository)(nil).SetMFAPendingSecret), ctx, id, secret)
+}
+
+// SetRecoveryCodes mocks base method.
+func (m *MockUserRepository) SetRecoveryCodes(ctx context.Context, id primitive.ObjectID, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecoveryCodes", ctx, id, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecoveryCodes indicates an expected call of SetRecoveryCodes.
+func (mr *MockUserRepositoryMockRecorder) SetRecoveryCodes(ctx, id, recoveryCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecoveryCodes", reflect.TypeOf((*MockUserRepository)(nil).SetRecoveryCodes), ctx, id, recoveryCodeHashes)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, id primitive.ObjectID, update *models.UpdateUserRequest) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +231,20 @@ func (mr *MockUserRepositoryMockRecorder) Update(ctx, id, update any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, id, update)
 }
 
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id primitive.ObjectID, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(ctx, id, hashedPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), ctx, id, hashedPassword)
+}
+
 // MockRefreshTokenRepository is a mock of RefreshTokenRepository interface.
 type MockRefreshTokenRepository struct {
 	ctrl     *gomock.Controller
@@ -575,18 +690,18 @@ func (mr *MockTeamInvitationRepositoryMockRecorder) DeleteAllByTeamID(ctx, teamI
 }
 
 // DeleteExpired mocks base method.
-func (m *MockTeamInvitationRepository) DeleteExpired(ctx context.Context) (int, error) {
+func (m *MockTeamInvitationRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, before)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteExpired indicates an expected call of DeleteExpired.
-func (mr *MockTeamInvitationRepositoryMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+func (mr *MockTeamInvitationRepositoryMockRecorder) DeleteExpired(ctx, before any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockTeamInvitationRepository)(nil).DeleteExpired), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockTeamInvitationRepository)(nil).DeleteExpired), ctx, before)
 }
 
 // FindByEmail mocks base method.
@@ -649,6 +764,66 @@ func (mr *MockTeamInvitationRepositoryMockRecorder) FindByTeamID(ctx, teamID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamID", reflect.TypeOf((*MockTeamInvitationRepository)(nil).FindByTeamID), ctx, teamID)
 }
 
+// FindHistoryByTeamID mocks base method.
+func (m *MockTeamInvitationRepository) FindHistoryByTeamID(ctx context.Context, teamID primitive.ObjectID, page, limit int) ([]models.TeamInvitation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHistoryByTeamID", ctx, teamID, page, limit)
+	ret0, _ := ret[0].([]models.TeamInvitation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindHistoryByTeamID indicates an expected call of FindHistoryByTeamID.
+func (mr *MockTeamInvitationRepositoryMockRecorder) FindHistoryByTeamID(ctx, teamID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHistoryByTeamID", reflect.TypeOf((*MockTeamInvitationRepository)(nil).FindHistoryByTeamID), ctx, teamID, page, limit)
+}
+
+// MarkExpired mocks base method.
+func (m *MockTeamInvitationRepository) MarkExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpired indicates an expected call of MarkExpired.
+func (mr *MockTeamInvitationRepositoryMockRecorder) MarkExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpired", reflect.TypeOf((*MockTeamInvitationRepository)(nil).MarkExpired), ctx)
+}
+
+// Renew mocks base method.
+func (m *MockTeamInvitationRepository) Renew(ctx context.Context, id primitive.ObjectID, expiresAt time.Time, resent bool) (*models.TeamInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, id, expiresAt, resent)
+	ret0, _ := ret[0].(*models.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockTeamInvitationRepositoryMockRecorder) Renew(ctx, id, expiresAt, resent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockTeamInvitationRepository)(nil).Renew), ctx, id, expiresAt, resent)
+}
+
+// Resolve mocks base method.
+func (m *MockTeamInvitationRepository) Resolve(ctx context.Context, id primitive.ObjectID, status string, resolvedBy primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, id, status, resolvedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockTeamInvitationRepositoryMockRecorder) Resolve(ctx, id, status, resolvedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockTeamInvitationRepository)(nil).Resolve), ctx, id, status, resolvedBy)
+}
+
 // MockVoiceMemoRepository is a mock of VoiceMemoRepository interface.
 type MockVoiceMemoRepository struct {
 	ctrl     *gomock.Controller
@@ -749,6 +924,36 @@ func (mr *MockVoiceMemoRepositoryMockRecorder) FindByUserID(ctx, userID, page, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockVoiceMemoRepository)(nil).FindByUserID), ctx, userID, page, limit)
 }
 
+// GetTeamUsage mocks base method.
+func (m *MockVoiceMemoRepository) GetTeamUsage(ctx context.Context, teamID primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamUsage", ctx, teamID, periodStart)
+	ret0, _ := ret[0].(*models.VoiceMemoUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamUsage indicates an expected call of GetTeamUsage.
+func (mr *MockVoiceMemoRepositoryMockRecorder) GetTeamUsage(ctx, teamID, periodStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamUsage", reflect.TypeOf((*MockVoiceMemoRepository)(nil).GetTeamUsage), ctx, teamID, periodStart)
+}
+
+// GetUserUsage mocks base method.
+func (m *MockVoiceMemoRepository) GetUserUsage(ctx context.Context, userID primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserUsage", ctx, userID, periodStart)
+	ret0, _ := ret[0].(*models.VoiceMemoUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserUsage indicates an expected call of GetUserUsage.
+func (mr *MockVoiceMemoRepositoryMockRecorder) GetUserUsage(ctx, userID, periodStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUsage", reflect.TypeOf((*MockVoiceMemoRepository)(nil).GetUserUsage), ctx, userID, periodStart)
+}
+
 // SoftDeleteByID mocks base method.
 func (m *MockVoiceMemoRepository) SoftDeleteByID(ctx context.Context, id primitive.ObjectID) error {
 	m.ctrl.T.Helper()
@@ -876,3 +1081,308 @@ func (mr *MockVoiceMemoRepositoryMockRecorder) UpdateTranscriptionAndStatus(ctx,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTranscriptionAndStatus", reflect.TypeOf((*MockVoiceMemoRepository)(nil).UpdateTranscriptionAndStatus), ctx, id, transcription, status)
 }
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByUserID mocks base method.
+func (m *MockAPIKeyRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserID", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserID indicates an expected call of CountByUserID.
+func (mr *MockAPIKeyRepositoryMockRecorder) CountByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockAPIKeyRepository)(nil).CountByUserID), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepositoryMockRecorder) Create(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepository)(nil).Create), ctx, key)
+}
+
+// Delete mocks base method.
+func (m *MockAPIKeyRepository) Delete(ctx context.Context, id, userID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAPIKeyRepositoryMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPIKeyRepository)(nil).Delete), ctx, id, userID)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockAPIKeyRepository) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockAPIKeyRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockAPIKeyRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByHash mocks base method.
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) FindByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).FindByHash), ctx, keyHash)
+}
+
+// FindByUserID mocks base method.
+func (m *MockAPIKeyRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockAPIKeyRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockAPIKeyRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// UpdateLastUsed mocks base method.
+func (m *MockAPIKeyRepository) UpdateLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsed", ctx, id, lastUsedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsed indicates an expected call of UpdateLastUsed.
+func (mr *MockAPIKeyRepositoryMockRecorder) UpdateLastUsed(ctx, id, lastUsedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsed", reflect.TypeOf((*MockAPIKeyRepository)(nil).UpdateLastUsed), ctx, id, lastUsedAt)
+}
+
+// MockTeamRoleRepository is a mock of TeamRoleRepository interface.
+type MockTeamRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamRoleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamRoleRepositoryMockRecorder is the mock recorder for MockTeamRoleRepository.
+type MockTeamRoleRepositoryMockRecorder struct {
+	mock *MockTeamRoleRepository
+}
+
+// NewMockTeamRoleRepository creates a new mock instance.
+func NewMockTeamRoleRepository(ctrl *gomock.Controller) *MockTeamRoleRepository {
+	mock := &MockTeamRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockTeamRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamRoleRepository) EXPECT() *MockTeamRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByTeamID mocks base method.
+func (m *MockTeamRoleRepository) CountByTeamID(ctx context.Context, teamID primitive.ObjectID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByTeamID", ctx, teamID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByTeamID indicates an expected call of CountByTeamID.
+func (mr *MockTeamRoleRepositoryMockRecorder) CountByTeamID(ctx, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByTeamID", reflect.TypeOf((*MockTeamRoleRepository)(nil).CountByTeamID), ctx, teamID)
+}
+
+// Create mocks base method.
+func (m *MockTeamRoleRepository) Create(ctx context.Context, role *models.TeamRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTeamRoleRepositoryMockRecorder) Create(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTeamRoleRepository)(nil).Create), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockTeamRoleRepository) Delete(ctx context.Context, teamID primitive.ObjectID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, teamID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTeamRoleRepositoryMockRecorder) Delete(ctx, teamID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTeamRoleRepository)(nil).Delete), ctx, teamID, name)
+}
+
+// DeleteAllByTeamID mocks base method.
+func (m *MockTeamRoleRepository) DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByTeamID", ctx, teamID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllByTeamID indicates an expected call of DeleteAllByTeamID.
+func (mr *MockTeamRoleRepositoryMockRecorder) DeleteAllByTeamID(ctx, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByTeamID", reflect.TypeOf((*MockTeamRoleRepository)(nil).DeleteAllByTeamID), ctx, teamID)
+}
+
+// FindByTeamAndName mocks base method.
+func (m *MockTeamRoleRepository) FindByTeamAndName(ctx context.Context, teamID primitive.ObjectID, name string) (*models.TeamRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamAndName", ctx, teamID, name)
+	ret0, _ := ret[0].(*models.TeamRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTeamAndName indicates an expected call of FindByTeamAndName.
+func (mr *MockTeamRoleRepositoryMockRecorder) FindByTeamAndName(ctx, teamID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamAndName", reflect.TypeOf((*MockTeamRoleRepository)(nil).FindByTeamAndName), ctx, teamID, name)
+}
+
+// FindByTeamID mocks base method.
+func (m *MockTeamRoleRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID) ([]models.TeamRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamID", ctx, teamID)
+	ret0, _ := ret[0].([]models.TeamRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTeamID indicates an expected call of FindByTeamID.
+func (mr *MockTeamRoleRepositoryMockRecorder) FindByTeamID(ctx, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamID", reflect.TypeOf((*MockTeamRoleRepository)(nil).FindByTeamID), ctx, teamID)
+}
+
+// UpdateActions mocks base method.
+func (m *MockTeamRoleRepository) UpdateActions(ctx context.Context, teamID primitive.ObjectID, name string, actions []string) (*models.TeamRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActions", ctx, teamID, name, actions)
+	ret0, _ := ret[0].(*models.TeamRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActions indicates an expected call of UpdateActions.
+func (mr *MockTeamRoleRepositoryMockRecorder) UpdateActions(ctx, teamID, name, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActions", reflect.TypeOf((*MockTeamRoleRepository)(nil).UpdateActions), ctx, teamID, name, actions)
+}
+
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditLogRepository) Create(ctx context.Context, entry *models.AuditLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditLogRepositoryMockRecorder) Create(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditLogRepository)(nil).Create), ctx, entry)
+}
+
+// FindByTeamID mocks base method.
+func (m *MockAuditLogRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) ([]models.AuditLogEntry, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamID", ctx, teamID, filter, page, limit)
+	ret0, _ := ret[0].([]models.AuditLogEntry)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByTeamID indicates an expected call of FindByTeamID.
+func (mr *MockAuditLogRepositoryMockRecorder) FindByTeamID(ctx, teamID, filter, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamID", reflect.TypeOf((*MockAuditLogRepository)(nil).FindByTeamID), ctx, teamID, filter, page, limit)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RelationshipRepository defines the interface for authorization relationship storage.
+type RelationshipRepository interface {
+	WriteRelationships(ctx context.Context, deletes []models.RelationshipFilter, touches []models.Relationship) error
+	ReadRelationships(ctx context.Context, filter models.RelationshipFilter) ([]models.Relationship, error)
+}
+
+// relationshipRepository implements RelationshipRepository using MongoDB.
+type relationshipRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRelationshipRepository creates a new RelationshipRepository.
+func NewRelationshipRepository(db *mongo.Database) RelationshipRepository {
+	collection := db.Collection("relationships")
+
+	// Create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "resourceType", Value: 1},
+				{Key: "resourceId", Value: 1},
+				{Key: "subjectType", Value: 1},
+				{Key: "subjectId", Value: 1},
+				{Key: "relation", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &relationshipRepository{
+		collection: collection,
+	}
+}
+
+// WriteRelationships deletes the relationships matching each filter, then creates the touched
+// relationships if they do not already exist. Deletes run first so a relation can be replaced in one call.
+func (r *relationshipRepository) WriteRelationships(ctx context.Context, deletes []models.RelationshipFilter, touches []models.Relationship) error {
+	for _, filter := range deletes {
+		if _, err := r.collection.DeleteMany(ctx, relationshipFilterQuery(filter)); err != nil {
+			return err
+		}
+	}
+
+	for _, rel := range touches {
+		query := bson.M{
+			"resourceType": rel.ResourceType,
+			"resourceId":   rel.ResourceID,
+			"relation":     rel.Relation,
+			"subjectType":  rel.SubjectType,
+			"subjectId":    rel.SubjectID,
+		}
+		opts := options.Update().SetUpsert(true)
+		if _, err := r.collection.UpdateOne(ctx, query, bson.M{"$setOnInsert": rel}, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadRelationships returns the relationships matching filter.
+func (r *relationshipRepository) ReadRelationships(ctx context.Context, filter models.RelationshipFilter) ([]models.Relationship, error) {
+	cursor, err := r.collection.Find(ctx, relationshipFilterQuery(filter))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var relationships []models.Relationship
+	if err := cursor.All(ctx, &relationships); err != nil {
+		return nil, err
+	}
+	if relationships == nil {
+		relationships = []models.Relationship{}
+	}
+
+	return relationships, nil
+}
+
+// relationshipFilterQuery converts a filter into a MongoDB query, skipping empty fields.
+func relationshipFilterQuery(filter models.RelationshipFilter) bson.M {
+	query := bson.M{"resourceType": filter.ResourceType}
+	if filter.ResourceID != "" {
+		query["resourceId"] = filter.ResourceID
+	}
+	if filter.Relation != "" {
+		query["relation"] = filter.Relation
+	}
+	if filter.SubjectType != "" {
+		query["subjectType"] = filter.SubjectType
+	}
+	if filter.SubjectID != "" {
+		query["subjectId"] = filter.SubjectID
+	}
+	return query
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelationshipRepository(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewRelationshipRepository(tdb.Database)
+
+	assert.NotNil(t, repo)
+}
+
+func TestRelationshipRepository_WriteAndRead(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewRelationshipRepository(tdb.Database)
+	ctx := context.Background()
+
+	admin := models.Relationship{ResourceType: "team", ResourceID: "t1", Relation: "admin", SubjectType: "user", SubjectID: "u1"}
+	member := models.Relationship{ResourceType: "team", ResourceID: "t1", Relation: "member", SubjectType: "user", SubjectID: "u2"}
+
+	t.Run("reads written relationships by resource and subject", func(t *testing.T) {
+		tdb.ClearCollection(t, "relationships")
+
+		require.NoError(t, repo.WriteRelationships(ctx, nil, []models.Relationship{admin, member}))
+
+		rels, err := repo.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: "team", ResourceID: "t1", SubjectType: "user", SubjectID: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{admin}, rels)
+
+		rels, err = repo.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: "team", ResourceID: "t1"})
+		require.NoError(t, err)
+		assert.Len(t, rels, 2)
+	})
+
+	t.Run("writing the same relationship twice is idempotent", func(t *testing.T) {
+		tdb.ClearCollection(t, "relationships")
+
+		require.NoError(t, repo.WriteRelationships(ctx, nil, []models.Relationship{admin}))
+		require.NoError(t, repo.WriteRelationships(ctx, nil, []models.Relationship{admin}))
+
+		rels, err := repo.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: "team", ResourceID: "t1"})
+		require.NoError(t, err)
+		assert.Len(t, rels, 1)
+	})
+
+	t.Run("deletes run before touches", func(t *testing.T) {
+		tdb.ClearCollection(t, "relationships")
+
+		require.NoError(t, repo.WriteRelationships(ctx, nil, []models.Relationship{admin, member}))
+
+		// Replace u1's relation on the team
+		promoted := admin
+		promoted.Relation = "owner"
+		deletes := []models.RelationshipFilter{{ResourceType: "team", ResourceID: "t1", SubjectType: "user", SubjectID: "u1"}}
+		require.NoError(t, repo.WriteRelationships(ctx, deletes, []models.Relationship{promoted}))
+
+		rels, err := repo.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: "team", ResourceID: "t1", SubjectID: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{promoted}, rels)
+
+		// Other subjects are untouched
+		rels, err = repo.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: "team", ResourceID: "t1", SubjectID: "u2"})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Relationship{member}, rels)
+	})
+
+	t.Run("returns empty slice when nothing matches", func(t *testing.T) {
+		tdb.ClearCollection(t, "relationships")
+
+		rels, err := repo.ReadRelationships(ctx, models.RelationshipFilter{ResourceType: "team", ResourceID: "missing"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, rels)
+		assert.Empty(t, rels)
+	})
+}
@@ -11,11 +11,15 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // InvitationExpiryDays is the number of days until an invitation expires.
 const InvitationExpiryDays = 7
 
+// InvitationHistoryRetentionDays is the number of days an invitation is kept after it expires.
+const InvitationHistoryRetentionDays = 90
+
 // TeamInvitationRepository defines the interface for team invitation data operations.
 type TeamInvitationRepository interface {
 	Create(ctx context.Context, invitation *models.TeamInvitation) error
@@ -23,10 +27,14 @@ type TeamInvitationRepository interface {
 	FindByTeamID(ctx context.Context, teamID primitive.ObjectID) ([]models.TeamInvitation, error)
 	FindByEmail(ctx context.Context, email string) ([]models.TeamInvitation, error)
 	FindByTeamAndEmail(ctx context.Context, teamID primitive.ObjectID, email string) (*models.TeamInvitation, error)
+	FindHistoryByTeamID(ctx context.Context, teamID primitive.ObjectID, page, limit int) ([]models.TeamInvitation, int, error)
 	CountPendingByTeamID(ctx context.Context, teamID primitive.ObjectID) (int, error)
+	Resolve(ctx context.Context, id primitive.ObjectID, status string, resolvedBy primitive.ObjectID) error
+	Renew(ctx context.Context, id primitive.ObjectID, expiresAt time.Time, resent bool) (*models.TeamInvitation, error)
+	MarkExpired(ctx context.Context) (int, error)
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) error
-	DeleteExpired(ctx context.Context) (int, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
 }
 
 // teamInvitationRepository implements TeamInvitationRepository using MongoDB.
@@ -41,11 +49,21 @@ func NewTeamInvitationRepository(db *mongo.Database) TeamInvitationRepository {
 	}
 }
 
+// pendingStatus matches invitations that have not been resolved.
+// Invitations created before status tracking have no status field and are treated as pending.
+var pendingStatus = bson.M{"$in": bson.A{models.InvitationStatusPending, nil}}
+
+// renewableStatus matches invitations that can be resent or extended.
+var renewableStatus = bson.M{"$in": bson.A{models.InvitationStatusPending, models.InvitationStatusExpired, nil}}
+
 // Create inserts a new invitation into the database.
 func (r *teamInvitationRepository) Create(ctx context.Context, invitation *models.TeamInvitation) error {
+	now := time.Now()
 	invitation.ID = primitive.NewObjectID()
-	invitation.CreatedAt = time.Now()
-	invitation.ExpiresAt = time.Now().AddDate(0, 0, InvitationExpiryDays)
+	invitation.Status = models.InvitationStatusPending
+	invitation.CreatedAt = now
+	invitation.LastSentAt = now
+	invitation.ExpiresAt = now.AddDate(0, 0, InvitationExpiryDays)
 
 	_, err := r.collection.InsertOne(ctx, invitation)
 	return err
@@ -69,6 +87,7 @@ func (r *teamInvitationRepository) FindByID(ctx context.Context, id primitive.Ob
 func (r *teamInvitationRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID) ([]models.TeamInvitation, error) {
 	filter := bson.M{
 		"teamId":    teamID,
+		"status":    pendingStatus,
 		"expiresAt": bson.M{"$gt": time.Now()},
 	}
 
@@ -94,6 +113,7 @@ func (r *teamInvitationRepository) FindByTeamID(ctx context.Context, teamID prim
 func (r *teamInvitationRepository) FindByEmail(ctx context.Context, email string) ([]models.TeamInvitation, error) {
 	filter := bson.M{
 		"email":     email,
+		"status":    pendingStatus,
 		"expiresAt": bson.M{"$gt": time.Now()},
 	}
 
@@ -120,6 +140,7 @@ func (r *teamInvitationRepository) FindByTeamAndEmail(ctx context.Context, teamI
 	filter := bson.M{
 		"teamId":    teamID,
 		"email":     email,
+		"status":    pendingStatus,
 		"expiresAt": bson.M{"$gt": time.Now()},
 	}
 
@@ -135,10 +156,44 @@ func (r *teamInvitationRepository) FindByTeamAndEmail(ctx context.Context, teamI
 	return &invitation, nil
 }
 
+// FindHistoryByTeamID returns paginated invitations for a team in any status, newest first.
+func (r *teamInvitationRepository) FindHistoryByTeamID(ctx context.Context, teamID primitive.ObjectID, page, limit int) ([]models.TeamInvitation, int, error) {
+	filter := bson.M{"teamId": teamID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * limit)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var invitations []models.TeamInvitation
+	if err := cursor.All(ctx, &invitations); err != nil {
+		return nil, 0, err
+	}
+
+	if invitations == nil {
+		invitations = []models.TeamInvitation{}
+	}
+
+	return invitations, int(total), nil
+}
+
 // CountPendingByTeamID returns the number of pending invitations for a team.
 func (r *teamInvitationRepository) CountPendingByTeamID(ctx context.Context, teamID primitive.ObjectID) (int, error) {
 	filter := bson.M{
 		"teamId":    teamID,
+		"status":    pendingStatus,
 		"expiresAt": bson.M{"$gt": time.Now()},
 	}
 
@@ -150,6 +205,84 @@ func (r *teamInvitationRepository) CountPendingByTeamID(ctx context.Context, tea
 	return int(count), nil
 }
 
+// Resolve atomically moves a pending invitation to a final status and records who resolved it.
+// Returns ErrInvitationNotFound if the invitation does not exist or is no longer pending.
+func (r *teamInvitationRepository) Resolve(ctx context.Context, id primitive.ObjectID, status string, resolvedBy primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": pendingStatus,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"resolvedBy": resolvedBy,
+			"resolvedAt": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apperrors.ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// Renew sets a new expiry on a pending or expired invitation, makes it pending again
+// and returns the updated invitation. When resent is true, the resend count is
+// incremented and the last sent time is updated.
+// Returns ErrInvitationNotFound if the invitation does not exist or was accepted, declined or cancelled.
+func (r *teamInvitationRepository) Renew(ctx context.Context, id primitive.ObjectID, expiresAt time.Time, resent bool) (*models.TeamInvitation, error) {
+	filter := bson.M{
+		"_id":    id,
+		"status": renewableStatus,
+	}
+	set := bson.M{
+		"status":    models.InvitationStatusPending,
+		"expiresAt": expiresAt,
+	}
+	update := bson.M{"$set": set}
+	if resent {
+		set["lastSentAt"] = time.Now()
+		update["$inc"] = bson.M{"resendCount": 1}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var invitation models.TeamInvitation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&invitation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// MarkExpired moves pending invitations past their expiry to the expired status.
+func (r *teamInvitationRepository) MarkExpired(ctx context.Context) (int, error) {
+	filter := bson.M{
+		"status":    pendingStatus,
+		"expiresAt": bson.M{"$lte": time.Now()},
+	}
+	update := bson.M{
+		"$set": bson.M{"status": models.InvitationStatusExpired},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
+
 // Delete removes an invitation.
 func (r *teamInvitationRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
@@ -170,10 +303,10 @@ func (r *teamInvitationRepository) DeleteAllByTeamID(ctx context.Context, teamID
 	return err
 }
 
-// DeleteExpired removes all expired invitations.
-func (r *teamInvitationRepository) DeleteExpired(ctx context.Context) (int, error) {
+// DeleteExpired removes all invitations that expired at or before the given time.
+func (r *teamInvitationRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
 	filter := bson.M{
-		"expiresAt": bson.M{"$lte": time.Now()},
+		"expiresAt": bson.M{"$lte": before},
 	}
 
 	result, err := r.collection.DeleteMany(ctx, filter)
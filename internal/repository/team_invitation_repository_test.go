@@ -464,7 +464,7 @@ func TestTeamInvitationRepository_DeleteExpired(t *testing.T) {
 			require.NoError(t, err)
 		}
 
-		count, err := repo.DeleteExpired(ctx)
+		count, err := repo.DeleteExpired(ctx, time.Now())
 
 		require.NoError(t, err)
 		assert.Equal(t, 3, count)
@@ -487,9 +487,210 @@ func TestTeamInvitationRepository_DeleteExpired(t *testing.T) {
 		}
 		require.NoError(t, repo.Create(ctx, invitation))
 
-		count, err := repo.DeleteExpired(ctx)
+		count, err := repo.DeleteExpired(ctx, time.Now())
 
 		require.NoError(t, err)
 		assert.Equal(t, 0, count)
 	})
 }
+
+func TestTeamInvitationRepository_Resolve(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamInvitationRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("resolves pending invitation", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		invitation := &models.TeamInvitation{
+			TeamID:    primitive.NewObjectID(),
+			Email:     "test@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+		}
+		require.NoError(t, repo.Create(ctx, invitation))
+
+		resolvedBy := primitive.NewObjectID()
+		err := repo.Resolve(ctx, invitation.ID, models.InvitationStatusDeclined, resolvedBy)
+		require.NoError(t, err)
+
+		found, err := repo.FindByID(ctx, invitation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationStatusDeclined, found.Status)
+		require.NotNil(t, found.ResolvedBy)
+		assert.Equal(t, resolvedBy, *found.ResolvedBy)
+		assert.NotNil(t, found.ResolvedAt)
+
+		pending, err := repo.FindByTeamID(ctx, invitation.TeamID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("returns error when invitation is already resolved", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		invitation := &models.TeamInvitation{
+			TeamID:    primitive.NewObjectID(),
+			Email:     "test@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+		}
+		require.NoError(t, repo.Create(ctx, invitation))
+		require.NoError(t, repo.Resolve(ctx, invitation.ID, models.InvitationStatusCancelled, primitive.NewObjectID()))
+
+		err := repo.Resolve(ctx, invitation.ID, models.InvitationStatusAccepted, primitive.NewObjectID())
+
+		assert.Equal(t, apperrors.ErrInvitationNotFound, err)
+	})
+}
+
+func TestTeamInvitationRepository_Renew(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamInvitationRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("resend increments count and resets expired status", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		invitation := &models.TeamInvitation{
+			ID:        primitive.NewObjectID(),
+			TeamID:    primitive.NewObjectID(),
+			Email:     "test@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+			Status:    models.InvitationStatusExpired,
+			CreatedAt: time.Now().Add(-10 * 24 * time.Hour),
+			ExpiresAt: time.Now().Add(-3 * 24 * time.Hour),
+		}
+		_, err := tdb.Database.Collection("team_invitations").InsertOne(ctx, invitation)
+		require.NoError(t, err)
+
+		expiresAt := time.Now().Add(7 * 24 * time.Hour)
+		renewed, err := repo.Renew(ctx, invitation.ID, expiresAt, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationStatusPending, renewed.Status)
+		assert.Equal(t, 1, renewed.ResendCount)
+		assert.WithinDuration(t, expiresAt, renewed.ExpiresAt, time.Second)
+		assert.WithinDuration(t, time.Now(), renewed.LastSentAt, time.Minute)
+	})
+
+	t.Run("extend keeps resend count", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		invitation := &models.TeamInvitation{
+			TeamID:    primitive.NewObjectID(),
+			Email:     "test@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+		}
+		require.NoError(t, repo.Create(ctx, invitation))
+
+		renewed, err := repo.Renew(ctx, invitation.ID, invitation.ExpiresAt.Add(24*time.Hour), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, renewed.ResendCount)
+	})
+
+	t.Run("returns error for resolved invitation", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		invitation := &models.TeamInvitation{
+			TeamID:    primitive.NewObjectID(),
+			Email:     "test@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+		}
+		require.NoError(t, repo.Create(ctx, invitation))
+		require.NoError(t, repo.Resolve(ctx, invitation.ID, models.InvitationStatusAccepted, primitive.NewObjectID()))
+
+		renewed, err := repo.Renew(ctx, invitation.ID, time.Now().Add(24*time.Hour), true)
+
+		assert.Nil(t, renewed)
+		assert.Equal(t, apperrors.ErrInvitationNotFound, err)
+	})
+}
+
+func TestTeamInvitationRepository_MarkExpired(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamInvitationRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("marks only pending invitations past expiry", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		teamID := primitive.NewObjectID()
+
+		valid := &models.TeamInvitation{
+			TeamID:    teamID,
+			Email:     "valid@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+		}
+		require.NoError(t, repo.Create(ctx, valid))
+
+		expired := &models.TeamInvitation{
+			ID:        primitive.NewObjectID(),
+			TeamID:    teamID,
+			Email:     "expired@example.com",
+			Role:      "member",
+			InvitedBy: primitive.NewObjectID(),
+			Status:    models.InvitationStatusPending,
+			CreatedAt: time.Now().Add(-10 * 24 * time.Hour),
+			ExpiresAt: time.Now().Add(-3 * 24 * time.Hour),
+		}
+		_, err := tdb.Database.Collection("team_invitations").InsertOne(ctx, expired)
+		require.NoError(t, err)
+
+		count, err := repo.MarkExpired(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		found, err := repo.FindByID(ctx, expired.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationStatusExpired, found.Status)
+
+		found, err = repo.FindByID(ctx, valid.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationStatusPending, found.Status)
+	})
+}
+
+func TestTeamInvitationRepository_FindHistoryByTeamID(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamInvitationRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("returns invitations in every status with pagination", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_invitations")
+
+		teamID := primitive.NewObjectID()
+		for i := 0; i < 3; i++ {
+			invitation := &models.TeamInvitation{
+				TeamID:    teamID,
+				Email:     "user" + string(rune('a'+i)) + "@example.com",
+				Role:      "member",
+				InvitedBy: primitive.NewObjectID(),
+			}
+			require.NoError(t, repo.Create(ctx, invitation))
+			if i == 0 {
+				require.NoError(t, repo.Resolve(ctx, invitation.ID, models.InvitationStatusDeclined, primitive.NewObjectID()))
+			}
+		}
+
+		invitations, total, err := repo.FindHistoryByTeamID(ctx, teamID, 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Len(t, invitations, 2)
+	})
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TeamRoleRepository defines the interface for custom team role data operations.
+type TeamRoleRepository interface {
+	Create(ctx context.Context, role *models.TeamRole) error
+	FindByTeamID(ctx context.Context, teamID primitive.ObjectID) ([]models.TeamRole, error)
+	FindByTeamAndName(ctx context.Context, teamID primitive.ObjectID, name string) (*models.TeamRole, error)
+	CountByTeamID(ctx context.Context, teamID primitive.ObjectID) (int, error)
+	UpdateActions(ctx context.Context, teamID primitive.ObjectID, name string, actions []string) (*models.TeamRole, error)
+	Delete(ctx context.Context, teamID primitive.ObjectID, name string) error
+	DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) error
+}
+
+// teamRoleRepository implements TeamRoleRepository using MongoDB.
+type teamRoleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewTeamRoleRepository creates a new TeamRoleRepository.
+func NewTeamRoleRepository(db *mongo.Database) TeamRoleRepository {
+	collection := db.Collection("team_roles")
+
+	// Create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "teamId", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &teamRoleRepository{
+		collection: collection,
+	}
+}
+
+// Create inserts a new custom role into the database.
+// Returns ErrTeamRoleExists if the team already has a role with the same name.
+func (r *teamRoleRepository) Create(ctx context.Context, role *models.TeamRole) error {
+	role.ID = primitive.NewObjectID()
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = role.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, role)
+	if mongo.IsDuplicateKeyError(err) {
+		return apperrors.ErrTeamRoleExists
+	}
+	return err
+}
+
+// FindByTeamID returns all custom roles of a team, sorted by name.
+func (r *teamRoleRepository) FindByTeamID(ctx context.Context, teamID primitive.ObjectID) ([]models.TeamRole, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"teamId": teamID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var roles []models.TeamRole
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+
+	if roles == nil {
+		roles = []models.TeamRole{}
+	}
+
+	return roles, nil
+}
+
+// FindByTeamAndName returns a custom role by team and name.
+func (r *teamRoleRepository) FindByTeamAndName(ctx context.Context, teamID primitive.ObjectID, name string) (*models.TeamRole, error) {
+	var role models.TeamRole
+
+	err := r.collection.FindOne(ctx, bson.M{"teamId": teamID, "name": name}).Decode(&role)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrTeamRoleNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// CountByTeamID returns the number of custom roles a team has.
+func (r *teamRoleRepository) CountByTeamID(ctx context.Context, teamID primitive.ObjectID) (int, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"teamId": teamID})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// UpdateActions replaces the actions of a custom role and returns the updated role.
+func (r *teamRoleRepository) UpdateActions(ctx context.Context, teamID primitive.ObjectID, name string, actions []string) (*models.TeamRole, error) {
+	filter := bson.M{"teamId": teamID, "name": name}
+	update := bson.M{
+		"$set": bson.M{
+			"actions":   actions,
+			"updatedAt": time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var role models.TeamRole
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&role)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrTeamRoleNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// Delete removes a custom role from a team.
+func (r *teamRoleRepository) Delete(ctx context.Context, teamID primitive.ObjectID, name string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"teamId": teamID, "name": name})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return apperrors.ErrTeamRoleNotFound
+	}
+
+	return nil
+}
+
+// DeleteAllByTeamID removes all custom roles of a team (used when deleting a team).
+func (r *teamRoleRepository) DeleteAllByTeamID(ctx context.Context, teamID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"teamId": teamID})
+	return err
+}
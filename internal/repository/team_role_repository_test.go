@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewTeamRoleRepository(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamRoleRepository(tdb.Database)
+
+	assert.NotNil(t, repo)
+}
+
+func TestTeamRoleRepository_CreateAndFind(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamRoleRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("finds created role by team and name", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		teamID := primitive.NewObjectID()
+		role := &models.TeamRole{TeamID: teamID, Name: "reviewer", Actions: []string{"team:view", "memo:view"}}
+		require.NoError(t, repo.Create(ctx, role))
+		assert.False(t, role.ID.IsZero())
+		assert.NotZero(t, role.CreatedAt)
+
+		found, err := repo.FindByTeamAndName(ctx, teamID, "reviewer")
+
+		require.NoError(t, err)
+		assert.Equal(t, role.ID, found.ID)
+		assert.Equal(t, []string{"team:view", "memo:view"}, found.Actions)
+	})
+
+	t.Run("rejects duplicate name in the same team", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		teamID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "reviewer"}))
+
+		err := repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "reviewer"})
+		assert.ErrorIs(t, err, apperrors.ErrTeamRoleExists)
+
+		// The same name is fine in another team
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: primitive.NewObjectID(), Name: "reviewer"}))
+	})
+
+	t.Run("returns error for unknown role", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		_, err := repo.FindByTeamAndName(ctx, primitive.NewObjectID(), "missing")
+
+		assert.ErrorIs(t, err, apperrors.ErrTeamRoleNotFound)
+	})
+}
+
+func TestTeamRoleRepository_FindByTeamID(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamRoleRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("returns the team's roles sorted by name", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		teamID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "tester"}))
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "reviewer"}))
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: primitive.NewObjectID(), Name: "other"}))
+
+		roles, err := repo.FindByTeamID(ctx, teamID)
+		require.NoError(t, err)
+		require.Len(t, roles, 2)
+		assert.Equal(t, "reviewer", roles[0].Name)
+		assert.Equal(t, "tester", roles[1].Name)
+
+		count, err := repo.CountByTeamID(ctx, teamID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("returns empty slice when team has no roles", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		roles, err := repo.FindByTeamID(ctx, primitive.NewObjectID())
+
+		require.NoError(t, err)
+		assert.NotNil(t, roles)
+		assert.Empty(t, roles)
+	})
+}
+
+func TestTeamRoleRepository_UpdateActions(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamRoleRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("replaces actions", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		teamID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "reviewer", Actions: []string{"team:view"}}))
+
+		updated, err := repo.UpdateActions(ctx, teamID, "reviewer", []string{"team:view", "memo:view"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team:view", "memo:view"}, updated.Actions)
+	})
+
+	t.Run("returns error for unknown role", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		_, err := repo.UpdateActions(ctx, primitive.NewObjectID(), "missing", []string{"team:view"})
+
+		assert.ErrorIs(t, err, apperrors.ErrTeamRoleNotFound)
+	})
+}
+
+func TestTeamRoleRepository_Delete(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamRoleRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("deletes role", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		teamID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "reviewer"}))
+
+		require.NoError(t, repo.Delete(ctx, teamID, "reviewer"))
+
+		_, err := repo.FindByTeamAndName(ctx, teamID, "reviewer")
+		assert.ErrorIs(t, err, apperrors.ErrTeamRoleNotFound)
+	})
+
+	t.Run("returns error for unknown role", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		err := repo.Delete(ctx, primitive.NewObjectID(), "missing")
+
+		assert.ErrorIs(t, err, apperrors.ErrTeamRoleNotFound)
+	})
+}
+
+func TestTeamRoleRepository_DeleteAllByTeamID(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewTeamRoleRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("deletes all roles of team", func(t *testing.T) {
+		tdb.ClearCollection(t, "team_roles")
+
+		teamID := primitive.NewObjectID()
+		otherTeamID := primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "reviewer"}))
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: teamID, Name: "editor"}))
+		require.NoError(t, repo.Create(ctx, &models.TeamRole{TeamID: otherTeamID, Name: "reviewer"}))
+
+		require.NoError(t, repo.DeleteAllByTeamID(ctx, teamID))
+
+		roles, err := repo.FindByTeamID(ctx, teamID)
+		require.NoError(t, err)
+		assert.Empty(t, roles)
+
+		otherRoles, err := repo.FindByTeamID(ctx, otherTeamID)
+		require.NoError(t, err)
+		assert.Len(t, otherRoles, 1)
+	})
+}
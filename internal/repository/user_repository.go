@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	apperrors "gin-sample/internal/errors"
@@ -19,9 +20,17 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	FindByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByIdentity(ctx context.Context, provider, subject string) (*models.User, error)
 	FindAll(ctx context.Context) ([]models.User, error)
 	Update(ctx context.Context, id primitive.ObjectID, update *models.UpdateUserRequest) (*models.User, error)
 	Delete(ctx context.Context, id primitive.ObjectID) error
+	UpdatePassword(ctx context.Context, id primitive.ObjectID, hashedPassword string) error
+	SetMFAPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) error
+	EnableMFA(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodeHashes []string) error
+	DisableMFA(ctx context.Context, id primitive.ObjectID) error
+	SetRecoveryCodes(ctx context.Context, id primitive.ObjectID, recoveryCodeHashes []string) error
+	ConsumeRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error)
+	AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.ExternalIdentity) error
 }
 
 // userRepository implements UserRepository using MongoDB
@@ -44,6 +53,10 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 		return apperrors.ErrUserAlreadyExists
 	}
 
+	for i := range user.Identities {
+		user.Identities[i].Key = models.IdentityKey(user.Identities[i].Provider, user.Identities[i].Subject)
+	}
+
 	// Set timestamps
 	now := time.Now()
 	user.CreatedAt = now
@@ -51,6 +64,9 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 
 	// Insert into database
 	result, err := r.collection.InsertOne(ctx, user)
+	if isIdentityKeyConflict(err) {
+		return apperrors.ErrIdentityAlreadyLinked
+	}
 	if err != nil {
 		return err
 	}
@@ -90,6 +106,22 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models
 	return &user, nil
 }
 
+// FindByIdentity finds the user linked to an external identity
+func (r *userRepository) FindByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	var user models.User
+
+	filter := bson.M{"identities.key": models.IdentityKey(provider, subject)}
+	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 // FindAll returns all users
 func (r *userRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{})
@@ -161,3 +193,131 @@ func (r *userRepository) Delete(ctx context.Context, id primitive.ObjectID) erro
 
 	return nil
 }
+
+// SetMFAPendingSecret stores a TOTP secret that has not been confirmed yet.
+// Starting enrollment again replaces the previous pending secret.
+func (r *userRepository) SetMFAPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"mfaPendingSecret": secret,
+			"updatedAt":        time.Now(),
+		},
+	}
+
+	return r.updateOne(ctx, id, update)
+}
+
+// EnableMFA activates MFA with the confirmed secret and recovery code hashes,
+// clearing the pending secret.
+func (r *userRepository) EnableMFA(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodeHashes []string) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"mfaEnabled":       true,
+			"mfaEnabledAt":     now,
+			"mfaSecret":        secret,
+			"mfaRecoveryCodes": recoveryCodeHashes,
+			"updatedAt":        now,
+		},
+		"$unset": bson.M{"mfaPendingSecret": ""},
+	}
+
+	return r.updateOne(ctx, id, update)
+}
+
+// DisableMFA turns MFA off and removes the secret and recovery codes
+func (r *userRepository) DisableMFA(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"mfaEnabled": false,
+			"updatedAt":  time.Now(),
+		},
+		"$unset": bson.M{
+			"mfaEnabledAt":     "",
+			"mfaSecret":        "",
+			"mfaPendingSecret": "",
+			"mfaRecoveryCodes": "",
+		},
+	}
+
+	return r.updateOne(ctx, id, update)
+}
+
+// UpdatePassword replaces a user's password hash.
+func (r *userRepository) UpdatePassword(ctx context.Context, id primitive.ObjectID, hashedPassword string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"password":  hashedPassword,
+			"updatedAt": time.Now(),
+		},
+	}
+
+	return r.updateOne(ctx, id, update)
+}
+
+// SetRecoveryCodes replaces all recovery code hashes
+func (r *userRepository) SetRecoveryCodes(ctx context.Context, id primitive.ObjectID, recoveryCodeHashes []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"mfaRecoveryCodes": recoveryCodeHashes,
+			"updatedAt":        time.Now(),
+		},
+	}
+
+	return r.updateOne(ctx, id, update)
+}
+
+// ConsumeRecoveryCode atomically removes a recovery code hash so it cannot be used twice.
+// Returns false if the user has no matching unused code.
+func (r *userRepository) ConsumeRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	filter := bson.M{
+		"_id":              id,
+		"mfaRecoveryCodes": codeHash,
+	}
+	update := bson.M{
+		"$pull": bson.M{"mfaRecoveryCodes": codeHash},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// AddIdentity links an external identity to a user.
+// Returns ErrIdentityAlreadyLinked if the identity is linked to another user.
+func (r *userRepository) AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.ExternalIdentity) error {
+	identity.Key = models.IdentityKey(identity.Provider, identity.Subject)
+	update := bson.M{
+		"$push": bson.M{"identities": identity},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	err := r.updateOne(ctx, id, update)
+	if isIdentityKeyConflict(err) {
+		return apperrors.ErrIdentityAlreadyLinked
+	}
+	return err
+}
+
+// isIdentityKeyConflict reports whether err is a duplicate key error on the identities.key index
+func isIdentityKeyConflict(err error) bool {
+	return mongo.IsDuplicateKeyError(err) && strings.Contains(err.Error(), "identities.key")
+}
+
+// updateOne applies an update to a single user, returning ErrUserNotFound if no user matched
+func (r *userRepository) updateOne(ctx context.Context, id primitive.ObjectID, update bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apperrors.ErrUserNotFound
+	}
+
+	return nil
+}
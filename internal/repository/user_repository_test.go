@@ -3,13 +3,17 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestNewUserRepository(t *testing.T) {
@@ -301,3 +305,218 @@ func TestUserRepository_Delete(t *testing.T) {
 		assert.Equal(t, apperrors.ErrUserNotFound, err)
 	})
 }
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewUserRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("updates password of existing user", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		user := &models.User{
+			Email:    "password@example.com",
+			Password: "oldhash",
+			Name:     "Password User",
+		}
+		err := repo.Create(ctx, user)
+		require.NoError(t, err)
+
+		err = repo.UpdatePassword(ctx, user.ID, "newhash")
+
+		require.NoError(t, err)
+
+		found, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "newhash", found.Password)
+	})
+
+	t.Run("returns error for non-existent user", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		err := repo.UpdatePassword(ctx, primitive.NewObjectID(), "newhash")
+
+		assert.Equal(t, apperrors.ErrUserNotFound, err)
+	})
+}
+
+func TestUserRepository_Identities(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewUserRepository(tdb.Database)
+	ctx := context.Background()
+
+	// Mirrors the identities.key index created by cmd/index
+	_, err := tdb.Database.Collection("users").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "identities.key", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+			"identities.key": bson.M{"$exists": true},
+		}),
+	})
+	require.NoError(t, err)
+
+	t.Run("finds user by linked identity", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		user := &models.User{Email: "linked@example.com", Name: "Linked User"}
+		require.NoError(t, repo.Create(ctx, user))
+
+		err := repo.AddIdentity(ctx, user.ID, models.ExternalIdentity{
+			Provider: "google",
+			Subject:  "sub-123",
+			Email:    "linked@example.com",
+			LinkedAt: time.Now(),
+		})
+		require.NoError(t, err)
+
+		found, err := repo.FindByIdentity(ctx, "google", "sub-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+		require.Len(t, found.Identities, 1)
+		assert.Equal(t, "google", found.Identities[0].Provider)
+	})
+
+	t.Run("does not match subject from another provider", func(t *testing.T) {
+		_, err := repo.FindByIdentity(ctx, "acme", "sub-123")
+
+		assert.Equal(t, apperrors.ErrUserNotFound, err)
+	})
+
+	t.Run("rejects an identity linked to another user", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		first := &models.User{Email: "first@example.com", Name: "First User"}
+		second := &models.User{Email: "second@example.com", Name: "Second User"}
+		require.NoError(t, repo.Create(ctx, first))
+		require.NoError(t, repo.Create(ctx, second))
+		require.NoError(t, repo.AddIdentity(ctx, first.ID, models.ExternalIdentity{Provider: "google", Subject: "sub-123"}))
+
+		err := repo.AddIdentity(ctx, second.ID, models.ExternalIdentity{Provider: "google", Subject: "sub-123"})
+
+		assert.Equal(t, apperrors.ErrIdentityAlreadyLinked, err)
+	})
+
+	t.Run("rejects a new user with an identity linked to another user", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		first := &models.User{Email: "first@example.com", Name: "First User"}
+		require.NoError(t, repo.Create(ctx, first))
+		require.NoError(t, repo.AddIdentity(ctx, first.ID, models.ExternalIdentity{Provider: "google", Subject: "sub-123"}))
+
+		err := repo.Create(ctx, &models.User{
+			Email:      "second@example.com",
+			Name:       "Second User",
+			Identities: []models.ExternalIdentity{{Provider: "google", Subject: "sub-123"}},
+		})
+
+		assert.Equal(t, apperrors.ErrIdentityAlreadyLinked, err)
+	})
+
+	t.Run("allows subjects that only collide across providers", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		first := &models.User{Email: "first@example.com", Name: "First User"}
+		second := &models.User{Email: "second@example.com", Name: "Second User"}
+		require.NoError(t, repo.Create(ctx, first))
+		require.NoError(t, repo.Create(ctx, second))
+		require.NoError(t, repo.AddIdentity(ctx, first.ID, models.ExternalIdentity{Provider: "google", Subject: "sub-1"}))
+		require.NoError(t, repo.AddIdentity(ctx, first.ID, models.ExternalIdentity{Provider: "acme", Subject: "sub-2"}))
+
+		err := repo.AddIdentity(ctx, second.ID, models.ExternalIdentity{Provider: "google", Subject: "sub-2"})
+
+		require.NoError(t, err)
+		found, err := repo.FindByIdentity(ctx, "google", "sub-2")
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, found.ID)
+	})
+
+	t.Run("sets identity keys of users created with identities", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		user := &models.User{
+			Email:      "created@example.com",
+			Name:       "Created User",
+			Identities: []models.ExternalIdentity{{Provider: "google", Subject: "sub-789"}},
+		}
+		require.NoError(t, repo.Create(ctx, user))
+
+		found, err := repo.FindByIdentity(ctx, "google", "sub-789")
+
+		require.NoError(t, err)
+		assert.Equal(t, "google|sub-789", found.Identities[0].Key)
+	})
+
+	t.Run("returns error for non-existent user", func(t *testing.T) {
+		err := repo.AddIdentity(ctx, primitive.NewObjectID(), models.ExternalIdentity{Provider: "google", Subject: "sub-456"})
+
+		assert.Equal(t, apperrors.ErrUserNotFound, err)
+	})
+}
+
+func TestUserRepository_MFA(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewUserRepository(tdb.Database)
+	ctx := context.Background()
+
+	t.Run("enables mfa from pending secret", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		user := &models.User{Email: "mfa@example.com", Password: "hashedpassword", Name: "MFA User"}
+		require.NoError(t, repo.Create(ctx, user))
+
+		require.NoError(t, repo.SetMFAPendingSecret(ctx, user.ID, "PENDING"))
+		require.NoError(t, repo.EnableMFA(ctx, user.ID, "PENDING", []string{"hash1", "hash2"}))
+
+		found, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, found.MFAEnabled)
+		assert.NotNil(t, found.MFAEnabledAt)
+		assert.Equal(t, "PENDING", found.MFASecret)
+		assert.Empty(t, found.MFAPendingSecret)
+		assert.Equal(t, []string{"hash1", "hash2"}, found.MFARecoveryCodes)
+	})
+
+	t.Run("consumes recovery code once", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		user := &models.User{Email: "recovery@example.com", Password: "hashedpassword", Name: "Recovery User"}
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, repo.EnableMFA(ctx, user.ID, "SECRET", []string{"hash1"}))
+
+		consumed, err := repo.ConsumeRecoveryCode(ctx, user.ID, "hash1")
+		require.NoError(t, err)
+		assert.True(t, consumed)
+
+		consumed, err = repo.ConsumeRecoveryCode(ctx, user.ID, "hash1")
+		require.NoError(t, err)
+		assert.False(t, consumed)
+	})
+
+	t.Run("disables mfa", func(t *testing.T) {
+		tdb.ClearCollection(t, "users")
+
+		user := &models.User{Email: "disable@example.com", Password: "hashedpassword", Name: "Disable User"}
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, repo.EnableMFA(ctx, user.ID, "SECRET", []string{"hash1"}))
+
+		require.NoError(t, repo.DisableMFA(ctx, user.ID))
+
+		found, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, found.MFAEnabled)
+		assert.Empty(t, found.MFASecret)
+		assert.Empty(t, found.MFARecoveryCodes)
+	})
+
+	t.Run("returns error for non-existent user", func(t *testing.T) {
+		err := repo.DisableMFA(ctx, primitive.NewObjectID())
+
+		assert.Equal(t, apperrors.ErrUserNotFound, err)
+	})
+}
@@ -30,6 +30,8 @@ type VoiceMemoRepository interface {
 	SoftDeleteWithOwnership(ctx context.Context, id, userID primitive.ObjectID) error
 	SoftDeleteWithTeam(ctx context.Context, id, teamID primitive.ObjectID) error
 	SoftDeleteByTeamID(ctx context.Context, teamID primitive.ObjectID) error
+	GetUserUsage(ctx context.Context, userID primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error)
+	GetTeamUsage(ctx context.Context, teamID primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error)
 }
 
 // voiceMemoRepository implements VoiceMemoRepository using MongoDB.
@@ -110,6 +112,7 @@ func (r *voiceMemoRepository) UpdateStatusConditional(ctx context.Context, id pr
 }
 
 // UpdateStatusWithOwnership atomically updates status if the user owns the memo and it's in the expected state.
+// Moving the memo to transcribing records when transcription started, for usage quotas.
 // Returns the updated memo on success to avoid a separate FindByID call.
 // Returns ErrVoiceMemoNotFound if memo doesn't exist.
 // Returns ErrVoiceMemoUnauthorized if memo exists but user doesn't own it.
@@ -123,11 +126,15 @@ func (r *voiceMemoRepository) UpdateStatusWithOwnership(ctx context.Context, id,
 		"deletedAt": bson.M{"$exists": false},
 	}
 
+	set := bson.M{
+		"status":    toStatus,
+		"updatedAt": now,
+	}
+	if toStatus == models.StatusTranscribing {
+		set["transcriptionStartedAt"] = now
+	}
 	update := bson.M{
-		"$set": bson.M{
-			"status":    toStatus,
-			"updatedAt": now,
-		},
+		"$set": set,
 		"$inc": bson.M{"version": 1},
 	}
 
@@ -169,6 +176,7 @@ func (r *voiceMemoRepository) UpdateStatusWithOwnership(ctx context.Context, id,
 }
 
 // UpdateStatusWithTeam atomically updates status if the memo belongs to the team and is in the expected state.
+// Moving the memo to transcribing records when transcription started, for usage quotas.
 // Returns the updated memo on success to avoid a separate FindByID call.
 // Returns ErrVoiceMemoNotFound if memo doesn't exist or doesn't belong to team.
 // Returns ErrVoiceMemoInvalidStatus if memo is not in the expected fromStatus.
@@ -181,11 +189,15 @@ func (r *voiceMemoRepository) UpdateStatusWithTeam(ctx context.Context, id, team
 		"deletedAt": bson.M{"$exists": false},
 	}
 
+	set := bson.M{
+		"status":    toStatus,
+		"updatedAt": now,
+	}
+	if toStatus == models.StatusTranscribing {
+		set["transcriptionStartedAt"] = now
+	}
 	update := bson.M{
-		"$set": bson.M{
-			"status":    toStatus,
-			"updatedAt": now,
-		},
+		"$set": set,
 		"$inc": bson.M{"version": 1},
 	}
 
@@ -524,3 +536,64 @@ func (r *voiceMemoRepository) SoftDeleteByTeamID(ctx context.Context, teamID pri
 	_, err := r.collection.UpdateMany(ctx, filter, update)
 	return err
 }
+
+// GetUserUsage returns usage of a user's private memos.
+// Transcription seconds only count memos submitted for transcription since periodStart.
+func (r *voiceMemoRepository) GetUserUsage(ctx context.Context, userID primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error) {
+	return r.usage(ctx, bson.M{
+		"userId": userID,
+		"teamId": bson.M{"$exists": false},
+	}, periodStart)
+}
+
+// GetTeamUsage returns usage of a team's memos.
+// Transcription seconds only count memos submitted for transcription since periodStart.
+func (r *voiceMemoRepository) GetTeamUsage(ctx context.Context, teamID primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error) {
+	return r.usage(ctx, bson.M{"teamId": teamID}, periodStart)
+}
+
+// usage aggregates memo count and audio bytes of non-deleted memos, and the duration of
+// memos submitted for transcription since periodStart (including deleted ones, since
+// their transcription was already consumed).
+func (r *voiceMemoRepository) usage(ctx context.Context, scope bson.M, periodStart time.Time) (*models.VoiceMemoUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: scope}},
+		{{Key: "$group", Value: bson.M{
+			"_id": nil,
+			"memoCount": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$type": "$deletedAt"}, "missing"}}, 1, 0},
+			}},
+			"audioBytes": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$type": "$deletedAt"}, "missing"}}, "$fileSize", 0},
+			}},
+			"transcriptionSeconds": bson.M{"$sum": bson.M{
+				"$cond": bson.A{
+					bson.M{"$and": bson.A{
+						bson.M{"$gte": bson.A{"$transcriptionStartedAt", periodStart}},
+						bson.M{"$ne": bson.A{"$status", models.StatusPendingUpload}},
+					}},
+					"$duration",
+					0,
+				},
+			}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	usage := &models.VoiceMemoUsage{}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(usage); err != nil {
+			return nil, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
@@ -847,3 +848,91 @@ func TestVoiceMemoRepository_SoftDeleteByTeamID(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestVoiceMemoRepository_GetUsage(t *testing.T) {
+	tdb := SetupTestDB(t)
+	defer tdb.Cleanup(t)
+
+	repo := NewVoiceMemoRepository(tdb.Database)
+	ctx := context.Background()
+
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	periodStart := time.Now().Add(-time.Hour)
+
+	seed := func(t *testing.T) {
+		tdb.ClearCollection(t, "voice_memos")
+
+		startedAt := time.Now()
+		memos := []*models.VoiceMemo{
+			{UserID: userID, Title: "Private", AudioFileKey: "a.mp3", Status: models.StatusReady, Duration: 60, FileSize: 1000, TranscriptionStartedAt: &startedAt},
+			{UserID: userID, Title: "Pending", AudioFileKey: "b.mp3", Status: models.StatusPendingUpload, Duration: 30, FileSize: 500},
+			{UserID: userID, TeamID: &teamID, Title: "Team", AudioFileKey: "c.mp3", Status: models.StatusReady, Duration: 120, FileSize: 2000, TranscriptionStartedAt: &startedAt},
+		}
+		for _, memo := range memos {
+			require.NoError(t, repo.Create(ctx, memo))
+		}
+
+		deleted := &models.VoiceMemo{UserID: userID, Title: "Deleted", AudioFileKey: "d.mp3", Status: models.StatusReady, Duration: 90, FileSize: 4000, TranscriptionStartedAt: &startedAt}
+		require.NoError(t, repo.Create(ctx, deleted))
+		require.NoError(t, repo.SoftDeleteByID(ctx, deleted.ID))
+	}
+
+	t.Run("user usage excludes team memos and counts deleted transcriptions", func(t *testing.T) {
+		seed(t)
+
+		usage, err := repo.GetUserUsage(ctx, userID, periodStart)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), usage.MemoCount)
+		assert.Equal(t, int64(1500), usage.AudioBytes)
+		assert.Equal(t, int64(150), usage.TranscriptionSeconds)
+	})
+
+	t.Run("team usage only counts team memos", func(t *testing.T) {
+		seed(t)
+
+		usage, err := repo.GetTeamUsage(ctx, teamID, periodStart)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage.MemoCount)
+		assert.Equal(t, int64(2000), usage.AudioBytes)
+		assert.Equal(t, int64(120), usage.TranscriptionSeconds)
+	})
+
+	t.Run("transcriptions before period start are not counted", func(t *testing.T) {
+		seed(t)
+
+		usage, err := repo.GetUserUsage(ctx, userID, time.Now().Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), usage.MemoCount)
+		assert.Equal(t, int64(0), usage.TranscriptionSeconds)
+	})
+
+	t.Run("counts memos created before the period and confirmed during it", func(t *testing.T) {
+		tdb.ClearCollection(t, "voice_memos")
+
+		memo := &models.VoiceMemo{UserID: userID, Title: "Late", AudioFileKey: "e.mp3", Status: models.StatusPendingUpload, Duration: 45, FileSize: 700}
+		require.NoError(t, repo.Create(ctx, memo))
+		time.Sleep(10 * time.Millisecond)
+		confirmedPeriodStart := time.Now()
+
+		_, err := repo.UpdateStatusWithOwnership(ctx, memo.ID, userID, models.StatusPendingUpload, models.StatusTranscribing)
+		require.NoError(t, err)
+
+		usage, err := repo.GetUserUsage(ctx, userID, confirmedPeriodStart)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(45), usage.TranscriptionSeconds)
+	})
+
+	t.Run("returns zero usage when no memos exist", func(t *testing.T) {
+		tdb.ClearCollection(t, "voice_memos")
+
+		usage, err := repo.GetTeamUsage(ctx, primitive.NewObjectID(), periodStart)
+
+		require.NoError(t, err)
+		assert.Equal(t, &models.VoiceMemoUsage{}, usage)
+	})
+}
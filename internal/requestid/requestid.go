@@ -0,0 +1,48 @@
+// Package requestid carries the ID of the HTTP request being served through a context.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header is the HTTP header that carries the request ID.
+const Header = "X-Request-ID"
+
+// maxLength is the longest client-supplied request ID that is accepted.
+const maxLength = 128
+
+type contextKey struct{}
+
+// New generates a random request ID.
+func New() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes) // never returns an error
+	return hex.EncodeToString(bytes)
+}
+
+// Valid reports whether a client-supplied request ID can be used as is.
+// IDs must be non-empty, at most 128 characters and printable ASCII, so they are safe to log.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or empty string if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -2,13 +2,18 @@
 package router
 
 import (
+	"log/slog"
 	"net/http"
 
 	_ "gin-sample/swagger" // Import generated swagger docs
 
 	"gin-sample/internal/authz"
+	"gin-sample/internal/cache"
 	"gin-sample/internal/handler"
+	"gin-sample/internal/logger"
+	"gin-sample/internal/metrics"
 	"gin-sample/internal/middleware"
+	"gin-sample/internal/ratelimit"
 	"gin-sample/pkg/auth"
 
 	"github.com/gin-gonic/gin"
@@ -19,29 +24,110 @@ import (
 // Config holds all dependencies needed to set up routes.
 type Config struct {
 	AuthHandler       *handler.AuthHandler
+	MFAHandler        *handler.MFAHandler
 	UserHandler       *handler.UserHandler
 	VoiceMemoHandler  *handler.VoiceMemoHandler
 	TeamHandler       *handler.TeamHandler
 	TeamMemberHandler *handler.TeamMemberHandler
+	TeamRoleHandler   *handler.TeamRoleHandler
+	AuditLogHandler   *handler.AuditLogHandler
 	InvitationHandler *handler.TeamInvitationHandler
+	APIKeyHandler     *handler.APIKeyHandler
+	HealthHandler     *handler.HealthHandler
 	JWTManager        *auth.JWTManager
 	Authorizer        authz.Authorizer
+	// TokenRevocation rejects revoked access tokens. Nil disables the check.
+	TokenRevocation cache.TokenRevocationStore
+	// TokenRevocationFailOpen accepts tokens when TokenRevocation fails instead of rejecting them.
+	TokenRevocationFailOpen bool
+	// APIKeys authenticates personal API keys. Nil disables API keys.
+	APIKeys middleware.APIKeyAuthenticator
+	// RateLimiter limits public auth endpoints. Nil disables rate limiting.
+	RateLimiter ratelimit.Limiter
+	// AuthRateLimits returns the current limits, read on every request so they can be reloaded.
+	// Nil disables the limits.
+	AuthRateLimits func() AuthRateLimits
+	// Hardening sets the security headers and request body limit. The docs path is set by Setup.
+	Hardening middleware.HardeningConfig
+	// Swagger serves the API docs at /docs.
+	Swagger bool
+	// CORS returns the current CORS policy, read on every request so it can be reloaded.
+	// Nil sends no CORS headers.
+	CORS func() *middleware.CORSConfig
+	// TrustedProxies are the proxies allowed to set the client IP via X-Forwarded-For.
+	TrustedProxies []string
+	// ProblemJSONErrors sends all error responses as application/problem+json.
+	ProblemJSONErrors bool
+	// Metrics records request latencies and is served at /metrics. Nil disables metrics.
+	Metrics *metrics.Metrics
+	// TracingServiceName names the server in request spans. Empty disables request tracing.
+	TracingServiceName string
+}
+
+// AuthRateLimits holds the request limits for public auth endpoints.
+// A limit with zero requests is not enforced.
+type AuthRateLimits struct {
+	LoginPerIP      ratelimit.Limit
+	LoginPerAccount ratelimit.Limit
+	RegisterPerIP   ratelimit.Limit
+	RefreshPerIP    ratelimit.Limit
 }
 
 // Setup creates and configures the Gin router.
 func Setup(cfg *Config) *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		logger.Fatal("invalid trusted proxies", "error", err)
+	}
 
-	// Global middleware
-	r.Use(middleware.CORS())
+	hardening := cfg.Hardening
+	hardening.DocsPath = "/docs/"
+
+	// Global middleware. RequestID runs first so request logs and errors carry the ID,
+	// followed by tracing so request logs carry the trace ID;
+	// Logger and Metrics run before ErrorHandler so they see the final status code.
+	r.Use(middleware.RequestID())
+	if cfg.TracingServiceName != "" {
+		r.Use(middleware.Tracing(cfg.TracingServiceName))
+	}
+	r.Use(
+		middleware.Logger(slog.Default()),
+		middleware.Metrics(cfg.Metrics),
+		gin.Recovery(),
+		middleware.ErrorHandler(cfg.ProblemJSONErrors),
+		middleware.Hardening(hardening),
+	)
+	if cfg.CORS != nil {
+		r.Use(middleware.CORS(cfg.CORS))
+	}
+
+	// Prometheus metrics
+	if cfg.Metrics != nil {
+		r.GET("/metrics", gin.WrapH(cfg.Metrics.Handler()))
+	}
 
 	// Swagger docs at /docs
-	r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	if cfg.Swagger {
+		r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
 
-	// Health check
+	// Health check (process only; see /livez and /readyz for component status)
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
+	r.GET("/livez", cfg.HealthHandler.Live)
+	r.GET("/readyz", cfg.HealthHandler.Ready)
+
+	// Public keys for verifying access tokens (empty when tokens are signed with HS256)
+	r.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, cfg.JWTManager.JWKS())
+	})
+
+	// API keys and scoped access tokens are limited to the actions in their scopes,
+	// checked by RequireScope and TeamAuthz. Other routes require full access.
+	requireAuth := middleware.Auth(cfg.JWTManager, cfg.TokenRevocation, cfg.TokenRevocationFailOpen, cfg.APIKeys)
+	fullAccess := middleware.RequireFullAccess()
 
 	// API v1
 	v1 := r.Group("/api/v1")
@@ -49,22 +135,61 @@ func Setup(cfg *Config) *gin.Engine {
 		// Auth routes (public)
 		authRoutes := v1.Group("/auth")
 		{
-			authRoutes.POST("/register", cfg.AuthHandler.Register)
-			authRoutes.POST("/login", cfg.AuthHandler.Login)
-			authRoutes.POST("/refresh", cfg.AuthHandler.Refresh)
+			registerPerIP := func(l AuthRateLimits) ratelimit.Limit { return l.RegisterPerIP }
+			loginPerIP := func(l AuthRateLimits) ratelimit.Limit { return l.LoginPerIP }
+			loginPerAccount := func(l AuthRateLimits) ratelimit.Limit { return l.LoginPerAccount }
+			refreshPerIP := func(l AuthRateLimits) ratelimit.Limit { return l.RefreshPerIP }
+			authRoutes.POST("/register",
+				rateLimit(cfg, "register:ip", registerPerIP, middleware.ByClientIP),
+				cfg.AuthHandler.Register)
+			authRoutes.POST("/login",
+				rateLimit(cfg, "login:ip", loginPerIP, middleware.ByClientIP),
+				rateLimit(cfg, "login:account", loginPerAccount, middleware.ByJSONField("email")),
+				cfg.AuthHandler.Login)
+			authRoutes.POST("/refresh",
+				rateLimit(cfg, "refresh:ip", refreshPerIP, middleware.ByClientIP),
+				cfg.AuthHandler.Refresh)
+			authRoutes.POST("/mfa/verify",
+				rateLimit(cfg, "login:ip", loginPerIP, middleware.ByClientIP),
+				cfg.AuthHandler.VerifyMFA)
+
+			// OpenID Connect login
+			authRoutes.GET("/oidc", cfg.AuthHandler.ListOIDCProviders)
+			authRoutes.GET("/oidc/:provider/authorize",
+				rateLimit(cfg, "login:ip", loginPerIP, middleware.ByClientIP),
+				cfg.AuthHandler.StartOIDCLogin)
+			authRoutes.POST("/oidc/:provider/callback",
+				rateLimit(cfg, "login:ip", loginPerIP, middleware.ByClientIP),
+				cfg.AuthHandler.CompleteOIDCLogin)
 		}
 
 		// Auth routes (protected)
 		authProtected := v1.Group("/auth")
-		authProtected.Use(middleware.Auth(cfg.JWTManager))
+		authProtected.Use(requireAuth, fullAccess)
 		{
 			authProtected.POST("/logout", cfg.AuthHandler.Logout)
 			authProtected.POST("/logout-all", cfg.AuthHandler.LogoutAll)
+			authProtected.PUT("/password", cfg.AuthHandler.ChangePassword)
+			authProtected.POST("/tokens", cfg.AuthHandler.CreateScopedToken)
+			authProtected.GET("/sessions", cfg.AuthHandler.ListSessions)
+			authProtected.DELETE("/sessions/:id", cfg.AuthHandler.RevokeSession)
+
+			// MFA enrollment
+			authProtected.GET("/mfa", cfg.MFAHandler.GetStatus)
+			authProtected.POST("/mfa/enroll", cfg.MFAHandler.Enroll)
+			authProtected.POST("/mfa/activate", cfg.MFAHandler.Activate)
+			authProtected.POST("/mfa/disable", cfg.MFAHandler.Disable)
+			authProtected.POST("/mfa/recovery-codes", cfg.MFAHandler.RegenerateRecoveryCodes)
+
+			// Personal API keys
+			authProtected.POST("/api-keys", cfg.APIKeyHandler.CreateAPIKey)
+			authProtected.GET("/api-keys", cfg.APIKeyHandler.ListAPIKeys)
+			authProtected.DELETE("/api-keys/:id", cfg.APIKeyHandler.RevokeAPIKey)
 		}
 
 		// User routes (protected)
 		users := v1.Group("/users")
-		users.Use(middleware.Auth(cfg.JWTManager))
+		users.Use(requireAuth, fullAccess)
 		{
 			users.GET("", cfg.UserHandler.GetAllUsers)
 			users.GET("/:id", cfg.UserHandler.GetUser)
@@ -74,22 +199,22 @@ func Setup(cfg *Config) *gin.Engine {
 
 		// Private voice memo routes (protected)
 		voiceMemos := v1.Group("/voice-memos")
-		voiceMemos.Use(middleware.Auth(cfg.JWTManager))
+		voiceMemos.Use(requireAuth)
 		{
-			voiceMemos.GET("", cfg.VoiceMemoHandler.ListVoiceMemos)
-			voiceMemos.POST("", cfg.VoiceMemoHandler.CreateVoiceMemo)
-			voiceMemos.DELETE("/:id", cfg.VoiceMemoHandler.DeleteVoiceMemo)
-			voiceMemos.POST("/:id/confirm-upload", cfg.VoiceMemoHandler.ConfirmUpload)
-			voiceMemos.POST("/:id/retry-transcription", cfg.VoiceMemoHandler.RetryTranscription)
+			voiceMemos.GET("", middleware.RequireScope(authz.ActionMemoView), cfg.VoiceMemoHandler.ListVoiceMemos)
+			voiceMemos.POST("", middleware.RequireScope(authz.ActionMemoCreate), cfg.VoiceMemoHandler.CreateVoiceMemo)
+			voiceMemos.DELETE("/:id", middleware.RequireScope(authz.ActionMemoDelete), cfg.VoiceMemoHandler.DeleteVoiceMemo)
+			voiceMemos.POST("/:id/confirm-upload", middleware.RequireScope(authz.ActionMemoUpdate), cfg.VoiceMemoHandler.ConfirmUpload)
+			voiceMemos.POST("/:id/retry-transcription", middleware.RequireScope(authz.ActionMemoUpdate), cfg.VoiceMemoHandler.RetryTranscription)
 		}
 
 		// Team routes (protected)
 		teams := v1.Group("/teams")
-		teams.Use(middleware.Auth(cfg.JWTManager))
+		teams.Use(requireAuth)
 		{
 			// Team CRUD
-			teams.POST("", cfg.TeamHandler.CreateTeam)
-			teams.GET("", cfg.TeamHandler.ListTeams)
+			teams.POST("", fullAccess, cfg.TeamHandler.CreateTeam)
+			teams.GET("", fullAccess, cfg.TeamHandler.ListTeams)
 
 			// Team routes requiring team membership
 			teamWithID := teams.Group("/:teamId")
@@ -99,6 +224,8 @@ func Setup(cfg *Config) *gin.Engine {
 				teamWithID.PUT("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionTeamUpdate), cfg.TeamHandler.UpdateTeam)
 				teamWithID.DELETE("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionTeamDelete), cfg.TeamHandler.DeleteTeam)
 				teamWithID.POST("/transfer", middleware.TeamAuthz(cfg.Authorizer, authz.ActionTeamTransfer), cfg.TeamHandler.TransferOwnership)
+				teamWithID.GET("/usage", middleware.TeamAuthz(cfg.Authorizer, authz.ActionTeamView), cfg.VoiceMemoHandler.GetTeamUsage)
+				teamWithID.GET("/audit-log", middleware.TeamAuthz(cfg.Authorizer, authz.ActionAuditView), cfg.AuditLogHandler.ListTeamAuditLog)
 
 				// Team members
 				members := teamWithID.Group("/members")
@@ -107,14 +234,26 @@ func Setup(cfg *Config) *gin.Engine {
 					members.DELETE("/:userId", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberRemove), cfg.TeamMemberHandler.RemoveMember)
 					members.PUT("/:userId/role", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberUpdateRole), cfg.TeamMemberHandler.UpdateRole)
 				}
-				teamWithID.POST("/leave", middleware.TeamMember(cfg.Authorizer), cfg.TeamMemberHandler.LeaveTeam)
+				teamWithID.POST("/leave", fullAccess, middleware.TeamMember(cfg.Authorizer), cfg.TeamMemberHandler.LeaveTeam)
+
+				// Team roles
+				roles := teamWithID.Group("/roles")
+				{
+					roles.GET("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionTeamView), cfg.TeamRoleHandler.ListRoles)
+					roles.POST("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionRoleManage), cfg.TeamRoleHandler.CreateRole)
+					roles.PUT("/:name", middleware.TeamAuthz(cfg.Authorizer, authz.ActionRoleManage), cfg.TeamRoleHandler.UpdateRole)
+					roles.DELETE("/:name", middleware.TeamAuthz(cfg.Authorizer, authz.ActionRoleManage), cfg.TeamRoleHandler.DeleteRole)
+				}
 
 				// Team invitations
 				invitations := teamWithID.Group("/invitations")
 				{
 					invitations.POST("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberInvite), cfg.InvitationHandler.CreateInvitation)
 					invitations.GET("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberInvite), cfg.InvitationHandler.ListTeamInvitations)
+					invitations.GET("/history", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberInvite), cfg.InvitationHandler.ListInvitationHistory)
 					invitations.DELETE("/:id", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberInvite), cfg.InvitationHandler.CancelInvitation)
+					invitations.POST("/:id/resend", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberInvite), cfg.InvitationHandler.ResendInvitation)
+					invitations.POST("/:id/extend", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemberInvite), cfg.InvitationHandler.ExtendInvitation)
 				}
 
 				// Team voice memos
@@ -124,15 +263,20 @@ func Setup(cfg *Config) *gin.Engine {
 					teamMemos.POST("", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemoCreate), cfg.VoiceMemoHandler.CreateTeamVoiceMemo)
 					teamMemos.GET("/:id", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemoView), cfg.VoiceMemoHandler.GetTeamVoiceMemo)
 					teamMemos.DELETE("/:id", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemoDelete), cfg.VoiceMemoHandler.DeleteTeamVoiceMemo)
+					// Confirming and retrying update the memo: the handler checks memo:update
+					// (scope and role) against the memo's creator
 					teamMemos.POST("/:id/confirm-upload", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemoCreate), cfg.VoiceMemoHandler.ConfirmTeamUpload)
 					teamMemos.POST("/:id/retry-transcription", middleware.TeamAuthz(cfg.Authorizer, authz.ActionMemoCreate), cfg.VoiceMemoHandler.RetryTeamTranscription)
 				}
 			}
 		}
 
+		// Usage and quotas (protected)
+		v1.GET("/usage", requireAuth, fullAccess, cfg.VoiceMemoHandler.GetUsage)
+
 		// User invitations routes (protected)
 		invitations := v1.Group("/invitations")
-		invitations.Use(middleware.Auth(cfg.JWTManager))
+		invitations.Use(requireAuth, fullAccess)
 		{
 			invitations.GET("", cfg.InvitationHandler.ListMyInvitations)
 			invitations.POST("/:id/accept", cfg.InvitationHandler.AcceptInvitation)
@@ -142,3 +286,14 @@ func Setup(cfg *Config) *gin.Engine {
 
 	return r
 }
+
+// rateLimit returns a middleware enforcing the limit selected from the current auth rate
+// limits, or a pass-through if rate limiting is disabled.
+func rateLimit(cfg *Config, name string, selectLimit func(AuthRateLimits) ratelimit.Limit, keyFunc middleware.RateLimitKeyFunc) gin.HandlerFunc {
+	if cfg.RateLimiter == nil || cfg.AuthRateLimits == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.RateLimit(cfg.RateLimiter, name, func() ratelimit.Limit {
+		return selectLimit(cfg.AuthRateLimits())
+	}, keyFunc)
+}
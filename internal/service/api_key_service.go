@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	"gin-sample/internal/repository"
+	"gin-sample/pkg/auth"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxAPIKeysPerUser is the number of API keys a user can have at once.
+const MaxAPIKeysPerUser = 25
+
+// apiKeyLastUsedInterval limits how often a key's last-used time is written,
+// so busy keys do not cause a database write per request.
+const apiKeyLastUsedInterval = time.Minute
+
+// APIKeyService handles personal API keys.
+type APIKeyService struct {
+	repo       repository.APIKeyRepository
+	userRepo   repository.UserRepository
+	memberRepo repository.TeamMemberRepository
+	generator  auth.APIKeyGenerator
+}
+
+// NewAPIKeyService creates a new APIKeyService.
+func NewAPIKeyService(
+	repo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	memberRepo repository.TeamMemberRepository,
+	generator auth.APIKeyGenerator,
+) *APIKeyService {
+	return &APIKeyService{
+		repo:       repo,
+		userRepo:   userRepo,
+		memberRepo: memberRepo,
+		generator:  generator,
+	}
+}
+
+// CreateAPIKey creates an API key for a user. The plaintext key is only returned here.
+// A team-scoped key can only be created by a member of that team.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, userID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	count, err := s.repo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxAPIKeysPerUser {
+		return nil, apperrors.ErrAPIKeyLimitReached
+	}
+
+	apiKey := &models.APIKey{
+		UserID: userID,
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}
+
+	if req.TeamID != "" {
+		teamID, err := primitive.ObjectIDFromHex(req.TeamID)
+		if err != nil {
+			return nil, apperrors.ErrTeamNotFound
+		}
+		if _, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, userID); err != nil {
+			return nil, err
+		}
+		apiKey.TeamID = &teamID
+	}
+
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	key, prefix, err := s.generator.Generate()
+	if err != nil {
+		return nil, err
+	}
+	apiKey.Prefix = prefix
+	apiKey.KeyHash = s.generator.Hash(key)
+
+	if err := s.repo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &models.CreateAPIKeyResponse{
+		Key:    key,
+		APIKey: *apiKey,
+	}, nil
+}
+
+// ListAPIKeys returns a user's API keys.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, userID primitive.ObjectID) (*models.APIKeyListResponse, error) {
+	keys, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.APIKeyListResponse{Items: keys}, nil
+}
+
+// RevokeAPIKey deletes one of a user's API keys.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, userID, keyID primitive.ObjectID) error {
+	return s.repo.Delete(ctx, keyID, userID)
+}
+
+// AuthenticateAPIKey returns the API key matching a plaintext key.
+// Expired keys and keys of deleted users are rejected. The last-used time is
+// updated at most once per minute, on a best-effort basis.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	apiKey, err := s.repo.FindByHash(ctx, s.generator.Hash(key))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.IsExpired(now) {
+		return nil, apperrors.ErrInvalidAPIKey
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, apiKey.UserID); err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := s.repo.UpdateLastUsed(ctx, apiKey.ID, now); err != nil {
+			slog.WarnContext(ctx, "failed to record api key use", "api_key_id", apiKey.ID.Hex(), "error", err)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, nil
+}
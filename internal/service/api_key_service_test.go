@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	repomocks "gin-sample/internal/repository/mocks"
+	"gin-sample/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestAPIKeyService creates an APIKeyService with mock repositories and a real key generator.
+func newTestAPIKeyService(ctrl *gomock.Controller) (*APIKeyService, *repomocks.MockAPIKeyRepository, *repomocks.MockUserRepository, *repomocks.MockTeamMemberRepository) {
+	mockRepo := repomocks.NewMockAPIKeyRepository(ctrl)
+	mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+	mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+	service := NewAPIKeyService(mockRepo, mockUserRepo, mockMemberRepo, auth.NewAPIKeyGenerator())
+	return service, mockRepo, mockUserRepo, mockMemberRepo
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+
+	t.Run("creates key and stores only its hash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+		var stored *models.APIKey
+
+		mockRepo.EXPECT().CountByUserID(ctx, userID).Return(0, nil)
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, key *models.APIKey) error {
+				stored = key
+				return nil
+			})
+
+		result, err := service.CreateAPIKey(ctx, userID, &models.CreateAPIKeyRequest{
+			Name:          "Backup script",
+			Scopes:        []string{auth.ScopeMemosRead},
+			ExpiresInDays: 30,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.True(t, auth.IsAPIKey(result.Key))
+		assert.Equal(t, auth.NewAPIKeyGenerator().Hash(result.Key), stored.KeyHash)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, "Backup script", result.APIKey.Name)
+		assert.Contains(t, result.Key, result.APIKey.Prefix)
+		require.NotNil(t, result.APIKey.ExpiresAt)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *result.APIKey.ExpiresAt, time.Minute)
+		assert.Nil(t, result.APIKey.TeamID)
+	})
+
+	t.Run("creates team-scoped key for team member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, mockMemberRepo := newTestAPIKeyService(ctrl)
+
+		mockRepo.EXPECT().CountByUserID(ctx, userID).Return(0, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(ctx, teamID, userID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: userID}, nil)
+		mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		result, err := service.CreateAPIKey(ctx, userID, &models.CreateAPIKeyRequest{
+			Name:   "Team sync",
+			Scopes: []string{auth.ScopeMemosRead, auth.ScopeMemosWrite},
+			TeamID: teamID.Hex(),
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result.APIKey.TeamID)
+		assert.Equal(t, teamID, *result.APIKey.TeamID)
+		assert.Nil(t, result.APIKey.ExpiresAt)
+	})
+
+	t.Run("returns error when user is not a team member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, mockMemberRepo := newTestAPIKeyService(ctrl)
+
+		mockRepo.EXPECT().CountByUserID(ctx, userID).Return(0, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(ctx, teamID, userID).
+			Return(nil, apperrors.ErrNotTeamMember)
+
+		result, err := service.CreateAPIKey(ctx, userID, &models.CreateAPIKeyRequest{
+			Name:   "Team sync",
+			Scopes: []string{auth.ScopeMemosRead},
+			TeamID: teamID.Hex(),
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, apperrors.ErrNotTeamMember)
+	})
+
+	t.Run("returns error when key limit is reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+
+		mockRepo.EXPECT().CountByUserID(ctx, userID).Return(MaxAPIKeysPerUser, nil)
+
+		result, err := service.CreateAPIKey(ctx, userID, &models.CreateAPIKeyRequest{
+			Name:   "One too many",
+			Scopes: []string{auth.ScopeMemosRead},
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, apperrors.ErrAPIKeyLimitReached)
+	})
+}
+
+func TestAPIKeyService_ListAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+	keys := []models.APIKey{{ID: primitive.NewObjectID(), Name: "Backup script"}}
+
+	mockRepo.EXPECT().FindByUserID(ctx, userID).Return(keys, nil)
+
+	result, err := service.ListAPIKeys(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, keys, result.Items)
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	keyID := primitive.NewObjectID()
+
+	t.Run("deletes key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+		mockRepo.EXPECT().Delete(ctx, keyID, userID).Return(nil)
+
+		assert.NoError(t, service.RevokeAPIKey(ctx, userID, keyID))
+	})
+
+	t.Run("returns not found for unknown key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+		mockRepo.EXPECT().Delete(ctx, keyID, userID).Return(apperrors.ErrAPIKeyNotFound)
+
+		assert.ErrorIs(t, service.RevokeAPIKey(ctx, userID, keyID), apperrors.ErrAPIKeyNotFound)
+	})
+}
+
+func TestAPIKeyService_AuthenticateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	key := "gmk_1a2b3c4d_secret"
+	keyHash := auth.NewAPIKeyGenerator().Hash(key)
+
+	t.Run("returns key and records use", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, mockUserRepo, _ := newTestAPIKeyService(ctrl)
+		apiKey := &models.APIKey{ID: primitive.NewObjectID(), UserID: userID, KeyHash: keyHash}
+
+		mockRepo.EXPECT().FindByHash(ctx, keyHash).Return(apiKey, nil)
+		mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&models.User{ID: userID}, nil)
+		mockRepo.EXPECT().UpdateLastUsed(ctx, apiKey.ID, gomock.Any()).Return(nil)
+
+		result, err := service.AuthenticateAPIKey(ctx, key)
+
+		require.NoError(t, err)
+		assert.Equal(t, apiKey.ID, result.ID)
+		assert.NotNil(t, result.LastUsedAt)
+	})
+
+	t.Run("skips last-used update for recently used key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, mockUserRepo, _ := newTestAPIKeyService(ctrl)
+		lastUsed := time.Now().Add(-10 * time.Second)
+		apiKey := &models.APIKey{ID: primitive.NewObjectID(), UserID: userID, LastUsedAt: &lastUsed}
+
+		mockRepo.EXPECT().FindByHash(ctx, keyHash).Return(apiKey, nil)
+		mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&models.User{ID: userID}, nil)
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("succeeds when last-used update fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, mockUserRepo, _ := newTestAPIKeyService(ctrl)
+		apiKey := &models.APIKey{ID: primitive.NewObjectID(), UserID: userID}
+
+		mockRepo.EXPECT().FindByHash(ctx, keyHash).Return(apiKey, nil)
+		mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&models.User{ID: userID}, nil)
+		mockRepo.EXPECT().UpdateLastUsed(ctx, apiKey.ID, gomock.Any()).Return(assert.AnError)
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects expired key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+		expiredAt := time.Now().Add(-time.Hour)
+
+		mockRepo.EXPECT().FindByHash(ctx, keyHash).Return(&models.APIKey{UserID: userID, ExpiresAt: &expiredAt}, nil)
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIKey)
+	})
+
+	t.Run("rejects key of deleted user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, mockUserRepo, _ := newTestAPIKeyService(ctrl)
+
+		mockRepo.EXPECT().FindByHash(ctx, keyHash).Return(&models.APIKey{UserID: userID}, nil)
+		mockUserRepo.EXPECT().FindByID(ctx, userID).Return(nil, apperrors.ErrUserNotFound)
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIKey)
+	})
+
+	t.Run("rejects unknown key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, _ := newTestAPIKeyService(ctrl)
+
+		mockRepo.EXPECT().FindByHash(ctx, keyHash).Return(nil, apperrors.ErrInvalidAPIKey)
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIKey)
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"gin-sample/internal/models"
+	"gin-sample/internal/repository"
+	"gin-sample/internal/requestid"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditRecorder appends entries to the audit log.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *models.AuditLogEntry)
+}
+
+// AuditLogService records and lists administrative actions taken in teams.
+type AuditLogService struct {
+	repo repository.AuditLogRepository
+}
+
+// NewAuditLogService creates a new AuditLogService.
+func NewAuditLogService(repo repository.AuditLogRepository) *AuditLogService {
+	return &AuditLogService{
+		repo: repo,
+	}
+}
+
+// Record appends an entry to the audit log, stamped with the request ID from ctx.
+// The action has already taken effect, so a failed write is logged rather than returned.
+func (s *AuditLogService) Record(ctx context.Context, entry *models.AuditLogEntry) {
+	if entry.RequestID == "" {
+		entry.RequestID = requestid.FromContext(ctx)
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		slog.WarnContext(ctx, "failed to record audit log entry", "action", entry.Action,
+			"target_type", entry.TargetType, "target_id", entry.TargetID.Hex(), "team_id", entry.TeamID.Hex(), "error", err)
+	}
+}
+
+// ListTeamAuditLog returns paginated audit log entries for a team, newest first.
+func (s *AuditLogService) ListTeamAuditLog(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (*models.AuditLogListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	entries, total, err := s.repo.FindByTeamID(ctx, teamID, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := total / limit
+	if total%limit > 0 {
+		totalPages++
+	}
+
+	return &models.AuditLogListResponse{
+		Items: entries,
+		Pagination: models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// recordAudit appends an entry to the audit log, if an audit recorder is configured.
+func recordAudit(ctx context.Context, audit AuditRecorder, entry *models.AuditLogEntry) {
+	if audit == nil {
+		return
+	}
+	audit.Record(ctx, entry)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gin-sample/internal/models"
+	repomocks "gin-sample/internal/repository/mocks"
+	"gin-sample/internal/requestid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeAuditRecorder collects recorded audit log entries.
+type fakeAuditRecorder struct {
+	entries []*models.AuditLogEntry
+}
+
+func (f *fakeAuditRecorder) Record(_ context.Context, entry *models.AuditLogEntry) {
+	f.entries = append(f.entries, entry)
+}
+
+func TestNewAuditLogService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repomocks.NewMockAuditLogRepository(ctrl)
+
+	service := NewAuditLogService(mockRepo)
+
+	assert.NotNil(t, service)
+}
+
+func TestAuditLogService_Record(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	t.Run("stamps the request ID from the context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockAuditLogRepository(ctrl)
+		entry := &models.AuditLogEntry{TeamID: teamID, Action: "member:remove"}
+
+		mockRepo.EXPECT().
+			Create(gomock.Any(), entry).
+			DoAndReturn(func(ctx context.Context, e *models.AuditLogEntry) error {
+				assert.Equal(t, "req-123", e.RequestID)
+				return nil
+			})
+
+		service := NewAuditLogService(mockRepo)
+		service.Record(requestid.NewContext(context.Background(), "req-123"), entry)
+	})
+
+	t.Run("write failure does not panic", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockAuditLogRepository(ctrl)
+
+		mockRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(errors.New("database error"))
+
+		service := NewAuditLogService(mockRepo)
+		service.Record(context.Background(), &models.AuditLogEntry{TeamID: teamID})
+	})
+}
+
+func TestAuditLogService_ListTeamAuditLog(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	actorID := primitive.NewObjectID()
+
+	t.Run("returns paginated entries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockAuditLogRepository(ctrl)
+		filter := models.AuditLogFilter{ActorID: &actorID, Action: "member:remove"}
+		entries := []models.AuditLogEntry{
+			{ID: primitive.NewObjectID(), TeamID: teamID, ActorID: actorID, Action: "member:remove"},
+		}
+
+		mockRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID, filter, 2, 10).
+			Return(entries, 11, nil)
+
+		service := NewAuditLogService(mockRepo)
+		result, err := service.ListTeamAuditLog(context.Background(), teamID, filter, 2, 10)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, 2, result.Pagination.Page)
+		assert.Equal(t, 11, result.Pagination.TotalItems)
+		assert.Equal(t, 2, result.Pagination.TotalPages)
+	})
+
+	t.Run("applies default pagination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockAuditLogRepository(ctrl)
+
+		mockRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID, models.AuditLogFilter{}, 1, 20).
+			Return([]models.AuditLogEntry{}, 0, nil)
+
+		service := NewAuditLogService(mockRepo)
+		result, err := service.ListTeamAuditLog(context.Background(), teamID, models.AuditLogFilter{}, 0, 500)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Pagination.Page)
+		assert.Equal(t, 20, result.Pagination.Limit)
+	})
+
+	t.Run("propagates repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockAuditLogRepository(ctrl)
+		dbErr := errors.New("database error")
+
+		mockRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID, models.AuditLogFilter{}, 1, 20).
+			Return(nil, 0, dbErr)
+
+		service := NewAuditLogService(mockRepo)
+		result, err := service.ListTeamAuditLog(context.Background(), teamID, models.AuditLogFilter{}, 1, 20)
+
+		assert.Equal(t, dbErr, err)
+		assert.Nil(t, result)
+	})
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sort"
 	"time"
 
 	"gin-sample/internal/cache"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
+	"gin-sample/internal/ratelimit"
 	"gin-sample/internal/repository"
 	"gin-sample/pkg/auth"
 
@@ -20,6 +24,7 @@ import (
 type AuthService struct {
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	apiKeyRepo       repository.APIKeyRepository
 	cache            cache.Cache
 	tokenStore       cache.RefreshTokenStore
 	jwtManager       auth.TokenManager
@@ -27,6 +32,12 @@ type AuthService struct {
 	accessTokenTTL   time.Duration
 	refreshTokenTTL  time.Duration
 	rotationEnabled  bool
+	mfaChallengeTTL  time.Duration
+	mfaVerifier      *mfaVerifier
+	lockout          ratelimit.Lockout
+	revocations      cache.TokenRevocationStore
+	oidcProviders    []auth.OIDCProvider
+	oidcStateTTL     time.Duration
 }
 
 // AuthServiceConfig holds configuration for AuthService.
@@ -40,6 +51,19 @@ type AuthServiceConfig struct {
 	AccessTokenTTL   time.Duration
 	RefreshTokenTTL  time.Duration
 	RotationEnabled  bool
+	TOTPProvider     auth.TOTPProvider
+	MFAChallengeTTL  time.Duration
+	// Lockout locks accounts after repeated failed logins. Nil disables lockout.
+	Lockout ratelimit.Lockout
+	// TokenRevocation revokes access tokens on logout and password change. Nil disables
+	// revocation, so access tokens stay valid until they expire.
+	TokenRevocation cache.TokenRevocationStore
+	// APIKeyRepo revokes the user's API keys on logout from all devices and password change.
+	// Nil leaves API keys valid until they are revoked or expire.
+	APIKeyRepo repository.APIKeyRepository
+	// OIDCProviders are the identity providers available for login, in display order.
+	OIDCProviders []auth.OIDCProvider
+	OIDCStateTTL  time.Duration
 }
 
 // NewAuthService creates a new AuthService.
@@ -47,6 +71,7 @@ func NewAuthService(cfg AuthServiceConfig) *AuthService {
 	return &AuthService{
 		userRepo:         cfg.UserRepo,
 		refreshTokenRepo: cfg.RefreshTokenRepo,
+		apiKeyRepo:       cfg.APIKeyRepo,
 		cache:            cfg.Cache,
 		tokenStore:       cfg.TokenStore,
 		jwtManager:       cfg.JWTManager,
@@ -54,11 +79,32 @@ func NewAuthService(cfg AuthServiceConfig) *AuthService {
 		accessTokenTTL:   cfg.AccessTokenTTL,
 		refreshTokenTTL:  cfg.RefreshTokenTTL,
 		rotationEnabled:  cfg.RotationEnabled,
+		mfaChallengeTTL:  cfg.MFAChallengeTTL,
+		mfaVerifier:      &mfaVerifier{userRepo: cfg.UserRepo, cache: cfg.Cache, totp: cfg.TOTPProvider},
+		lockout:          cfg.Lockout,
+		revocations:      cfg.TokenRevocation,
+		oidcProviders:    cfg.OIDCProviders,
+		oidcStateTTL:     cfg.OIDCStateTTL,
 	}
 }
 
+// mfaChallenge is the data stored in cache for a pending MFA login.
+type mfaChallenge struct {
+	UserID    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// oidcLoginState is the data stored in cache for a pending OIDC login.
+type oidcLoginState struct {
+	Provider     string    `json:"provider"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
 // Register creates a new user account and returns auth tokens.
-func (s *AuthService) Register(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error) {
+// The client info is recorded on the new login session.
+func (s *AuthService) Register(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
 		return nil, err
@@ -74,34 +120,334 @@ func (s *AuthService) Register(ctx context.Context, req *models.CreateUserReques
 		return nil, err
 	}
 
-	return s.generateAuthResponse(ctx, user)
+	return s.generateAuthResponse(ctx, user, client)
 }
 
-// Login authenticates a user and returns auth tokens.
-func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
+// Login authenticates a user with their password.
+// Users without MFA get auth tokens. Users with MFA enabled get an MFA challenge
+// instead, which must be completed with VerifyMFA.
+// Locked accounts are rejected with an AccountLockedError before the password is checked.
+func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+	if err := s.checkLockout(ctx, req.Email); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, apperrors.ErrInvalidCredentials
+		s.recordLoginFailure(ctx, req.Email)
+		return nil, nil, apperrors.ErrInvalidCredentials
 	}
 
 	if err := auth.CheckPassword(req.Password, user.Password); err != nil {
-		return nil, apperrors.ErrInvalidCredentials
+		s.recordLoginFailure(ctx, req.Email)
+		return nil, nil, apperrors.ErrInvalidCredentials
+	}
+
+	if user.MFAEnabled {
+		challenge, err := s.createMFAChallenge(ctx, user.ID.Hex())
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	s.resetLockout(ctx, req.Email)
+
+	result, err := s.generateAuthResponse(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// VerifyMFA completes login by exchanging an MFA challenge token and a TOTP or recovery code for auth tokens.
+// The challenge is invalidated after MFAMaxAttempts codes have been tried, and locked accounts are rejected.
+func (s *AuthService) VerifyMFA(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	key := cache.MFAChallengeCacheKey(req.MFAToken)
+
+	var challenge mfaChallenge
+	found, err := s.cache.Get(ctx, key, &challenge)
+	if err != nil {
+		return nil, err
+	}
+	if !found || time.Now().After(challenge.ExpiresAt) {
+		return nil, apperrors.ErrInvalidMFAToken
+	}
+
+	userID, err := primitive.ObjectIDFromHex(challenge.UserID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidMFAToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			_ = s.cache.Delete(ctx, key)
+			return nil, apperrors.ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		_ = s.cache.Delete(ctx, key)
+		return nil, apperrors.ErrInvalidMFAToken
 	}
 
-	return s.generateAuthResponse(ctx, user)
+	// Locked accounts cannot keep guessing codes with a challenge obtained before the lock
+	if err := s.checkLockout(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	attemptsKey := cache.MFAChallengeAttemptsCacheKey(req.MFAToken)
+	if err := s.mfaVerifier.verifyLimited(ctx, user, user.MFASecret, req.Code, attemptsKey, time.Until(challenge.ExpiresAt)); err != nil {
+		if errors.Is(err, apperrors.ErrTooManyMFAAttempts) {
+			_ = s.cache.Delete(ctx, key)
+			return nil, apperrors.ErrInvalidMFAToken
+		}
+		if errors.Is(err, apperrors.ErrInvalidMFACode) {
+			s.recordLoginFailure(ctx, user.Email)
+		}
+		return nil, err
+	}
+
+	// Challenge tokens are single-use
+	_ = s.cache.Delete(ctx, key)
+	s.resetLockout(ctx, user.Email)
+
+	return s.generateAuthResponse(ctx, user, client)
+}
+
+// ListOIDCProviders returns the identity providers available for login.
+func (s *AuthService) ListOIDCProviders() *models.OIDCProviderListResponse {
+	items := make([]models.OIDCProviderInfo, 0, len(s.oidcProviders))
+	for _, provider := range s.oidcProviders {
+		items = append(items, models.OIDCProviderInfo{Name: provider.Name(), DisplayName: provider.DisplayName()})
+	}
+	return &models.OIDCProviderListResponse{Items: items}
+}
+
+// StartOIDCLogin begins an authorization code login with PKCE.
+// The state, nonce and code verifier are kept server-side until CompleteOIDCLogin.
+func (s *AuthService) StartOIDCLogin(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error) {
+	provider := s.findOIDCProvider(providerName)
+	if provider == nil {
+		return nil, apperrors.ErrOIDCProviderNotFound
+	}
+
+	state, err := generateOIDCToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := generateOIDCToken()
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := &oidcLoginState{
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: auth.GenerateCodeVerifier(),
+		ExpiresAt:    time.Now().Add(s.oidcStateTTL),
+	}
+
+	if err := s.cache.Set(ctx, cache.OIDCStateCacheKey(state), loginState, s.oidcStateTTL); err != nil {
+		return nil, err
+	}
+
+	return &models.OIDCAuthorizeResponse{
+		AuthorizationURL: provider.AuthCodeURL(state, nonce, loginState.CodeVerifier),
+		State:            state,
+		ExpiresIn:        int(s.oidcStateTTL.Seconds()),
+	}, nil
+}
+
+// CompleteOIDCLogin exchanges the authorization code for a verified identity and logs in the linked user.
+// Unknown identities are linked to the user with the same verified email if the provider is trusted
+// to assert emails, or a new user is created.
+// Like Login, users with MFA enabled get an MFA challenge instead of auth tokens.
+func (s *AuthService) CompleteOIDCLogin(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+	provider := s.findOIDCProvider(providerName)
+	if provider == nil {
+		return nil, nil, apperrors.ErrOIDCProviderNotFound
+	}
+
+	key := cache.OIDCStateCacheKey(req.State)
+
+	// State is single-use: consume it atomically so a replayed callback finds nothing
+	var loginState oidcLoginState
+	found, err := s.cache.GetDel(ctx, key, &loginState)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to consume oidc state", "provider", provider.Name(), "error", err)
+		return nil, nil, apperrors.ErrInvalidOIDCState
+	}
+	if !found || time.Now().After(loginState.ExpiresAt) || loginState.Provider != provider.Name() {
+		return nil, nil, apperrors.ErrInvalidOIDCState
+	}
+
+	identity, err := provider.Exchange(ctx, req.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		slog.WarnContext(ctx, "oidc login failed", "provider", provider.Name(), "error", err)
+		return nil, nil, apperrors.ErrOIDCLoginFailed
+	}
+
+	user, err := s.resolveOIDCUser(ctx, provider, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.MFAEnabled {
+		challenge, err := s.createMFAChallenge(ctx, user.ID.Hex())
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	result, err := s.generateAuthResponse(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// findOIDCProvider returns the configured provider with the given name, or nil.
+func (s *AuthService) findOIDCProvider(name string) auth.OIDCProvider {
+	for _, provider := range s.oidcProviders {
+		if provider.Name() == name {
+			return provider
+		}
+	}
+	return nil
+}
+
+// resolveOIDCUser finds the user linked to an external identity, or creates one by verified email.
+// An existing account with the same email is only linked if the provider is trusted to assert
+// emails; otherwise any provider could take over an account by claiming its email.
+func (s *AuthService) resolveOIDCUser(ctx context.Context, provider auth.OIDCProvider, identity *auth.OIDCIdentity) (*models.User, error) {
+	user, err := s.userRepo.FindByIdentity(ctx, provider.Name(), identity.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// Linking by email is only safe when the provider has verified it
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, apperrors.ErrOIDCEmailNotVerified
+	}
+
+	link := models.ExternalIdentity{
+		Provider: provider.Name(),
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		LinkedAt: time.Now(),
+	}
+
+	user, err = s.userRepo.FindByEmail(ctx, identity.Email)
+	if err == nil {
+		if !provider.TrustsEmail() {
+			return nil, apperrors.ErrOIDCAccountExists
+		}
+		if err := s.userRepo.AddIdentity(ctx, user.ID, link); err != nil {
+			return nil, err
+		}
+		user.Identities = append(user.Identities, link)
+		return user, nil
+	}
+	if !errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	// Users created through a provider have no password and can only log in with the provider
+	user = &models.User{
+		Email:      identity.Email,
+		Name:       name,
+		Identities: []models.ExternalIdentity{link},
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// createMFAChallenge stores a short-lived challenge for the second login step.
+func (s *AuthService) createMFAChallenge(ctx context.Context, userID string) (*models.MFAChallengeResponse, error) {
+	token, err := generateMFAToken()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &mfaChallenge{
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(s.mfaChallengeTTL),
+	}
+
+	if err := s.cache.Set(ctx, cache.MFAChallengeCacheKey(token), challenge, s.mfaChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	return &models.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int(s.mfaChallengeTTL.Seconds()),
+	}, nil
+}
+
+// checkLockout returns an AccountLockedError if the account is locked.
+// Lockout is best-effort: if the store is unavailable, login proceeds.
+func (s *AuthService) checkLockout(ctx context.Context, email string) error {
+	if s.lockout == nil {
+		return nil
+	}
+
+	lockedFor, err := s.lockout.LockedFor(ctx, email)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to check login lockout", "error", err)
+		return nil
+	}
+	if lockedFor > 0 {
+		return &apperrors.AccountLockedError{RetryAfter: lockedFor}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login attempt towards lockout.
+func (s *AuthService) recordLoginFailure(ctx context.Context, email string) {
+	if s.lockout == nil {
+		return
+	}
+	if _, err := s.lockout.RecordFailure(ctx, email); err != nil {
+		slog.WarnContext(ctx, "failed to record login failure", "error", err)
+	}
+}
+
+// resetLockout clears failed login attempts after a successful login.
+func (s *AuthService) resetLockout(ctx context.Context, email string) {
+	if s.lockout == nil {
+		return
+	}
+	if err := s.lockout.Reset(ctx, email); err != nil {
+		slog.WarnContext(ctx, "failed to reset login lockout", "error", err)
+	}
 }
 
 // Refresh exchanges a refresh token for a new access token.
-// If rotation is enabled, returns a new refresh token as well.
-func (s *AuthService) Refresh(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error) {
+// If rotation is enabled, returns a new refresh token as well and records session activity.
+func (s *AuthService) Refresh(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error) {
 	if s.rotationEnabled && s.tokenGenerator != nil {
-		return s.refreshWithRotation(ctx, req)
+		return s.refreshWithRotation(ctx, req, client)
 	}
 	return s.refreshWithoutRotation(ctx, req)
 }
 
 // refreshWithRotation handles refresh with token rotation.
-func (s *AuthService) refreshWithRotation(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error) {
+func (s *AuthService) refreshWithRotation(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error) {
 	// Extract family ID from token
 	familyID, err := s.tokenGenerator.ExtractFamilyID(req.RefreshToken)
 	if err != nil {
@@ -129,13 +475,14 @@ func (s *AuthService) refreshWithRotation(ctx context.Context, req *models.Refre
 	// Check against current token
 	if s.tokenGenerator.CompareHashes(incomingHash, storedData.CurrentTokenHash) {
 		// Valid current token - perform rotation
-		return s.performRotation(ctx, familyID, storedData)
+		return s.performRotation(ctx, familyID, storedData, client)
 	}
 
 	// Check against previous token (1-token lookback for reuse detection)
 	if storedData.PreviousTokenHash != "" && s.tokenGenerator.CompareHashes(incomingHash, storedData.PreviousTokenHash) {
-		// REUSE DETECTED - invalidate entire family
+		// REUSE DETECTED - invalidate entire family and its access tokens
 		_ = s.tokenStore.Delete(ctx, familyID)
+		s.revokeSessionTokens(ctx, familyID)
 		return nil, apperrors.ErrRefreshTokenReused
 	}
 
@@ -144,15 +491,15 @@ func (s *AuthService) refreshWithRotation(ctx context.Context, req *models.Refre
 }
 
 // performRotation generates new tokens and rotates the stored token data.
-func (s *AuthService) performRotation(ctx context.Context, familyID string, storedData *cache.RefreshTokenData) (*models.RefreshResponse, error) {
+func (s *AuthService) performRotation(ctx context.Context, familyID string, storedData *cache.RefreshTokenData, client models.ClientInfo) (*models.RefreshResponse, error) {
 	// Generate new refresh token with same family
 	newRefreshToken, err := s.tokenGenerator.GenerateWithFamily(familyID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate new access token
-	accessToken, err := s.jwtManager.GenerateToken(storedData.UserID)
+	// Generate new access token bound to the session
+	accessToken, err := s.issueAccessToken(ctx, storedData.UserID, familyID)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +508,7 @@ func (s *AuthService) performRotation(ctx context.Context, familyID string, stor
 	newHash := s.tokenGenerator.Hash(newRefreshToken)
 
 	// Rotate stored data (current becomes previous)
-	if err := s.tokenStore.Rotate(ctx, familyID, newHash, s.refreshTokenTTL); err != nil {
+	if err := s.tokenStore.Rotate(ctx, familyID, newHash, client.IPAddress, s.refreshTokenTTL); err != nil {
 		return nil, err
 	}
 
@@ -196,7 +543,7 @@ func (s *AuthService) refreshWithoutRotation(ctx context.Context, req *models.Re
 	}
 
 	// Generate new access token
-	accessToken, err := s.jwtManager.GenerateToken(userID)
+	accessToken, err := s.issueAccessToken(ctx, userID, "")
 	if err != nil {
 		return nil, err
 	}
@@ -207,8 +554,11 @@ func (s *AuthService) refreshWithoutRotation(ctx context.Context, req *models.Re
 	}, nil
 }
 
-// Logout invalidates a refresh token.
-func (s *AuthService) Logout(ctx context.Context, req *models.LogoutRequest) error {
+// Logout invalidates a refresh token and the access token the request was made with.
+// accessClaims may be nil if the access token is not known.
+func (s *AuthService) Logout(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error {
+	s.revokeAccessToken(ctx, accessClaims)
+
 	if s.rotationEnabled && s.tokenGenerator != nil {
 		return s.logoutWithRotation(ctx, req)
 	}
@@ -224,6 +574,7 @@ func (s *AuthService) logoutWithRotation(ctx context.Context, req *models.Logout
 	}
 	// Delete the token family - ignore errors for idempotency
 	_ = s.tokenStore.Delete(ctx, familyID)
+	s.revokeSessionTokens(ctx, familyID)
 	return nil
 }
 
@@ -240,8 +591,22 @@ func (s *AuthService) logoutWithoutRotation(ctx context.Context, req *models.Log
 	return nil
 }
 
-// LogoutAll invalidates all refresh tokens for a user.
+// LogoutAll invalidates all refresh tokens, access tokens and API keys for a user.
 func (s *AuthService) LogoutAll(ctx context.Context, userID primitive.ObjectID) error {
+	// Revoke access tokens first so they stop working even if token cleanup fails
+	if s.revocations != nil {
+		if err := s.revocations.RevokeUserTokens(ctx, userID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	// API keys outlive sessions, so revoke them too
+	if s.apiKeyRepo != nil {
+		if err := s.apiKeyRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+	}
+
 	// Handle rotation-enabled mode
 	if s.rotationEnabled && s.tokenStore != nil {
 		if err := s.tokenStore.DeleteAllByUserID(ctx, userID.Hex()); err != nil {
@@ -270,22 +635,177 @@ func (s *AuthService) LogoutAll(ctx context.Context, userID primitive.ObjectID)
 	return s.refreshTokenRepo.DeleteByUserID(ctx, userID)
 }
 
-// generateAuthResponse creates access and refresh tokens for a user.
-func (s *AuthService) generateAuthResponse(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
-	accessToken, err := s.jwtManager.GenerateToken(user.ID.Hex())
+// ListSessions returns the user's active login sessions, most recently used first.
+// The session matching currentSessionID is marked as current.
+// Sessions are only tracked when refresh token rotation is enabled.
+func (s *AuthService) ListSessions(ctx context.Context, userID primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error) {
+	if !s.rotationEnabled || s.tokenStore == nil {
+		return nil, apperrors.ErrSessionsUnsupported
+	}
+
+	families, err := s.tokenStore.ListByUserID(ctx, userID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sessions := make([]models.Session, 0, len(families))
+	for familyID, data := range families {
+		if now.After(data.ExpiresAt) {
+			continue
+		}
+
+		lastUsedAt := data.LastUsedAt
+		if lastUsedAt.IsZero() {
+			// Sessions created before activity tracking
+			lastUsedAt = data.CreatedAt
+		}
+
+		sessions = append(sessions, models.Session{
+			ID:         familyID,
+			UserAgent:  data.UserAgent,
+			IPAddress:  data.IPAddress,
+			CreatedAt:  data.CreatedAt,
+			LastUsedAt: lastUsedAt,
+			ExpiresAt:  data.ExpiresAt,
+			Current:    familyID == currentSessionID,
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return &models.SessionListResponse{Items: sessions}, nil
+}
+
+// RevokeSession signs out one login session by deleting its refresh token family
+// and revoking the access tokens issued for it.
+func (s *AuthService) RevokeSession(ctx context.Context, userID primitive.ObjectID, sessionID string) error {
+	if !s.rotationEnabled || s.tokenStore == nil {
+		return apperrors.ErrSessionsUnsupported
+	}
+
+	data, err := s.tokenStore.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	// Do not reveal whether another user's session exists
+	if data == nil || data.UserID != userID.Hex() {
+		return apperrors.ErrSessionNotFound
+	}
+
+	if err := s.tokenStore.Delete(ctx, sessionID); err != nil {
+		return err
+	}
+	s.revokeSessionTokens(ctx, sessionID)
+
+	return nil
+}
+
+// ChangePassword replaces the user's password after verifying the current one.
+// All sessions are signed out, including the current one, and the user's API keys are revoked.
+func (s *AuthService) ChangePassword(ctx context.Context, userID primitive.ObjectID, req *models.ChangePasswordRequest) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := auth.CheckPassword(req.CurrentPassword, user.Password); err != nil {
+		return apperrors.ErrInvalidCredentials
+	}
+
+	hashedPassword, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return err
+	}
+
+	return s.LogoutAll(ctx, userID)
+}
+
+// CreateScopedToken issues an access token restricted to the requested actions, for handing
+// to third-party integrations. It is bound to the caller's session, so signing out revokes it,
+// and it expires like any other access token.
+func (s *AuthService) CreateScopedToken(ctx context.Context, userID primitive.ObjectID, sessionID string, req *models.ScopedTokenRequest) (*models.ScopedTokenResponse, error) {
+	accessToken, err := s.jwtManager.GenerateScopedToken(userID.Hex(), sessionID, s.tokenVersion(ctx, userID.Hex()), req.Scopes)
 	if err != nil {
 		return nil, err
 	}
 
-	var refreshTokenStr string
+	return &models.ScopedTokenResponse{
+		AccessToken: accessToken,
+		ExpiresIn:   int(s.accessTokenTTL.Seconds()),
+		Scopes:      req.Scopes,
+	}, nil
+}
+
+// issueAccessToken creates an access token carrying the user's current token version.
+// sessionID is empty for tokens that are not bound to a session.
+func (s *AuthService) issueAccessToken(ctx context.Context, userID, sessionID string) (string, error) {
+	return s.jwtManager.GenerateSessionToken(userID, sessionID, s.tokenVersion(ctx, userID))
+}
+
+// tokenVersion returns the user's current token version for new access tokens.
+func (s *AuthService) tokenVersion(ctx context.Context, userID string) int64 {
+	if s.revocations == nil {
+		return 0
+	}
+	version, err := s.revocations.TokenVersion(ctx, userID)
+	if err != nil {
+		// A stale version only causes early rejection, so issue the token anyway
+		slog.WarnContext(ctx, "failed to get token version", "user_id", userID, "error", err)
+	}
+	return version
+}
+
+// revokeAccessToken adds a single access token to the denylist until it expires (best-effort).
+func (s *AuthService) revokeAccessToken(ctx context.Context, claims *auth.Claims) {
+	if s.revocations == nil || claims == nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return
+	}
+	if err := s.revocations.RevokeToken(ctx, claims.ID, time.Until(claims.ExpiresAt.Time)); err != nil {
+		slog.WarnContext(ctx, "failed to revoke access token", "error", err)
+	}
+}
+
+// revokeSessionTokens invalidates the access tokens issued for a session (best-effort).
+func (s *AuthService) revokeSessionTokens(ctx context.Context, sessionID string) {
+	if s.revocations == nil {
+		return
+	}
+	if err := s.revocations.RevokeSessionTokens(ctx, sessionID, s.accessTokenTTL); err != nil {
+		slog.WarnContext(ctx, "failed to revoke session tokens", "session_id", sessionID, "error", err)
+	}
+}
+
+// generateAuthResponse creates access and refresh tokens for a user.
+// With rotation enabled, the access token is bound to the new session.
+func (s *AuthService) generateAuthResponse(ctx context.Context, user *models.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	var accessToken, refreshTokenStr string
+	var err error
 
 	if s.rotationEnabled && s.tokenGenerator != nil {
 		// Use family-based rotation tokens
-		refreshTokenStr, err = s.generateRotationToken(ctx, user.ID.Hex())
+		var familyID string
+		refreshTokenStr, familyID, err = s.generateRotationToken(ctx, user.ID.Hex(), client)
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken, err = s.issueAccessToken(ctx, user.ID.Hex(), familyID)
 		if err != nil {
 			return nil, err
 		}
 	} else {
+		accessToken, err = s.issueAccessToken(ctx, user.ID.Hex(), "")
+		if err != nil {
+			return nil, err
+		}
+
 		// Use legacy token storage
 		refreshTokenStr, err = s.generateLegacyToken(ctx, user.ID)
 		if err != nil {
@@ -302,24 +822,29 @@ func (s *AuthService) generateAuthResponse(ctx context.Context, user *models.Use
 }
 
 // generateRotationToken creates a new refresh token with family-based rotation.
-func (s *AuthService) generateRotationToken(ctx context.Context, userID string) (string, error) {
+// It returns the token and its family ID, which identifies the session.
+func (s *AuthService) generateRotationToken(ctx context.Context, userID string, client models.ClientInfo) (string, string, error) {
 	token, familyID, err := s.tokenGenerator.Generate()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
+	now := time.Now()
 	tokenData := &cache.RefreshTokenData{
 		UserID:           userID,
 		CurrentTokenHash: s.tokenGenerator.Hash(token),
-		ExpiresAt:        time.Now().Add(s.refreshTokenTTL),
-		CreatedAt:        time.Now(),
+		ExpiresAt:        now.Add(s.refreshTokenTTL),
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
 	}
 
 	if err := s.tokenStore.Create(ctx, familyID, tokenData, s.refreshTokenTTL); err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	return token, nil
+	return token, familyID, nil
 }
 
 // generateRandomToken creates a cryptographically secure random token.
@@ -331,6 +856,24 @@ func generateRandomToken() (string, error) {
 	return "rf_" + hex.EncodeToString(bytes), nil
 }
 
+// generateMFAToken creates a cryptographically secure MFA challenge token.
+func generateMFAToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "mfa_" + hex.EncodeToString(bytes), nil
+}
+
+// generateOIDCToken creates a cryptographically secure OIDC state or nonce.
+func generateOIDCToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "oidc_" + hex.EncodeToString(bytes), nil
+}
+
 // generateLegacyToken creates a refresh token using the legacy MongoDB storage.
 func (s *AuthService) generateLegacyToken(ctx context.Context, userID primitive.ObjectID) (string, error) {
 	refreshTokenStr, err := generateRandomToken()
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,10 +10,12 @@ import (
 	cachemocks "gin-sample/internal/cache/mocks"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
+	ratelimitmocks "gin-sample/internal/ratelimit/mocks"
 	repomocks "gin-sample/internal/repository/mocks"
 	"gin-sample/pkg/auth"
 	authmocks "gin-sample/pkg/auth/mocks"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -37,6 +40,26 @@ func newTestAuthService(
 	})
 }
 
+// newTestMFAAuthService creates an AuthService in legacy mode with a TOTP provider for MFA testing.
+func newTestMFAAuthService(
+	userRepo *repomocks.MockUserRepository,
+	refreshTokenRepo *repomocks.MockRefreshTokenRepository,
+	cache *cachemocks.MockCache,
+	jwtManager *authmocks.MockTokenManager,
+	totp *authmocks.MockTOTPProvider,
+) *AuthService {
+	return NewAuthService(AuthServiceConfig{
+		UserRepo:         userRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		Cache:            cache,
+		JWTManager:       jwtManager,
+		AccessTokenTTL:   15 * time.Minute,
+		RefreshTokenTTL:  7 * 24 * time.Hour,
+		TOTPProvider:     totp,
+		MFAChallengeTTL:  5 * time.Minute,
+	})
+}
+
 func TestNewAuthService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -80,7 +103,7 @@ func TestAuthService_Register(t *testing.T) {
 
 		// Expect token generation
 		mockJWT.EXPECT().
-			GenerateToken(gomock.Any()).
+			GenerateSessionToken(gomock.Any(), "", int64(0)).
 			Return("access-token", nil)
 
 		// Expect refresh token storage
@@ -95,7 +118,7 @@ func TestAuthService_Register(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		resp, err := service.Register(context.Background(), createUserReq, models.ClientInfo{})
 
 		require.NoError(t, err)
 		assert.NotNil(t, resp)
@@ -119,7 +142,7 @@ func TestAuthService_Register(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		resp, err := service.Register(context.Background(), createUserReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrUserAlreadyExists, err)
@@ -142,12 +165,12 @@ func TestAuthService_Register(t *testing.T) {
 			})
 
 		mockJWT.EXPECT().
-			GenerateToken(gomock.Any()).
+			GenerateSessionToken(gomock.Any(), "", int64(0)).
 			Return("", assert.AnError)
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		resp, err := service.Register(context.Background(), createUserReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -183,7 +206,7 @@ func TestAuthService_Login(t *testing.T) {
 			Return(validUser, nil)
 
 		mockJWT.EXPECT().
-			GenerateToken(validUserID.Hex()).
+			GenerateSessionToken(validUserID.Hex(), "", int64(0)).
 			Return("access-token", nil)
 
 		mockRefreshRepo.EXPECT().
@@ -196,9 +219,10 @@ func TestAuthService_Login(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Login(context.Background(), loginReq)
+		resp, challenge, err := service.Login(context.Background(), loginReq, models.ClientInfo{})
 
 		require.NoError(t, err)
+		assert.Nil(t, challenge)
 		assert.Equal(t, "access-token", resp.AccessToken)
 		assert.NotEmpty(t, resp.RefreshToken)
 	})
@@ -218,7 +242,7 @@ func TestAuthService_Login(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Login(context.Background(), loginReq)
+		resp, _, err := service.Login(context.Background(), loginReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
@@ -244,9 +268,108 @@ func TestAuthService_Login(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Login(context.Background(), wrongPasswordReq)
+		resp, _, err := service.Login(context.Background(), wrongPasswordReq, models.ClientInfo{})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
+	})
+}
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	validUserID := primitive.NewObjectID()
+	hashedPassword, _ := auth.HashPassword("password123")
+	validUser := &models.User{
+		ID:       validUserID,
+		Email:    "test@example.com",
+		Password: hashedPassword,
+		Name:     "Test User",
+	}
+
+	newService := func(ctrl *gomock.Controller, userRepo *repomocks.MockUserRepository, lockout *ratelimitmocks.MockLockout) *AuthService {
+		return NewAuthService(AuthServiceConfig{
+			UserRepo:         userRepo,
+			RefreshTokenRepo: repomocks.NewMockRefreshTokenRepository(ctrl),
+			Cache:            cachemocks.NewMockCache(ctrl),
+			JWTManager:       authmocks.NewMockTokenManager(ctrl),
+			AccessTokenTTL:   15 * time.Minute,
+			RefreshTokenTTL:  7 * 24 * time.Hour,
+			Lockout:          lockout,
+		})
+	}
+
+	t.Run("rejects locked account without checking password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockLockout := ratelimitmocks.NewMockLockout(ctrl)
+
+		mockLockout.EXPECT().
+			LockedFor(gomock.Any(), "test@example.com").
+			Return(2*time.Minute, nil)
+
+		service := newService(ctrl, mockUserRepo, mockLockout)
+
+		resp, _, err := service.Login(context.Background(), &models.LoginRequest{Email: "test@example.com", Password: "password123"}, models.ClientInfo{})
 
 		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, apperrors.ErrAccountLocked)
+		var lockedErr *apperrors.AccountLockedError
+		require.ErrorAs(t, err, &lockedErr)
+		assert.Equal(t, 2*time.Minute, lockedErr.RetryAfter)
+	})
+
+	t.Run("records failure for wrong password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockLockout := ratelimitmocks.NewMockLockout(ctrl)
+
+		mockLockout.EXPECT().LockedFor(gomock.Any(), "test@example.com").Return(time.Duration(0), nil)
+		mockUserRepo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(validUser, nil)
+		mockLockout.EXPECT().RecordFailure(gomock.Any(), "test@example.com").Return(time.Duration(0), nil)
+
+		service := newService(ctrl, mockUserRepo, mockLockout)
+
+		_, _, err := service.Login(context.Background(), &models.LoginRequest{Email: "test@example.com", Password: "wrongpassword"}, models.ClientInfo{})
+
+		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
+	})
+
+	t.Run("records failure for unknown account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockLockout := ratelimitmocks.NewMockLockout(ctrl)
+
+		mockLockout.EXPECT().LockedFor(gomock.Any(), "nobody@example.com").Return(time.Duration(0), nil)
+		mockUserRepo.EXPECT().FindByEmail(gomock.Any(), "nobody@example.com").Return(nil, apperrors.ErrUserNotFound)
+		mockLockout.EXPECT().RecordFailure(gomock.Any(), "nobody@example.com").Return(time.Duration(0), nil)
+
+		service := newService(ctrl, mockUserRepo, mockLockout)
+
+		_, _, err := service.Login(context.Background(), &models.LoginRequest{Email: "nobody@example.com", Password: "password123"}, models.ClientInfo{})
+
+		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
+	})
+
+	t.Run("proceeds when lockout store fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockLockout := ratelimitmocks.NewMockLockout(ctrl)
+
+		mockLockout.EXPECT().LockedFor(gomock.Any(), gomock.Any()).Return(time.Duration(0), assert.AnError)
+		mockUserRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(validUser, nil)
+		mockLockout.EXPECT().RecordFailure(gomock.Any(), gomock.Any()).Return(time.Duration(0), assert.AnError)
+
+		service := newService(ctrl, mockUserRepo, mockLockout)
+
+		_, _, err := service.Login(context.Background(), &models.LoginRequest{Email: "test@example.com", Password: "wrongpassword"}, models.ClientInfo{})
+
 		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
 	})
 }
@@ -271,12 +394,12 @@ func TestAuthService_Refresh(t *testing.T) {
 			Return(validUserID.Hex(), nil)
 
 		mockJWT.EXPECT().
-			GenerateToken(validUserID.Hex()).
+			GenerateSessionToken(validUserID.Hex(), "", int64(0)).
 			Return("new-access-token", nil)
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		require.NoError(t, err)
 		assert.Equal(t, "new-access-token", resp.AccessToken)
@@ -310,12 +433,12 @@ func TestAuthService_Refresh(t *testing.T) {
 			Return(nil)
 
 		mockJWT.EXPECT().
-			GenerateToken(validUserID.Hex()).
+			GenerateSessionToken(validUserID.Hex(), "", int64(0)).
 			Return("new-access-token", nil)
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		require.NoError(t, err)
 		assert.Equal(t, "new-access-token", resp.AccessToken)
@@ -340,7 +463,7 @@ func TestAuthService_Refresh(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrInvalidRefreshToken, err)
@@ -361,7 +484,7 @@ func TestAuthService_Refresh(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -392,7 +515,7 @@ func TestAuthService_Logout(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		err := service.Logout(context.Background(), logoutReq)
+		err := service.Logout(context.Background(), logoutReq, nil)
 
 		assert.NoError(t, err)
 	})
@@ -412,7 +535,7 @@ func TestAuthService_Logout(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		err := service.Logout(context.Background(), logoutReq)
+		err := service.Logout(context.Background(), logoutReq, nil)
 
 		assert.Error(t, err)
 	})
@@ -436,7 +559,7 @@ func TestAuthService_Logout(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		err := service.Logout(context.Background(), logoutReq)
+		err := service.Logout(context.Background(), logoutReq, nil)
 
 		assert.NoError(t, err)
 	})
@@ -639,7 +762,7 @@ func TestAuthService_Register_WithRotation(t *testing.T) {
 
 		// Expect JWT generation
 		mockJWT.EXPECT().
-			GenerateToken(gomock.Any()).
+			GenerateSessionToken(gomock.Any(), "family123", int64(0)).
 			Return("access-token", nil)
 
 		// Expect rotation token generation
@@ -651,16 +774,23 @@ func TestAuthService_Register_WithRotation(t *testing.T) {
 			Hash("rt_family123_random456").
 			Return("hashed_token")
 
-		// Expect token store creation
+		// Expect token store creation with device metadata
 		mockTokenStore.EXPECT().
 			Create(gomock.Any(), "family123", gomock.Any(), 7*24*time.Hour).
-			Return(nil)
+			DoAndReturn(func(ctx context.Context, familyID string, data *cache.RefreshTokenData, ttl time.Duration) error {
+				assert.Equal(t, "hashed_token", data.CurrentTokenHash)
+				assert.Equal(t, "test-agent/1.0", data.UserAgent)
+				assert.Equal(t, "203.0.113.7", data.IPAddress)
+				assert.False(t, data.LastUsedAt.IsZero())
+				return nil
+			})
 
 		service := newTestAuthServiceWithRotation(
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		client := models.ClientInfo{UserAgent: "test-agent/1.0", IPAddress: "203.0.113.7"}
+		resp, err := service.Register(context.Background(), createUserReq, client)
 
 		require.NoError(t, err)
 		assert.NotNil(t, resp)
@@ -717,7 +847,7 @@ func TestAuthService_Refresh_WithRotation(t *testing.T) {
 
 		// Generate new access token
 		mockJWT.EXPECT().
-			GenerateToken("user123").
+			GenerateSessionToken("user123", "family123", int64(0)).
 			Return("new-access-token", nil)
 
 		// Hash new token
@@ -727,14 +857,14 @@ func TestAuthService_Refresh_WithRotation(t *testing.T) {
 
 		// Rotate stored data
 		mockTokenStore.EXPECT().
-			Rotate(gomock.Any(), "family123", "new_hash", 7*24*time.Hour).
+			Rotate(gomock.Any(), "family123", "new_hash", "203.0.113.7", 7*24*time.Hour).
 			Return(nil)
 
 		service := newTestAuthServiceWithRotation(
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{IPAddress: "203.0.113.7"})
 
 		require.NoError(t, err)
 		assert.Equal(t, "new-access-token", resp.AccessToken)
@@ -760,7 +890,7 @@ func TestAuthService_Refresh_WithRotation(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrInvalidRefreshToken, err)
@@ -800,7 +930,7 @@ func TestAuthService_Refresh_WithRotation(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrRefreshTokenExpired, err)
@@ -856,7 +986,7 @@ func TestAuthService_Refresh_WithRotation(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrRefreshTokenReused, err)
@@ -905,7 +1035,7 @@ func TestAuthService_Refresh_WithRotation(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrInvalidRefreshToken, err)
@@ -940,7 +1070,7 @@ func TestAuthService_Logout_WithRotation(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		err := service.Logout(context.Background(), logoutReq)
+		err := service.Logout(context.Background(), logoutReq, nil)
 
 		assert.NoError(t, err)
 	})
@@ -967,10 +1097,194 @@ func TestAuthService_Logout_WithRotation(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		err := service.Logout(context.Background(), logoutReq)
+		err := service.Logout(context.Background(), logoutReq, nil)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuthService_ListSessions(t *testing.T) {
+	userID := primitive.NewObjectID()
+	now := time.Now()
+
+	t.Run("lists sessions most recently used first and marks current", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenStore := cachemocks.NewMockRefreshTokenStore(ctrl)
+		mockTokenStore.EXPECT().
+			ListByUserID(gomock.Any(), userID.Hex()).
+			Return(map[string]*cache.RefreshTokenData{
+				"family-old": {
+					UserID:     userID.Hex(),
+					UserAgent:  "laptop",
+					CreatedAt:  now.Add(-48 * time.Hour),
+					LastUsedAt: now.Add(-24 * time.Hour),
+					ExpiresAt:  now.Add(time.Hour),
+				},
+				"family-new": {
+					UserID:     userID.Hex(),
+					UserAgent:  "phone",
+					IPAddress:  "203.0.113.7",
+					CreatedAt:  now.Add(-time.Hour),
+					LastUsedAt: now.Add(-time.Minute),
+					ExpiresAt:  now.Add(time.Hour),
+				},
+				"family-expired": {
+					UserID:    userID.Hex(),
+					ExpiresAt: now.Add(-time.Minute),
+				},
+			}, nil)
+
+		service := newTestAuthServiceWithRotation(
+			repomocks.NewMockUserRepository(ctrl), repomocks.NewMockRefreshTokenRepository(ctrl),
+			cachemocks.NewMockCache(ctrl), mockTokenStore,
+			authmocks.NewMockTokenManager(ctrl), authmocks.NewMockRefreshTokenGenerator(ctrl),
+		)
+
+		resp, err := service.ListSessions(context.Background(), userID, "family-old")
+
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 2)
+		assert.Equal(t, "family-new", resp.Items[0].ID)
+		assert.Equal(t, "phone", resp.Items[0].UserAgent)
+		assert.Equal(t, "203.0.113.7", resp.Items[0].IPAddress)
+		assert.False(t, resp.Items[0].Current)
+		assert.Equal(t, "family-old", resp.Items[1].ID)
+		assert.True(t, resp.Items[1].Current)
+	})
+
+	t.Run("falls back to creation time for sessions without activity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		createdAt := now.Add(-time.Hour)
+		mockTokenStore := cachemocks.NewMockRefreshTokenStore(ctrl)
+		mockTokenStore.EXPECT().
+			ListByUserID(gomock.Any(), userID.Hex()).
+			Return(map[string]*cache.RefreshTokenData{
+				"family123": {UserID: userID.Hex(), CreatedAt: createdAt, ExpiresAt: now.Add(time.Hour)},
+			}, nil)
+
+		service := newTestAuthServiceWithRotation(
+			repomocks.NewMockUserRepository(ctrl), repomocks.NewMockRefreshTokenRepository(ctrl),
+			cachemocks.NewMockCache(ctrl), mockTokenStore,
+			authmocks.NewMockTokenManager(ctrl), authmocks.NewMockRefreshTokenGenerator(ctrl),
+		)
+
+		resp, err := service.ListSessions(context.Background(), userID, "")
+
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, createdAt, resp.Items[0].LastUsedAt)
+	})
+
+	t.Run("returns error when store fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenStore := cachemocks.NewMockRefreshTokenStore(ctrl)
+		mockTokenStore.EXPECT().
+			ListByUserID(gomock.Any(), userID.Hex()).
+			Return(nil, assert.AnError)
+
+		service := newTestAuthServiceWithRotation(
+			repomocks.NewMockUserRepository(ctrl), repomocks.NewMockRefreshTokenRepository(ctrl),
+			cachemocks.NewMockCache(ctrl), mockTokenStore,
+			authmocks.NewMockTokenManager(ctrl), authmocks.NewMockRefreshTokenGenerator(ctrl),
+		)
+
+		resp, err := service.ListSessions(context.Background(), userID, "")
+
+		assert.Nil(t, resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("returns unsupported without rotation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := newTestAuthService(
+			repomocks.NewMockUserRepository(ctrl), repomocks.NewMockRefreshTokenRepository(ctrl),
+			cachemocks.NewMockCache(ctrl), authmocks.NewMockTokenManager(ctrl),
+		)
+
+		resp, err := service.ListSessions(context.Background(), userID, "")
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperrors.ErrSessionsUnsupported, err)
+	})
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	newService := func(ctrl *gomock.Controller, tokenStore *cachemocks.MockRefreshTokenStore) *AuthService {
+		return newTestAuthServiceWithRotation(
+			repomocks.NewMockUserRepository(ctrl), repomocks.NewMockRefreshTokenRepository(ctrl),
+			cachemocks.NewMockCache(ctrl), tokenStore,
+			authmocks.NewMockTokenManager(ctrl), authmocks.NewMockRefreshTokenGenerator(ctrl),
+		)
+	}
+
+	t.Run("revokes own session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenStore := cachemocks.NewMockRefreshTokenStore(ctrl)
+		mockTokenStore.EXPECT().
+			Get(gomock.Any(), "family123").
+			Return(&cache.RefreshTokenData{UserID: userID.Hex()}, nil)
+		mockTokenStore.EXPECT().
+			Delete(gomock.Any(), "family123").
+			Return(nil)
+
+		err := newService(ctrl, mockTokenStore).RevokeSession(context.Background(), userID, "family123")
 
 		assert.NoError(t, err)
 	})
+
+	t.Run("returns not found for another user's session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenStore := cachemocks.NewMockRefreshTokenStore(ctrl)
+		mockTokenStore.EXPECT().
+			Get(gomock.Any(), "family123").
+			Return(&cache.RefreshTokenData{UserID: primitive.NewObjectID().Hex()}, nil)
+
+		err := newService(ctrl, mockTokenStore).RevokeSession(context.Background(), userID, "family123")
+
+		assert.Equal(t, apperrors.ErrSessionNotFound, err)
+	})
+
+	t.Run("returns not found for unknown session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenStore := cachemocks.NewMockRefreshTokenStore(ctrl)
+		mockTokenStore.EXPECT().
+			Get(gomock.Any(), "family123").
+			Return(nil, nil)
+
+		err := newService(ctrl, mockTokenStore).RevokeSession(context.Background(), userID, "family123")
+
+		assert.Equal(t, apperrors.ErrSessionNotFound, err)
+	})
+
+	t.Run("returns unsupported without rotation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := newTestAuthService(
+			repomocks.NewMockUserRepository(ctrl), repomocks.NewMockRefreshTokenRepository(ctrl),
+			cachemocks.NewMockCache(ctrl), authmocks.NewMockTokenManager(ctrl),
+		)
+
+		err := service.RevokeSession(context.Background(), userID, "family123")
+
+		assert.Equal(t, apperrors.ErrSessionsUnsupported, err)
+	})
 }
 
 func TestAuthService_LogoutAll_WithRotation(t *testing.T) {
@@ -1103,10 +1417,6 @@ func TestAuthService_Register_WithRotation_ErrorCases(t *testing.T) {
 				return nil
 			})
 
-		mockJWT.EXPECT().
-			GenerateToken(gomock.Any()).
-			Return("access-token", nil)
-
 		// Token generation fails
 		mockTokenGen.EXPECT().
 			Generate().
@@ -1116,7 +1426,7 @@ func TestAuthService_Register_WithRotation_ErrorCases(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		resp, err := service.Register(context.Background(), createUserReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -1140,10 +1450,6 @@ func TestAuthService_Register_WithRotation_ErrorCases(t *testing.T) {
 				return nil
 			})
 
-		mockJWT.EXPECT().
-			GenerateToken(gomock.Any()).
-			Return("access-token", nil)
-
 		mockTokenGen.EXPECT().
 			Generate().
 			Return("rt_family123_random456", "family123", nil)
@@ -1161,7 +1467,7 @@ func TestAuthService_Register_WithRotation_ErrorCases(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		resp, err := service.Register(context.Background(), createUserReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -1192,7 +1498,7 @@ func TestAuthService_Register_LegacyToken_ErrorCases(t *testing.T) {
 			})
 
 		mockJWT.EXPECT().
-			GenerateToken(gomock.Any()).
+			GenerateSessionToken(gomock.Any(), "", int64(0)).
 			Return("access-token", nil)
 
 		// Refresh token repo create fails
@@ -1202,7 +1508,7 @@ func TestAuthService_Register_LegacyToken_ErrorCases(t *testing.T) {
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Register(context.Background(), createUserReq)
+		resp, err := service.Register(context.Background(), createUserReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -1229,12 +1535,12 @@ func TestAuthService_Refresh_LegacyToken_ErrorCases(t *testing.T) {
 
 		// JWT generation fails
 		mockJWT.EXPECT().
-			GenerateToken("user123").
+			GenerateSessionToken("user123", "", int64(0)).
 			Return("", assert.AnError)
 
 		service := newTestAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -1270,7 +1576,7 @@ func TestAuthService_Refresh_WithRotation_EdgeCases(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrInvalidRefreshToken, err)
@@ -1299,7 +1605,7 @@ func TestAuthService_Refresh_WithRotation_EdgeCases(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Equal(t, apperrors.ErrInvalidRefreshToken, err)
@@ -1343,7 +1649,7 @@ func TestAuthService_Refresh_WithRotation_EdgeCases(t *testing.T) {
 			Return("rt_family123_newrandom", nil)
 
 		mockJWT.EXPECT().
-			GenerateToken("user123").
+			GenerateSessionToken("user123", "family123", int64(0)).
 			Return("new-access-token", nil)
 
 		mockTokenGen.EXPECT().
@@ -1352,14 +1658,14 @@ func TestAuthService_Refresh_WithRotation_EdgeCases(t *testing.T) {
 
 		// Rotate fails
 		mockTokenStore.EXPECT().
-			Rotate(gomock.Any(), "family123", "new_hash", 7*24*time.Hour).
+			Rotate(gomock.Any(), "family123", "new_hash", gomock.Any(), 7*24*time.Hour).
 			Return(assert.AnError)
 
 		service := newTestAuthServiceWithRotation(
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -1407,7 +1713,7 @@ func TestAuthService_Refresh_WithRotation_EdgeCases(t *testing.T) {
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
@@ -1452,16 +1758,914 @@ func TestAuthService_Refresh_WithRotation_EdgeCases(t *testing.T) {
 
 		// JWT generation fails
 		mockJWT.EXPECT().
-			GenerateToken("user123").
+			GenerateSessionToken("user123", "family123", int64(0)).
 			Return("", assert.AnError)
 
 		service := newTestAuthServiceWithRotation(
 			mockUserRepo, mockRefreshRepo, mockCache, mockTokenStore, mockJWT, mockTokenGen,
 		)
 
-		resp, err := service.Refresh(context.Background(), refreshReq)
+		resp, err := service.Refresh(context.Background(), refreshReq, models.ClientInfo{})
 
 		assert.Nil(t, resp)
 		assert.Error(t, err)
 	})
 }
+
+func TestAuthService_LoginWithMFA(t *testing.T) {
+	userID := primitive.NewObjectID()
+	hashedPassword, _ := auth.HashPassword("password123")
+	mfaUser := &models.User{
+		ID:         userID,
+		Email:      "test@example.com",
+		Password:   hashedPassword,
+		MFAEnabled: true,
+		MFASecret:  "JBSWY3DPEHPK3PXP",
+	}
+
+	loginReq := &models.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+
+	t.Run("returns mfa challenge instead of tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByEmail(gomock.Any(), loginReq.Email).
+			Return(mfaUser, nil)
+
+		mockCache.EXPECT().
+			Set(gomock.Any(), gomock.Any(), gomock.Any(), 5*time.Minute).
+			DoAndReturn(func(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+				assert.True(t, strings.HasPrefix(key, "mfa_challenge:mfa_"))
+				challenge := value.(*mfaChallenge)
+				assert.Equal(t, userID.Hex(), challenge.UserID)
+				return nil
+			})
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, authmocks.NewMockTOTPProvider(ctrl))
+
+		resp, challenge, err := service.Login(context.Background(), loginReq, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+		require.NotNil(t, challenge)
+		assert.True(t, challenge.MFARequired)
+		assert.True(t, strings.HasPrefix(challenge.MFAToken, "mfa_"))
+		assert.Equal(t, 300, challenge.ExpiresIn)
+	})
+}
+
+func TestAuthService_VerifyMFA(t *testing.T) {
+	userID := primitive.NewObjectID()
+	mfaUser := &models.User{
+		ID:         userID,
+		Email:      "test@example.com",
+		MFAEnabled: true,
+		MFASecret:  "JBSWY3DPEHPK3PXP",
+	}
+	req := &models.MFAVerifyRequest{MFAToken: "mfa_token", Code: "123456"}
+	challengeKey := cache.MFAChallengeCacheKey("mfa_token")
+	attemptsKey := cache.MFAChallengeAttemptsCacheKey("mfa_token")
+
+	// expectChallenge makes the cache return a stored challenge for the test token.
+	expectChallenge := func(mockCache *cachemocks.MockCache) {
+		mockCache.EXPECT().
+			Get(gomock.Any(), challengeKey, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string, dest interface{}) (bool, error) {
+				challenge := dest.(*mfaChallenge)
+				challenge.UserID = userID.Hex()
+				challenge.ExpiresAt = time.Now().Add(time.Minute)
+				return true, nil
+			})
+	}
+
+	// expectAttempt makes the cache count a code tried for the test token as the given attempt.
+	expectAttempt := func(mockCache *cachemocks.MockCache, attempt int64) {
+		mockCache.EXPECT().Incr(gomock.Any(), attemptsKey, gomock.Any()).Return(attempt, nil)
+	}
+
+	t.Run("exchanges challenge and totp code for tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		expectAttempt(mockCache, 1)
+		mockTOTP.EXPECT().Validate("123456", mfaUser.MFASecret).Return(true)
+		mockCache.EXPECT().SetNX(gomock.Any(), cache.MFAUsedCodeCacheKey(userID.Hex(), "123456"), true, mfaCodeReplayWindow).Return(true, nil)
+		mockCache.EXPECT().Delete(gomock.Any(), attemptsKey).Return(nil)
+		mockCache.EXPECT().Delete(gomock.Any(), challengeKey).Return(nil)
+		mockJWT.EXPECT().GenerateSessionToken(userID.Hex(), "", int64(0)).Return("access-token", nil)
+		mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockCache.EXPECT().SetRefreshToken(gomock.Any(), gomock.Any(), userID.Hex(), gomock.Any()).Return(nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, mockTOTP)
+
+		resp, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", resp.AccessToken)
+	})
+
+	t.Run("accepts unused recovery code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		recoveryReq := &models.MFAVerifyRequest{MFAToken: "mfa_token", Code: "abcde-12345"}
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		expectAttempt(mockCache, 1)
+		mockTOTP.EXPECT().Validate("abcde-12345", mfaUser.MFASecret).Return(false)
+		mockUserRepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), userID, auth.HashRecoveryCode("abcde-12345")).Return(true, nil)
+		mockCache.EXPECT().Delete(gomock.Any(), attemptsKey).Return(nil)
+		mockCache.EXPECT().Delete(gomock.Any(), challengeKey).Return(nil)
+		mockJWT.EXPECT().GenerateSessionToken(userID.Hex(), "", int64(0)).Return("access-token", nil)
+		mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockCache.EXPECT().SetRefreshToken(gomock.Any(), gomock.Any(), userID.Hex(), gomock.Any()).Return(nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, mockTOTP)
+
+		resp, err := service.VerifyMFA(context.Background(), recoveryReq, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", resp.AccessToken)
+	})
+
+	t.Run("rejects replayed totp code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		expectAttempt(mockCache, 1)
+		mockTOTP.EXPECT().Validate("123456", mfaUser.MFASecret).Return(true)
+		mockCache.EXPECT().SetNX(gomock.Any(), cache.MFAUsedCodeCacheKey(userID.Hex(), "123456"), true, mfaCodeReplayWindow).Return(false, nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, mockTOTP)
+
+		resp, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperrors.ErrInvalidMFACode, err)
+	})
+
+	t.Run("rejects wrong code within max attempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		expectAttempt(mockCache, MFAMaxAttempts)
+		mockTOTP.EXPECT().Validate("123456", mfaUser.MFASecret).Return(false)
+		mockUserRepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), userID, gomock.Any()).Return(false, nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, mockTOTP)
+
+		resp, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperrors.ErrInvalidMFACode, err)
+	})
+
+	t.Run("invalidates challenge after max attempts without checking the code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		expectAttempt(mockCache, MFAMaxAttempts+1)
+		mockCache.EXPECT().Delete(gomock.Any(), challengeKey).Return(nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, mockTOTP)
+
+		_, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		assert.Equal(t, apperrors.ErrInvalidMFAToken, err)
+	})
+
+	t.Run("rejects locked account before checking the code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockLockout := ratelimitmocks.NewMockLockout(ctrl)
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockLockout.EXPECT().LockedFor(gomock.Any(), mfaUser.Email).Return(10*time.Minute, nil)
+
+		service := NewAuthService(AuthServiceConfig{
+			UserRepo:        mockUserRepo,
+			Cache:           mockCache,
+			TOTPProvider:    authmocks.NewMockTOTPProvider(ctrl),
+			MFAChallengeTTL: 5 * time.Minute,
+			Lockout:         mockLockout,
+		})
+
+		_, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		var lockedErr *apperrors.AccountLockedError
+		require.ErrorAs(t, err, &lockedErr)
+		assert.Equal(t, 10*time.Minute, lockedErr.RetryAfter)
+	})
+
+	t.Run("returns error for unknown challenge", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+
+		mockCache.EXPECT().Get(gomock.Any(), challengeKey, gomock.Any()).Return(false, nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, authmocks.NewMockTOTPProvider(ctrl))
+
+		resp, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperrors.ErrInvalidMFAToken, err)
+	})
+
+	t.Run("returns error when mfa was disabled after login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRefreshRepo := repomocks.NewMockRefreshTokenRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockJWT := authmocks.NewMockTokenManager(ctrl)
+
+		expectChallenge(mockCache)
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(&models.User{ID: userID}, nil)
+		mockCache.EXPECT().Delete(gomock.Any(), challengeKey).Return(nil)
+
+		service := newTestMFAAuthService(mockUserRepo, mockRefreshRepo, mockCache, mockJWT, authmocks.NewMockTOTPProvider(ctrl))
+
+		_, err := service.VerifyMFA(context.Background(), req, models.ClientInfo{})
+
+		assert.Equal(t, apperrors.ErrInvalidMFAToken, err)
+	})
+}
+
+// Token revocation tests
+
+// revocationTestMocks holds the mocks for an AuthService with token revocation enabled.
+type revocationTestMocks struct {
+	userRepo    *repomocks.MockUserRepository
+	refreshRepo *repomocks.MockRefreshTokenRepository
+	cache       *cachemocks.MockCache
+	tokenStore  *cachemocks.MockRefreshTokenStore
+	jwt         *authmocks.MockTokenManager
+	tokenGen    *authmocks.MockRefreshTokenGenerator
+	revocations *cachemocks.MockTokenRevocationStore
+	apiKeyRepo  *repomocks.MockAPIKeyRepository
+}
+
+// newTestAuthServiceWithRevocation creates an AuthService with token revocation enabled.
+func newTestAuthServiceWithRevocation(ctrl *gomock.Controller, rotation bool) (*AuthService, *revocationTestMocks) {
+	m := &revocationTestMocks{
+		userRepo:    repomocks.NewMockUserRepository(ctrl),
+		refreshRepo: repomocks.NewMockRefreshTokenRepository(ctrl),
+		cache:       cachemocks.NewMockCache(ctrl),
+		tokenStore:  cachemocks.NewMockRefreshTokenStore(ctrl),
+		jwt:         authmocks.NewMockTokenManager(ctrl),
+		tokenGen:    authmocks.NewMockRefreshTokenGenerator(ctrl),
+		revocations: cachemocks.NewMockTokenRevocationStore(ctrl),
+		apiKeyRepo:  repomocks.NewMockAPIKeyRepository(ctrl),
+	}
+
+	return NewAuthService(AuthServiceConfig{
+		UserRepo:         m.userRepo,
+		RefreshTokenRepo: m.refreshRepo,
+		Cache:            m.cache,
+		TokenStore:       m.tokenStore,
+		JWTManager:       m.jwt,
+		TokenGenerator:   m.tokenGen,
+		AccessTokenTTL:   15 * time.Minute,
+		RefreshTokenTTL:  7 * 24 * time.Hour,
+		RotationEnabled:  rotation,
+		TokenRevocation:  m.revocations,
+		APIKeyRepo:       m.apiKeyRepo,
+	}), m
+}
+
+func TestAuthService_TokenRevocation(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("issues access tokens with the current token version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.revocations.EXPECT().TokenVersion(gomock.Any(), "user123").Return(int64(4), nil)
+		m.cache.EXPECT().GetRefreshToken(gomock.Any(), "rf_token").Return("user123", nil)
+		m.jwt.EXPECT().GenerateSessionToken("user123", "", int64(4)).Return("access-token", nil)
+
+		resp, err := service.Refresh(context.Background(), &models.RefreshRequest{RefreshToken: "rf_token"}, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", resp.AccessToken)
+	})
+
+	t.Run("issues tokens with version zero when the store fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.revocations.EXPECT().TokenVersion(gomock.Any(), "user123").Return(int64(0), assert.AnError)
+		m.cache.EXPECT().GetRefreshToken(gomock.Any(), "rf_token").Return("user123", nil)
+		m.jwt.EXPECT().GenerateSessionToken("user123", "", int64(0)).Return("access-token", nil)
+
+		_, err := service.Refresh(context.Background(), &models.RefreshRequest{RefreshToken: "rf_token"}, models.ClientInfo{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("logout revokes the current access token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		claims := &auth.Claims{UserID: userID.Hex()}
+		claims.ID = "jti-123"
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(10 * time.Minute))
+
+		m.revocations.EXPECT().
+			RevokeToken(gomock.Any(), "jti-123", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, ttl time.Duration) error {
+				assert.InDelta(t, (10 * time.Minute).Seconds(), ttl.Seconds(), 5)
+				return nil
+			})
+		m.refreshRepo.EXPECT().DeleteByToken(gomock.Any(), "rf_token").Return(nil)
+		m.cache.EXPECT().DeleteRefreshToken(gomock.Any(), "rf_token").Return(nil)
+
+		err := service.Logout(context.Background(), &models.LogoutRequest{RefreshToken: "rf_token"}, claims)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("logout with rotation revokes the session's access tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, true)
+
+		m.tokenGen.EXPECT().ExtractFamilyID("rt_family123_random").Return("family123", nil)
+		m.tokenStore.EXPECT().Delete(gomock.Any(), "family123").Return(nil)
+		m.revocations.EXPECT().RevokeSessionTokens(gomock.Any(), "family123", 15*time.Minute).Return(nil)
+
+		err := service.Logout(context.Background(), &models.LogoutRequest{RefreshToken: "rt_family123_random"}, nil)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("logout all revokes all access tokens and api keys", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.revocations.EXPECT().RevokeUserTokens(gomock.Any(), userID.Hex()).Return(nil)
+		m.apiKeyRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		m.refreshRepo.EXPECT().FindAllByUserID(gomock.Any(), userID).Return(nil, nil)
+		m.refreshRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+
+		err := service.LogoutAll(context.Background(), userID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("logout all fails when access tokens cannot be revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.revocations.EXPECT().RevokeUserTokens(gomock.Any(), userID.Hex()).Return(assert.AnError)
+
+		err := service.LogoutAll(context.Background(), userID)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("logout all fails when api keys cannot be revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.revocations.EXPECT().RevokeUserTokens(gomock.Any(), userID.Hex()).Return(nil)
+		m.apiKeyRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(assert.AnError)
+
+		err := service.LogoutAll(context.Background(), userID)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("reuse detection revokes the session's access tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, true)
+
+		m.tokenGen.EXPECT().ExtractFamilyID("rt_family123_old").Return("family123", nil)
+		m.tokenStore.EXPECT().Get(gomock.Any(), "family123").Return(&cache.RefreshTokenData{
+			UserID:            "user123",
+			CurrentTokenHash:  "current_hash",
+			PreviousTokenHash: "previous_hash",
+			ExpiresAt:         time.Now().Add(time.Hour),
+		}, nil)
+		m.tokenGen.EXPECT().Hash("rt_family123_old").Return("previous_hash")
+		m.tokenGen.EXPECT().CompareHashes("previous_hash", "current_hash").Return(false)
+		m.tokenGen.EXPECT().CompareHashes("previous_hash", "previous_hash").Return(true)
+		m.tokenStore.EXPECT().Delete(gomock.Any(), "family123").Return(nil)
+		m.revocations.EXPECT().RevokeSessionTokens(gomock.Any(), "family123", 15*time.Minute).Return(nil)
+
+		_, err := service.Refresh(context.Background(), &models.RefreshRequest{RefreshToken: "rt_family123_old"}, models.ClientInfo{})
+
+		assert.Equal(t, apperrors.ErrRefreshTokenReused, err)
+	})
+
+	t.Run("revoking a session revokes its access tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, true)
+
+		m.tokenStore.EXPECT().Get(gomock.Any(), "family123").Return(&cache.RefreshTokenData{UserID: userID.Hex()}, nil)
+		m.tokenStore.EXPECT().Delete(gomock.Any(), "family123").Return(nil)
+		m.revocations.EXPECT().RevokeSessionTokens(gomock.Any(), "family123", 15*time.Minute).Return(nil)
+
+		err := service.RevokeSession(context.Background(), userID, "family123")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	userID := primitive.NewObjectID()
+	hashedPassword, _ := auth.HashPassword("oldpassword")
+	req := &models.ChangePasswordRequest{CurrentPassword: "oldpassword", NewPassword: "newpassword"}
+
+	t.Run("changes password, signs out all sessions and revokes api keys", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(&models.User{ID: userID, Password: hashedPassword}, nil)
+		m.userRepo.EXPECT().
+			UpdatePassword(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ primitive.ObjectID, hash string) error {
+				assert.NoError(t, auth.CheckPassword("newpassword", hash))
+				return nil
+			})
+		m.revocations.EXPECT().RevokeUserTokens(gomock.Any(), userID.Hex()).Return(nil)
+		m.apiKeyRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		m.refreshRepo.EXPECT().FindAllByUserID(gomock.Any(), userID).Return(nil, nil)
+		m.refreshRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+
+		err := service.ChangePassword(context.Background(), userID, req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects wrong current password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(&models.User{ID: userID, Password: hashedPassword}, nil)
+
+		err := service.ChangePassword(context.Background(), userID, &models.ChangePasswordRequest{
+			CurrentPassword: "wrongpassword",
+			NewPassword:     "newpassword",
+		})
+
+		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
+	})
+
+	t.Run("returns error when user not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, false)
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, apperrors.ErrUserNotFound)
+
+		err := service.ChangePassword(context.Background(), userID, req)
+
+		assert.Equal(t, apperrors.ErrUserNotFound, err)
+	})
+}
+
+type oidcTestMocks struct {
+	userRepo    *repomocks.MockUserRepository
+	refreshRepo *repomocks.MockRefreshTokenRepository
+	cache       *cachemocks.MockCache
+	jwt         *authmocks.MockTokenManager
+	provider    *authmocks.MockOIDCProvider
+}
+
+// newTestAuthServiceWithOIDC creates an AuthService in legacy mode with one OIDC provider named "acme".
+func newTestAuthServiceWithOIDC(ctrl *gomock.Controller) (*AuthService, *oidcTestMocks) {
+	m := &oidcTestMocks{
+		userRepo:    repomocks.NewMockUserRepository(ctrl),
+		refreshRepo: repomocks.NewMockRefreshTokenRepository(ctrl),
+		cache:       cachemocks.NewMockCache(ctrl),
+		jwt:         authmocks.NewMockTokenManager(ctrl),
+		provider:    authmocks.NewMockOIDCProvider(ctrl),
+	}
+	m.provider.EXPECT().Name().Return("acme").AnyTimes()
+	m.provider.EXPECT().DisplayName().Return("Acme SSO").AnyTimes()
+
+	return NewAuthService(AuthServiceConfig{
+		UserRepo:         m.userRepo,
+		RefreshTokenRepo: m.refreshRepo,
+		Cache:            m.cache,
+		JWTManager:       m.jwt,
+		AccessTokenTTL:   15 * time.Minute,
+		RefreshTokenTTL:  7 * 24 * time.Hour,
+		MFAChallengeTTL:  5 * time.Minute,
+		OIDCProviders:    []auth.OIDCProvider{m.provider},
+		OIDCStateTTL:     10 * time.Minute,
+	}), m
+}
+
+// expectOIDCState makes the cache consume a pending login state for "oidc_state:oidc_state123".
+func (m *oidcTestMocks) expectOIDCState(provider string) {
+	m.cache.EXPECT().
+		GetDel(gomock.Any(), "oidc_state:oidc_state123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, dest interface{}) (bool, error) {
+			*dest.(*oidcLoginState) = oidcLoginState{
+				Provider:     provider,
+				Nonce:        "nonce123",
+				CodeVerifier: "verifier123",
+				ExpiresAt:    time.Now().Add(time.Minute),
+			}
+			return true, nil
+		})
+}
+
+// expectTokens expects legacy token issuance for a user.
+func (m *oidcTestMocks) expectTokens(userID primitive.ObjectID) {
+	m.jwt.EXPECT().GenerateSessionToken(userID.Hex(), "", int64(0)).Return("access-token", nil)
+	m.refreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	m.cache.EXPECT().SetRefreshToken(gomock.Any(), gomock.Any(), userID.Hex(), gomock.Any()).Return(nil)
+}
+
+func TestAuthService_CreateScopedToken(t *testing.T) {
+	userID := primitive.NewObjectID()
+	req := &models.ScopedTokenRequest{Scopes: []string{"memo:view", "memo:create"}}
+
+	t.Run("issues token restricted to the scopes and bound to the session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, true)
+
+		m.revocations.EXPECT().TokenVersion(gomock.Any(), userID.Hex()).Return(int64(2), nil)
+		m.jwt.EXPECT().
+			GenerateScopedToken(userID.Hex(), "a1b2c3d4e5f67890", int64(2), req.Scopes).
+			Return("scoped-token", nil)
+
+		result, err := service.CreateScopedToken(context.Background(), userID, "a1b2c3d4e5f67890", req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "scoped-token", result.AccessToken)
+		assert.Equal(t, 900, result.ExpiresIn)
+		assert.Equal(t, req.Scopes, result.Scopes)
+	})
+
+	t.Run("returns error when signing fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithRevocation(ctrl, true)
+
+		m.revocations.EXPECT().TokenVersion(gomock.Any(), userID.Hex()).Return(int64(0), nil)
+		m.jwt.EXPECT().GenerateScopedToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", assert.AnError)
+
+		result, err := service.CreateScopedToken(context.Background(), userID, "", req)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthService_ListOIDCProviders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := newTestAuthServiceWithOIDC(ctrl)
+
+	resp := service.ListOIDCProviders()
+
+	assert.Equal(t, []models.OIDCProviderInfo{{Name: "acme", DisplayName: "Acme SSO"}}, resp.Items)
+}
+
+func TestAuthService_StartOIDCLogin(t *testing.T) {
+	t.Run("stores login state and returns authorization URL", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+
+		var stored *oidcLoginState
+		var storedKey string
+		m.cache.EXPECT().
+			Set(gomock.Any(), gomock.Any(), gomock.Any(), 10*time.Minute).
+			DoAndReturn(func(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+				storedKey = key
+				stored = value.(*oidcLoginState)
+				return nil
+			})
+		m.provider.EXPECT().
+			AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(state, nonce, codeVerifier string) string {
+				assert.Equal(t, "oidc_state:"+state, storedKey)
+				assert.Equal(t, stored.Nonce, nonce)
+				assert.Equal(t, stored.CodeVerifier, codeVerifier)
+				return "https://idp.example.com/authorize?state=" + state
+			})
+
+		resp, err := service.StartOIDCLogin(context.Background(), "acme")
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(resp.State, "oidc_"))
+		assert.Equal(t, "https://idp.example.com/authorize?state="+resp.State, resp.AuthorizationURL)
+		assert.Equal(t, 600, resp.ExpiresIn)
+		assert.Equal(t, "acme", stored.Provider)
+		assert.NotEmpty(t, stored.CodeVerifier)
+		assert.NotEqual(t, resp.State, stored.Nonce)
+	})
+
+	t.Run("returns error for unknown provider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, _ := newTestAuthServiceWithOIDC(ctrl)
+
+		_, err := service.StartOIDCLogin(context.Background(), "unknown")
+
+		assert.ErrorIs(t, err, apperrors.ErrOIDCProviderNotFound)
+	})
+}
+
+func TestAuthService_CompleteOIDCLogin(t *testing.T) {
+	userID := primitive.NewObjectID()
+	req := &models.OIDCCallbackRequest{Code: "code123", State: "oidc_state123"}
+	verifiedIdentity := &auth.OIDCIdentity{
+		Subject:       "sub123",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Name:          "Provider User",
+	}
+
+	t.Run("logs in user linked to the identity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+		user := &models.User{ID: userID, Email: "user@example.com"}
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(verifiedIdentity, nil)
+		m.userRepo.EXPECT().FindByIdentity(gomock.Any(), "acme", "sub123").Return(user, nil)
+		m.expectTokens(userID)
+
+		resp, challenge, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Nil(t, challenge)
+		assert.Equal(t, "access-token", resp.AccessToken)
+		assert.Equal(t, userID, resp.User.ID)
+	})
+
+	t.Run("links identity to user with the same verified email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+		user := &models.User{ID: userID, Email: "user@example.com"}
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(verifiedIdentity, nil)
+		m.userRepo.EXPECT().FindByIdentity(gomock.Any(), "acme", "sub123").Return(nil, apperrors.ErrUserNotFound)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		m.provider.EXPECT().TrustsEmail().Return(true)
+		m.userRepo.EXPECT().
+			AddIdentity(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id primitive.ObjectID, identity models.ExternalIdentity) error {
+				assert.Equal(t, "acme", identity.Provider)
+				assert.Equal(t, "sub123", identity.Subject)
+				return nil
+			})
+		m.expectTokens(userID)
+
+		resp, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		require.NoError(t, err)
+		require.Len(t, resp.User.Identities, 1)
+		assert.Equal(t, "acme", resp.User.Identities[0].Provider)
+	})
+
+	t.Run("refuses to link existing user when provider is not trusted for emails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+		user := &models.User{ID: userID, Email: "user@example.com"}
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(verifiedIdentity, nil)
+		m.userRepo.EXPECT().FindByIdentity(gomock.Any(), "acme", "sub123").Return(nil, apperrors.ErrUserNotFound)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		m.provider.EXPECT().TrustsEmail().Return(false)
+
+		resp, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, apperrors.ErrOIDCAccountExists)
+	})
+
+	t.Run("creates user for new identity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(verifiedIdentity, nil)
+		m.userRepo.EXPECT().FindByIdentity(gomock.Any(), "acme", "sub123").Return(nil, apperrors.ErrUserNotFound)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), "user@example.com").Return(nil, apperrors.ErrUserNotFound)
+		m.userRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, user *models.User) error {
+				assert.Equal(t, "user@example.com", user.Email)
+				assert.Equal(t, "Provider User", user.Name)
+				assert.Empty(t, user.Password)
+				require.Len(t, user.Identities, 1)
+				assert.Equal(t, "sub123", user.Identities[0].Subject)
+				user.ID = userID
+				return nil
+			})
+		m.expectTokens(userID)
+
+		resp, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, resp.User.ID)
+	})
+
+	t.Run("returns mfa challenge for user with mfa enabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+		user := &models.User{ID: userID, Email: "user@example.com", MFAEnabled: true}
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(verifiedIdentity, nil)
+		m.userRepo.EXPECT().FindByIdentity(gomock.Any(), "acme", "sub123").Return(user, nil)
+		m.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), 5*time.Minute).Return(nil)
+
+		resp, challenge, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+		require.NotNil(t, challenge)
+		assert.True(t, challenge.MFARequired)
+	})
+
+	t.Run("rejects unverified email without linked identity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+		unverified := &auth.OIDCIdentity{Subject: "sub123", Email: "user@example.com"}
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(unverified, nil)
+		m.userRepo.EXPECT().FindByIdentity(gomock.Any(), "acme", "sub123").Return(nil, apperrors.ErrUserNotFound)
+
+		_, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		assert.ErrorIs(t, err, apperrors.ErrOIDCEmailNotVerified)
+	})
+
+	t.Run("returns login failed when exchange fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+
+		m.expectOIDCState("acme")
+		m.provider.EXPECT().Exchange(gomock.Any(), "code123", "verifier123", "nonce123").Return(nil, assert.AnError)
+
+		_, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		assert.ErrorIs(t, err, apperrors.ErrOIDCLoginFailed)
+	})
+
+	t.Run("rejects unknown state", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+
+		m.cache.EXPECT().GetDel(gomock.Any(), "oidc_state:oidc_state123", gomock.Any()).Return(false, nil)
+
+		_, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOIDCState)
+	})
+
+	t.Run("rejects state that cannot be consumed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+
+		m.cache.EXPECT().GetDel(gomock.Any(), "oidc_state:oidc_state123", gomock.Any()).Return(false, assert.AnError)
+
+		_, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOIDCState)
+	})
+
+	t.Run("rejects state issued for another provider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestAuthServiceWithOIDC(ctrl)
+
+		m.cache.EXPECT().
+			GetDel(gomock.Any(), "oidc_state:oidc_state123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string, dest interface{}) (bool, error) {
+				*dest.(*oidcLoginState) = oidcLoginState{Provider: "other", ExpiresAt: time.Now().Add(time.Minute)}
+				return true, nil
+			})
+
+		_, _, err := service.CompleteOIDCLogin(context.Background(), "acme", req, models.ClientInfo{})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOIDCState)
+	})
+
+	t.Run("returns error for unknown provider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, _ := newTestAuthServiceWithOIDC(ctrl)
+
+		_, _, err := service.CompleteOIDCLogin(context.Background(), "unknown", req, models.ClientInfo{})
+
+		assert.ErrorIs(t, err, apperrors.ErrOIDCProviderNotFound)
+	})
+}
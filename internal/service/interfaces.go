@@ -5,17 +5,43 @@ import (
 	"context"
 
 	"gin-sample/internal/models"
+	"gin-sample/pkg/auth"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AuthServicer defines the interface for authentication operations.
 type AuthServicer interface {
-	Register(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error)
-	Login(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error)
-	Refresh(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error)
-	Logout(ctx context.Context, req *models.LogoutRequest) error
+	Register(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error)
+	Login(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error)
+	VerifyMFA(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error)
+	Refresh(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error)
+	Logout(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error
 	LogoutAll(ctx context.Context, userID primitive.ObjectID) error
+	ListSessions(ctx context.Context, userID primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error)
+	RevokeSession(ctx context.Context, userID primitive.ObjectID, sessionID string) error
+	ChangePassword(ctx context.Context, userID primitive.ObjectID, req *models.ChangePasswordRequest) error
+	CreateScopedToken(ctx context.Context, userID primitive.ObjectID, sessionID string, req *models.ScopedTokenRequest) (*models.ScopedTokenResponse, error)
+	ListOIDCProviders() *models.OIDCProviderListResponse
+	StartOIDCLogin(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error)
+	CompleteOIDCLogin(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error)
+}
+
+// MFAServicer defines the interface for MFA enrollment operations.
+type MFAServicer interface {
+	GetStatus(ctx context.Context, userID primitive.ObjectID) (*models.MFAStatusResponse, error)
+	Enroll(ctx context.Context, userID primitive.ObjectID) (*models.MFAEnrollResponse, error)
+	Activate(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID primitive.ObjectID, req *models.MFADisableRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error)
+}
+
+// APIKeyServicer defines the interface for API key operations.
+type APIKeyServicer interface {
+	CreateAPIKey(ctx context.Context, userID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID primitive.ObjectID) (*models.APIKeyListResponse, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID primitive.ObjectID) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error)
 }
 
 // UserServicer defines the interface for user operations.
@@ -31,28 +57,39 @@ type TeamServicer interface {
 	CreateTeam(ctx context.Context, userID primitive.ObjectID, req *models.CreateTeamRequest) (*models.Team, error)
 	ListTeams(ctx context.Context, userID primitive.ObjectID, page, limit int) (*models.TeamListResponse, error)
 	GetTeam(ctx context.Context, teamID primitive.ObjectID) (*models.Team, error)
-	UpdateTeam(ctx context.Context, teamID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error)
-	DeleteTeam(ctx context.Context, teamID primitive.ObjectID) error
+	UpdateTeam(ctx context.Context, teamID, actorID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error)
+	DeleteTeam(ctx context.Context, teamID, actorID primitive.ObjectID) error
 	TransferOwnership(ctx context.Context, teamID, currentOwnerID, newOwnerID primitive.ObjectID) error
 }
 
 // TeamMemberServicer defines the interface for team member operations.
 type TeamMemberServicer interface {
-	ListMembers(ctx context.Context, teamID primitive.ObjectID) (*models.TeamMemberListResponse, error)
+	ListMembers(ctx context.Context, teamID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error)
 	RemoveMember(ctx context.Context, teamID, targetUserID, requestingUserID primitive.ObjectID) error
 	UpdateRole(ctx context.Context, teamID, targetUserID, requestingUserID primitive.ObjectID, newRole string) error
 	LeaveTeam(ctx context.Context, teamID, userID primitive.ObjectID) error
 	GetMember(ctx context.Context, teamID, userID primitive.ObjectID) (*models.TeamMember, error)
 }
 
+// TeamRoleServicer defines the interface for custom team role operations.
+type TeamRoleServicer interface {
+	ListRoles(ctx context.Context, teamID primitive.ObjectID) (*models.TeamRoleListResponse, error)
+	CreateRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, req *models.CreateTeamRoleRequest) (*models.TeamRole, error)
+	UpdateRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string, req *models.UpdateTeamRoleRequest) (*models.TeamRole, error)
+	DeleteRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string) error
+}
+
 // TeamInvitationServicer defines the interface for invitation operations.
 type TeamInvitationServicer interface {
 	CreateInvitation(ctx context.Context, teamID, inviterID primitive.ObjectID, req *models.CreateInvitationRequest) (*models.TeamInvitation, error)
 	ListTeamInvitations(ctx context.Context, teamID primitive.ObjectID) (*models.InvitationListResponse, error)
-	CancelInvitation(ctx context.Context, invitationID, teamID primitive.ObjectID) error
+	ListInvitationHistory(ctx context.Context, teamID primitive.ObjectID, page, limit int) (*models.InvitationHistoryResponse, error)
+	CancelInvitation(ctx context.Context, invitationID, teamID, cancelledBy primitive.ObjectID) error
+	ResendInvitation(ctx context.Context, invitationID, teamID, resentBy primitive.ObjectID) (*models.TeamInvitation, error)
+	ExtendInvitation(ctx context.Context, invitationID, teamID, extendedBy primitive.ObjectID, req *models.ExtendInvitationRequest) (*models.TeamInvitation, error)
 	ListMyInvitations(ctx context.Context, userEmail string) (*models.MyInvitationListResponse, error)
 	AcceptInvitation(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) (*models.AcceptInvitationResponse, error)
-	DeclineInvitation(ctx context.Context, invitationID primitive.ObjectID, userEmail string) error
+	DeclineInvitation(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) error
 }
 
 // VoiceMemoServicer defines the interface for voice memo operations.
@@ -64,21 +101,34 @@ type VoiceMemoServicer interface {
 	DeleteVoiceMemo(ctx context.Context, memoID, userID primitive.ObjectID) error
 	ConfirmUpload(ctx context.Context, memoID, userID primitive.ObjectID) error
 	RetryTranscription(ctx context.Context, memoID, userID primitive.ObjectID) error
+	GetUserUsage(ctx context.Context, userID primitive.ObjectID) (*models.UsageResponse, error)
 
 	// Team voice memo operations
 	ListByTeamID(ctx context.Context, teamID string, page, limit int) (*models.VoiceMemoListResponse, error)
 	CreateTeamVoiceMemo(ctx context.Context, userID, teamID primitive.ObjectID, req *models.CreateVoiceMemoRequest) (*models.CreateVoiceMemoResponse, error)
-	DeleteTeamVoiceMemo(ctx context.Context, memoID, teamID primitive.ObjectID) error
+	DeleteTeamVoiceMemo(ctx context.Context, memoID, teamID, actorID primitive.ObjectID) error
+	GetTeamVoiceMemoOwner(ctx context.Context, memoID, teamID primitive.ObjectID) (primitive.ObjectID, error)
 	ConfirmTeamUpload(ctx context.Context, memoID, teamID primitive.ObjectID) error
 	RetryTeamTranscription(ctx context.Context, memoID, teamID primitive.ObjectID) error
+	GetTeamUsage(ctx context.Context, teamID primitive.ObjectID) (*models.UsageResponse, error)
+}
+
+// AuditLogServicer defines the interface for audit log operations.
+type AuditLogServicer interface {
+	ListTeamAuditLog(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (*models.AuditLogListResponse, error)
 }
 
 // Ensure concrete types implement interfaces
 var (
 	_ AuthServicer           = (*AuthService)(nil)
+	_ MFAServicer            = (*MFAService)(nil)
+	_ APIKeyServicer         = (*APIKeyService)(nil)
 	_ UserServicer           = (*UserService)(nil)
 	_ TeamServicer           = (*TeamService)(nil)
 	_ TeamMemberServicer     = (*TeamMemberService)(nil)
+	_ TeamRoleServicer       = (*TeamRoleService)(nil)
 	_ TeamInvitationServicer = (*TeamInvitationService)(nil)
 	_ VoiceMemoServicer      = (*VoiceMemoService)(nil)
+	_ AuditLogServicer       = (*AuditLogService)(nil)
+	_ AuditRecorder          = (*AuditLogService)(nil)
 )
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gin-sample/internal/cache"
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	"gin-sample/internal/repository"
+	"gin-sample/pkg/auth"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MFAMaxAttempts is the number of codes that can be tried for one MFA login challenge,
+// and by a user managing MFA settings within mfaAttemptWindow.
+const MFAMaxAttempts = 5
+
+// mfaAttemptWindow is how long codes tried to manage MFA settings count towards MFAMaxAttempts.
+const mfaAttemptWindow = 15 * time.Minute
+
+// mfaCodeReplayWindow covers the TOTP validation window (current period plus one period
+// of skew on either side), so a code cannot be used twice.
+const mfaCodeReplayWindow = 90 * time.Second
+
+// MFAService handles TOTP enrollment and recovery code management.
+type MFAService struct {
+	userRepo repository.UserRepository
+	cache    cache.Cache
+	totp     auth.TOTPProvider
+	verifier *mfaVerifier
+}
+
+// NewMFAService creates a new MFAService.
+func NewMFAService(userRepo repository.UserRepository, cache cache.Cache, totp auth.TOTPProvider) *MFAService {
+	return &MFAService{
+		userRepo: userRepo,
+		cache:    cache,
+		totp:     totp,
+		verifier: &mfaVerifier{userRepo: userRepo, cache: cache, totp: totp},
+	}
+}
+
+// GetStatus returns the MFA enrollment status for a user.
+func (s *MFAService) GetStatus(ctx context.Context, userID primitive.ObjectID) (*models.MFAStatusResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MFAStatusResponse{
+		Enabled:                user.MFAEnabled,
+		EnabledAt:              user.MFAEnabledAt,
+		RecoveryCodesRemaining: len(user.MFARecoveryCodes),
+	}, nil
+}
+
+// Enroll starts TOTP enrollment by generating a new secret.
+// MFA is not enabled until the secret is confirmed with Activate.
+func (s *MFAService) Enroll(ctx context.Context, userID primitive.ObjectID) (*models.MFAEnrollResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, apperrors.ErrMFAAlreadyEnabled
+	}
+
+	secret, uri, err := s.totp.GenerateSecret(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.SetMFAPendingSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: uri,
+	}, nil
+}
+
+// Activate confirms enrollment with a code from the authenticator app, enables MFA
+// and returns the recovery codes. The codes are only returned once.
+// Codes are limited to MFAMaxAttempts per user within mfaAttemptWindow.
+func (s *MFAService) Activate(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, apperrors.ErrMFAAlreadyEnabled
+	}
+	if user.MFAPendingSecret == "" {
+		return nil, apperrors.ErrMFANotEnrolled
+	}
+
+	if err := s.verifier.verifyLimited(ctx, user, user.MFAPendingSecret, req.Code, cache.MFAActivationAttemptsCacheKey(userID.Hex()), mfaAttemptWindow); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.EnableMFA(ctx, userID, user.MFAPendingSecret, hashes); err != nil {
+		return nil, err
+	}
+
+	// Invalidate cached user so mfaEnabled is up to date
+	_ = s.cache.Delete(ctx, cache.UserCacheKey(userID.Hex()))
+
+	return &models.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns MFA off. The user must re-authenticate with their password and a current code.
+// Codes are limited to MFAMaxAttempts per user within mfaAttemptWindow.
+func (s *MFAService) Disable(ctx context.Context, userID primitive.ObjectID, req *models.MFADisableRequest) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled {
+		return apperrors.ErrMFANotEnabled
+	}
+
+	if err := auth.CheckPassword(req.Password, user.Password); err != nil {
+		return apperrors.ErrInvalidCredentials
+	}
+
+	if err := s.verifier.verifyLimited(ctx, user, user.MFASecret, req.Code, cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.DisableMFA(ctx, userID); err != nil {
+		return err
+	}
+
+	// Invalidate cached user so mfaEnabled is up to date
+	_ = s.cache.Delete(ctx, cache.UserCacheKey(userID.Hex()))
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after verifying a current code.
+// Codes are limited to MFAMaxAttempts per user within mfaAttemptWindow.
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		return nil, apperrors.ErrMFANotEnabled
+	}
+
+	if err := s.verifier.verifyLimited(ctx, user, user.MFASecret, req.Code, cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.SetRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &models.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// newRecoveryCodes generates a fresh set of recovery codes and their hashes for storage.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := auth.GenerateRecoveryCodes(auth.RecoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = auth.HashRecoveryCode(code)
+	}
+
+	return codes, hashes, nil
+}
+
+// mfaVerifier checks second-factor codes for users with MFA enabled.
+// It is shared by the login and MFA management flows.
+type mfaVerifier struct {
+	userRepo repository.UserRepository
+	cache    cache.Cache
+	totp     auth.TOTPProvider
+}
+
+// verify accepts a TOTP code for secret that has not been used yet or, if the user has MFA
+// enabled, an unused recovery code. A recovery code is consumed when it is accepted.
+func (v *mfaVerifier) verify(ctx context.Context, user *models.User, secret, code string) error {
+	code = strings.TrimSpace(code)
+
+	if v.totp.Validate(code, secret) {
+		// Claim the code so it cannot be replayed; a code that was already claimed is rejected
+		claimed, err := v.cache.SetNX(ctx, cache.MFAUsedCodeCacheKey(user.ID.Hex(), code), true, mfaCodeReplayWindow)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return apperrors.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	if !user.MFAEnabled {
+		return apperrors.ErrInvalidMFACode
+	}
+
+	consumed, err := v.userRepo.ConsumeRecoveryCode(ctx, user.ID, auth.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return apperrors.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// verifyLimited verifies a code like verify, but allows at most MFAMaxAttempts codes to be
+// tried per attemptsKey until window has passed since the first one.
+// Attempts are counted before the code is checked, so concurrent requests cannot exceed the limit.
+// The count is cleared when a code is accepted.
+func (v *mfaVerifier) verifyLimited(ctx context.Context, user *models.User, secret, code, attemptsKey string, window time.Duration) error {
+	attempts, err := v.cache.Incr(ctx, attemptsKey, window)
+	if err != nil {
+		return err
+	}
+	if attempts > MFAMaxAttempts {
+		return apperrors.ErrTooManyMFAAttempts
+	}
+
+	if err := v.verify(ctx, user, secret, code); err != nil {
+		return err
+	}
+
+	_ = v.cache.Delete(ctx, attemptsKey)
+	return nil
+}
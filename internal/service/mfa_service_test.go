@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gin-sample/internal/cache"
+	cachemocks "gin-sample/internal/cache/mocks"
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	repomocks "gin-sample/internal/repository/mocks"
+	"gin-sample/pkg/auth"
+	authmocks "gin-sample/pkg/auth/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewMFAService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewMFAService(repomocks.NewMockUserRepository(ctrl), cachemocks.NewMockCache(ctrl), authmocks.NewMockTOTPProvider(ctrl))
+
+	assert.NotNil(t, service)
+}
+
+func TestMFAService_GetStatus(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("returns enrollment status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		enabledAt := time.Now()
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{
+				ID:               userID,
+				MFAEnabled:       true,
+				MFAEnabledAt:     &enabledAt,
+				MFARecoveryCodes: []string{"hash1", "hash2"},
+			}, nil)
+
+		service := NewMFAService(mockUserRepo, cachemocks.NewMockCache(ctrl), authmocks.NewMockTOTPProvider(ctrl))
+		result, err := service.GetStatus(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.True(t, result.Enabled)
+		assert.Equal(t, &enabledAt, result.EnabledAt)
+		assert.Equal(t, 2, result.RecoveryCodesRemaining)
+	})
+}
+
+func TestMFAService_Enroll(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("stores pending secret and returns uri", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, Email: "user@example.com"}, nil)
+
+		mockTOTP.EXPECT().
+			GenerateSecret("user@example.com").
+			Return("SECRET", "otpauth://totp/gin-sample:user@example.com?secret=SECRET", nil)
+
+		mockUserRepo.EXPECT().
+			SetMFAPendingSecret(gomock.Any(), userID, "SECRET").
+			Return(nil)
+
+		service := NewMFAService(mockUserRepo, cachemocks.NewMockCache(ctrl), mockTOTP)
+		result, err := service.Enroll(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET", result.Secret)
+		assert.Contains(t, result.OTPAuthURI, "otpauth://totp/")
+	})
+
+	t.Run("returns error when mfa is already enabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, MFAEnabled: true}, nil)
+
+		service := NewMFAService(mockUserRepo, cachemocks.NewMockCache(ctrl), authmocks.NewMockTOTPProvider(ctrl))
+		result, err := service.Enroll(context.Background(), userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.ErrMFAAlreadyEnabled, err)
+	})
+}
+
+func TestMFAService_Activate(t *testing.T) {
+	userID := primitive.NewObjectID()
+	req := &models.MFACodeRequest{Code: "123456"}
+	attemptsKey := cache.MFAActivationAttemptsCacheKey(userID.Hex())
+
+	t.Run("enables mfa and returns recovery codes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, MFAPendingSecret: "SECRET"}, nil)
+
+		mockCache.EXPECT().Incr(gomock.Any(), attemptsKey, mfaAttemptWindow).Return(int64(1), nil)
+		mockTOTP.EXPECT().Validate("123456", "SECRET").Return(true)
+		mockCache.EXPECT().SetNX(gomock.Any(), cache.MFAUsedCodeCacheKey(userID.Hex(), "123456"), true, mfaCodeReplayWindow).Return(true, nil)
+		mockCache.EXPECT().Delete(gomock.Any(), attemptsKey).Return(nil)
+
+		var storedHashes []string
+		mockUserRepo.EXPECT().
+			EnableMFA(gomock.Any(), userID, "SECRET", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id primitive.ObjectID, secret string, hashes []string) error {
+				storedHashes = hashes
+				return nil
+			})
+
+		mockCache.EXPECT().Delete(gomock.Any(), cache.UserCacheKey(userID.Hex())).Return(nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		result, err := service.Activate(context.Background(), userID, req)
+
+		require.NoError(t, err)
+		assert.Len(t, result.RecoveryCodes, auth.RecoveryCodeCount)
+		require.Len(t, storedHashes, auth.RecoveryCodeCount)
+		// Only hashes are stored
+		assert.Equal(t, auth.HashRecoveryCode(result.RecoveryCodes[0]), storedHashes[0])
+	})
+
+	t.Run("returns error when enrollment was not started", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID}, nil)
+
+		service := NewMFAService(mockUserRepo, cachemocks.NewMockCache(ctrl), authmocks.NewMockTOTPProvider(ctrl))
+		_, err := service.Activate(context.Background(), userID, req)
+
+		assert.Equal(t, apperrors.ErrMFANotEnrolled, err)
+	})
+
+	t.Run("returns error for invalid code without trying recovery codes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, MFAPendingSecret: "SECRET"}, nil)
+
+		mockCache.EXPECT().Incr(gomock.Any(), attemptsKey, mfaAttemptWindow).Return(int64(2), nil)
+		mockTOTP.EXPECT().Validate("123456", "SECRET").Return(false)
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		_, err := service.Activate(context.Background(), userID, req)
+
+		assert.Equal(t, apperrors.ErrInvalidMFACode, err)
+	})
+
+	t.Run("rejects replayed code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, MFAPendingSecret: "SECRET"}, nil)
+
+		mockCache.EXPECT().Incr(gomock.Any(), attemptsKey, mfaAttemptWindow).Return(int64(1), nil)
+		mockTOTP.EXPECT().Validate("123456", "SECRET").Return(true)
+		mockCache.EXPECT().SetNX(gomock.Any(), cache.MFAUsedCodeCacheKey(userID.Hex(), "123456"), true, mfaCodeReplayWindow).Return(false, nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		_, err := service.Activate(context.Background(), userID, req)
+
+		assert.Equal(t, apperrors.ErrInvalidMFACode, err)
+	})
+
+	t.Run("fails when the code cannot be claimed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, MFAPendingSecret: "SECRET"}, nil)
+
+		mockCache.EXPECT().Incr(gomock.Any(), attemptsKey, mfaAttemptWindow).Return(int64(1), nil)
+		mockTOTP.EXPECT().Validate("123456", "SECRET").Return(true)
+		mockCache.EXPECT().SetNX(gomock.Any(), cache.MFAUsedCodeCacheKey(userID.Hex(), "123456"), true, mfaCodeReplayWindow).Return(false, errors.New("redis down"))
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		_, err := service.Activate(context.Background(), userID, req)
+
+		assert.EqualError(t, err, "redis down")
+	})
+
+	t.Run("rejects codes after max attempts without checking them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(&models.User{ID: userID, MFAPendingSecret: "SECRET"}, nil)
+
+		mockCache.EXPECT().Incr(gomock.Any(), attemptsKey, mfaAttemptWindow).Return(int64(MFAMaxAttempts+1), nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, authmocks.NewMockTOTPProvider(ctrl))
+		_, err := service.Activate(context.Background(), userID, req)
+
+		assert.Equal(t, apperrors.ErrTooManyMFAAttempts, err)
+	})
+}
+
+func TestMFAService_Disable(t *testing.T) {
+	userID := primitive.NewObjectID()
+	hashedPassword, _ := auth.HashPassword("password123")
+	mfaUser := &models.User{
+		ID:         userID,
+		Password:   hashedPassword,
+		MFAEnabled: true,
+		MFASecret:  "SECRET",
+	}
+
+	t.Run("disables mfa after re-authentication", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockCache.EXPECT().Incr(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow).Return(int64(1), nil)
+		mockTOTP.EXPECT().Validate("123456", "SECRET").Return(true)
+		mockCache.EXPECT().SetNX(gomock.Any(), cache.MFAUsedCodeCacheKey(userID.Hex(), "123456"), true, mfaCodeReplayWindow).Return(true, nil)
+		mockCache.EXPECT().Delete(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex())).Return(nil)
+		mockUserRepo.EXPECT().DisableMFA(gomock.Any(), userID).Return(nil)
+		mockCache.EXPECT().Delete(gomock.Any(), cache.UserCacheKey(userID.Hex())).Return(nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		err := service.Disable(context.Background(), userID, &models.MFADisableRequest{Password: "password123", Code: "123456"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error for wrong password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+
+		service := NewMFAService(mockUserRepo, cachemocks.NewMockCache(ctrl), authmocks.NewMockTOTPProvider(ctrl))
+		err := service.Disable(context.Background(), userID, &models.MFADisableRequest{Password: "wrong", Code: "123456"})
+
+		assert.Equal(t, apperrors.ErrInvalidCredentials, err)
+	})
+
+	t.Run("returns error for invalid code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockCache.EXPECT().Incr(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow).Return(int64(2), nil)
+		mockTOTP.EXPECT().Validate("000000", "SECRET").Return(false)
+		mockUserRepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), userID, gomock.Any()).Return(false, nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		err := service.Disable(context.Background(), userID, &models.MFADisableRequest{Password: "password123", Code: "000000"})
+
+		assert.Equal(t, apperrors.ErrInvalidMFACode, err)
+	})
+
+	t.Run("rejects codes after max attempts without checking them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockCache.EXPECT().Incr(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow).Return(int64(MFAMaxAttempts+1), nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, authmocks.NewMockTOTPProvider(ctrl))
+		err := service.Disable(context.Background(), userID, &models.MFADisableRequest{Password: "password123", Code: "123456"})
+
+		assert.Equal(t, apperrors.ErrTooManyMFAAttempts, err)
+	})
+
+	t.Run("returns error when mfa is not enabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(&models.User{ID: userID}, nil)
+
+		service := NewMFAService(mockUserRepo, cachemocks.NewMockCache(ctrl), authmocks.NewMockTOTPProvider(ctrl))
+		err := service.Disable(context.Background(), userID, &models.MFADisableRequest{Password: "password123", Code: "123456"})
+
+		assert.Equal(t, apperrors.ErrMFANotEnabled, err)
+	})
+}
+
+func TestMFAService_RegenerateRecoveryCodes(t *testing.T) {
+	userID := primitive.NewObjectID()
+	mfaUser := &models.User{ID: userID, MFAEnabled: true, MFASecret: "SECRET"}
+
+	t.Run("replaces recovery codes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockCache.EXPECT().Incr(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow).Return(int64(1), nil)
+		mockTOTP.EXPECT().Validate("abcde-12345", "SECRET").Return(false)
+		mockUserRepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), userID, auth.HashRecoveryCode("abcde-12345")).Return(true, nil)
+		mockCache.EXPECT().Delete(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex())).Return(nil)
+		mockUserRepo.EXPECT().SetRecoveryCodes(gomock.Any(), userID, gomock.Len(auth.RecoveryCodeCount)).Return(nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		result, err := service.RegenerateRecoveryCodes(context.Background(), userID, &models.MFACodeRequest{Code: "abcde-12345"})
+
+		require.NoError(t, err)
+		assert.Len(t, result.RecoveryCodes, auth.RecoveryCodeCount)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		mockTOTP := authmocks.NewMockTOTPProvider(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockCache.EXPECT().Incr(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow).Return(int64(1), nil)
+		mockTOTP.EXPECT().Validate("abcde-12345", "SECRET").Return(false)
+		mockUserRepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), userID, gomock.Any()).Return(false, errors.New("database error"))
+
+		service := NewMFAService(mockUserRepo, mockCache, mockTOTP)
+		_, err := service.RegenerateRecoveryCodes(context.Background(), userID, &models.MFACodeRequest{Code: "abcde-12345"})
+
+		assert.EqualError(t, err, "database error")
+	})
+
+	t.Run("rejects codes after max attempts without checking them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+
+		mockUserRepo.EXPECT().FindByID(gomock.Any(), userID).Return(mfaUser, nil)
+		mockCache.EXPECT().Incr(gomock.Any(), cache.MFAAttemptsCacheKey(userID.Hex()), mfaAttemptWindow).Return(int64(MFAMaxAttempts+1), nil)
+
+		service := NewMFAService(mockUserRepo, mockCache, authmocks.NewMockTOTPProvider(ctrl))
+		_, err := service.RegenerateRecoveryCodes(context.Background(), userID, &models.MFACodeRequest{Code: "abcde-12345"})
+
+		assert.Equal(t, apperrors.ErrTooManyMFAAttempts, err)
+	})
+}
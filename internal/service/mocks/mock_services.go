@@ -5,43 +5,59 @@ import (
 	"context"
 
 	"gin-sample/internal/models"
+	"gin-sample/pkg/auth"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockAuthService is a mock implementation of AuthServicer.
 type MockAuthService struct {
-	RegisterFunc  func(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error)
-	LoginFunc     func(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error)
-	RefreshFunc   func(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error)
-	LogoutFunc    func(ctx context.Context, req *models.LogoutRequest) error
-	LogoutAllFunc func(ctx context.Context, userID primitive.ObjectID) error
-}
-
-func (m *MockAuthService) Register(ctx context.Context, req *models.CreateUserRequest) (*models.AuthResponse, error) {
+	RegisterFunc          func(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error)
+	LoginFunc             func(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error)
+	VerifyMFAFunc         func(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error)
+	RefreshFunc           func(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error)
+	LogoutFunc            func(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error
+	LogoutAllFunc         func(ctx context.Context, userID primitive.ObjectID) error
+	ListSessionsFunc      func(ctx context.Context, userID primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error)
+	RevokeSessionFunc     func(ctx context.Context, userID primitive.ObjectID, sessionID string) error
+	ChangePasswordFunc    func(ctx context.Context, userID primitive.ObjectID, req *models.ChangePasswordRequest) error
+	CreateScopedTokenFunc func(ctx context.Context, userID primitive.ObjectID, sessionID string, req *models.ScopedTokenRequest) (*models.ScopedTokenResponse, error)
+	ListOIDCProvidersFunc func() *models.OIDCProviderListResponse
+	StartOIDCLoginFunc    func(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error)
+	CompleteOIDCLoginFunc func(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error)
+}
+
+func (m *MockAuthService) Register(ctx context.Context, req *models.CreateUserRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 	if m.RegisterFunc != nil {
-		return m.RegisterFunc(ctx, req)
+		return m.RegisterFunc(ctx, req, client)
 	}
 	return nil, nil
 }
 
-func (m *MockAuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
+func (m *MockAuthService) Login(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
 	if m.LoginFunc != nil {
-		return m.LoginFunc(ctx, req)
+		return m.LoginFunc(ctx, req, client)
+	}
+	return nil, nil, nil
+}
+
+func (m *MockAuthService) VerifyMFA(ctx context.Context, req *models.MFAVerifyRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	if m.VerifyMFAFunc != nil {
+		return m.VerifyMFAFunc(ctx, req, client)
 	}
 	return nil, nil
 }
 
-func (m *MockAuthService) Refresh(ctx context.Context, req *models.RefreshRequest) (*models.RefreshResponse, error) {
+func (m *MockAuthService) Refresh(ctx context.Context, req *models.RefreshRequest, client models.ClientInfo) (*models.RefreshResponse, error) {
 	if m.RefreshFunc != nil {
-		return m.RefreshFunc(ctx, req)
+		return m.RefreshFunc(ctx, req, client)
 	}
 	return nil, nil
 }
 
-func (m *MockAuthService) Logout(ctx context.Context, req *models.LogoutRequest) error {
+func (m *MockAuthService) Logout(ctx context.Context, req *models.LogoutRequest, accessClaims *auth.Claims) error {
 	if m.LogoutFunc != nil {
-		return m.LogoutFunc(ctx, req)
+		return m.LogoutFunc(ctx, req, accessClaims)
 	}
 	return nil
 }
@@ -53,6 +69,135 @@ func (m *MockAuthService) LogoutAll(ctx context.Context, userID primitive.Object
 	return nil
 }
 
+func (m *MockAuthService) ListSessions(ctx context.Context, userID primitive.ObjectID, currentSessionID string) (*models.SessionListResponse, error) {
+	if m.ListSessionsFunc != nil {
+		return m.ListSessionsFunc(ctx, userID, currentSessionID)
+	}
+	return nil, nil
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID primitive.ObjectID, sessionID string) error {
+	if m.RevokeSessionFunc != nil {
+		return m.RevokeSessionFunc(ctx, userID, sessionID)
+	}
+	return nil
+}
+
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID primitive.ObjectID, req *models.ChangePasswordRequest) error {
+	if m.ChangePasswordFunc != nil {
+		return m.ChangePasswordFunc(ctx, userID, req)
+	}
+	return nil
+}
+
+func (m *MockAuthService) CreateScopedToken(ctx context.Context, userID primitive.ObjectID, sessionID string, req *models.ScopedTokenRequest) (*models.ScopedTokenResponse, error) {
+	if m.CreateScopedTokenFunc != nil {
+		return m.CreateScopedTokenFunc(ctx, userID, sessionID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockAuthService) ListOIDCProviders() *models.OIDCProviderListResponse {
+	if m.ListOIDCProvidersFunc != nil {
+		return m.ListOIDCProvidersFunc()
+	}
+	return &models.OIDCProviderListResponse{Items: []models.OIDCProviderInfo{}}
+}
+
+func (m *MockAuthService) StartOIDCLogin(ctx context.Context, providerName string) (*models.OIDCAuthorizeResponse, error) {
+	if m.StartOIDCLoginFunc != nil {
+		return m.StartOIDCLoginFunc(ctx, providerName)
+	}
+	return nil, nil
+}
+
+func (m *MockAuthService) CompleteOIDCLogin(ctx context.Context, providerName string, req *models.OIDCCallbackRequest, client models.ClientInfo) (*models.AuthResponse, *models.MFAChallengeResponse, error) {
+	if m.CompleteOIDCLoginFunc != nil {
+		return m.CompleteOIDCLoginFunc(ctx, providerName, req, client)
+	}
+	return nil, nil, nil
+}
+
+// MockMFAService is a mock implementation of MFAServicer.
+type MockMFAService struct {
+	GetStatusFunc               func(ctx context.Context, userID primitive.ObjectID) (*models.MFAStatusResponse, error)
+	EnrollFunc                  func(ctx context.Context, userID primitive.ObjectID) (*models.MFAEnrollResponse, error)
+	ActivateFunc                func(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error)
+	DisableFunc                 func(ctx context.Context, userID primitive.ObjectID, req *models.MFADisableRequest) error
+	RegenerateRecoveryCodesFunc func(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error)
+}
+
+func (m *MockMFAService) GetStatus(ctx context.Context, userID primitive.ObjectID) (*models.MFAStatusResponse, error) {
+	if m.GetStatusFunc != nil {
+		return m.GetStatusFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockMFAService) Enroll(ctx context.Context, userID primitive.ObjectID) (*models.MFAEnrollResponse, error) {
+	if m.EnrollFunc != nil {
+		return m.EnrollFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockMFAService) Activate(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+	if m.ActivateFunc != nil {
+		return m.ActivateFunc(ctx, userID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockMFAService) Disable(ctx context.Context, userID primitive.ObjectID, req *models.MFADisableRequest) error {
+	if m.DisableFunc != nil {
+		return m.DisableFunc(ctx, userID, req)
+	}
+	return nil
+}
+
+func (m *MockMFAService) RegenerateRecoveryCodes(ctx context.Context, userID primitive.ObjectID, req *models.MFACodeRequest) (*models.MFARecoveryCodesResponse, error) {
+	if m.RegenerateRecoveryCodesFunc != nil {
+		return m.RegenerateRecoveryCodesFunc(ctx, userID, req)
+	}
+	return nil, nil
+}
+
+// MockAPIKeyService is a mock implementation of APIKeyServicer.
+type MockAPIKeyService struct {
+	CreateAPIKeyFunc       func(ctx context.Context, userID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error)
+	ListAPIKeysFunc        func(ctx context.Context, userID primitive.ObjectID) (*models.APIKeyListResponse, error)
+	RevokeAPIKeyFunc       func(ctx context.Context, userID, keyID primitive.ObjectID) error
+	AuthenticateAPIKeyFunc func(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+func (m *MockAPIKeyService) CreateAPIKey(ctx context.Context, userID primitive.ObjectID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	if m.CreateAPIKeyFunc != nil {
+		return m.CreateAPIKeyFunc(ctx, userID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyService) ListAPIKeys(ctx context.Context, userID primitive.ObjectID) (*models.APIKeyListResponse, error) {
+	if m.ListAPIKeysFunc != nil {
+		return m.ListAPIKeysFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, userID, keyID primitive.ObjectID) error {
+	if m.RevokeAPIKeyFunc != nil {
+		return m.RevokeAPIKeyFunc(ctx, userID, keyID)
+	}
+	return nil
+}
+
+func (m *MockAPIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if m.AuthenticateAPIKeyFunc != nil {
+		return m.AuthenticateAPIKeyFunc(ctx, key)
+	}
+	return nil, nil
+}
+
 // MockUserService is a mock implementation of UserServicer.
 type MockUserService struct {
 	GetUserFunc     func(ctx context.Context, id primitive.ObjectID) (*models.User, error)
@@ -94,8 +239,8 @@ type MockTeamService struct {
 	CreateTeamFunc        func(ctx context.Context, userID primitive.ObjectID, req *models.CreateTeamRequest) (*models.Team, error)
 	ListTeamsFunc         func(ctx context.Context, userID primitive.ObjectID, page, limit int) (*models.TeamListResponse, error)
 	GetTeamFunc           func(ctx context.Context, teamID primitive.ObjectID) (*models.Team, error)
-	UpdateTeamFunc        func(ctx context.Context, teamID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error)
-	DeleteTeamFunc        func(ctx context.Context, teamID primitive.ObjectID) error
+	UpdateTeamFunc        func(ctx context.Context, teamID, actorID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error)
+	DeleteTeamFunc        func(ctx context.Context, teamID, actorID primitive.ObjectID) error
 	TransferOwnershipFunc func(ctx context.Context, teamID, currentOwnerID, newOwnerID primitive.ObjectID) error
 }
 
@@ -120,16 +265,16 @@ func (m *MockTeamService) GetTeam(ctx context.Context, teamID primitive.ObjectID
 	return nil, nil
 }
 
-func (m *MockTeamService) UpdateTeam(ctx context.Context, teamID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+func (m *MockTeamService) UpdateTeam(ctx context.Context, teamID, actorID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
 	if m.UpdateTeamFunc != nil {
-		return m.UpdateTeamFunc(ctx, teamID, req)
+		return m.UpdateTeamFunc(ctx, teamID, actorID, req)
 	}
 	return nil, nil
 }
 
-func (m *MockTeamService) DeleteTeam(ctx context.Context, teamID primitive.ObjectID) error {
+func (m *MockTeamService) DeleteTeam(ctx context.Context, teamID, actorID primitive.ObjectID) error {
 	if m.DeleteTeamFunc != nil {
-		return m.DeleteTeamFunc(ctx, teamID)
+		return m.DeleteTeamFunc(ctx, teamID, actorID)
 	}
 	return nil
 }
@@ -143,16 +288,16 @@ func (m *MockTeamService) TransferOwnership(ctx context.Context, teamID, current
 
 // MockTeamMemberService is a mock implementation of TeamMemberServicer.
 type MockTeamMemberService struct {
-	ListMembersFunc  func(ctx context.Context, teamID primitive.ObjectID) (*models.TeamMemberListResponse, error)
+	ListMembersFunc  func(ctx context.Context, teamID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error)
 	RemoveMemberFunc func(ctx context.Context, teamID, targetUserID, requestingUserID primitive.ObjectID) error
 	UpdateRoleFunc   func(ctx context.Context, teamID, targetUserID, requestingUserID primitive.ObjectID, newRole string) error
 	LeaveTeamFunc    func(ctx context.Context, teamID, userID primitive.ObjectID) error
 	GetMemberFunc    func(ctx context.Context, teamID, userID primitive.ObjectID) (*models.TeamMember, error)
 }
 
-func (m *MockTeamMemberService) ListMembers(ctx context.Context, teamID primitive.ObjectID) (*models.TeamMemberListResponse, error) {
+func (m *MockTeamMemberService) ListMembers(ctx context.Context, teamID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error) {
 	if m.ListMembersFunc != nil {
-		return m.ListMembersFunc(ctx, teamID)
+		return m.ListMembersFunc(ctx, teamID, includeMFAStatus)
 	}
 	return nil, nil
 }
@@ -185,14 +330,53 @@ func (m *MockTeamMemberService) GetMember(ctx context.Context, teamID, userID pr
 	return nil, nil
 }
 
+// MockTeamRoleService is a mock implementation of TeamRoleServicer.
+type MockTeamRoleService struct {
+	ListRolesFunc  func(ctx context.Context, teamID primitive.ObjectID) (*models.TeamRoleListResponse, error)
+	CreateRoleFunc func(ctx context.Context, teamID, requestingUserID primitive.ObjectID, req *models.CreateTeamRoleRequest) (*models.TeamRole, error)
+	UpdateRoleFunc func(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string, req *models.UpdateTeamRoleRequest) (*models.TeamRole, error)
+	DeleteRoleFunc func(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string) error
+}
+
+func (m *MockTeamRoleService) ListRoles(ctx context.Context, teamID primitive.ObjectID) (*models.TeamRoleListResponse, error) {
+	if m.ListRolesFunc != nil {
+		return m.ListRolesFunc(ctx, teamID)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRoleService) CreateRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, req *models.CreateTeamRoleRequest) (*models.TeamRole, error) {
+	if m.CreateRoleFunc != nil {
+		return m.CreateRoleFunc(ctx, teamID, requestingUserID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRoleService) UpdateRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string, req *models.UpdateTeamRoleRequest) (*models.TeamRole, error) {
+	if m.UpdateRoleFunc != nil {
+		return m.UpdateRoleFunc(ctx, teamID, requestingUserID, name, req)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRoleService) DeleteRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string) error {
+	if m.DeleteRoleFunc != nil {
+		return m.DeleteRoleFunc(ctx, teamID, requestingUserID, name)
+	}
+	return nil
+}
+
 // MockTeamInvitationService is a mock implementation of TeamInvitationServicer.
 type MockTeamInvitationService struct {
-	CreateInvitationFunc    func(ctx context.Context, teamID, inviterID primitive.ObjectID, req *models.CreateInvitationRequest) (*models.TeamInvitation, error)
-	ListTeamInvitationsFunc func(ctx context.Context, teamID primitive.ObjectID) (*models.InvitationListResponse, error)
-	CancelInvitationFunc    func(ctx context.Context, invitationID, teamID primitive.ObjectID) error
-	ListMyInvitationsFunc   func(ctx context.Context, userEmail string) (*models.MyInvitationListResponse, error)
-	AcceptInvitationFunc    func(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) (*models.AcceptInvitationResponse, error)
-	DeclineInvitationFunc   func(ctx context.Context, invitationID primitive.ObjectID, userEmail string) error
+	CreateInvitationFunc      func(ctx context.Context, teamID, inviterID primitive.ObjectID, req *models.CreateInvitationRequest) (*models.TeamInvitation, error)
+	ListTeamInvitationsFunc   func(ctx context.Context, teamID primitive.ObjectID) (*models.InvitationListResponse, error)
+	ListInvitationHistoryFunc func(ctx context.Context, teamID primitive.ObjectID, page, limit int) (*models.InvitationHistoryResponse, error)
+	CancelInvitationFunc      func(ctx context.Context, invitationID, teamID, cancelledBy primitive.ObjectID) error
+	ResendInvitationFunc      func(ctx context.Context, invitationID, teamID, resentBy primitive.ObjectID) (*models.TeamInvitation, error)
+	ExtendInvitationFunc      func(ctx context.Context, invitationID, teamID, extendedBy primitive.ObjectID, req *models.ExtendInvitationRequest) (*models.TeamInvitation, error)
+	ListMyInvitationsFunc     func(ctx context.Context, userEmail string) (*models.MyInvitationListResponse, error)
+	AcceptInvitationFunc      func(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) (*models.AcceptInvitationResponse, error)
+	DeclineInvitationFunc     func(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) error
 }
 
 func (m *MockTeamInvitationService) CreateInvitation(ctx context.Context, teamID, inviterID primitive.ObjectID, req *models.CreateInvitationRequest) (*models.TeamInvitation, error) {
@@ -209,13 +393,34 @@ func (m *MockTeamInvitationService) ListTeamInvitations(ctx context.Context, tea
 	return nil, nil
 }
 
-func (m *MockTeamInvitationService) CancelInvitation(ctx context.Context, invitationID, teamID primitive.ObjectID) error {
+func (m *MockTeamInvitationService) ListInvitationHistory(ctx context.Context, teamID primitive.ObjectID, page, limit int) (*models.InvitationHistoryResponse, error) {
+	if m.ListInvitationHistoryFunc != nil {
+		return m.ListInvitationHistoryFunc(ctx, teamID, page, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamInvitationService) CancelInvitation(ctx context.Context, invitationID, teamID, cancelledBy primitive.ObjectID) error {
 	if m.CancelInvitationFunc != nil {
-		return m.CancelInvitationFunc(ctx, invitationID, teamID)
+		return m.CancelInvitationFunc(ctx, invitationID, teamID, cancelledBy)
 	}
 	return nil
 }
 
+func (m *MockTeamInvitationService) ResendInvitation(ctx context.Context, invitationID, teamID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+	if m.ResendInvitationFunc != nil {
+		return m.ResendInvitationFunc(ctx, invitationID, teamID, resentBy)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamInvitationService) ExtendInvitation(ctx context.Context, invitationID, teamID, extendedBy primitive.ObjectID, req *models.ExtendInvitationRequest) (*models.TeamInvitation, error) {
+	if m.ExtendInvitationFunc != nil {
+		return m.ExtendInvitationFunc(ctx, invitationID, teamID, extendedBy, req)
+	}
+	return nil, nil
+}
+
 func (m *MockTeamInvitationService) ListMyInvitations(ctx context.Context, userEmail string) (*models.MyInvitationListResponse, error) {
 	if m.ListMyInvitationsFunc != nil {
 		return m.ListMyInvitationsFunc(ctx, userEmail)
@@ -230,9 +435,9 @@ func (m *MockTeamInvitationService) AcceptInvitation(ctx context.Context, invita
 	return nil, nil
 }
 
-func (m *MockTeamInvitationService) DeclineInvitation(ctx context.Context, invitationID primitive.ObjectID, userEmail string) error {
+func (m *MockTeamInvitationService) DeclineInvitation(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) error {
 	if m.DeclineInvitationFunc != nil {
-		return m.DeclineInvitationFunc(ctx, invitationID, userEmail)
+		return m.DeclineInvitationFunc(ctx, invitationID, userID, userEmail)
 	}
 	return nil
 }
@@ -247,9 +452,12 @@ type MockVoiceMemoService struct {
 	RetryTranscriptionFunc     func(ctx context.Context, memoID, userID primitive.ObjectID) error
 	ListByTeamIDFunc           func(ctx context.Context, teamID string, page, limit int) (*models.VoiceMemoListResponse, error)
 	CreateTeamVoiceMemoFunc    func(ctx context.Context, userID, teamID primitive.ObjectID, req *models.CreateVoiceMemoRequest) (*models.CreateVoiceMemoResponse, error)
-	DeleteTeamVoiceMemoFunc    func(ctx context.Context, memoID, teamID primitive.ObjectID) error
+	DeleteTeamVoiceMemoFunc    func(ctx context.Context, memoID, teamID, actorID primitive.ObjectID) error
+	GetTeamVoiceMemoOwnerFunc  func(ctx context.Context, memoID, teamID primitive.ObjectID) (primitive.ObjectID, error)
 	ConfirmTeamUploadFunc      func(ctx context.Context, memoID, teamID primitive.ObjectID) error
 	RetryTeamTranscriptionFunc func(ctx context.Context, memoID, teamID primitive.ObjectID) error
+	GetUserUsageFunc           func(ctx context.Context, userID primitive.ObjectID) (*models.UsageResponse, error)
+	GetTeamUsageFunc           func(ctx context.Context, teamID primitive.ObjectID) (*models.UsageResponse, error)
 }
 
 func (m *MockVoiceMemoService) ListByUserID(ctx context.Context, userID string, page, limit int) (*models.VoiceMemoListResponse, error) {
@@ -308,13 +516,20 @@ func (m *MockVoiceMemoService) CreateTeamVoiceMemo(ctx context.Context, userID,
 	return nil, nil
 }
 
-func (m *MockVoiceMemoService) DeleteTeamVoiceMemo(ctx context.Context, memoID, teamID primitive.ObjectID) error {
+func (m *MockVoiceMemoService) DeleteTeamVoiceMemo(ctx context.Context, memoID, teamID, actorID primitive.ObjectID) error {
 	if m.DeleteTeamVoiceMemoFunc != nil {
-		return m.DeleteTeamVoiceMemoFunc(ctx, memoID, teamID)
+		return m.DeleteTeamVoiceMemoFunc(ctx, memoID, teamID, actorID)
 	}
 	return nil
 }
 
+func (m *MockVoiceMemoService) GetTeamVoiceMemoOwner(ctx context.Context, memoID, teamID primitive.ObjectID) (primitive.ObjectID, error) {
+	if m.GetTeamVoiceMemoOwnerFunc != nil {
+		return m.GetTeamVoiceMemoOwnerFunc(ctx, memoID, teamID)
+	}
+	return primitive.NilObjectID, nil
+}
+
 func (m *MockVoiceMemoService) ConfirmTeamUpload(ctx context.Context, memoID, teamID primitive.ObjectID) error {
 	if m.ConfirmTeamUploadFunc != nil {
 		return m.ConfirmTeamUploadFunc(ctx, memoID, teamID)
@@ -328,3 +543,29 @@ func (m *MockVoiceMemoService) RetryTeamTranscription(ctx context.Context, memoI
 	}
 	return nil
 }
+
+func (m *MockVoiceMemoService) GetUserUsage(ctx context.Context, userID primitive.ObjectID) (*models.UsageResponse, error) {
+	if m.GetUserUsageFunc != nil {
+		return m.GetUserUsageFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockVoiceMemoService) GetTeamUsage(ctx context.Context, teamID primitive.ObjectID) (*models.UsageResponse, error) {
+	if m.GetTeamUsageFunc != nil {
+		return m.GetTeamUsageFunc(ctx, teamID)
+	}
+	return nil, nil
+}
+
+// MockAuditLogService is a mock implementation of AuditLogServicer.
+type MockAuditLogService struct {
+	ListTeamAuditLogFunc func(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (*models.AuditLogListResponse, error)
+}
+
+func (m *MockAuditLogService) ListTeamAuditLog(ctx context.Context, teamID primitive.ObjectID, filter models.AuditLogFilter, page, limit int) (*models.AuditLogListResponse, error) {
+	if m.ListTeamAuditLogFunc != nil {
+		return m.ListTeamAuditLogFunc(ctx, teamID, filter, page, limit)
+	}
+	return nil, nil
+}
@@ -3,10 +3,11 @@ package service
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	"gin-sample/internal/repository"
@@ -20,27 +21,56 @@ type TeamInvitationService struct {
 	memberRepo     repository.TeamMemberRepository
 	teamRepo       repository.TeamRepository
 	userRepo       repository.UserRepository
+	roleRepo       repository.TeamRoleRepository
+	relationships  RelationshipWriter
+	audit          AuditRecorder
 }
 
 // NewTeamInvitationService creates a new TeamInvitationService.
+// If relationships is not nil, accepted invitations are recorded as authorization relationships.
+// If audit is not nil, created and cancelled invitations are written to the audit log.
 func NewTeamInvitationService(
 	invitationRepo repository.TeamInvitationRepository,
 	memberRepo repository.TeamMemberRepository,
 	teamRepo repository.TeamRepository,
 	userRepo repository.UserRepository,
+	roleRepo repository.TeamRoleRepository,
+	relationships RelationshipWriter,
+	audit AuditRecorder,
 ) *TeamInvitationService {
 	return &TeamInvitationService{
 		invitationRepo: invitationRepo,
 		memberRepo:     memberRepo,
 		teamRepo:       teamRepo,
 		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		relationships:  relationships,
+		audit:          audit,
 	}
 }
 
 // CreateInvitation creates a new invitation to join a team.
+// The invited role cannot grant actions the inviter does not have.
 func (s *TeamInvitationService) CreateInvitation(ctx context.Context, teamID, inviterID primitive.ObjectID, req *models.CreateInvitationRequest) (*models.TeamInvitation, error) {
 	email := strings.ToLower(strings.TrimSpace(req.Email))
 
+	newRoleActions, err := assignableRoleActions(ctx, s.roleRepo, teamID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	inviter, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, inviterID)
+	if err != nil {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	inviterActions, err := roleActions(ctx, s.roleRepo, teamID, inviter.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !authz.ActionsCover(inviterActions, newRoleActions) {
+		return nil, apperrors.ErrRoleExceedsPermissions
+	}
+
 	// Get team to check seats
 	team, err := s.teamRepo.FindByID(ctx, teamID)
 	if err != nil {
@@ -91,6 +121,14 @@ func (s *TeamInvitationService) CreateInvitation(ctx context.Context, teamID, in
 		return nil, err
 	}
 
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    inviterID,
+		Action:     authz.ActionMemberInvite,
+		TargetType: models.AuditTargetInvitation,
+		TargetID:   invitation.ID,
+		After:      invitationAuditState(invitation),
+	})
 	return invitation, nil
 }
 
@@ -106,8 +144,38 @@ func (s *TeamInvitationService) ListTeamInvitations(ctx context.Context, teamID
 	}, nil
 }
 
+// ListInvitationHistory returns paginated invitations for a team in any status.
+func (s *TeamInvitationService) ListInvitationHistory(ctx context.Context, teamID primitive.ObjectID, page, limit int) (*models.InvitationHistoryResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	invitations, total, err := s.invitationRepo.FindHistoryByTeamID(ctx, teamID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := total / limit
+	if total%limit > 0 {
+		totalPages++
+	}
+
+	return &models.InvitationHistoryResponse{
+		Items: invitations,
+		Pagination: models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
 // CancelInvitation cancels a pending invitation.
-func (s *TeamInvitationService) CancelInvitation(ctx context.Context, invitationID, teamID primitive.ObjectID) error {
+func (s *TeamInvitationService) CancelInvitation(ctx context.Context, invitationID, teamID, cancelledBy primitive.ObjectID) error {
 	// Verify invitation belongs to team
 	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
 	if err != nil {
@@ -116,8 +184,114 @@ func (s *TeamInvitationService) CancelInvitation(ctx context.Context, invitation
 	if invitation.TeamID != teamID {
 		return apperrors.ErrInvitationNotFound
 	}
+	if !isInvitationPending(invitation) {
+		return apperrors.ErrInvitationNotPending
+	}
 
-	return s.invitationRepo.Delete(ctx, invitationID)
+	if err := s.invitationRepo.Resolve(ctx, invitationID, models.InvitationStatusCancelled, cancelledBy); err != nil {
+		return err
+	}
+
+	before := invitationAuditState(invitation)
+	after := invitationAuditState(invitation)
+	after["status"] = models.InvitationStatusCancelled
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    cancelledBy,
+		Action:     models.AuditActionInvitationCancel,
+		TargetType: models.AuditTargetInvitation,
+		TargetID:   invitationID,
+		Before:     before,
+		After:      after,
+	})
+	return nil
+}
+
+// ResendInvitation resends an invitation and resets its expiry to a full validity period.
+// Expired invitations can be resent as long as the team still has a free seat.
+func (s *TeamInvitationService) ResendInvitation(ctx context.Context, invitationID, teamID, resentBy primitive.ObjectID) (*models.TeamInvitation, error) {
+	invitation, err := s.findRenewableInvitation(ctx, invitationID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, repository.InvitationExpiryDays)
+	renewed, err := s.invitationRepo.Renew(ctx, invitation.ID, expiresAt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, invitationRenewedEntry(models.AuditActionInvitationResend, resentBy, invitation, renewed))
+	return renewed, nil
+}
+
+// ExtendInvitation pushes an invitation's expiry back by the requested number of days.
+// Expired invitations are extended from now as long as the team still has a free seat.
+func (s *TeamInvitationService) ExtendInvitation(ctx context.Context, invitationID, teamID, extendedBy primitive.ObjectID, req *models.ExtendInvitationRequest) (*models.TeamInvitation, error) {
+	invitation, err := s.findRenewableInvitation(ctx, invitationID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	base := invitation.ExpiresAt
+	if now := time.Now(); base.Before(now) {
+		base = now
+	}
+
+	renewed, err := s.invitationRepo.Renew(ctx, invitation.ID, base.AddDate(0, 0, req.Days), false)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, invitationRenewedEntry(models.AuditActionInvitationExtend, extendedBy, invitation, renewed))
+	return renewed, nil
+}
+
+// findRenewableInvitation loads a team invitation that can be resent or extended.
+// Renewing an expired invitation makes it pending again, so it is refused if the email has
+// since been invited again, and the seat limit is rechecked.
+func (s *TeamInvitationService) findRenewableInvitation(ctx context.Context, invitationID, teamID primitive.ObjectID) (*models.TeamInvitation, error) {
+	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.TeamID != teamID {
+		return nil, apperrors.ErrInvitationNotFound
+	}
+	if !isInvitationPending(invitation) && invitation.Status != models.InvitationStatusExpired {
+		return nil, apperrors.ErrInvitationNotPending
+	}
+
+	if invitation.ExpiresAt.After(time.Now()) {
+		return invitation, nil
+	}
+
+	// Check for another pending invitation to the same email
+	_, err = s.invitationRepo.FindByTeamAndEmail(ctx, teamID, invitation.Email)
+	if err == nil {
+		return nil, apperrors.ErrPendingInvitation
+	}
+	if !errors.Is(err, apperrors.ErrInvitationNotFound) {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	memberCount, err := s.memberRepo.CountByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	invitationCount, err := s.invitationRepo.CountPendingByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if memberCount+invitationCount >= team.Seats {
+		return nil, apperrors.ErrSeatsExceeded
+	}
+
+	return invitation, nil
 }
 
 // ListMyInvitations returns all pending invitations for a user's email.
@@ -180,6 +354,10 @@ func (s *TeamInvitationService) AcceptInvitation(ctx context.Context, invitation
 		return nil, apperrors.ErrInvitationEmailMismatch
 	}
 
+	if !isInvitationPending(invitation) && invitation.Status != models.InvitationStatusExpired {
+		return nil, apperrors.ErrInvitationNotPending
+	}
+
 	// Check if invitation is expired
 	if invitation.ExpiresAt.Before(time.Now()) {
 		return nil, apperrors.ErrInvitationExpired
@@ -210,10 +388,15 @@ func (s *TeamInvitationService) AcceptInvitation(ctx context.Context, invitation
 	if err := s.memberRepo.Create(ctx, member); err != nil {
 		return nil, err
 	}
+	if err := writeMemberRelationship(ctx, s.relationships, invitation.TeamID, userID, invitation.Role); err != nil {
+		// Rollback member creation
+		_ = s.memberRepo.Delete(ctx, invitation.TeamID, userID)
+		return nil, err
+	}
 
-	// Delete the invitation (member already created, so log error but don't fail)
-	if err := s.invitationRepo.Delete(ctx, invitationID); err != nil {
-		log.Printf("Warning: failed to delete invitation %s after accepting: %v", invitationID.Hex(), err)
+	// Mark the invitation accepted (member already created, so log error but don't fail)
+	if err := s.invitationRepo.Resolve(ctx, invitationID, models.InvitationStatusAccepted, userID); err != nil {
+		slog.WarnContext(ctx, "failed to mark invitation accepted", "invitation_id", invitationID.Hex(), "error", err)
 	}
 
 	return &models.AcceptInvitationResponse{
@@ -222,8 +405,8 @@ func (s *TeamInvitationService) AcceptInvitation(ctx context.Context, invitation
 	}, nil
 }
 
-// DeclineInvitation declines an invitation.
-func (s *TeamInvitationService) DeclineInvitation(ctx context.Context, invitationID primitive.ObjectID, userEmail string) error {
+// DeclineInvitation declines an invitation and records who declined it.
+func (s *TeamInvitationService) DeclineInvitation(ctx context.Context, invitationID, userID primitive.ObjectID, userEmail string) error {
 	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
 	if err != nil {
 		return err
@@ -235,5 +418,46 @@ func (s *TeamInvitationService) DeclineInvitation(ctx context.Context, invitatio
 		return apperrors.ErrInvitationEmailMismatch
 	}
 
-	return s.invitationRepo.Delete(ctx, invitationID)
+	if !isInvitationPending(invitation) {
+		return apperrors.ErrInvitationNotPending
+	}
+
+	return s.invitationRepo.Resolve(ctx, invitationID, models.InvitationStatusDeclined, userID)
+}
+
+// isInvitationPending reports whether an invitation has not been accepted, declined, cancelled or marked expired.
+// Invitations created before status tracking have an empty status and are pending.
+func isInvitationPending(invitation *models.TeamInvitation) bool {
+	return invitation.Status == "" || invitation.Status == models.InvitationStatusPending
+}
+
+// invitationAuditState returns the audited fields of an invitation.
+// Invitations created before status tracking have no status and are reported as pending.
+func invitationAuditState(invitation *models.TeamInvitation) map[string]any {
+	status := invitation.Status
+	if status == "" {
+		status = models.InvitationStatusPending
+	}
+	return map[string]any{
+		"email":  invitation.Email,
+		"role":   invitation.Role,
+		"status": status,
+	}
+}
+
+// invitationRenewedEntry builds the audit entry for an invitation whose expiry was reset or extended.
+func invitationRenewedEntry(action string, actorID primitive.ObjectID, before, after *models.TeamInvitation) *models.AuditLogEntry {
+	beforeState := invitationAuditState(before)
+	beforeState["expiresAt"] = before.ExpiresAt
+	afterState := invitationAuditState(after)
+	afterState["expiresAt"] = after.ExpiresAt
+	return &models.AuditLogEntry{
+		TeamID:     before.TeamID,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: models.AuditTargetInvitation,
+		TargetID:   before.ID,
+		Before:     beforeState,
+		After:      afterState,
+	}
 }
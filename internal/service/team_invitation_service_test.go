@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	repomocks "gin-sample/internal/repository/mocks"
@@ -24,7 +26,7 @@ func TestNewTeamInvitationService(t *testing.T) {
 	mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 	mockUserRepo := repomocks.NewMockUserRepository(ctrl)
 
-	service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+	service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 
 	assert.NotNil(t, service)
 }
@@ -36,6 +38,7 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 		Email: "invitee@example.com",
 		Role:  models.RoleMember,
 	}
+	inviter := &models.TeamMember{TeamID: teamID, UserID: inviterID, Role: models.RoleAdmin}
 
 	t.Run("successfully creates invitation", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -48,6 +51,10 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 
 		team := &models.Team{ID: teamID, Seats: 10}
 
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, inviterID).
+			Return(inviter, nil)
+
 		mockTeamRepo.EXPECT().
 			FindByID(gomock.Any(), teamID).
 			Return(team, nil)
@@ -77,7 +84,7 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 				return nil
 			})
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.CreateInvitation(context.Background(), teamID, inviterID, createReq)
 
 		require.NoError(t, err)
@@ -85,6 +92,30 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 		assert.Equal(t, createReq.Email, result.Email)
 	})
 
+	t.Run("returns error for unknown role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+		mockRoleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(nil, apperrors.ErrTeamRoleNotFound)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, mockRoleRepo, nil, nil)
+		result, err := service.CreateInvitation(context.Background(), teamID, inviterID, &models.CreateInvitationRequest{
+			Email: "invitee@example.com",
+			Role:  "reviewer",
+		})
+
+		assert.Equal(t, apperrors.ErrInvalidRole, err)
+		assert.Nil(t, result)
+	})
+
 	t.Run("returns error when user is already a member", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -98,6 +129,10 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 		existingUserID := primitive.NewObjectID()
 		existingUser := &models.User{ID: existingUserID, Email: createReq.Email}
 
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, inviterID).
+			Return(inviter, nil)
+
 		mockTeamRepo.EXPECT().
 			FindByID(gomock.Any(), teamID).
 			Return(team, nil)
@@ -110,7 +145,7 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, existingUserID).
 			Return(&models.TeamMember{}, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.CreateInvitation(context.Background(), teamID, inviterID, createReq)
 
 		assert.Nil(t, result)
@@ -128,6 +163,10 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 
 		team := &models.Team{ID: teamID, Seats: 10}
 
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, inviterID).
+			Return(inviter, nil)
+
 		mockTeamRepo.EXPECT().
 			FindByID(gomock.Any(), teamID).
 			Return(team, nil)
@@ -140,7 +179,7 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 			FindByTeamAndEmail(gomock.Any(), teamID, createReq.Email).
 			Return(&models.TeamInvitation{}, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.CreateInvitation(context.Background(), teamID, inviterID, createReq)
 
 		assert.Nil(t, result)
@@ -158,6 +197,10 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 
 		team := &models.Team{ID: teamID, Seats: 5}
 
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, inviterID).
+			Return(inviter, nil)
+
 		mockTeamRepo.EXPECT().
 			FindByID(gomock.Any(), teamID).
 			Return(team, nil)
@@ -178,12 +221,39 @@ func TestTeamInvitationService_CreateInvitation(t *testing.T) {
 			CountPendingByTeamID(gomock.Any(), teamID).
 			Return(2, nil) // 3 + 2 = 5 >= 5 seats
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.CreateInvitation(context.Background(), teamID, inviterID, createReq)
 
 		assert.Nil(t, result)
 		assert.Equal(t, apperrors.ErrSeatsExceeded, err)
 	})
+
+	t.Run("cannot invite with a role granting more than the inviter has", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, inviterID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: inviterID, Role: "recruiter"}, nil)
+		mockRoleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "recruiter").
+			Return(&models.TeamRole{TeamID: teamID, Name: "recruiter", Actions: []string{authz.ActionTeamView, authz.ActionMemberInvite}}, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, mockRoleRepo, nil, nil)
+		result, err := service.CreateInvitation(context.Background(), teamID, inviterID, &models.CreateInvitationRequest{
+			Email: "invitee@example.com",
+			Role:  models.RoleAdmin,
+		})
+
+		assert.Equal(t, apperrors.ErrRoleExceedsPermissions, err)
+		assert.Nil(t, result)
+	})
 }
 
 func TestTeamInvitationService_ListTeamInvitations(t *testing.T) {
@@ -207,7 +277,7 @@ func TestTeamInvitationService_ListTeamInvitations(t *testing.T) {
 			FindByTeamID(gomock.Any(), teamID).
 			Return(invitations, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.ListTeamInvitations(context.Background(), teamID)
 
 		require.NoError(t, err)
@@ -218,6 +288,7 @@ func TestTeamInvitationService_ListTeamInvitations(t *testing.T) {
 func TestTeamInvitationService_CancelInvitation(t *testing.T) {
 	teamID := primitive.NewObjectID()
 	invitationID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
 
 	t.Run("successfully cancels invitation", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -235,13 +306,19 @@ func TestTeamInvitationService_CancelInvitation(t *testing.T) {
 			Return(invitation, nil)
 
 		mockInvitationRepo.EXPECT().
-			Delete(gomock.Any(), invitationID).
+			Resolve(gomock.Any(), invitationID, models.InvitationStatusCancelled, userID).
 			Return(nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
-		err := service.CancelInvitation(context.Background(), invitationID, teamID)
+		audit := &fakeAuditRecorder{}
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, audit)
+		err := service.CancelInvitation(context.Background(), invitationID, teamID, userID)
 
 		assert.NoError(t, err)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, models.AuditActionInvitationCancel, audit.entries[0].Action)
+		assert.Equal(t, userID, audit.entries[0].ActorID)
+		assert.Equal(t, invitationID, audit.entries[0].TargetID)
+		assert.Equal(t, models.InvitationStatusCancelled, audit.entries[0].After["status"])
 	})
 
 	t.Run("returns error when invitation belongs to different team", func(t *testing.T) {
@@ -260,13 +337,339 @@ func TestTeamInvitationService_CancelInvitation(t *testing.T) {
 			FindByID(gomock.Any(), invitationID).
 			Return(invitation, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
-		err := service.CancelInvitation(context.Background(), invitationID, teamID)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		err := service.CancelInvitation(context.Background(), invitationID, teamID, userID)
+
+		assert.Equal(t, apperrors.ErrInvitationNotFound, err)
+	})
+	t.Run("returns error when invitation is no longer pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{ID: invitationID, TeamID: teamID, Status: models.InvitationStatusAccepted}
+
+		mockInvitationRepo.EXPECT().
+			FindByID(gomock.Any(), invitationID).
+			Return(invitation, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		err := service.CancelInvitation(context.Background(), invitationID, teamID, userID)
+
+		assert.Equal(t, apperrors.ErrInvitationNotPending, err)
+	})
+}
+
+func TestTeamInvitationService_ListInvitationHistory(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	t.Run("returns paginated history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitations := []models.TeamInvitation{
+			{ID: primitive.NewObjectID(), TeamID: teamID, Status: models.InvitationStatusDeclined},
+			{ID: primitive.NewObjectID(), TeamID: teamID, Status: models.InvitationStatusPending},
+		}
+
+		mockInvitationRepo.EXPECT().
+			FindHistoryByTeamID(gomock.Any(), teamID, 2, 10).
+			Return(invitations, 12, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		result, err := service.ListInvitationHistory(context.Background(), teamID, 2, 10)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 2)
+		assert.Equal(t, 2, result.Pagination.Page)
+		assert.Equal(t, 12, result.Pagination.TotalItems)
+		assert.Equal(t, 2, result.Pagination.TotalPages)
+	})
+
+	t.Run("applies default pagination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().
+			FindHistoryByTeamID(gomock.Any(), teamID, 1, 20).
+			Return([]models.TeamInvitation{}, 0, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		result, err := service.ListInvitationHistory(context.Background(), teamID, 0, 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Pagination.Page)
+		assert.Equal(t, 20, result.Pagination.Limit)
+	})
+}
+
+func TestTeamInvitationService_ResendInvitation(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	invitationID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	t.Run("resends pending invitation with a fresh expiry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{
+			ID:        invitationID,
+			TeamID:    teamID,
+			Status:    models.InvitationStatusPending,
+			ExpiresAt: time.Now().Add(24 * time.Hour),
+		}
+
+		mockInvitationRepo.EXPECT().
+			FindByID(gomock.Any(), invitationID).
+			Return(invitation, nil)
+
+		mockInvitationRepo.EXPECT().
+			Renew(gomock.Any(), invitationID, gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, _ primitive.ObjectID, expiresAt time.Time, _ bool) (*models.TeamInvitation, error) {
+				assert.WithinDuration(t, time.Now().AddDate(0, 0, 7), expiresAt, time.Minute)
+				return &models.TeamInvitation{ID: invitationID, ExpiresAt: expiresAt, ResendCount: 1}, nil
+			})
+
+		audit := &fakeAuditRecorder{}
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, audit)
+		result, err := service.ResendInvitation(context.Background(), invitationID, teamID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.ResendCount)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, models.AuditActionInvitationResend, audit.entries[0].Action)
+		assert.Equal(t, userID, audit.entries[0].ActorID)
+		assert.Equal(t, invitationID, audit.entries[0].TargetID)
+		assert.Equal(t, invitation.ExpiresAt, audit.entries[0].Before["expiresAt"])
+		assert.Equal(t, result.ExpiresAt, audit.entries[0].After["expiresAt"])
+	})
+
+	t.Run("resends expired invitation when seats are available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{
+			ID:        invitationID,
+			TeamID:    teamID,
+			Email:     "invitee@example.com",
+			Status:    models.InvitationStatusExpired,
+			ExpiresAt: time.Now().Add(-24 * time.Hour),
+		}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+		mockInvitationRepo.EXPECT().
+			FindByTeamAndEmail(gomock.Any(), teamID, "invitee@example.com").
+			Return(nil, apperrors.ErrInvitationNotFound)
+		mockTeamRepo.EXPECT().FindByID(gomock.Any(), teamID).Return(&models.Team{ID: teamID, Seats: 10}, nil)
+		mockMemberRepo.EXPECT().CountByTeamID(gomock.Any(), teamID).Return(5, nil)
+		mockInvitationRepo.EXPECT().CountPendingByTeamID(gomock.Any(), teamID).Return(2, nil)
+		mockInvitationRepo.EXPECT().
+			Renew(gomock.Any(), invitationID, gomock.Any(), true).
+			Return(&models.TeamInvitation{ID: invitationID, Status: models.InvitationStatusPending}, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		result, err := service.ResendInvitation(context.Background(), invitationID, teamID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationStatusPending, result.Status)
+	})
+
+	t.Run("returns error when expired invitation has no free seat", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{
+			ID:        invitationID,
+			TeamID:    teamID,
+			Email:     "invitee@example.com",
+			Status:    models.InvitationStatusExpired,
+			ExpiresAt: time.Now().Add(-24 * time.Hour),
+		}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+		mockInvitationRepo.EXPECT().
+			FindByTeamAndEmail(gomock.Any(), teamID, "invitee@example.com").
+			Return(nil, apperrors.ErrInvitationNotFound)
+		mockTeamRepo.EXPECT().FindByID(gomock.Any(), teamID).Return(&models.Team{ID: teamID, Seats: 5}, nil)
+		mockMemberRepo.EXPECT().CountByTeamID(gomock.Any(), teamID).Return(4, nil)
+		mockInvitationRepo.EXPECT().CountPendingByTeamID(gomock.Any(), teamID).Return(1, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		result, err := service.ResendInvitation(context.Background(), invitationID, teamID, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.ErrSeatsExceeded, err)
+	})
+
+	t.Run("returns error when expired invitation's email has another pending invitation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{
+			ID:        invitationID,
+			TeamID:    teamID,
+			Email:     "invitee@example.com",
+			Status:    models.InvitationStatusExpired,
+			ExpiresAt: time.Now().Add(-24 * time.Hour),
+		}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+		mockInvitationRepo.EXPECT().
+			FindByTeamAndEmail(gomock.Any(), teamID, "invitee@example.com").
+			Return(&models.TeamInvitation{ID: primitive.NewObjectID(), TeamID: teamID, Email: "invitee@example.com"}, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		result, err := service.ResendInvitation(context.Background(), invitationID, teamID, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.ErrPendingInvitation, err)
+	})
+
+	t.Run("returns error when invitation was already resolved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{ID: invitationID, TeamID: teamID, Status: models.InvitationStatusDeclined}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		_, err := service.ResendInvitation(context.Background(), invitationID, teamID, userID)
+
+		assert.Equal(t, apperrors.ErrInvitationNotPending, err)
+	})
+
+	t.Run("returns error when invitation belongs to different team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{ID: invitationID, TeamID: primitive.NewObjectID()}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		_, err := service.ResendInvitation(context.Background(), invitationID, teamID, userID)
 
 		assert.Equal(t, apperrors.ErrInvitationNotFound, err)
 	})
 }
 
+func TestTeamInvitationService_ExtendInvitation(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	invitationID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	t.Run("extends from current expiry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		expiresAt := time.Now().Add(48 * time.Hour)
+		invitation := &models.TeamInvitation{ID: invitationID, TeamID: teamID, ExpiresAt: expiresAt}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+		mockInvitationRepo.EXPECT().
+			Renew(gomock.Any(), invitationID, expiresAt.AddDate(0, 0, 3), false).
+			Return(&models.TeamInvitation{ID: invitationID, ExpiresAt: expiresAt.AddDate(0, 0, 3)}, nil)
+
+		audit := &fakeAuditRecorder{}
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, audit)
+		result, err := service.ExtendInvitation(context.Background(), invitationID, teamID, userID, &models.ExtendInvitationRequest{Days: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt.AddDate(0, 0, 3), result.ExpiresAt)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, models.AuditActionInvitationExtend, audit.entries[0].Action)
+		assert.Equal(t, userID, audit.entries[0].ActorID)
+		assert.Equal(t, expiresAt, audit.entries[0].Before["expiresAt"])
+		assert.Equal(t, expiresAt.AddDate(0, 0, 3), audit.entries[0].After["expiresAt"])
+	})
+
+	t.Run("extends expired invitation from now", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{
+			ID:        invitationID,
+			TeamID:    teamID,
+			Email:     "invitee@example.com",
+			ExpiresAt: time.Now().Add(-72 * time.Hour),
+		}
+
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+		mockInvitationRepo.EXPECT().
+			FindByTeamAndEmail(gomock.Any(), teamID, "invitee@example.com").
+			Return(nil, apperrors.ErrInvitationNotFound)
+		mockTeamRepo.EXPECT().FindByID(gomock.Any(), teamID).Return(&models.Team{ID: teamID, Seats: 10}, nil)
+		mockMemberRepo.EXPECT().CountByTeamID(gomock.Any(), teamID).Return(1, nil)
+		mockInvitationRepo.EXPECT().CountPendingByTeamID(gomock.Any(), teamID).Return(0, nil)
+		mockInvitationRepo.EXPECT().
+			Renew(gomock.Any(), invitationID, gomock.Any(), false).
+			DoAndReturn(func(_ context.Context, _ primitive.ObjectID, expiresAt time.Time, _ bool) (*models.TeamInvitation, error) {
+				assert.WithinDuration(t, time.Now().AddDate(0, 0, 2), expiresAt, time.Minute)
+				return &models.TeamInvitation{ID: invitationID, ExpiresAt: expiresAt}, nil
+			})
+
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		_, err := service.ExtendInvitation(context.Background(), invitationID, teamID, userID, &models.ExtendInvitationRequest{Days: 2})
+
+		require.NoError(t, err)
+	})
+}
+
 func TestTeamInvitationService_ListMyInvitations(t *testing.T) {
 	userEmail := "user@example.com"
 
@@ -307,7 +710,7 @@ func TestTeamInvitationService_ListMyInvitations(t *testing.T) {
 			FindByID(gomock.Any(), inviterID).
 			Return(inviter, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.ListMyInvitations(context.Background(), userEmail)
 
 		require.NoError(t, err)
@@ -359,14 +762,46 @@ func TestTeamInvitationService_AcceptInvitation(t *testing.T) {
 			Return(nil)
 
 		mockInvitationRepo.EXPECT().
-			Delete(gomock.Any(), invitationID).
+			Resolve(gomock.Any(), invitationID, models.InvitationStatusAccepted, userID).
 			Return(nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		relationships := newFakeRelationshipWriter()
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, relationships, nil)
 		result, err := service.AcceptInvitation(context.Background(), invitationID, userID, userEmail)
 
 		require.NoError(t, err)
 		assert.Equal(t, teamID.Hex(), result.TeamID)
+		assert.Equal(t, map[primitive.ObjectID]string{userID: models.RoleMember}, relationships.roles)
+	})
+
+	t.Run("removes member when relationship write fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+
+		invitation := &models.TeamInvitation{
+			ID:        invitationID,
+			TeamID:    teamID,
+			Email:     userEmail,
+			Role:      models.RoleMember,
+			ExpiresAt: time.Now().Add(24 * time.Hour),
+		}
+		mockInvitationRepo.EXPECT().FindByID(gomock.Any(), invitationID).Return(invitation, nil)
+		mockTeamRepo.EXPECT().FindByID(gomock.Any(), teamID).Return(&models.Team{ID: teamID, Seats: 10}, nil)
+		mockMemberRepo.EXPECT().CountByTeamID(gomock.Any(), teamID).Return(5, nil)
+		mockMemberRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockMemberRepo.EXPECT().Delete(gomock.Any(), teamID, userID).Return(nil)
+
+		relationships := newFakeRelationshipWriter()
+		relationships.err = errors.New("store unavailable")
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, relationships, nil)
+		_, err := service.AcceptInvitation(context.Background(), invitationID, userID, userEmail)
+
+		assert.ErrorIs(t, err, relationships.err)
 	})
 
 	t.Run("returns error when email does not match", func(t *testing.T) {
@@ -387,7 +822,7 @@ func TestTeamInvitationService_AcceptInvitation(t *testing.T) {
 			FindByID(gomock.Any(), invitationID).
 			Return(invitation, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.AcceptInvitation(context.Background(), invitationID, userID, userEmail)
 
 		assert.Nil(t, result)
@@ -413,7 +848,7 @@ func TestTeamInvitationService_AcceptInvitation(t *testing.T) {
 			FindByID(gomock.Any(), invitationID).
 			Return(invitation, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.AcceptInvitation(context.Background(), invitationID, userID, userEmail)
 
 		assert.Nil(t, result)
@@ -450,7 +885,7 @@ func TestTeamInvitationService_AcceptInvitation(t *testing.T) {
 			CountByTeamID(gomock.Any(), teamID).
 			Return(5, nil) // At capacity
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
 		result, err := service.AcceptInvitation(context.Background(), invitationID, userID, userEmail)
 
 		assert.Nil(t, result)
@@ -460,6 +895,7 @@ func TestTeamInvitationService_AcceptInvitation(t *testing.T) {
 
 func TestTeamInvitationService_DeclineInvitation(t *testing.T) {
 	invitationID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
 	userEmail := "user@example.com"
 
 	t.Run("successfully declines invitation", func(t *testing.T) {
@@ -481,11 +917,11 @@ func TestTeamInvitationService_DeclineInvitation(t *testing.T) {
 			Return(invitation, nil)
 
 		mockInvitationRepo.EXPECT().
-			Delete(gomock.Any(), invitationID).
+			Resolve(gomock.Any(), invitationID, models.InvitationStatusDeclined, userID).
 			Return(nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
-		err := service.DeclineInvitation(context.Background(), invitationID, userEmail)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		err := service.DeclineInvitation(context.Background(), invitationID, userID, userEmail)
 
 		assert.NoError(t, err)
 	})
@@ -508,8 +944,8 @@ func TestTeamInvitationService_DeclineInvitation(t *testing.T) {
 			FindByID(gomock.Any(), invitationID).
 			Return(invitation, nil)
 
-		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo)
-		err := service.DeclineInvitation(context.Background(), invitationID, userEmail)
+		service := NewTeamInvitationService(mockInvitationRepo, mockMemberRepo, mockTeamRepo, mockUserRepo, nil, nil, nil)
+		err := service.DeclineInvitation(context.Background(), invitationID, userID, userEmail)
 
 		assert.Equal(t, apperrors.ErrInvitationEmailMismatch, err)
 	})
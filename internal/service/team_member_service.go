@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	"gin-sample/internal/repository"
@@ -10,28 +13,56 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MemberCacheInvalidator drops cached team memberships after they change.
+type MemberCacheInvalidator interface {
+	InvalidateMember(ctx context.Context, teamID, userID primitive.ObjectID) error
+}
+
+// RelationshipWriter records team memberships as authorization relationships.
+type RelationshipWriter interface {
+	WriteTeamMember(ctx context.Context, teamID, userID primitive.ObjectID, role string) error
+	DeleteTeamMember(ctx context.Context, teamID, userID primitive.ObjectID) error
+	DeleteTeam(ctx context.Context, teamID primitive.ObjectID) error
+}
+
 // TeamMemberService handles business logic for team member operations.
 type TeamMemberService struct {
-	memberRepo repository.TeamMemberRepository
-	userRepo   repository.UserRepository
-	teamRepo   repository.TeamRepository
+	memberRepo    repository.TeamMemberRepository
+	userRepo      repository.UserRepository
+	teamRepo      repository.TeamRepository
+	roleRepo      repository.TeamRoleRepository
+	memberCache   MemberCacheInvalidator
+	relationships RelationshipWriter
+	audit         AuditRecorder
 }
 
 // NewTeamMemberService creates a new TeamMemberService.
+// If memberCache is not nil, role changes and removals invalidate the member's cached membership.
+// If relationships is not nil, they are also recorded as authorization relationships.
+// If audit is not nil, removals and role changes are written to the audit log.
 func NewTeamMemberService(
 	memberRepo repository.TeamMemberRepository,
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
+	roleRepo repository.TeamRoleRepository,
+	memberCache MemberCacheInvalidator,
+	relationships RelationshipWriter,
+	audit AuditRecorder,
 ) *TeamMemberService {
 	return &TeamMemberService{
-		memberRepo: memberRepo,
-		userRepo:   userRepo,
-		teamRepo:   teamRepo,
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		roleRepo:      roleRepo,
+		memberCache:   memberCache,
+		relationships: relationships,
+		audit:         audit,
 	}
 }
 
 // ListMembers returns all members of a team with user details.
-func (s *TeamMemberService) ListMembers(ctx context.Context, teamID primitive.ObjectID) (*models.TeamMemberListResponse, error) {
+// When includeMFAStatus is true, each user summary also reports whether MFA is enabled.
+func (s *TeamMemberService) ListMembers(ctx context.Context, teamID primitive.ObjectID, includeMFAStatus bool) (*models.TeamMemberListResponse, error) {
 	members, err := s.memberRepo.FindByTeamID(ctx, teamID)
 	if err != nil {
 		return nil, err
@@ -56,6 +87,10 @@ func (s *TeamMemberService) ListMembers(ctx context.Context, teamID primitive.Ob
 				Email: user.Email,
 				Name:  user.Name,
 			}
+			if includeMFAStatus {
+				mfaEnabled := user.MFAEnabled
+				memberWithUser.User.MFAEnabled = &mfaEnabled
+			}
 		}
 
 		membersWithUsers = append(membersWithUsers, memberWithUser)
@@ -79,27 +114,39 @@ func (s *TeamMemberService) RemoveMember(ctx context.Context, teamID, targetUser
 		return apperrors.ErrCannotRemoveOwner
 	}
 
-	// Only owner can remove an admin
-	if targetMember.Role == models.RoleAdmin {
-		requestingMember, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, requestingUserID)
-		if err != nil || requestingMember.Role != models.RoleOwner {
-			return apperrors.ErrInsufficientPermissions
-		}
-	}
-
 	// Cannot remove self (use leave endpoint)
 	if targetUserID == requestingUserID {
 		return apperrors.ErrCannotRemoveSelf
 	}
 
-	return s.memberRepo.Delete(ctx, teamID, targetUserID)
+	requestingMember, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, requestingUserID)
+	if err != nil {
+		return apperrors.ErrInsufficientPermissions
+	}
+	if err := s.checkOutranks(ctx, teamID, requestingMember, targetMember); err != nil {
+		return err
+	}
+
+	if err := deleteMemberRelationship(ctx, s.relationships, teamID, targetUserID); err != nil {
+		return err
+	}
+	if err := s.memberRepo.Delete(ctx, teamID, targetUserID); err != nil {
+		restoreMemberRelationship(ctx, s.relationships, teamID, targetUserID, targetMember.Role)
+		return err
+	}
+	invalidateMember(ctx, s.memberCache, teamID, targetUserID)
+	recordAudit(ctx, s.audit, memberRemovedEntry(teamID, targetUserID, requestingUserID, targetMember.Role))
+	return nil
 }
 
 // UpdateRole updates a member's role in a team.
+// The new role can be any built-in role except owner, or one of the team's custom roles.
+// Members cannot change their own role or assign a role granting actions they do not have.
 func (s *TeamMemberService) UpdateRole(ctx context.Context, teamID, targetUserID, requestingUserID primitive.ObjectID, newRole string) error {
 	// Validate role
-	if newRole != models.RoleAdmin && newRole != models.RoleMember {
-		return apperrors.ErrInvalidRole
+	newRoleActions, err := assignableRoleActions(ctx, s.roleRepo, teamID, newRole)
+	if err != nil {
+		return err
 	}
 
 	// Get target member
@@ -113,18 +160,73 @@ func (s *TeamMemberService) UpdateRole(ctx context.Context, teamID, targetUserID
 		return apperrors.ErrCannotChangeOwnerRole
 	}
 
-	// Only owner can change an admin's role
-	if targetMember.Role == models.RoleAdmin {
-		requestingMember, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, requestingUserID)
-		if err != nil || requestingMember.Role != models.RoleOwner {
-			return apperrors.ErrInsufficientPermissions
-		}
+	if targetUserID == requestingUserID {
+		return apperrors.ErrCannotChangeOwnRole
+	}
+
+	requestingMember, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, requestingUserID)
+	if err != nil {
+		return apperrors.ErrInsufficientPermissions
+	}
+
+	if err := s.checkOutranks(ctx, teamID, requestingMember, targetMember); err != nil {
+		return err
 	}
 
-	return s.memberRepo.UpdateRole(ctx, teamID, targetUserID, newRole)
+	// The new role cannot grant more than the requester has
+	requesterActions, err := roleActions(ctx, s.roleRepo, teamID, requestingMember.Role)
+	if err != nil {
+		return err
+	}
+	if !authz.ActionsCover(requesterActions, newRoleActions) {
+		return apperrors.ErrRoleExceedsPermissions
+	}
+
+	if err := s.memberRepo.UpdateRole(ctx, teamID, targetUserID, newRole); err != nil {
+		return err
+	}
+	invalidateMember(ctx, s.memberCache, teamID, targetUserID)
+	if err := writeMemberRelationship(ctx, s.relationships, teamID, targetUserID, newRole); err != nil {
+		// Rollback role change
+		_ = s.memberRepo.UpdateRole(ctx, teamID, targetUserID, targetMember.Role)
+		invalidateMember(ctx, s.memberCache, teamID, targetUserID)
+		return err
+	}
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    requestingUserID,
+		Action:     authz.ActionMemberUpdateRole,
+		TargetType: models.AuditTargetMember,
+		TargetID:   targetUserID,
+		Before:     map[string]any{"role": targetMember.Role},
+		After:      map[string]any{"role": newRole},
+	})
+	return nil
+}
+
+// checkOutranks checks that requester may remove or change the role of target.
+// The owner outranks everyone; other members only outrank members whose role grants
+// strictly fewer actions than their own, so admins cannot act on other admins.
+func (s *TeamMemberService) checkOutranks(ctx context.Context, teamID primitive.ObjectID, requester, target *models.TeamMember) error {
+	if requester.Role == models.RoleOwner {
+		return nil
+	}
+	requesterActions, err := roleActions(ctx, s.roleRepo, teamID, requester.Role)
+	if err != nil {
+		return err
+	}
+	targetActions, err := roleActions(ctx, s.roleRepo, teamID, target.Role)
+	if err != nil {
+		return err
+	}
+	if !authz.ActionsCover(requesterActions, targetActions) || authz.ActionsCover(targetActions, requesterActions) {
+		return apperrors.ErrInsufficientPermissions
+	}
+	return nil
 }
 
 // LeaveTeam removes the requesting user from a team.
+// It is audited as a removal whose actor is the member who left.
 func (s *TeamMemberService) LeaveTeam(ctx context.Context, teamID, userID primitive.ObjectID) error {
 	// Get member
 	member, err := s.memberRepo.FindByTeamAndUser(ctx, teamID, userID)
@@ -137,10 +239,73 @@ func (s *TeamMemberService) LeaveTeam(ctx context.Context, teamID, userID primit
 		return apperrors.ErrOwnerCannotLeave
 	}
 
-	return s.memberRepo.Delete(ctx, teamID, userID)
+	if err := deleteMemberRelationship(ctx, s.relationships, teamID, userID); err != nil {
+		return err
+	}
+	if err := s.memberRepo.Delete(ctx, teamID, userID); err != nil {
+		restoreMemberRelationship(ctx, s.relationships, teamID, userID, member.Role)
+		return err
+	}
+	invalidateMember(ctx, s.memberCache, teamID, userID)
+	recordAudit(ctx, s.audit, memberRemovedEntry(teamID, userID, userID, member.Role))
+	return nil
+}
+
+// memberRemovedEntry returns the audit log entry for a member leaving or being removed from a team.
+func memberRemovedEntry(teamID, userID, actorID primitive.ObjectID, role string) *models.AuditLogEntry {
+	return &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    actorID,
+		Action:     authz.ActionMemberRemove,
+		TargetType: models.AuditTargetMember,
+		TargetID:   userID,
+		Before:     map[string]any{"role": role},
+	}
 }
 
 // GetMember returns a team member by team and user ID.
 func (s *TeamMemberService) GetMember(ctx context.Context, teamID, userID primitive.ObjectID) (*models.TeamMember, error) {
 	return s.memberRepo.FindByTeamAndUser(ctx, teamID, userID)
 }
+
+// invalidateMember drops a cached membership, if a cache is configured.
+// Errors are ignored: entries expire on their own after a short TTL.
+func invalidateMember(ctx context.Context, memberCache MemberCacheInvalidator, teamID, userID primitive.ObjectID) {
+	if memberCache == nil {
+		return
+	}
+	_ = memberCache.InvalidateMember(ctx, teamID, userID)
+}
+
+// writeMemberRelationship records a member's role, if a relationship writer is configured.
+// Callers roll back the membership change when it fails, so the two stores stay in sync.
+func writeMemberRelationship(ctx context.Context, relationships RelationshipWriter, teamID, userID primitive.ObjectID, role string) error {
+	if relationships == nil {
+		return nil
+	}
+	if err := relationships.WriteTeamMember(ctx, teamID, userID, role); err != nil {
+		return fmt.Errorf("write member relationship: %w", err)
+	}
+	return nil
+}
+
+// deleteMemberRelationship removes a member's role, if a relationship writer is configured.
+// Callers delete the relationship before the membership so a failure never leaves a removed
+// member with access.
+func deleteMemberRelationship(ctx context.Context, relationships RelationshipWriter, teamID, userID primitive.ObjectID) error {
+	if relationships == nil {
+		return nil
+	}
+	if err := relationships.DeleteTeamMember(ctx, teamID, userID); err != nil {
+		return fmt.Errorf("delete member relationship: %w", err)
+	}
+	return nil
+}
+
+// restoreMemberRelationship writes back a member's role after the membership change that
+// removed it failed. Failures are logged: the reconcile command repairs what is left.
+func restoreMemberRelationship(ctx context.Context, relationships RelationshipWriter, teamID, userID primitive.ObjectID, role string) {
+	if err := writeMemberRelationship(ctx, relationships, teamID, userID, role); err != nil {
+		slog.ErrorContext(ctx, "failed to restore member relationship", "team_id", teamID.Hex(), "user_id", userID.Hex(), "error", err)
+	}
+}
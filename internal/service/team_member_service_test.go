@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	repomocks "gin-sample/internal/repository/mocks"
@@ -14,6 +16,52 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// fakeMemberCache records which memberships were invalidated.
+type fakeMemberCache struct {
+	invalidated []primitive.ObjectID
+}
+
+func (f *fakeMemberCache) InvalidateMember(_ context.Context, _, userID primitive.ObjectID) error {
+	f.invalidated = append(f.invalidated, userID)
+	return nil
+}
+
+// fakeRelationshipWriter records the roles written as relationships.
+// When err is set every write fails with it and changes nothing.
+type fakeRelationshipWriter struct {
+	roles        map[primitive.ObjectID]string
+	deletedTeams []primitive.ObjectID
+	err          error
+}
+
+func newFakeRelationshipWriter() *fakeRelationshipWriter {
+	return &fakeRelationshipWriter{roles: make(map[primitive.ObjectID]string)}
+}
+
+func (f *fakeRelationshipWriter) WriteTeamMember(_ context.Context, _, userID primitive.ObjectID, role string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.roles[userID] = role
+	return nil
+}
+
+func (f *fakeRelationshipWriter) DeleteTeamMember(_ context.Context, _, userID primitive.ObjectID) error {
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.roles, userID)
+	return nil
+}
+
+func (f *fakeRelationshipWriter) DeleteTeam(_ context.Context, teamID primitive.ObjectID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deletedTeams = append(f.deletedTeams, teamID)
+	return nil
+}
+
 func TestNewTeamMemberService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -22,7 +70,7 @@ func TestNewTeamMemberService(t *testing.T) {
 	mockUserRepo := repomocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 
-	service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+	service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 
 	assert.NotNil(t, service)
 }
@@ -52,8 +100,8 @@ func TestTeamMemberService_ListMembers(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(user, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
-		result, err := service.ListMembers(context.Background(), teamID)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
+		result, err := service.ListMembers(context.Background(), teamID, false)
 
 		require.NoError(t, err)
 		assert.Len(t, result.Items, 1)
@@ -61,6 +109,35 @@ func TestTeamMemberService_ListMembers(t *testing.T) {
 		assert.Equal(t, user.Email, result.Items[0].User.Email)
 	})
 
+	t.Run("includes mfa status when requested", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+
+		members := []models.TeamMember{
+			{ID: primitive.NewObjectID(), TeamID: teamID, UserID: userID, Role: models.RoleMember},
+		}
+		user := &models.User{ID: userID, Email: "test@example.com", Name: "Test User", MFAEnabled: true}
+
+		mockMemberRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return(members, nil)
+
+		mockUserRepo.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Return(user, nil)
+
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
+		result, err := service.ListMembers(context.Background(), teamID, true)
+
+		require.NoError(t, err)
+		require.NotNil(t, result.Items[0].User.MFAEnabled)
+		assert.True(t, *result.Items[0].User.MFAEnabled)
+	})
+
 	t.Run("returns members without user details when user not found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -81,8 +158,8 @@ func TestTeamMemberService_ListMembers(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(nil, apperrors.ErrUserNotFound)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
-		result, err := service.ListMembers(context.Background(), teamID)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
+		result, err := service.ListMembers(context.Background(), teamID, false)
 
 		require.NoError(t, err)
 		assert.Len(t, result.Items, 1)
@@ -101,8 +178,8 @@ func TestTeamMemberService_ListMembers(t *testing.T) {
 			FindByTeamID(gomock.Any(), teamID).
 			Return(nil, assert.AnError)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
-		result, err := service.ListMembers(context.Background(), teamID)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
+		result, err := service.ListMembers(context.Background(), teamID, false)
 
 		assert.Nil(t, result)
 		assert.Error(t, err)
@@ -114,6 +191,8 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 	ownerID := primitive.NewObjectID()
 	adminID := primitive.NewObjectID()
 	memberID := primitive.NewObjectID()
+	owner := &models.TeamMember{TeamID: teamID, UserID: ownerID, Role: models.RoleOwner}
+	admin := &models.TeamMember{TeamID: teamID, UserID: adminID, Role: models.RoleAdmin}
 
 	t.Run("owner can remove member", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -128,15 +207,54 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 		mockMemberRepo.EXPECT().
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(targetMember, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
+			Return(owner, nil)
 
 		mockMemberRepo.EXPECT().
 			Delete(gomock.Any(), teamID, memberID).
 			Return(nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		memberCache := &fakeMemberCache{}
+		relationships := newFakeRelationshipWriter()
+		relationships.roles[memberID] = models.RoleMember
+		audit := &fakeAuditRecorder{}
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, memberCache, relationships, audit)
 		err := service.RemoveMember(context.Background(), teamID, memberID, ownerID)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{memberID}, memberCache.invalidated)
+		assert.NotContains(t, relationships.roles, memberID)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, authz.ActionMemberRemove, audit.entries[0].Action)
+		assert.Equal(t, ownerID, audit.entries[0].ActorID)
+		assert.Equal(t, models.RoleMember, audit.entries[0].Before["role"])
+		assert.Nil(t, audit.entries[0].After)
+	})
+
+	t.Run("keeps member when relationship delete fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, memberID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: memberID, Role: models.RoleMember}, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
+			Return(owner, nil)
+
+		relationships := newFakeRelationshipWriter()
+		relationships.err = errors.New("store unavailable")
+		audit := &fakeAuditRecorder{}
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, relationships, audit)
+		err := service.RemoveMember(context.Background(), teamID, memberID, ownerID)
+
+		assert.ErrorIs(t, err, relationships.err)
+		assert.Empty(t, audit.entries)
 	})
 
 	t.Run("admin can remove member", func(t *testing.T) {
@@ -152,12 +270,15 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 		mockMemberRepo.EXPECT().
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(targetMember, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, adminID).
+			Return(admin, nil)
 
 		mockMemberRepo.EXPECT().
 			Delete(gomock.Any(), teamID, memberID).
 			Return(nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.RemoveMember(context.Background(), teamID, memberID, adminID)
 
 		assert.NoError(t, err)
@@ -177,7 +298,7 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
 			Return(targetMember, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.RemoveMember(context.Background(), teamID, ownerID, adminID)
 
 		assert.Equal(t, apperrors.ErrCannotRemoveOwner, err)
@@ -202,7 +323,7 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(requestingMember, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.RemoveMember(context.Background(), teamID, adminID, memberID)
 
 		assert.Equal(t, apperrors.ErrInsufficientPermissions, err)
@@ -231,12 +352,61 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 			Delete(gomock.Any(), teamID, adminID).
 			Return(nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.RemoveMember(context.Background(), teamID, adminID, ownerID)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("cannot remove member whose role grants at least as much", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			requesterRole string
+			targetRole    string
+		}{
+			{"admin removing another admin", models.RoleAdmin, models.RoleAdmin},
+			{"admin removing custom role with all admin actions", models.RoleAdmin, "co-admin"},
+			{"custom role removing member with the same role", "moderator", "moderator"},
+			{"custom role removing member with more actions", "moderator", models.RoleMember},
+		}
+
+		customRoles := map[string][]string{
+			"co-admin":  mustBuiltinRoleActions(t, models.RoleAdmin),
+			"moderator": {authz.ActionTeamView, authz.ActionMemberRemove},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+				mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+				mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+				mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+				requesterID := primitive.NewObjectID()
+				mockMemberRepo.EXPECT().
+					FindByTeamAndUser(gomock.Any(), teamID, memberID).
+					Return(&models.TeamMember{TeamID: teamID, UserID: memberID, Role: tt.targetRole}, nil)
+				mockMemberRepo.EXPECT().
+					FindByTeamAndUser(gomock.Any(), teamID, requesterID).
+					Return(&models.TeamMember{TeamID: teamID, UserID: requesterID, Role: tt.requesterRole}, nil)
+				mockRoleRepo.EXPECT().
+					FindByTeamAndName(gomock.Any(), teamID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ primitive.ObjectID, name string) (*models.TeamRole, error) {
+						return &models.TeamRole{TeamID: teamID, Name: name, Actions: customRoles[name]}, nil
+					}).
+					AnyTimes()
+
+				service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, mockRoleRepo, nil, nil, nil)
+				err := service.RemoveMember(context.Background(), teamID, memberID, requesterID)
+
+				assert.Equal(t, apperrors.ErrInsufficientPermissions, err)
+			})
+		}
+	})
+
 	t.Run("cannot remove self", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -251,7 +421,7 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(member, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.RemoveMember(context.Background(), teamID, memberID, memberID) // Same user
 
 		assert.Equal(t, apperrors.ErrCannotRemoveSelf, err)
@@ -269,7 +439,7 @@ func TestTeamMemberService_RemoveMember(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(nil, apperrors.ErrNotTeamMember)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.RemoveMember(context.Background(), teamID, memberID, ownerID)
 
 		assert.Equal(t, apperrors.ErrNotTeamMember, err)
@@ -281,6 +451,7 @@ func TestTeamMemberService_UpdateRole(t *testing.T) {
 	ownerID := primitive.NewObjectID()
 	adminID := primitive.NewObjectID()
 	memberID := primitive.NewObjectID()
+	owner := &models.TeamMember{TeamID: teamID, UserID: ownerID, Role: models.RoleOwner}
 
 	t.Run("owner can promote member to admin", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -295,15 +466,29 @@ func TestTeamMemberService_UpdateRole(t *testing.T) {
 		mockMemberRepo.EXPECT().
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(member, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
+			Return(owner, nil)
 
 		mockMemberRepo.EXPECT().
 			UpdateRole(gomock.Any(), teamID, memberID, models.RoleAdmin).
 			Return(nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		memberCache := &fakeMemberCache{}
+		relationships := newFakeRelationshipWriter()
+		audit := &fakeAuditRecorder{}
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, memberCache, relationships, audit)
 		err := service.UpdateRole(context.Background(), teamID, memberID, ownerID, models.RoleAdmin)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{memberID}, memberCache.invalidated)
+		assert.Equal(t, models.RoleAdmin, relationships.roles[memberID])
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, authz.ActionMemberUpdateRole, audit.entries[0].Action)
+		assert.Equal(t, ownerID, audit.entries[0].ActorID)
+		assert.Equal(t, memberID, audit.entries[0].TargetID)
+		assert.Equal(t, models.RoleMember, audit.entries[0].Before["role"])
+		assert.Equal(t, models.RoleAdmin, audit.entries[0].After["role"])
 	})
 
 	t.Run("returns error for invalid role", func(t *testing.T) {
@@ -313,13 +498,72 @@ func TestTeamMemberService_UpdateRole(t *testing.T) {
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+		mockRoleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "invalid-role").
+			Return(nil, apperrors.ErrTeamRoleNotFound)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, mockRoleRepo, nil, nil, nil)
 		err := service.UpdateRole(context.Background(), teamID, memberID, ownerID, "invalid-role")
 
 		assert.Equal(t, apperrors.ErrInvalidRole, err)
 	})
 
+	t.Run("assigns preset role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+
+		targetMember := &models.TeamMember{TeamID: teamID, UserID: memberID, Role: models.RoleMember}
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, memberID).
+			Return(targetMember, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
+			Return(owner, nil)
+		mockMemberRepo.EXPECT().
+			UpdateRole(gomock.Any(), teamID, memberID, models.RoleContributor).
+			Return(nil)
+
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
+		err := service.UpdateRole(context.Background(), teamID, memberID, ownerID, models.RoleContributor)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("assigns custom role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+		mockRoleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(&models.TeamRole{TeamID: teamID, Name: "reviewer"}, nil)
+		targetMember := &models.TeamMember{TeamID: teamID, UserID: memberID, Role: models.RoleMember}
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, memberID).
+			Return(targetMember, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
+			Return(owner, nil)
+		mockMemberRepo.EXPECT().
+			UpdateRole(gomock.Any(), teamID, memberID, "reviewer").
+			Return(nil)
+
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, mockRoleRepo, nil, nil, nil)
+		err := service.UpdateRole(context.Background(), teamID, memberID, ownerID, "reviewer")
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("returns error for owner role", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -328,7 +572,7 @@ func TestTeamMemberService_UpdateRole(t *testing.T) {
 		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.UpdateRole(context.Background(), teamID, memberID, ownerID, models.RoleOwner)
 
 		assert.Equal(t, apperrors.ErrInvalidRole, err)
@@ -342,13 +586,11 @@ func TestTeamMemberService_UpdateRole(t *testing.T) {
 		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 
-		ownerMember := &models.TeamMember{TeamID: teamID, UserID: ownerID, Role: models.RoleOwner}
-
 		mockMemberRepo.EXPECT().
 			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
-			Return(ownerMember, nil)
+			Return(owner, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.UpdateRole(context.Background(), teamID, ownerID, adminID, models.RoleAdmin)
 
 		assert.Equal(t, apperrors.ErrCannotChangeOwnerRole, err)
@@ -373,11 +615,131 @@ func TestTeamMemberService_UpdateRole(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, gomock.Any()).
 			Return(otherAdmin, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.UpdateRole(context.Background(), teamID, adminID, otherAdmin.UserID, models.RoleMember)
 
 		assert.Equal(t, apperrors.ErrInsufficientPermissions, err)
 	})
+
+	t.Run("rolls back role when relationship write fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, memberID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: memberID, Role: models.RoleMember}, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
+			Return(owner, nil)
+		gomock.InOrder(
+			mockMemberRepo.EXPECT().UpdateRole(gomock.Any(), teamID, memberID, models.RoleAdmin).Return(nil),
+			mockMemberRepo.EXPECT().UpdateRole(gomock.Any(), teamID, memberID, models.RoleMember).Return(nil),
+		)
+
+		relationships := newFakeRelationshipWriter()
+		relationships.err = errors.New("store unavailable")
+		audit := &fakeAuditRecorder{}
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, relationships, audit)
+		err := service.UpdateRole(context.Background(), teamID, memberID, ownerID, models.RoleAdmin)
+
+		assert.ErrorIs(t, err, relationships.err)
+		assert.Empty(t, audit.entries)
+	})
+
+	t.Run("cannot change own role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, memberID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: memberID, Role: models.RoleMember}, nil)
+
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
+		err := service.UpdateRole(context.Background(), teamID, memberID, memberID, models.RoleAdmin)
+
+		assert.Equal(t, apperrors.ErrCannotChangeOwnRole, err)
+	})
+
+	t.Run("cannot assign a role granting more than the requester has", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			requesterRole string
+			newRole       string
+		}{
+			{"custom role promoting to admin", "moderator", models.RoleAdmin},
+			{"admin assigning role management", models.RoleAdmin, "role-manager"},
+		}
+
+		customRoles := map[string][]string{
+			"guest":        {authz.ActionTeamView},
+			"moderator":    {authz.ActionTeamView, authz.ActionMemberUpdateRole},
+			"role-manager": {authz.ActionTeamView, authz.ActionRoleManage},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+				mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+				mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+				mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+				requesterID := primitive.NewObjectID()
+				mockRoleRepo.EXPECT().
+					FindByTeamAndName(gomock.Any(), teamID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ primitive.ObjectID, name string) (*models.TeamRole, error) {
+						return &models.TeamRole{TeamID: teamID, Name: name, Actions: customRoles[name]}, nil
+					}).
+					AnyTimes()
+				mockMemberRepo.EXPECT().
+					FindByTeamAndUser(gomock.Any(), teamID, memberID).
+					Return(&models.TeamMember{TeamID: teamID, UserID: memberID, Role: "guest"}, nil)
+				mockMemberRepo.EXPECT().
+					FindByTeamAndUser(gomock.Any(), teamID, requesterID).
+					Return(&models.TeamMember{TeamID: teamID, UserID: requesterID, Role: tt.requesterRole}, nil)
+
+				service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, mockRoleRepo, nil, nil, nil)
+				err := service.UpdateRole(context.Background(), teamID, memberID, requesterID, tt.newRole)
+
+				assert.Equal(t, apperrors.ErrRoleExceedsPermissions, err)
+			})
+		}
+	})
+
+	t.Run("cannot change role of member whose role grants at least as much", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockUserRepo := repomocks.NewMockUserRepository(ctrl)
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+
+		mockRoleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "co-admin").
+			Return(&models.TeamRole{TeamID: teamID, Name: "co-admin", Actions: mustBuiltinRoleActions(t, models.RoleAdmin)}, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, memberID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: memberID, Role: "co-admin"}, nil)
+		mockMemberRepo.EXPECT().
+			FindByTeamAndUser(gomock.Any(), teamID, adminID).
+			Return(&models.TeamMember{TeamID: teamID, UserID: adminID, Role: models.RoleAdmin}, nil)
+
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, mockRoleRepo, nil, nil, nil)
+		err := service.UpdateRole(context.Background(), teamID, memberID, adminID, models.RoleViewer)
+
+		assert.Equal(t, apperrors.ErrInsufficientPermissions, err)
+	})
 }
 
 func TestTeamMemberService_LeaveTeam(t *testing.T) {
@@ -403,10 +765,15 @@ func TestTeamMemberService_LeaveTeam(t *testing.T) {
 			Delete(gomock.Any(), teamID, memberID).
 			Return(nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		memberCache := &fakeMemberCache{}
+		relationships := newFakeRelationshipWriter()
+		relationships.roles[memberID] = models.RoleMember
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, memberCache, relationships, nil)
 		err := service.LeaveTeam(context.Background(), teamID, memberID)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{memberID}, memberCache.invalidated)
+		assert.NotContains(t, relationships.roles, memberID)
 	})
 
 	t.Run("owner cannot leave team", func(t *testing.T) {
@@ -423,7 +790,7 @@ func TestTeamMemberService_LeaveTeam(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, ownerID).
 			Return(owner, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.LeaveTeam(context.Background(), teamID, ownerID)
 
 		assert.Equal(t, apperrors.ErrOwnerCannotLeave, err)
@@ -441,7 +808,7 @@ func TestTeamMemberService_LeaveTeam(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, memberID).
 			Return(nil, apperrors.ErrNotTeamMember)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		err := service.LeaveTeam(context.Background(), teamID, memberID)
 
 		assert.Equal(t, apperrors.ErrNotTeamMember, err)
@@ -466,7 +833,7 @@ func TestTeamMemberService_GetMember(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, userID).
 			Return(member, nil)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		result, err := service.GetMember(context.Background(), teamID, userID)
 
 		require.NoError(t, err)
@@ -485,10 +852,18 @@ func TestTeamMemberService_GetMember(t *testing.T) {
 			FindByTeamAndUser(gomock.Any(), teamID, userID).
 			Return(nil, apperrors.ErrNotTeamMember)
 
-		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo)
+		service := NewTeamMemberService(mockMemberRepo, mockUserRepo, mockTeamRepo, nil, nil, nil, nil)
 		result, err := service.GetMember(context.Background(), teamID, userID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, apperrors.ErrNotTeamMember, err)
 	})
 }
+
+// mustBuiltinRoleActions returns the actions of a built-in role.
+func mustBuiltinRoleActions(t *testing.T, role string) []string {
+	t.Helper()
+	actions, ok := authz.BuiltinRoleActions(role)
+	require.True(t, ok)
+	return actions
+}
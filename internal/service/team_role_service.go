@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gin-sample/internal/authz"
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	"gin-sample/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxCustomRolesPerTeam is the number of custom roles a team can define.
+const MaxCustomRolesPerTeam = 20
+
+// RoleCacheInvalidator drops cached role permissions after a custom role changes.
+type RoleCacheInvalidator interface {
+	InvalidateRole(teamID primitive.ObjectID, name string)
+}
+
+// TeamRoleService handles custom team roles.
+type TeamRoleService struct {
+	roleRepo       repository.TeamRoleRepository
+	memberRepo     repository.TeamMemberRepository
+	invitationRepo repository.TeamInvitationRepository
+	roleCache      RoleCacheInvalidator
+	audit          AuditRecorder
+}
+
+// NewTeamRoleService creates a new TeamRoleService.
+func NewTeamRoleService(
+	roleRepo repository.TeamRoleRepository,
+	memberRepo repository.TeamMemberRepository,
+	invitationRepo repository.TeamInvitationRepository,
+	roleCache RoleCacheInvalidator,
+	audit AuditRecorder,
+) *TeamRoleService {
+	return &TeamRoleService{
+		roleRepo:       roleRepo,
+		memberRepo:     memberRepo,
+		invitationRepo: invitationRepo,
+		roleCache:      roleCache,
+		audit:          audit,
+	}
+}
+
+// ListRoles returns the built-in roles and the team's custom roles.
+func (s *TeamRoleService) ListRoles(ctx context.Context, teamID primitive.ObjectID) (*models.TeamRoleListResponse, error) {
+	custom, err := s.roleRepo.FindByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TeamRoleListResponse{
+		BuiltIn: authz.BuiltinRoles(),
+		Custom:  custom,
+	}, nil
+}
+
+// CreateRole creates a custom role in a team.
+// Custom roles cannot reuse the name of a built-in role.
+func (s *TeamRoleService) CreateRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, req *models.CreateTeamRoleRequest) (*models.TeamRole, error) {
+	if authz.IsBuiltinRole(req.Name) {
+		return nil, apperrors.ErrTeamRoleExists
+	}
+
+	count, err := s.roleRepo.CountByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxCustomRolesPerTeam {
+		return nil, apperrors.ErrTeamRoleLimitReached
+	}
+
+	role := &models.TeamRole{
+		TeamID:  teamID,
+		Name:    req.Name,
+		Actions: uniqueActions(req.Actions),
+	}
+
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	// Members may already hold this role name from before it existed
+	s.roleCache.InvalidateRole(teamID, role.Name)
+
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    requestingUserID,
+		Action:     models.AuditActionRoleCreate,
+		TargetType: models.AuditTargetRole,
+		TargetID:   role.ID,
+		After:      roleAuditState(role),
+	})
+	return role, nil
+}
+
+// UpdateRole replaces the actions of a custom role.
+func (s *TeamRoleService) UpdateRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string, req *models.UpdateTeamRoleRequest) (*models.TeamRole, error) {
+	if authz.IsBuiltinRole(name) {
+		return nil, apperrors.ErrTeamRoleNotFound
+	}
+
+	existing, err := s.roleRepo.FindByTeamAndName(ctx, teamID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.UpdateActions(ctx, teamID, name, uniqueActions(req.Actions))
+	if err != nil {
+		return nil, err
+	}
+
+	s.roleCache.InvalidateRole(teamID, name)
+
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    requestingUserID,
+		Action:     models.AuditActionRoleUpdate,
+		TargetType: models.AuditTargetRole,
+		TargetID:   role.ID,
+		Before:     roleAuditState(existing),
+		After:      roleAuditState(role),
+	})
+	return role, nil
+}
+
+// DeleteRole deletes a custom role that is not assigned to any member or pending invitation.
+func (s *TeamRoleService) DeleteRole(ctx context.Context, teamID, requestingUserID primitive.ObjectID, name string) error {
+	if authz.IsBuiltinRole(name) {
+		return apperrors.ErrTeamRoleNotFound
+	}
+
+	role, err := s.roleRepo.FindByTeamAndName(ctx, teamID, name)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.memberRepo.FindByTeamID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Role == name {
+			return apperrors.ErrTeamRoleInUse
+		}
+	}
+
+	invitations, err := s.invitationRepo.FindByTeamID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	for _, inv := range invitations {
+		if inv.Role == name {
+			return apperrors.ErrTeamRoleInUse
+		}
+	}
+
+	if err := s.roleRepo.Delete(ctx, teamID, name); err != nil {
+		return err
+	}
+
+	s.roleCache.InvalidateRole(teamID, name)
+
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    requestingUserID,
+		Action:     models.AuditActionRoleDelete,
+		TargetType: models.AuditTargetRole,
+		TargetID:   role.ID,
+		Before:     roleAuditState(role),
+	})
+	return nil
+}
+
+// roleAuditState returns the audited fields of a custom role.
+func roleAuditState(role *models.TeamRole) map[string]any {
+	return map[string]any{
+		"name":    role.Name,
+		"actions": role.Actions,
+	}
+}
+
+// validateAssignableRole checks that a role can be given to a member or invitee of a team.
+// Any built-in role except owner can be assigned, as can the team's custom roles.
+func validateAssignableRole(ctx context.Context, roleRepo repository.TeamRoleRepository, teamID primitive.ObjectID, role string) error {
+	_, err := assignableRoleActions(ctx, roleRepo, teamID, role)
+	return err
+}
+
+// assignableRoleActions checks that a role can be assigned, like validateAssignableRole,
+// and returns the actions it grants.
+func assignableRoleActions(ctx context.Context, roleRepo repository.TeamRoleRepository, teamID primitive.ObjectID, role string) ([]string, error) {
+	if role == models.RoleOwner {
+		return nil, apperrors.ErrInvalidRole
+	}
+	actions, err := roleActions(ctx, roleRepo, teamID, role)
+	if errors.Is(err, apperrors.ErrTeamRoleNotFound) {
+		return nil, apperrors.ErrInvalidRole
+	}
+	return actions, err
+}
+
+// roleActions returns the actions granted by a built-in role or one of the team's custom roles.
+func roleActions(ctx context.Context, roleRepo repository.TeamRoleRepository, teamID primitive.ObjectID, role string) ([]string, error) {
+	if actions, ok := authz.BuiltinRoleActions(role); ok {
+		return actions, nil
+	}
+	custom, err := roleRepo.FindByTeamAndName(ctx, teamID, role)
+	if err != nil {
+		return nil, err
+	}
+	return custom.Actions, nil
+}
+
+// uniqueActions removes duplicate actions while keeping their order.
+func uniqueActions(actions []string) []string {
+	seen := make(map[string]bool, len(actions))
+	unique := make([]string, 0, len(actions))
+	for _, action := range actions {
+		if !seen[action] {
+			seen[action] = true
+			unique = append(unique, action)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	apperrors "gin-sample/internal/errors"
+	"gin-sample/internal/models"
+	repomocks "gin-sample/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeRoleCache records which roles were invalidated.
+type fakeRoleCache struct {
+	invalidated []string
+}
+
+func (f *fakeRoleCache) InvalidateRole(_ primitive.ObjectID, name string) {
+	f.invalidated = append(f.invalidated, name)
+}
+
+type teamRoleServiceMocks struct {
+	roleRepo       *repomocks.MockTeamRoleRepository
+	memberRepo     *repomocks.MockTeamMemberRepository
+	invitationRepo *repomocks.MockTeamInvitationRepository
+	roleCache      *fakeRoleCache
+	audit          *fakeAuditRecorder
+}
+
+func newTestTeamRoleService(ctrl *gomock.Controller) (*TeamRoleService, *teamRoleServiceMocks) {
+	m := &teamRoleServiceMocks{
+		roleRepo:       repomocks.NewMockTeamRoleRepository(ctrl),
+		memberRepo:     repomocks.NewMockTeamMemberRepository(ctrl),
+		invitationRepo: repomocks.NewMockTeamInvitationRepository(ctrl),
+		roleCache:      &fakeRoleCache{},
+		audit:          &fakeAuditRecorder{},
+	}
+	return NewTeamRoleService(m.roleRepo, m.memberRepo, m.invitationRepo, m.roleCache, m.audit), m
+}
+
+func TestTeamRoleService_ListRoles(t *testing.T) {
+	teamID := primitive.NewObjectID()
+
+	t.Run("returns built-in and custom roles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamRole{{Name: "reviewer"}}, nil)
+
+		result, err := service.ListRoles(context.Background(), teamID)
+
+		require.NoError(t, err)
+		assert.Len(t, result.BuiltIn, 5)
+		require.Len(t, result.Custom, 1)
+		assert.Equal(t, "reviewer", result.Custom[0].Name)
+	})
+}
+
+func TestTeamRoleService_CreateRole(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+
+	t.Run("creates role with unique actions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().CountByTeamID(gomock.Any(), teamID).Return(0, nil)
+		m.roleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, role *models.TeamRole) error {
+				assert.Equal(t, teamID, role.TeamID)
+				assert.Equal(t, []string{"team:view", "memo:view"}, role.Actions)
+				return nil
+			})
+
+		role, err := service.CreateRole(context.Background(), teamID, ownerID, &models.CreateTeamRoleRequest{
+			Name:    "reviewer",
+			Actions: []string{"team:view", "memo:view", "team:view"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "reviewer", role.Name)
+		assert.Equal(t, []string{"reviewer"}, m.roleCache.invalidated)
+		require.Len(t, m.audit.entries, 1)
+		assert.Equal(t, models.AuditActionRoleCreate, m.audit.entries[0].Action)
+		assert.Equal(t, models.AuditTargetRole, m.audit.entries[0].TargetType)
+		assert.Equal(t, ownerID, m.audit.entries[0].ActorID)
+		assert.Equal(t, []string{"team:view", "memo:view"}, m.audit.entries[0].After["actions"])
+	})
+
+	t.Run("rejects built-in role name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, _ := newTestTeamRoleService(ctrl)
+
+		_, err := service.CreateRole(context.Background(), teamID, ownerID, &models.CreateTeamRoleRequest{
+			Name:    models.RoleViewer,
+			Actions: []string{"team:view"},
+		})
+
+		assert.Equal(t, apperrors.ErrTeamRoleExists, err)
+	})
+
+	t.Run("rejects when limit reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().CountByTeamID(gomock.Any(), teamID).Return(MaxCustomRolesPerTeam, nil)
+
+		_, err := service.CreateRole(context.Background(), teamID, ownerID, &models.CreateTeamRoleRequest{
+			Name:    "reviewer",
+			Actions: []string{"team:view"},
+		})
+
+		assert.Equal(t, apperrors.ErrTeamRoleLimitReached, err)
+	})
+}
+
+func TestTeamRoleService_UpdateRole(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+
+	t.Run("updates actions and invalidates cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		roleID := primitive.NewObjectID()
+		m.roleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(&models.TeamRole{ID: roleID, Name: "reviewer", Actions: []string{"team:view", "memo:view"}}, nil)
+		m.roleRepo.EXPECT().
+			UpdateActions(gomock.Any(), teamID, "reviewer", []string{"team:view"}).
+			Return(&models.TeamRole{ID: roleID, Name: "reviewer", Actions: []string{"team:view"}}, nil)
+
+		role, err := service.UpdateRole(context.Background(), teamID, ownerID, "reviewer", &models.UpdateTeamRoleRequest{
+			Actions: []string{"team:view"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team:view"}, role.Actions)
+		assert.Equal(t, []string{"reviewer"}, m.roleCache.invalidated)
+		require.Len(t, m.audit.entries, 1)
+		assert.Equal(t, models.AuditActionRoleUpdate, m.audit.entries[0].Action)
+		assert.Equal(t, roleID, m.audit.entries[0].TargetID)
+		assert.Equal(t, []string{"team:view", "memo:view"}, m.audit.entries[0].Before["actions"])
+		assert.Equal(t, []string{"team:view"}, m.audit.entries[0].After["actions"])
+	})
+
+	t.Run("returns not found for unknown role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(nil, apperrors.ErrTeamRoleNotFound)
+
+		_, err := service.UpdateRole(context.Background(), teamID, ownerID, "reviewer", &models.UpdateTeamRoleRequest{
+			Actions: []string{"team:view"},
+		})
+
+		assert.Equal(t, apperrors.ErrTeamRoleNotFound, err)
+		assert.Empty(t, m.audit.entries)
+	})
+
+	t.Run("built-in roles cannot be changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+
+		_, err := service.UpdateRole(context.Background(), teamID, ownerID, models.RoleMember, &models.UpdateTeamRoleRequest{
+			Actions: []string{"team:view"},
+		})
+
+		assert.Equal(t, apperrors.ErrTeamRoleNotFound, err)
+		assert.Empty(t, m.roleCache.invalidated)
+	})
+}
+
+func TestTeamRoleService_DeleteRole(t *testing.T) {
+	teamID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+
+	t.Run("deletes unused role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		roleID := primitive.NewObjectID()
+		m.roleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(&models.TeamRole{ID: roleID, Name: "reviewer"}, nil)
+		m.memberRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamMember{{Role: models.RoleMember}}, nil)
+		m.invitationRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamInvitation{{Role: models.RoleAdmin}}, nil)
+		m.roleRepo.EXPECT().Delete(gomock.Any(), teamID, "reviewer").Return(nil)
+
+		err := service.DeleteRole(context.Background(), teamID, ownerID, "reviewer")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reviewer"}, m.roleCache.invalidated)
+		require.Len(t, m.audit.entries, 1)
+		assert.Equal(t, models.AuditActionRoleDelete, m.audit.entries[0].Action)
+		assert.Equal(t, roleID, m.audit.entries[0].TargetID)
+		assert.Equal(t, "reviewer", m.audit.entries[0].Before["name"])
+	})
+
+	t.Run("rejects role assigned to a member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(&models.TeamRole{Name: "reviewer"}, nil)
+		m.memberRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamMember{{Role: "reviewer"}}, nil)
+
+		err := service.DeleteRole(context.Background(), teamID, ownerID, "reviewer")
+
+		assert.Equal(t, apperrors.ErrTeamRoleInUse, err)
+		assert.Empty(t, m.audit.entries)
+	})
+
+	t.Run("rejects role used by a pending invitation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(&models.TeamRole{Name: "reviewer"}, nil)
+		m.memberRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamMember{}, nil)
+		m.invitationRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamInvitation{{Role: "reviewer"}}, nil)
+
+		err := service.DeleteRole(context.Background(), teamID, ownerID, "reviewer")
+
+		assert.Equal(t, apperrors.ErrTeamRoleInUse, err)
+	})
+
+	t.Run("returns not found for unknown role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, m := newTestTeamRoleService(ctrl)
+		m.roleRepo.EXPECT().
+			FindByTeamAndName(gomock.Any(), teamID, "reviewer").
+			Return(nil, apperrors.ErrTeamRoleNotFound)
+
+		err := service.DeleteRole(context.Background(), teamID, ownerID, "reviewer")
+
+		assert.Equal(t, apperrors.ErrTeamRoleNotFound, err)
+	})
+}
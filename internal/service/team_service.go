@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	"gin-sample/internal/repository"
@@ -16,21 +18,40 @@ type TeamService struct {
 	teamRepo       repository.TeamRepository
 	memberRepo     repository.TeamMemberRepository
 	invitationRepo repository.TeamInvitationRepository
+	roleRepo       repository.TeamRoleRepository
 	memoRepo       repository.VoiceMemoRepository
+	memberCache    MemberCacheInvalidator
+	roleCache      RoleCacheInvalidator
+	relationships  RelationshipWriter
+	audit          AuditRecorder
 }
 
 // NewTeamService creates a new TeamService.
+// If memberCache is not nil, ownership transfers and team deletion invalidate cached memberships.
+// If roleCache is not nil, team deletion invalidates the team's cached custom roles.
+// If relationships is not nil, team creation, transfers and deletion are recorded as authorization relationships.
+// If audit is not nil, updates, transfers and deletion are written to the audit log.
 func NewTeamService(
 	teamRepo repository.TeamRepository,
 	memberRepo repository.TeamMemberRepository,
 	invitationRepo repository.TeamInvitationRepository,
+	roleRepo repository.TeamRoleRepository,
 	memoRepo repository.VoiceMemoRepository,
+	memberCache MemberCacheInvalidator,
+	roleCache RoleCacheInvalidator,
+	relationships RelationshipWriter,
+	audit AuditRecorder,
 ) *TeamService {
 	return &TeamService{
 		teamRepo:       teamRepo,
 		memberRepo:     memberRepo,
 		invitationRepo: invitationRepo,
+		roleRepo:       roleRepo,
 		memoRepo:       memoRepo,
+		memberCache:    memberCache,
+		roleCache:      roleCache,
+		relationships:  relationships,
+		audit:          audit,
 	}
 }
 
@@ -79,6 +100,12 @@ func (s *TeamService) CreateTeam(ctx context.Context, userID primitive.ObjectID,
 		_ = s.teamRepo.SoftDelete(ctx, team.ID)
 		return nil, err
 	}
+	if err := writeMemberRelationship(ctx, s.relationships, team.ID, userID, models.RoleOwner); err != nil {
+		// Rollback member and team creation
+		_ = s.memberRepo.Delete(ctx, team.ID, userID)
+		_ = s.teamRepo.SoftDelete(ctx, team.ID)
+		return nil, err
+	}
 
 	return team, nil
 }
@@ -118,12 +145,13 @@ func (s *TeamService) GetTeam(ctx context.Context, teamID primitive.ObjectID) (*
 	return s.teamRepo.FindByID(ctx, teamID)
 }
 
-// UpdateTeam updates a team's information.
-func (s *TeamService) UpdateTeam(ctx context.Context, teamID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
+// UpdateTeam updates a team's information on behalf of actorID.
+func (s *TeamService) UpdateTeam(ctx context.Context, teamID, actorID primitive.ObjectID, req *models.UpdateTeamRequest) (*models.Team, error) {
 	team, err := s.teamRepo.FindByID(ctx, teamID)
 	if err != nil {
 		return nil, err
 	}
+	before := teamAuditState(team)
 
 	// Update fields if provided
 	if req.Name != nil {
@@ -151,28 +179,85 @@ func (s *TeamService) UpdateTeam(ctx context.Context, teamID primitive.ObjectID,
 		return nil, err
 	}
 
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    actorID,
+		Action:     authz.ActionTeamUpdate,
+		TargetType: models.AuditTargetTeam,
+		TargetID:   teamID,
+		Before:     before,
+		After:      teamAuditState(team),
+	})
 	return team, nil
 }
 
-// DeleteTeam soft deletes a team and all related data.
-func (s *TeamService) DeleteTeam(ctx context.Context, teamID primitive.ObjectID) error {
+// DeleteTeam soft deletes a team and all related data on behalf of actorID.
+// The team's audit log is kept.
+func (s *TeamService) DeleteTeam(ctx context.Context, teamID, actorID primitive.ObjectID) error {
 	// Soft delete team voice memos
 	if err := s.memoRepo.SoftDeleteByTeamID(ctx, teamID); err != nil {
 		return err
 	}
 
+	// Look up members first so their cached memberships can be dropped
+	var members []models.TeamMember
+	if s.memberCache != nil {
+		var err error
+		members, err = s.memberRepo.FindByTeamID(ctx, teamID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove access first so a failure below never leaves former members with access
+	if s.relationships != nil {
+		if err := s.relationships.DeleteTeam(ctx, teamID); err != nil {
+			return fmt.Errorf("delete team relationships: %w", err)
+		}
+	}
+
 	// Hard delete all team members
 	if err := s.memberRepo.DeleteAllByTeamID(ctx, teamID); err != nil {
 		return err
 	}
+	for _, member := range members {
+		invalidateMember(ctx, s.memberCache, teamID, member.UserID)
+	}
 
 	// Hard delete all pending invitations
 	if err := s.invitationRepo.DeleteAllByTeamID(ctx, teamID); err != nil {
 		return err
 	}
 
+	// Hard delete all custom roles, dropping their cached permissions
+	var roles []models.TeamRole
+	if s.roleCache != nil {
+		var err error
+		roles, err = s.roleRepo.FindByTeamID(ctx, teamID)
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.roleRepo.DeleteAllByTeamID(ctx, teamID); err != nil {
+		return err
+	}
+	for _, role := range roles {
+		s.roleCache.InvalidateRole(teamID, role.Name)
+	}
+
 	// Soft delete team
-	return s.teamRepo.SoftDelete(ctx, teamID)
+	if err := s.teamRepo.SoftDelete(ctx, teamID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    actorID,
+		Action:     authz.ActionTeamDelete,
+		TargetType: models.AuditTargetTeam,
+		TargetID:   teamID,
+	})
+	return nil
 }
 
 // TransferOwnership transfers team ownership to another member.
@@ -189,6 +274,12 @@ func (s *TeamService) TransferOwnership(ctx context.Context, teamID, currentOwne
 		return err
 	}
 
+	// Both roles may change below, including on rollback
+	defer func() {
+		invalidateMember(ctx, s.memberCache, teamID, newOwnerID)
+		invalidateMember(ctx, s.memberCache, teamID, currentOwnerID)
+	}()
+
 	// Update new owner's role to owner
 	if err := s.memberRepo.UpdateRole(ctx, teamID, newOwnerID, models.RoleOwner); err != nil {
 		return err
@@ -209,5 +300,46 @@ func (s *TeamService) TransferOwnership(ctx context.Context, teamID, currentOwne
 		_ = s.memberRepo.UpdateRole(ctx, teamID, newOwnerID, newOwnerMember.Role)
 		return err
 	}
+
+	if err := s.writeTransferRelationships(ctx, teamID, currentOwnerID, newOwnerID, newOwnerMember.Role); err != nil {
+		// Rollback the team and both role changes
+		team.OwnerID = currentOwnerID
+		_ = s.teamRepo.Update(ctx, team)
+		_ = s.memberRepo.UpdateRole(ctx, teamID, currentOwnerID, models.RoleOwner)
+		_ = s.memberRepo.UpdateRole(ctx, teamID, newOwnerID, newOwnerMember.Role)
+		return err
+	}
+	recordAudit(ctx, s.audit, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    currentOwnerID,
+		Action:     authz.ActionTeamTransfer,
+		TargetType: models.AuditTargetTeam,
+		TargetID:   teamID,
+		Before:     map[string]any{"ownerId": currentOwnerID.Hex()},
+		After:      map[string]any{"ownerId": newOwnerID.Hex()},
+	})
+	return nil
+}
+
+// writeTransferRelationships records an ownership transfer. If the second write fails, the
+// first is undone so both members keep their previous roles.
+func (s *TeamService) writeTransferRelationships(ctx context.Context, teamID, currentOwnerID, newOwnerID primitive.ObjectID, newOwnerRole string) error {
+	if err := writeMemberRelationship(ctx, s.relationships, teamID, newOwnerID, models.RoleOwner); err != nil {
+		return err
+	}
+	if err := writeMemberRelationship(ctx, s.relationships, teamID, currentOwnerID, models.RoleAdmin); err != nil {
+		restoreMemberRelationship(ctx, s.relationships, teamID, newOwnerID, newOwnerRole)
+		return err
+	}
 	return nil
 }
+
+// teamAuditState returns the audited fields of a team.
+func teamAuditState(team *models.Team) map[string]any {
+	return map[string]any{
+		"name":        team.Name,
+		"slug":        team.Slug,
+		"description": team.Description,
+		"logoUrl":     team.LogoURL,
+	}
+}
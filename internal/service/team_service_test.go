@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	repomocks "gin-sample/internal/repository/mocks"
@@ -21,9 +22,10 @@ func TestNewTeamService(t *testing.T) {
 	mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 	mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 	mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+	mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 	mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
-	service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+	service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 
 	assert.NotNil(t, service)
 }
@@ -43,6 +45,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockTeamRepo.EXPECT().
@@ -71,12 +74,14 @@ func TestTeamService_CreateTeam(t *testing.T) {
 				return nil
 			})
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		relationships := newFakeRelationshipWriter()
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, relationships, nil)
 		team, err := service.CreateTeam(context.Background(), userID, createReq)
 
 		require.NoError(t, err)
 		assert.NotNil(t, team)
 		assert.Equal(t, createReq.Name, team.Name)
+		assert.Equal(t, map[primitive.ObjectID]string{userID: models.RoleOwner}, relationships.roles)
 	})
 
 	t.Run("returns error when team limit reached", func(t *testing.T) {
@@ -86,13 +91,14 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockTeamRepo.EXPECT().
 			CountByOwnerID(gomock.Any(), userID).
 			Return(1, nil) // Already has 1 team
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		team, err := service.CreateTeam(context.Background(), userID, createReq)
 
 		assert.Nil(t, team)
@@ -106,6 +112,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockTeamRepo.EXPECT().
@@ -117,7 +124,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 			FindBySlug(gomock.Any(), createReq.Slug).
 			Return(existingTeam, nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		team, err := service.CreateTeam(context.Background(), userID, createReq)
 
 		assert.Nil(t, team)
@@ -131,6 +138,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		var createdTeamID primitive.ObjectID
@@ -158,7 +166,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 			SoftDelete(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		team, err := service.CreateTeam(context.Background(), userID, createReq)
 
 		assert.Nil(t, team)
@@ -176,6 +184,7 @@ func TestTeamService_ListTeams(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		teams := []models.Team{
@@ -187,7 +196,7 @@ func TestTeamService_ListTeams(t *testing.T) {
 			FindByUserID(gomock.Any(), userID, 1, 10).
 			Return(teams, 2, nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		result, err := service.ListTeams(context.Background(), userID, 1, 10)
 
 		require.NoError(t, err)
@@ -203,13 +212,14 @@ func TestTeamService_ListTeams(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockTeamRepo.EXPECT().
 			FindByUserID(gomock.Any(), userID, 1, 10). // Default values
 			Return([]models.Team{}, 0, nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		_, err := service.ListTeams(context.Background(), userID, 0, 0) // Invalid values
 
 		assert.NoError(t, err)
@@ -222,13 +232,14 @@ func TestTeamService_ListTeams(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockTeamRepo.EXPECT().
 			FindByUserID(gomock.Any(), userID, 1, 10). // Capped at 10
 			Return([]models.Team{}, 0, nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		_, err := service.ListTeams(context.Background(), userID, 1, 100) // Request 100
 
 		assert.NoError(t, err)
@@ -245,6 +256,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		team := &models.Team{ID: teamID, Name: "Test Team"}
@@ -252,7 +264,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 			FindByID(gomock.Any(), teamID).
 			Return(team, nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		result, err := service.GetTeam(context.Background(), teamID)
 
 		require.NoError(t, err)
@@ -266,13 +278,14 @@ func TestTeamService_GetTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockTeamRepo.EXPECT().
 			FindByID(gomock.Any(), teamID).
 			Return(nil, apperrors.ErrTeamNotFound)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		result, err := service.GetTeam(context.Background(), teamID)
 
 		assert.Nil(t, result)
@@ -290,6 +303,7 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		newName := "Updated Name"
@@ -303,8 +317,8 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			Update(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
-		result, err := service.UpdateTeam(context.Background(), teamID, updateReq)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
+		result, err := service.UpdateTeam(context.Background(), teamID, primitive.NewObjectID(), updateReq)
 
 		require.NoError(t, err)
 		assert.Equal(t, newName, result.Name)
@@ -317,6 +331,7 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		newSlug := "new-slug"
@@ -334,8 +349,8 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			Update(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
-		result, err := service.UpdateTeam(context.Background(), teamID, updateReq)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
+		result, err := service.UpdateTeam(context.Background(), teamID, primitive.NewObjectID(), updateReq)
 
 		require.NoError(t, err)
 		assert.Equal(t, newSlug, result.Slug)
@@ -348,6 +363,7 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		newSlug := "taken-slug"
@@ -362,8 +378,8 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			FindBySlug(gomock.Any(), newSlug).
 			Return(&models.Team{ID: otherTeamID, Slug: newSlug}, nil) // Different team has slug
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
-		result, err := service.UpdateTeam(context.Background(), teamID, updateReq)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
+		result, err := service.UpdateTeam(context.Background(), teamID, primitive.NewObjectID(), updateReq)
 
 		assert.Nil(t, result)
 		assert.Equal(t, apperrors.ErrTeamSlugTaken, err)
@@ -376,6 +392,7 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		sameSlug := "same-slug"
@@ -393,8 +410,8 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			Update(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
-		_, err := service.UpdateTeam(context.Background(), teamID, updateReq)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
+		_, err := service.UpdateTeam(context.Background(), teamID, primitive.NewObjectID(), updateReq)
 
 		assert.NoError(t, err)
 	})
@@ -410,6 +427,7 @@ func TestTeamService_DeleteTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockMemoRepo.EXPECT().
@@ -424,14 +442,153 @@ func TestTeamService_DeleteTeam(t *testing.T) {
 			DeleteAllByTeamID(gomock.Any(), teamID).
 			Return(nil)
 
+		mockRoleRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockTeamRepo.EXPECT().
+			SoftDelete(gomock.Any(), teamID).
+			Return(nil)
+
+		relationships := newFakeRelationshipWriter()
+		audit := &fakeAuditRecorder{}
+		actorID := primitive.NewObjectID()
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, relationships, audit)
+		err := service.DeleteTeam(context.Background(), teamID, actorID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{teamID}, relationships.deletedTeams)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, authz.ActionTeamDelete, audit.entries[0].Action)
+		assert.Equal(t, actorID, audit.entries[0].ActorID)
+		assert.Equal(t, teamID, audit.entries[0].TargetID)
+	})
+
+	t.Run("invalidates cached memberships", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		ownerID := primitive.NewObjectID()
+		memberID := primitive.NewObjectID()
+
+		mockMemoRepo.EXPECT().
+			SoftDeleteByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockMemberRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return([]models.TeamMember{{TeamID: teamID, UserID: ownerID}, {TeamID: teamID, UserID: memberID}}, nil)
+
+		mockMemberRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockInvitationRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockRoleRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockTeamRepo.EXPECT().
+			SoftDelete(gomock.Any(), teamID).
+			Return(nil)
+
+		memberCache := &fakeMemberCache{}
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, memberCache, nil, nil, nil)
+		err := service.DeleteTeam(context.Background(), teamID, primitive.NewObjectID())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{ownerID, memberID}, memberCache.invalidated)
+	})
+
+	t.Run("deletes custom roles and invalidates cached roles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		roles := []models.TeamRole{
+			{TeamID: teamID, Name: "editor"},
+			{TeamID: teamID, Name: "reviewer"},
+		}
+
+		mockMemoRepo.EXPECT().
+			SoftDeleteByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockMemberRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockInvitationRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockRoleRepo.EXPECT().
+			FindByTeamID(gomock.Any(), teamID).
+			Return(roles, nil)
+
+		mockRoleRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			DoAndReturn(func(ctx context.Context, tID primitive.ObjectID) error {
+				roles = nil
+				return nil
+			})
+
 		mockTeamRepo.EXPECT().
 			SoftDelete(gomock.Any(), teamID).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
-		err := service.DeleteTeam(context.Background(), teamID)
+		roleCache := &fakeRoleCache{}
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, roleCache, nil, nil)
+		err := service.DeleteTeam(context.Background(), teamID, primitive.NewObjectID())
 
 		assert.NoError(t, err)
+		assert.Empty(t, roles)
+		assert.Equal(t, []string{"editor", "reviewer"}, roleCache.invalidated)
+	})
+
+	t.Run("returns error if role deletion fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
+		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
+		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
+		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockMemoRepo.EXPECT().
+			SoftDeleteByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockMemberRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockInvitationRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(nil)
+
+		mockRoleRepo.EXPECT().
+			DeleteAllByTeamID(gomock.Any(), teamID).
+			Return(assert.AnError)
+
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
+		err := service.DeleteTeam(context.Background(), teamID, primitive.NewObjectID())
+
+		assert.Error(t, err)
 	})
 
 	t.Run("returns error if memo deletion fails", func(t *testing.T) {
@@ -441,14 +598,15 @@ func TestTeamService_DeleteTeam(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockMemoRepo.EXPECT().
 			SoftDeleteByTeamID(gomock.Any(), teamID).
 			Return(assert.AnError)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
-		err := service.DeleteTeam(context.Background(), teamID)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
+		err := service.DeleteTeam(context.Background(), teamID, primitive.NewObjectID())
 
 		assert.Error(t, err)
 	})
@@ -466,6 +624,7 @@ func TestTeamService_TransferOwnership(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		newOwnerMember := &models.TeamMember{
@@ -499,10 +658,20 @@ func TestTeamService_TransferOwnership(t *testing.T) {
 			Update(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		memberCache := &fakeMemberCache{}
+		relationships := newFakeRelationshipWriter()
+		audit := &fakeAuditRecorder{}
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, memberCache, nil, relationships, audit)
 		err := service.TransferOwnership(context.Background(), teamID, currentOwnerID, newOwnerID)
 
 		assert.NoError(t, err)
+		assert.ElementsMatch(t, []primitive.ObjectID{newOwnerID, currentOwnerID}, memberCache.invalidated)
+		assert.Equal(t, map[primitive.ObjectID]string{newOwnerID: models.RoleOwner, currentOwnerID: models.RoleAdmin}, relationships.roles)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, authz.ActionTeamTransfer, audit.entries[0].Action)
+		assert.Equal(t, currentOwnerID, audit.entries[0].ActorID)
+		assert.Equal(t, currentOwnerID.Hex(), audit.entries[0].Before["ownerId"])
+		assert.Equal(t, newOwnerID.Hex(), audit.entries[0].After["ownerId"])
 	})
 
 	t.Run("returns error when new owner is not a member", func(t *testing.T) {
@@ -512,13 +681,14 @@ func TestTeamService_TransferOwnership(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		mockMemberRepo.EXPECT().
 			FindByTeamAndUser(gomock.Any(), teamID, newOwnerID).
 			Return(nil, apperrors.ErrNotTeamMember)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		err := service.TransferOwnership(context.Background(), teamID, currentOwnerID, newOwnerID)
 
 		assert.Equal(t, apperrors.ErrNotTeamMember, err)
@@ -531,6 +701,7 @@ func TestTeamService_TransferOwnership(t *testing.T) {
 		mockTeamRepo := repomocks.NewMockTeamRepository(ctrl)
 		mockMemberRepo := repomocks.NewMockTeamMemberRepository(ctrl)
 		mockInvitationRepo := repomocks.NewMockTeamInvitationRepository(ctrl)
+		mockRoleRepo := repomocks.NewMockTeamRoleRepository(ctrl)
 		mockMemoRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
 
 		newOwnerMember := &models.TeamMember{
@@ -570,7 +741,7 @@ func TestTeamService_TransferOwnership(t *testing.T) {
 			UpdateRole(gomock.Any(), teamID, newOwnerID, models.RoleMember).
 			Return(nil)
 
-		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockMemoRepo)
+		service := NewTeamService(mockTeamRepo, mockMemberRepo, mockInvitationRepo, mockRoleRepo, mockMemoRepo, nil, nil, nil, nil)
 		err := service.TransferOwnership(context.Background(), teamID, currentOwnerID, newOwnerID)
 
 		assert.Error(t, err)
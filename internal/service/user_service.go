@@ -12,19 +12,27 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// SessionRevoker signs a user out of all sessions.
+type SessionRevoker interface {
+	LogoutAll(ctx context.Context, userID primitive.ObjectID) error
+}
+
 // UserService handles business logic for user operations.
 type UserService struct {
 	repo         repository.UserRepository
 	cache        cache.Cache
 	userCacheTTL time.Duration
+	sessions     SessionRevoker
 }
 
 // NewUserService creates a new UserService.
-func NewUserService(repo repository.UserRepository, cache cache.Cache, userCacheTTL time.Duration) *UserService {
+// If sessions is not nil, deleting a user signs them out of all sessions.
+func NewUserService(repo repository.UserRepository, cache cache.Cache, userCacheTTL time.Duration, sessions SessionRevoker) *UserService {
 	return &UserService{
 		repo:         repo,
 		cache:        cache,
 		userCacheTTL: userCacheTTL,
+		sessions:     sessions,
 	}
 }
 
@@ -68,8 +76,15 @@ func (s *UserService) UpdateUser(ctx context.Context, id primitive.ObjectID, req
 	return user, nil
 }
 
-// DeleteUser removes a user.
+// DeleteUser removes a user and revokes their tokens.
 func (s *UserService) DeleteUser(ctx context.Context, id primitive.ObjectID) error {
+	// Revoke first so a failure leaves the account intact rather than deleted but signed in
+	if s.sessions != nil {
+		if err := s.sessions.LogoutAll(ctx, id); err != nil {
+			return err
+		}
+	}
+
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
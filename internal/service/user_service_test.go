@@ -23,7 +23,7 @@ func TestNewUserService(t *testing.T) {
 	mockRepo := repomocks.NewMockUserRepository(ctrl)
 	mockCache := cachemocks.NewMockCache(ctrl)
 
-	service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+	service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 
 	assert.NotNil(t, service)
 	assert.Equal(t, mockRepo, service.repo)
@@ -54,7 +54,7 @@ func TestUserService_GetUser(t *testing.T) {
 				return true, nil
 			})
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		user, err := service.GetUser(context.Background(), validUserID)
 
 		require.NoError(t, err)
@@ -81,7 +81,7 @@ func TestUserService_GetUser(t *testing.T) {
 			Set(gomock.Any(), "user:"+validUserID.Hex(), validUser, 15*time.Minute).
 			Return(nil)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		user, err := service.GetUser(context.Background(), validUserID)
 
 		require.NoError(t, err)
@@ -103,7 +103,7 @@ func TestUserService_GetUser(t *testing.T) {
 			FindByID(gomock.Any(), validUserID).
 			Return(nil, apperrors.ErrUserNotFound)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		user, err := service.GetUser(context.Background(), validUserID)
 
 		assert.Nil(t, user)
@@ -129,7 +129,7 @@ func TestUserService_GetUser(t *testing.T) {
 			Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(assert.AnError) // Cache set fails
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		user, err := service.GetUser(context.Background(), validUserID)
 
 		require.NoError(t, err) // Should not fail on cache error
@@ -154,7 +154,7 @@ func TestUserService_GetAllUsers(t *testing.T) {
 			FindAll(gomock.Any()).
 			Return(users, nil)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		result, err := service.GetAllUsers(context.Background())
 
 		require.NoError(t, err)
@@ -172,7 +172,7 @@ func TestUserService_GetAllUsers(t *testing.T) {
 			FindAll(gomock.Any()).
 			Return(nil, assert.AnError)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		result, err := service.GetAllUsers(context.Background())
 
 		assert.Nil(t, result)
@@ -204,7 +204,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 			Delete(gomock.Any(), "user:"+validUserID.Hex()).
 			Return(nil)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		user, err := service.UpdateUser(context.Background(), validUserID, updateReq)
 
 		require.NoError(t, err)
@@ -222,7 +222,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 			Update(gomock.Any(), validUserID, updateReq).
 			Return(nil, apperrors.ErrUserNotFound)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		user, err := service.UpdateUser(context.Background(), validUserID, updateReq)
 
 		assert.Nil(t, user)
@@ -248,7 +248,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 			Delete(gomock.Any(), "user:"+validUserID.Hex()).
 			Return(nil)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		err := service.DeleteUser(context.Background(), validUserID)
 
 		assert.NoError(t, err)
@@ -265,11 +265,62 @@ func TestUserService_DeleteUser(t *testing.T) {
 			Delete(gomock.Any(), validUserID).
 			Return(apperrors.ErrUserNotFound)
 
-		service := NewUserService(mockRepo, mockCache, 15*time.Minute)
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, nil)
 		err := service.DeleteUser(context.Background(), validUserID)
 
 		assert.Equal(t, apperrors.ErrUserNotFound, err)
 	})
+
+	t.Run("signs user out of all sessions before deleting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+		sessions := &fakeSessionRevoker{}
+
+		mockRepo.EXPECT().
+			Delete(gomock.Any(), validUserID).
+			DoAndReturn(func(context.Context, primitive.ObjectID) error {
+				assert.Equal(t, []primitive.ObjectID{validUserID}, sessions.revoked)
+				return nil
+			})
+		mockCache.EXPECT().
+			Delete(gomock.Any(), "user:"+validUserID.Hex()).
+			Return(nil)
+
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, sessions)
+		err := service.DeleteUser(context.Background(), validUserID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("does not delete user when sessions cannot be revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockUserRepository(ctrl)
+		mockCache := cachemocks.NewMockCache(ctrl)
+
+		service := NewUserService(mockRepo, mockCache, 15*time.Minute, &fakeSessionRevoker{err: assert.AnError})
+		err := service.DeleteUser(context.Background(), validUserID)
+
+		assert.Error(t, err)
+	})
+}
+
+// fakeSessionRevoker records the users signed out by LogoutAll.
+type fakeSessionRevoker struct {
+	revoked []primitive.ObjectID
+	err     error
+}
+
+func (f *fakeSessionRevoker) LogoutAll(_ context.Context, userID primitive.ObjectID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.revoked = append(f.revoked, userID)
+	return nil
 }
 
 // Helper function
@@ -4,36 +4,83 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	"gin-sample/internal/queue"
+	"gin-sample/internal/ratelimit"
 	"gin-sample/internal/repository"
+	"gin-sample/internal/requestid"
 	"gin-sample/internal/storage"
+	"gin-sample/internal/tracing"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // VoiceMemoService handles business logic for voice memo operations.
 type VoiceMemoService struct {
-	repo                  repository.VoiceMemoRepository
-	s3Client              storage.Storage
-	queue                 queue.Queue
-	presignedURLExpiry    time.Duration
-	presignedUploadExpiry time.Duration
+	repo     repository.VoiceMemoRepository
+	s3Client storage.Storage
+	queue    queue.Queue
+	limiter  ratelimit.Limiter
+	settings atomic.Pointer[VoiceMemoSettings]
+	audit    AuditRecorder
+}
+
+// VoiceMemoSettings are the voice memo settings that can change while the server runs.
+type VoiceMemoSettings struct {
+	PresignedURLExpiry    time.Duration
+	PresignedUploadExpiry time.Duration
+	Limits                VoiceMemoLimits
+}
+
+// VoiceMemoLimits configures rate limits and quotas for voice memos.
+// Zero values are not enforced.
+type VoiceMemoLimits struct {
+	// CreatePerUser limits memo creation per user, for private and team memos.
+	CreatePerUser ratelimit.Limit
+	// CreatePerTeam limits memo creation per team.
+	CreatePerTeam ratelimit.Limit
+	// User applies to a user's private memos.
+	User UsageQuota
+	// Team applies to all memos of a team.
+	Team UsageQuota
+}
+
+// UsageQuota limits stored memos, audio storage and monthly transcription.
+type UsageQuota struct {
+	MaxMemos                int64
+	MaxAudioBytes           int64
+	MaxTranscriptionMinutes int64
 }
 
 // NewVoiceMemoService creates a new VoiceMemoService.
-func NewVoiceMemoService(repo repository.VoiceMemoRepository, s3Client storage.Storage, queue queue.Queue, presignedURLExpiry, presignedUploadExpiry time.Duration) *VoiceMemoService {
-	return &VoiceMemoService{
-		repo:                  repo,
-		s3Client:              s3Client,
-		queue:                 queue,
-		presignedURLExpiry:    presignedURLExpiry,
-		presignedUploadExpiry: presignedUploadExpiry,
-	}
+// The limiter may be nil, in which case rate limits are not enforced.
+// If audit is not nil, team memo deletions are written to the audit log.
+func NewVoiceMemoService(repo repository.VoiceMemoRepository, s3Client storage.Storage, queue queue.Queue, presignedURLExpiry, presignedUploadExpiry time.Duration, limiter ratelimit.Limiter, limits VoiceMemoLimits, audit AuditRecorder) *VoiceMemoService {
+	s := &VoiceMemoService{
+		repo:     repo,
+		s3Client: s3Client,
+		queue:    queue,
+		limiter:  limiter,
+		audit:    audit,
+	}
+	s.UpdateSettings(VoiceMemoSettings{
+		PresignedURLExpiry:    presignedURLExpiry,
+		PresignedUploadExpiry: presignedUploadExpiry,
+		Limits:                limits,
+	})
+	return s
+}
+
+// UpdateSettings replaces the presigned URL expiries, rate limits and quotas.
+// Operations already in progress keep the settings they started with.
+func (s *VoiceMemoService) UpdateSettings(settings VoiceMemoSettings) {
+	s.settings.Store(&settings)
 }
 
 // ListByUserID retrieves paginated voice memos for a user with pre-signed URLs.
@@ -59,9 +106,10 @@ func (s *VoiceMemoService) ListByUserID(ctx context.Context, userID string, page
 	}
 
 	// Generate pre-signed URLs for each memo
+	expiry := s.settings.Load().PresignedURLExpiry
 	for i := range memos {
 		if memos[i].AudioFileKey != "" {
-			url, err := s.s3Client.GetPresignedURL(ctx, memos[i].AudioFileKey, s.presignedURLExpiry)
+			url, err := s.s3Client.GetPresignedURL(ctx, memos[i].AudioFileKey, expiry)
 			if err != nil {
 				// Log error but continue - URL will be empty
 				continue
@@ -113,9 +161,10 @@ func (s *VoiceMemoService) ListByTeamID(ctx context.Context, teamID string, page
 	}
 
 	// Generate pre-signed URLs for each memo
+	expiry := s.settings.Load().PresignedURLExpiry
 	for i := range memos {
 		if memos[i].AudioFileKey != "" {
-			url, err := s.s3Client.GetPresignedURL(ctx, memos[i].AudioFileKey, s.presignedURLExpiry)
+			url, err := s.s3Client.GetPresignedURL(ctx, memos[i].AudioFileKey, expiry)
 			if err != nil {
 				continue
 			}
@@ -148,7 +197,7 @@ func (s *VoiceMemoService) GetVoiceMemo(ctx context.Context, memoID primitive.Ob
 
 	// Generate pre-signed URL
 	if memo.AudioFileKey != "" {
-		url, err := s.s3Client.GetPresignedURL(ctx, memo.AudioFileKey, s.presignedURLExpiry)
+		url, err := s.s3Client.GetPresignedURL(ctx, memo.AudioFileKey, s.settings.Load().PresignedURLExpiry)
 		if err == nil {
 			memo.AudioFileURL = url
 		}
@@ -157,14 +206,64 @@ func (s *VoiceMemoService) GetVoiceMemo(ctx context.Context, memoID primitive.Ob
 	return memo, nil
 }
 
-// DeleteTeamVoiceMemo soft deletes a team voice memo with atomic team check.
-// Idempotent - returns nil if memo is already deleted.
-func (s *VoiceMemoService) DeleteTeamVoiceMemo(ctx context.Context, memoID, teamID primitive.ObjectID) error {
-	return s.repo.SoftDeleteWithTeam(ctx, memoID, teamID)
+// DeleteTeamVoiceMemo soft deletes a team voice memo on behalf of actorID with atomic team check.
+// Idempotent - returns nil if memo is already deleted. Only the first deletion is audited.
+func (s *VoiceMemoService) DeleteTeamVoiceMemo(ctx context.Context, memoID, teamID, actorID primitive.ObjectID) error {
+	if s.audit == nil {
+		return s.repo.SoftDeleteWithTeam(ctx, memoID, teamID)
+	}
+
+	// Look up the memo first so the entry can record what was deleted
+	memo, err := s.repo.FindByIDIncludingDeleted(ctx, memoID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SoftDeleteWithTeam(ctx, memoID, teamID); err != nil {
+		return err
+	}
+	if memo.DeletedAt != nil {
+		return nil
+	}
+
+	s.audit.Record(ctx, &models.AuditLogEntry{
+		TeamID:     teamID,
+		ActorID:    actorID,
+		Action:     authz.ActionMemoDelete,
+		TargetType: models.AuditTargetMemo,
+		TargetID:   memoID,
+		Before: map[string]any{
+			"title":     memo.Title,
+			"creatorId": memo.UserID.Hex(),
+			"status":    string(memo.Status),
+		},
+	})
+	return nil
+}
+
+// GetTeamVoiceMemoOwner returns the ID of the user who created a team voice memo.
+// Soft-deleted memos are included so that repeated deletes stay idempotent.
+func (s *VoiceMemoService) GetTeamVoiceMemoOwner(ctx context.Context, memoID, teamID primitive.ObjectID) (primitive.ObjectID, error) {
+	memo, err := s.repo.FindByIDIncludingDeleted(ctx, memoID)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if memo.TeamID == nil || *memo.TeamID != teamID {
+		return primitive.NilObjectID, apperrors.ErrVoiceMemoNotFound
+	}
+	return memo.UserID, nil
 }
 
 // CreateVoiceMemo creates a new private voice memo and returns upload URL.
+// Returns a RateLimitError or a quota error if the user is over their limits.
 func (s *VoiceMemoService) CreateVoiceMemo(ctx context.Context, userID primitive.ObjectID, req *models.CreateVoiceMemoRequest) (*models.CreateVoiceMemoResponse, error) {
+	settings := s.settings.Load()
+	if err := s.checkRateLimit(ctx, "memo_create:user:"+userID.Hex(), settings.Limits.CreatePerUser); err != nil {
+		return nil, err
+	}
+	if err := s.checkStorageQuota(ctx, settings.Limits.User, req.FileSize, s.userUsage(userID)); err != nil {
+		return nil, err
+	}
+
 	// Generate S3 key for private memo: voice-memos/{userId}/{memoId}.{format}
 	memoID := primitive.NewObjectID()
 	audioKey := fmt.Sprintf("voice-memos/%s/%s.%s", userID.Hex(), memoID.Hex(), req.AudioFormat)
@@ -174,7 +273,7 @@ func (s *VoiceMemoService) CreateVoiceMemo(ctx context.Context, userID primitive
 		ID:           memoID,
 		UserID:       userID,
 		Title:        req.Title,
-		Duration:     req.Duration,
+		Duration:     billableDuration(req.Duration, req.FileSize, req.AudioFormat),
 		FileSize:     req.FileSize,
 		AudioFormat:  req.AudioFormat,
 		Tags:         req.Tags,
@@ -195,7 +294,7 @@ func (s *VoiceMemoService) CreateVoiceMemo(ctx context.Context, userID primitive
 
 	// Generate pre-signed upload URL
 	contentType := getContentType(req.AudioFormat)
-	uploadURL, err := s.s3Client.GetPresignedPutURL(ctx, audioKey, contentType, s.presignedUploadExpiry)
+	uploadURL, err := s.s3Client.GetPresignedPutURL(ctx, audioKey, contentType, req.FileSize, settings.PresignedUploadExpiry)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +306,19 @@ func (s *VoiceMemoService) CreateVoiceMemo(ctx context.Context, userID primitive
 }
 
 // CreateTeamVoiceMemo creates a new team voice memo and returns upload URL.
+// Returns a RateLimitError or a quota error if the user or team is over their limits.
 func (s *VoiceMemoService) CreateTeamVoiceMemo(ctx context.Context, userID, teamID primitive.ObjectID, req *models.CreateVoiceMemoRequest) (*models.CreateVoiceMemoResponse, error) {
+	settings := s.settings.Load()
+	if err := s.checkRateLimit(ctx, "memo_create:user:"+userID.Hex(), settings.Limits.CreatePerUser); err != nil {
+		return nil, err
+	}
+	if err := s.checkRateLimit(ctx, "memo_create:team:"+teamID.Hex(), settings.Limits.CreatePerTeam); err != nil {
+		return nil, err
+	}
+	if err := s.checkStorageQuota(ctx, settings.Limits.Team, req.FileSize, s.teamUsage(teamID)); err != nil {
+		return nil, err
+	}
+
 	// Generate S3 key for team memo: voice-memos/{teamId}/{userId}/{memoId}.{format}
 	memoID := primitive.NewObjectID()
 	audioKey := fmt.Sprintf("voice-memos/%s/%s/%s.%s", teamID.Hex(), userID.Hex(), memoID.Hex(), req.AudioFormat)
@@ -218,7 +329,7 @@ func (s *VoiceMemoService) CreateTeamVoiceMemo(ctx context.Context, userID, team
 		UserID:       userID,
 		TeamID:       &teamID,
 		Title:        req.Title,
-		Duration:     req.Duration,
+		Duration:     billableDuration(req.Duration, req.FileSize, req.AudioFormat),
 		FileSize:     req.FileSize,
 		AudioFormat:  req.AudioFormat,
 		Tags:         req.Tags,
@@ -239,7 +350,7 @@ func (s *VoiceMemoService) CreateTeamVoiceMemo(ctx context.Context, userID, team
 
 	// Generate pre-signed upload URL
 	contentType := getContentType(req.AudioFormat)
-	uploadURL, err := s.s3Client.GetPresignedPutURL(ctx, audioKey, contentType, s.presignedUploadExpiry)
+	uploadURL, err := s.s3Client.GetPresignedPutURL(ctx, audioKey, contentType, req.FileSize, settings.PresignedUploadExpiry)
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +362,7 @@ func (s *VoiceMemoService) CreateTeamVoiceMemo(ctx context.Context, userID, team
 }
 
 // ConfirmUpload confirms audio upload and triggers transcription for a private memo.
+// Returns ErrTranscriptionQuotaExceeded if the user's monthly transcription quota is used up.
 func (s *VoiceMemoService) ConfirmUpload(ctx context.Context, memoID, userID primitive.ObjectID) error {
 	// Atomically update status from pending_upload to transcribing with ownership check
 	// Returns the updated memo to avoid a separate FindByID call
@@ -259,19 +371,31 @@ func (s *VoiceMemoService) ConfirmUpload(ctx context.Context, memoID, userID pri
 		return err
 	}
 
+	if err := s.verifyUpload(ctx, memo); err != nil {
+		// Revert status back to pending_upload so the audio can be uploaded again
+		s.revertStatus(ctx, memoID, models.StatusPendingUpload)
+		return err
+	}
+
+	if err := s.checkTranscriptionQuota(ctx, s.settings.Load().Limits.User, s.userUsage(userID)); err != nil {
+		// Revert status back to pending_upload so the upload can be confirmed later
+		s.revertStatus(ctx, memoID, models.StatusPendingUpload)
+		return err
+	}
+
 	// Enqueue transcription job
 	job := queue.TranscriptionJob{
 		MemoID:       memoID,
 		AudioFileKey: memo.AudioFileKey,
 		RetryCount:   0,
+		RequestID:    requestid.FromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
 	}
 
 	if err := s.queue.Enqueue(job); err != nil {
 		if errors.Is(err, queue.ErrQueueFull) {
 			// Revert status back to pending_upload if queue is full (only if still transcribing)
-			if revertErr := s.repo.UpdateStatusConditional(ctx, memoID, models.StatusTranscribing, models.StatusPendingUpload); revertErr != nil {
-				log.Printf("Failed to revert status for memo %s: %v", memoID.Hex(), revertErr)
-			}
+			s.revertStatus(ctx, memoID, models.StatusPendingUpload)
 			return apperrors.ErrTranscriptionQueueFull
 		}
 		return err
@@ -281,6 +405,7 @@ func (s *VoiceMemoService) ConfirmUpload(ctx context.Context, memoID, userID pri
 }
 
 // ConfirmTeamUpload confirms audio upload and triggers transcription for a team memo.
+// Returns ErrTranscriptionQuotaExceeded if the team's monthly transcription quota is used up.
 func (s *VoiceMemoService) ConfirmTeamUpload(ctx context.Context, memoID, teamID primitive.ObjectID) error {
 	// Atomically update status from pending_upload to transcribing with team check
 	// Returns the updated memo to avoid a separate FindByID call
@@ -289,19 +414,31 @@ func (s *VoiceMemoService) ConfirmTeamUpload(ctx context.Context, memoID, teamID
 		return err
 	}
 
+	if err := s.verifyUpload(ctx, memo); err != nil {
+		// Revert status back to pending_upload so the audio can be uploaded again
+		s.revertStatus(ctx, memoID, models.StatusPendingUpload)
+		return err
+	}
+
+	if err := s.checkTranscriptionQuota(ctx, s.settings.Load().Limits.Team, s.teamUsage(teamID)); err != nil {
+		// Revert status back to pending_upload so the upload can be confirmed later
+		s.revertStatus(ctx, memoID, models.StatusPendingUpload)
+		return err
+	}
+
 	// Enqueue transcription job
 	job := queue.TranscriptionJob{
 		MemoID:       memoID,
 		AudioFileKey: memo.AudioFileKey,
 		RetryCount:   0,
+		RequestID:    requestid.FromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
 	}
 
 	if err := s.queue.Enqueue(job); err != nil {
 		if errors.Is(err, queue.ErrQueueFull) {
 			// Revert status back to pending_upload if queue is full (only if still transcribing)
-			if revertErr := s.repo.UpdateStatusConditional(ctx, memoID, models.StatusTranscribing, models.StatusPendingUpload); revertErr != nil {
-				log.Printf("Failed to revert status for memo %s: %v", memoID.Hex(), revertErr)
-			}
+			s.revertStatus(ctx, memoID, models.StatusPendingUpload)
 			return apperrors.ErrTranscriptionQueueFull
 		}
 		return err
@@ -310,12 +447,26 @@ func (s *VoiceMemoService) ConfirmTeamUpload(ctx context.Context, memoID, teamID
 	return nil
 }
 
+// errMemoNotFailed is returned when retrying transcription of a memo that is not in failed state.
+var errMemoNotFailed = apperrors.ErrVoiceMemoInvalidStatus.WithMessage("memo is not in failed state")
+
 // RetryTranscription retries transcription for a failed private memo.
+// Returns ErrVoiceMemoInvalidStatus if the memo has not failed, or ErrTranscriptionQuotaExceeded
+// if the user's monthly transcription quota is used up.
 func (s *VoiceMemoService) RetryTranscription(ctx context.Context, memoID, userID primitive.ObjectID) error {
 	// Atomically update status from failed to transcribing with ownership check
 	// Returns the updated memo to avoid a separate FindByID call
 	memo, err := s.repo.UpdateStatusWithOwnership(ctx, memoID, userID, models.StatusFailed, models.StatusTranscribing)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrVoiceMemoInvalidStatus) {
+			return errMemoNotFailed
+		}
+		return err
+	}
+
+	if err := s.checkTranscriptionQuota(ctx, s.settings.Load().Limits.User, s.userUsage(userID)); err != nil {
+		// Revert status back to failed so the retry can be attempted later
+		s.revertStatus(ctx, memoID, models.StatusFailed)
 		return err
 	}
 
@@ -324,14 +475,14 @@ func (s *VoiceMemoService) RetryTranscription(ctx context.Context, memoID, userI
 		MemoID:       memoID,
 		AudioFileKey: memo.AudioFileKey,
 		RetryCount:   0, // Reset retry count for manual retry
+		RequestID:    requestid.FromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
 	}
 
 	if err := s.queue.Enqueue(job); err != nil {
 		if errors.Is(err, queue.ErrQueueFull) {
 			// Revert status back to failed if queue is full (only if still transcribing)
-			if revertErr := s.repo.UpdateStatusConditional(ctx, memoID, models.StatusTranscribing, models.StatusFailed); revertErr != nil {
-				log.Printf("Failed to revert status for memo %s: %v", memoID.Hex(), revertErr)
-			}
+			s.revertStatus(ctx, memoID, models.StatusFailed)
 			return apperrors.ErrTranscriptionQueueFull
 		}
 		return err
@@ -341,11 +492,22 @@ func (s *VoiceMemoService) RetryTranscription(ctx context.Context, memoID, userI
 }
 
 // RetryTeamTranscription retries transcription for a failed team memo.
+// Returns ErrVoiceMemoInvalidStatus if the memo has not failed, or ErrTranscriptionQuotaExceeded
+// if the team's monthly transcription quota is used up.
 func (s *VoiceMemoService) RetryTeamTranscription(ctx context.Context, memoID, teamID primitive.ObjectID) error {
 	// Atomically update status from failed to transcribing with team check
 	// Returns the updated memo to avoid a separate FindByID call
 	memo, err := s.repo.UpdateStatusWithTeam(ctx, memoID, teamID, models.StatusFailed, models.StatusTranscribing)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrVoiceMemoInvalidStatus) {
+			return errMemoNotFailed
+		}
+		return err
+	}
+
+	if err := s.checkTranscriptionQuota(ctx, s.settings.Load().Limits.Team, s.teamUsage(teamID)); err != nil {
+		// Revert status back to failed so the retry can be attempted later
+		s.revertStatus(ctx, memoID, models.StatusFailed)
 		return err
 	}
 
@@ -354,14 +516,14 @@ func (s *VoiceMemoService) RetryTeamTranscription(ctx context.Context, memoID, t
 		MemoID:       memoID,
 		AudioFileKey: memo.AudioFileKey,
 		RetryCount:   0, // Reset retry count for manual retry
+		RequestID:    requestid.FromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
 	}
 
 	if err := s.queue.Enqueue(job); err != nil {
 		if errors.Is(err, queue.ErrQueueFull) {
 			// Revert status back to failed if queue is full (only if still transcribing)
-			if revertErr := s.repo.UpdateStatusConditional(ctx, memoID, models.StatusTranscribing, models.StatusFailed); revertErr != nil {
-				log.Printf("Failed to revert status for memo %s: %v", memoID.Hex(), revertErr)
-			}
+			s.revertStatus(ctx, memoID, models.StatusFailed)
 			return apperrors.ErrTranscriptionQueueFull
 		}
 		return err
@@ -370,6 +532,164 @@ func (s *VoiceMemoService) RetryTeamTranscription(ctx context.Context, memoID, t
 	return nil
 }
 
+// GetUserUsage returns a user's private memo usage and quota limits.
+func (s *VoiceMemoService) GetUserUsage(ctx context.Context, userID primitive.ObjectID) (*models.UsageResponse, error) {
+	usage, err := s.userUsage(userID)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newUsageResponse(usage, s.settings.Load().Limits.User, time.Now()), nil
+}
+
+// GetTeamUsage returns a team's memo usage and quota limits.
+func (s *VoiceMemoService) GetTeamUsage(ctx context.Context, teamID primitive.ObjectID) (*models.UsageResponse, error) {
+	usage, err := s.teamUsage(teamID)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newUsageResponse(usage, s.settings.Load().Limits.Team, time.Now()), nil
+}
+
+// usageFunc loads current usage for quota checks.
+type usageFunc func(ctx context.Context) (*models.VoiceMemoUsage, error)
+
+// userUsage returns a usageFunc for a user's private memos in the current period.
+func (s *VoiceMemoService) userUsage(userID primitive.ObjectID) usageFunc {
+	return func(ctx context.Context) (*models.VoiceMemoUsage, error) {
+		start, _ := usagePeriod(time.Now())
+		return s.repo.GetUserUsage(ctx, userID, start)
+	}
+}
+
+// teamUsage returns a usageFunc for a team's memos in the current period.
+func (s *VoiceMemoService) teamUsage(teamID primitive.ObjectID) usageFunc {
+	return func(ctx context.Context) (*models.VoiceMemoUsage, error) {
+		start, _ := usagePeriod(time.Now())
+		return s.repo.GetTeamUsage(ctx, teamID, start)
+	}
+}
+
+// revertStatus moves a memo from transcribing back to status, if it is still transcribing.
+// Failures are logged: the caller is already returning the error that caused the revert.
+func (s *VoiceMemoService) revertStatus(ctx context.Context, memoID primitive.ObjectID, status models.VoiceMemoStatus) {
+	if err := s.repo.UpdateStatusConditional(ctx, memoID, models.StatusTranscribing, status); err != nil {
+		slog.ErrorContext(ctx, "failed to revert memo status", "memo_id", memoID.Hex(), "error", err)
+	}
+}
+
+// verifyUpload checks that the memo's audio was uploaded with the declared size, which is
+// what the storage quota and the transcription duration were checked against.
+func (s *VoiceMemoService) verifyUpload(ctx context.Context, memo *models.VoiceMemo) error {
+	info, err := s.s3Client.HeadObject(ctx, memo.AudioFileKey)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return apperrors.ErrAudioNotUploaded
+	}
+	if err != nil {
+		return err
+	}
+	if info.Size != memo.FileSize {
+		return apperrors.ErrAudioSizeMismatch
+	}
+	return nil
+}
+
+// checkRateLimit returns a RateLimitError if key is over its limit.
+// Rate limiting is best-effort: if the limiter is unavailable, the request is allowed.
+func (s *VoiceMemoService) checkRateLimit(ctx context.Context, key string, limit ratelimit.Limit) error {
+	if s.limiter == nil || limit.Requests <= 0 {
+		return nil
+	}
+
+	result, err := s.limiter.Allow(ctx, key, limit)
+	if err != nil {
+		slog.WarnContext(ctx, "rate limit check failed", "key", key, "error", err)
+		return nil
+	}
+	if !result.Allowed {
+		return &apperrors.RateLimitError{RetryAfter: result.RetryAfter}
+	}
+	return nil
+}
+
+// checkStorageQuota returns an error if one more memo of fileSize bytes would exceed the quota.
+func (s *VoiceMemoService) checkStorageQuota(ctx context.Context, quota UsageQuota, fileSize int64, load usageFunc) error {
+	if quota.MaxMemos <= 0 && quota.MaxAudioBytes <= 0 {
+		return nil
+	}
+
+	usage, err := load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if quota.MaxMemos > 0 && usage.MemoCount >= quota.MaxMemos {
+		return apperrors.ErrMemoQuotaExceeded
+	}
+	if quota.MaxAudioBytes > 0 && usage.AudioBytes+fileSize > quota.MaxAudioBytes {
+		return apperrors.ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// checkTranscriptionQuota returns an error if monthly transcription usage exceeds the quota.
+// It runs after the memo is marked transcribing, so usage already includes that memo.
+func (s *VoiceMemoService) checkTranscriptionQuota(ctx context.Context, quota UsageQuota, load usageFunc) error {
+	if quota.MaxTranscriptionMinutes <= 0 {
+		return nil
+	}
+
+	usage, err := load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if usage.TranscriptionSeconds > quota.MaxTranscriptionMinutes*60 {
+		return apperrors.ErrTranscriptionQuotaExceeded
+	}
+	return nil
+}
+
+// usagePeriod returns the calendar month (UTC) containing now.
+func usagePeriod(now time.Time) (start, end time.Time) {
+	now = now.UTC()
+	start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// newUsageResponse builds the usage response. Transcription minutes are rounded up.
+func newUsageResponse(usage *models.VoiceMemoUsage, quota UsageQuota, now time.Time) *models.UsageResponse {
+	start, end := usagePeriod(now)
+	return &models.UsageResponse{
+		Memos:                models.UsageMetric{Used: usage.MemoCount, Limit: quota.MaxMemos},
+		AudioBytes:           models.UsageMetric{Used: usage.AudioBytes, Limit: quota.MaxAudioBytes},
+		TranscriptionMinutes: models.UsageMetric{Used: (usage.TranscriptionSeconds + 59) / 60, Limit: quota.MaxTranscriptionMinutes},
+		PeriodStart:          start,
+		PeriodEnd:            end,
+	}
+}
+
+// maxAudioBytesPerSecond is the highest bitrate expected for each audio format, in bytes
+// per second. A file's size divided by it is the shortest duration the file can hold.
+var maxAudioBytesPerSecond = map[string]int64{
+	"mp3":  40_000,  // 320 kbit/s, the MP3 maximum
+	"aac":  64_000,  // 512 kbit/s
+	"m4a":  64_000,  // 512 kbit/s AAC
+	"webm": 64_000,  // Opus tops out at 510 kbit/s
+	"wav":  576_000, // 96 kHz 24-bit stereo PCM
+}
+
+// billableDuration returns the declared duration in seconds, raised to the shortest
+// duration fileSize bytes of format can hold. The duration is not verifiable without
+// decoding the audio, so this stops a client from under-declaring it to stretch the
+// transcription quota.
+func billableDuration(declared int, fileSize int64, format string) int {
+	bytesPerSecond, ok := maxAudioBytesPerSecond[format]
+	if !ok {
+		return declared
+	}
+	return max(declared, int((fileSize+bytesPerSecond-1)/bytesPerSecond))
+}
+
 // getContentType returns the MIME type for an audio format.
 func getContentType(format string) string {
 	switch format {
@@ -5,16 +5,24 @@ import (
 	"testing"
 	"time"
 
+	"gin-sample/internal/authz"
 	apperrors "gin-sample/internal/errors"
 	"gin-sample/internal/models"
 	"gin-sample/internal/queue"
 	queuemocks "gin-sample/internal/queue/mocks"
+	"gin-sample/internal/ratelimit"
+	ratelimitmocks "gin-sample/internal/ratelimit/mocks"
 	repomocks "gin-sample/internal/repository/mocks"
+	"gin-sample/internal/requestid"
+	"gin-sample/internal/storage"
 	storagemocks "gin-sample/internal/storage/mocks"
+	"gin-sample/internal/tracing"
+	"gin-sample/internal/tracing/tracingtest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
 
@@ -26,7 +34,7 @@ func TestNewVoiceMemoService(t *testing.T) {
 	mockStorage := storagemocks.NewMockStorage(ctrl)
 	mockQueue := queuemocks.NewMockQueue(ctrl)
 
-	service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+	service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 
 	assert.NotNil(t, service)
 	assert.Equal(t, mockRepo, service.repo)
@@ -71,7 +79,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 			GetPresignedURL(gomock.Any(), memos[1].AudioFileKey, gomock.Any()).
 			Return("https://s3.example.com/memo2.mp3", nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), validUserID.Hex(), 1, 10)
 
 		require.NoError(t, err)
@@ -96,7 +104,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 			FindByUserID(gomock.Any(), validUserID, 1, 10).
 			Return([]models.VoiceMemo{}, 0, nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), validUserID.Hex(), 0, 0)
 
 		require.NoError(t, err)
@@ -117,7 +125,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 			FindByUserID(gomock.Any(), validUserID, 1, 10).
 			Return([]models.VoiceMemo{}, 0, nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), validUserID.Hex(), 1, 100)
 
 		require.NoError(t, err)
@@ -132,7 +140,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 		mockStorage := storagemocks.NewMockStorage(ctrl)
 		mockQueue := queuemocks.NewMockQueue(ctrl)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), "invalid-id", 1, 10)
 
 		assert.Nil(t, resp)
@@ -151,7 +159,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 			FindByUserID(gomock.Any(), validUserID, 1, 10).
 			Return(nil, 0, assert.AnError)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), validUserID.Hex(), 1, 10)
 
 		assert.Nil(t, resp)
@@ -191,7 +199,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 			Return("", assert.AnError).
 			Times(2)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), validUserID.Hex(), 1, 10)
 
 		require.NoError(t, err)
@@ -213,7 +221,7 @@ func TestVoiceMemoService_ListByUserID(t *testing.T) {
 			FindByUserID(gomock.Any(), validUserID, 1, 10).
 			Return([]models.VoiceMemo{}, 15, nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByUserID(context.Background(), validUserID.Hex(), 1, 10)
 
 		require.NoError(t, err)
@@ -237,7 +245,7 @@ func TestVoiceMemoService_DeleteVoiceMemo(t *testing.T) {
 			SoftDeleteWithOwnership(gomock.Any(), memoID, userID).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.DeleteVoiceMemo(context.Background(), memoID, userID)
 
 		assert.NoError(t, err)
@@ -255,7 +263,7 @@ func TestVoiceMemoService_DeleteVoiceMemo(t *testing.T) {
 			SoftDeleteWithOwnership(gomock.Any(), memoID, userID).
 			Return(apperrors.ErrVoiceMemoNotFound)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.DeleteVoiceMemo(context.Background(), memoID, userID)
 
 		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
@@ -291,7 +299,7 @@ func TestVoiceMemoService_ListByTeamID(t *testing.T) {
 			GetPresignedURL(gomock.Any(), memos[0].AudioFileKey, gomock.Any()).
 			Return("https://s3.example.com/team-memo1.mp3", nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByTeamID(context.Background(), validTeamID.Hex(), 1, 10)
 
 		require.NoError(t, err)
@@ -307,7 +315,7 @@ func TestVoiceMemoService_ListByTeamID(t *testing.T) {
 		mockStorage := storagemocks.NewMockStorage(ctrl)
 		mockQueue := queuemocks.NewMockQueue(ctrl)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByTeamID(context.Background(), "invalid-id", 1, 10)
 
 		assert.Nil(t, resp)
@@ -326,7 +334,7 @@ func TestVoiceMemoService_ListByTeamID(t *testing.T) {
 			FindByTeamID(gomock.Any(), validTeamID, 1, 10).
 			Return([]models.VoiceMemo{}, 0, nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.ListByTeamID(context.Background(), validTeamID.Hex(), -1, 50)
 
 		require.NoError(t, err)
@@ -361,7 +369,7 @@ func TestVoiceMemoService_GetVoiceMemo(t *testing.T) {
 			GetPresignedURL(gomock.Any(), memo.AudioFileKey, gomock.Any()).
 			Return("https://s3.example.com/memo1.mp3", nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		result, err := service.GetVoiceMemo(context.Background(), memoID)
 
 		require.NoError(t, err)
@@ -393,7 +401,7 @@ func TestVoiceMemoService_GetVoiceMemo(t *testing.T) {
 			GetPresignedURL(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return("", assert.AnError)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		result, err := service.GetVoiceMemo(context.Background(), memoID)
 
 		require.NoError(t, err)
@@ -413,7 +421,7 @@ func TestVoiceMemoService_GetVoiceMemo(t *testing.T) {
 			FindByID(gomock.Any(), memoID).
 			Return(nil, apperrors.ErrVoiceMemoNotFound)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		result, err := service.GetVoiceMemo(context.Background(), memoID)
 
 		assert.Nil(t, result)
@@ -440,7 +448,7 @@ func TestVoiceMemoService_GetVoiceMemo(t *testing.T) {
 			Return(memoWithoutKey, nil)
 
 		// GetPresignedURL should NOT be called
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		result, err := service.GetVoiceMemo(context.Background(), memoID)
 
 		require.NoError(t, err)
@@ -465,8 +473,8 @@ func TestVoiceMemoService_DeleteTeamVoiceMemo(t *testing.T) {
 			SoftDeleteWithTeam(gomock.Any(), memoID, teamID).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
-		err := service.DeleteTeamVoiceMemo(context.Background(), memoID, teamID)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		err := service.DeleteTeamVoiceMemo(context.Background(), memoID, teamID, primitive.NewObjectID())
 
 		assert.NoError(t, err)
 	})
@@ -483,8 +491,120 @@ func TestVoiceMemoService_DeleteTeamVoiceMemo(t *testing.T) {
 			SoftDeleteWithTeam(gomock.Any(), memoID, teamID).
 			Return(apperrors.ErrVoiceMemoNotFound)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
-		err := service.DeleteTeamVoiceMemo(context.Background(), memoID, teamID)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		err := service.DeleteTeamVoiceMemo(context.Background(), memoID, teamID, primitive.NewObjectID())
+
+		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
+	})
+
+	t.Run("records deletion in the audit log", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+		actorID := primitive.NewObjectID()
+		creatorID := primitive.NewObjectID()
+
+		memo := &models.VoiceMemo{ID: memoID, UserID: creatorID, Title: "Standup", Status: models.StatusReady}
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				FindByIDIncludingDeleted(gomock.Any(), memoID).
+				Return(memo, nil),
+			mockRepo.EXPECT().
+				SoftDeleteWithTeam(gomock.Any(), memoID, teamID).
+				Return(nil),
+		)
+
+		audit := &fakeAuditRecorder{}
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, audit)
+		err := service.DeleteTeamVoiceMemo(context.Background(), memoID, teamID, actorID)
+
+		assert.NoError(t, err)
+		require.Len(t, audit.entries, 1)
+		assert.Equal(t, authz.ActionMemoDelete, audit.entries[0].Action)
+		assert.Equal(t, actorID, audit.entries[0].ActorID)
+		assert.Equal(t, "Standup", audit.entries[0].Before["title"])
+		assert.Equal(t, creatorID.Hex(), audit.entries[0].Before["creatorId"])
+	})
+
+	t.Run("does not record repeated deletes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+		deletedAt := time.Now()
+
+		mockRepo.EXPECT().
+			FindByIDIncludingDeleted(gomock.Any(), memoID).
+			Return(&models.VoiceMemo{ID: memoID, DeletedAt: &deletedAt}, nil)
+		mockRepo.EXPECT().
+			SoftDeleteWithTeam(gomock.Any(), memoID, teamID).
+			Return(nil)
+
+		audit := &fakeAuditRecorder{}
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, audit)
+		err := service.DeleteTeamVoiceMemo(context.Background(), memoID, teamID, primitive.NewObjectID())
+
+		assert.NoError(t, err)
+		assert.Empty(t, audit.entries)
+	})
+}
+
+func TestVoiceMemoService_GetTeamVoiceMemoOwner(t *testing.T) {
+	memoID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	t.Run("returns the memo creator", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockRepo.EXPECT().
+			FindByIDIncludingDeleted(gomock.Any(), memoID).
+			Return(&models.VoiceMemo{ID: memoID, UserID: userID, TeamID: &teamID}, nil)
+
+		service := NewVoiceMemoService(mockRepo, storagemocks.NewMockStorage(ctrl), queuemocks.NewMockQueue(ctrl), time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		ownerID, err := service.GetTeamVoiceMemoOwner(context.Background(), memoID, teamID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, ownerID)
+	})
+
+	t.Run("returns not found for memo in another team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		otherTeamID := primitive.NewObjectID()
+
+		mockRepo.EXPECT().
+			FindByIDIncludingDeleted(gomock.Any(), memoID).
+			Return(&models.VoiceMemo{ID: memoID, UserID: userID, TeamID: &otherTeamID}, nil)
+
+		service := NewVoiceMemoService(mockRepo, storagemocks.NewMockStorage(ctrl), queuemocks.NewMockQueue(ctrl), time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		_, err := service.GetTeamVoiceMemoOwner(context.Background(), memoID, teamID)
+
+		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
+	})
+
+	t.Run("returns not found for private memo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockRepo.EXPECT().
+			FindByIDIncludingDeleted(gomock.Any(), memoID).
+			Return(&models.VoiceMemo{ID: memoID, UserID: userID}, nil)
+
+		service := NewVoiceMemoService(mockRepo, storagemocks.NewMockStorage(ctrl), queuemocks.NewMockQueue(ctrl), time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		_, err := service.GetTeamVoiceMemoOwner(context.Background(), memoID, teamID)
 
 		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
 	})
@@ -521,10 +641,10 @@ func TestVoiceMemoService_CreateVoiceMemo(t *testing.T) {
 			})
 
 		mockStorage.EXPECT().
-			GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/mpeg", gomock.Any()).
+			GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/mpeg", req.FileSize, gomock.Any()).
 			Return("https://s3.example.com/upload-url", nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.CreateVoiceMemo(context.Background(), userID, req)
 
 		require.NoError(t, err)
@@ -558,10 +678,10 @@ func TestVoiceMemoService_CreateVoiceMemo(t *testing.T) {
 			})
 
 		mockStorage.EXPECT().
-			GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/wav", gomock.Any()).
+			GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/wav", gomock.Any(), gomock.Any()).
 			Return("https://s3.example.com/upload-url", nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.CreateVoiceMemo(context.Background(), userID, reqWithNilTags)
 
 		require.NoError(t, err)
@@ -580,7 +700,7 @@ func TestVoiceMemoService_CreateVoiceMemo(t *testing.T) {
 			Create(gomock.Any(), gomock.Any()).
 			Return(assert.AnError)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.CreateVoiceMemo(context.Background(), userID, req)
 
 		assert.Nil(t, resp)
@@ -600,10 +720,10 @@ func TestVoiceMemoService_CreateVoiceMemo(t *testing.T) {
 			Return(nil)
 
 		mockStorage.EXPECT().
-			GetPresignedPutURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			GetPresignedPutURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return("", assert.AnError)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.CreateVoiceMemo(context.Background(), userID, req)
 
 		assert.Nil(t, resp)
@@ -642,10 +762,10 @@ func TestVoiceMemoService_CreateVoiceMemo(t *testing.T) {
 					Return(nil)
 
 				mockStorage.EXPECT().
-					GetPresignedPutURL(gomock.Any(), gomock.Any(), tc.contentType, gomock.Any()).
+					GetPresignedPutURL(gomock.Any(), gomock.Any(), tc.contentType, gomock.Any(), gomock.Any()).
 					Return("https://s3.example.com/upload", nil)
 
-				service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+				service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 				_, err := service.CreateVoiceMemo(context.Background(), userID, formatReq)
 
 				assert.NoError(t, err)
@@ -686,10 +806,10 @@ func TestVoiceMemoService_CreateTeamVoiceMemo(t *testing.T) {
 			})
 
 		mockStorage.EXPECT().
-			GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/mp4", gomock.Any()).
+			GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/mp4", gomock.Any(), gomock.Any()).
 			Return("https://s3.example.com/team-upload-url", nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.CreateTeamVoiceMemo(context.Background(), userID, teamID, req)
 
 		require.NoError(t, err)
@@ -710,7 +830,7 @@ func TestVoiceMemoService_CreateTeamVoiceMemo(t *testing.T) {
 			Create(gomock.Any(), gomock.Any()).
 			Return(assert.AnError)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		resp, err := service.CreateTeamVoiceMemo(context.Background(), userID, teamID, req)
 
 		assert.Nil(t, resp)
@@ -725,6 +845,7 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 		ID:           memoID,
 		UserID:       userID,
 		AudioFileKey: "voice-memos/user1/memo1.mp3",
+		FileSize:     1048576,
 		Status:       models.StatusTranscribing,
 	}
 
@@ -739,6 +860,9 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 		mockRepo.EXPECT().
 			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
 
 		mockQueue.EXPECT().
 			Enqueue(gomock.Any()).
@@ -746,15 +870,48 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 				assert.Equal(t, memoID, job.MemoID)
 				assert.Equal(t, memo.AudioFileKey, job.AudioFileKey)
 				assert.Equal(t, 0, job.RetryCount)
+				assert.Equal(t, "req-123", job.RequestID)
 				return nil
 			})
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
-		err := service.ConfirmUpload(context.Background(), memoID, userID)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		err := service.ConfirmUpload(requestid.NewContext(context.Background(), "req-123"), memoID, userID)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("carries the request's trace context to the job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		tracingtest.Record(t)
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		ctx, span := tracing.Tracer().Start(context.Background(), "confirm-upload")
+		defer span.End()
+
+		mockRepo.EXPECT().
+			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
+			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
+
+		mockQueue.EXPECT().
+			Enqueue(gomock.Any()).
+			DoAndReturn(func(job queue.TranscriptionJob) error {
+				linked := trace.SpanContextFromContext(tracing.Extract(context.Background(), job.TraceContext))
+				assert.Equal(t, span.SpanContext().TraceID(), linked.TraceID())
+				assert.Equal(t, span.SpanContext().SpanID(), linked.SpanID())
+				return nil
+			})
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		assert.NoError(t, service.ConfirmUpload(ctx, memoID, userID))
+	})
+
 	t.Run("returns error when status update fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -767,7 +924,7 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(nil, apperrors.ErrVoiceMemoNotFound)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.ConfirmUpload(context.Background(), memoID, userID)
 
 		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
@@ -784,6 +941,9 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 		mockRepo.EXPECT().
 			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
 
 		mockQueue.EXPECT().
 			Enqueue(gomock.Any()).
@@ -793,7 +953,7 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusPendingUpload).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.ConfirmUpload(context.Background(), memoID, userID)
 
 		assert.Equal(t, apperrors.ErrTranscriptionQueueFull, err)
@@ -810,6 +970,9 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 		mockRepo.EXPECT().
 			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
 
 		mockQueue.EXPECT().
 			Enqueue(gomock.Any()).
@@ -819,7 +982,7 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusPendingUpload).
 			Return(assert.AnError) // Revert fails
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.ConfirmUpload(context.Background(), memoID, userID)
 
 		// Should still return queue full error
@@ -837,17 +1000,91 @@ func TestVoiceMemoService_ConfirmUpload(t *testing.T) {
 		mockRepo.EXPECT().
 			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
 
 		mockQueue.EXPECT().
 			Enqueue(gomock.Any()).
 			Return(assert.AnError) // Not ErrQueueFull
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.ConfirmUpload(context.Background(), memoID, userID)
 
 		assert.Error(t, err)
 		assert.NotEqual(t, apperrors.ErrTranscriptionQueueFull, err)
 	})
+
+	uploadTests := []struct {
+		name        string
+		info        *storage.ObjectInfo
+		headErr     error
+		expectedErr error
+	}{
+		{
+			name:        "reverts status when the audio was not uploaded",
+			headErr:     storage.ErrObjectNotFound,
+			expectedErr: apperrors.ErrAudioNotUploaded,
+		},
+		{
+			name:        "reverts status when the uploaded size differs from the declared size",
+			info:        &storage.ObjectInfo{Size: memo.FileSize * 2},
+			expectedErr: apperrors.ErrAudioSizeMismatch,
+		},
+		{
+			name:        "reverts status when the upload cannot be checked",
+			headErr:     assert.AnError,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range uploadTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+			mockStorage := storagemocks.NewMockStorage(ctrl)
+			mockQueue := queuemocks.NewMockQueue(ctrl)
+
+			mockRepo.EXPECT().
+				UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
+				Return(memo, nil)
+			mockStorage.EXPECT().
+				HeadObject(gomock.Any(), memo.AudioFileKey).
+				Return(tt.info, tt.headErr)
+			mockRepo.EXPECT().
+				UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusPendingUpload).
+				Return(nil)
+
+			service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+			err := service.ConfirmUpload(context.Background(), memoID, userID)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestBillableDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared int
+		fileSize int64
+		format   string
+		expected int
+	}{
+		{name: "keeps a plausible declared duration", declared: 120, fileSize: 1_000_000, format: "mp3", expected: 120},
+		{name: "raises an under-declared mp3 duration", declared: 0, fileSize: 4_000_000, format: "mp3", expected: 100},
+		{name: "rounds the minimum up", declared: 1, fileSize: 40_001, format: "mp3", expected: 2},
+		{name: "uses the format's bitrate", declared: 0, fileSize: 5_760_000, format: "wav", expected: 10},
+		{name: "keeps the declared duration for unknown formats", declared: 5, fileSize: 4_000_000, format: "flac", expected: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, billableDuration(tt.declared, tt.fileSize, tt.format))
+		})
+	}
 }
 
 func TestVoiceMemoService_ConfirmTeamUpload(t *testing.T) {
@@ -857,6 +1094,7 @@ func TestVoiceMemoService_ConfirmTeamUpload(t *testing.T) {
 		ID:           memoID,
 		TeamID:       &teamID,
 		AudioFileKey: "voice-memos/team1/memo1.mp3",
+		FileSize:     1048576,
 		Status:       models.StatusTranscribing,
 	}
 
@@ -871,12 +1109,15 @@ func TestVoiceMemoService_ConfirmTeamUpload(t *testing.T) {
 		mockRepo.EXPECT().
 			UpdateStatusWithTeam(gomock.Any(), memoID, teamID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
 
 		mockQueue.EXPECT().
 			Enqueue(gomock.Any()).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.ConfirmTeamUpload(context.Background(), memoID, teamID)
 
 		assert.NoError(t, err)
@@ -893,6 +1134,9 @@ func TestVoiceMemoService_ConfirmTeamUpload(t *testing.T) {
 		mockRepo.EXPECT().
 			UpdateStatusWithTeam(gomock.Any(), memoID, teamID, models.StatusPendingUpload, models.StatusTranscribing).
 			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
 
 		mockQueue.EXPECT().
 			Enqueue(gomock.Any()).
@@ -902,7 +1146,7 @@ func TestVoiceMemoService_ConfirmTeamUpload(t *testing.T) {
 			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusPendingUpload).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.ConfirmTeamUpload(context.Background(), memoID, teamID)
 
 		assert.Equal(t, apperrors.ErrTranscriptionQueueFull, err)
@@ -938,7 +1182,7 @@ func TestVoiceMemoService_RetryTranscription(t *testing.T) {
 				return nil
 			})
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.RetryTranscription(context.Background(), memoID, userID)
 
 		assert.NoError(t, err)
@@ -956,12 +1200,31 @@ func TestVoiceMemoService_RetryTranscription(t *testing.T) {
 			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusFailed, models.StatusTranscribing).
 			Return(nil, apperrors.ErrVoiceMemoNotFound)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.RetryTranscription(context.Background(), memoID, userID)
 
 		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
 	})
 
+	t.Run("returns invalid status error when memo has not failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusFailed, models.StatusTranscribing).
+			Return(nil, apperrors.ErrVoiceMemoInvalidStatus)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		err := service.RetryTranscription(context.Background(), memoID, userID)
+
+		assert.ErrorIs(t, err, apperrors.ErrVoiceMemoInvalidStatus)
+		assert.EqualError(t, err, "memo is not in failed state")
+	})
+
 	t.Run("reverts status to failed when queue is full", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -982,7 +1245,7 @@ func TestVoiceMemoService_RetryTranscription(t *testing.T) {
 			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusFailed).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.RetryTranscription(context.Background(), memoID, userID)
 
 		assert.Equal(t, apperrors.ErrTranscriptionQueueFull, err)
@@ -996,6 +1259,7 @@ func TestVoiceMemoService_RetryTeamTranscription(t *testing.T) {
 		ID:           memoID,
 		TeamID:       &teamID,
 		AudioFileKey: "voice-memos/team1/memo1.mp3",
+		FileSize:     1048576,
 		Status:       models.StatusTranscribing,
 	}
 
@@ -1015,7 +1279,7 @@ func TestVoiceMemoService_RetryTeamTranscription(t *testing.T) {
 			Enqueue(gomock.Any()).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.RetryTeamTranscription(context.Background(), memoID, teamID)
 
 		assert.NoError(t, err)
@@ -1041,7 +1305,7 @@ func TestVoiceMemoService_RetryTeamTranscription(t *testing.T) {
 			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusFailed).
 			Return(nil)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.RetryTeamTranscription(context.Background(), memoID, teamID)
 
 		assert.Equal(t, apperrors.ErrTranscriptionQueueFull, err)
@@ -1059,9 +1323,422 @@ func TestVoiceMemoService_RetryTeamTranscription(t *testing.T) {
 			UpdateStatusWithTeam(gomock.Any(), memoID, teamID, models.StatusFailed, models.StatusTranscribing).
 			Return(nil, apperrors.ErrVoiceMemoNotFound)
 
-		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute)
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
 		err := service.RetryTeamTranscription(context.Background(), memoID, teamID)
 
 		assert.Equal(t, apperrors.ErrVoiceMemoNotFound, err)
 	})
+
+	t.Run("returns invalid status error when memo has not failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithTeam(gomock.Any(), memoID, teamID, models.StatusFailed, models.StatusTranscribing).
+			Return(nil, apperrors.ErrVoiceMemoInvalidStatus)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{}, nil)
+		err := service.RetryTeamTranscription(context.Background(), memoID, teamID)
+
+		assert.ErrorIs(t, err, apperrors.ErrVoiceMemoInvalidStatus)
+		assert.EqualError(t, err, "memo is not in failed state")
+	})
+}
+
+func TestVoiceMemoService_CreateVoiceMemo_Limits(t *testing.T) {
+	userID := primitive.NewObjectID()
+	req := &models.CreateVoiceMemoRequest{
+		Title:       "Test Memo",
+		FileSize:    1000,
+		AudioFormat: "mp3",
+	}
+	createLimit := ratelimit.Limit{Requests: 10, Window: time.Minute}
+
+	t.Run("returns rate limit error when user is over the create limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+		mockLimiter := ratelimitmocks.NewMockLimiter(ctrl)
+
+		mockLimiter.EXPECT().
+			Allow(gomock.Any(), "memo_create:user:"+userID.Hex(), createLimit).
+			Return(&ratelimit.Result{Allowed: false, RetryAfter: 30 * time.Second}, nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, mockLimiter, VoiceMemoLimits{CreatePerUser: createLimit}, nil)
+		result, err := service.CreateVoiceMemo(context.Background(), userID, req)
+
+		assert.Nil(t, result)
+		var rateErr *apperrors.RateLimitError
+		require.ErrorAs(t, err, &rateErr)
+		assert.Equal(t, 30*time.Second, rateErr.RetryAfter)
+	})
+
+	t.Run("allows creation when limiter fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+		mockLimiter := ratelimitmocks.NewMockLimiter(ctrl)
+
+		mockLimiter.EXPECT().Allow(gomock.Any(), gomock.Any(), createLimit).Return(nil, assert.AnError)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockStorage.EXPECT().GetPresignedPutURL(gomock.Any(), gomock.Any(), "audio/mpeg", gomock.Any(), 15*time.Minute).Return("https://upload", nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, mockLimiter, VoiceMemoLimits{CreatePerUser: createLimit}, nil)
+		result, err := service.CreateVoiceMemo(context.Background(), userID, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://upload", result.UploadURL)
+	})
+
+	t.Run("returns error when memo quota is reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{MemoCount: 5}, nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{User: UsageQuota{MaxMemos: 5}}, nil)
+		result, err := service.CreateVoiceMemo(context.Background(), userID, req)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.ErrMemoQuotaExceeded, err)
+	})
+
+	t.Run("returns error when audio storage quota would be exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{MemoCount: 1, AudioBytes: 9500}, nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{User: UsageQuota{MaxAudioBytes: 10000}}, nil)
+		result, err := service.CreateVoiceMemo(context.Background(), userID, req)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.ErrStorageQuotaExceeded, err)
+	})
+}
+
+func TestVoiceMemoService_CreateTeamVoiceMemo_Limits(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	req := &models.CreateVoiceMemoRequest{
+		Title:       "Team Memo",
+		FileSize:    1000,
+		AudioFormat: "mp3",
+	}
+	userLimit := ratelimit.Limit{Requests: 10, Window: time.Minute}
+	teamLimit := ratelimit.Limit{Requests: 50, Window: time.Minute}
+
+	t.Run("returns rate limit error when team is over the create limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+		mockLimiter := ratelimitmocks.NewMockLimiter(ctrl)
+
+		mockLimiter.EXPECT().
+			Allow(gomock.Any(), "memo_create:user:"+userID.Hex(), userLimit).
+			Return(&ratelimit.Result{Allowed: true}, nil)
+		mockLimiter.EXPECT().
+			Allow(gomock.Any(), "memo_create:team:"+teamID.Hex(), teamLimit).
+			Return(&ratelimit.Result{Allowed: false, RetryAfter: time.Second}, nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, mockLimiter, VoiceMemoLimits{CreatePerUser: userLimit, CreatePerTeam: teamLimit}, nil)
+		result, err := service.CreateTeamVoiceMemo(context.Background(), userID, teamID, req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, apperrors.ErrRateLimitExceeded)
+	})
+
+	t.Run("returns error when team memo quota is reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			GetTeamUsage(gomock.Any(), teamID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{MemoCount: 100}, nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{Team: UsageQuota{MaxMemos: 100}}, nil)
+		result, err := service.CreateTeamVoiceMemo(context.Background(), userID, teamID, req)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.ErrMemoQuotaExceeded, err)
+	})
+}
+
+func TestVoiceMemoService_ConfirmUpload_TranscriptionQuota(t *testing.T) {
+	memoID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	memo := &models.VoiceMemo{
+		ID:           memoID,
+		UserID:       userID,
+		AudioFileKey: "voice-memos/user1/memo1.mp3",
+		FileSize:     1048576,
+		Duration:     120,
+		Status:       models.StatusTranscribing,
+	}
+
+	t.Run("reverts status when user transcription quota is exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
+			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{TranscriptionSeconds: 601}, nil)
+		mockRepo.EXPECT().
+			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusPendingUpload).
+			Return(nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{User: UsageQuota{MaxTranscriptionMinutes: 10}}, nil)
+		err := service.ConfirmUpload(context.Background(), memoID, userID)
+
+		assert.Equal(t, apperrors.ErrTranscriptionQuotaExceeded, err)
+	})
+
+	t.Run("enqueues when usage is within the quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusPendingUpload, models.StatusTranscribing).
+			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{TranscriptionSeconds: 600}, nil)
+		mockQueue.EXPECT().Enqueue(gomock.Any()).Return(nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{User: UsageQuota{MaxTranscriptionMinutes: 10}}, nil)
+		err := service.ConfirmUpload(context.Background(), memoID, userID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("reverts status when team transcription quota is exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithTeam(gomock.Any(), memoID, teamID, models.StatusPendingUpload, models.StatusTranscribing).
+			Return(memo, nil)
+		mockStorage.EXPECT().
+			HeadObject(gomock.Any(), memo.AudioFileKey).
+			Return(&storage.ObjectInfo{Size: memo.FileSize}, nil)
+		mockRepo.EXPECT().
+			GetTeamUsage(gomock.Any(), teamID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{TranscriptionSeconds: 3601}, nil)
+		mockRepo.EXPECT().
+			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusPendingUpload).
+			Return(nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{Team: UsageQuota{MaxTranscriptionMinutes: 60}}, nil)
+		err := service.ConfirmTeamUpload(context.Background(), memoID, teamID)
+
+		assert.Equal(t, apperrors.ErrTranscriptionQuotaExceeded, err)
+	})
+}
+
+func TestVoiceMemoService_RetryTranscription_TranscriptionQuota(t *testing.T) {
+	memoID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	memo := &models.VoiceMemo{
+		ID:           memoID,
+		UserID:       userID,
+		AudioFileKey: "voice-memos/user1/memo1.mp3",
+		Duration:     120,
+		Status:       models.StatusTranscribing,
+	}
+
+	t.Run("reverts status when user transcription quota is exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithOwnership(gomock.Any(), memoID, userID, models.StatusFailed, models.StatusTranscribing).
+			Return(memo, nil)
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{TranscriptionSeconds: 601}, nil)
+		mockRepo.EXPECT().
+			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusFailed).
+			Return(nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{User: UsageQuota{MaxTranscriptionMinutes: 10}}, nil)
+		err := service.RetryTranscription(context.Background(), memoID, userID)
+
+		assert.Equal(t, apperrors.ErrTranscriptionQuotaExceeded, err)
+	})
+
+	t.Run("reverts status when team transcription quota is exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+		mockStorage := storagemocks.NewMockStorage(ctrl)
+		mockQueue := queuemocks.NewMockQueue(ctrl)
+
+		mockRepo.EXPECT().
+			UpdateStatusWithTeam(gomock.Any(), memoID, teamID, models.StatusFailed, models.StatusTranscribing).
+			Return(memo, nil)
+		mockRepo.EXPECT().
+			GetTeamUsage(gomock.Any(), teamID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{TranscriptionSeconds: 3601}, nil)
+		mockRepo.EXPECT().
+			UpdateStatusConditional(gomock.Any(), memoID, models.StatusTranscribing, models.StatusFailed).
+			Return(nil)
+
+		service := NewVoiceMemoService(mockRepo, mockStorage, mockQueue, time.Hour, 15*time.Minute, nil, VoiceMemoLimits{Team: UsageQuota{MaxTranscriptionMinutes: 60}}, nil)
+		err := service.RetryTeamTranscription(context.Background(), memoID, teamID)
+
+		assert.Equal(t, apperrors.ErrTranscriptionQuotaExceeded, err)
+	})
+}
+
+func TestVoiceMemoService_GetUsage(t *testing.T) {
+	userID := primitive.NewObjectID()
+	teamID := primitive.NewObjectID()
+	limits := VoiceMemoLimits{
+		User: UsageQuota{MaxMemos: 100, MaxAudioBytes: 1 << 20, MaxTranscriptionMinutes: 60},
+		Team: UsageQuota{MaxMemos: 1000},
+	}
+
+	t.Run("returns user usage with limits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id primitive.ObjectID, periodStart time.Time) (*models.VoiceMemoUsage, error) {
+				assert.Equal(t, 1, periodStart.Day())
+				return &models.VoiceMemoUsage{MemoCount: 3, AudioBytes: 2048, TranscriptionSeconds: 61}, nil
+			})
+
+		service := NewVoiceMemoService(mockRepo, nil, nil, time.Hour, 15*time.Minute, nil, limits, nil)
+		result, err := service.GetUserUsage(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UsageMetric{Used: 3, Limit: 100}, result.Memos)
+		assert.Equal(t, models.UsageMetric{Used: 2048, Limit: 1 << 20}, result.AudioBytes)
+		assert.Equal(t, models.UsageMetric{Used: 2, Limit: 60}, result.TranscriptionMinutes)
+		assert.Equal(t, result.PeriodStart.AddDate(0, 1, 0), result.PeriodEnd)
+	})
+
+	t.Run("returns team usage with limits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockRepo.EXPECT().
+			GetTeamUsage(gomock.Any(), teamID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{MemoCount: 7}, nil)
+
+		service := NewVoiceMemoService(mockRepo, nil, nil, time.Hour, 15*time.Minute, nil, limits, nil)
+		result, err := service.GetTeamUsage(context.Background(), teamID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UsageMetric{Used: 7, Limit: 1000}, result.Memos)
+		assert.Equal(t, int64(0), result.AudioBytes.Limit)
+	})
+
+	t.Run("returns error when repository fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(nil, assert.AnError)
+
+		service := NewVoiceMemoService(mockRepo, nil, nil, time.Hour, 15*time.Minute, nil, limits, nil)
+		result, err := service.GetUserUsage(context.Background(), userID)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+
+	t.Run("uses limits from updated settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repomocks.NewMockVoiceMemoRepository(ctrl)
+
+		mockRepo.EXPECT().
+			GetUserUsage(gomock.Any(), userID, gomock.Any()).
+			Return(&models.VoiceMemoUsage{MemoCount: 3}, nil)
+
+		service := NewVoiceMemoService(mockRepo, nil, nil, time.Hour, 15*time.Minute, nil, limits, nil)
+		service.UpdateSettings(VoiceMemoSettings{
+			PresignedURLExpiry:    time.Hour,
+			PresignedUploadExpiry: 15 * time.Minute,
+			Limits:                VoiceMemoLimits{User: UsageQuota{MaxMemos: 5}},
+		})
+		result, err := service.GetUserUsage(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UsageMetric{Used: 3, Limit: 5}, result.Memos)
+	})
+}
+
+func TestUsagePeriod(t *testing.T) {
+	start, end := usagePeriod(time.Date(2024, time.December, 15, 10, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), end)
 }
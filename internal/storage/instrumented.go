@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"gin-sample/internal/metrics"
+)
+
+// instrumentedStorage records the latency of every Storage call as an S3 dependency call.
+type instrumentedStorage struct {
+	next    Storage
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedStorage wraps next so every call is recorded in m.
+func NewInstrumentedStorage(next Storage, m *metrics.Metrics) Storage {
+	return &instrumentedStorage{next: next, metrics: m}
+}
+
+// observe records a call that started at start and returned *err.
+func (s *instrumentedStorage) observe(operation string, start time.Time, err *error) {
+	s.metrics.ObserveDependencyCall(metrics.DependencyS3, operation, time.Since(start), *err)
+}
+
+func (s *instrumentedStorage) GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (_ string, err error) {
+	defer s.observe("GetPresignedURL", time.Now(), &err)
+	return s.next.GetPresignedURL(ctx, key, expiry)
+}
+
+func (s *instrumentedStorage) GetPresignedPutURL(ctx context.Context, key, contentType string, contentLength int64, expiry time.Duration) (_ string, err error) {
+	defer s.observe("GetPresignedPutURL", time.Now(), &err)
+	return s.next.GetPresignedPutURL(ctx, key, contentType, contentLength, expiry)
+}
+
+func (s *instrumentedStorage) HeadObject(ctx context.Context, key string) (_ *ObjectInfo, err error) {
+	defer s.observe("HeadObject", time.Now(), &err)
+	return s.next.HeadObject(ctx, key)
+}
+
+func (s *instrumentedStorage) PutObject(ctx context.Context, key string, body io.Reader, contentType string) (err error) {
+	defer s.observe("PutObject", time.Now(), &err)
+	return s.next.PutObject(ctx, key, body, contentType)
+}
+
+func (s *instrumentedStorage) DeleteObject(ctx context.Context, key string) (err error) {
+	defer s.observe("DeleteObject", time.Now(), &err)
+	return s.next.DeleteObject(ctx, key)
+}
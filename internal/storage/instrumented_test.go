@@ -0,0 +1,37 @@
+package storage_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gin-sample/internal/metrics"
+	"gin-sample/internal/storage"
+	"gin-sample/internal/storage/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInstrumentedStorage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := metrics.New()
+	mockStorage := mocks.NewMockStorage(ctrl)
+	s := storage.NewInstrumentedStorage(mockStorage, m)
+
+	mockStorage.EXPECT().
+		GetPresignedURL(gomock.Any(), "audio.mp3", time.Hour).
+		Return("https://example.com/audio.mp3", nil)
+
+	url, err := s.GetPresignedURL(context.Background(), "audio.mp3", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/audio.mp3", url)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `gin_sample_dependency_call_duration_seconds_count{dependency="s3",operation="GetPresignedURL",result="ok"} 1`)
+}
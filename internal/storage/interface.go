@@ -2,18 +2,31 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 )
 
+// ErrObjectNotFound is returned when an object does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	// Size is the object size in bytes.
+	Size int64
+}
+
 //go:generate mockgen -destination=mocks/mock_storage.go -package=mocks gin-sample/internal/storage Storage
 
 // Storage defines the interface for object storage operations.
 type Storage interface {
 	// GetPresignedURL generates a pre-signed URL for downloading an object.
 	GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
-	// GetPresignedPutURL generates a pre-signed URL for uploading an object.
-	GetPresignedPutURL(ctx context.Context, key, contentType string, expiry time.Duration) (string, error)
+	// GetPresignedPutURL generates a pre-signed URL for uploading an object of exactly
+	// contentLength bytes.
+	GetPresignedPutURL(ctx context.Context, key, contentType string, contentLength int64, expiry time.Duration) (string, error)
+	// HeadObject returns information about an object, or ErrObjectNotFound.
+	HeadObject(ctx context.Context, key string) (*ObjectInfo, error)
 	// PutObject uploads an object to storage.
 	PutObject(ctx context.Context, key string, body io.Reader, contentType string) error
 	// DeleteObject deletes an object from storage.
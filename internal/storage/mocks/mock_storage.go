@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	storage "gin-sample/internal/storage"
 	io "io"
 	reflect "reflect"
 	time "time"
@@ -57,18 +58,18 @@ func (mr *MockStorageMockRecorder) DeleteObject(ctx, key any) *gomock.Call {
 }
 
 // GetPresignedPutURL mocks base method.
-func (m *MockStorage) GetPresignedPutURL(ctx context.Context, key, contentType string, expiry time.Duration) (string, error) {
+func (m *MockStorage) GetPresignedPutURL(ctx context.Context, key, contentType string, contentLength int64, expiry time.Duration) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPresignedPutURL", ctx, key, contentType, expiry)
+	ret := m.ctrl.Call(m, "GetPresignedPutURL", ctx, key, contentType, contentLength, expiry)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPresignedPutURL indicates an expected call of GetPresignedPutURL.
-func (mr *MockStorageMockRecorder) GetPresignedPutURL(ctx, key, contentType, expiry any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetPresignedPutURL(ctx, key, contentType, contentLength, expiry any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedPutURL", reflect.TypeOf((*MockStorage)(nil).GetPresignedPutURL), ctx, key, contentType, expiry)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedPutURL", reflect.TypeOf((*MockStorage)(nil).GetPresignedPutURL), ctx, key, contentType, contentLength, expiry)
 }
 
 // GetPresignedURL mocks base method.
@@ -86,6 +87,21 @@ func (mr *MockStorageMockRecorder) GetPresignedURL(ctx, key, expiry any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedURL", reflect.TypeOf((*MockStorage)(nil).GetPresignedURL), ctx, key, expiry)
 }
 
+// HeadObject mocks base method.
+func (m *MockStorage) HeadObject(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeadObject", ctx, key)
+	ret0, _ := ret[0].(*storage.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadObject indicates an expected call of HeadObject.
+func (mr *MockStorageMockRecorder) HeadObject(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadObject", reflect.TypeOf((*MockStorage)(nil).HeadObject), ctx, key)
+}
+
 // PutObject mocks base method.
 func (m *MockStorage) PutObject(ctx context.Context, key string, body io.Reader, contentType string) error {
 	m.ctrl.T.Helper()
@@ -3,14 +3,20 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
-	"log"
+	"log/slog"
 	"time"
 
+	"gin-sample/internal/logger"
+	"gin-sample/internal/tracing"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/middleware"
 )
 
 // S3Client wraps the S3 client for generating pre-signed URLs.
@@ -33,9 +39,10 @@ func NewS3Client(endpoint, accessKey, secretKey, bucket string, useSSL bool) *S3
 	cfg, err := config.LoadDefaultConfig(context.Background(),
 		config.WithRegion("us-east-1"), // MinIO requires a region
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
+		config.WithAPIOptions([]func(*middleware.Stack) error{tracing.AWSMiddleware}),
 	)
 	if err != nil {
-		log.Fatalf("Failed to load S3 config: %v", err)
+		logger.Fatal("failed to load S3 config", "error", err)
 	}
 
 	// Create S3 client with service-specific endpoint configuration (non-deprecated approach)
@@ -44,7 +51,7 @@ func NewS3Client(endpoint, accessKey, secretKey, bucket string, useSSL bool) *S3
 		o.UsePathStyle = true // Required for MinIO
 	})
 
-	log.Printf("Connected to S3 at %s", endpointURL)
+	slog.Info("connected to S3", "endpoint", endpointURL)
 
 	return &S3Client{
 		client:        client,
@@ -53,6 +60,12 @@ func NewS3Client(endpoint, accessKey, secretKey, bucket string, useSSL bool) *S3
 	}
 }
 
+// Ping checks that the bucket exists and is accessible with the configured credentials.
+func (s *S3Client) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+	return err
+}
+
 // GetPresignedURL generates a pre-signed URL for downloading an object.
 func (s *S3Client) GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
 	request, err := s.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
@@ -78,11 +91,13 @@ func (s *S3Client) PutObject(ctx context.Context, key string, body io.Reader, co
 }
 
 // GetPresignedPutURL generates a pre-signed URL for uploading an object.
-func (s *S3Client) GetPresignedPutURL(ctx context.Context, key, contentType string, expiry time.Duration) (string, error) {
+// Content-Length is part of the signature, so uploads of any other size are rejected.
+func (s *S3Client) GetPresignedPutURL(ctx context.Context, key, contentType string, contentLength int64, expiry time.Duration) (string, error) {
 	request, err := s.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.bucket),
-		Key:         aws.String(key),
-		ContentType: aws.String(contentType),
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(contentLength),
 	}, s3.WithPresignExpires(expiry))
 	if err != nil {
 		return "", err
@@ -91,6 +106,23 @@ func (s *S3Client) GetPresignedPutURL(ctx context.Context, key, contentType stri
 	return request.URL, nil
 }
 
+// HeadObject returns the size of an object, or ErrObjectNotFound if it does not exist.
+func (s *S3Client) HeadObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	output, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return &ObjectInfo{Size: aws.ToInt64(output.ContentLength)}, nil
+}
+
 // DeleteObject deletes an object from S3.
 func (s *S3Client) DeleteObject(ctx context.Context, key string) error {
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
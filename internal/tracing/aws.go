@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AWSMiddleware records a client span for every AWS SDK operation, named after the service
+// and operation, e.g. "S3.PutObject". Pre-signing runs the same middleware stack, so each
+// presigned URL shows up as its own span. Register it with config.WithAPIOptions.
+func AWSMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Tracing", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (middleware.InitializeOutput, middleware.Metadata, error) {
+		service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+		ctx, span := Tracer().Start(ctx, service+"."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemKey.String("aws-api"),
+				semconv.RPCService(service),
+				semconv.RPCMethod(operation),
+			),
+		)
+		defer span.End()
+
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		RecordError(span, err)
+		return out, metadata, err
+	}), middleware.After)
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"gin-sample/internal/tracing/tracingtest"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// statusClient answers every request with the given status code.
+type statusClient int
+
+func (c statusClient) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: int(c),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func newTestS3Client(status int) *s3.Client {
+	return s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String("http://localhost:9000"),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("key", "secret", ""),
+		HTTPClient:   statusClient(status),
+		APIOptions:   []func(*middleware.Stack) error{AWSMiddleware},
+	})
+}
+
+func TestAWSMiddleware(t *testing.T) {
+	t.Run("records operations", func(t *testing.T) {
+		recorder := tracingtest.Record(t)
+		client := newTestS3Client(http.StatusOK)
+
+		_, err := client.HeadObject(context.Background(), &s3.HeadObjectInput{Bucket: aws.String("memos"), Key: aws.String("a.mp3")})
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "S3.HeadObject", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.String("rpc.system", "aws-api"))
+		assert.Contains(t, spans[0].Attributes(), attribute.String("rpc.method", "HeadObject"))
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("records failures", func(t *testing.T) {
+		recorder := tracingtest.Record(t)
+		client := newTestS3Client(http.StatusNotFound)
+
+		_, err := client.HeadObject(context.Background(), &s3.HeadObjectInput{Bucket: aws.String("memos"), Key: aws.String("a.mp3")})
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+
+	t.Run("records pre-signing", func(t *testing.T) {
+		recorder := tracingtest.Record(t)
+		presigner := s3.NewPresignClient(newTestS3Client(http.StatusOK))
+
+		_, err := presigner.PresignGetObject(context.Background(), &s3.GetObjectInput{Bucket: aws.String("memos"), Key: aws.String("a.mp3")}, s3.WithPresignExpires(time.Minute))
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "S3.GetObject", spans[0].Name())
+	})
+}
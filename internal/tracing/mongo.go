@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// mongoSpanKey identifies an in-flight command; request IDs are only unique per connection.
+type mongoSpanKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// mongoMonitor starts a span when a command is sent and ends it when the reply arrives.
+type mongoMonitor struct {
+	spans sync.Map // mongoSpanKey -> trace.Span
+}
+
+// NewMongoMonitor returns a command monitor that records a client span for every MongoDB
+// command, named after the command and collection, e.g. "find voice_memos". Command
+// documents are not recorded, so filter values never reach the trace backend.
+func NewMongoMonitor() *event.CommandMonitor {
+	m := &mongoMonitor{}
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+func (m *mongoMonitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	name := evt.CommandName
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNameMongoDB,
+		semconv.DBNamespace(evt.DatabaseName),
+		semconv.DBOperationName(evt.CommandName),
+	}
+	// The collection is the value of the command's first element, e.g. {"find": "users", ...}
+	if first, err := evt.Command.IndexErr(0); err == nil && first.Key() == evt.CommandName {
+		if collection, ok := first.Value().StringValueOK(); ok {
+			name += " " + collection
+			attrs = append(attrs, semconv.DBCollectionName(collection))
+		}
+	}
+
+	_, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	m.spans.Store(mongoSpanKey{evt.ConnectionID, evt.RequestID}, span)
+}
+
+func (m *mongoMonitor) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	m.finish(evt.CommandFinishedEvent, nil)
+}
+
+func (m *mongoMonitor) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	m.finish(evt.CommandFinishedEvent, errors.New(evt.Failure))
+}
+
+func (m *mongoMonitor) finish(evt event.CommandFinishedEvent, err error) {
+	value, ok := m.spans.LoadAndDelete(mongoSpanKey{evt.ConnectionID, evt.RequestID})
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	RecordError(span, err)
+	span.End()
+}
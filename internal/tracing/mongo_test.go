@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"gin-sample/internal/tracing/tracingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMongoMonitor(t *testing.T) {
+	recorder := tracingtest.Record(t)
+	monitor := NewMongoMonitor()
+
+	ctx, parent := Tracer().Start(context.Background(), "request")
+	command, err := bson.Marshal(bson.D{{Key: "find", Value: "voice_memos"}, {Key: "filter", Value: bson.D{{Key: "secret", Value: "value"}}}})
+	require.NoError(t, err)
+
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command: command, DatabaseName: "app", CommandName: "find", RequestID: 1, ConnectionID: "db:27017[-1]",
+	})
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command: command, DatabaseName: "app", CommandName: "find", RequestID: 1, ConnectionID: "db:27017[-2]",
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 1, ConnectionID: "db:27017[-1]"},
+	})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 1, ConnectionID: "db:27017[-2]"},
+		Failure:              "connection reset",
+	})
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	succeeded, failed := spans[0], spans[1]
+
+	assert.Equal(t, "find voice_memos", succeeded.Name())
+	assert.Equal(t, trace.SpanKindClient, succeeded.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), succeeded.Parent().SpanID())
+	assert.Equal(t, codes.Unset, succeeded.Status().Code)
+	assert.Contains(t, succeeded.Attributes(), attribute.String("db.system.name", "mongodb"))
+	assert.Contains(t, succeeded.Attributes(), attribute.String("db.namespace", "app"))
+	assert.Contains(t, succeeded.Attributes(), attribute.String("db.collection.name", "voice_memos"))
+	for _, attr := range succeeded.Attributes() {
+		assert.NotContains(t, attr.Value.Emit(), "secret", "command documents are not recorded")
+	}
+
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Equal(t, "connection reset", failed.Status().Description)
+}
+
+func TestMongoMonitorCommandWithoutCollection(t *testing.T) {
+	recorder := tracingtest.Record(t)
+	monitor := NewMongoMonitor()
+
+	command, err := bson.Marshal(bson.D{{Key: "ping", Value: 1}})
+	require.NoError(t, err)
+	monitor.Started(context.Background(), &event.CommandStartedEvent{Command: command, DatabaseName: "admin", CommandName: "ping", RequestID: 7})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "ping", RequestID: 7},
+	})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ping", spans[0].Name())
+}
@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gin-sample/internal/tracing/tracingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("disabled leaves the no-op provider in place", func(t *testing.T) {
+		before := otel.GetTracerProvider()
+
+		shutdown, err := Setup(context.Background(), Config{Enabled: false})
+
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+		assert.Equal(t, before, otel.GetTracerProvider())
+	})
+
+	t.Run("rejects an invalid sample ratio", func(t *testing.T) {
+		_, err := Setup(context.Background(), Config{Enabled: true, Endpoint: "localhost:4318", SampleRatio: 1.5})
+		assert.Error(t, err)
+	})
+}
+
+func TestInjectExtract(t *testing.T) {
+	t.Run("no trace", func(t *testing.T) {
+		tracingtest.Record(t)
+		assert.Nil(t, Inject(context.Background()))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		tracingtest.Record(t)
+		ctx, span := Tracer().Start(context.Background(), "request")
+		defer span.End()
+
+		carrier := Inject(ctx)
+		require.Contains(t, carrier, "traceparent")
+
+		extracted := trace.SpanContextFromContext(Extract(context.Background(), carrier))
+		assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+		assert.True(t, extracted.IsRemote())
+	})
+}
+
+func TestRecordError(t *testing.T) {
+	recorder := tracingtest.Record(t)
+
+	_, span := Tracer().Start(context.Background(), "ok")
+	RecordError(span, nil)
+	span.End()
+	_, span = Tracer().Start(context.Background(), "failed")
+	RecordError(span, errors.New("boom"))
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "boom", spans[1].Status().Description)
+}
@@ -1,23 +1,152 @@
 package validator
 
 import (
+	"errors"
+	"net/http"
+	"reflect"
 	"regexp"
+	"strings"
+
+	apperrors "gin-sample/internal/errors"
 
 	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	detranslations "github.com/go-playground/validator/v10/translations/de"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	estranslations "github.com/go-playground/validator/v10/translations/es"
+	frtranslations "github.com/go-playground/validator/v10/translations/fr"
 )
 
 // slugRegex matches valid slugs: lowercase alphanumeric with hyphens, no leading/trailing/consecutive hyphens
 var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
 
+// uni holds the translators for the supported locales. English is the fallback.
+var uni = ut.New(en.New(), en.New(), es.New(), fr.New(), de.New())
+
+// defaultTranslations registers the built-in validator messages for a locale.
+type defaultTranslations func(v *validator.Validate, trans ut.Translator) error
+
+// localeTranslations lists the supported locales with their built-in messages and slug message.
+var localeTranslations = map[string]struct {
+	register defaultTranslations
+	slug     string
+}{
+	"en": {entranslations.RegisterDefaultTranslations, "{0} must be a valid slug (lowercase letters, numbers and single hyphens)"},
+	"es": {estranslations.RegisterDefaultTranslations, "{0} debe ser un slug válido (letras minúsculas, números y guiones simples)"},
+	"fr": {frtranslations.RegisterDefaultTranslations, "{0} doit être un slug valide (lettres minuscules, chiffres et tirets simples)"},
+	"de": {detranslations.RegisterDefaultTranslations, "{0} muss ein gültiger Slug sein (Kleinbuchstaben, Ziffern und einzelne Bindestriche)"},
+}
+
 // validateSlug validates that a string is a valid slug
 func validateSlug(fl validator.FieldLevel) bool {
 	return slugRegex.MatchString(fl.Field().String())
 }
 
-// RegisterCustomValidators registers all custom validators with gin's validator
+// jsonFieldName reports fields by their JSON name, so validation errors match the request body
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// RegisterCustomValidators registers all custom validators and error message translations with gin's validator
 func RegisterCustomValidators() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		_ = v.RegisterValidation("slug", validateSlug)
+		v.RegisterTagNameFunc(jsonFieldName)
+		registerTranslations(v)
+	}
+}
+
+// registerTranslations registers the built-in and custom messages for every supported locale
+func registerTranslations(v *validator.Validate) {
+	for locale, lt := range localeTranslations {
+		trans, _ := uni.GetTranslator(locale)
+		_ = lt.register(v, trans)
+		_ = v.RegisterTranslation("slug", trans, registerMessage("slug", lt.slug), translateField("slug"))
 	}
 }
+
+// registerMessage returns a RegisterTranslationsFunc that adds message under tag
+func registerMessage(tag, message string) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, message, true)
+	}
+}
+
+// translateField returns a TranslationFunc that renders tag's message with the field name
+func translateField(tag string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		msg, err := trans.T(tag, fe.Field())
+		if err != nil {
+			return fe.Error()
+		}
+		return msg
+	}
+}
+
+// BindError converts an error from ShouldBindJSON into an application error.
+// Validation failures become a validation error listing each invalid field with a
+// message localized for acceptLanguage; a body over the size limit becomes
+// ErrRequestTooLarge; other errors, such as malformed JSON, become a bad request.
+func BindError(err error, acceptLanguage string) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return apperrors.ErrRequestTooLarge
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return apperrors.BadRequest(err.Error())
+	}
+
+	trans := translator(acceptLanguage)
+	fields := make([]apperrors.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, apperrors.FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fe.Translate(trans),
+		})
+	}
+	return apperrors.Validation(fields...)
+}
+
+// fieldPath returns the field's path without the root struct name, e.g. "name" or "members[0].email"
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+// translator returns the translator for the first supported language in an Accept-Language
+// header, falling back to English. Languages are taken in the order listed; quality values are ignored.
+func translator(acceptLanguage string) ut.Translator {
+	var locales []string
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		tag = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "-", "_")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		locales = append(locales, tag)
+		if base, _, ok := strings.Cut(tag, "_"); ok {
+			locales = append(locales, base)
+		}
+	}
+
+	trans, _ := uni.FindTranslator(locales...)
+	return trans
+}
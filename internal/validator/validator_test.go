@@ -1,9 +1,16 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
+	apperrors "gin-sample/internal/errors"
+
+	"github.com/gin-gonic/gin/binding"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSlugRegex(t *testing.T) {
@@ -59,3 +66,69 @@ func TestRegisterCustomValidators(t *testing.T) {
 		})
 	})
 }
+
+type bindTestRequest struct {
+	Name string `json:"name" binding:"required,max=5"`
+	Slug string `json:"slug" binding:"omitempty,slug"`
+}
+
+func TestBindError(t *testing.T) {
+	RegisterCustomValidators()
+
+	t.Run("translates validation errors to field errors", func(t *testing.T) {
+		err := binding.Validator.ValidateStruct(&bindTestRequest{Name: "too long", Slug: "Bad Slug"})
+		require.Error(t, err)
+
+		var appErr *apperrors.AppError
+		require.True(t, errors.As(BindError(err, ""), &appErr))
+		assert.Equal(t, apperrors.CodeValidationFailed, appErr.Code)
+		assert.Equal(t, []apperrors.FieldError{
+			{Field: "name", Rule: "max", Param: "5", Message: "name must be a maximum of 5 characters in length"},
+			{Field: "slug", Rule: "slug", Message: "slug must be a valid slug (lowercase letters, numbers and single hyphens)"},
+		}, appErr.Fields)
+	})
+
+	t.Run("localizes messages", func(t *testing.T) {
+		err := binding.Validator.ValidateStruct(&bindTestRequest{Slug: "Bad Slug"})
+		require.Error(t, err)
+
+		var appErr *apperrors.AppError
+		require.True(t, errors.As(BindError(err, "fr-CA,fr;q=0.9"), &appErr))
+		require.Len(t, appErr.Fields, 2)
+		assert.Equal(t, "name est un champ obligatoire", appErr.Fields[0].Message)
+		assert.Equal(t, "slug doit être un slug valide (lettres minuscules, chiffres et tirets simples)", appErr.Fields[1].Message)
+	})
+
+	t.Run("non-validation errors are bad requests", func(t *testing.T) {
+		var appErr *apperrors.AppError
+		require.True(t, errors.As(BindError(errors.New("unexpected EOF"), ""), &appErr))
+		assert.Equal(t, apperrors.CodeBadRequest, appErr.Code)
+		assert.Equal(t, "unexpected EOF", appErr.Message)
+	})
+
+	t.Run("body over the size limit is too large", func(t *testing.T) {
+		err := fmt.Errorf("read body: %w", &http.MaxBytesError{Limit: 10})
+		assert.ErrorIs(t, BindError(err, ""), apperrors.ErrRequestTooLarge)
+	})
+}
+
+func TestTranslator(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"empty header falls back to english", "", "en"},
+		{"exact match", "de", "de"},
+		{"region falls back to base language", "es-MX", "es"},
+		{"first supported language wins", "ja, fr;q=0.8, en;q=0.5", "fr"},
+		{"unsupported language falls back to english", "ja", "en"},
+		{"wildcard", "*", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, translator(tt.acceptLanguage).Locale())
+		})
+	}
+}
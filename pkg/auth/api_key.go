@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix starts every personal API key so it can be told apart from a JWT.
+const APIKeyPrefix = "gmk_"
+
+// API key scopes
+const (
+	ScopeMemosRead  = "memos:read"
+	ScopeMemosWrite = "memos:write"
+)
+
+// APIKeyGenerator generates and hashes personal API keys.
+type APIKeyGenerator interface {
+	// Generate creates a new API key and returns it with its display prefix.
+	Generate() (key string, prefix string, err error)
+	// Hash returns the SHA-256 hash of a key.
+	Hash(key string) string
+}
+
+type apiKeyGenerator struct{}
+
+// NewAPIKeyGenerator creates a new APIKeyGenerator.
+func NewAPIKeyGenerator() APIKeyGenerator {
+	return &apiKeyGenerator{}
+}
+
+// Generate creates a new API key in format: gmk_{prefix}_{random}
+// - prefix: 8-character hex string (4 bytes), shown in key listings
+// - random: 64-character hex string (32 bytes)
+// The returned prefix includes the "gmk_" marker (e.g. "gmk_1a2b3c4d").
+func (g *apiKeyGenerator) Generate() (string, string, error) {
+	prefix, err := randomHex(4)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate key prefix: %w", err)
+	}
+
+	random, err := randomHex(32)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate random part: %w", err)
+	}
+
+	displayPrefix := APIKeyPrefix + prefix
+	return displayPrefix + "_" + random, displayPrefix, nil
+}
+
+// Hash returns the SHA-256 hash of the key as a hex string.
+func (g *apiKeyGenerator) Hash(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// IsAPIKey reports whether a bearer token looks like a personal API key rather than a JWT.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// randomHex generates a random hex string of specified byte length.
+func randomHex(byteLen int) (string, error) {
+	bytes := make([]byte, byteLen)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyGenerator_Generate(t *testing.T) {
+	gen := NewAPIKeyGenerator()
+
+	t.Run("generates valid key format", func(t *testing.T) {
+		key, prefix, err := gen.Generate()
+
+		require.NoError(t, err)
+
+		// Key format: gmk_{prefix}_{random}
+		parts := strings.Split(key, "_")
+		require.Len(t, parts, 3)
+		assert.Equal(t, "gmk", parts[0])
+		assert.Len(t, parts[1], 8)  // prefix is 8 hex chars (4 bytes)
+		assert.Len(t, parts[2], 64) // random is 64 hex chars (32 bytes)
+		assert.Equal(t, "gmk_"+parts[1], prefix)
+		assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	})
+
+	t.Run("generates unique keys", func(t *testing.T) {
+		key1, _, _ := gen.Generate()
+		key2, _, _ := gen.Generate()
+
+		assert.NotEqual(t, key1, key2)
+	})
+}
+
+func TestAPIKeyGenerator_Hash(t *testing.T) {
+	gen := NewAPIKeyGenerator()
+	key, _, err := gen.Generate()
+	require.NoError(t, err)
+
+	hash := gen.Hash(key)
+
+	assert.Len(t, hash, 64) // SHA-256 = 32 bytes = 64 hex chars
+	assert.Equal(t, hash, gen.Hash(key))
+	assert.NotEqual(t, key, hash)
+}
+
+func TestIsAPIKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{"api key", "gmk_1a2b3c4d_abcdef", true},
+		{"jwt", "eyJhbGciOiJIUzI1NiIs.eyJzdWIiOiIx.sig", false},
+		{"refresh token", "rt_1234567890abcdef_abcdef", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsAPIKey(tt.token))
+		})
+	}
+}
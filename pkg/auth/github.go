@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	githubBaseURL = "https://github.com"
+	githubAPIURL  = "https://api.github.com"
+)
+
+// GitHubConfig configures login with GitHub. GitHub is an OAuth2 provider without
+// OpenID Connect support, so identities are read from its REST API instead of an ID token.
+type GitHubConfig struct {
+	// Name identifies the provider in URLs and linked identities (e.g. "github").
+	Name        string
+	DisplayName string
+	// BaseURL is the GitHub Enterprise Server URL. Defaults to https://github.com.
+	BaseURL string
+	// APIURL is the REST API URL. Defaults to https://api.github.com, or <BaseURL>/api/v3
+	// when BaseURL is set.
+	APIURL       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where GitHub sends the user back with the authorization code.
+	RedirectURL string
+	// Scopes requested. Defaults to read:user and user:email.
+	Scopes []string
+	// TrustEmail allows linking a login to an existing account with the same verified email.
+	// GitHub accounts can verify any email address, so this is rarely appropriate.
+	TrustEmail bool
+}
+
+type githubProvider struct {
+	name        string
+	displayName string
+	trustEmail  bool
+	apiURL      string
+	oauth2      *oauth2.Config
+}
+
+// NewGitHubProvider creates an OIDCProvider that logs in with GitHub.
+func NewGitHubProvider(cfg GitHubConfig) OIDCProvider {
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	apiURL := strings.TrimSuffix(cfg.APIURL, "/")
+	if baseURL == "" {
+		baseURL = githubBaseURL
+		if apiURL == "" {
+			apiURL = githubAPIURL
+		}
+	}
+	if apiURL == "" {
+		apiURL = baseURL + "/api/v3"
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"read:user", "user:email"}
+	}
+
+	displayName := cfg.DisplayName
+	if displayName == "" {
+		displayName = cfg.Name
+	}
+
+	return &githubProvider{
+		name:        cfg.Name,
+		displayName: displayName,
+		trustEmail:  cfg.TrustEmail,
+		apiURL:      apiURL,
+		oauth2: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  baseURL + "/login/oauth/authorize",
+				TokenURL: baseURL + "/login/oauth/access_token",
+			},
+			Scopes: scopes,
+		},
+	}
+}
+
+// Name returns the provider's identifier.
+func (p *githubProvider) Name() string {
+	return p.name
+}
+
+// DisplayName returns the provider's human-readable name.
+func (p *githubProvider) DisplayName() string {
+	return p.displayName
+}
+
+// TrustsEmail reports whether the provider's verified emails may link existing accounts.
+func (p *githubProvider) TrustsEmail() bool {
+	return p.trustEmail
+}
+
+// AuthCodeURL returns the authorization URL with the state and S256 code challenge.
+// GitHub issues no ID token, so the nonce is not sent; the state binds the login instead.
+func (p *githubProvider) AuthCodeURL(state, _, codeVerifier string) string {
+	return p.oauth2.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier))
+}
+
+// Exchange redeems the code with the PKCE verifier, then reads the user's ID, name and
+// primary email from the GitHub API. The subject is the numeric user ID, which unlike
+// the login never changes.
+func (p *githubProvider) Exchange(ctx context.Context, code, codeVerifier, _ string) (*OIDCIdentity, error) {
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	client := p.oauth2.Client(ctx, token)
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.get(ctx, client, "/user", &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("github user has no id")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &OIDCIdentity{
+		Subject: strconv.FormatInt(user.ID, 10),
+		Name:    user.Name,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	// Prefer the primary email; fall back to any verified one
+	for _, email := range emails {
+		if email.Verified && (email.Primary || !identity.EmailVerified) {
+			identity.Email = email.Email
+			identity.EmailVerified = true
+		}
+	}
+
+	return identity, nil
+}
+
+// get fetches a GitHub API resource and decodes the JSON response into v.
+func (p *githubProvider) get(ctx context.Context, client *http.Client, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch github %s: %w", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch github %s: status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode github %s: %w", path, err)
+	}
+	return nil
+}
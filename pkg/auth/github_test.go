@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockGitHubEmail is an entry of the mock GitHub /user/emails response.
+type mockGitHubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// mockGitHub serves the GitHub Enterprise Server token and REST API endpoints for one
+// authorization code, issued for the code challenge of the last AuthCodeURL.
+type mockGitHub struct {
+	server        *httptest.Server
+	codeChallenge string
+	emails        []mockGitHubEmail
+}
+
+func setupGitHubProvider(t *testing.T) (*mockGitHub, OIDCProvider) {
+	t.Helper()
+
+	gh := &mockGitHub{
+		emails: []mockGitHubEmail{
+			{Email: "old@example.com", Verified: true},
+			{Email: "octo@example.com", Primary: true, Verified: true},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != "code123" || base64.RawURLEncoding.EncodeToString(sum[:]) != gh.codeChallenge {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "gho_token", "token_type": "bearer"})
+	})
+	authorized := func(next func(w http.ResponseWriter)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer gho_token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w)
+		}
+	}
+	mux.HandleFunc("GET /api/v3/user", authorized(func(w http.ResponseWriter) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 583231, "login": "octocat", "name": ""})
+	}))
+	mux.HandleFunc("GET /api/v3/user/emails", authorized(func(w http.ResponseWriter) {
+		_ = json.NewEncoder(w).Encode(gh.emails)
+	}))
+	gh.server = httptest.NewServer(mux)
+	t.Cleanup(gh.server.Close)
+
+	provider := NewGitHubProvider(GitHubConfig{
+		Name:         "github",
+		DisplayName:  "GitHub",
+		BaseURL:      gh.server.URL,
+		ClientID:     "github-client",
+		ClientSecret: "github-secret",
+		RedirectURL:  "http://localhost:3000/auth/callback",
+	})
+
+	return gh, provider
+}
+
+// authorize records the code challenge of an authorization URL, as GitHub would on login.
+func (gh *mockGitHub) authorize(t *testing.T, authCodeURL string) {
+	t.Helper()
+
+	parsed, err := url.Parse(authCodeURL)
+	require.NoError(t, err)
+	gh.codeChallenge = parsed.Query().Get("code_challenge")
+}
+
+func TestGitHubProvider_AuthCodeURL(t *testing.T) {
+	gh, provider := setupGitHubProvider(t)
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state123", "nonce123", GenerateCodeVerifier()))
+	require.NoError(t, err)
+
+	query := authURL.Query()
+	assert.Equal(t, gh.server.URL+"/login/oauth/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(t, "state123", query.Get("state"))
+	assert.Empty(t, query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.Equal(t, "read:user user:email", query.Get("scope"))
+	assert.Equal(t, "http://localhost:3000/auth/callback", query.Get("redirect_uri"))
+}
+
+func TestGitHubProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns identity with primary verified email", func(t *testing.T) {
+		gh, provider := setupGitHubProvider(t)
+		verifier := GenerateCodeVerifier()
+		gh.authorize(t, provider.AuthCodeURL("state123", "nonce123", verifier))
+
+		identity, err := provider.Exchange(ctx, "code123", verifier, "nonce123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "583231", identity.Subject)
+		assert.Equal(t, "octo@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+		assert.Equal(t, "octocat", identity.Name)
+	})
+
+	t.Run("falls back to another verified email", func(t *testing.T) {
+		gh, provider := setupGitHubProvider(t)
+		gh.emails = []mockGitHubEmail{
+			{Email: "unverified@example.com", Primary: true},
+			{Email: "verified@example.com", Verified: true},
+		}
+		verifier := GenerateCodeVerifier()
+		gh.authorize(t, provider.AuthCodeURL("state123", "", verifier))
+
+		identity, err := provider.Exchange(ctx, "code123", verifier, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "verified@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+	})
+
+	t.Run("returns no email when none is verified", func(t *testing.T) {
+		gh, provider := setupGitHubProvider(t)
+		gh.emails = []mockGitHubEmail{{Email: "unverified@example.com", Primary: true}}
+		verifier := GenerateCodeVerifier()
+		gh.authorize(t, provider.AuthCodeURL("state123", "", verifier))
+
+		identity, err := provider.Exchange(ctx, "code123", verifier, "")
+
+		require.NoError(t, err)
+		assert.Empty(t, identity.Email)
+		assert.False(t, identity.EmailVerified)
+	})
+
+	t.Run("rejects wrong code verifier", func(t *testing.T) {
+		gh, provider := setupGitHubProvider(t)
+		gh.authorize(t, provider.AuthCodeURL("state123", "", GenerateCodeVerifier()))
+
+		_, err := provider.Exchange(ctx, "code123", GenerateCodeVerifier(), "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewGitHubProvider(t *testing.T) {
+	t.Run("defaults to github.com", func(t *testing.T) {
+		provider := NewGitHubProvider(GitHubConfig{Name: "github", ClientID: "id"})
+
+		authURL, err := url.Parse(provider.AuthCodeURL("state", "", GenerateCodeVerifier()))
+		require.NoError(t, err)
+		assert.Equal(t, "github.com", authURL.Host)
+		assert.Equal(t, "github", provider.DisplayName())
+		assert.Equal(t, githubAPIURL, provider.(*githubProvider).apiURL)
+	})
+}
@@ -6,6 +6,10 @@ package auth
 type TokenManager interface {
 	// GenerateToken creates a new JWT token for a user.
 	GenerateToken(userID string) (string, error)
+	// GenerateSessionToken creates a new JWT token for a user bound to a login session and token version.
+	GenerateSessionToken(userID, sessionID string, version int64) (string, error)
+	// GenerateScopedToken creates a session token restricted to the given scopes.
+	GenerateScopedToken(userID, sessionID string, version int64, scopes []string) (string, error)
 	// ValidateToken parses and validates a JWT token, returning the claims if valid.
 	ValidateToken(tokenString string) (*Claims, error)
 }
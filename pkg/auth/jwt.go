@@ -1,6 +1,10 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,16 +13,31 @@ import (
 // Claims represents the JWT claims (data stored in the token).
 type Claims struct {
 	UserID string `json:"userId"`
+	// SessionID identifies the refresh token family (login session) the token was issued for.
+	SessionID string `json:"sid,omitempty"`
+	// TokenVersion is the user's token version at issue time. Tokens with an older
+	// version than the user's current one have been revoked.
+	TokenVersion int64 `json:"ver,omitempty"`
+	// Scopes restricts the token to these actions (e.g. "memo:view"). Tokens without
+	// scopes can do everything the user can.
+	Scopes []string `json:"scp,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // JWTManager handles JWT token operations.
+// Tokens are signed with an HS256 secret, or with an asymmetric key (RS256, ES256 or EdDSA)
+// identified by the "kid" header so other services can verify them using the public JWKS.
 type JWTManager struct {
-	secret []byte
+	secret     []byte
+	signingKey *SigningKey
+	// verificationKeys are the public keys accepted for asymmetric tokens, by key ID
+	verificationKeys map[string]*VerificationKey
+	// keyIDs preserves the configured key order for JWKS output
+	keyIDs []string
 	expiry time.Duration
 }
 
-// NewJWTManager creates a new JWT manager.
+// NewJWTManager creates a new JWT manager that signs tokens with an HS256 secret.
 func NewJWTManager(secret string, expiry time.Duration) *JWTManager {
 	return &JWTManager{
 		secret: []byte(secret),
@@ -26,25 +45,79 @@ func NewJWTManager(secret string, expiry time.Duration) *JWTManager {
 	}
 }
 
+// NewAsymmetricJWTManager creates a new JWT manager that signs tokens with signingKey.
+// verificationKeys are additional public keys accepted during key rotation (retired or upcoming keys).
+// legacySecret, when non-empty, keeps HS256 tokens issued before the switch valid until they expire;
+// it is never used for signing.
+func NewAsymmetricJWTManager(signingKey *SigningKey, verificationKeys []*VerificationKey, legacySecret string, expiry time.Duration) (*JWTManager, error) {
+	if signingKey == nil {
+		return nil, errors.New("signing key is required")
+	}
+
+	j := &JWTManager{
+		signingKey:       signingKey,
+		verificationKeys: make(map[string]*VerificationKey),
+		expiry:           expiry,
+	}
+	if legacySecret != "" {
+		j.secret = []byte(legacySecret)
+	}
+
+	for _, key := range append([]*VerificationKey{signingKey.Public()}, verificationKeys...) {
+		if _, exists := j.verificationKeys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		j.verificationKeys[key.ID] = key
+		j.keyIDs = append(j.keyIDs, key.ID)
+	}
+
+	return j, nil
+}
+
 // GenerateToken creates a new JWT token for a user.
 func (j *JWTManager) GenerateToken(userID string) (string, error) {
+	return j.GenerateSessionToken(userID, "", 0)
+}
+
+// GenerateSessionToken creates a new JWT token for a user bound to a login session and token version.
+// sessionID is empty for tokens that are not bound to a session.
+func (j *JWTManager) GenerateSessionToken(userID, sessionID string, version int64) (string, error) {
+	return j.GenerateScopedToken(userID, sessionID, version, nil)
+}
+
+// GenerateScopedToken creates a new JWT token like GenerateSessionToken, restricted to scopes.
+// A nil or empty scopes list creates an unrestricted token.
+func (j *JWTManager) GenerateScopedToken(userID, sessionID string, version int64, scopes []string) (string, error) {
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	claims := &Claims{
-		UserID: userID,
+		UserID:       userID,
+		SessionID:    sessionID,
+		TokenVersion: version,
+		Scopes:       scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
+	if j.signingKey != nil {
+		token := jwt.NewWithClaims(j.signingKey.Method, claims)
+		token.Header["kid"] = j.signingKey.ID
+		return token.SignedString(j.signingKey.Key)
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(j.secret)
 }
 
 // ValidateToken parses and validates a JWT token, returning the claims if valid.
 func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return j.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc)
 
 	if err != nil {
 		return nil, err
@@ -57,3 +130,42 @@ func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// JWKS returns the public keys used to verify access tokens.
+// The set is empty when tokens are signed with an HS256 secret.
+func (j *JWTManager) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(j.keyIDs))}
+	for _, kid := range j.keyIDs {
+		set.Keys = append(set.Keys, j.verificationKeys[kid].JWK())
+	}
+	return set
+}
+
+// keyFunc selects the verification key for a token, rejecting algorithms that do not match the key.
+func (j *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if token.Method == jwt.SigningMethodHS256 {
+		if len(j.secret) == 0 && j.signingKey != nil {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return j.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := j.verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %q", jwt.ErrTokenUnverifiable, kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("%w: algorithm %s does not match key %q", jwt.ErrTokenSignatureInvalid, token.Method.Alg(), kid)
+	}
+	return key.Key, nil
+}
+
+// generateTokenID creates a random unique token ID (jti) in hex format.
+func generateTokenID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
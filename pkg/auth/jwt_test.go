@@ -66,6 +66,68 @@ func TestJWTManager_GenerateToken(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, userID, claims.UserID)
 	})
+
+	t.Run("token without session has empty session ID", func(t *testing.T) {
+		token, _ := manager.GenerateToken("test-user-123")
+		claims, err := manager.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Empty(t, claims.SessionID)
+	})
+}
+
+func TestJWTManager_GenerateSessionToken(t *testing.T) {
+	manager := NewJWTManager("testsecret123", 15*time.Minute)
+
+	t.Run("token contains user ID and session ID", func(t *testing.T) {
+		token, err := manager.GenerateSessionToken("test-user-123", "a1b2c3d4e5f67890", 3)
+		require.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "test-user-123", claims.UserID)
+		assert.Equal(t, "a1b2c3d4e5f67890", claims.SessionID)
+		assert.Equal(t, int64(3), claims.TokenVersion)
+	})
+
+	t.Run("each token has a unique ID", func(t *testing.T) {
+		token1, _ := manager.GenerateSessionToken("test-user-123", "", 0)
+		token2, _ := manager.GenerateSessionToken("test-user-123", "", 0)
+
+		claims1, err := manager.ValidateToken(token1)
+		require.NoError(t, err)
+		claims2, err := manager.ValidateToken(token2)
+		require.NoError(t, err)
+
+		assert.Len(t, claims1.ID, 32)
+		assert.NotEqual(t, claims1.ID, claims2.ID)
+	})
+}
+
+func TestJWTManager_GenerateScopedToken(t *testing.T) {
+	manager := NewJWTManager("testsecret123", 15*time.Minute)
+
+	t.Run("token carries scopes", func(t *testing.T) {
+		token, err := manager.GenerateScopedToken("test-user-123", "a1b2c3d4e5f67890", 1, []string{"memo:view", "memo:create"})
+		require.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"memo:view", "memo:create"}, claims.Scopes)
+		assert.Equal(t, "a1b2c3d4e5f67890", claims.SessionID)
+	})
+
+	t.Run("session token has no scopes", func(t *testing.T) {
+		token, err := manager.GenerateSessionToken("test-user-123", "", 0)
+		require.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Empty(t, claims.Scopes)
+	})
 }
 
 func TestJWTManager_ValidateToken(t *testing.T) {
@@ -158,6 +220,139 @@ func TestJWTManager_ValidateToken(t *testing.T) {
 	})
 }
 
+func TestNewAsymmetricJWTManager(t *testing.T) {
+	signingKey, err := ParseSigningKey(generatePrivateKeyPEM(t, "ES256"), "current")
+	require.NoError(t, err)
+
+	t.Run("requires signing key", func(t *testing.T) {
+		_, err := NewAsymmetricJWTManager(nil, nil, "", 15*time.Minute)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects duplicate key IDs", func(t *testing.T) {
+		_, err := NewAsymmetricJWTManager(signingKey, []*VerificationKey{signingKey.Public()}, "", 15*time.Minute)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTManager_Asymmetric(t *testing.T) {
+	for _, alg := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(alg+" round trip with kid header", func(t *testing.T) {
+			signingKey, err := ParseSigningKey(generatePrivateKeyPEM(t, alg), "key-"+alg)
+			require.NoError(t, err)
+			manager, err := NewAsymmetricJWTManager(signingKey, nil, "", 15*time.Minute)
+			require.NoError(t, err)
+
+			token, err := manager.GenerateSessionToken("user123", "family123", 2)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, alg, parsed.Method.Alg())
+			assert.Equal(t, "key-"+alg, parsed.Header["kid"])
+
+			claims, err := manager.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user123", claims.UserID)
+			assert.Equal(t, "family123", claims.SessionID)
+		})
+	}
+
+	oldKey, err := ParseSigningKey(generatePrivateKeyPEM(t, "RS256"), "old")
+	require.NoError(t, err)
+	newKey, err := ParseSigningKey(generatePrivateKeyPEM(t, "EdDSA"), "new")
+	require.NoError(t, err)
+
+	oldManager, err := NewAsymmetricJWTManager(oldKey, nil, "", 15*time.Minute)
+	require.NoError(t, err)
+	rotatedManager, err := NewAsymmetricJWTManager(newKey, []*VerificationKey{oldKey.Public()}, "legacysecret", 15*time.Minute)
+	require.NoError(t, err)
+
+	t.Run("accepts tokens signed by a retired key", func(t *testing.T) {
+		token, _ := oldManager.GenerateToken("user123")
+
+		claims, err := rotatedManager.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user123", claims.UserID)
+	})
+
+	t.Run("rejects tokens signed by an unknown key", func(t *testing.T) {
+		token, _ := rotatedManager.GenerateToken("user123")
+
+		_, err := oldManager.ValidateToken(token)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects tokens with a known kid but a different key", func(t *testing.T) {
+		imposterKey, err := ParseSigningKey(generatePrivateKeyPEM(t, "RS256"), "old")
+		require.NoError(t, err)
+		imposter, err := NewAsymmetricJWTManager(imposterKey, nil, "", 15*time.Minute)
+		require.NoError(t, err)
+		token, _ := imposter.GenerateToken("user123")
+
+		_, err = rotatedManager.ValidateToken(token)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("accepts legacy HS256 tokens when secret is configured", func(t *testing.T) {
+		token, _ := NewJWTManager("legacysecret", 15*time.Minute).GenerateToken("user123")
+
+		claims, err := rotatedManager.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user123", claims.UserID)
+	})
+
+	t.Run("rejects HS256 tokens when no secret is configured", func(t *testing.T) {
+		token, _ := NewJWTManager("", 15*time.Minute).GenerateToken("user123")
+
+		_, err := oldManager.ValidateToken(token)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256 manager rejects asymmetric tokens", func(t *testing.T) {
+		token, _ := oldManager.GenerateToken("user123")
+
+		_, err := NewJWTManager("legacysecret", 15*time.Minute).ValidateToken(token)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTManager_JWKS(t *testing.T) {
+	t.Run("empty for HS256", func(t *testing.T) {
+		set := NewJWTManager("secret", 15*time.Minute).JWKS()
+
+		assert.NotNil(t, set.Keys)
+		assert.Empty(t, set.Keys)
+	})
+
+	t.Run("lists signing key first, then verification keys", func(t *testing.T) {
+		current, err := ParseSigningKey(generatePrivateKeyPEM(t, "ES256"), "current")
+		require.NoError(t, err)
+		retired, err := ParseSigningKey(generatePrivateKeyPEM(t, "RS256"), "retired")
+		require.NoError(t, err)
+		manager, err := NewAsymmetricJWTManager(current, []*VerificationKey{retired.Public()}, "secret", 15*time.Minute)
+		require.NoError(t, err)
+
+		set := manager.JWKS()
+
+		require.Len(t, set.Keys, 2)
+		assert.Equal(t, "current", set.Keys[0].Kid)
+		assert.Equal(t, "ES256", set.Keys[0].Alg)
+		assert.Equal(t, "P-256", set.Keys[0].Crv)
+		assert.Equal(t, "retired", set.Keys[1].Kid)
+		assert.Equal(t, "RS256", set.Keys[1].Alg)
+		assert.Equal(t, "AQAB", set.Keys[1].E)
+	})
+}
+
 func TestJWTManager_TokenManager_Interface(t *testing.T) {
 	t.Run("JWTManager implements TokenManager interface", func(t *testing.T) {
 		var _ TokenManager = (*JWTManager)(nil)
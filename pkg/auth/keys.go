@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is an asymmetric private key used to sign access tokens.
+type SigningKey struct {
+	// ID is published as the "kid" token header and JWKS entry.
+	ID     string
+	Method jwt.SigningMethod
+	Key    crypto.Signer
+}
+
+// VerificationKey is a public key accepted when validating access tokens.
+type VerificationKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	Key    crypto.PublicKey
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC and OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is a JSON Web Key Set, served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadSigningKeyFile reads a PEM-encoded private key (PKCS#8, PKCS#1 or SEC 1).
+// The algorithm is derived from the key type: RSA → RS256, P-256 → ES256, Ed25519 → EdDSA.
+// If kid is empty, the key's RFC 7638 thumbprint is used.
+func LoadSigningKeyFile(path, kid string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	return ParseSigningKey(data, kid)
+}
+
+// ParseSigningKey parses a PEM-encoded private key. See LoadSigningKeyFile.
+func ParseSigningKey(data []byte, kid string) (*SigningKey, error) {
+	key, err := parsePEMKey(data)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("signing key must be a private key")
+	}
+
+	verification, err := newVerificationKey(signer.Public(), kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{ID: verification.ID, Method: verification.Method, Key: signer}, nil
+}
+
+// LoadVerificationKeyFile reads a PEM-encoded public key (or private key, of which
+// only the public half is kept). Used to keep accepting tokens signed by a retired
+// key, or to publish an upcoming key before it starts signing.
+// If kid is empty, the key's RFC 7638 thumbprint is used.
+func LoadVerificationKeyFile(path, kid string) (*VerificationKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification key: %w", err)
+	}
+	return ParseVerificationKey(data, kid)
+}
+
+// ParseVerificationKey parses a PEM-encoded public key. See LoadVerificationKeyFile.
+func ParseVerificationKey(data []byte, kid string) (*VerificationKey, error) {
+	key, err := parsePEMKey(data)
+	if err != nil {
+		return nil, err
+	}
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+	return newVerificationKey(key, kid)
+}
+
+// Public returns the verification half of the signing key.
+func (k *SigningKey) Public() *VerificationKey {
+	return &VerificationKey{ID: k.ID, Method: k.Method, Key: k.Key.Public()}
+}
+
+// JWK returns the key in JSON Web Key format.
+func (k *VerificationKey) JWK() JWK {
+	jwk := publicJWK(k.Key)
+	jwk.Use = "sig"
+	jwk.Alg = k.Method.Alg()
+	jwk.Kid = k.ID
+	return jwk
+}
+
+// parsePEMKey decodes the first PEM block and parses the key it contains.
+func parsePEMKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid key: no PEM block found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return key, nil
+}
+
+// newVerificationKey picks the signing method for a public key and fills in the key ID.
+func newVerificationKey(key crypto.PublicKey, kid string) (*VerificationKey, error) {
+	var method jwt.SigningMethod
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must be at least 2048 bits")
+		}
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 ECDSA keys (ES256) are supported")
+		}
+		method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+
+	if kid == "" {
+		kid = thumbprint(key)
+	}
+
+	return &VerificationKey{ID: kid, Method: method, Key: key}, nil
+}
+
+// publicJWK encodes the key material of a public key. Members are left empty for unknown key types.
+func publicJWK(key crypto.PublicKey) JWK {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		ecdhKey, err := k.ECDH()
+		if err != nil {
+			return JWK{Kty: "EC"}
+		}
+		// Uncompressed point: 0x04 || X || Y
+		point := ecdhKey.Bytes()[1:]
+		size := len(point) / 2
+		return JWK{
+			Kty: "EC",
+			Crv: k.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(point[:size]),
+			Y:   base64.RawURLEncoding.EncodeToString(point[size:]),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k),
+		}
+	}
+	return JWK{}
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint of a public key (base64url SHA-256).
+func thumbprint(key crypto.PublicKey) string {
+	jwk := publicJWK(key)
+
+	// Required members only, in lexicographic order
+	var canonical string
+	switch jwk.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, jwk.Crv, jwk.X, jwk.Y)
+	case "OKP":
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, jwk.Crv, jwk.X)
+	}
+
+	hash := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
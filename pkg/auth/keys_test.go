@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generatePrivateKeyPEM creates a PKCS#8 PEM-encoded private key for tests.
+func generatePrivateKeyPEM(t *testing.T, alg string) []byte {
+	t.Helper()
+
+	var key crypto.Signer
+	var err error
+	switch alg {
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// publicKeyPEM returns the PKIX PEM-encoded public half of a private key PEM.
+func publicKeyPEM(t *testing.T, privatePEM []byte) []byte {
+	t.Helper()
+
+	key, err := ParseSigningKey(privatePEM, "")
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Key.Public())
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestParseSigningKey(t *testing.T) {
+	tests := []struct {
+		alg     string
+		kty     string
+		wantAlg string
+	}{
+		{alg: "RS256", kty: "RSA", wantAlg: "RS256"},
+		{alg: "ES256", kty: "EC", wantAlg: "ES256"},
+		{alg: "EdDSA", kty: "OKP", wantAlg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			key, err := ParseSigningKey(generatePrivateKeyPEM(t, tt.alg), "key-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, "key-1", key.ID)
+			assert.Equal(t, tt.wantAlg, key.Method.Alg())
+
+			jwk := key.Public().JWK()
+			assert.Equal(t, tt.kty, jwk.Kty)
+			assert.Equal(t, "sig", jwk.Use)
+			assert.Equal(t, tt.wantAlg, jwk.Alg)
+			assert.Equal(t, "key-1", jwk.Kid)
+		})
+	}
+
+	t.Run("defaults key ID to thumbprint", func(t *testing.T) {
+		privatePEM := generatePrivateKeyPEM(t, "ES256")
+
+		signing, err := ParseSigningKey(privatePEM, "")
+		require.NoError(t, err)
+		verification, err := ParseVerificationKey(publicKeyPEM(t, privatePEM), "")
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, signing.ID)
+		assert.Equal(t, signing.ID, verification.ID, "thumbprint depends only on the public key")
+	})
+
+	t.Run("rejects public key", func(t *testing.T) {
+		_, err := ParseSigningKey(publicKeyPEM(t, generatePrivateKeyPEM(t, "EdDSA")), "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects short RSA key", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+		_, err = ParseSigningKey(data, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unsupported curve", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		_, err = ParseSigningKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects invalid PEM", func(t *testing.T) {
+		_, err := ParseSigningKey([]byte("not a key"), "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestThumbprint(t *testing.T) {
+	// RFC 7638 section 3.1 example key
+	key, err := ParseVerificationKey([]byte(`-----BEGIN PUBLIC KEY-----
+MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA0vx7agoebGcQSuuPiLJX
+ZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tS
+oc/BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ/2W+5JsGY4Hc5n9yBXArwl93lqt
+7/RN5w6Cf0h4QyQ5v+65YGjQR0/FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0
+zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt+bFTWhAI4vMQFh6WeZu0f
+M4lFd2NcRwr3XPksINHaQ+G/xBniIqbw0Ls1jF44+csFCur+kEgU8awapJzKnqDK
+gwIDAQAB
+-----END PUBLIC KEY-----`), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.ID)
+}
+
+func TestLoadKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	privatePEM := generatePrivateKeyPEM(t, "RS256")
+	privatePath := filepath.Join(dir, "private.pem")
+	publicPath := filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(privatePath, privatePEM, 0o600))
+	require.NoError(t, os.WriteFile(publicPath, publicKeyPEM(t, privatePEM), 0o600))
+
+	t.Run("loads signing key", func(t *testing.T) {
+		key, err := LoadSigningKeyFile(privatePath, "current")
+
+		require.NoError(t, err)
+		assert.Equal(t, "current", key.ID)
+		assert.Equal(t, jwt.SigningMethodRS256, key.Method)
+	})
+
+	t.Run("loads verification key from public or private key", func(t *testing.T) {
+		fromPublic, err := LoadVerificationKeyFile(publicPath, "")
+		require.NoError(t, err)
+		fromPrivate, err := LoadVerificationKeyFile(privatePath, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, fromPublic.JWK(), fromPrivate.JWK())
+	})
+
+	t.Run("returns error for missing file", func(t *testing.T) {
+		_, err := LoadSigningKeyFile(filepath.Join(dir, "missing.pem"), "")
+
+		assert.Error(t, err)
+	})
+}
@@ -40,6 +40,36 @@ func (m *MockTokenManager) EXPECT() *MockTokenManagerMockRecorder {
 	return m.recorder
 }
 
+// GenerateScopedToken mocks base method.
+func (m *MockTokenManager) GenerateScopedToken(userID, sessionID string, version int64, scopes []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateScopedToken", userID, sessionID, version, scopes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateScopedToken indicates an expected call of GenerateScopedToken.
+func (mr *MockTokenManagerMockRecorder) GenerateScopedToken(userID, sessionID, version, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateScopedToken", reflect.TypeOf((*MockTokenManager)(nil).GenerateScopedToken), userID, sessionID, version, scopes)
+}
+
+// GenerateSessionToken mocks base method.
+func (m *MockTokenManager) GenerateSessionToken(userID, sessionID string, version int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSessionToken", userID, sessionID, version)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSessionToken indicates an expected call of GenerateSessionToken.
+func (mr *MockTokenManagerMockRecorder) GenerateSessionToken(userID, sessionID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionToken", reflect.TypeOf((*MockTokenManager)(nil).GenerateSessionToken), userID, sessionID, version)
+}
+
 // GenerateToken mocks base method.
 func (m *MockTokenManager) GenerateToken(userID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gin-sample/pkg/auth (interfaces: OIDCProvider)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_oidc.go -package=mocks gin-sample/pkg/auth OIDCProvider
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	auth "gin-sample/pkg/auth"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOIDCProvider is a mock of OIDCProvider interface.
+type MockOIDCProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCProviderMockRecorder is the mock recorder for MockOIDCProvider.
+type MockOIDCProviderMockRecorder struct {
+	mock *MockOIDCProvider
+}
+
+// NewMockOIDCProvider creates a new mock instance.
+func NewMockOIDCProvider(ctrl *gomock.Controller) *MockOIDCProvider {
+	mock := &MockOIDCProvider{ctrl: ctrl}
+	mock.recorder = &MockOIDCProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCProvider) EXPECT() *MockOIDCProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockOIDCProvider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", state, nonce, codeVerifier)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockOIDCProviderMockRecorder) AuthCodeURL(state, nonce, codeVerifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockOIDCProvider)(nil).AuthCodeURL), state, nonce, codeVerifier)
+}
+
+// DisplayName mocks base method.
+func (m *MockOIDCProvider) DisplayName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisplayName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// DisplayName indicates an expected call of DisplayName.
+func (mr *MockOIDCProviderMockRecorder) DisplayName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayName", reflect.TypeOf((*MockOIDCProvider)(nil).DisplayName))
+}
+
+// Exchange mocks base method.
+func (m *MockOIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*auth.OIDCIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, codeVerifier, nonce)
+	ret0, _ := ret[0].(*auth.OIDCIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockOIDCProviderMockRecorder) Exchange(ctx, code, codeVerifier, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockOIDCProvider)(nil).Exchange), ctx, code, codeVerifier, nonce)
+}
+
+// Name mocks base method.
+func (m *MockOIDCProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockOIDCProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockOIDCProvider)(nil).Name))
+}
+
+// TrustsEmail mocks base method.
+func (m *MockOIDCProvider) TrustsEmail() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrustsEmail")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TrustsEmail indicates an expected call of TrustsEmail.
+func (mr *MockOIDCProviderMockRecorder) TrustsEmail() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrustsEmail", reflect.TypeOf((*MockOIDCProvider)(nil).TrustsEmail))
+}
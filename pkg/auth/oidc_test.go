@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"gin-sample/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOIDCProvider(t *testing.T) (*testutil.MockOIDCProvider, OIDCProvider) {
+	t.Helper()
+
+	idp := testutil.NewMockOIDCProvider(t)
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		Name:         "acme",
+		DisplayName:  "Acme SSO",
+		IssuerURL:    idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://localhost:3000/auth/callback",
+	})
+	require.NoError(t, err)
+
+	return idp, provider
+}
+
+func TestNewOIDCProvider(t *testing.T) {
+	t.Run("discovers provider", func(t *testing.T) {
+		_, provider := setupOIDCProvider(t)
+
+		assert.Equal(t, "acme", provider.Name())
+		assert.Equal(t, "Acme SSO", provider.DisplayName())
+	})
+
+	t.Run("returns error when discovery fails", func(t *testing.T) {
+		idp := testutil.NewMockOIDCProvider(t)
+		idp.Server.Close()
+
+		_, err := NewOIDCProvider(context.Background(), OIDCConfig{Name: "acme", IssuerURL: idp.Issuer()})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	_, provider := setupOIDCProvider(t)
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state123", "nonce123", GenerateCodeVerifier()))
+	require.NoError(t, err)
+
+	query := authURL.Query()
+	assert.Equal(t, "state123", query.Get("state"))
+	assert.Equal(t, "nonce123", query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "http://localhost:3000/auth/callback", query.Get("redirect_uri"))
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns verified identity", func(t *testing.T) {
+		idp, provider := setupOIDCProvider(t)
+		verifier := GenerateCodeVerifier()
+		code, state := idp.Authorize(t, provider.AuthCodeURL("state123", "nonce123", verifier))
+		require.Equal(t, "state123", state)
+
+		identity, err := provider.Exchange(ctx, code, verifier, "nonce123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "oidc-user-1", identity.Subject)
+		assert.Equal(t, "oidc@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+		assert.Equal(t, "OIDC User", identity.Name)
+	})
+
+	t.Run("rejects wrong code verifier", func(t *testing.T) {
+		idp, provider := setupOIDCProvider(t)
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state123", "nonce123", GenerateCodeVerifier()))
+
+		_, err := provider.Exchange(ctx, code, GenerateCodeVerifier(), "nonce123")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects nonce mismatch", func(t *testing.T) {
+		idp, provider := setupOIDCProvider(t)
+		verifier := GenerateCodeVerifier()
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state123", "nonce123", verifier))
+
+		_, err := provider.Exchange(ctx, code, verifier, "other-nonce")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects reused code", func(t *testing.T) {
+		idp, provider := setupOIDCProvider(t)
+		verifier := GenerateCodeVerifier()
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state123", "nonce123", verifier))
+		_, err := provider.Exchange(ctx, code, verifier, "nonce123")
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(ctx, code, verifier, "nonce123")
+
+		assert.Error(t, err)
+	})
+}
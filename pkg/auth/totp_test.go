@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPProvider_GenerateSecret(t *testing.T) {
+	provider := NewTOTPProvider("gin-sample")
+
+	t.Run("generates secret and otpauth uri", func(t *testing.T) {
+		secret, uri, err := provider.GenerateSecret("user@example.com")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, secret)
+		assert.True(t, strings.HasPrefix(uri, "otpauth://totp/gin-sample:user@example.com?"))
+		assert.Contains(t, uri, "secret="+secret)
+		assert.Contains(t, uri, "issuer=gin-sample")
+	})
+
+	t.Run("generates unique secrets", func(t *testing.T) {
+		secret1, _, _ := provider.GenerateSecret("user@example.com")
+		secret2, _, _ := provider.GenerateSecret("user@example.com")
+
+		assert.NotEqual(t, secret1, secret2)
+	})
+}
+
+func TestTOTPProvider_Validate(t *testing.T) {
+	provider := NewTOTPProvider("gin-sample")
+	secret, _, err := provider.GenerateSecret("user@example.com")
+	require.NoError(t, err)
+
+	t.Run("accepts current code", func(t *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		assert.True(t, provider.Validate(code, secret))
+	})
+
+	t.Run("accepts code with surrounding whitespace", func(t *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		assert.True(t, provider.Validate(" "+code+" ", secret))
+	})
+
+	t.Run("rejects code from another period", func(t *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now().Add(-5*time.Minute))
+		require.NoError(t, err)
+
+		assert.False(t, provider.Validate(code, secret))
+	})
+
+	t.Run("rejects malformed code", func(t *testing.T) {
+		assert.False(t, provider.Validate("abc", secret))
+	})
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	t.Run("generates requested number of unique codes", func(t *testing.T) {
+		codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+
+		require.NoError(t, err)
+		assert.Len(t, codes, RecoveryCodeCount)
+
+		seen := make(map[string]bool)
+		for _, code := range codes {
+			assert.Len(t, code, 11) // xxxxx-xxxxx
+			assert.Equal(t, "-", code[5:6])
+			assert.False(t, seen[code])
+			seen[code] = true
+		}
+	})
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	t.Run("ignores case, spaces and dashes", func(t *testing.T) {
+		hash := HashRecoveryCode("abcde-12345")
+
+		assert.Len(t, hash, 64)
+		assert.Equal(t, hash, HashRecoveryCode("ABCDE12345"))
+		assert.Equal(t, hash, HashRecoveryCode(" abcde 12345 "))
+	})
+
+	t.Run("different codes produce different hashes", func(t *testing.T) {
+		assert.NotEqual(t, HashRecoveryCode("abcde-12345"), HashRecoveryCode("abcde-12346"))
+	})
+}
@@ -2,16 +2,50 @@
 package response
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ProblemContentType is the media type of RFC 7807 problem responses.
+const ProblemContentType = "application/problem+json"
+
 // Response is the standard API response format.
 type Response struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool                   `json:"success"`
+	Data    interface{}            `json:"data,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+	Code    string                 `json:"code,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Fields  []FieldError           `json:"fields,omitempty"`
+}
+
+// FieldError describes why a single request field is invalid.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "name" or "members[0].email".
+	Field string `json:"field"`
+	// Rule is the validation rule that failed, e.g. "required" or "slug".
+	Rule string `json:"rule"`
+	// Param is the rule's parameter, e.g. "100" for "max=100".
+	Param string `json:"param,omitempty"`
+	// Message is a human-readable, localized description.
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details object, extended with the error code,
+// details and field errors of the standard error response.
+type Problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Code     string                 `json:"code,omitempty"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+	Errors   []FieldError           `json:"errors,omitempty"`
 }
 
 // Success sends a successful response with data.
@@ -43,6 +77,42 @@ func Error(c *gin.Context, status int, message string) {
 	})
 }
 
+// ErrorWithCode sends an error response with a machine-readable error code.
+func ErrorWithCode(c *gin.Context, status int, code, message string) {
+	c.JSON(status, Response{
+		Success: false,
+		Error:   message,
+		Code:    code,
+	})
+}
+
+// ErrorWithDetails sends an error response with a code, details and field errors.
+func ErrorWithDetails(c *gin.Context, status int, code, message string, details map[string]interface{}, fields []FieldError) {
+	c.JSON(status, Response{
+		Success: false,
+		Error:   message,
+		Code:    code,
+		Details: details,
+		Fields:  fields,
+	})
+}
+
+// ProblemDetails sends an RFC 7807 problem response. Type defaults to "about:blank",
+// Title to the status text and Instance to the request path.
+func ProblemDetails(c *gin.Context, problem Problem) {
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Instance == "" && c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
 // BadRequest sends a 400 error response.
 func BadRequest(c *gin.Context, message string) {
 	Error(c, http.StatusBadRequest, message)
@@ -68,6 +138,21 @@ func Conflict(c *gin.Context, message string) {
 	Error(c, http.StatusConflict, message)
 }
 
+// TooManyRequests sends a 429 error response with a Retry-After header in whole seconds.
+func TooManyRequests(c *gin.Context, message string, retryAfter time.Duration) {
+	SetRetryAfter(c, retryAfter)
+	Error(c, http.StatusTooManyRequests, message)
+}
+
+// SetRetryAfter sets the Retry-After header in whole seconds, rounding up to at least one.
+func SetRetryAfter(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+}
+
 // InternalError sends a 500 error response.
 func InternalError(c *gin.Context) {
 	Error(c, http.StatusInternalServerError, "internal server error")
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -82,6 +83,58 @@ func TestError(t *testing.T) {
 	assert.Equal(t, "I'm a teapot", resp.Error)
 }
 
+func TestErrorWithCode(t *testing.T) {
+	c, w := setupTestContext()
+
+	ErrorWithCode(c, http.StatusForbidden, "quota_exceeded", "quota exceeded")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var resp Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "quota exceeded", resp.Error)
+	assert.Equal(t, "quota_exceeded", resp.Code)
+}
+
+func TestErrorWithDetails(t *testing.T) {
+	c, w := setupTestContext()
+
+	ErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "request validation failed",
+		map[string]interface{}{"limit": float64(10)},
+		[]FieldError{{Field: "email", Rule: "required", Message: "email is required"}})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "validation_failed", resp.Code)
+	assert.Equal(t, float64(10), resp.Details["limit"])
+	assert.Equal(t, []FieldError{{Field: "email", Rule: "required", Message: "email is required"}}, resp.Fields)
+}
+
+func TestProblemDetails(t *testing.T) {
+	c, w := setupTestContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/teams/123", nil)
+
+	ProblemDetails(c, Problem{Status: http.StatusNotFound, Detail: "team not found", Code: "team_not_found"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	var problem Problem
+	err := json.Unmarshal(w.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, "team not found", problem.Detail)
+	assert.Equal(t, "/api/v1/teams/123", problem.Instance)
+	assert.Equal(t, "team_not_found", problem.Code)
+}
+
 func TestBadRequest(t *testing.T) {
 	c, w := setupTestContext()
 
@@ -152,6 +205,35 @@ func TestConflict(t *testing.T) {
 	assert.Equal(t, "resource already exists", resp.Error)
 }
 
+func TestTooManyRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		expected   string
+	}{
+		{"whole seconds", 30 * time.Second, "30"},
+		{"rounds up partial seconds", 1500 * time.Millisecond, "2"},
+		{"at least one second", 0, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := setupTestContext()
+
+			TooManyRequests(c, "too many requests", tt.retryAfter)
+
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Retry-After"))
+
+			var resp Response
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			assert.NoError(t, err)
+			assert.False(t, resp.Success)
+			assert.Equal(t, "too many requests", resp.Error)
+		})
+	}
+}
+
 func TestInternalError(t *testing.T) {
 	c, w := setupTestContext()
 
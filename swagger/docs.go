@@ -18,30 +18,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user and return access token and refresh token",
-                "consumes": [
-                    "application/json"
+        "/auth/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List the authenticated user's API keys. Keys themselves are never returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "User login",
-                "parameters": [
-                    {
-                        "description": "User credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
-                        }
-                    }
+                    "api-keys"
                 ],
+                "summary": "List API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -54,19 +45,13 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AuthResponse"
+                                            "$ref": "#/definitions/models.APIKeyListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -80,16 +65,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Invalidate the refresh token",
+                "description": "Create a personal API key for scripts and integrations. The key is returned only once.\nScopes: memos:read, memos:write. Set teamId to restrict the key to one team's memos.",
                 "consumes": [
                     "application/json"
                 ],
@@ -97,23 +80,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "api-keys"
                 ],
-                "summary": "User logout",
+                "summary": "Create API key",
                 "parameters": [
                     {
-                        "description": "Refresh token to invalidate",
+                        "description": "Key name, scopes, team and expiry",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LogoutRequest"
+                            "$ref": "#/definitions/models.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CreateAPIKeyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -127,6 +125,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "403": {
+                        "description": "Not a member of the team",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "API key limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -136,31 +146,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout-all": {
-            "post": {
+        "/auth/api-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Invalidate all refresh tokens for the authenticated user",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Delete one of the authenticated user's API keys. It stops working immediately.",
                 "tags": [
-                    "auth"
+                    "api-keys"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout from all devices",
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -170,9 +198,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/auth/login": {
             "post": {
-                "description": "Exchange a refresh token for a new access token",
+                "description": "Authenticate user and return access token and refresh token.\nIf the user has MFA enabled, the response is an MFA challenge (models.MFAChallengeResponse)\nwith mfaRequired=true and an mfaToken to exchange at /auth/mfa/verify.",
                 "consumes": [
                     "application/json"
                 ],
@@ -182,15 +210,15 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Refresh access token",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "description": "Refresh token",
+                        "description": "User credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RefreshRequest"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
@@ -206,7 +234,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.RefreshResponse"
+                                            "$ref": "#/definitions/models.AuthResponse"
                                         }
                                     }
                                 }
@@ -225,6 +253,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "429": {
+                        "description": "Too many requests or account locked; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -234,9 +268,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/auth/logout": {
             "post": {
-                "description": "Create a new user account with email, password, and name",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invalidate the refresh token and the access token used for this request",
                 "consumes": [
                     "application/json"
                 ],
@@ -246,36 +285,21 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Register a new user",
+                "summary": "User logout",
                 "parameters": [
                     {
-                        "description": "User registration details",
+                        "description": "Refresh token to invalidate",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateUserRequest"
+                            "$ref": "#/definitions/models.LogoutRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.AuthResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -283,8 +307,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -298,24 +322,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations": {
-            "get": {
+        "/auth/logout-all": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List all pending invitations for the authenticated user",
-                "consumes": [
+                "description": "Invalidate all refresh tokens, access tokens and API keys for the authenticated user",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout from all devices",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the MFA enrollment status of the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invitations"
+                    "mfa"
                 ],
-                "summary": "List my invitations",
+                "summary": "Get MFA status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -328,7 +383,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.MyInvitationListResponse"
+                                            "$ref": "#/definitions/models.MFAStatusResponse"
                                         }
                                     }
                                 }
@@ -341,6 +396,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -350,14 +411,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations/{id}/accept": {
+        "/auth/mfa/activate": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Accept an invitation to join a team",
+                "description": "Confirm enrollment with a TOTP code and enable MFA. Returns recovery codes, which are shown only once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -365,16 +426,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invitations"
+                    "mfa"
                 ],
-                "summary": "Accept invitation",
+                "summary": "Activate MFA",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Invitation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -389,7 +452,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AcceptInvitationResponse"
+                                            "$ref": "#/definitions/models.MFARecoveryCodesResponse"
                                         }
                                     }
                                 }
@@ -408,14 +471,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "MFA is already enabled",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -429,14 +486,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations/{id}/decline": {
+        "/auth/mfa/disable": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Decline an invitation to join a team",
+                "description": "Disable MFA. Requires the account password and a current TOTP or recovery code.",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,24 +501,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invitations"
+                    "mfa"
                 ],
-                "summary": "Decline invitation",
+                "summary": "Disable MFA",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Invitation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Password and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFADisableRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -475,14 +531,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too many invalid MFA codes",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -496,38 +546,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams": {
-            "get": {
+        "/auth/mfa/enroll": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of teams the authenticated user belongs to",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Generate a TOTP secret and otpauth URI for an authenticator app. MFA is enabled after activation.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "teams"
-                ],
-                "summary": "List user's teams",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default: 10, max: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "mfa"
                 ],
+                "summary": "Start MFA enrollment",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -540,7 +573,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.TeamListResponse"
+                                            "$ref": "#/definitions/models.MFAEnrollResponse"
                                         }
                                     }
                                 }
@@ -553,6 +586,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "MFA is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -560,14 +599,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/mfa/recovery-codes": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new team. The authenticated user becomes the owner.",
+                "description": "Replace all recovery codes after verifying a current TOTP or recovery code",
                 "consumes": [
                     "application/json"
                 ],
@@ -575,23 +616,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teams"
+                    "mfa"
                 ],
-                "summary": "Create a new team",
+                "summary": "Regenerate recovery codes",
                 "parameters": [
                     {
-                        "description": "Team details",
-                        "name": "body",
+                        "description": "TOTP or recovery code",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateTeamRequest"
+                            "$ref": "#/definitions/models.MFACodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -601,7 +642,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Team"
+                                            "$ref": "#/definitions/models.MFARecoveryCodesResponse"
                                         }
                                     }
                                 }
@@ -620,14 +661,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    "429": {
+                        "description": "Too many invalid MFA codes",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -641,14 +676,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve details of a specific team",
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Exchange the MFA token from login and a TOTP or recovery code for access and refresh tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -656,16 +686,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teams"
+                    "auth"
                 ],
-                "summary": "Get team details",
+                "summary": "Complete MFA login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
+                        "description": "MFA token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAVerifyRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -680,7 +712,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Team"
+                                            "$ref": "#/definitions/models.AuthResponse"
                                         }
                                     }
                                 }
@@ -699,11 +731,90 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "429": {
+                        "description": "Too many requests; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/oidc": {
+            "get": {
+                "description": "List the OpenID Connect identity providers available for login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List login providers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OIDCProviderListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/authorize": {
+            "get": {
+                "description": "Start an authorization code login with PKCE. Send the user to authorizationUrl;\nthe provider redirects back with a code and state to post to /auth/oidc/{provider}/callback.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Start provider login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OIDCAuthorizeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -718,14 +829,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update team details. Requires owner or admin role.",
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "post": {
+                "description": "Exchange the authorization code and state from the provider redirect for access and refresh tokens.\nThe provider account is linked to the user with the same verified email if the provider is configured\nwith trust_email, or a new user is created.\nIf the user has MFA enabled, the response is an MFA challenge (models.MFAChallengeResponse).",
                 "consumes": [
                     "application/json"
                 ],
@@ -733,24 +841,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teams"
+                    "auth"
                 ],
-                "summary": "Update team",
+                "summary": "Complete provider login",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
+                        "description": "Provider name",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Team update details",
-                        "name": "body",
+                        "description": "Authorization code and state",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateTeamRequest"
+                            "$ref": "#/definitions/models.OIDCCallbackRequest"
                         }
                     }
                 ],
@@ -766,7 +874,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Team"
+                                            "$ref": "#/definitions/models.AuthResponse"
                                         }
                                     }
                                 }
@@ -798,7 +906,13 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "An account with this email exists and the provider is not trusted to link it (code: oidc_account_exists), or the provider account is linked to another user (code: identity_already_linked)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests; see Retry-After",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -810,14 +924,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/password": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a team and all its data. Requires owner role.",
+                "description": "Change the authenticated user's password. All sessions are signed out, including the current one, and all API keys are revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -825,24 +941,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teams"
+                    "auth"
                 ],
-                "summary": "Delete team",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -851,19 +966,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Invalid session or wrong current password",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -877,14 +980,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}/invitations": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "List all pending invitations for a team. Requires owner or admin role.",
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -892,16 +990,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-invitations"
+                    "auth"
                 ],
-                "summary": "List team invitations",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -916,7 +1016,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.InvitationListResponse"
+                                            "$ref": "#/definitions/models.RefreshResponse"
                                         }
                                     }
                                 }
@@ -935,8 +1035,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "429": {
+                        "description": "Too many requests; see Retry-After",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -948,14 +1048,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Invite a user to join a team. Requires owner or admin role.",
+                "description": "Create a new user account with email, password, and name",
                 "consumes": [
                     "application/json"
                 ],
@@ -963,24 +1060,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-invitations"
+                    "auth"
                 ],
-                "summary": "Create team invitation",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Invitation details",
-                        "name": "body",
-                        "in": "body",
+                        "description": "User registration details",
+                        "name": "request",
+                        "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateInvitationRequest"
+                            "$ref": "#/definitions/models.CreateUserRequest"
                         }
                     }
                 ],
@@ -996,7 +1086,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.TeamInvitation"
+                                            "$ref": "#/definitions/models.AuthResponse"
                                         }
                                     }
                                 }
@@ -1009,20 +1099,63 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "429": {
+                        "description": "Too many requests; see Retry-After",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the authenticated user's active login sessions (devices), most recently used first.\nThe session of the current access token is marked with current=true.\nRequires refresh token rotation to be enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List active sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SessionListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1032,56 +1165,43 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
+                    },
+                    "501": {
+                        "description": "Refresh token rotation is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
                     }
                 }
             }
         },
-        "/teams/{teamId}/invitations/{id}": {
+        "/auth/sessions/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel a pending invitation. Requires owner or admin role.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Sign out one device by revoking its session. Its refresh and access tokens stop working immediately.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team-invitations"
+                    "auth"
                 ],
-                "summary": "Cancel team invitation",
+                "summary": "Revoke a session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Invitation ID",
+                        "description": "Session ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -1089,12 +1209,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1106,18 +1220,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
+                    },
+                    "501": {
+                        "description": "Refresh token rotation is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
                     }
                 }
             }
         },
-        "/teams/{teamId}/leave": {
+        "/auth/tokens": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Leave a team. Owner cannot leave without transferring ownership.",
+                "description": "Create an access token that can only perform the given actions (e.g. memo:view), for third-party integrations.\nIt expires like a normal access token, is revoked when the current session signs out, and cannot be refreshed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1125,23 +1245,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-members"
+                    "auth"
                 ],
-                "summary": "Leave team",
+                "summary": "Create restricted access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
+                        "description": "Actions the token may perform",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScopedTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScopedTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1157,13 +1291,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Restricted tokens cannot create tokens",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1177,14 +1305,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}/members": {
+        "/invitations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all members of a team with their details",
+                "description": "List all pending invitations for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1192,18 +1320,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-members"
-                ],
-                "summary": "List team members",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "invitations"
                 ],
+                "summary": "List my invitations",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1216,31 +1335,19 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.TeamMemberListResponse"
+                                            "$ref": "#/definitions/models.MyInvitationListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1250,14 +1357,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}/members/{userId}": {
-            "delete": {
+        "/invitations/{id}/accept": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a member from the team. Requires owner or admin role.",
+                "description": "Accept an invitation to join a team",
                 "consumes": [
                     "application/json"
                 ],
@@ -1265,21 +1372,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-members"
+                    "invitations"
                 ],
-                "summary": "Remove team member",
+                "summary": "Accept invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID to remove",
-                        "name": "userId",
+                        "description": "Invitation ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1288,7 +1388,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AcceptInvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1315,6 +1427,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "Invitation is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1324,14 +1442,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}/members/{userId}/role": {
-            "put": {
+        "/invitations/{id}/decline": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a member's role in the team. Requires owner or admin role.",
+                "description": "Decline an invitation to join a team",
                 "consumes": [
                     "application/json"
                 ],
@@ -1339,32 +1457,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-members"
+                    "invitations"
                 ],
-                "summary": "Update member role",
+                "summary": "Decline invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Invitation ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New role",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateRoleRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -1398,6 +1500,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "Invitation is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1407,14 +1515,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}/transfer": {
-            "post": {
+        "/teams": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Transfer team ownership to another member. Requires owner role.",
+                "description": "Retrieve paginated list of teams the authenticated user belongs to",
                 "consumes": [
                     "application/json"
                 ],
@@ -1424,36 +1532,38 @@ const docTemplate = `{
                 "tags": [
                     "teams"
                 ],
-                "summary": "Transfer team ownership",
+                "summary": "List user's teams",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "New owner details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TransferOwnershipRequest"
-                        }
+                        "type": "integer",
+                        "description": "Items per page (default: 10, max: 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TeamListResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -1462,18 +1572,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1481,16 +1579,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/teams/{teamId}/voice-memos": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of a team's voice memos, sorted by newest first",
+                "description": "Create a new team. The authenticated user becomes the owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1498,33 +1594,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-voice-memos"
+                    "teams"
                 ],
-                "summary": "List team voice memos",
+                "summary": "Create a new team",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team ID",
-                        "name": "teamId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default: 10, max: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Team details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTeamRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -1534,7 +1620,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.VoiceMemoListResponse"
+                                            "$ref": "#/definitions/models.Team"
                                         }
                                     }
                                 }
@@ -1559,6 +1645,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1566,14 +1658,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/teams/{teamId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new team voice memo and get a pre-signed URL for audio upload",
+                "description": "Retrieve details of a specific team",
                 "consumes": [
                     "application/json"
                 ],
@@ -1581,9 +1675,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-voice-memos"
+                    "teams"
                 ],
-                "summary": "Create team voice memo",
+                "summary": "Get team details",
                 "parameters": [
                     {
                         "type": "string",
@@ -1591,20 +1685,11 @@ const docTemplate = `{
                         "name": "teamId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Voice memo details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateVoiceMemoRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1614,7 +1699,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.CreateVoiceMemoResponse"
+                                            "$ref": "#/definitions/models.Team"
                                         }
                                     }
                                 }
@@ -1639,6 +1724,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1646,16 +1737,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/teams/{teamId}/voice-memos/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific voice memo from a team",
+                "description": "Update team details. Requires owner or admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1663,9 +1752,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team-voice-memos"
+                    "teams"
                 ],
-                "summary": "Get team voice memo",
+                "summary": "Update team",
                 "parameters": [
                     {
                         "type": "string",
@@ -1675,11 +1764,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Team update details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTeamRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1694,7 +1785,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.VoiceMemo"
+                                            "$ref": "#/definitions/models.Team"
                                         }
                                     }
                                 }
@@ -1725,6 +1816,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1739,11 +1836,17 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a voice memo from a team. Idempotent - returns 204 even if already deleted.",
+                "description": "Soft delete a team and all its data. Requires owner role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "team-voice-memos"
+                    "teams"
                 ],
-                "summary": "Delete team voice memo",
+                "summary": "Delete team",
                 "parameters": [
                     {
                         "type": "string",
@@ -1751,18 +1854,14 @@ const docTemplate = `{
                         "name": "teamId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1797,21 +1896,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamId}/voice-memos/{id}/confirm-upload": {
-            "post": {
+        "/teams/{teamId}/audit-log": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Confirm that audio has been uploaded to S3 and trigger transcription for a team memo",
+                "description": "List administrative actions taken in a team, newest first. Each entry records the actor, the action taken (e.g. member:remove or invitation:cancel), the target, its before/after state and the request ID. Requires owner or admin role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team-voice-memos"
+                    "teams"
                 ],
-                "summary": "Confirm team audio upload",
+                "summary": "List team audit log",
                 "parameters": [
                     {
                         "type": "string",
@@ -1822,17 +1921,77 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only entries by this user",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries with this action (e.g. member:remove)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "team",
+                            "member",
+                            "invitation",
+                            "memo",
+                            "role"
+                        ],
+                        "type": "string",
+                        "description": "Only entries on this target type",
+                        "name": "targetType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries on this target",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AuditLogListResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1853,48 +2012,33 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "409": {
-                        "description": "Invalid status transition",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
-                    },
-                    "503": {
-                        "description": "Transcription queue full",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
                     }
                 }
             }
         },
-        "/teams/{teamId}/voice-memos/{id}/retry-transcription": {
-            "post": {
+        "/teams/{teamId}/invitations": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retry transcription for a failed team voice memo",
+                "description": "List all pending invitations for a team. Requires owner or admin role.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team-voice-memos"
+                    "team-invitations"
                 ],
-                "summary": "Retry team transcription",
+                "summary": "List team invitations",
                 "parameters": [
                     {
                         "type": "string",
@@ -1902,20 +2046,25 @@ const docTemplate = `{
                         "name": "teamId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.InvitationListResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1936,73 +2085,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "409": {
-                        "description": "Invalid status - memo is not in failed state",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "503": {
-                        "description": "Transcription queue full",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of all users",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "List all users",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.User"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2010,16 +2092,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single user by their ID",
+                "description": "Invite a user to join a team. Requires owner or admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2027,21 +2107,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "team-invitations"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Create team invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Team ID",
+                        "name": "teamId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Invitation details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateInvitationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -2051,7 +2140,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.User"
+                                            "$ref": "#/definitions/models.TeamInvitation"
                                         }
                                     }
                                 }
@@ -2064,8 +2153,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2077,14 +2178,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/teams/{teamId}/invitations/history": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a user's email and/or name",
+                "description": "List all invitations for a team in any status, newest first. Requires owner or admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2092,25 +2195,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "team-invitations"
                 ],
-                "summary": "Update user",
+                "summary": "List team invitation history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Team ID",
+                        "name": "teamId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Fields to update",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2125,7 +2231,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.User"
+                                            "$ref": "#/definitions/models.InvitationHistoryResponse"
                                         }
                                     }
                                 }
@@ -2138,14 +2244,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2157,14 +2263,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/teams/{teamId}/invitations/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a user from the system",
+                "description": "Cancel a pending invitation. Requires owner or admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2172,13 +2280,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "team-invitations"
                 ],
-                "summary": "Delete user",
+                "summary": "Cancel team invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2197,12 +2312,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "Invitation is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2212,14 +2345,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/voice-memos": {
-            "get": {
+        "/teams/{teamId}/invitations/{id}/extend": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of the authenticated user's voice memos, sorted by newest first",
+                "description": "Push back the expiry of a pending or expired invitation. Requires owner or admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2227,21 +2360,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "voice-memos"
+                    "team-invitations"
                 ],
-                "summary": "List user's voice memos",
+                "summary": "Extend team invitation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Items per page (default: 10, max: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Extension details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExtendInvitationRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2256,19 +2400,43 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.VoiceMemoListResponse"
+                                            "$ref": "#/definitions/models.TeamInvitation"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Invitation is no longer pending, or the email has another pending invitation",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2276,14 +2444,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/teams/{teamId}/invitations/{id}/resend": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new private voice memo and get a pre-signed URL for audio upload",
+                "description": "Resend a pending or expired invitation and reset its expiry. Requires owner or admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2291,23 +2461,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "voice-memos"
+                    "team-invitations"
                 ],
-                "summary": "Create voice memo",
+                "summary": "Resend team invitation",
                 "parameters": [
                     {
-                        "description": "Voice memo details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateVoiceMemoRequest"
-                        }
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -2317,7 +2492,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.CreateVoiceMemoResponse"
+                                            "$ref": "#/definitions/models.TeamInvitation"
                                         }
                                     }
                                 }
@@ -2336,6 +2511,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Invitation is no longer pending, or the email has another pending invitation",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2345,30 +2538,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/voice-memos/{id}": {
-            "delete": {
+        "/teams/{teamId}/leave": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark a voice memo as deleted. User can only delete their own memos. Idempotent - returns 204 even if already deleted.",
+                "description": "Leave a team. Owner cannot leave without transferring ownership.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "voice-memos"
+                    "team-members"
                 ],
-                "summary": "Soft delete voice memo",
+                "summary": "Leave team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
+                        "description": "Team ID",
+                        "name": "teamId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2403,26 +2605,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/voice-memos/{id}/confirm-upload": {
-            "post": {
+        "/teams/{teamId}/members": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Confirm that audio has been uploaded to S3 and trigger transcription",
+                "description": "Retrieve all members of a team with their details. Members who can change member roles also see each member's MFA status.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "voice-memos"
+                    "team-members"
                 ],
-                "summary": "Confirm audio upload",
+                "summary": "List team members",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
+                        "description": "Team ID",
+                        "name": "teamId",
                         "in": "path",
                         "required": true
                     }
@@ -2431,7 +2636,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TeamMemberListResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2452,26 +2669,82 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from the team. Requires owner or admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-members"
+                ],
+                "summary": "Remove team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID to remove",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "409": {
-                        "description": "Invalid status transition",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "503": {
-                        "description": "Transcription queue full",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2479,28 +2752,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/voice-memos/{id}/retry-transcription": {
-            "post": {
+        "/teams/{teamId}/members/{userId}/role": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retry transcription for a failed voice memo",
+                "description": "Update a member's role in the team. Requires owner or admin role.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "voice-memos"
+                    "team-members"
                 ],
-                "summary": "Retry transcription",
+                "summary": "Update member role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Voice Memo ID",
-                        "name": "id",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2534,42 +2826,1748 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "409": {
-                        "description": "Invalid status - memo is not in failed state",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
-                    },
-                    "503": {
-                        "description": "Transcription queue full",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AcceptInvitationResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "invitation accepted"
-                },
-                "teamId": {
+        },
+        "/teams/{teamId}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the built-in roles (owner, admin, member, contributor, viewer) and the team's custom roles with their actions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-roles"
+                ],
+                "summary": "List team roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TeamRoleListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Define a custom role with a chosen set of actions. Requires owner role.\nOwner-only actions (team:delete, team:transfer, role:manage) cannot be granted.\nRoles that create memos also need memo:update_own to confirm uploads and retry transcriptions of their memos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-roles"
+                ],
+                "summary": "Create custom team role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role name and actions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTeamRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TeamRole"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Role name taken or role limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/roles/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the actions of a custom role. Members with the role are affected immediately. Requires owner role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-roles"
+                ],
+                "summary": "Update custom team role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New actions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTeamRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TeamRole"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a custom role. Fails if the role is assigned to a member or a pending invitation. Requires owner role.",
+                "tags": [
+                    "team-roles"
+                ],
+                "summary": "Delete custom team role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Role is in use",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transfer team ownership to another member. Requires owner role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Transfer team ownership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get usage and quota limits for a team's voice memos. A limit of 0 means unlimited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Get team usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UsageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/voice-memos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated list of a team's voice memos, sorted by newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-voice-memos"
+                ],
+                "summary": "List team voice memos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 10, max: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.VoiceMemoListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new team voice memo and get a pre-signed URL for audio upload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-voice-memos"
+                ],
+                "summary": "Create team voice memo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voice memo details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateVoiceMemoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CreateVoiceMemoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Memo or storage quota exceeded (code: memo_quota_exceeded, storage_quota_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/voice-memos/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific voice memo from a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-voice-memos"
+                ],
+                "summary": "Get team voice memo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.VoiceMemo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a voice memo from a team. Idempotent - returns 204 even if already deleted.\nMembers and contributors can delete only memos they created; admins and owners can delete any team memo.",
+                "tags": [
+                    "team-voice-memos"
+                ],
+                "summary": "Delete team voice memo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/voice-memos/{id}/confirm-upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm that audio has been uploaded to S3 and trigger transcription for a team memo.\nMembers and contributors can confirm only memos they created.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-voice-memos"
+                ],
+                "summary": "Confirm team audio upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or uploaded audio size differs from the declared size (code: audio_size_mismatch)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition, or audio not uploaded (code: audio_not_uploaded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transcription queue full",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/voice-memos/{id}/retry-transcription": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retry transcription for a failed team voice memo.\nMembers and contributors can retry only memos they created.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team-voice-memos"
+                ],
+                "summary": "Retry team transcription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status - memo is not in failed state",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transcription queue full",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get usage and quota limits for the authenticated user's private voice memos. A limit of 0 means unlimited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Get my usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UsageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single user by their ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a user's email and/or name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/voice-memos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated list of the authenticated user's voice memos, sorted by newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voice-memos"
+                ],
+                "summary": "List user's voice memos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 10, max: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.VoiceMemoListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new private voice memo and get a pre-signed URL for audio upload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voice-memos"
+                ],
+                "summary": "Create voice memo",
+                "parameters": [
+                    {
+                        "description": "Voice memo details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateVoiceMemoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CreateVoiceMemoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Memo or storage quota exceeded (code: memo_quota_exceeded, storage_quota_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/voice-memos/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a voice memo as deleted. User can only delete their own memos. Idempotent - returns 204 even if already deleted.",
+                "tags": [
+                    "voice-memos"
+                ],
+                "summary": "Soft delete voice memo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/voice-memos/{id}/confirm-upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm that audio has been uploaded to S3 and trigger transcription",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voice-memos"
+                ],
+                "summary": "Confirm audio upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or uploaded audio size differs from the declared size (code: audio_size_mismatch)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition, or audio not uploaded (code: audio_not_uploaded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transcription queue full",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/voice-memos/{id}/retry-transcription": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retry transcription for a failed voice memo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voice-memos"
+                ],
+                "summary": "Retry transcription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voice Memo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, or transcription quota exceeded (code: transcription_quota_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status - memo is not in failed state",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transcription queue full",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2024-04-15T09:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "example": "2024-01-20T14:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backup script"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "gmk_1a2b3c4d"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "memos:read"
+                    ]
+                },
+                "teamId": {
+                    "description": "Restricts the key to one team's memos",
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439012"
+                }
+            }
+        },
+        "models.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIKey"
+                    }
+                }
+            }
+        },
+        "models.AcceptInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "invitation accepted"
+                },
+                "teamId": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439012"
+                }
+            }
+        },
+        "models.AuditLogEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "member:update_role"
+                },
+                "actorId": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439013"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "4bf92f3577b34da6a3ce929d0e0e4736"
+                },
+                "targetId": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439014"
+                },
+                "targetType": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "teamId": {
                     "type": "string",
                     "example": "507f1f77bcf86cd799439012"
                 }
             }
         },
+        "models.AuditLogListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditLogEntry"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
         "models.AuthResponse": {
             "type": "object",
             "properties": {
@@ -2590,6 +4588,71 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string",
+                    "example": "secret123"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "newsecret456"
+                }
+            }
+        },
+        "models.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expiresInDays": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Backup script"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "memos:read"
+                    ]
+                },
+                "teamId": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439012"
+                }
+            }
+        },
+        "models.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "$ref": "#/definitions/models.APIKey"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "gmk_1a2b3c4d_9f8e7d6c..."
+                }
+            }
+        },
         "models.CreateInvitationRequest": {
             "type": "object",
             "required": [
@@ -2603,10 +4666,7 @@ const docTemplate = `{
                 },
                 "role": {
                     "type": "string",
-                    "enum": [
-                        "admin",
-                        "member"
-                    ],
+                    "maxLength": 32,
                     "example": "member"
                 }
             }
@@ -2641,6 +4701,32 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CreateTeamRoleRequest": {
+            "type": "object",
+            "required": [
+                "actions",
+                "name"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "team:view",
+                        "memo:view"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 2,
+                    "example": "reviewer"
+                }
+            }
+        },
         "models.CreateUserRequest": {
             "type": "object",
             "required": [
@@ -2701,82 +4787,276 @@ const docTemplate = `{
                 },
                 "tags": {
                     "type": "array",
-                    "maxItems": 10,
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "meeting"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1,
+                    "example": "Meeting Notes"
+                }
+            }
+        },
+        "models.CreateVoiceMemoResponse": {
+            "type": "object",
+            "properties": {
+                "memo": {
+                    "$ref": "#/definitions/models.VoiceMemo"
+                },
+                "uploadUrl": {
+                    "type": "string",
+                    "example": "https://s3.amazonaws.com/bucket/voice-memos/...?X-Amz-Algorithm=..."
+                }
+            }
+        },
+        "models.ExtendInvitationRequest": {
+            "type": "object",
+            "required": [
+                "days"
+            ],
+            "properties": {
+                "days": {
+                    "type": "integer",
+                    "maximum": 30,
+                    "minimum": 1,
+                    "example": 7
+                }
+            }
+        },
+        "models.ExternalIdentity": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "linkedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "google"
+                }
+            }
+        },
+        "models.InvitationHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamInvitation"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.InvitationListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamInvitation"
+                    }
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "models.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "rf_8a7b3c9d..."
+                }
+            }
+        },
+        "models.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "models.MFADisableRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "models.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauthUri": {
+                    "type": "string",
+                    "example": "otpauth://totp/gin-sample:user@example.com?secret=JBSWY3DPEHPK3PXP\u0026issuer=gin-sample"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "models.MFARecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "work",
-                        "meeting"
+                        "a1b2c-3d4e5",
+                        "f6a7b-8c9d0"
                     ]
+                }
+            }
+        },
+        "models.MFAStatusResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "title": {
+                "enabledAt": {
                     "type": "string",
-                    "maxLength": 200,
-                    "minLength": 1,
-                    "example": "Meeting Notes"
+                    "example": "2024-01-15T09:30:00Z"
+                },
+                "recoveryCodesRemaining": {
+                    "type": "integer",
+                    "example": 8
                 }
             }
         },
-        "models.CreateVoiceMemoResponse": {
+        "models.MFAVerifyRequest": {
             "type": "object",
+            "required": [
+                "code",
+                "mfaToken"
+            ],
             "properties": {
-                "memo": {
-                    "$ref": "#/definitions/models.VoiceMemo"
+                "code": {
+                    "type": "string",
+                    "example": "123456"
                 },
-                "uploadUrl": {
+                "mfaToken": {
                     "type": "string",
-                    "example": "https://s3.amazonaws.com/bucket/voice-memos/...?X-Amz-Algorithm=..."
+                    "example": "mfa_3f2a9c..."
                 }
             }
         },
-        "models.InvitationListResponse": {
+        "models.MyInvitationListResponse": {
             "type": "object",
             "properties": {
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TeamInvitation"
+                        "$ref": "#/definitions/models.TeamInvitationWithDetails"
                     }
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.OIDCAuthorizeResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "authorizationUrl": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "https://accounts.google.com/o/oauth2/v2/auth?client_id=..."
                 },
-                "password": {
+                "expiresIn": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "state": {
                     "type": "string",
-                    "example": "secret123"
+                    "example": "oidc_7c1e4b..."
                 }
             }
         },
-        "models.LogoutRequest": {
+        "models.OIDCCallbackRequest": {
             "type": "object",
             "required": [
-                "refreshToken"
+                "code",
+                "state"
             ],
             "properties": {
-                "refreshToken": {
+                "code": {
                     "type": "string",
-                    "example": "rf_8a7b3c9d..."
+                    "example": "4/0AX4XfWh..."
+                },
+                "state": {
+                    "type": "string",
+                    "example": "oidc_7c1e4b..."
                 }
             }
         },
-        "models.MyInvitationListResponse": {
+        "models.OIDCProviderInfo": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string",
+                    "example": "Google"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "google"
+                }
+            }
+        },
+        "models.OIDCProviderListResponse": {
             "type": "object",
             "properties": {
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TeamInvitationWithDetails"
+                        "$ref": "#/definitions/models.OIDCProviderInfo"
                     }
                 }
             }
@@ -2831,6 +5111,109 @@ const docTemplate = `{
                 }
             }
         },
+        "models.RoleDefinition": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "team:view",
+                        "memo:view",
+                        "memo:create",
+                        "memo:update_own",
+                        "memo:delete_own"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "contributor"
+                }
+            }
+        },
+        "models.ScopedTokenRequest": {
+            "type": "object",
+            "required": [
+                "scopes"
+            ],
+            "properties": {
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "memo:view"
+                    ]
+                }
+            }
+        },
+        "models.ScopedTokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                },
+                "expiresIn": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "memo:view"
+                    ]
+                }
+            }
+        },
+        "models.Session": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f67890"
+                },
+                "ipAddress": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"
+                }
+            }
+        },
+        "models.SessionListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Session"
+                    }
+                }
+            }
+        },
         "models.Team": {
             "type": "object",
             "properties": {
@@ -2902,10 +5285,32 @@ const docTemplate = `{
                     "type": "string",
                     "example": "507f1f77bcf86cd799439013"
                 },
+                "lastSentAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                },
+                "resendCount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "resolvedAt": {
+                    "type": "string",
+                    "example": "2024-01-16T09:30:00Z"
+                },
+                "resolvedBy": {
+                    "description": "User who accepted, declined or cancelled",
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439014"
+                },
                 "role": {
                     "type": "string",
                     "example": "member"
                 },
+                "status": {
+                    "description": "Documents created before status tracking have no status and are pending",
+                    "type": "string",
+                    "example": "pending"
+                },
                 "teamId": {
                     "type": "string",
                     "example": "507f1f77bcf86cd799439012"
@@ -2992,6 +5397,58 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TeamRole": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "team:view",
+                        "memo:view"
+                    ]
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "reviewer"
+                },
+                "teamId": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439012"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                }
+            }
+        },
+        "models.TeamRoleListResponse": {
+            "type": "object",
+            "properties": {
+                "builtIn": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RoleDefinition"
+                    }
+                },
+                "custom": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamRole"
+                    }
+                }
+            }
+        },
         "models.TeamSummary": {
             "type": "object",
             "properties": {
@@ -3029,10 +5486,7 @@ const docTemplate = `{
             "properties": {
                 "role": {
                     "type": "string",
-                    "enum": [
-                        "admin",
-                        "member"
-                    ],
+                    "maxLength": 32,
                     "example": "admin"
                 }
             }
@@ -3063,6 +5517,26 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateTeamRoleRequest": {
+            "type": "object",
+            "required": [
+                "actions"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "team:view",
+                        "memo:view",
+                        "memo:create"
+                    ]
+                }
+            }
+        },
         "models.UpdateUserRequest": {
             "type": "object",
             "properties": {
@@ -3077,6 +5551,41 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UsageMetric": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "audioBytes": {
+                    "$ref": "#/definitions/models.UsageMetric"
+                },
+                "memos": {
+                    "$ref": "#/definitions/models.UsageMetric"
+                },
+                "periodEnd": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "periodStart": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "transcriptionMinutes": {
+                    "$ref": "#/definitions/models.UsageMetric"
+                }
+            }
+        },
         "models.User": {
             "type": "object",
             "properties": {
@@ -3092,6 +5601,22 @@ const docTemplate = `{
                     "type": "string",
                     "example": "507f1f77bcf86cd799439011"
                 },
+                "identities": {
+                    "description": "Identities are the linked OpenID Connect provider accounts.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalIdentity"
+                    }
+                },
+                "mfaEnabled": {
+                    "description": "MFA settings. Secrets and recovery code hashes are never included in JSON responses.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfaEnabledAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:30:00Z"
+                },
                 "name": {
                     "type": "string",
                     "example": "John Doe"
@@ -3113,6 +5638,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "507f1f77bcf86cd799439013"
                 },
+                "mfaEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "name": {
                     "type": "string",
                     "example": "John Doe"
@@ -3229,13 +5758,47 @@ const docTemplate = `{
                 "StatusFailed"
             ]
         },
+        "response.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field is the JSON path of the field, e.g. \"name\" or \"members[0].email\".",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message is a human-readable, localized description.",
+                    "type": "string"
+                },
+                "param": {
+                    "description": "Param is the rule's parameter, e.g. \"100\" for \"max=100\".",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Rule is the validation rule that failed, e.g. \"required\" or \"slug\".",
+                    "type": "string"
+                }
+            }
+        },
         "response.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {},
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
                 "error": {
                     "type": "string"
                 },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FieldError"
+                    }
+                },
                 "success": {
                     "type": "boolean"
                 }
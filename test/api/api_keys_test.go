@@ -0,0 +1,120 @@
+//go:build api
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"gin-sample/internal/models"
+	"gin-sample/pkg/auth"
+	"gin-sample/test/api/testserver"
+	"gin-sample/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createAPIKey creates an API key and returns the response data.
+func createAPIKey(t *testing.T, token string, req models.CreateAPIKeyRequest) map[string]interface{} {
+	t.Helper()
+
+	w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/auth/api-keys", token, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return testutil.ParseAPIResponse(t, w).Data
+}
+
+// TestAPIKeys tests the /api/v1/auth/api-keys endpoints and using keys on memo routes.
+func TestAPIKeys(t *testing.T) {
+	testServer.CleanupBetweenTests(t)
+
+	authHelper := testserver.NewAuthHelper(testServer)
+	teamHelper := testserver.NewTeamHelper(testServer)
+
+	t.Run("success - create, list, use and revoke a key", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+		_, token := authHelper.CreateAuthenticatedUser(t, "Key User", "keys@example.com", "password123")
+
+		created := createAPIKey(t, token, models.CreateAPIKeyRequest{
+			Name:   "Backup script",
+			Scopes: []string{auth.ScopeMemosRead},
+		})
+		key := created["key"].(string)
+		apiKey := created["apiKey"].(map[string]interface{})
+		assert.True(t, auth.IsAPIKey(key))
+		assert.NotContains(t, apiKey, "keyHash")
+
+		// Listed without the key itself
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/auth/api-keys", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		items := testutil.ParseAPIResponse(t, w).Data["items"].([]interface{})
+		require.Len(t, items, 1)
+		assert.Equal(t, apiKey["prefix"], items[0].(map[string]interface{})["prefix"])
+		assert.NotContains(t, w.Body.String(), key)
+
+		// Key can read memos and records its last use
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/voice-memos", key, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/auth/api-keys", token, nil)
+		items = testutil.ParseAPIResponse(t, w).Data["items"].([]interface{})
+		assert.NotEmpty(t, items[0].(map[string]interface{})["lastUsedAt"])
+
+		// Key cannot write without memos:write
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/voice-memos", key, models.CreateVoiceMemoRequest{
+			Title: "From script", FileSize: 1000, AudioFormat: "mp3",
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// Key cannot be used outside memo routes
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/auth/api-keys", key, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// Revoked key stops working
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, "/api/v1/auth/api-keys/"+apiKey["id"].(string), token, nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/voice-memos", key, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("success - team-scoped key only works for its team", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+		_, token := authHelper.CreateAuthenticatedUser(t, "Team Key User", "teamkeys@example.com", "password123")
+		teamID := testserver.GetIDFromResponse(t, teamHelper.CreateTeam(t, token, "Key Team"))
+
+		created := createAPIKey(t, token, models.CreateAPIKeyRequest{
+			Name:   "Team sync",
+			Scopes: []string{auth.ScopeMemosRead},
+			TeamID: teamID,
+		})
+		key := created["key"].(string)
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/teams/"+teamID+"/voice-memos", key, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/voice-memos", key, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("error - team-scoped key for a team the user is not in", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		teamID := testserver.GetIDFromResponse(t, teamHelper.CreateTeam(t, ownerToken, "Private Team"))
+		_, token := authHelper.CreateAuthenticatedUser(t, "Outsider", "outsider@example.com", "password123")
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/auth/api-keys", token, models.CreateAPIKeyRequest{
+			Name:   "Sneaky",
+			Scopes: []string{auth.ScopeMemosRead},
+			TeamID: teamID,
+		})
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/voice-memos", "gmk_00000000_unknown", nil)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -495,3 +495,41 @@ func TestAuthTokenValidity(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+// TestScopedTokens tests the POST /api/v1/auth/tokens endpoint and using restricted tokens.
+func TestScopedTokens(t *testing.T) {
+	testServer.CleanupBetweenTests(t)
+
+	authHelper := testserver.NewAuthHelper(testServer)
+	teamHelper := testserver.NewTeamHelper(testServer)
+	_, accessToken := authHelper.CreateAuthenticatedUser(t, "Scoped User", "scoped@example.com", "password123")
+	teamID := testserver.GetIDFromResponse(t, teamHelper.CreateTeam(t, accessToken, "Scoped Team"))
+
+	w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/auth/tokens", accessToken,
+		models.ScopedTokenRequest{Scopes: []string{"memo:view"}})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	scopedToken := testutil.ParseAPIResponse(t, w).Data["accessToken"].(string)
+
+	t.Run("success - allowed actions work", func(t *testing.T) {
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/teams/"+teamID+"/voice-memos", scopedToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/voice-memos", scopedToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("error - actions outside the scopes are forbidden even for the team owner", func(t *testing.T) {
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPut, "/api/v1/teams/"+teamID, scopedToken,
+			map[string]string{"name": "Renamed"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("error - restricted token cannot use account endpoints or create tokens", func(t *testing.T) {
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/auth/tokens", scopedToken,
+			models.ScopedTokenRequest{Scopes: []string{"team:delete"}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/users", scopedToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
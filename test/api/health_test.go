@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"testing"
 
+	"gin-sample/internal/health"
 	"gin-sample/test/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,42 @@ func TestHealthCheck(t *testing.T) {
 		assert.Equal(t, "ok", resp["status"], "status should be 'ok'")
 	})
 }
+
+func TestReadiness(t *testing.T) {
+	t.Run("reports each dependency", func(t *testing.T) {
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodGet, "/readyz", nil)
+
+		require.Equal(t, http.StatusOK, w.Code, "readiness should return 200 when dependencies are up")
+
+		var report health.Report
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+
+		assert.Equal(t, health.StatusOK, report.Status)
+		for _, component := range []string{"shutdown", "mongodb", "redis", "s3", "transcription_queue"} {
+			assert.Equal(t, health.StatusOK, report.Components[component].Status, component)
+		}
+	})
+}
+
+func TestLiveness(t *testing.T) {
+	t.Run("returns ok status", func(t *testing.T) {
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodGet, "/livez", nil)
+
+		require.Equal(t, http.StatusOK, w.Code, "liveness should return 200")
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	t.Run("returns empty key set for HS256 tokens", func(t *testing.T) {
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodGet, "/.well-known/jwks.json", nil)
+
+		require.Equal(t, http.StatusOK, w.Code, "jwks should return 200")
+
+		var resp map[string][]map[string]string
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err, "response should be valid JSON")
+
+		assert.Contains(t, resp, "keys")
+		assert.Empty(t, resp["keys"], "no public keys are published for HS256")
+	})
+}
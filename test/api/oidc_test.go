@@ -0,0 +1,128 @@
+//go:build api
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"gin-sample/internal/models"
+	"gin-sample/test/api/testserver"
+	"gin-sample/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oidcLogin runs the full provider login flow and returns the callback response.
+func oidcLogin(t *testing.T) *testutil.APIResponse {
+	t.Helper()
+
+	w := testutil.MakeRequest(t, testServer.Router, http.MethodGet,
+		"/api/v1/auth/oidc/"+testserver.TestOIDCProviderName+"/authorize", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	authorize := testutil.ParseAPIResponse(t, w)
+
+	authURL, ok := authorize.Data["authorizationUrl"].(string)
+	require.True(t, ok, "authorizationUrl should be a string")
+	code, state := testServer.OIDCProvider.Authorize(t, authURL)
+	assert.Equal(t, authorize.Data["state"], state, "provider should echo the state")
+
+	w = testutil.MakeRequest(t, testServer.Router, http.MethodPost,
+		"/api/v1/auth/oidc/"+testserver.TestOIDCProviderName+"/callback",
+		models.OIDCCallbackRequest{Code: code, State: state})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	return testutil.ParseAPIResponse(t, w)
+}
+
+// TestOIDCLogin tests the /api/v1/auth/oidc endpoints against a mock provider.
+func TestOIDCLogin(t *testing.T) {
+	testServer.CleanupBetweenTests(t)
+
+	t.Run("lists configured providers", func(t *testing.T) {
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodGet, "/api/v1/auth/oidc", nil)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := testutil.ParseAPIResponse(t, w)
+		items, ok := resp.Data["items"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, items, 1)
+		assert.Equal(t, testserver.TestOIDCProviderName, items[0].(map[string]interface{})["name"])
+	})
+
+	t.Run("success - creates user on first login and reuses it afterwards", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+		testServer.OIDCProvider.SetUser(testutil.OIDCUser{
+			Subject: "new-user", Email: "new@example.com", EmailVerified: true, Name: "New User",
+		})
+
+		first := oidcLogin(t)
+		assert.NotEmpty(t, first.Data["accessToken"])
+		assert.NotEmpty(t, first.Data["refreshToken"])
+		user := first.Data["user"].(map[string]interface{})
+		assert.Equal(t, "new@example.com", user["email"])
+		assert.Equal(t, "New User", user["name"])
+
+		second := oidcLogin(t)
+		assert.Equal(t, user["id"], second.Data["user"].(map[string]interface{})["id"])
+
+		// Access token works on protected routes
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet,
+			"/api/v1/users/"+user["id"].(string), first.Data["accessToken"].(string), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("success - links existing user with the same verified email", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodPost, "/api/v1/auth/register", models.CreateUserRequest{
+			Name: "Existing User", Email: "existing@example.com", Password: "password123",
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		registered := testutil.ParseAPIResponse(t, w).Data["user"].(map[string]interface{})
+
+		testServer.OIDCProvider.SetUser(testutil.OIDCUser{
+			Subject: "existing-user", Email: "existing@example.com", EmailVerified: true,
+		})
+
+		resp := oidcLogin(t)
+
+		user := resp.Data["user"].(map[string]interface{})
+		assert.Equal(t, registered["id"], user["id"])
+		identities, ok := user["identities"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, identities, 1)
+		assert.Equal(t, testserver.TestOIDCProviderName, identities[0].(map[string]interface{})["provider"])
+	})
+
+	t.Run("error - unverified email is not linked", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+		testServer.OIDCProvider.SetUser(testutil.OIDCUser{
+			Subject: "unverified-user", Email: "unverified@example.com", EmailVerified: false,
+		})
+
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodGet,
+			"/api/v1/auth/oidc/"+testserver.TestOIDCProviderName+"/authorize", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		code, state := testServer.OIDCProvider.Authorize(t, testutil.ParseAPIResponse(t, w).Data["authorizationUrl"].(string))
+
+		w = testutil.MakeRequest(t, testServer.Router, http.MethodPost,
+			"/api/v1/auth/oidc/"+testserver.TestOIDCProviderName+"/callback",
+			models.OIDCCallbackRequest{Code: code, State: state})
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("error - unknown state", func(t *testing.T) {
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodPost,
+			"/api/v1/auth/oidc/"+testserver.TestOIDCProviderName+"/callback",
+			models.OIDCCallbackRequest{Code: "code", State: "oidc_unknown"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("error - unknown provider", func(t *testing.T) {
+		w := testutil.MakeRequest(t, testServer.Router, http.MethodGet, "/api/v1/auth/oidc/unknown/authorize", nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
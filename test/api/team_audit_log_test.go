@@ -0,0 +1,83 @@
+//go:build api
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"gin-sample/internal/models"
+	"gin-sample/test/api/testserver"
+	"gin-sample/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListTeamAuditLog tests the GET /api/v1/teams/:teamId/audit-log endpoint.
+func TestListTeamAuditLog(t *testing.T) {
+	testServer.CleanupBetweenTests(t)
+
+	authHelper := testserver.NewAuthHelper(testServer)
+	teamHelper := testserver.NewTeamHelper(testServer)
+
+	t.Run("success - owner sees role change", func(t *testing.T) {
+		ownerData, token := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		memberData, _ := authHelper.CreateAuthenticatedUser(t, "Member", "member@example.com", "password123")
+
+		teamData := teamHelper.CreateTeam(t, token, "Audited Team")
+		teamID := testserver.GetIDFromResponse(t, teamData)
+		teamOID := testserver.GetObjectIDFromResponse(t, teamData)
+		memberID := testserver.GetIDFromResponse(t, memberData)
+
+		teamHelper.SeedTeamMember(t, &models.TeamMember{
+			TeamID:   teamOID,
+			UserID:   testserver.GetObjectIDFromResponse(t, memberData),
+			Role:     models.RoleMember,
+			JoinedAt: time.Now(),
+		})
+
+		req := models.UpdateRoleRequest{Role: models.RoleAdmin}
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPut, "/api/v1/teams/"+teamID+"/members/"+memberID+"/role", token, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/teams/"+teamID+"/audit-log?targetType=member", token, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		resp := testutil.ParseAPIResponse(t, w)
+		items, ok := resp.Data["items"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, items, 1)
+
+		entry := items[0].(map[string]interface{})
+		assert.Equal(t, "member:update_role", entry["action"])
+		assert.Equal(t, testserver.GetIDFromResponse(t, ownerData), entry["actorId"])
+		assert.Equal(t, memberID, entry["targetId"])
+		assert.NotEmpty(t, entry["requestId"])
+		assert.Equal(t, models.RoleMember, entry["before"].(map[string]interface{})["role"])
+		assert.Equal(t, models.RoleAdmin, entry["after"].(map[string]interface{})["role"])
+	})
+
+	t.Run("error - member cannot view audit log", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		memberData, memberToken := authHelper.CreateAuthenticatedUser(t, "Member", "member@example.com", "password123")
+
+		teamData := teamHelper.CreateTeam(t, ownerToken, "Private Audit Team")
+		teamID := testserver.GetIDFromResponse(t, teamData)
+
+		teamHelper.SeedTeamMember(t, &models.TeamMember{
+			TeamID:   testserver.GetObjectIDFromResponse(t, teamData),
+			UserID:   testserver.GetObjectIDFromResponse(t, memberData),
+			Role:     models.RoleMember,
+			JoinedAt: time.Now(),
+		})
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/teams/"+teamID+"/audit-log", memberToken, nil)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
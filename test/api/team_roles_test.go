@@ -0,0 +1,175 @@
+//go:build api
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"gin-sample/internal/models"
+	"gin-sample/test/api/testserver"
+	"gin-sample/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTeamRoles tests the /api/v1/teams/:teamId/roles endpoints.
+func TestTeamRoles(t *testing.T) {
+	testServer.CleanupBetweenTests(t)
+
+	authHelper := testserver.NewAuthHelper(testServer)
+	teamHelper := testserver.NewTeamHelper(testServer)
+
+	t.Run("success - owner creates, assigns, updates and deletes a custom role", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		memberData, memberToken := authHelper.CreateAuthenticatedUser(t, "Member", "member@example.com", "password123")
+
+		teamData := teamHelper.CreateTeam(t, ownerToken, "Roles Team")
+		teamID := testserver.GetIDFromResponse(t, teamData)
+		teamHelper.SeedTeamMember(t, &models.TeamMember{
+			TeamID:   testserver.GetObjectIDFromResponse(t, teamData),
+			UserID:   testserver.GetObjectIDFromResponse(t, memberData),
+			Role:     models.RoleMember,
+			JoinedAt: time.Now(),
+		})
+		memberID := testserver.GetIDFromResponse(t, memberData)
+		rolesURL := "/api/v1/teams/" + teamID + "/roles"
+
+		// Create a read-only custom role
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, rolesURL, ownerToken, models.CreateTeamRoleRequest{
+			Name:    "reviewer",
+			Actions: []string{"team:view", "memo:view"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		// Assign it to the member
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPut, "/api/v1/teams/"+teamID+"/members/"+memberID+"/role", ownerToken, models.UpdateRoleRequest{Role: "reviewer"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// The member can view but not create memos
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/teams/"+teamID+"/voice-memos", memberToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		memoReq := models.CreateVoiceMemoRequest{Title: "Memo", Duration: 60, FileSize: 1024, AudioFormat: "mp3"}
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos", memberToken, memoReq)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// Granting memo:create takes effect immediately
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPut, rolesURL+"/reviewer", ownerToken, models.UpdateTeamRoleRequest{
+			Actions: []string{"team:view", "memo:view", "memo:create"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos", memberToken, memoReq)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		// The role cannot be deleted while assigned
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, rolesURL+"/reviewer", ownerToken, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPut, "/api/v1/teams/"+teamID+"/members/"+memberID+"/role", ownerToken, models.UpdateRoleRequest{Role: models.RoleMember})
+		require.Equal(t, http.StatusOK, w.Code)
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, rolesURL+"/reviewer", ownerToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("success - lists built-in and custom roles", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		teamID := testserver.GetIDFromResponse(t, teamHelper.CreateTeam(t, ownerToken, "Roles Team"))
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodGet, "/api/v1/teams/"+teamID+"/roles", ownerToken, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		resp := testutil.ParseAPIResponse(t, w)
+		builtIn, _ := resp.Data["builtIn"].([]interface{})
+		assert.Len(t, builtIn, 5)
+		custom, _ := resp.Data["custom"].([]interface{})
+		assert.Empty(t, custom)
+	})
+
+	t.Run("error - admin cannot manage roles", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		adminData, adminToken := authHelper.CreateAuthenticatedUser(t, "Admin", "admin@example.com", "password123")
+
+		teamData := teamHelper.CreateTeam(t, ownerToken, "Roles Team")
+		teamHelper.SeedTeamMember(t, &models.TeamMember{
+			TeamID:   testserver.GetObjectIDFromResponse(t, teamData),
+			UserID:   testserver.GetObjectIDFromResponse(t, adminData),
+			Role:     models.RoleAdmin,
+			JoinedAt: time.Now(),
+		})
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+testserver.GetIDFromResponse(t, teamData)+"/roles", adminToken, models.CreateTeamRoleRequest{
+			Name:    "reviewer",
+			Actions: []string{"team:view"},
+		})
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("error - unknown role cannot be assigned", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+		memberData, _ := authHelper.CreateAuthenticatedUser(t, "Member", "member@example.com", "password123")
+
+		teamData := teamHelper.CreateTeam(t, ownerToken, "Roles Team")
+		teamID := testserver.GetIDFromResponse(t, teamData)
+		teamHelper.SeedTeamMember(t, &models.TeamMember{
+			TeamID:   testserver.GetObjectIDFromResponse(t, teamData),
+			UserID:   testserver.GetObjectIDFromResponse(t, memberData),
+			Role:     models.RoleMember,
+			JoinedAt: time.Now(),
+		})
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPut, "/api/v1/teams/"+teamID+"/members/"+testserver.GetIDFromResponse(t, memberData)+"/role", ownerToken, models.UpdateRoleRequest{Role: "reviewer"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestContributorDeletesOwnMemos tests that the contributor preset can only delete its own team memos.
+func TestContributorDeletesOwnMemos(t *testing.T) {
+	testServer.CleanupBetweenTests(t)
+
+	authHelper := testserver.NewAuthHelper(testServer)
+	teamHelper := testserver.NewTeamHelper(testServer)
+
+	_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner@example.com", "password123")
+	contributorData, contributorToken := authHelper.CreateAuthenticatedUser(t, "Contributor", "contributor@example.com", "password123")
+
+	teamData := teamHelper.CreateTeam(t, ownerToken, "Contributor Team")
+	teamID := testserver.GetIDFromResponse(t, teamData)
+	teamHelper.SeedTeamMember(t, &models.TeamMember{
+		TeamID:   testserver.GetObjectIDFromResponse(t, teamData),
+		UserID:   testserver.GetObjectIDFromResponse(t, contributorData),
+		Role:     models.RoleContributor,
+		JoinedAt: time.Now(),
+	})
+	memosURL := "/api/v1/teams/" + teamID + "/voice-memos"
+	memoReq := models.CreateVoiceMemoRequest{Title: "Memo", Duration: 60, FileSize: 1024, AudioFormat: "mp3"}
+
+	createMemo := func(token string) string {
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, memosURL, token, memoReq)
+		require.Equal(t, http.StatusCreated, w.Code)
+		resp := testutil.ParseAPIResponse(t, w)
+		memo, ok := resp.Data["memo"].(map[string]interface{})
+		require.True(t, ok)
+		return memo["id"].(string)
+	}
+
+	ownerMemoID := createMemo(ownerToken)
+	contributorMemoID := createMemo(contributorToken)
+
+	w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, memosURL+"/"+ownerMemoID, contributorToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, memosURL+"/"+contributorMemoID, contributorToken, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -612,7 +612,7 @@ func TestDeleteTeamVoiceMemo(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
 
-	t.Run("success - member can delete team memo", func(t *testing.T) {
+	t.Run("success - member can delete only their own team memo", func(t *testing.T) {
 		testServer.CleanupBetweenTests(t)
 
 		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner3@example.com", "password123")
@@ -647,9 +647,20 @@ func TestDeleteTeamVoiceMemo(t *testing.T) {
 		memoID, ok := memo["id"].(string)
 		require.True(t, ok, "memo id should be a string")
 
-		// Member deletes the memo (allowed per authorization rules)
+		// Member cannot delete a memo created by someone else
 		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, "/api/v1/teams/"+teamID+"/voice-memos/"+memoID, memberToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// Member creates and deletes their own memo
+		createW = testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos", memberToken, createReq)
+		require.Equal(t, http.StatusCreated, createW.Code)
+		createResp = testutil.ParseAPIResponse(t, createW)
+		memo, ok = createResp.Data["memo"].(map[string]interface{})
+		require.True(t, ok, "memo should be map[string]interface{}")
+		ownMemoID, ok := memo["id"].(string)
+		require.True(t, ok, "memo id should be a string")
 
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, "/api/v1/teams/"+teamID+"/voice-memos/"+ownMemoID, memberToken, nil)
 		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
 
@@ -871,7 +882,7 @@ func TestConfirmTeamUpload(t *testing.T) {
 		require.True(t, ok, "uploadUrl should be a string")
 
 		// Upload test audio
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, 512000)
 
 		// Confirm upload
 		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos/"+memoID+"/confirm-upload", ownerToken, nil)
@@ -908,7 +919,7 @@ func TestConfirmTeamUpload(t *testing.T) {
 		uploadURL, ok := createResp.Data["uploadUrl"].(string)
 		require.True(t, ok, "uploadUrl should be a string")
 
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, 512000)
 
 		// First confirm
 		w1 := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos/"+memoID+"/confirm-upload", ownerToken, nil)
@@ -950,6 +961,43 @@ func TestConfirmTeamUpload(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 
+	t.Run("error - member cannot confirm another member's upload", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, ownerToken := authHelper.CreateAuthenticatedUser(t, "Owner", "owner5@example.com", "password123")
+		memberData, memberToken := authHelper.CreateAuthenticatedUser(t, "Member", "member@example.com", "password123")
+
+		teamData := teamHelper.CreateTeam(t, ownerToken, "Member Confirm Team")
+		teamID := testserver.GetIDFromResponse(t, teamData)
+		teamHelper.SeedTeamMember(t, &models.TeamMember{
+			TeamID:   testserver.GetObjectIDFromResponse(t, teamData),
+			UserID:   testserver.GetObjectIDFromResponse(t, memberData),
+			Role:     models.RoleMember,
+			JoinedAt: time.Now(),
+		})
+
+		// Owner creates a memo
+		createReq := models.CreateVoiceMemoRequest{
+			Title:       "Owner Memo",
+			Duration:    60,
+			FileSize:    512000,
+			AudioFormat: "mp3",
+		}
+		createW := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos", ownerToken, createReq)
+		require.Equal(t, http.StatusCreated, createW.Code)
+
+		createResp := testutil.ParseAPIResponse(t, createW)
+		memo, ok := createResp.Data["memo"].(map[string]interface{})
+		require.True(t, ok, "memo should be map[string]interface{}")
+		memoID, ok := memo["id"].(string)
+		require.True(t, ok, "memo id should be a string")
+
+		// Member tries to confirm the owner's memo
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos/"+memoID+"/confirm-upload", memberToken, nil)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
 	t.Run("error - memo not found", func(t *testing.T) {
 		testServer.CleanupBetweenTests(t)
 
@@ -1007,7 +1055,7 @@ func TestRetryTeamTranscription(t *testing.T) {
 		require.True(t, ok, "uploadUrl should be a string")
 
 		// Upload test audio
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, 512000)
 
 		// Set memo status to failed directly via repository
 		memoOID, err := primitive.ObjectIDFromHex(memoID)
@@ -1148,7 +1196,7 @@ func TestTeamVoiceMemoWorkflow(t *testing.T) {
 		assert.Equal(t, string(models.StatusPendingUpload), memo["status"])
 
 		// 2. Upload audio
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, 512000)
 
 		// 3. Confirm upload
 		confirmW := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/voice-memos/"+memoID+"/confirm-upload", ownerToken, nil)
@@ -3,6 +3,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -430,7 +431,13 @@ func TestDeleteTeam(t *testing.T) {
 		teamData := teamHelper.CreateTeam(t, token, "Delete Me")
 		teamID := testserver.GetIDFromResponse(t, teamData)
 
-		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, "/api/v1/teams/"+teamID, token, nil)
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/teams/"+teamID+"/roles", token, models.CreateTeamRoleRequest{
+			Name:    "reviewer",
+			Actions: []string{"team:view", "memo:view"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w = testutil.MakeAuthRequest(t, testServer.Router, http.MethodDelete, "/api/v1/teams/"+teamID, token, nil)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -443,6 +450,11 @@ func TestDeleteTeam(t *testing.T) {
 		listResp := testutil.ParseAPIResponse(t, w2)
 		items, _ := listResp.Data["items"].([]interface{})
 		assert.Empty(t, items)
+
+		// Verify the team's custom roles are deleted
+		roles, err := testServer.TeamRoleRepo.FindByTeamID(context.Background(), testserver.GetObjectIDFromResponse(t, teamData))
+		require.NoError(t, err)
+		assert.Empty(t, roles)
 	})
 
 	t.Run("error - non-owner cannot delete team", func(t *testing.T) {
@@ -144,6 +144,9 @@ func NewVoiceMemoHelper(server *TestServer) *VoiceMemoHelper {
 	return &VoiceMemoHelper{server: server}
 }
 
+// VoiceMemoFileSize is the file size CreateVoiceMemo declares; uploads must match it.
+const VoiceMemoFileSize = 1024 * 1024
+
 // CreateVoiceMemo creates a voice memo and returns the response data.
 func (vh *VoiceMemoHelper) CreateVoiceMemo(t *testing.T, token, title string, duration int) map[string]interface{} {
 	t.Helper()
@@ -151,7 +154,7 @@ func (vh *VoiceMemoHelper) CreateVoiceMemo(t *testing.T, token, title string, du
 	req := models.CreateVoiceMemoRequest{
 		Title:       title,
 		Duration:    duration,
-		FileSize:    1024 * 1024, // 1MB default file size
+		FileSize:    VoiceMemoFileSize,
 		AudioFormat: "mp3",
 	}
 
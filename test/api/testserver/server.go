@@ -10,6 +10,7 @@ import (
 	"gin-sample/internal/authz"
 	"gin-sample/internal/cache"
 	"gin-sample/internal/handler"
+	"gin-sample/internal/health"
 	"gin-sample/internal/queue"
 	"gin-sample/internal/repository"
 	"gin-sample/internal/router"
@@ -18,6 +19,7 @@ import (
 	"gin-sample/internal/transcription"
 	"gin-sample/pkg/auth"
 	"gin-sample/test/api/testdb"
+	"gin-sample/test/testutil"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,6 +33,8 @@ const (
 	TestRefreshTokenExpiry = 7 * 24 * time.Hour
 	// TestDBName is the database name used in tests.
 	TestDBName = "test_api"
+	// TestOIDCProviderName is the name of the mock OIDC login provider.
+	TestOIDCProviderName = "mock"
 )
 
 // TestServer holds all dependencies for API integration tests.
@@ -50,6 +54,7 @@ type TestServer struct {
 	TeamRepo           repository.TeamRepository
 	TeamMemberRepo     repository.TeamMemberRepository
 	TeamInvitationRepo repository.TeamInvitationRepository
+	TeamRoleRepo       repository.TeamRoleRepository
 
 	// Services (for direct service access in tests)
 	AuthService           service.AuthServicer
@@ -61,6 +66,8 @@ type TestServer struct {
 
 	// Auth
 	JWTManager *auth.JWTManager
+	// OIDCProvider is the mock identity provider registered as TestOIDCProviderName.
+	OIDCProvider *testutil.MockOIDCProvider
 
 	// Queue
 	TranscriptionQueue     *queue.MemoryQueue
@@ -105,6 +112,32 @@ func New(ctx context.Context) (*TestServer, error) {
 
 	// JWT Manager
 	jwtManager := auth.NewJWTManager(TestAccessTokenSecret, TestAccessTokenExpiry)
+	tokenRevocation := cache.NewTokenRevocationStore(redisCache.Client())
+
+	// OIDC login against a local mock provider
+	mockIDP, err := testutil.StartMockOIDCProvider()
+	if err != nil {
+		_ = mongoDB.Cleanup(ctx)
+		_ = redisContainer.Cleanup(ctx)
+		_ = minioContainer.Cleanup(ctx)
+		return nil, err
+	}
+	oidcProvider, err := auth.NewOIDCProvider(ctx, auth.OIDCConfig{
+		Name:         TestOIDCProviderName,
+		IssuerURL:    mockIDP.Issuer(),
+		ClientID:     mockIDP.ClientID,
+		ClientSecret: mockIDP.ClientSecret,
+		RedirectURL:  "http://localhost:3000/auth/callback",
+		// Trusted so logins link to existing accounts, like a company SSO would be
+		TrustEmail: true,
+	})
+	if err != nil {
+		mockIDP.Close()
+		_ = mongoDB.Cleanup(ctx)
+		_ = redisContainer.Cleanup(ctx)
+		_ = minioContainer.Cleanup(ctx)
+		return nil, err
+	}
 
 	// Repository layer
 	userRepo := repository.NewUserRepository(mongoDB.Database)
@@ -113,9 +146,13 @@ func New(ctx context.Context) (*TestServer, error) {
 	teamRepo := repository.NewTeamRepository(mongoDB.Database)
 	teamMemberRepo := repository.NewTeamMemberRepository(mongoDB.Database)
 	teamInvitationRepo := repository.NewTeamInvitationRepository(mongoDB.Database)
+	apiKeyRepo := repository.NewAPIKeyRepository(mongoDB.Database)
+	teamRoleRepo := repository.NewTeamRoleRepository(mongoDB.Database)
 
 	// Authorization
-	authorizer := authz.NewLocalAuthorizer(teamMemberRepo)
+	memberFinder := authz.NewCachedMemberFinder(teamMemberRepo, redisCache, time.Minute)
+	authorizer := authz.NewLocalAuthorizer(memberFinder, teamRoleRepo)
+	relationshipAuthorizer := authz.NewRelationshipAuthorizer(repository.NewRelationshipRepository(mongoDB.Database), teamRoleRepo)
 
 	// Transcription queue and processor
 	transcriptionQueue := queue.NewMemoryQueue(100)
@@ -125,28 +162,45 @@ func New(ctx context.Context) (*TestServer, error) {
 	authService := service.NewAuthService(service.AuthServiceConfig{
 		UserRepo:         userRepo,
 		RefreshTokenRepo: refreshTokenRepo,
+		APIKeyRepo:       apiKeyRepo,
 		Cache:            redisCache,
 		JWTManager:       jwtManager,
 		AccessTokenTTL:   TestAccessTokenExpiry,
 		RefreshTokenTTL:  TestRefreshTokenExpiry,
 		RotationEnabled:  false,
+		TokenRevocation:  tokenRevocation,
+		OIDCProviders:    []auth.OIDCProvider{oidcProvider},
+		OIDCStateTTL:     10 * time.Minute,
 	})
-	userService := service.NewUserService(userRepo, redisCache, 5*time.Minute)
-	voiceMemoService := service.NewVoiceMemoService(voiceMemoRepo, s3Client, transcriptionQueue, 15*time.Minute, 15*time.Minute)
-	teamService := service.NewTeamService(teamRepo, teamMemberRepo, teamInvitationRepo, voiceMemoRepo)
-	teamMemberService := service.NewTeamMemberService(teamMemberRepo, userRepo, teamRepo)
-	teamInvitationService := service.NewTeamInvitationService(teamInvitationRepo, teamMemberRepo, teamRepo, userRepo)
+	userService := service.NewUserService(userRepo, redisCache, 5*time.Minute, authService)
+	auditLogService := service.NewAuditLogService(repository.NewAuditLogRepository(mongoDB.Database))
+	voiceMemoService := service.NewVoiceMemoService(voiceMemoRepo, s3Client, transcriptionQueue, 15*time.Minute, 15*time.Minute, nil, service.VoiceMemoLimits{}, auditLogService)
+	teamService := service.NewTeamService(teamRepo, teamMemberRepo, teamInvitationRepo, teamRoleRepo, voiceMemoRepo, memberFinder, authorizer, relationshipAuthorizer, auditLogService)
+	teamMemberService := service.NewTeamMemberService(teamMemberRepo, userRepo, teamRepo, teamRoleRepo, memberFinder, relationshipAuthorizer, auditLogService)
+	teamInvitationService := service.NewTeamInvitationService(teamInvitationRepo, teamMemberRepo, teamRepo, userRepo, teamRoleRepo, relationshipAuthorizer, auditLogService)
+	teamRoleService := service.NewTeamRoleService(teamRoleRepo, teamMemberRepo, teamInvitationRepo, authorizer, auditLogService)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo, teamMemberRepo, auth.NewAPIKeyGenerator())
 
 	// Transcription processor
-	transcriptionProcessor := queue.NewProcessor(transcriptionQueue, transcriptionService, voiceMemoRepo, 2)
+	transcriptionProcessor := queue.NewProcessor(transcriptionQueue, transcriptionService, voiceMemoRepo, 2, nil)
+
+	// Health checks (workers are started per test, so only dependencies are checked)
+	healthChecker := health.NewChecker(5 * time.Second)
+	healthChecker.AddReadinessCheck("mongodb", func(ctx context.Context) error { return mongoDB.Client.Ping(ctx, nil) })
+	healthChecker.AddReadinessCheck("redis", redisCache.Ping)
+	healthChecker.AddReadinessCheck("s3", s3Client.Ping)
+	healthChecker.AddReadinessCheck("transcription_queue", health.QueueSaturation(transcriptionQueue, 0.9))
 
 	// Handler layer
 	authHandler := handler.NewAuthHandler(authService)
 	userHandler := handler.NewUserHandler(userService)
-	voiceMemoHandler := handler.NewVoiceMemoHandler(voiceMemoService)
+	voiceMemoHandler := handler.NewVoiceMemoHandler(voiceMemoService, authorizer)
 	teamHandler := handler.NewTeamHandler(teamService)
-	teamMemberHandler := handler.NewTeamMemberHandler(teamMemberService)
+	teamMemberHandler := handler.NewTeamMemberHandler(teamMemberService, authorizer)
+	teamRoleHandler := handler.NewTeamRoleHandler(teamRoleService)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogService)
 	invitationHandler := handler.NewTeamInvitationHandler(teamInvitationService, userService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
 
 	// Router
 	r := router.Setup(&router.Config{
@@ -155,9 +209,15 @@ func New(ctx context.Context) (*TestServer, error) {
 		VoiceMemoHandler:  voiceMemoHandler,
 		TeamHandler:       teamHandler,
 		TeamMemberHandler: teamMemberHandler,
+		TeamRoleHandler:   teamRoleHandler,
+		AuditLogHandler:   auditLogHandler,
 		InvitationHandler: invitationHandler,
+		APIKeyHandler:     apiKeyHandler,
+		HealthHandler:     handler.NewHealthHandler(healthChecker),
 		JWTManager:        jwtManager,
 		Authorizer:        authorizer,
+		TokenRevocation:   tokenRevocation,
+		APIKeys:           apiKeyService,
 	})
 
 	return &TestServer{
@@ -171,6 +231,7 @@ func New(ctx context.Context) (*TestServer, error) {
 		TeamRepo:               teamRepo,
 		TeamMemberRepo:         teamMemberRepo,
 		TeamInvitationRepo:     teamInvitationRepo,
+		TeamRoleRepo:           teamRoleRepo,
 		AuthService:            authService,
 		UserService:            userService,
 		VoiceMemoService:       voiceMemoService,
@@ -178,14 +239,18 @@ func New(ctx context.Context) (*TestServer, error) {
 		TeamMemberService:      teamMemberService,
 		TeamInvitationService:  teamInvitationService,
 		JWTManager:             jwtManager,
+		OIDCProvider:           mockIDP,
 		TranscriptionQueue:     transcriptionQueue,
 		TranscriptionProcessor: transcriptionProcessor,
 		transcriptionService:   transcriptionService,
 	}, nil
 }
 
-// Cleanup terminates all containers.
+// Cleanup terminates all containers and the mock OIDC provider.
 func (ts *TestServer) Cleanup(ctx context.Context) {
+	if ts.OIDCProvider != nil {
+		ts.OIDCProvider.Close()
+	}
 	if ts.MinIO != nil {
 		_ = ts.MinIO.Cleanup(ctx)
 	}
@@ -209,5 +274,5 @@ func (ts *TestServer) StopTranscriptionProcessor() {
 	// Reset the queue so it can be used again
 	ts.TranscriptionQueue.Reset()
 	// Create a new processor since the old one has shutdown state
-	ts.TranscriptionProcessor = queue.NewProcessor(ts.TranscriptionQueue, ts.transcriptionService, ts.VoiceMemoRepo, 2)
+	ts.TranscriptionProcessor = queue.NewProcessor(ts.TranscriptionQueue, ts.transcriptionService, ts.VoiceMemoRepo, 2, nil)
 }
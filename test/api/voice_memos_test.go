@@ -380,7 +380,7 @@ func TestConfirmUpload(t *testing.T) {
 
 		// Simulate uploading a file to MinIO
 		uploadURL := memoData["uploadUrl"].(string)
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, testserver.VoiceMemoFileSize)
 
 		// Confirm upload
 		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/voice-memos/"+memoID+"/confirm-upload", token, nil)
@@ -402,7 +402,7 @@ func TestConfirmUpload(t *testing.T) {
 		memoID := memo["id"].(string)
 
 		uploadURL := memoData["uploadUrl"].(string)
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, testserver.VoiceMemoFileSize)
 
 		// First confirm
 		w1 := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/voice-memos/"+memoID+"/confirm-upload", token, nil)
@@ -413,6 +413,43 @@ func TestConfirmUpload(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, w2.Code)
 	})
 
+	t.Run("error - audio not uploaded", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, token := authHelper.CreateAuthenticatedUser(t, "No Upload", "noupload@example.com", "password123")
+		memoData := voiceMemoHelper.CreateVoiceMemo(t, token, "No Upload Memo", 60)
+
+		memo, _ := memoData["memo"].(map[string]interface{})
+		memoID := memo["id"].(string)
+
+		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/voice-memos/"+memoID+"/confirm-upload", token, nil)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// The memo can be confirmed once the audio is uploaded
+		uploadTestAudio(t, memoData["uploadUrl"].(string), testserver.VoiceMemoFileSize)
+		w2 := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/voice-memos/"+memoID+"/confirm-upload", token, nil)
+		assert.Equal(t, http.StatusOK, w2.Code)
+	})
+
+	t.Run("error - upload of a different size is rejected", func(t *testing.T) {
+		testServer.CleanupBetweenTests(t)
+
+		_, token := authHelper.CreateAuthenticatedUser(t, "Wrong Size", "wrongsize@example.com", "password123")
+		memoData := voiceMemoHelper.CreateVoiceMemo(t, token, "Wrong Size Memo", 60)
+
+		req, err := http.NewRequest(http.MethodPut, memoData["uploadUrl"].(string), bytes.NewReader(make([]byte, 2*testserver.VoiceMemoFileSize)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "audio/mpeg")
+
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
 	t.Run("error - cannot confirm another user's memo", func(t *testing.T) {
 		testServer.CleanupBetweenTests(t)
 
@@ -519,7 +556,8 @@ func TestActualFileUpload(t *testing.T) {
 		require.NotEmpty(t, uploadURL)
 
 		// Upload actual test audio content
-		testContent := []byte("fake audio content for testing purposes")
+		testContent := make([]byte, testserver.VoiceMemoFileSize)
+		copy(testContent, "fake audio content for testing purposes")
 		uploadTestAudioWithContent(t, uploadURL, testContent)
 
 		// Verify file exists in MinIO
@@ -556,7 +594,7 @@ func TestTranscriptionWorkflow(t *testing.T) {
 
 		// 2. Upload audio
 		uploadURL := memoData["uploadUrl"].(string)
-		uploadTestAudio(t, uploadURL)
+		uploadTestAudio(t, uploadURL, testserver.VoiceMemoFileSize)
 
 		// 3. Confirm upload
 		w := testutil.MakeAuthRequest(t, testServer.Router, http.MethodPost, "/api/v1/voice-memos/"+memoID+"/confirm-upload", token, nil)
@@ -579,10 +617,11 @@ func TestTranscriptionWorkflow(t *testing.T) {
 	})
 }
 
-// uploadTestAudio uploads test audio content to the given pre-signed URL.
-func uploadTestAudio(t *testing.T, uploadURL string) {
+// uploadTestAudio uploads size bytes of test audio content to the given pre-signed URL.
+// The size must match the memo's declared file size, which the URL is signed for.
+func uploadTestAudio(t *testing.T, uploadURL string, size int) {
 	t.Helper()
-	uploadTestAudioWithContent(t, uploadURL, []byte("test audio content"))
+	uploadTestAudioWithContent(t, uploadURL, make([]byte, size))
 }
 
 // uploadTestAudioWithContent uploads specific content to the given pre-signed URL.
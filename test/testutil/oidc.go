@@ -0,0 +1,271 @@
+package testutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// OIDCUser is the identity a MockOIDCProvider logs in.
+type OIDCUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// MockOIDCProvider is a local OpenID Connect provider for tests.
+// It supports discovery, the authorization code flow with PKCE (S256 only), JWKS and userinfo.
+// Authorization requests are approved immediately for the current user (see SetUser).
+type MockOIDCProvider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	user  OIDCUser
+	codes map[string]mockOIDCCode
+}
+
+// mockOIDCCode is an issued authorization code and the request it belongs to.
+type mockOIDCCode struct {
+	user          OIDCUser
+	nonce         string
+	codeChallenge string
+	redirectURI   string
+}
+
+// NewMockOIDCProvider starts a mock OIDC provider that is shut down when the test ends.
+func NewMockOIDCProvider(t *testing.T) *MockOIDCProvider {
+	t.Helper()
+
+	p, err := StartMockOIDCProvider()
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+// StartMockOIDCProvider starts a mock OIDC provider. Call Close when done.
+func StartMockOIDCProvider() (*MockOIDCProvider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &MockOIDCProvider{
+		ClientID:     "test-client",
+		ClientSecret: "test-secret",
+		key:          key,
+		codes:        make(map[string]mockOIDCCode),
+		user: OIDCUser{
+			Subject:       "oidc-user-1",
+			Email:         "oidc@example.com",
+			EmailVerified: true,
+			Name:          "OIDC User",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/userinfo", p.userinfo)
+
+	p.Server = httptest.NewServer(mux)
+
+	return p, nil
+}
+
+// Close shuts down the provider.
+func (p *MockOIDCProvider) Close() {
+	p.Server.Close()
+}
+
+// Issuer returns the provider's issuer URL.
+func (p *MockOIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+// SetUser changes the identity returned for subsequent logins.
+func (p *MockOIDCProvider) SetUser(user OIDCUser) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+// Authorize follows an authorization URL as a logged-in user would and
+// returns the code and state the provider redirects back with.
+func (p *MockOIDCProvider) Authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode, "authorization request should redirect")
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (p *MockOIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/jwks",
+		"userinfo_endpoint":                     p.Issuer() + "/userinfo",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *MockOIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != p.ClientID || query.Get("response_type") != "code" {
+		http.Error(w, "invalid client or response type", http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE S256 code challenge required", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randomHex()
+	p.mu.Lock()
+	p.codes[code] = mockOIDCCode{
+		user:          p.user,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		redirectURI:   redirectURI.String(),
+	}
+	p.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (p *MockOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	issued, found := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code")) // codes are single-use
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !found || issued.redirectURI != r.PostForm.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != issued.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.Issuer(),
+		"sub":            issued.user.Subject,
+		"aud":            p.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          issued.nonce,
+		"email":          issued.user.Email,
+		"email_verified": issued.user.EmailVerified,
+		"name":           issued.user.Name,
+	})
+	idToken.Header["kid"] = "mock-key"
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "at_" + issued.user.Subject,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func (p *MockOIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": "mock-key",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *MockOIDCProvider) userinfo(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	user := p.user
+	p.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	})
+}
+
+// writeJSON writes a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// randomHex returns 16 random bytes as a hex string.
+func randomHex() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}